@@ -0,0 +1,131 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package v1
+
+import (
+	"context"
+	"errors"
+	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/core"
+	"net/http"
+)
+
+var _ StrictServerInterface = (*Wrapper)(nil)
+var _ core.ErrorStatusCodeResolver = (*Wrapper)(nil)
+
+// Wrapper implements the generated interface from oapi-codegen
+type Wrapper struct {
+	Trail audit.Trail
+}
+
+func (w *Wrapper) ResolveStatusCode(err error) int {
+	switch {
+	case errors.Is(err, audit.ErrAuditTrailDisabled):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (w *Wrapper) Routes(router core.EchoRouter) {
+	RegisterHandlers(router, NewStrictHandler(w, []StrictMiddlewareFunc{
+		func(f StrictHandlerFunc, operationID string) StrictHandlerFunc {
+			return func(ctx echo.Context, request interface{}) (response interface{}, err error) {
+				ctx.Set(core.OperationIDContextKey, operationID)
+				ctx.Set(core.ModuleNameContextKey, audit.ModuleName)
+				ctx.Set(core.StatusCodeResolverContextKey, w)
+				return f(ctx, request)
+			}
+		},
+		func(f StrictHandlerFunc, operationID string) StrictHandlerFunc {
+			return audit.StrictMiddleware(f, audit.ModuleName, operationID)
+		},
+	}))
+}
+
+func (w *Wrapper) SearchEvents(_ context.Context, request SearchEventsRequestObject) (SearchEventsResponseObject, error) {
+	query := audit.Query{
+		Since: request.Params.Since,
+		Until: request.Params.Until,
+	}
+	if request.Params.Actor != nil {
+		query.Actor = *request.Params.Actor
+	}
+	if request.Params.EventType != nil {
+		query.Type = *request.Params.EventType
+	}
+	if request.Params.After != nil {
+		if *request.Params.After < 0 {
+			return nil, core.InvalidInputError("after must not be negative")
+		}
+		query.After = *request.Params.After
+	}
+	if request.Params.Limit != nil {
+		if *request.Params.Limit < 1 || *request.Params.Limit > audit.MaxQueryLimit {
+			return nil, core.InvalidInputError("limit must be between 1 and %d", audit.MaxQueryLimit)
+		}
+		query.Limit = *request.Params.Limit
+	}
+	if query.Since != nil && query.Until != nil && !query.Since.Before(*query.Until) {
+		return nil, core.InvalidInputError("since must be before until")
+	}
+	records, err := w.Trail.Find(query)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]AuditEvent, len(records))
+	for i, record := range records {
+		results[i] = AuditEvent{
+			Sequence:     record.Sequence,
+			Timestamp:    record.Timestamp,
+			Actor:        record.Actor,
+			Operation:    record.Operation,
+			EventType:    record.Type,
+			Module:       record.Module,
+			KeyID:        optional(record.KeyID),
+			Subject:      optional(record.Subject),
+			Message:      record.Message,
+			PreviousHash: optional(record.PreviousHash),
+			Hash:         record.Hash,
+		}
+	}
+	return SearchEvents200JSONResponse(results), nil
+}
+
+func (w *Wrapper) VerifyTrail(_ context.Context, _ VerifyTrailRequestObject) (VerifyTrailResponseObject, error) {
+	verification, err := w.Trail.Verify()
+	if err != nil {
+		return nil, err
+	}
+	return VerifyTrail200JSONResponse{
+		Valid:    verification.Valid,
+		Events:   verification.Events,
+		LastHash: optional(verification.LastHash),
+		Reason:   optional(verification.Reason),
+	}, nil
+}
+
+// optional returns a pointer to the given string, or nil if it's empty.
+func optional(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
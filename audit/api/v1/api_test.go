@@ -0,0 +1,193 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+package v1
+
+import (
+	"context"
+	"errors"
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestWrapper_SearchEvents(t *testing.T) {
+	ctx := context.Background()
+	timestamp := time.Unix(1700000000, 0)
+	record := audit.Record{
+		Event: audit.Event{
+			Timestamp: timestamp,
+			Actor:     "alice",
+			Operation: "Crypto.SignJWT",
+			Type:      "CryptoSignJWTEvent",
+			Module:    "Crypto",
+			KeyID:     "kid",
+			Message:   "Signing a JWT",
+		},
+		Sequence: 1,
+		Hash:     "hash",
+	}
+	t.Run("ok", func(t *testing.T) {
+		test := newMockContext(t)
+		actor := "alice"
+		eventType := "CryptoSignJWTEvent"
+		after := 5
+		limit := 10
+		since := timestamp
+		until := timestamp.Add(time.Hour)
+		test.trail.EXPECT().Find(audit.Query{Actor: actor, Type: eventType, Since: &since, Until: &until, After: after, Limit: limit}).Return([]audit.Record{record}, nil)
+
+		response, err := test.wrapper.SearchEvents(ctx, SearchEventsRequestObject{Params: SearchEventsParams{
+			Actor:     &actor,
+			EventType: &eventType,
+			Since:     &since,
+			Until:     &until,
+			After:     &after,
+			Limit:     &limit,
+		}})
+
+		require.NoError(t, err)
+		require.IsType(t, SearchEvents200JSONResponse{}, response)
+		events := response.(SearchEvents200JSONResponse)
+		require.Len(t, events, 1)
+		assert.Equal(t, 1, events[0].Sequence)
+		assert.Equal(t, timestamp, events[0].Timestamp)
+		assert.Equal(t, "alice", events[0].Actor)
+		assert.Equal(t, "CryptoSignJWTEvent", events[0].EventType)
+		assert.Equal(t, "kid", *events[0].KeyID)
+		assert.Nil(t, events[0].Subject)
+		assert.Nil(t, events[0].PreviousHash)
+		assert.Equal(t, "hash", events[0].Hash)
+	})
+	t.Run("no parameters", func(t *testing.T) {
+		test := newMockContext(t)
+		test.trail.EXPECT().Find(audit.Query{}).Return(nil, nil)
+
+		response, err := test.wrapper.SearchEvents(ctx, SearchEventsRequestObject{})
+
+		require.NoError(t, err)
+		assert.Empty(t, response.(SearchEvents200JSONResponse))
+	})
+	t.Run("invalid limit", func(t *testing.T) {
+		test := newMockContext(t)
+		limit := 0
+
+		_, err := test.wrapper.SearchEvents(ctx, SearchEventsRequestObject{Params: SearchEventsParams{Limit: &limit}})
+
+		assert.EqualError(t, err, "limit must be between 1 and 1000")
+	})
+	t.Run("limit too high", func(t *testing.T) {
+		test := newMockContext(t)
+		limit := audit.MaxQueryLimit + 1
+
+		_, err := test.wrapper.SearchEvents(ctx, SearchEventsRequestObject{Params: SearchEventsParams{Limit: &limit}})
+
+		assert.EqualError(t, err, "limit must be between 1 and 1000")
+	})
+	t.Run("invalid after", func(t *testing.T) {
+		test := newMockContext(t)
+		after := -1
+
+		_, err := test.wrapper.SearchEvents(ctx, SearchEventsRequestObject{Params: SearchEventsParams{After: &after}})
+
+		assert.EqualError(t, err, "after must not be negative")
+	})
+	t.Run("since after until", func(t *testing.T) {
+		test := newMockContext(t)
+		since := timestamp
+		until := timestamp.Add(-time.Hour)
+
+		_, err := test.wrapper.SearchEvents(ctx, SearchEventsRequestObject{Params: SearchEventsParams{Since: &since, Until: &until}})
+
+		assert.EqualError(t, err, "since must be before until")
+	})
+	t.Run("error", func(t *testing.T) {
+		test := newMockContext(t)
+		test.trail.EXPECT().Find(gomock.Any()).Return(nil, audit.ErrAuditTrailDisabled)
+
+		_, err := test.wrapper.SearchEvents(ctx, SearchEventsRequestObject{})
+
+		assert.ErrorIs(t, err, audit.ErrAuditTrailDisabled)
+	})
+}
+
+func TestWrapper_VerifyTrail(t *testing.T) {
+	ctx := context.Background()
+	t.Run("valid", func(t *testing.T) {
+		test := newMockContext(t)
+		test.trail.EXPECT().Verify().Return(&audit.Verification{Valid: true, Events: 2, LastHash: "hash"}, nil)
+
+		response, err := test.wrapper.VerifyTrail(ctx, VerifyTrailRequestObject{})
+
+		require.NoError(t, err)
+		result := response.(VerifyTrail200JSONResponse)
+		assert.True(t, result.Valid)
+		assert.Equal(t, 2, result.Events)
+		assert.Equal(t, "hash", *result.LastHash)
+		assert.Nil(t, result.Reason)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		test := newMockContext(t)
+		test.trail.EXPECT().Verify().Return(&audit.Verification{Reason: "event 1 was altered (hash mismatch)"}, nil)
+
+		response, err := test.wrapper.VerifyTrail(ctx, VerifyTrailRequestObject{})
+
+		require.NoError(t, err)
+		result := response.(VerifyTrail200JSONResponse)
+		assert.False(t, result.Valid)
+		assert.Equal(t, "event 1 was altered (hash mismatch)", *result.Reason)
+	})
+	t.Run("error", func(t *testing.T) {
+		test := newMockContext(t)
+		test.trail.EXPECT().Verify().Return(nil, errors.New("failed"))
+
+		_, err := test.wrapper.VerifyTrail(ctx, VerifyTrailRequestObject{})
+
+		assert.EqualError(t, err, "failed")
+	})
+}
+
+func TestWrapper_ResolveStatusCode(t *testing.T) {
+	expected := map[error]int{
+		audit.ErrAuditTrailDisabled: http.StatusBadRequest,
+		errors.New("foo"):           http.StatusInternalServerError,
+	}
+	wrapper := Wrapper{}
+	for err, expectedCode := range expected {
+		t.Run(err.Error(), func(t *testing.T) {
+			assert.Equal(t, expectedCode, wrapper.ResolveStatusCode(err))
+		})
+	}
+}
+
+type mockContext struct {
+	trail   *audit.MockTrail
+	wrapper Wrapper
+}
+
+func newMockContext(t *testing.T) mockContext {
+	ctrl := gomock.NewController(t)
+	trail := audit.NewMockTrail(ctrl)
+	return mockContext{
+		trail:   trail,
+		wrapper: Wrapper{Trail: trail},
+	}
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package v1
+
+import (
+	"context"
+	"github.com/nuts-foundation/nuts-node/core"
+	"net/http"
+)
+
+// HTTPClient holds the server address and other basic settings for the http client
+type HTTPClient struct {
+	core.ClientConfig
+	TokenGenerator core.AuthorizationTokenGenerator
+}
+
+func (hb HTTPClient) client() ClientInterface {
+	response, err := NewClientWithResponses(hb.GetAddress(), WithHTTPClient(core.MustCreateInternalHTTPClient(hb.ClientConfig, hb.TokenGenerator)))
+	if err != nil {
+		panic(err)
+	}
+	return response
+}
+
+// SearchEvents queries the audit trail of the node.
+func (hb HTTPClient) SearchEvents(params SearchEventsParams) ([]AuditEvent, error) {
+	ctx := context.Background()
+
+	if response, err := hb.client().SearchEvents(ctx, &params); err != nil {
+		return nil, err
+	} else if err := core.TestResponseCode(http.StatusOK, response); err != nil {
+		return nil, err
+	} else {
+		parsedResponse, err := ParseSearchEventsResponse(response)
+		if err != nil {
+			return nil, err
+		}
+		return *parsedResponse.JSON200, nil
+	}
+}
+
+// VerifyTrail verifies the integrity of the audit trail of the node.
+func (hb HTTPClient) VerifyTrail() (*Verification, error) {
+	ctx := context.Background()
+
+	if response, err := hb.client().VerifyTrail(ctx); err != nil {
+		return nil, err
+	} else if err := core.TestResponseCode(http.StatusOK, response); err != nil {
+		return nil, err
+	} else {
+		parsedResponse, err := ParseVerifyTrailResponse(response)
+		if err != nil {
+			return nil, err
+		}
+		return parsedResponse.JSON200, nil
+	}
+}
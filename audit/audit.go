@@ -33,6 +33,10 @@ const (
 	CryptoNewKeyEvent = "CreateNewKey"
 	// CryptoDeleteKeyEvent occurs when deleting a key.
 	CryptoDeleteKeyEvent = "DeleteKey"
+	// CryptoExportKeyEvent occurs when exporting a private key.
+	CryptoExportKeyEvent = "ExportKey"
+	// CryptoImportKeyEvent occurs when importing a private key.
+	CryptoImportKeyEvent = "ImportKey"
 	// CryptoSignJWTEvent occurs when signing a JWT.
 	CryptoSignJWTEvent = "SignJWT"
 	// CryptoSignJWSEvent occurs when signing a JWS.
@@ -69,7 +73,7 @@ func auditLogger() *logrus.Logger {
 		// It contains somewhat hacky string replacement, since logrus doesn't support custom log levels
 		// and will probably never do so (since it's in maintenance mode).
 		// Should be solved by migrating to a different logging library, which does support custom log levels.
-		// Alternative solution would be to extend the audit feature to always write to another audit sink (e.g. different log file or database).
+		// Audit events can also be written to a sink (see addSinkHook), e.g. a SQL database.
 		// Then the audit logs in the application log don't matter that much anymore, and they can be logged on e.g., INFO.
 		auditFormatter, err := newAuditFormatter(logrus.StandardLogger().Formatter)
 		if err != nil {
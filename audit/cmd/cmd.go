@@ -0,0 +1,141 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/nuts-foundation/nuts-node/audit"
+	api "github.com/nuts-foundation/nuts-node/audit/api/v1"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"time"
+)
+
+// FlagSet contains flags relevant for the module
+func FlagSet() *pflag.FlagSet {
+	defs := audit.DefaultConfig()
+	flagSet := pflag.NewFlagSet("audit", pflag.ContinueOnError)
+	flagSet.Bool("audit.sql.enabled", defs.SQL.Enabled, "Write audit events to the SQL database (in addition to the application log), "+
+		"as tamper-evident audit trail that can be queried through the internal API.")
+	flagSet.String("audit.sql.keyfile", defs.SQL.KeyFile, "File containing the 32-byte key used to protect the audit trail. "+
+		"Required when multiple nodes write to the same database, they must all use the same key. "+
+		"If not set, a key is generated in the data directory.")
+	return flagSet
+}
+
+// Cmd contains sub-commands for the remote client
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Audit trail commands",
+	}
+	cmd.AddCommand(eventsCmd())
+	cmd.AddCommand(verifyCmd())
+	return cmd
+}
+
+func eventsCmd() *cobra.Command {
+	var actor, eventType, since, until string
+	var after, limit int
+	result := &cobra.Command{
+		Use:     "events",
+		Short:   "Lists the events in the audit trail, optionally filtered by actor, event type and time range.",
+		Example: `nuts audit events --type SignJWT --since 2025-01-01T00:00:00Z`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			params := api.SearchEventsParams{}
+			if actor != "" {
+				params.Actor = &actor
+			}
+			if eventType != "" {
+				params.EventType = &eventType
+			}
+			if after > 0 {
+				params.After = &after
+			}
+			if limit > 0 {
+				params.Limit = &limit
+			}
+			var err error
+			if params.Since, err = parseTime(since); err != nil {
+				return fmt.Errorf("invalid since: %w", err)
+			}
+			if params.Until, err = parseTime(until); err != nil {
+				return fmt.Errorf("invalid until: %w", err)
+			}
+			events, err := httpClient(core.NewClientConfigForCommand(cmd)).SearchEvents(params)
+			if err != nil {
+				return fmt.Errorf("unable to query audit trail: %w", err)
+			}
+			formatted, _ := json.MarshalIndent(events, "", "  ")
+			cmd.Println(string(formatted))
+			return nil
+		},
+	}
+	result.Flags().StringVar(&actor, "actor", "", "Only list events performed by this actor.")
+	result.Flags().StringVar(&eventType, "type", "", "Only list events of this type (e.g. SignJWT).")
+	result.Flags().StringVar(&since, "since", "", "Only list events that occurred at or after this time (RFC3339).")
+	result.Flags().StringVar(&until, "until", "", "Only list events that occurred before this time (RFC3339).")
+	result.Flags().IntVar(&after, "after", 0, "Only list events after this sequence number, to list the next page of events.")
+	result.Flags().IntVar(&limit, "limit", 0, "Maximum number of events to list (default 100, at most 1000).")
+	return result
+}
+
+func verifyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "verify",
+		Short: "Verifies the integrity (hash chain) of the audit trail.",
+		Long: "Verifies the integrity (hash chain) of the audit trail. It prints the number of verified events and the hash of the last event. " +
+			"Removal of the last events can be detected by comparing the hash with a previously printed one.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			verification, err := httpClient(core.NewClientConfigForCommand(cmd)).VerifyTrail()
+			if err != nil {
+				return fmt.Errorf("unable to verify audit trail: %w", err)
+			}
+			formatted, _ := json.MarshalIndent(verification, "", "  ")
+			cmd.Println(string(formatted))
+			if !verification.Valid {
+				return errors.New("audit trail is not valid")
+			}
+			return nil
+		},
+	}
+}
+
+func parseTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	result, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// httpClient creates a remote client
+func httpClient(config core.ClientConfig) api.HTTPClient {
+	return api.HTTPClient{
+		ClientConfig: config,
+	}
+}
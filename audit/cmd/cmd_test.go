@@ -0,0 +1,133 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+package cmd
+
+import (
+	"bytes"
+	api "github.com/nuts-foundation/nuts-node/audit/api/v1"
+	"github.com/nuts-foundation/nuts-node/core"
+	http2 "github.com/nuts-foundation/nuts-node/test/http"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestFlagSet(t *testing.T) {
+	flags := FlagSet()
+
+	enabled, err := flags.GetBool("audit.sql.enabled")
+
+	require.NoError(t, err)
+	assert.False(t, enabled)
+	keyFile, err := flags.GetString("audit.sql.keyfile")
+	require.NoError(t, err)
+	assert.Empty(t, keyFile)
+}
+
+func TestCmd_Events(t *testing.T) {
+	hash := "hash"
+	events := []api.AuditEvent{{Sequence: 1, Actor: "alice", EventType: "SignJWT", Hash: hash, Timestamp: time.Now()}}
+	t.Run("ok", func(t *testing.T) {
+		cmd, buf := newCmd(t)
+		handler := setupServer(t, http.StatusOK, events)
+		cmd.SetArgs([]string{"events", "--actor", "alice", "--type", "SignJWT", "--since", "2025-01-01T00:00:00Z", "--after", "5", "--limit", "10"})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), `"actor": "alice"`)
+		assert.Equal(t, "alice", handler.Request.URL.Query().Get("actor"))
+		assert.Equal(t, "SignJWT", handler.Request.URL.Query().Get("eventType"))
+		assert.Equal(t, "2025-01-01T00:00:00Z", handler.Request.URL.Query().Get("since"))
+		assert.Equal(t, "5", handler.Request.URL.Query().Get("after"))
+		assert.Equal(t, "10", handler.Request.URL.Query().Get("limit"))
+		assert.False(t, handler.Request.URL.Query().Has("until"))
+	})
+	t.Run("invalid time", func(t *testing.T) {
+		cmd, _ := newCmd(t)
+		cmd.SetArgs([]string{"events", "--until", "yesterday"})
+
+		err := cmd.Execute()
+
+		assert.ErrorContains(t, err, "invalid until")
+	})
+	t.Run("server error", func(t *testing.T) {
+		cmd, _ := newCmd(t)
+		_ = setupServer(t, http.StatusBadRequest, nil)
+		cmd.SetArgs([]string{"events"})
+
+		err := cmd.Execute()
+
+		assert.ErrorContains(t, err, "unable to query audit trail")
+	})
+}
+
+func TestCmd_Verify(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		cmd, buf := newCmd(t)
+		_ = setupServer(t, http.StatusOK, api.Verification{Valid: true, Events: 3})
+		cmd.SetArgs([]string{"verify"})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), `"events": 3`)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		cmd, buf := newCmd(t)
+		reason := "event 2 was altered (hash mismatch)"
+		_ = setupServer(t, http.StatusOK, api.Verification{Valid: false, Events: 1, Reason: &reason})
+		cmd.SetArgs([]string{"verify"})
+
+		err := cmd.Execute()
+
+		assert.EqualError(t, err, "audit trail is not valid")
+		assert.Contains(t, buf.String(), reason)
+	})
+	t.Run("server error", func(t *testing.T) {
+		cmd, _ := newCmd(t)
+		_ = setupServer(t, http.StatusInternalServerError, nil)
+		cmd.SetArgs([]string{"verify"})
+
+		err := cmd.Execute()
+
+		assert.ErrorContains(t, err, "unable to verify audit trail")
+	})
+}
+
+func newCmd(t *testing.T) (*cobra.Command, *bytes.Buffer) {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	command := Cmd()
+	command.SetOut(buf)
+	command.SetErr(buf)
+	command.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+	return command, buf
+}
+
+func setupServer(t *testing.T, statusCode int, responseData interface{}) *http2.Handler {
+	handler := &http2.Handler{StatusCode: statusCode, ResponseData: responseData}
+	s := httptest.NewServer(handler)
+	t.Setenv("NUTS_ADDRESS", s.URL)
+	t.Cleanup(s.Close)
+	return handler
+}
@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: audit/module.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=audit/mock.go -package=audit -source=audit/module.go
+//
+
+// Package audit is a generated GoMock package.
+package audit
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTrail is a mock of Trail interface.
+type MockTrail struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrailMockRecorder
+	isgomock struct{}
+}
+
+// MockTrailMockRecorder is the mock recorder for MockTrail.
+type MockTrailMockRecorder struct {
+	mock *MockTrail
+}
+
+// NewMockTrail creates a new mock instance.
+func NewMockTrail(ctrl *gomock.Controller) *MockTrail {
+	mock := &MockTrail{ctrl: ctrl}
+	mock.recorder = &MockTrailMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrail) EXPECT() *MockTrailMockRecorder {
+	return m.recorder
+}
+
+// Find mocks base method.
+func (m *MockTrail) Find(query Query) ([]Record, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", query)
+	ret0, _ := ret[0].([]Record)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockTrailMockRecorder) Find(query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockTrail)(nil).Find), query)
+}
+
+// Verify mocks base method.
+func (m *MockTrail) Verify() (*Verification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify")
+	ret0, _ := ret[0].(*Verification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockTrailMockRecorder) Verify() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockTrail)(nil).Verify))
+}
@@ -0,0 +1,183 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package audit
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/storage"
+	"io/fs"
+	"os"
+	"path"
+)
+
+// ModuleName contains the name of the audit module.
+const ModuleName = "Audit"
+
+// keySize is the size in bytes of the key used to calculate the hash chain of the audit trail.
+const keySize = 32
+
+// ErrAuditTrailDisabled is returned when the audit trail is queried, but the SQL audit sink is not enabled.
+var ErrAuditTrailDisabled = errors.New("audit trail is not enabled (audit.sql.enabled)")
+
+var _ core.Named = (*Module)(nil)
+var _ core.Configurable = (*Module)(nil)
+var _ core.Injectable = (*Module)(nil)
+var _ core.Runnable = (*Module)(nil)
+var _ Trail = (*Module)(nil)
+
+// Trail provides access to the persisted audit trail.
+type Trail interface {
+	// Find returns the audit events matching the query.
+	Find(query Query) ([]Record, error)
+	// Verify checks the integrity of the audit trail.
+	Verify() (*Verification, error)
+}
+
+// Config holds the configuration of the audit module.
+type Config struct {
+	// SQL configures writing audit events to the SQL database.
+	SQL SQLConfig `koanf:"sql"`
+}
+
+// SQLConfig holds the configuration of the SQL audit sink.
+type SQLConfig struct {
+	// Enabled specifies whether audit events are written to the SQL database.
+	Enabled bool `koanf:"enabled"`
+	// KeyFile specifies the file containing the key used to calculate the hash chain of the audit trail.
+	// All nodes writing to the same database must use the same key.
+	// If not set, a key is generated in the data directory, which only supports a single node writing to the database.
+	KeyFile string `koanf:"keyfile"`
+}
+
+// DefaultConfig returns the default configuration of the audit module.
+func DefaultConfig() Config {
+	return Config{}
+}
+
+// New creates a new audit module, which writes audit events to the SQL database of the given storage engine if enabled.
+func New(storageEngine storage.Engine) *Module {
+	return &Module{
+		config:        DefaultConfig(),
+		storageEngine: storageEngine,
+	}
+}
+
+// Module is the audit engine. It manages the sinks audit events are written to.
+type Module struct {
+	config        Config
+	storageEngine storage.Engine
+	sqlSink       *SQLSink
+	sinkHook      *sinkHook
+}
+
+func (m *Module) Name() string {
+	return ModuleName
+}
+
+func (m *Module) Config() interface{} {
+	return &m.config
+}
+
+func (m *Module) Configure(config core.ServerConfig) error {
+	if m.config.SQL.Enabled {
+		// the key is stored outside the database,
+		// so the hash chain can't be recalculated by someone who only has access to the database.
+		var key []byte
+		var err error
+		if m.config.SQL.KeyFile != "" {
+			// the key is shared by the nodes writing to the same database, so it must not be generated by one of them
+			key, err = loadKey(m.config.SQL.KeyFile)
+		} else {
+			key, err = loadOrCreateKey(path.Join(config.Datadir, "audit", "trail.key"))
+		}
+		if err != nil {
+			return fmt.Errorf("unable to load audit trail key: %w", err)
+		}
+		m.sqlSink = NewSQLSink(m.storageEngine.GetSQLDatabase(), key)
+		// add the sink right away, so events of engines being configured or started are written as well
+		m.sinkHook = addSinkHook(m.sqlSink)
+	}
+	return nil
+}
+
+func (m *Module) Start() error {
+	if m.sqlSink != nil {
+		if err := m.sqlSink.checkKey(); err != nil {
+			return err
+		}
+		m.sqlSink.Start()
+	}
+	return nil
+}
+
+func (m *Module) Shutdown() error {
+	if m.sqlSink != nil {
+		removeSinkHook(m.sinkHook)
+		return m.sqlSink.Close()
+	}
+	return nil
+}
+
+func (m *Module) Find(query Query) ([]Record, error) {
+	if m.sqlSink == nil {
+		return nil, ErrAuditTrailDisabled
+	}
+	return m.sqlSink.Find(query)
+}
+
+func (m *Module) Verify() (*Verification, error) {
+	if m.sqlSink == nil {
+		return nil, ErrAuditTrailDisabled
+	}
+	return m.sqlSink.Verify()
+}
+
+// loadKey reads the key from the given file.
+func loadKey(filename string) ([]byte, error) {
+	key, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	if len(key) != keySize {
+		return nil, fmt.Errorf("invalid key size (file=%s)", filename)
+	}
+	return key, nil
+}
+
+// loadOrCreateKey reads the key from the given file, or generates and stores a new key if the file doesn't exist.
+func loadOrCreateKey(filename string) ([]byte, error) {
+	key, err := loadKey(filename)
+	if !errors.Is(err, fs.ErrNotExist) {
+		return key, err
+	}
+	key = make([]byte, keySize)
+	if _, err = rand.Read(key); err != nil {
+		return nil, err
+	}
+	if err = os.MkdirAll(path.Dir(filename), 0700); err != nil {
+		return nil, err
+	}
+	if err = os.WriteFile(filename, key, 0600); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
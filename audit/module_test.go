@@ -0,0 +1,136 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+package audit
+
+import (
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/nuts-foundation/nuts-node/test/io"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path"
+	"testing"
+	"time"
+)
+
+func TestModule_Lifecycle(t *testing.T) {
+	t.Run("enabled", func(t *testing.T) {
+		module := New(storage.NewTestStorageEngine(t))
+		module.config.SQL.Enabled = true
+		require.NoError(t, module.Configure(testServerConfig(t)))
+		require.NoError(t, module.Start())
+
+		Log(TestContext(), logrus.NewEntry(logrus.StandardLogger()), "TestEvent").Info("test message")
+
+		require.Eventually(t, func() bool {
+			records, _ := module.Find(Query{Type: "TestEvent"})
+			return len(records) == 1
+		}, 5*time.Second, 10*time.Millisecond)
+		require.NoError(t, module.Shutdown())
+		result, err := module.Verify()
+		require.NoError(t, err)
+		assert.True(t, result.Valid)
+
+		t.Run("removed sink doesn't receive events", func(t *testing.T) {
+			Log(TestContext(), logrus.NewEntry(logrus.StandardLogger()), "TestEvent").Info("test message")
+
+			assert.Empty(t, module.sqlSink.queue)
+		})
+	})
+	t.Run("invalid key", func(t *testing.T) {
+		config := testServerConfig(t)
+		require.NoError(t, os.MkdirAll(path.Join(config.Datadir, "audit"), 0700))
+		require.NoError(t, os.WriteFile(path.Join(config.Datadir, "audit", "trail.key"), []byte("too short"), 0600))
+		module := New(storage.NewTestStorageEngine(t))
+		module.config.SQL.Enabled = true
+
+		err := module.Configure(config)
+
+		assert.ErrorContains(t, err, "unable to load audit trail key: invalid key size")
+	})
+	t.Run("configured key file", func(t *testing.T) {
+		keyFile := path.Join(io.TestDirectory(t), "trail.key")
+		require.NoError(t, os.WriteFile(keyFile, testKey, 0600))
+		module := New(storage.NewTestStorageEngine(t))
+		module.config.SQL.Enabled = true
+		module.config.SQL.KeyFile = keyFile
+
+		require.NoError(t, module.Configure(testServerConfig(t)))
+
+		assert.Equal(t, testKey, module.sqlSink.key)
+	})
+	t.Run("configured key file doesn't exist", func(t *testing.T) {
+		module := New(storage.NewTestStorageEngine(t))
+		module.config.SQL.Enabled = true
+		module.config.SQL.KeyFile = path.Join(io.TestDirectory(t), "trail.key")
+
+		err := module.Configure(testServerConfig(t))
+
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+	t.Run("audit trail written with another key", func(t *testing.T) {
+		storageEngine := storage.NewTestStorageEngine(t)
+		otherNode := NewSQLSink(storageEngine.GetSQLDatabase(), []byte("fedcba9876543210fedcba9876543210"))
+		_ = otherNode.Write(Event{Timestamp: time.Now(), Type: "A"})
+		require.NoError(t, otherNode.flush())
+		keyFile := path.Join(io.TestDirectory(t), "trail.key")
+		require.NoError(t, os.WriteFile(keyFile, testKey, 0600))
+		module := New(storageEngine)
+		module.config.SQL.Enabled = true
+		module.config.SQL.KeyFile = keyFile
+		require.NoError(t, module.Configure(testServerConfig(t)))
+
+		err := module.Start()
+
+		assert.ErrorIs(t, err, ErrKeyMismatch)
+	})
+	t.Run("disabled", func(t *testing.T) {
+		module := New(storage.NewTestStorageEngine(t))
+		require.NoError(t, module.Configure(testServerConfig(t)))
+		require.NoError(t, module.Start())
+		defer module.Shutdown()
+
+		_, err := module.Find(Query{})
+		assert.ErrorIs(t, err, ErrAuditTrailDisabled)
+		_, err = module.Verify()
+		assert.ErrorIs(t, err, ErrAuditTrailDisabled)
+	})
+}
+
+func TestLoadOrCreateKey(t *testing.T) {
+	filename := path.Join(io.TestDirectory(t), "audit", "trail.key")
+
+	key, err := loadOrCreateKey(filename)
+	require.NoError(t, err)
+	assert.Len(t, key, keySize)
+
+	t.Run("existing key is loaded", func(t *testing.T) {
+		loaded, err := loadOrCreateKey(filename)
+
+		require.NoError(t, err)
+		assert.Equal(t, key, loaded)
+	})
+}
+
+func testServerConfig(t *testing.T) core.ServerConfig {
+	return core.TestServerConfig(func(config *core.ServerConfig) {
+		config.Datadir = io.TestDirectory(t)
+	})
+}
@@ -0,0 +1,124 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package audit
+
+import (
+	"fmt"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/sirupsen/logrus"
+	"sync"
+	"time"
+)
+
+// Event is an audit event as written to a Sink.
+type Event struct {
+	// Timestamp is the time at which the event occurred.
+	Timestamp time.Time
+	// Actor is the user or service that performed the operation.
+	Actor string
+	// Operation is the name of the operation that was performed.
+	Operation string
+	// Type is the name of the event, e.g. CryptoSignJWTEvent.
+	Type string
+	// Module is the name of the module that logged the event.
+	Module string
+	// KeyID is the ID of the key involved in the event, if any.
+	KeyID string
+	// Subject is the subject (e.g. a DID) of the event, if any.
+	Subject string
+	// Message is the human-readable description of the event.
+	Message string
+}
+
+// Sink persists audit events, in addition to audit events being written to the application log.
+type Sink interface {
+	// Write persists the given event. It's called for every audit event, so it should not block for long.
+	Write(event Event) error
+}
+
+// hooksMux guards changes to the hooks of the audit logger.
+var hooksMux sync.Mutex
+
+// addSinkHook starts writing all subsequent audit events to the given Sink.
+// The sink is owned by the caller (e.g. the audit module), which must call removeSinkHook with the returned hook when it stops.
+func addSinkHook(sink Sink) *sinkHook {
+	hooksMux.Lock()
+	defer hooksMux.Unlock()
+	hook := &sinkHook{sink: sink}
+	auditLogger().AddHook(hook)
+	return hook
+}
+
+// removeSinkHook stops writing audit events to the Sink of the given hook.
+func removeSinkHook(hook *sinkHook) {
+	hooksMux.Lock()
+	defer hooksMux.Unlock()
+	logger := auditLogger()
+	hooks := make(logrus.LevelHooks)
+	for level, levelHooks := range logger.Hooks {
+		for _, curr := range levelHooks {
+			if curr != logrus.Hook(hook) {
+				hooks[level] = append(hooks[level], curr)
+			}
+		}
+	}
+	logger.ReplaceHooks(hooks)
+}
+
+// sinkHook is a logrus.Hook that writes audit log entries to a Sink.
+type sinkHook struct {
+	sink Sink
+}
+
+func (s *sinkHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (s *sinkHook) Fire(entry *logrus.Entry) error {
+	event := eventFromEntry(entry)
+	if err := s.sink.Write(event); err != nil {
+		// Don't return the error: logrus would print it to stderr, bypassing the application log.
+		sinkLogger().WithError(err).
+			Errorf("Failed to write audit event to sink (event=%s, actor=%s)", event.Type, event.Actor)
+	}
+	return nil
+}
+
+// eventFromEntry converts an audit log entry to an Event.
+// The subject is taken from the first field that is present of: subject, credentialSubject or walletDID.
+func eventFromEntry(entry *logrus.Entry) Event {
+	field := func(names ...string) string {
+		for _, name := range names {
+			if value, ok := entry.Data[name]; ok && value != nil {
+				return fmt.Sprintf("%v", value)
+			}
+		}
+		return ""
+	}
+	return Event{
+		Timestamp: entry.Time,
+		Actor:     field("actor"),
+		Operation: field("operation"),
+		Type:      field("event"),
+		Module:    field(core.LogFieldModule),
+		KeyID:     field(core.LogFieldKeyID),
+		Subject:   field(core.LogFieldAuditSubject, core.LogFieldCredentialSubject, core.LogFieldWalletDID),
+		Message:   entry.Message,
+	}
+}
@@ -0,0 +1,391 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package audit
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"sync"
+	"time"
+)
+
+// DefaultQueryLimit is the maximum number of records returned by SQLSink.Find if the query doesn't specify a limit.
+const DefaultQueryLimit = 100
+
+// MaxQueryLimit is the maximum number of records returned by SQLSink.Find, regardless of the query's limit.
+const MaxQueryLimit = 1000
+
+// maxQueuedEvents is the maximum number of events queued in memory, e.g. while the database is unavailable.
+// Events that don't fit in the queue are not written to the database, but are still in the application log.
+var maxQueuedEvents = 10000
+
+// ErrQueueFull is returned by SQLSink.Write when the event can't be queued, because too many events are waiting to be written.
+var ErrQueueFull = errors.New("audit event queue is full")
+
+// retryInterval is the interval at which writing queued events is retried after a failure.
+var retryInterval = 5 * time.Second
+
+var _ Sink = (*SQLSink)(nil)
+
+// eventRecord is an audit event as stored in the audit_event table.
+// Each record contains the hash of the previous record, making the audit trail tamper-evident:
+// altering or removing a record breaks the hash chain.
+// The hashes are HMACs with a key that's not stored in the database,
+// so someone with only access to the database can't recalculate the hash chain after altering it.
+type eventRecord struct {
+	// Sequence is the position of the record in the hash chain, starting at 1.
+	Sequence  int    `gorm:"primaryKey;column:sequence_number"`
+	Timestamp int64  `gorm:"column:created_at"`
+	Actor     string `gorm:"column:actor"`
+	Operation string `gorm:"column:operation"`
+	Event     string `gorm:"column:event_type"`
+	Module    string `gorm:"column:module"`
+	KeyID     string `gorm:"column:key_id"`
+	Subject   string `gorm:"column:subject"`
+	Message   string `gorm:"column:message"`
+	// PreviousHash is the hash of the previous record, or empty for the first record.
+	PreviousHash string `gorm:"column:previous_hash"`
+	Hash         string `gorm:"column:hash"`
+}
+
+func (eventRecord) TableName() string {
+	return "audit_event"
+}
+
+// calculateHash returns the HMAC-SHA256 (hex encoded) with the given key over the contents of the record, including the hash of the previous record.
+func (r eventRecord) calculateHash(key []byte) string {
+	data, _ := json.Marshal([]interface{}{r.Sequence, r.Timestamp, r.Actor, r.Operation, r.Event, r.Module, r.KeyID, r.Subject, r.Message, r.PreviousHash})
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (r eventRecord) toRecord() Record {
+	return Record{
+		Event: Event{
+			Timestamp: time.Unix(r.Timestamp, 0),
+			Actor:     r.Actor,
+			Operation: r.Operation,
+			Type:      r.Event,
+			Module:    r.Module,
+			KeyID:     r.KeyID,
+			Subject:   r.Subject,
+			Message:   r.Message,
+		},
+		Sequence:     r.Sequence,
+		PreviousHash: r.PreviousHash,
+		Hash:         r.Hash,
+	}
+}
+
+// Record is an audit event as stored by the SQLSink.
+type Record struct {
+	Event
+	// Sequence is the position of the event in the audit trail, starting at 1.
+	Sequence int
+	// PreviousHash is the hash of the previous event in the audit trail, or empty for the first event.
+	PreviousHash string
+	// Hash is the hash over the event and the hash of the previous event.
+	Hash string
+}
+
+// Query specifies which events to return from the audit trail. Empty fields are ignored.
+type Query struct {
+	// Actor only returns events performed by the given actor.
+	Actor string
+	// Type only returns events of the given type.
+	Type string
+	// Since only returns events that occurred at or after the given time.
+	Since *time.Time
+	// Until only returns events that occurred before the given time.
+	Until *time.Time
+	// After only returns events with a sequence number higher than the given one.
+	// It's used as cursor for paging: pass the sequence of the last event of the previous page.
+	After int
+	// Limit is the maximum number of events to return. Defaults to DefaultQueryLimit, and can't exceed MaxQueryLimit.
+	Limit int
+}
+
+// Verification is the result of verifying the hash chain of the audit trail.
+type Verification struct {
+	// Valid indicates whether the hash chain is intact.
+	Valid bool
+	// Events is the number of events that were verified.
+	Events int
+	// LastHash is the hash of the last valid event. It should be stored outside the node (anchored), to detect removal of events at the end of the audit trail later on.
+	LastHash string
+	// Reason describes why the hash chain is invalid.
+	Reason string
+}
+
+// NewSQLSink creates a new SQLSink that writes to the given database.
+// The key is used to calculate the hash chain, and must be kept outside the database.
+// Start must be called to start writing events to the database.
+func NewSQLSink(db *gorm.DB, key []byte) *SQLSink {
+	return &SQLSink{
+		db:     db,
+		key:    key,
+		notify: make(chan struct{}, 1),
+	}
+}
+
+// SQLSink is a Sink that writes audit events to the audit_event table of the SQL database.
+// Events are queued and written asynchronously, since audit events are often logged while a database transaction is in progress
+// (which, depending on the database, may hold the only connection).
+type SQLSink struct {
+	db    *gorm.DB
+	key   []byte
+	mux   sync.Mutex
+	queue []Event
+	// writing is the number of events taken from the queue that are being written to the database.
+	writing int
+	notify  chan struct{}
+	cancel  context.CancelFunc
+	stopped sync.WaitGroup
+}
+
+// Write queues the event to be written to the database.
+// It returns ErrQueueFull if too many events are waiting to be written, e.g. because the database is unavailable.
+func (s *SQLSink) Write(event Event) error {
+	s.mux.Lock()
+	if len(s.queue)+s.writing >= maxQueuedEvents {
+		s.mux.Unlock()
+		return ErrQueueFull
+	}
+	s.queue = append(s.queue, event)
+	s.mux.Unlock()
+	s.signal()
+	return nil
+}
+
+// signal notifies the writer that there are queued events.
+func (s *SQLSink) signal() {
+	select {
+	case s.notify <- struct{}{}:
+	default:
+		// writer already notified
+	}
+}
+
+// Start starts writing queued events to the database in the background.
+func (s *SQLSink) Start() {
+	var ctx context.Context
+	ctx, s.cancel = context.WithCancel(context.Background())
+	s.stopped.Add(1)
+	go func() {
+		defer s.stopped.Done()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-s.notify:
+			}
+			if err := s.flush(); err != nil {
+				sinkLogger().WithError(err).Errorf("Failed to write audit events to database, retrying in %s", retryInterval)
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(retryInterval):
+					s.signal()
+				}
+			}
+		}
+	}()
+}
+
+// Close stops the background writer and writes the remaining queued events to the database.
+func (s *SQLSink) Close() error {
+	if s.cancel != nil {
+		s.cancel()
+		s.stopped.Wait()
+	}
+	return s.flush()
+}
+
+// ErrKeyMismatch is returned when the audit trail in the database was written with another key than the one configured.
+// This happens when multiple nodes write to the same database, but don't share the key.
+var ErrKeyMismatch = errors.New("audit trail was written with another key, all nodes writing to the same database must use the same key (audit.sql.keyfile)")
+
+// checkKey checks that the last event in the audit trail was written with the sink's key,
+// so the sink doesn't extend a hash chain it can't verify (e.g. of another node with another key).
+func (s *SQLSink) checkKey() error {
+	var last eventRecord
+	if err := s.db.Order("sequence_number desc").Limit(1).Find(&last).Error; err != nil {
+		return err
+	}
+	if last.Sequence > 0 && last.Hash != last.calculateHash(s.key) {
+		return ErrKeyMismatch
+	}
+	return nil
+}
+
+// flush writes all queued events to the database, in a single transaction.
+// If writing fails, the events stay queued.
+// Multiple nodes may write to the same database: the last event is locked so the events are appended one transaction at a time,
+// and the transaction is retried if another node appended an event concurrently (which can't be locked if there are no events yet).
+func (s *SQLSink) flush() error {
+	s.mux.Lock()
+	events := s.queue
+	s.queue = nil
+	s.writing = len(events)
+	s.mux.Unlock()
+	if len(events) == 0 {
+		return nil
+	}
+	var err error
+	for {
+		err = s.append(events)
+		if !errors.Is(err, gorm.ErrDuplicatedKey) {
+			break
+		}
+	}
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.writing = 0
+	if err != nil {
+		// requeue events, preserving their order
+		s.queue = append(events, s.queue...)
+	}
+	return err
+}
+
+// append writes the events to the end of the audit trail, in a single transaction.
+func (s *SQLSink) append(events []Event) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var last eventRecord
+		var err error
+		// Microsoft SQL server does not support the locking clause, so we have to use a raw query instead.
+		if tx.Dialector.Name() == "sqlserver" {
+			err = tx.Raw("SELECT TOP 1 * FROM audit_event WITH (UPDLOCK, ROWLOCK) ORDER BY sequence_number DESC").Scan(&last).Error
+		} else {
+			err = tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).Order("sequence_number desc").Limit(1).Find(&last).Error
+		}
+		if err != nil {
+			return err
+		}
+		if last.Sequence > 0 && last.Hash != last.calculateHash(s.key) {
+			return ErrKeyMismatch
+		}
+		for _, event := range events {
+			record := eventRecord{
+				Sequence:     last.Sequence + 1,
+				Timestamp:    event.Timestamp.Unix(),
+				Actor:        event.Actor,
+				Operation:    event.Operation,
+				Event:        event.Type,
+				Module:       event.Module,
+				KeyID:        event.KeyID,
+				Subject:      event.Subject,
+				Message:      event.Message,
+				PreviousHash: last.Hash,
+			}
+			record.Hash = record.calculateHash(s.key)
+			if err := tx.Create(&record).Error; err != nil {
+				return err
+			}
+			last = record
+		}
+		return nil
+	})
+}
+
+// Find returns the events matching the query, ordered by their sequence in the audit trail.
+// At most MaxQueryLimit events are returned; use Query.After to get the next events.
+func (s *SQLSink) Find(query Query) ([]Record, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = DefaultQueryLimit
+	}
+	limit = min(limit, MaxQueryLimit)
+	stmt := s.db.Model(&eventRecord{}).Order("sequence_number asc").Limit(limit)
+	if query.After > 0 {
+		stmt = stmt.Where("sequence_number > ?", query.After)
+	}
+	if query.Actor != "" {
+		stmt = stmt.Where("actor = ?", query.Actor)
+	}
+	if query.Type != "" {
+		stmt = stmt.Where("event_type = ?", query.Type)
+	}
+	if query.Since != nil {
+		stmt = stmt.Where("created_at >= ?", query.Since.Unix())
+	}
+	if query.Until != nil {
+		stmt = stmt.Where("created_at < ?", query.Until.Unix())
+	}
+	var records []eventRecord
+	if err := stmt.Find(&records).Error; err != nil {
+		return nil, err
+	}
+	result := make([]Record, len(records))
+	for i, record := range records {
+		result[i] = record.toRecord()
+	}
+	return result, nil
+}
+
+// Verify checks the hash chain of the audit trail, to detect events that were altered, inserted or removed.
+// Events removed from the end of the audit trail can't be detected this way;
+// that requires comparing the returned LastHash with a previously stored value.
+// It only returns an error if the events can't be read from the database.
+func (s *SQLSink) Verify() (*Verification, error) {
+	result := Verification{Valid: true}
+	var records []eventRecord
+	err := s.db.Order("sequence_number asc").FindInBatches(&records, 1000, func(_ *gorm.DB, _ int) error {
+		for _, record := range records {
+			var reason string
+			switch {
+			case record.Sequence != result.Events+1:
+				reason = fmt.Sprintf("expected event %d, found event %d (events were removed)", result.Events+1, record.Sequence)
+			case record.PreviousHash != result.LastHash:
+				reason = fmt.Sprintf("event %d doesn't reference the hash of the previous event", record.Sequence)
+			case record.Hash != record.calculateHash(s.key):
+				reason = fmt.Sprintf("event %d was altered (hash mismatch)", record.Sequence)
+			}
+			if reason != "" {
+				result.Valid = false
+				result.Reason = reason
+				return errChainBroken
+			}
+			result.Events++
+			result.LastHash = record.Hash
+		}
+		return nil
+	}).Error
+	if err != nil && !errors.Is(err, errChainBroken) {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// errChainBroken is used to stop verifying the hash chain at the first broken link.
+var errChainBroken = errors.New("hash chain broken")
+
+func sinkLogger() *logrus.Entry {
+	return logrus.StandardLogger().WithField(core.LogFieldModule, ModuleName)
+}
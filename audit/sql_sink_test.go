@@ -0,0 +1,352 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+package audit
+
+import (
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+func TestSQLSink_Write(t *testing.T) {
+	t.Run("events are chained", func(t *testing.T) {
+		sink, _ := newTestSQLSink(t)
+		now := time.Now()
+		require.NoError(t, sink.Write(Event{Timestamp: now, Actor: "alice", Type: "A", KeyID: "kid", Subject: "did:web:example.com"}))
+		require.NoError(t, sink.Write(Event{Timestamp: now, Actor: "bob", Type: "B"}))
+
+		require.NoError(t, sink.flush())
+
+		records, err := sink.Find(Query{})
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, 1, records[0].Sequence)
+		assert.Equal(t, "alice", records[0].Actor)
+		assert.Equal(t, "kid", records[0].KeyID)
+		assert.Equal(t, "did:web:example.com", records[0].Subject)
+		assert.Equal(t, now.Unix(), records[0].Timestamp.Unix())
+		assert.Empty(t, records[0].PreviousHash)
+		assert.Equal(t, 2, records[1].Sequence)
+		assert.Equal(t, records[0].Hash, records[1].PreviousHash)
+	})
+	t.Run("chain continues after flush", func(t *testing.T) {
+		sink, _ := newTestSQLSink(t)
+		_ = sink.Write(Event{Timestamp: time.Now(), Type: "A"})
+		require.NoError(t, sink.flush())
+		_ = sink.Write(Event{Timestamp: time.Now(), Type: "B"})
+		require.NoError(t, sink.flush())
+
+		records, err := sink.Find(Query{})
+
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, records[0].Hash, records[1].PreviousHash)
+	})
+	t.Run("background writer", func(t *testing.T) {
+		sink, _ := newTestSQLSink(t)
+		sink.Start()
+		defer sink.Close()
+
+		_ = sink.Write(Event{Timestamp: time.Now(), Type: "A"})
+
+		require.Eventually(t, func() bool {
+			records, _ := sink.Find(Query{})
+			return len(records) == 1
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+	t.Run("close writes queued events", func(t *testing.T) {
+		sink, _ := newTestSQLSink(t)
+		_ = sink.Write(Event{Timestamp: time.Now(), Type: "A"})
+
+		require.NoError(t, sink.Close())
+
+		records, _ := sink.Find(Query{})
+		assert.Len(t, records, 1)
+	})
+	t.Run("events stay queued when writing fails", func(t *testing.T) {
+		sink, db := newTestSQLSink(t)
+		_ = sink.Write(Event{Timestamp: time.Now(), Type: "A"})
+		require.NoError(t, db.Exec("ALTER TABLE audit_event RENAME TO audit_event_tmp").Error)
+
+		err := sink.flush()
+
+		assert.Error(t, err)
+		assert.Len(t, sink.queue, 1)
+
+		// writing succeeds once the database is available again
+		require.NoError(t, db.Exec("ALTER TABLE audit_event_tmp RENAME TO audit_event").Error)
+		require.NoError(t, sink.flush())
+		assert.Empty(t, sink.queue)
+	})
+	t.Run("nodes sharing the database and key append to the same chain", func(t *testing.T) {
+		sink, db := newTestSQLSink(t)
+		otherNode := NewSQLSink(db, testKey)
+		_ = sink.Write(Event{Timestamp: time.Now(), Type: "A"})
+		_ = otherNode.Write(Event{Timestamp: time.Now(), Type: "B"})
+		require.NoError(t, otherNode.flush())
+		require.NoError(t, sink.flush())
+
+		result, err := sink.Verify()
+
+		require.NoError(t, err)
+		assert.True(t, result.Valid)
+		assert.Equal(t, 2, result.Events)
+	})
+	t.Run("node with another key doesn't extend the chain", func(t *testing.T) {
+		sink, db := newTestSQLSink(t)
+		_ = sink.Write(Event{Timestamp: time.Now(), Type: "A"})
+		require.NoError(t, sink.flush())
+		otherNode := NewSQLSink(db, []byte("fedcba9876543210fedcba9876543210"))
+		_ = otherNode.Write(Event{Timestamp: time.Now(), Type: "B"})
+
+		err := otherNode.flush()
+
+		assert.ErrorIs(t, err, ErrKeyMismatch)
+		assert.Len(t, otherNode.queue, 1)
+	})
+	t.Run("retried when another node appended concurrently", func(t *testing.T) {
+		sink, db := newTestSQLSink(t)
+		conflicts := 0
+		require.NoError(t, db.Callback().Create().Before("gorm:create").Register("test:conflict", func(tx *gorm.DB) {
+			if conflicts == 0 {
+				conflicts++
+				_ = tx.AddError(gorm.ErrDuplicatedKey)
+			}
+		}))
+		_ = sink.Write(Event{Timestamp: time.Now(), Type: "A"})
+
+		require.NoError(t, sink.flush())
+
+		assert.Equal(t, 1, conflicts)
+		records, _ := sink.Find(Query{})
+		assert.Len(t, records, 1)
+	})
+	t.Run("queue is bounded", func(t *testing.T) {
+		defer func(original int) {
+			maxQueuedEvents = original
+		}(maxQueuedEvents)
+		maxQueuedEvents = 2
+		sink, db := newTestSQLSink(t)
+		require.NoError(t, sink.Write(Event{Timestamp: time.Now(), Type: "A"}))
+		require.NoError(t, db.Exec("ALTER TABLE audit_event RENAME TO audit_event_tmp").Error)
+		require.Error(t, sink.flush())
+
+		require.NoError(t, sink.Write(Event{Timestamp: time.Now(), Type: "B"}))
+		err := sink.Write(Event{Timestamp: time.Now(), Type: "C"})
+
+		assert.ErrorIs(t, err, ErrQueueFull)
+		assert.Len(t, sink.queue, 2)
+	})
+}
+
+func TestSQLSink_Find(t *testing.T) {
+	sink, _ := newTestSQLSink(t)
+	start := time.Unix(1700000000, 0)
+	_ = sink.Write(Event{Timestamp: start, Actor: "alice", Type: "A"})
+	_ = sink.Write(Event{Timestamp: start.Add(time.Hour), Actor: "bob", Type: "A"})
+	_ = sink.Write(Event{Timestamp: start.Add(2 * time.Hour), Actor: "alice", Type: "B"})
+	require.NoError(t, sink.flush())
+
+	t.Run("no filter", func(t *testing.T) {
+		records, err := sink.Find(Query{})
+		require.NoError(t, err)
+		assert.Len(t, records, 3)
+	})
+	t.Run("actor", func(t *testing.T) {
+		records, err := sink.Find(Query{Actor: "alice"})
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, "A", records[0].Type)
+		assert.Equal(t, "B", records[1].Type)
+	})
+	t.Run("event type", func(t *testing.T) {
+		records, err := sink.Find(Query{Type: "A"})
+		require.NoError(t, err)
+		assert.Len(t, records, 2)
+	})
+	t.Run("time range", func(t *testing.T) {
+		since := start.Add(time.Hour)
+		until := start.Add(2 * time.Hour)
+
+		records, err := sink.Find(Query{Since: &since, Until: &until})
+
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, "bob", records[0].Actor)
+	})
+	t.Run("limit", func(t *testing.T) {
+		records, err := sink.Find(Query{Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, 1, records[0].Sequence)
+	})
+	t.Run("after", func(t *testing.T) {
+		records, err := sink.Find(Query{After: 1, Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, 2, records[0].Sequence)
+
+		records, err = sink.Find(Query{After: records[0].Sequence})
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, 3, records[0].Sequence)
+	})
+	t.Run("limit can't exceed maximum", func(t *testing.T) {
+		sink, _ := newTestSQLSink(t)
+		for i := 0; i < MaxQueryLimit+1; i++ {
+			_ = sink.Write(Event{Timestamp: start, Type: "A"})
+		}
+		require.NoError(t, sink.flush())
+
+		records, err := sink.Find(Query{Limit: MaxQueryLimit + 1})
+
+		require.NoError(t, err)
+		assert.Len(t, records, MaxQueryLimit)
+	})
+}
+
+func TestSQLSink_Verify(t *testing.T) {
+	setup := func(t *testing.T) (*SQLSink, *gorm.DB) {
+		sink, db := newTestSQLSink(t)
+		for _, eventType := range []string{"A", "B", "C"} {
+			_ = sink.Write(Event{Timestamp: time.Now(), Actor: "alice", Type: eventType})
+		}
+		require.NoError(t, sink.flush())
+		return sink, db
+	}
+	t.Run("empty", func(t *testing.T) {
+		sink, _ := newTestSQLSink(t)
+
+		result, err := sink.Verify()
+
+		require.NoError(t, err)
+		assert.True(t, result.Valid)
+		assert.Equal(t, 0, result.Events)
+	})
+	t.Run("valid", func(t *testing.T) {
+		sink, _ := setup(t)
+		records, _ := sink.Find(Query{})
+
+		result, err := sink.Verify()
+
+		require.NoError(t, err)
+		assert.True(t, result.Valid)
+		assert.Equal(t, 3, result.Events)
+		assert.Equal(t, records[2].Hash, result.LastHash)
+	})
+	t.Run("event altered", func(t *testing.T) {
+		sink, db := setup(t)
+		require.NoError(t, db.Exec("UPDATE audit_event SET actor = 'mallory' WHERE sequence_number = 2").Error)
+
+		result, err := sink.Verify()
+
+		require.NoError(t, err)
+		assert.False(t, result.Valid)
+		assert.Equal(t, 1, result.Events)
+		assert.Equal(t, "event 2 was altered (hash mismatch)", result.Reason)
+	})
+	t.Run("event removed", func(t *testing.T) {
+		sink, db := setup(t)
+		require.NoError(t, db.Exec("DELETE FROM audit_event WHERE sequence_number = 2").Error)
+
+		result, err := sink.Verify()
+
+		require.NoError(t, err)
+		assert.False(t, result.Valid)
+		assert.Equal(t, "expected event 2, found event 3 (events were removed)", result.Reason)
+	})
+	t.Run("event replaced", func(t *testing.T) {
+		sink, db := setup(t)
+		// replace event 2 with a record that has a valid hash, but doesn't reference event 1
+		record := eventRecord{Sequence: 2, Timestamp: time.Now().Unix(), Actor: "mallory", Event: "B"}
+		record.Hash = record.calculateHash(testKey)
+		require.NoError(t, db.Exec("DELETE FROM audit_event WHERE sequence_number = 2").Error)
+		require.NoError(t, db.Create(&record).Error)
+
+		result, err := sink.Verify()
+
+		require.NoError(t, err)
+		assert.False(t, result.Valid)
+		assert.Equal(t, "event 2 doesn't reference the hash of the previous event", result.Reason)
+	})
+	t.Run("event altered, hash recalculated without the key", func(t *testing.T) {
+		sink, db := setup(t)
+		records, _ := sink.Find(Query{})
+		record := eventRecord{Sequence: 2, Timestamp: records[1].Timestamp.Unix(), Actor: "mallory", Event: "B", PreviousHash: records[0].Hash}
+		record.Hash = record.calculateHash([]byte("guessed key"))
+		require.NoError(t, db.Exec("DELETE FROM audit_event WHERE sequence_number = 2").Error)
+		require.NoError(t, db.Create(&record).Error)
+
+		result, err := sink.Verify()
+
+		require.NoError(t, err)
+		assert.False(t, result.Valid)
+		assert.Equal(t, "event 2 was altered (hash mismatch)", result.Reason)
+	})
+}
+
+func TestSinkHook(t *testing.T) {
+	sink := &stubSink{}
+	hook := addSinkHook(sink)
+	defer removeSinkHook(hook)
+
+	Log(TestContext(), logrus.StandardLogger().WithField(core.LogFieldModule, "Crypto"), "TestEvent").
+		WithField(core.LogFieldKeyID, "kid").
+		WithField(core.LogFieldCredentialSubject, "did:web:example.com").
+		Info("test message")
+
+	require.Len(t, sink.events, 1)
+	event := sink.events[0]
+	assert.Equal(t, TestActor, event.Actor)
+	assert.Equal(t, "TestModule.TestOperation", event.Operation)
+	assert.Equal(t, "TestEvent", event.Type)
+	assert.Equal(t, "Crypto", event.Module)
+	assert.Equal(t, "kid", event.KeyID)
+	assert.Equal(t, "did:web:example.com", event.Subject)
+	assert.Equal(t, "test message", event.Message)
+	assert.False(t, event.Timestamp.IsZero())
+
+	t.Run("removed sinks don't receive events", func(t *testing.T) {
+		removeSinkHook(hook)
+
+		Log(TestContext(), logrus.NewEntry(logrus.StandardLogger()), "TestEvent").Info("test message")
+
+		assert.Len(t, sink.events, 1)
+	})
+}
+
+var testKey = []byte("0123456789abcdef0123456789abcdef")
+
+func newTestSQLSink(t *testing.T) (*SQLSink, *gorm.DB) {
+	db := storage.NewTestStorageEngine(t).GetSQLDatabase()
+	return NewSQLSink(db, testKey), db
+}
+
+type stubSink struct {
+	events []Event
+}
+
+func (s *stubSink) Write(event Event) error {
+	s.events = append(s.events, event)
+	return nil
+}
@@ -106,15 +106,15 @@ func (r Wrapper) handleAuthorizeRequestFromHolder(ctx context.Context, subject s
 
 	// Determine which PEX Presentation Definitions we want to see fulfilled during authorization through OpenID4VP.
 	// Each Presentation Definition triggers 1 OpenID4VP flow.
-	// TODO: Support multiple scopes?
-	presentationDefinitions, err := r.presentationDefinitionForScope(ctx, params.get(oauth.ScopeParam))
+	// Multiple scopes are composed into a single Presentation Definition by the policy backend.
+	presentationDefinitions, grantedScope, err := r.presentationDefinitionForScope(ctx, params.get(oauth.ScopeParam))
 	if err != nil {
 		return nil, withCallbackURI(err, redirectURL)
 	}
 
 	session := OAuthSession{
 		ClientID:          params.get(oauth.ClientIDParam),
-		Scope:             params.get(oauth.ScopeParam),
+		Scope:             grantedScope,
 		OwnSubject:        &subject,
 		ClientState:       params.get(oauth.StateParam),
 		RedirectURI:       redirectURL.String(),
@@ -532,7 +532,7 @@ func (r Wrapper) handleAuthorizeResponseSubmission(ctx context.Context, request
 
 	// Check signatures of VP and VCs. Trust should be established by the Presentation Definition.
 	for _, presentation := range pexEnvelope.Presentations {
-		_, err = r.vcr.Verifier().VerifyVP(presentation, true, true, nil)
+		_, err = r.vcr.Verifier().VerifyVP(ctx, presentation, true, true, nil)
 		if err != nil {
 			return nil, oauth.OAuth2Error{
 				Code:          oauth.InvalidRequest,
@@ -403,7 +403,7 @@ func TestWrapper_HandleAuthorizeResponse(t *testing.T) {
 			ctx := newTestClient(t)
 			putState(ctx, "state", session)
 			putNonce(ctx, challenge)
-			ctx.vcVerifier.EXPECT().VerifyVP(gomock.Any(), true, true, nil).Return(nil, nil)
+			ctx.vcVerifier.EXPECT().VerifyVP(gomock.Any(), gomock.Any(), true, true, nil).Return(nil, nil)
 
 			response, err := ctx.client.HandleAuthorizeResponse(context.Background(), baseRequest())
 
@@ -442,7 +442,7 @@ func TestWrapper_HandleAuthorizeResponse(t *testing.T) {
 			ctx := newTestClient(t)
 			putState(ctx, "state", session)
 			putNonce(ctx, challenge)
-			ctx.vcVerifier.EXPECT().VerifyVP(gomock.Any(), true, true, nil).Return(nil, nil)
+			ctx.vcVerifier.EXPECT().VerifyVP(gomock.Any(), gomock.Any(), true, true, nil).Return(nil, nil)
 			ctx.jar.EXPECT().Create(verifierDID, verifierURL.String(), "", gomock.Any())
 
 			response, err := ctx.client.HandleAuthorizeResponse(context.Background(), baseRequest())
@@ -457,7 +457,7 @@ func TestWrapper_HandleAuthorizeResponse(t *testing.T) {
 			ctx := newTestClient(t)
 			putState(ctx, "state", session)
 			putNonce(ctx, challenge)
-			ctx.vcVerifier.EXPECT().VerifyVP(gomock.Any(), true, true, nil).Return(nil, assert.AnError)
+			ctx.vcVerifier.EXPECT().VerifyVP(gomock.Any(), gomock.Any(), true, true, nil).Return(nil, assert.AnError)
 
 			_, err := ctx.client.HandleAuthorizeResponse(context.Background(), baseRequest())
 
@@ -568,7 +568,7 @@ func TestWrapper_HandleAuthorizeResponse(t *testing.T) {
 			// Presentation Definition does not contain Input Descriptor with ID 2
 			submission := `{"id":"1", "definition_id":"1", "descriptor_map":[{"id":"2","format":"ldp_vc","path":"$.verifiableCredential"}]}`
 			request.Body.PresentationSubmission = &submission
-			ctx.vcVerifier.EXPECT().VerifyVP(gomock.Any(), true, true, nil)
+			ctx.vcVerifier.EXPECT().VerifyVP(gomock.Any(), gomock.Any(), true, true, nil)
 
 			_, err := ctx.client.HandleAuthorizeResponse(context.Background(), request)
 
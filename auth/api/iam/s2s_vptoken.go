@@ -73,7 +73,7 @@ func (r Wrapper) handleS2SAccessTokenRequest(ctx context.Context, clientID strin
 			return nil, err
 		}
 	}
-	walletOwnerMapping, err := r.presentationDefinitionForScope(ctx, scope)
+	walletOwnerMapping, grantedScope, err := r.presentationDefinitionForScope(ctx, scope)
 	if err != nil {
 		return nil, err
 	}
@@ -97,7 +97,7 @@ func (r Wrapper) handleS2SAccessTokenRequest(ctx context.Context, clientID strin
 
 	// Check signatures of VP and VCs. Trust should be established by the Presentation Definition.
 	for _, presentation := range pexEnvelope.Presentations {
-		_, err = r.vcr.Verifier().VerifyVP(presentation, true, true, nil)
+		_, err = r.vcr.Verifier().VerifyVP(ctx, presentation, true, true, nil)
 		if err != nil {
 			return nil, oauth.OAuth2Error{
 				Code:          oauth.InvalidRequest,
@@ -109,7 +109,7 @@ func (r Wrapper) handleS2SAccessTokenRequest(ctx context.Context, clientID strin
 
 	// All OK, allow access
 	issuerURL := r.subjectToBaseURL(subject)
-	response, err := r.createAccessToken(issuerURL.String(), clientID, time.Now(), scope, *pexConsumer, dpopProof)
+	response, err := r.createAccessToken(issuerURL.String(), clientID, time.Now(), grantedScope, *pexConsumer, dpopProof)
 	if err != nil {
 		return nil, err
 	}
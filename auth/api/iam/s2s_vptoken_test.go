@@ -117,7 +117,7 @@ func TestWrapper_handleS2SAccessTokenRequest(t *testing.T) {
 	clientID := "https://example.com/oauth2/holder"
 	t.Run("JSON-LD VP", func(t *testing.T) {
 		ctx := newTestClient(t)
-		ctx.vcVerifier.EXPECT().VerifyVP(presentation, true, true, gomock.Any()).Return(presentation.VerifiableCredential, nil)
+		ctx.vcVerifier.EXPECT().VerifyVP(gomock.Any(), presentation, true, true, gomock.Any()).Return(presentation.VerifiableCredential, nil)
 		ctx.policy.EXPECT().PresentationDefinitions(gomock.Any(), requestedScope).Return(walletOwnerMapping, nil)
 
 		resp, err := ctx.client.handleS2SAccessTokenRequest(contextWithValue, clientID, issuerSubjectID, requestedScope, submissionJSON, presentation.Raw())
@@ -130,6 +130,32 @@ func TestWrapper_handleS2SAccessTokenRequest(t *testing.T) {
 		assert.Equal(t, int(accessTokenValidity.Seconds()), *tokenResponse.ExpiresIn)
 		assert.NotEmpty(t, tokenResponse.AccessToken)
 	})
+	t.Run("multiple scopes, unknown scope is not granted", func(t *testing.T) {
+		ctx := newTestClient(t)
+		ctx.vcVerifier.EXPECT().VerifyVP(gomock.Any(), presentation, true, true, gomock.Any()).Return(presentation.VerifiableCredential, nil)
+		scopeResolver := policy.NewMockScopeResolver(ctx.ctrl)
+		ctx.client.policyBackend = scopeResolver
+		scopeResolver.EXPECT().ResolveScopes(gomock.Any(), requestedScope+" unknown").Return(walletOwnerMapping, []string{requestedScope}, nil)
+
+		resp, err := ctx.client.handleS2SAccessTokenRequest(contextWithValue, clientID, issuerSubjectID, requestedScope+" unknown", submissionJSON, presentation.Raw())
+
+		require.NoError(t, err)
+		require.IsType(t, HandleTokenRequest200JSONResponse{}, resp)
+		tokenResponse := TokenResponse(resp.(HandleTokenRequest200JSONResponse))
+		assert.Equal(t, requestedScope, *tokenResponse.Scope)
+	})
+	t.Run("duplicate scope is granted once", func(t *testing.T) {
+		ctx := newTestClient(t)
+		ctx.vcVerifier.EXPECT().VerifyVP(gomock.Any(), presentation, true, true, gomock.Any()).Return(presentation.VerifiableCredential, nil)
+		ctx.policy.EXPECT().PresentationDefinitions(gomock.Any(), requestedScope+" "+requestedScope).Return(walletOwnerMapping, nil)
+
+		resp, err := ctx.client.handleS2SAccessTokenRequest(contextWithValue, clientID, issuerSubjectID, requestedScope+" "+requestedScope, submissionJSON, presentation.Raw())
+
+		require.NoError(t, err)
+		require.IsType(t, HandleTokenRequest200JSONResponse{}, resp)
+		tokenResponse := TokenResponse(resp.(HandleTokenRequest200JSONResponse))
+		assert.Equal(t, requestedScope, *tokenResponse.Scope)
+	})
 	t.Run("missing presentation expiry date", func(t *testing.T) {
 		ctx := newTestClient(t)
 		presentation, _ := test.CreateJWTPresentation(t, *subjectDID, func(token jwt.Token) {
@@ -166,7 +192,7 @@ func TestWrapper_handleS2SAccessTokenRequest(t *testing.T) {
 			require.NoError(t, token.Set(jwt.AudienceKey, issuerClientID))
 		}, verifiableCredential)
 		ctx.policy.EXPECT().PresentationDefinitions(gomock.Any(), requestedScope).Return(walletOwnerMapping, nil)
-		ctx.vcVerifier.EXPECT().VerifyVP(presentation, true, true, gomock.Any()).Return(presentation.VerifiableCredential, nil)
+		ctx.vcVerifier.EXPECT().VerifyVP(gomock.Any(), presentation, true, true, gomock.Any()).Return(presentation.VerifiableCredential, nil)
 
 		resp, err := ctx.client.handleS2SAccessTokenRequest(contextWithValue, clientID, issuerSubjectID, requestedScope, submissionJSON, presentation.Raw())
 
@@ -199,7 +225,7 @@ func TestWrapper_handleS2SAccessTokenRequest(t *testing.T) {
 	t.Run("nonce", func(t *testing.T) {
 		t.Run("replay attack (nonce is reused)", func(t *testing.T) {
 			ctx := newTestClient(t)
-			ctx.vcVerifier.EXPECT().VerifyVP(presentation, true, true, gomock.Any()).Return(presentation.VerifiableCredential, nil)
+			ctx.vcVerifier.EXPECT().VerifyVP(gomock.Any(), presentation, true, true, gomock.Any()).Return(presentation.VerifiableCredential, nil)
 			ctx.policy.EXPECT().PresentationDefinitions(gomock.Any(), requestedScope).Return(walletOwnerMapping, nil).Times(2)
 
 			_, err := ctx.client.handleS2SAccessTokenRequest(contextWithValue, clientID, issuerSubjectID, requestedScope, submissionJSON, presentation.Raw())
@@ -296,7 +322,7 @@ func TestWrapper_handleS2SAccessTokenRequest(t *testing.T) {
 	})
 	t.Run("VP verification fails", func(t *testing.T) {
 		ctx := newTestClient(t)
-		ctx.vcVerifier.EXPECT().VerifyVP(presentation, true, true, gomock.Any()).Return(nil, errors.New("invalid"))
+		ctx.vcVerifier.EXPECT().VerifyVP(gomock.Any(), presentation, true, true, gomock.Any()).Return(nil, errors.New("invalid"))
 		ctx.policy.EXPECT().PresentationDefinitions(gomock.Any(), requestedScope).Return(walletOwnerMapping, nil)
 
 		resp, err := ctx.client.handleS2SAccessTokenRequest(contextWithValue, clientID, issuerSubjectID, requestedScope, submissionJSON, presentation.Raw())
@@ -28,6 +28,8 @@ import (
 	"github.com/nuts-foundation/nuts-node/policy"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
 	"github.com/nuts-foundation/nuts-node/vcr/pe"
+	"slices"
+	"strings"
 )
 
 // validatePresentationSigner checks if the presenter of the VP is the same as the subject of the VCs being presented.
@@ -78,21 +80,44 @@ func (r Wrapper) validatePresentationAudience(presentation vc.VerifiablePresenta
 	}
 }
 
-func (r Wrapper) presentationDefinitionForScope(ctx context.Context, scope string) (pe.WalletOwnerMapping, error) {
-	mapping, err := r.policyBackend.PresentationDefinitions(ctx, scope)
+// presentationDefinitionForScope returns the PresentationDefinitions for the given (space-delimited) scopes,
+// and the scopes that can be granted when they're fulfilled.
+func (r Wrapper) presentationDefinitionForScope(ctx context.Context, scope string) (pe.WalletOwnerMapping, string, error) {
+	mapping, grantedScopes, err := r.resolveScopes(ctx, scope)
 	if err != nil {
 		if errors.Is(err, policy.ErrNotFound) {
-			return nil, oauth.OAuth2Error{
+			return nil, "", oauth.OAuth2Error{
 				Code:          oauth.InvalidScope,
 				InternalError: err,
 				Description:   fmt.Sprintf("unsupported scope (%s) for presentation exchange: %s", scope, err.Error()),
 			}
 		}
-		return nil, oauth.OAuth2Error{
+		return nil, "", oauth.OAuth2Error{
 			Code:          oauth.ServerError,
 			InternalError: err,
 			Description:   fmt.Sprintf("failed to retrieve presentation definition for scope (%s): %s", scope, err.Error()),
 		}
 	}
-	return mapping, err
+	return mapping, strings.Join(grantedScopes, " "), nil
+}
+
+// resolveScopes returns the PresentationDefinitions for the given (space-delimited) scopes, and the scopes they were resolved for.
+// When multiple scopes are requested, the policy backend might ignore unknown scopes (depending on its configuration).
+// Those scopes must not be granted, since no PresentationDefinition was fulfilled for them.
+// If the policy backend can't report the resolved scopes, all requested scopes are granted.
+func (r Wrapper) resolveScopes(ctx context.Context, scope string) (pe.WalletOwnerMapping, []string, error) {
+	if resolver, ok := r.policyBackend.(policy.ScopeResolver); ok {
+		return resolver.ResolveScopes(ctx, scope)
+	}
+	mapping, err := r.policyBackend.PresentationDefinitions(ctx, scope)
+	if err != nil {
+		return nil, nil, err
+	}
+	var scopes []string
+	for _, curr := range strings.Fields(scope) {
+		if !slices.Contains(scopes, curr) {
+			scopes = append(scopes, curr)
+		}
+	}
+	return mapping, scopes, nil
 }
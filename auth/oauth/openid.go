@@ -38,6 +38,10 @@ func DefaultOpenIDSupportedFormats() map[string]map[string][]string {
 		"jwt_vc_json": {"alg_values_supported": jwx.SupportedAlgorithmsAsStrings()},
 		"ldp_vc":      {"proof_type_values_supported": proofTypeValuesSupported},
 		"ldp_vp":      {"proof_type_values_supported": proofTypeValuesSupported},
+		"vc+sd-jwt": {
+			"sd-jwt_alg_values": jwx.SupportedAlgorithmsAsStrings(),
+			"kb-jwt_alg_values": jwx.SupportedAlgorithmsAsStrings(),
+		},
 	}
 }
 
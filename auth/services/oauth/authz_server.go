@@ -284,7 +284,7 @@ func (s *authzServer) validateAccessTokenRequest(ctx context.Context, bearerToke
 	}
 
 	// validate the legal base, according to RFC003 §5.2.1.7
-	if err = s.validateAuthorizationCredentials(validationCtx); err != nil {
+	if err = s.validateAuthorizationCredentials(ctx, validationCtx); err != nil {
 		return validationCtx, err
 	}
 
@@ -422,7 +422,7 @@ func (s *authzServer) validateSubject(ctx context.Context, validationCtx *valida
 }
 
 // validate the authorization credentials according to §5.2.1.7
-func (s *authzServer) validateAuthorizationCredentials(context *validationContext) error {
+func (s *authzServer) validateAuthorizationCredentials(ctx context.Context, context *validationContext) error {
 	// filter on authorization credentials
 	vcs, err := context.verifiableCredentials()
 	if err != nil {
@@ -452,7 +452,7 @@ func (s *authzServer) validateAuthorizationCredentials(context *validationContex
 
 	for _, authCred := range vcs {
 		// first check if the VC is valid and if the signature is correct
-		if err := s.vcVerifier.Verify(authCred, true, true, &iat); err != nil {
+		if err := s.vcVerifier.Verify(ctx, authCred, true, true, &iat); err != nil {
 			return fmt.Errorf(errInvalidVCClaim, err)
 		}
 
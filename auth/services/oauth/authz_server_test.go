@@ -181,7 +181,7 @@ func TestAuth_CreateAccessToken(t *testing.T) {
 			ctx.didResolver.EXPECT().Resolve(authorizerDID, gomock.Any()).Return(getAuthorizerDIDDocument(), nil, nil).AnyTimes()
 			ctx.serviceResolver.EXPECT().GetCompoundServiceEndpoint(authorizerDID, expectedService, services.OAuthEndpointType, true).Return(expectedAudience, nil).AnyTimes()
 			ctx.keyStore.EXPECT().Exists(ctx.audit, authorizerSigningKeyID).Return(true, nil).AnyTimes()
-			ctx.verifier.EXPECT().Verify(gomock.Any(), true, true, gomock.Any()).Return(nil).AnyTimes()
+			ctx.verifier.EXPECT().Verify(gomock.Any(), gomock.Any(), true, true, gomock.Any()).Return(nil).AnyTimes()
 			ctx.contractNotary.EXPECT().VerifyVP(gomock.Any(), nil).Return(services.TestVPVerificationResult{
 				Val:         contract.Valid,
 				DAttributes: map[string]string{"name": "Henk de Vries"},
@@ -228,7 +228,7 @@ func TestAuth_CreateAccessToken(t *testing.T) {
 		testCtx.serviceResolver.EXPECT().GetCompoundServiceEndpoint(authorizerDID, expectedService, services.OAuthEndpointType, true).Return(expectedAudience, nil)
 		testCtx.keyStore.EXPECT().Exists(testCtx.audit, authorizerSigningKeyID).Return(true, nil)
 		testCtx.keyStore.EXPECT().SignJWT(gomock.Any(), gomock.Any(), nil, authorizerSigningKeyID).Return("expectedAccessToken", nil)
-		testCtx.verifier.EXPECT().Verify(gomock.Any(), true, true, gomock.Any()).Return(nil)
+		testCtx.verifier.EXPECT().Verify(gomock.Any(), gomock.Any(), true, true, gomock.Any()).Return(nil)
 
 		ctx := validContext(t)
 		ctx.jwtBearerToken.Remove(userIdentityClaim)
@@ -255,7 +255,7 @@ func TestAuth_CreateAccessToken(t *testing.T) {
 			DAttributes: map[string]string{"name": "Henk de Vries"},
 			CAttributes: map[string]string{"legal_entity": "CareBears", "legal_entity_city": "Caretown"},
 		}, nil)
-		ctx.verifier.EXPECT().Verify(gomock.Any(), true, true, gomock.Any()).Return(nil)
+		ctx.verifier.EXPECT().Verify(gomock.Any(), gomock.Any(), true, true, gomock.Any()).Return(nil)
 
 		tokenCtx := validContext(t)
 		signToken(tokenCtx)
@@ -471,8 +471,8 @@ func TestService_validateAuthorizationCredentials(t *testing.T) {
 		tokenCtx := validContext(t)
 		signToken(tokenCtx)
 
-		ctx.verifier.EXPECT().Verify(gomock.Any(), true, true, gomock.Any()).Return(nil)
-		err := ctx.oauthService.validateAuthorizationCredentials(tokenCtx)
+		ctx.verifier.EXPECT().Verify(gomock.Any(), gomock.Any(), true, true, gomock.Any()).Return(nil)
+		err := ctx.oauthService.validateAuthorizationCredentials(context.Background(), tokenCtx)
 
 		require.NoError(t, err)
 
@@ -485,7 +485,7 @@ func TestService_validateAuthorizationCredentials(t *testing.T) {
 		tokenCtx.jwtBearerToken.Remove(vcClaim)
 		signToken(tokenCtx)
 
-		err := ctx.oauthService.validateAuthorizationCredentials(tokenCtx)
+		err := ctx.oauthService.validateAuthorizationCredentials(context.Background(), tokenCtx)
 
 		assert.NoError(t, err)
 	})
@@ -495,7 +495,7 @@ func TestService_validateAuthorizationCredentials(t *testing.T) {
 		tokenCtx.jwtBearerToken.Set(vcClaim, []interface{}{})
 		signToken(tokenCtx)
 
-		err := ctx.oauthService.validateAuthorizationCredentials(tokenCtx)
+		err := ctx.oauthService.validateAuthorizationCredentials(context.Background(), tokenCtx)
 
 		assert.NoError(t, err)
 	})
@@ -505,7 +505,7 @@ func TestService_validateAuthorizationCredentials(t *testing.T) {
 		tokenCtx.jwtBearerToken.Set(vcClaim, "not a vc")
 		signToken(tokenCtx)
 
-		err := ctx.oauthService.validateAuthorizationCredentials(tokenCtx)
+		err := ctx.oauthService.validateAuthorizationCredentials(context.Background(), tokenCtx)
 
 		assert.EqualError(t, err, "invalid jwt.vcs: field does not contain an array of credentials")
 	})
@@ -515,7 +515,7 @@ func TestService_validateAuthorizationCredentials(t *testing.T) {
 		tokenCtx.jwtBearerToken.Set(vcClaim, []interface{}{"}"})
 		signToken(tokenCtx)
 
-		err := ctx.oauthService.validateAuthorizationCredentials(tokenCtx)
+		err := ctx.oauthService.validateAuthorizationCredentials(context.Background(), tokenCtx)
 
 		assert.EqualError(t, err, "invalid jwt.vcs: cannot unmarshal authorization credential: invalid JWT")
 	})
@@ -524,9 +524,9 @@ func TestService_validateAuthorizationCredentials(t *testing.T) {
 		tokenCtx := validContext(t)
 		tokenCtx.jwtBearerToken.Set(jwt.IssuerKey, "unknown")
 		signToken(tokenCtx)
-		ctx.verifier.EXPECT().Verify(gomock.Any(), true, true, gomock.Any()).Return(nil)
+		ctx.verifier.EXPECT().Verify(gomock.Any(), gomock.Any(), true, true, gomock.Any()).Return(nil)
 
-		err := ctx.oauthService.validateAuthorizationCredentials(tokenCtx)
+		err := ctx.oauthService.validateAuthorizationCredentials(context.Background(), tokenCtx)
 
 		assert.EqualError(t, err, "credentialSubject.ID did:nuts:B8PUHs2AUHbFF1xLLK4eZjgErEcMXHxs68FteY7NDtCY of authorization credential with ID: did:nuts:GvkzxsezHvEc8nGhgz6Xo3jbqkHwswLmWw3CYtCm7hAW#38E90E8C-F7E5-4333-B63A-F9DD155A0272 does not match jwt.iss: unknown")
 	})
@@ -535,9 +535,9 @@ func TestService_validateAuthorizationCredentials(t *testing.T) {
 		tokenCtx := validContext(t)
 		tokenCtx.jwtBearerToken.Set(jwt.SubjectKey, "unknown")
 		signToken(tokenCtx)
-		ctx.verifier.EXPECT().Verify(gomock.Any(), true, true, gomock.Any()).Return(nil)
+		ctx.verifier.EXPECT().Verify(gomock.Any(), gomock.Any(), true, true, gomock.Any()).Return(nil)
 
-		err := ctx.oauthService.validateAuthorizationCredentials(tokenCtx)
+		err := ctx.oauthService.validateAuthorizationCredentials(context.Background(), tokenCtx)
 
 		assert.EqualError(t, err, "issuer did:nuts:GvkzxsezHvEc8nGhgz6Xo3jbqkHwswLmWw3CYtCm7hAW of authorization credential with ID: did:nuts:GvkzxsezHvEc8nGhgz6Xo3jbqkHwswLmWw3CYtCm7hAW#38E90E8C-F7E5-4333-B63A-F9DD155A0272 does not match jwt.sub: unknown")
 	})
@@ -546,9 +546,9 @@ func TestService_validateAuthorizationCredentials(t *testing.T) {
 		tokenCtx := validContext(t)
 		tokenCtx.jwtBearerToken.Set(jwt.SubjectKey, "unknown")
 		signToken(tokenCtx)
-		ctx.verifier.EXPECT().Verify(gomock.Any(), true, true, gomock.Any()).Return(vcrTypes.ErrRevoked)
+		ctx.verifier.EXPECT().Verify(gomock.Any(), gomock.Any(), true, true, gomock.Any()).Return(vcrTypes.ErrRevoked)
 
-		err := ctx.oauthService.validateAuthorizationCredentials(tokenCtx)
+		err := ctx.oauthService.validateAuthorizationCredentials(context.Background(), tokenCtx)
 
 		assert.EqualError(t, err, "invalid jwt.vcs: credential is revoked")
 	})
@@ -126,7 +126,7 @@ func TestValidator_VerifyVP(t *testing.T) {
 	t.Run("ok using mocks", func(t *testing.T) {
 		mockContext := newMockContext(t)
 		ss := NewValidator(mockContext.vcr, contract.StandardContractTemplates)
-		mockContext.verifier.EXPECT().VerifyVP(vp, true, true, &vpValidTime).Return([]vc.VerifiableCredential{testCredential}, nil)
+		mockContext.verifier.EXPECT().VerifyVP(gomock.Any(), vp, true, true, &vpValidTime).Return([]vc.VerifiableCredential{testCredential}, nil)
 		mockContext.vcr.EXPECT().Search(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]vc.VerifiableCredential{testCredential}, nil)
 
 		result, err := ss.VerifyVP(vp, &vpValidTime)
@@ -148,7 +148,7 @@ func TestValidator_VerifyVP(t *testing.T) {
 		credentialWithoutRole := vc.VerifiableCredential{}
 		data, _ := os.ReadFile("./test/vc-without-role.json")
 		_ = json.Unmarshal(data, &credentialWithoutRole)
-		mockContext.verifier.EXPECT().VerifyVP(vp, true, true, &vpValidTime).Return([]vc.VerifiableCredential{credentialWithoutRole}, nil)
+		mockContext.verifier.EXPECT().VerifyVP(gomock.Any(), vp, true, true, &vpValidTime).Return([]vc.VerifiableCredential{credentialWithoutRole}, nil)
 		mockContext.vcr.EXPECT().Search(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]vc.VerifiableCredential{testCredential}, nil)
 
 		result, err := ss.VerifyVP(vp, &vpValidTime)
@@ -160,7 +160,7 @@ func TestValidator_VerifyVP(t *testing.T) {
 	t.Run("technical error on verify", func(t *testing.T) {
 		mockContext := newMockContext(t)
 		ss := NewValidator(mockContext.vcr, contract.StandardContractTemplates)
-		mockContext.verifier.EXPECT().VerifyVP(vp, true, true, nil).Return(nil, errors.New("error"))
+		mockContext.verifier.EXPECT().VerifyVP(gomock.Any(), vp, true, true, nil).Return(nil, errors.New("error"))
 
 		_, err := ss.VerifyVP(vp, nil)
 
@@ -170,7 +170,7 @@ func TestValidator_VerifyVP(t *testing.T) {
 	t.Run("verification error on verify", func(t *testing.T) {
 		mockContext := newMockContext(t)
 		ss := NewValidator(mockContext.vcr, contract.StandardContractTemplates)
-		mockContext.verifier.EXPECT().VerifyVP(vp, true, true, nil).Return(nil, verifier.VerificationError{})
+		mockContext.verifier.EXPECT().VerifyVP(gomock.Any(), vp, true, true, nil).Return(nil, verifier.VerificationError{})
 
 		result, err := ss.VerifyVP(vp, nil)
 
@@ -232,7 +232,7 @@ func TestValidator_VerifyVP(t *testing.T) {
 		vpData, _ := os.ReadFile("./test/vp_invalid_contract.json")
 		_ = json.Unmarshal(vpData, &vp)
 		ss := NewValidator(mockContext.vcr, contract.StandardContractTemplates)
-		mockContext.verifier.EXPECT().VerifyVP(vp, true, true, nil).Return([]vc.VerifiableCredential{testCredential}, nil)
+		mockContext.verifier.EXPECT().VerifyVP(gomock.Any(), vp, true, true, nil).Return([]vc.VerifiableCredential{testCredential}, nil)
 		mockContext.vcr.EXPECT().Search(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]vc.VerifiableCredential{testCredential}, nil)
 
 		result, err := ss.VerifyVP(vp, nil)
@@ -246,7 +246,7 @@ func TestValidator_VerifyVP(t *testing.T) {
 		mockContext := newMockContext(t)
 		now := time.Now()
 		ss := NewValidator(mockContext.vcr, contract.StandardContractTemplates)
-		mockContext.verifier.EXPECT().VerifyVP(vp, true, true, &now).Return([]vc.VerifiableCredential{testCredential}, nil)
+		mockContext.verifier.EXPECT().VerifyVP(gomock.Any(), vp, true, true, &now).Return([]vc.VerifiableCredential{testCredential}, nil)
 		mockContext.vcr.EXPECT().Search(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]vc.VerifiableCredential{testCredential}, nil)
 
 		result, err := ss.VerifyVP(vp, &now)
@@ -259,7 +259,7 @@ func TestValidator_VerifyVP(t *testing.T) {
 	t.Run("error - missing credential", func(t *testing.T) {
 		mockContext := newMockContext(t)
 		ss := NewValidator(mockContext.vcr, contract.StandardContractTemplates)
-		mockContext.verifier.EXPECT().VerifyVP(vp, true, true, nil).Return([]vc.VerifiableCredential{}, nil)
+		mockContext.verifier.EXPECT().VerifyVP(gomock.Any(), vp, true, true, nil).Return([]vc.VerifiableCredential{}, nil)
 
 		result, err := ss.VerifyVP(vp, nil)
 
@@ -274,7 +274,7 @@ func TestValidator_VerifyVP(t *testing.T) {
 		vpData, _ := os.ReadFile("./test/vp_missing_proof.json")
 		_ = json.Unmarshal(vpData, &vp)
 		ss := NewValidator(mockContext.vcr, contract.StandardContractTemplates)
-		mockContext.verifier.EXPECT().VerifyVP(vp, true, true, nil).Return([]vc.VerifiableCredential{testCredential}, nil)
+		mockContext.verifier.EXPECT().VerifyVP(gomock.Any(), vp, true, true, nil).Return([]vc.VerifiableCredential{testCredential}, nil)
 
 		result, err := ss.VerifyVP(vp, nil)
 
@@ -289,7 +289,7 @@ func TestValidator_VerifyVP(t *testing.T) {
 		vpData, _ := os.ReadFile("./test/vp_incorrect_proof_type.json")
 		_ = json.Unmarshal(vpData, &vp)
 		ss := NewValidator(mockContext.vcr, contract.StandardContractTemplates)
-		mockContext.verifier.EXPECT().VerifyVP(vp, true, true, nil).Return([]vc.VerifiableCredential{testCredential}, nil)
+		mockContext.verifier.EXPECT().VerifyVP(gomock.Any(), vp, true, true, nil).Return([]vc.VerifiableCredential{testCredential}, nil)
 
 		result, err := ss.VerifyVP(vp, nil)
 
@@ -304,7 +304,7 @@ func TestValidator_VerifyVP(t *testing.T) {
 		vpData, _ := os.ReadFile("./test/vp_incorrect_signer.json")
 		_ = json.Unmarshal(vpData, &vp)
 		ss := NewValidator(mockContext.vcr, contract.StandardContractTemplates)
-		mockContext.verifier.EXPECT().VerifyVP(vp, true, true, nil).Return([]vc.VerifiableCredential{testCredential}, nil)
+		mockContext.verifier.EXPECT().VerifyVP(gomock.Any(), vp, true, true, nil).Return([]vc.VerifiableCredential{testCredential}, nil)
 
 		result, err := ss.VerifyVP(vp, nil)
 
@@ -321,7 +321,7 @@ func TestValidator_VerifyVP(t *testing.T) {
 		_ = json.Unmarshal(vpData, &vp)
 		credential.Issuer = did.MustParseDID("did:nuts:a").URI()
 		ss := NewValidator(mockContext.vcr, contract.StandardContractTemplates)
-		mockContext.verifier.EXPECT().VerifyVP(vp, true, true, nil).Return([]vc.VerifiableCredential{credential}, nil)
+		mockContext.verifier.EXPECT().VerifyVP(gomock.Any(), vp, true, true, nil).Return([]vc.VerifiableCredential{credential}, nil)
 
 		result, err := ss.VerifyVP(vp, nil)
 
@@ -336,7 +336,7 @@ func TestValidator_VerifyVP(t *testing.T) {
 		credentialWithoutRole := vc.VerifiableCredential{}
 		data, _ := os.ReadFile("./test/vc-without-role.json")
 		_ = json.Unmarshal(data, &credentialWithoutRole)
-		mockContext.verifier.EXPECT().VerifyVP(vp, true, true, &vpValidTime).Return([]vc.VerifiableCredential{credentialWithoutRole}, nil)
+		mockContext.verifier.EXPECT().VerifyVP(gomock.Any(), vp, true, true, &vpValidTime).Return([]vc.VerifiableCredential{credentialWithoutRole}, nil)
 		mockContext.vcr.EXPECT().Search(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]vc.VerifiableCredential{}, nil)
 
 		result, err := ss.VerifyVP(vp, &vpValidTime)
@@ -30,6 +30,10 @@ import (
 	"os"
 	"runtime/pprof"
 
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/audit"
+	auditAPI "github.com/nuts-foundation/nuts-node/audit/api/v1"
+	auditCmd "github.com/nuts-foundation/nuts-node/audit/cmd"
 	"github.com/nuts-foundation/nuts-node/auth"
 	authAPIv1 "github.com/nuts-foundation/nuts-node/auth/api/auth/v1"
 	authIAMAPI "github.com/nuts-foundation/nuts-node/auth/api/iam"
@@ -59,6 +63,7 @@ import (
 	networkCmd "github.com/nuts-foundation/nuts-node/network/cmd"
 	"github.com/nuts-foundation/nuts-node/pki"
 	"github.com/nuts-foundation/nuts-node/policy"
+	policyAPI "github.com/nuts-foundation/nuts-node/policy/api/v1"
 	"github.com/nuts-foundation/nuts-node/storage"
 	storageCmd "github.com/nuts-foundation/nuts-node/storage/cmd"
 	"github.com/nuts-foundation/nuts-node/vcr"
@@ -70,6 +75,7 @@ import (
 	vdrAPIv2 "github.com/nuts-foundation/nuts-node/vdr/api/v2"
 	vdrCmd "github.com/nuts-foundation/nuts-node/vdr/cmd"
 	"github.com/nuts-foundation/nuts-node/vdr/didnuts/didstore"
+	"github.com/nuts-foundation/nuts-node/vdr/didsubject"
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
 )
 
@@ -189,6 +195,7 @@ func CreateSystem(shutdownCallback context.CancelFunc) *core.System {
 	// Create instances
 	pkiInstance := pki.New()
 	storageInstance := storage.New()
+	auditInstance := audit.New(storageInstance)
 	cryptoInstance := crypto.NewCryptoInstance(storageInstance)
 	httpServerInstance := httpEngine.New(shutdownCallback, cryptoInstance)
 	jsonld := jsonld.NewJSONLDInstance()
@@ -198,12 +205,19 @@ func CreateSystem(shutdownCallback context.CancelFunc) *core.System {
 	vdrInstance := vdr.NewVDR(cryptoInstance, networkInstance, didStore, eventManager, storageInstance, pkiInstance)
 	credentialInstance := vcr.NewVCRInstance(cryptoInstance, vdrInstance, networkInstance, jsonld, eventManager, storageInstance, pkiInstance)
 	didmanInstance := didman.NewDidmanInstance(vdrInstance, credentialInstance, jsonld)
-	discoveryInstance := discovery.New(storageInstance, credentialInstance, vdrInstance, vdrInstance)
+	discoveryInstance := discovery.New(storageInstance, credentialInstance, vdrInstance, vdrInstance, eventManager)
 	authInstance := auth.NewAuthInstance(auth.DefaultConfig(), vdrInstance, vdrInstance, credentialInstance, cryptoInstance, didmanInstance, jsonld, pkiInstance)
 	statusEngine := status.NewStatusEngine(system)
 	metricsEngine := core.NewMetricsEngine()
 	goldenHammer := golden_hammer.New(vdrInstance, didmanInstance)
 	policyInstance := policy.New()
+	// status list credentials are re-signed with the new key when the keys of their issuer are rotated
+	vdrInstance.AddKeyRotationListener(func(ctx context.Context, id did.DID) error {
+		return credentialInstance.Issuer().ResignStatusLists(ctx, id)
+	})
+	// wallet credentials and Discovery Service activations are moved along with exported subjects
+	vdrInstance.AddSubjectDataExporter("wallet", credentialInstance.(didsubject.SubjectDataExporter))
+	vdrInstance.AddSubjectDataExporter("discovery", discoveryInstance)
 
 	// Register HTTP routes
 	didKeyResolver := resolver.DIDKeyResolver{Resolver: vdrInstance.Resolver()}
@@ -222,11 +236,15 @@ func CreateSystem(shutdownCallback context.CancelFunc) *core.System {
 	system.RegisterRoutes(&didmanAPI.Wrapper{Didman: didmanInstance})
 	system.RegisterRoutes(&discoveryAPI.Wrapper{Client: discoveryInstance})
 	system.RegisterRoutes(&discoveryServerAPI.Wrapper{Server: discoveryInstance})
+	system.RegisterRoutes(&policyAPI.Wrapper{Policy: policyInstance})
+	system.RegisterRoutes(&auditAPI.Wrapper{Trail: auditInstance})
 
 	// Register engines
 	// without dependencies
 	system.RegisterEngine(pkiInstance)
 	system.RegisterEngine(storageInstance)
+	// Register audit right after storage, so it writes audit events of engines started after it, and is shut down after them.
+	system.RegisterEngine(auditInstance)
 	system.RegisterEngine(cryptoInstance)
 	system.RegisterEngine(jsonld)
 	system.RegisterEngine(statusEngine)
@@ -266,6 +284,7 @@ func addSubCommands(system *core.System, root *cobra.Command) {
 		vcrCmd.Cmd(),
 		vdrCmd.Cmd(),
 		didmanCmd.Cmd(),
+		auditCmd.Cmd(),
 	}
 	for _, cmd := range clientCommands {
 		registerClientErrorHandler(cmd)
@@ -332,6 +351,7 @@ func serverConfigFlags() *pflag.FlagSet {
 	set.AddFlagSet(httpCmd.FlagSet())
 	set.AddFlagSet(storageCmd.FlagSet())
 	set.AddFlagSet(networkCmd.FlagSet())
+	set.AddFlagSet(vdrCmd.FlagSet())
 	set.AddFlagSet(vcrCmd.FlagSet())
 	set.AddFlagSet(jsonld.FlagSet())
 	set.AddFlagSet(authCmd.FlagSet())
@@ -340,6 +360,7 @@ func serverConfigFlags() *pflag.FlagSet {
 	set.AddFlagSet(goldenHammerCmd.FlagSet())
 	set.AddFlagSet(discoveryCmd.FlagSet())
 	set.AddFlagSet(policy.FlagSet())
+	set.AddFlagSet(auditCmd.FlagSet())
 
 	return set
 }
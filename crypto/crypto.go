@@ -21,6 +21,9 @@ package crypto
 import (
 	"context"
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
 	"errors"
 	"fmt"
 	"github.com/google/uuid"
@@ -245,7 +248,7 @@ func (client *Crypto) New(ctx context.Context, namingFunc KIDNamingFunc) (*orm.K
 			KeyName: keyName,
 			Version: version,
 		}
-		audit.Log(ctx, log.Logger(), audit.CryptoNewKeyEvent).Infof("Generated new key pair: %s", kid)
+		audit.Log(ctx, log.Logger(), audit.CryptoNewKeyEvent).WithField(core.LogFieldKeyID, kid).Infof("Generated new key pair: %s", kid)
 		return tx.Save(ref).Error
 	})
 	return ref, publicKey, err
@@ -259,7 +262,7 @@ func (client *Crypto) Delete(ctx context.Context, kid string) error {
 		if err != nil {
 			return err
 		}
-		audit.Log(ctx, log.Logger(), audit.CryptoDeleteKeyEvent).Infof("Deleting private key: %s", kid)
+		audit.Log(ctx, log.Logger(), audit.CryptoDeleteKeyEvent).WithField(core.LogFieldKeyID, kid).Infof("Deleting private key: %s", kid)
 		err = tx.WithContext(ctx).Where("kid = ?", kid).Delete(&orm.KeyReference{}).Error
 		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 			return fmt.Errorf("could not delete key reference from DB: %w", err)
@@ -279,6 +282,62 @@ func (client *Crypto) Link(ctx context.Context, kid string, keyName string, vers
 	})
 }
 
+// Export returns the private key with the given KID from the KeyStore.
+// Only keys that are held in memory by the backend (e.g. keys stored on disk or in Vault) can be exported.
+func (client *Crypto) Export(ctx context.Context, kid string) (crypto.PrivateKey, error) {
+	keyRef, err := client.findKeyReferenceByKid(ctx, kid)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := client.backend.GetPrivateKey(ctx, keyRef.KeyName, keyRef.Version)
+	if err != nil {
+		if errors.Is(err, spi.ErrNotFound) {
+			return nil, ErrPrivateKeyNotFound
+		}
+		return nil, err
+	}
+	switch signer.(type) {
+	case *ecdsa.PrivateKey, *rsa.PrivateKey, ed25519.PrivateKey:
+		audit.Log(ctx, log.Logger(), audit.CryptoExportKeyEvent).WithField(core.LogFieldKeyID, kid).Infof("Exporting private key: %s", kid)
+		return signer, nil
+	default:
+		return nil, ErrPrivateKeyNotExportable
+	}
+}
+
+// Import stores the given private key in the storage backend and links it to the given KID.
+func (client *Crypto) Import(ctx context.Context, kid string, key crypto.PrivateKey) error {
+	exists, err := client.Exists(ctx, kid)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrPrivateKeyAlreadyExists
+	}
+	keyName := uuid.New().String()
+	audit.Log(ctx, log.Logger(), audit.CryptoImportKeyEvent).WithField(core.LogFieldKeyID, kid).Infof("Importing private key: %s", kid)
+	// the private key is saved in the backend outside the transaction, since most backends can't take part in it.
+	if err = client.backend.SavePrivateKey(ctx, keyName, key); err != nil {
+		return fmt.Errorf("could not save private key: %w", err)
+	}
+	err = client.continueTransaction(ctx, func(tx *gorm.DB) error {
+		// backends that support importing keys don't version them
+		return tx.Save(&orm.KeyReference{
+			KID:     kid,
+			KeyName: keyName,
+			Version: "1",
+		}).Error
+	})
+	if err != nil {
+		// don't leave a private key in the backend that can't be found
+		if deleteErr := client.backend.DeletePrivateKey(ctx, keyName); deleteErr != nil {
+			log.Logger().WithError(deleteErr).WithField(core.LogFieldKeyID, kid).Error("Failed to delete private key after failed import")
+		}
+		return fmt.Errorf("could not save key reference: %w", err)
+	}
+	return nil
+}
+
 // Exists checks storage for an entry for the given legal entity and returns true if it exists
 func (client *Crypto) Exists(ctx context.Context, kid string) (bool, error) {
 	_, err := client.findKeyReferenceByKid(ctx, kid)
@@ -20,6 +20,10 @@ package crypto
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"github.com/nuts-foundation/nuts-node/audit"
 	"github.com/nuts-foundation/nuts-node/crypto/storage/fs"
 	"github.com/nuts-foundation/nuts-node/crypto/storage/spi"
@@ -33,6 +37,7 @@ import (
 	"testing"
 
 	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
 
 	"github.com/nuts-foundation/nuts-node/test/io"
 
@@ -155,6 +160,108 @@ func TestCrypto_Delete(t *testing.T) {
 	})
 }
 
+func TestCrypto_Export(t *testing.T) {
+	ctx := audit.TestContext()
+	auditLogs := audit.CaptureAuditLogs(t)
+
+	t.Run("ok", func(t *testing.T) {
+		client := createCrypto(t)
+		_, publicKey := newKeyReference(t, client, "kid")
+
+		privateKey, err := client.Export(ctx, "kid")
+
+		require.NoError(t, err)
+		assert.Equal(t, publicKey, privateKey.(crypto.Signer).Public())
+		auditLogs.AssertContains(t, ModuleName, "ExportKey", audit.TestActor, "Exporting private key: kid")
+	})
+	t.Run("error - not found", func(t *testing.T) {
+		client := createCrypto(t)
+
+		_, err := client.Export(ctx, "kid")
+
+		assert.ErrorIs(t, err, ErrPrivateKeyNotFound)
+	})
+	t.Run("error - not exportable", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		storageMock := spi.NewMockStorage(ctrl)
+		storageMock.EXPECT().GetPrivateKey(ctx, "test", "1").Return(unexportableSigner{}, nil)
+		client := &Crypto{backend: storageMock, db: orm.NewTestDatabase(t)}
+		require.NoError(t, client.db.Save(&orm.KeyReference{KID: "kid", KeyName: "test", Version: "1"}).Error)
+
+		_, err := client.Export(ctx, "kid")
+
+		assert.ErrorIs(t, err, ErrPrivateKeyNotExportable)
+	})
+}
+
+func TestCrypto_Import(t *testing.T) {
+	ctx := audit.TestContext()
+	auditLogs := audit.CaptureAuditLogs(t)
+	privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	t.Run("ok", func(t *testing.T) {
+		client := createCrypto(t)
+
+		err := client.Import(ctx, "kid", privateKey)
+
+		require.NoError(t, err)
+		publicKey, err := client.Resolve(ctx, "kid")
+		require.NoError(t, err)
+		assert.Equal(t, privateKey.Public(), publicKey)
+		auditLogs.AssertContains(t, ModuleName, "ImportKey", audit.TestActor, "Importing private key: kid")
+	})
+	t.Run("error - already exists", func(t *testing.T) {
+		client := createCrypto(t)
+		newKeyReference(t, client, "kid")
+
+		err := client.Import(ctx, "kid", privateKey)
+
+		assert.ErrorIs(t, err, ErrPrivateKeyAlreadyExists)
+	})
+	t.Run("error - backend fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		storageMock := spi.NewMockStorage(ctrl)
+		storageMock.EXPECT().SavePrivateKey(ctx, gomock.Any(), privateKey).Return(assert.AnError)
+		client := &Crypto{backend: storageMock, db: orm.NewTestDatabase(t)}
+
+		err := client.Import(ctx, "kid", privateKey)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		exists, _ := client.Exists(ctx, "kid")
+		assert.False(t, exists)
+	})
+	t.Run("error - saving key reference fails, private key is removed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		storageMock := spi.NewMockStorage(ctrl)
+		var keyName string
+		storageMock.EXPECT().SavePrivateKey(ctx, gomock.Any(), privateKey).DoAndReturn(func(_ context.Context, name string, _ crypto.PrivateKey) error {
+			keyName = name
+			return nil
+		})
+		storageMock.EXPECT().DeletePrivateKey(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, name string) error {
+			assert.Equal(t, keyName, name)
+			return nil
+		})
+		db := orm.NewTestDatabase(t)
+		failOnWrite := func(tx *gorm.DB) {
+			_ = tx.AddError(assert.AnError)
+		}
+		require.NoError(t, db.Callback().Create().Before("gorm:create").Register("fail", failOnWrite))
+		require.NoError(t, db.Callback().Update().Before("gorm:update").Register("fail", failOnWrite))
+		client := &Crypto{backend: storageMock, db: db}
+
+		err := client.Import(ctx, "kid", privateKey)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "could not save key reference")
+	})
+}
+
+// unexportableSigner mimics a key that's held by an HSM.
+type unexportableSigner struct {
+	crypto.Signer
+}
+
 func TestCrypto_Resolve(t *testing.T) {
 	ctx := context.Background()
 	client := createCrypto(t)
@@ -29,6 +29,12 @@ import (
 // ErrPrivateKeyNotFound is returned when the private key doesn't exist
 var ErrPrivateKeyNotFound = errors.New("private key not found")
 
+// ErrPrivateKeyNotExportable is returned when the private key can't be exported, e.g. because it's stored in an HSM.
+var ErrPrivateKeyNotExportable = errors.New("private key is not exportable")
+
+// ErrPrivateKeyAlreadyExists is returned when importing a private key with a KID that's already present in the KeyStore.
+var ErrPrivateKeyAlreadyExists = errors.New("private key already exists")
+
 // ErrorInvalidNumberOfSignatures indicates that the number of signatures present in the JWT is invalid.
 var ErrorInvalidNumberOfSignatures = errors.New("invalid number of signatures")
 
@@ -68,6 +74,15 @@ type KeyStore interface {
 	// Link links the key in the keystore to a kid
 	// see https://github.com/nuts-foundation/nuts-node/issues/3292
 	Link(ctx context.Context, kid string, keyName string, version string) error
+
+	// Export returns the private key with the given KID, so it can be moved to another node.
+	// It returns ErrPrivateKeyNotExportable if the storage backend doesn't allow the private key to leave it.
+	Export(ctx context.Context, kid string) (crypto.PrivateKey, error)
+
+	// Import stores the given private key in the storage backend and links it to the given KID.
+	// It returns ErrPrivateKeyAlreadyExists if a key with the given KID already exists.
+	// A DB transaction may be passed through the context using `orm.TransactionKey`.
+	Import(ctx context.Context, kid string, key crypto.PrivateKey) error
 }
 
 // Decrypter is the interface to support decryption
@@ -36,6 +36,7 @@ import (
 	"github.com/lestrrat-go/jwx/v2/jwt"
 	"github.com/mr-tron/base58"
 	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/crypto/jwx"
 	"github.com/nuts-foundation/nuts-node/crypto/log"
 	"github.com/nuts-foundation/nuts-node/crypto/storage/spi"
@@ -109,7 +110,7 @@ func (client *Crypto) DecryptJWE(ctx context.Context, message string) (body []by
 		return nil, nil, err
 	}
 
-	audit.Log(ctx, log.Logger(), audit.CryptoDecryptJWEEvent).Infof("Decrypting a JWE with kid: %s", kid)
+	audit.Log(ctx, log.Logger(), audit.CryptoDecryptJWEEvent).WithField(core.LogFieldKeyID, kid).Infof("Decrypting a JWE with kid: %s", kid)
 
 	keyJWK, err := jwk.FromRaw(privateKey)
 	if err != nil {
@@ -128,7 +129,11 @@ func (client *Crypto) DecryptJWE(ctx context.Context, message string) (body []by
 
 // SignJWT signs claims with the signer and returns the compacted token. The headers param can be used to add additional headers
 func SignJWT(ctx context.Context, key crypto.Signer, alg jwa.SignatureAlgorithm, claims map[string]interface{}, headers map[string]interface{}) (token string, err error) {
-	audit.Log(ctx, log.Logger(), audit.CryptoSignJWTEvent).Infof("Signing a JWT with key: %s (issuer: %s, subject: %s)", headers["kid"], claims["iss"], claims["sub"])
+	auditEntry := audit.Log(ctx, log.Logger(), audit.CryptoSignJWTEvent).WithField(core.LogFieldKeyID, headers["kid"])
+	if subject, ok := claims["sub"]; ok {
+		auditEntry = auditEntry.WithField(core.LogFieldAuditSubject, subject)
+	}
+	auditEntry.Infof("Signing a JWT with key: %s (issuer: %s, subject: %s)", headers["kid"], claims["iss"], claims["sub"])
 
 	var sig []byte
 	t := jwt.New()
@@ -238,7 +243,7 @@ func ParseJWS(token []byte, f PublicKeyFunc) (payload []byte, err error) {
 }
 
 func SignJWS(ctx context.Context, payload []byte, protectedHeaders map[string]interface{}, privateKey crypto.Signer, detachedPayload bool) (string, error) {
-	audit.Log(ctx, log.Logger(), audit.CryptoSignJWSEvent).Infof("Signing a JWS with key: %s", protectedHeaders["kid"])
+	audit.Log(ctx, log.Logger(), audit.CryptoSignJWSEvent).WithField(core.LogFieldKeyID, protectedHeaders["kid"]).Infof("Signing a JWS with key: %s", protectedHeaders["kid"])
 	headers := jws.NewHeaders()
 	for key, value := range protectedHeaders {
 		if err := headers.Set(key, value); err != nil {
@@ -226,6 +226,35 @@ func (mr *MockKeyStoreMockRecorder) Exists(ctx, kid any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockKeyStore)(nil).Exists), ctx, kid)
 }
 
+// Export mocks base method.
+func (m *MockKeyStore) Export(ctx context.Context, kid string) (crypto.PrivateKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, kid)
+	ret0, _ := ret[0].(crypto.PrivateKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockKeyStoreMockRecorder) Export(ctx, kid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockKeyStore)(nil).Export), ctx, kid)
+}
+
+// Import mocks base method.
+func (m *MockKeyStore) Import(ctx context.Context, kid string, key crypto.PrivateKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, kid, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockKeyStoreMockRecorder) Import(ctx, kid, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockKeyStore)(nil).Import), ctx, kid, key)
+}
+
 // Link mocks base method.
 func (m *MockKeyStore) Link(ctx context.Context, kid, keyName, version string) error {
 	m.ctrl.T.Helper()
@@ -20,17 +20,24 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/labstack/echo/v4"
 	"github.com/nuts-foundation/go-did/vc"
 	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/discovery"
 	"github.com/nuts-foundation/nuts-node/discovery/api/server/client"
 	"net/http"
+	"time"
 )
 
 type VerifiablePresentation = vc.VerifiablePresentation
 type PresentationsResponse = client.PresentationsResponse
+type TimestampEvent = client.TimestampEvent
+
+// keepAliveInterval specifies how often a comment is sent on event streams, to prevent (reverse) proxies from closing idle connections.
+var keepAliveInterval = 30 * time.Second
 
 var _ StrictServerInterface = (*Wrapper)(nil)
 var _ core.ErrorStatusCodeResolver = (*Wrapper)(nil)
@@ -47,6 +54,8 @@ func (w *Wrapper) ResolveStatusCode(err error) int {
 		return http.StatusBadRequest
 	case errors.Is(err, discovery.ErrServiceNotFound):
 		return http.StatusNotFound
+	case errors.Is(err, discovery.ErrTooManySubscribers):
+		return http.StatusTooManyRequests
 	default:
 		return http.StatusInternalServerError
 	}
@@ -84,12 +93,58 @@ func (w *Wrapper) GetPresentations(ctx context.Context, request GetPresentations
 
 func (w *Wrapper) RegisterPresentation(ctx context.Context, request RegisterPresentationRequestObject) (RegisterPresentationResponseObject, error) {
 	err := w.Server.Register(contextWithForwardedHost(ctx), request.ServiceID, *request.Body)
+	if errors.Is(err, discovery.ErrRegistrationQueued) {
+		return RegisterPresentation202Response{}, nil
+	}
 	if err != nil {
 		return nil, err
 	}
 	return RegisterPresentation201Response{}, nil
 }
 
+func (w *Wrapper) SubscribePresentations(ctx context.Context, request SubscribePresentationsRequestObject) (SubscribePresentationsResponseObject, error) {
+	timestamps, err := w.Server.Subscribe(contextWithRemoteIP(ctx), request.ServiceID)
+	if err != nil {
+		return nil, err
+	}
+	return eventStreamResponse{timestamps: timestamps}, nil
+}
+
+// eventStreamResponse writes the timestamps of a subscription as Server-Sent Events, until the subscription ends.
+type eventStreamResponse struct {
+	timestamps <-chan int
+}
+
+func (e eventStreamResponse) VisitSubscribePresentationsResponse(w http.ResponseWriter) error {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return errors.New("response writer does not support streaming")
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case timestamp, ok := <-e.timestamps:
+			if !ok {
+				return nil
+			}
+			data, _ := json.Marshal(TimestampEvent{Timestamp: timestamp})
+			if _, err := fmt.Fprintf(w, "event: timestamp\ndata: %s\n\n", data); err != nil {
+				return err
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return err
+			}
+		}
+		flusher.Flush()
+	}
+}
+
 func contextWithForwardedHost(ctx context.Context) context.Context {
 	// cast context to echo.Context
 	echoCtx := ctx.Value("echo.Context")
@@ -99,3 +154,12 @@ func contextWithForwardedHost(ctx context.Context) context.Context {
 	}
 	return ctx
 }
+
+func contextWithRemoteIP(ctx context.Context) context.Context {
+	echoCtx := ctx.Value("echo.Context")
+	if echoCtx != nil {
+		// subscriptions are limited per IP address, so pass it via context
+		ctx = context.WithValue(ctx, discovery.RemoteIPContextKey{}, echoCtx.(echo.Context).RealIP())
+	}
+	return ctx
+}
@@ -21,13 +21,16 @@ package server
 import (
 	"context"
 	"errors"
+	"github.com/labstack/echo/v4"
 	"github.com/nuts-foundation/go-did/vc"
 	"github.com/nuts-foundation/nuts-node/discovery"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 const serviceID = "wonderland"
@@ -91,6 +94,19 @@ func TestWrapper_RegisterPresentation(t *testing.T) {
 		assert.NoError(t, err)
 		assert.IsType(t, RegisterPresentation201Response{}, response)
 	})
+	t.Run("queued by replica", func(t *testing.T) {
+		test := newMockContext(t)
+		presentation := vc.VerifiablePresentation{}
+		test.server.EXPECT().Register(gomock.Any(), serviceID, presentation).Return(discovery.ErrRegistrationQueued)
+
+		response, err := test.wrapper.RegisterPresentation(ctx, RegisterPresentationRequestObject{
+			ServiceID: serviceID,
+			Body:      &presentation,
+		})
+
+		assert.NoError(t, err)
+		assert.IsType(t, RegisterPresentation202Response{}, response)
+	})
 	t.Run("error", func(t *testing.T) {
 		test := newMockContext(t)
 		presentation := vc.VerifiablePresentation{}
@@ -105,11 +121,71 @@ func TestWrapper_RegisterPresentation(t *testing.T) {
 	})
 }
 
+func TestWrapper_SubscribePresentations(t *testing.T) {
+	ctx := context.Background()
+	t.Run("ok", func(t *testing.T) {
+		test := newMockContext(t)
+		timestamps := make(chan int, 1)
+		timestamps <- 5
+		close(timestamps)
+		test.server.EXPECT().Subscribe(gomock.Any(), serviceID).Return(timestamps, nil)
+
+		response, err := test.wrapper.SubscribePresentations(ctx, SubscribePresentationsRequestObject{ServiceID: serviceID})
+
+		require.NoError(t, err)
+		recorder := httptest.NewRecorder()
+		require.NoError(t, response.VisitSubscribePresentationsResponse(recorder))
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "text/event-stream", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, "event: timestamp\ndata: {\"timestamp\":5}\n\n", recorder.Body.String())
+	})
+	t.Run("keep-alive", func(t *testing.T) {
+		keepAliveInterval = time.Millisecond
+		t.Cleanup(func() {
+			keepAliveInterval = 30 * time.Second
+		})
+		timestamps := make(chan int)
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			close(timestamps)
+		}()
+
+		recorder := httptest.NewRecorder()
+		err := eventStreamResponse{timestamps: timestamps}.VisitSubscribePresentationsResponse(recorder)
+
+		require.NoError(t, err)
+		assert.Contains(t, recorder.Body.String(), ": keep-alive\n\n")
+	})
+	t.Run("remote IP is passed", func(t *testing.T) {
+		test := newMockContext(t)
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.RemoteAddr = "1.2.3.4:5678"
+		echoCtx := echo.New().NewContext(request, httptest.NewRecorder())
+		test.server.EXPECT().Subscribe(gomock.Any(), serviceID).DoAndReturn(func(ctx context.Context, _ string) (<-chan int, error) {
+			assert.Equal(t, "1.2.3.4", ctx.Value(discovery.RemoteIPContextKey{}))
+			return nil, nil
+		})
+
+		_, err := test.wrapper.SubscribePresentations(context.WithValue(ctx, "echo.Context", echoCtx), SubscribePresentationsRequestObject{ServiceID: serviceID})
+
+		require.NoError(t, err)
+	})
+	t.Run("error", func(t *testing.T) {
+		test := newMockContext(t)
+		test.server.EXPECT().Subscribe(gomock.Any(), serviceID).Return(nil, discovery.ErrServiceNotFound)
+
+		_, err := test.wrapper.SubscribePresentations(ctx, SubscribePresentationsRequestObject{ServiceID: serviceID})
+
+		assert.ErrorIs(t, err, discovery.ErrServiceNotFound)
+	})
+}
+
 func TestWrapper_ResolveStatusCode(t *testing.T) {
 	expected := map[error]int{
 		discovery.ErrInvalidPresentation: http.StatusBadRequest,
 		errors.New("foo"):                http.StatusInternalServerError,
 		discovery.ErrServiceNotFound:     http.StatusNotFound,
+		discovery.ErrTooManySubscribers:  http.StatusTooManyRequests,
 	}
 	wrapper := Wrapper{}
 	for err, expectedCode := range expected {
@@ -19,9 +19,11 @@
 package client
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/nuts-foundation/go-did/vc"
 	"github.com/nuts-foundation/nuts-node/core"
@@ -30,13 +32,20 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 )
 
 // New creates a new DefaultHTTPClient.
 func New(timeout time.Duration) *DefaultHTTPClient {
+	// Event streams are long-lived, so they can't use a client that reads the full response or has an overall timeout.
+	// They also get their own transport, to prevent them from occupying the limited connections of the regular client.
+	streamTransport := client.SafeHttpTransport.Clone()
+	streamTransport.ResponseHeaderTimeout = timeout
+	streamTransport.MaxConnsPerHost = 0
 	return &DefaultHTTPClient{
-		client: client.New(timeout),
+		client:       client.New(timeout),
+		streamClient: &http.Client{Transport: streamTransport},
 	}
 }
 
@@ -44,7 +53,8 @@ var _ HTTPClient = &DefaultHTTPClient{}
 
 // DefaultHTTPClient implements HTTPClient using HTTP.
 type DefaultHTTPClient struct {
-	client core.HTTPRequestDoer
+	client       core.HTTPRequestDoer
+	streamClient core.HTTPRequestDoer
 }
 
 func (h DefaultHTTPClient) Register(ctx context.Context, serviceEndpointURL string, presentation vc.VerifiablePresentation) error {
@@ -60,11 +70,19 @@ func (h DefaultHTTPClient) Register(ctx context.Context, serviceEndpointURL stri
 	httpRequest.Header.Set("X-Forwarded-Host", httpRequest.Host) // prevent cycles
 	httpResponse, err := h.client.Do(httpRequest)
 	if err != nil {
-		return fmt.Errorf("failed to invoke remote Discovery Service (url=%s): %w", serviceEndpointURL, err)
+		return fmt.Errorf("%w (url=%s): %w", ErrServiceUnavailable, serviceEndpointURL, err)
 	}
 	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode == http.StatusAccepted {
+		// a replica accepted the presentation, it's registered on the leader when it becomes available again
+		log.Logger().Infof("Presentation is queued for registration by replica of Discovery Service (url=%s)", serviceEndpointURL)
+		return nil
+	}
 	if err := core.TestResponseCodeWithLog(201, httpResponse, log.Logger()); err != nil {
 		httpErr := err.(core.HttpError) // TestResponseCodeWithLog always returns an HttpError
+		if httpErr.StatusCode >= http.StatusInternalServerError {
+			return fmt.Errorf("%w: non-OK response from remote Discovery Service (url=%s): %s", ErrServiceUnavailable, serviceEndpointURL, problemResponseToError(httpErr))
+		}
 		return fmt.Errorf("non-OK response from remote Discovery Service (url=%s): %s", serviceEndpointURL, problemResponseToError(httpErr))
 	}
 	return nil
@@ -97,6 +115,69 @@ func (h DefaultHTTPClient) Get(ctx context.Context, serviceEndpointURL string, t
 	return result.Entries, result.Seed, result.Timestamp, nil
 }
 
+func (h DefaultHTTPClient) Subscribe(ctx context.Context, serviceEndpointURL string, onChange func(timestamp int)) error {
+	eventsURL, err := url.JoinPath(serviceEndpointURL, "events")
+	if err != nil {
+		return err
+	}
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, eventsURL, nil)
+	if err != nil {
+		return err
+	}
+	if client.StrictMode && httpRequest.URL.Scheme != "https" {
+		return errors.New("strictmode is enabled, but request is not over HTTPS")
+	}
+	httpRequest.Header.Set("Accept", "text/event-stream")
+	httpRequest.Header.Set("User-Agent", core.UserAgent())
+	httpResponse, err := h.streamClient.Do(httpRequest)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to remote Discovery Service (url=%s): %w", eventsURL, err)
+	}
+	defer httpResponse.Body.Close()
+	if err := core.TestResponseCode(200, httpResponse); err != nil {
+		httpErr := err.(core.HttpError) // TestResponseCode always returns an HttpError
+		return fmt.Errorf("non-OK response from remote Discovery Service (url=%s): %s", eventsURL, problemResponseToError(httpErr))
+	}
+	// Read Server-Sent Events, see https://html.spec.whatwg.org/multipage/server-sent-events.html
+	// Only the event type and data fields are supported, since the server doesn't send any other.
+	var eventType string
+	var data strings.Builder
+	scanner := bufio.NewScanner(httpResponse.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			// end of event, dispatch
+			if eventType == "timestamp" {
+				var event TimestampEvent
+				if err := json.Unmarshal([]byte(data.String()), &event); err != nil {
+					log.Logger().WithError(err).Warnf("Received invalid event from remote Discovery Service (url=%s)", eventsURL)
+				} else {
+					onChange(event.Timestamp)
+				}
+			}
+			eventType = ""
+			data.Reset()
+		case strings.HasPrefix(line, ":"):
+			// comment, used for keep-alive
+		case strings.HasPrefix(line, "event:"):
+			eventType = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteString("\n")
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("subscription on remote Discovery Service ended (url=%s): %w", eventsURL, err)
+	}
+	return nil
+}
+
 // problemResponseToError converts a Problem Details response to an error.
 // It creates an error with the given string concatenated with the title and detail fields of the problem details.
 func problemResponseToError(httpErr core.HttpError) string {
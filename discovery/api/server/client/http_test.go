@@ -49,6 +49,14 @@ func TestHTTPInvoker_Register(t *testing.T) {
 		assert.Equal(t, "application/json", handler.Request.Header.Get("Content-Type"))
 		assert.Equal(t, vpData, handler.RequestData)
 	})
+	t.Run("queued by replica", func(t *testing.T) {
+		server := httptest.NewServer(&testHTTP.Handler{StatusCode: http.StatusAccepted})
+		client := New(time.Minute)
+
+		err := client.Register(context.Background(), server.URL, vp)
+
+		assert.NoError(t, err)
+	})
 	t.Run("non-ok with problem details", func(t *testing.T) {
 		server := httptest.NewServer(&testHTTP.Handler{StatusCode: http.StatusBadRequest, ResponseData: `{"title":"missing credentials", "status":400, "detail":"could not resolve DID"}`})
 		client := New(time.Minute)
@@ -68,6 +76,25 @@ func TestHTTPInvoker_Register(t *testing.T) {
 		assert.ErrorContains(t, err, "non-OK response from remote Discovery Service")
 		assert.ErrorContains(t, err, "server returned HTTP 404")
 		assert.ErrorContains(t, err, "not found")
+		assert.NotErrorIs(t, err, ErrServiceUnavailable)
+	})
+	t.Run("server error", func(t *testing.T) {
+		server := httptest.NewServer(&testHTTP.Handler{StatusCode: http.StatusServiceUnavailable, ResponseData: `unavailable`})
+		client := New(time.Minute)
+
+		err := client.Register(context.Background(), server.URL, vp)
+
+		assert.ErrorIs(t, err, ErrServiceUnavailable)
+		assert.ErrorContains(t, err, "server returned HTTP 503")
+	})
+	t.Run("server unreachable", func(t *testing.T) {
+		server := httptest.NewServer(&testHTTP.Handler{StatusCode: http.StatusCreated})
+		server.Close()
+		client := New(time.Minute)
+
+		err := client.Register(context.Background(), server.URL, vp)
+
+		assert.ErrorIs(t, err, ErrServiceUnavailable)
 	})
 }
 
@@ -149,3 +176,55 @@ func TestHTTPInvoker_Get(t *testing.T) {
 		assert.ErrorContains(t, err, "failed to unmarshal response from remote Discovery Service")
 	})
 }
+
+func TestHTTPInvoker_Subscribe(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		var requestPath string
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			requestPath = request.URL.Path
+			writer.Header().Set("Content-Type", "text/event-stream")
+			writer.WriteHeader(http.StatusOK)
+			_, _ = writer.Write([]byte(": keep-alive\n\n"))
+			_, _ = writer.Write([]byte("event: timestamp\ndata: {\"timestamp\":1}\n\n"))
+			_, _ = writer.Write([]byte("event: other\ndata: {\"timestamp\":2}\n\n"))
+			_, _ = writer.Write([]byte("event: timestamp\ndata: invalid\n\n"))
+			_, _ = writer.Write([]byte("event: timestamp\ndata: {\"timestamp\":3}\n\n"))
+		}))
+		client := New(time.Minute)
+		var timestamps []int
+
+		err := client.Subscribe(context.Background(), server.URL+"/discovery/usecase", func(timestamp int) {
+			timestamps = append(timestamps, timestamp)
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "/discovery/usecase/events", requestPath)
+		assert.Equal(t, []int{1, 3}, timestamps)
+	})
+	t.Run("context cancelled", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			writer.WriteHeader(http.StatusOK)
+			writer.(http.Flusher).Flush()
+			<-request.Context().Done()
+		}))
+		client := New(time.Minute)
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			cancel()
+		}()
+
+		err := client.Subscribe(ctx, server.URL, func(int) {})
+
+		assert.NoError(t, err)
+	})
+	t.Run("not supported by server", func(t *testing.T) {
+		server := httptest.NewServer(&testHTTP.Handler{StatusCode: http.StatusNotFound, ResponseData: `not found`})
+		client := New(time.Minute)
+
+		err := client.Subscribe(context.Background(), server.URL, func(int) {})
+
+		assert.ErrorContains(t, err, "non-OK response from remote Discovery Service")
+		assert.ErrorContains(t, err, "server returned HTTP 404")
+	})
+}
@@ -20,9 +20,14 @@ package client
 
 import (
 	"context"
+	"errors"
 	"github.com/nuts-foundation/go-did/vc"
 )
 
+// ErrServiceUnavailable is returned when the remote Discovery Service can't be reached, or fails to process the request.
+// The request can be retried, e.g. on another Discovery Server of the service.
+var ErrServiceUnavailable = errors.New("remote Discovery Service is unavailable")
+
 // HTTPClient is the interface for the client that invokes the remote Discovery Service.
 type HTTPClient interface {
 	// Register registers a Verifiable Presentation on the remote Discovery Service.
@@ -32,4 +37,9 @@ type HTTPClient interface {
 	// If the call succeeds it returns the Verifiable Presentations and the timestamp that was returned by the server.
 	// If the given timestamp is 0, all Verifiable Presentations are retrieved.
 	Get(ctx context.Context, serviceEndpointURL string, timestamp int) (map[string]vc.VerifiablePresentation, string, int, error)
+
+	// Subscribe subscribes to changes of the remote Discovery Service, invoking onChange with the new timestamp of the service.
+	// It blocks until the subscription ends, which happens when the context is cancelled or the server closes the connection.
+	// It returns an error if the subscription couldn't be started, e.g. because the server doesn't support it.
+	Subscribe(ctx context.Context, serviceEndpointURL string, onChange func(timestamp int)) error
 }
@@ -71,3 +71,17 @@ func (mr *MockHTTPClientMockRecorder) Register(ctx, serviceEndpointURL, presenta
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockHTTPClient)(nil).Register), ctx, serviceEndpointURL, presentation)
 }
+
+// Subscribe mocks base method.
+func (m *MockHTTPClient) Subscribe(ctx context.Context, serviceEndpointURL string, onChange func(int)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", ctx, serviceEndpointURL, onChange)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockHTTPClientMockRecorder) Subscribe(ctx, serviceEndpointURL, onChange any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockHTTPClient)(nil).Subscribe), ctx, serviceEndpointURL, onChange)
+}
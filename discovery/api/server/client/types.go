@@ -29,3 +29,9 @@ type PresentationsResponse struct {
 	// Timestamp is the timestamp of the latest entry. It's not a unix timestamp but a Lamport Clock.
 	Timestamp int `json:"timestamp"`
 }
+
+// TimestampEvent is the data of the 'timestamp' event sent by the SubscribePresentations endpoint.
+type TimestampEvent struct {
+	// Timestamp is the timestamp of the latest entry of the Discovery Service.
+	Timestamp int `json:"timestamp"`
+}
@@ -119,6 +119,9 @@ type ClientInterface interface {
 	RegisterPresentationWithBody(ctx context.Context, serviceID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	RegisterPresentation(ctx context.Context, serviceID string, body RegisterPresentationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SubscribePresentations request
+	SubscribePresentations(ctx context.Context, serviceID string, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
 func (c *Client) GetPresentations(ctx context.Context, serviceID string, params *GetPresentationsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -157,6 +160,18 @@ func (c *Client) RegisterPresentation(ctx context.Context, serviceID string, bod
 	return c.Client.Do(req)
 }
 
+func (c *Client) SubscribePresentations(ctx context.Context, serviceID string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubscribePresentationsRequest(c.Server, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 // NewGetPresentationsRequest generates requests for GetPresentations
 func NewGetPresentationsRequest(server string, serviceID string, params *GetPresentationsParams) (*http.Request, error) {
 	var err error
@@ -260,6 +275,40 @@ func NewRegisterPresentationRequestWithBody(server string, serviceID string, con
 	return req, nil
 }
 
+// NewSubscribePresentationsRequest generates requests for SubscribePresentations
+func NewSubscribePresentationsRequest(server string, serviceID string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "serviceID", runtime.ParamLocationPath, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/discovery/%s/events", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
 	for _, r := range c.RequestEditors {
 		if err := r(ctx, req); err != nil {
@@ -310,6 +359,9 @@ type ClientWithResponsesInterface interface {
 	RegisterPresentationWithBodyWithResponse(ctx context.Context, serviceID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterPresentationResponse, error)
 
 	RegisterPresentationWithResponse(ctx context.Context, serviceID string, body RegisterPresentationJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterPresentationResponse, error)
+
+	// SubscribePresentationsWithResponse request
+	SubscribePresentationsWithResponse(ctx context.Context, serviceID string, reqEditors ...RequestEditorFn) (*SubscribePresentationsResponse, error)
 }
 
 type GetPresentationsResponse struct {
@@ -385,6 +437,37 @@ func (r RegisterPresentationResponse) StatusCode() int {
 	return 0
 }
 
+type SubscribePresentationsResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r SubscribePresentationsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SubscribePresentationsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 // GetPresentationsWithResponse request returning *GetPresentationsResponse
 func (c *ClientWithResponses) GetPresentationsWithResponse(ctx context.Context, serviceID string, params *GetPresentationsParams, reqEditors ...RequestEditorFn) (*GetPresentationsResponse, error) {
 	rsp, err := c.GetPresentations(ctx, serviceID, params, reqEditors...)
@@ -411,6 +494,15 @@ func (c *ClientWithResponses) RegisterPresentationWithResponse(ctx context.Conte
 	return ParseRegisterPresentationResponse(rsp)
 }
 
+// SubscribePresentationsWithResponse request returning *SubscribePresentationsResponse
+func (c *ClientWithResponses) SubscribePresentationsWithResponse(ctx context.Context, serviceID string, reqEditors ...RequestEditorFn) (*SubscribePresentationsResponse, error) {
+	rsp, err := c.SubscribePresentations(ctx, serviceID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubscribePresentationsResponse(rsp)
+}
+
 // ParseGetPresentationsResponse parses an HTTP response from a GetPresentationsWithResponse call
 func ParseGetPresentationsResponse(rsp *http.Response) (*GetPresentationsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -504,6 +596,41 @@ func ParseRegisterPresentationResponse(rsp *http.Response) (*RegisterPresentatio
 	return response, nil
 }
 
+// ParseSubscribePresentationsResponse parses an HTTP response from a SubscribePresentationsWithResponse call
+func ParseSubscribePresentationsResponse(rsp *http.Response) (*SubscribePresentationsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SubscribePresentationsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// Retrieves the presentations of a Discovery Service.
@@ -512,6 +639,9 @@ type ServerInterface interface {
 	// Register a presentation on the Discovery Service.
 	// (POST /discovery/{serviceID})
 	RegisterPresentation(ctx echo.Context, serviceID string) error
+	// Subscribes to changes of a Discovery Service.
+	// (GET /discovery/{serviceID}/events)
+	SubscribePresentations(ctx echo.Context, serviceID string) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -560,6 +690,22 @@ func (w *ServerInterfaceWrapper) RegisterPresentation(ctx echo.Context) error {
 	return err
 }
 
+// SubscribePresentations converts echo context to params.
+func (w *ServerInterfaceWrapper) SubscribePresentations(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "serviceID" -------------
+	var serviceID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "serviceID", ctx.Param("serviceID"), &serviceID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter serviceID: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.SubscribePresentations(ctx, serviceID)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -590,6 +736,7 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 
 	router.GET(baseURL+"/discovery/:serviceID", wrapper.GetPresentations)
 	router.POST(baseURL+"/discovery/:serviceID", wrapper.RegisterPresentation)
+	router.GET(baseURL+"/discovery/:serviceID/events", wrapper.SubscribePresentations)
 
 }
 
@@ -649,6 +796,14 @@ func (response RegisterPresentation201Response) VisitRegisterPresentationRespons
 	return nil
 }
 
+type RegisterPresentation202Response struct {
+}
+
+func (response RegisterPresentation202Response) VisitRegisterPresentationResponse(w http.ResponseWriter) error {
+	w.WriteHeader(202)
+	return nil
+}
+
 type RegisterPresentation400ApplicationProblemPlusJSONResponse struct {
 	// Detail A human-readable explanation specific to this occurrence of the problem.
 	Detail string `json:"detail"`
@@ -688,6 +843,54 @@ func (response RegisterPresentationdefaultApplicationProblemPlusJSONResponse) Vi
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
+type SubscribePresentationsRequestObject struct {
+	ServiceID string `json:"serviceID"`
+}
+
+type SubscribePresentationsResponseObject interface {
+	VisitSubscribePresentationsResponse(w http.ResponseWriter) error
+}
+
+type SubscribePresentations200TexteventStreamResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response SubscribePresentations200TexteventStreamResponse) VisitSubscribePresentationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/event-stream")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type SubscribePresentationsdefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
+}
+
+func (response SubscribePresentationsdefaultApplicationProblemPlusJSONResponse) VisitSubscribePresentationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
 	// Retrieves the presentations of a Discovery Service.
@@ -696,6 +899,9 @@ type StrictServerInterface interface {
 	// Register a presentation on the Discovery Service.
 	// (POST /discovery/{serviceID})
 	RegisterPresentation(ctx context.Context, request RegisterPresentationRequestObject) (RegisterPresentationResponseObject, error)
+	// Subscribes to changes of a Discovery Service.
+	// (GET /discovery/{serviceID}/events)
+	SubscribePresentations(ctx context.Context, request SubscribePresentationsRequestObject) (SubscribePresentationsResponseObject, error)
 }
 
 type StrictHandlerFunc = strictecho.StrictEchoHandlerFunc
@@ -766,3 +972,28 @@ func (sh *strictHandler) RegisterPresentation(ctx echo.Context, serviceID string
 	}
 	return nil
 }
+
+// SubscribePresentations operation middleware
+func (sh *strictHandler) SubscribePresentations(ctx echo.Context, serviceID string) error {
+	var request SubscribePresentationsRequestObject
+
+	request.ServiceID = serviceID
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.SubscribePresentations(ctx.Request().Context(), request.(SubscribePresentationsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SubscribePresentations")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(SubscribePresentationsResponseObject); ok {
+		return validResponse.VisitSubscribePresentationsResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
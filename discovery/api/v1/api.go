@@ -20,6 +20,7 @@ package v1
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"github.com/labstack/echo/v4"
 	"github.com/nuts-foundation/nuts-node/audit"
@@ -45,10 +46,14 @@ func (w *Wrapper) ResolveStatusCode(err error) int {
 	switch {
 	case errors.Is(err, discovery.ErrServiceNotFound):
 		return http.StatusNotFound
+	case errors.Is(err, discovery.ErrInvalidQuery):
+		return http.StatusBadRequest
 	case errors.Is(err, didsubject.ErrSubjectNotFound):
 		return http.StatusNotFound
 	case errors.Is(err, discovery.ErrPresentationRegistrationFailed):
 		return http.StatusPreconditionFailed
+	case errors.Is(err, discovery.ErrReadOnlyServiceDefinition):
+		return http.StatusConflict
 	default:
 		return http.StatusInternalServerError
 	}
@@ -79,9 +84,37 @@ func (w *Wrapper) Routes(router core.EchoRouter) {
 func (w *Wrapper) SearchPresentations(ctx context.Context, request SearchPresentationsRequestObject) (SearchPresentationsResponseObject, error) {
 	// Use query parameters provided in request context (see Routes())
 	queryValues := ctx.Value(requestQueryContextKey{}).(url.Values)
-	query := make(map[string]string)
+	properties := make(map[string]string)
 	for path, values := range queryValues {
-		query[path] = values[0]
+		switch path {
+		case "$filter", "$sort", "$offset", "$limit":
+			// not a JSON path, but a query option
+			continue
+		}
+		properties[path] = values[0]
+	}
+	query := discovery.Query{Properties: properties}
+	if request.Params.Filter != nil {
+		filter, err := discovery.ParseFilter(*request.Params.Filter)
+		if err != nil {
+			return nil, err
+		}
+		query.Filter = filter
+	}
+	if request.Params.Sort != nil {
+		query.Sort = discovery.ParseSort(*request.Params.Sort)
+	}
+	if request.Params.Offset != nil {
+		if *request.Params.Offset < 0 {
+			return nil, core.InvalidInputError("offset must not be negative")
+		}
+		query.Offset = *request.Params.Offset
+	}
+	if request.Params.Limit != nil {
+		if *request.Params.Limit < 0 {
+			return nil, core.InvalidInputError("limit must not be negative")
+		}
+		query.Limit = *request.Params.Limit
 	}
 	searchResults, err := w.Client.Search(request.ServiceID, query)
 	if err != nil {
@@ -134,6 +167,36 @@ func (w *Wrapper) GetServices(_ context.Context, _ GetServicesRequestObject) (Ge
 	return &result, nil
 }
 
+func (w *Wrapper) PutServiceDefinition(ctx context.Context, request PutServiceDefinitionRequestObject) (PutServiceDefinitionResponseObject, error) {
+	if request.Body == nil || request.Body.Definition == nil {
+		return nil, core.InvalidInputError("missing service definition")
+	}
+	// validate the definition against the JSON schema
+	data, err := json.Marshal(request.Body.Definition)
+	if err != nil {
+		return nil, core.InvalidInputError("invalid service definition: %w", err)
+	}
+	definition, err := discovery.ParseServiceDefinition(data)
+	if err != nil {
+		return nil, core.InvalidInputError("invalid service definition: %w", err)
+	}
+	if definition.ID != request.ServiceID {
+		return nil, core.InvalidInputError("service definition ID (%s) does not match service ID in path (%s)", definition.ID, request.ServiceID)
+	}
+	server := request.Body.Server != nil && *request.Body.Server
+	if err = w.Client.PutServiceDefinition(ctx, *definition, server); err != nil {
+		return nil, err
+	}
+	return PutServiceDefinition200Response{}, nil
+}
+
+func (w *Wrapper) RetireServiceDefinition(ctx context.Context, request RetireServiceDefinitionRequestObject) (RetireServiceDefinitionResponseObject, error) {
+	if err := w.Client.RetireServiceDefinition(ctx, request.ServiceID); err != nil {
+		return nil, err
+	}
+	return RetireServiceDefinition200Response{}, nil
+}
+
 func (w *Wrapper) GetServiceActivation(ctx context.Context, request GetServiceActivationRequestObject) (GetServiceActivationResponseObject, error) {
 	response := GetServiceActivation200JSONResponse{}
 	activated, presentations, err := w.Client.GetServiceActivation(ctx, request.ServiceID, request.SubjectID)
@@ -24,6 +24,8 @@ import (
 	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/vc"
 	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/core/to"
 	"github.com/nuts-foundation/nuts-node/discovery"
 	"github.com/nuts-foundation/nuts-node/vcr/signature/proof"
 	"github.com/nuts-foundation/nuts-node/vcr/test"
@@ -138,9 +140,9 @@ func TestWrapper_SearchPresentations(t *testing.T) {
 	ctx := context.WithValue(audit.TestContext(), requestQueryContextKey{}, url.Values{
 		"foo": []string{"bar"},
 	})
-	expectedQuery := map[string]string{
+	expectedQuery := discovery.Query{Properties: map[string]string{
 		"foo": "bar",
-	}
+	}}
 	id, _ := ssi.ParseURI("did:nuts:foo#1")
 	vp := test.ParsePresentation(t, vc.VerifiablePresentation{
 		ID:                   id,
@@ -187,6 +189,81 @@ func TestWrapper_SearchPresentations(t *testing.T) {
 		assert.NotNil(t, []SearchResult(actual))
 		assert.Len(t, actual, 0)
 	})
+	t.Run("filter, sort and pagination", func(t *testing.T) {
+		ctx := context.WithValue(audit.TestContext(), requestQueryContextKey{}, url.Values{
+			"foo":     []string{"bar"},
+			"$filter": []string{`a=b OR c~"d e"`},
+			"$sort":   []string{"a,-c"},
+			"$offset": []string{"10"},
+			"$limit":  []string{"5"},
+		})
+		filter := `a=b OR c~"d e"`
+		sort := "a,-c"
+		offset := 10
+		limit := 5
+		test := newMockContext(t)
+		test.client.EXPECT().Search(serviceID, discovery.Query{
+			Properties: map[string]string{"foo": "bar"},
+			Filter: discovery.Or{
+				discovery.Comparison{Path: "a", Operator: discovery.OperatorEquals, Value: "b"},
+				discovery.Comparison{Path: "c", Operator: discovery.OperatorMatches, Value: "d e"},
+			},
+			Sort:   []discovery.SortField{{Path: "a"}, {Path: "c", Descending: true}},
+			Offset: 10,
+			Limit:  5,
+		}).Return(nil, nil)
+
+		_, err := test.wrapper.SearchPresentations(ctx, SearchPresentationsRequestObject{
+			ServiceID: serviceID,
+			Params: SearchPresentationsParams{
+				Filter: &filter,
+				Sort:   &sort,
+				Offset: &offset,
+				Limit:  &limit,
+			},
+		})
+
+		assert.NoError(t, err)
+	})
+	t.Run("properties named like query options", func(t *testing.T) {
+		ctx := context.WithValue(audit.TestContext(), requestQueryContextKey{}, url.Values{
+			"filter": []string{"a"},
+			"sort":   []string{"b"},
+			"offset": []string{"c"},
+			"limit":  []string{"d"},
+		})
+		test := newMockContext(t)
+		test.client.EXPECT().Search(serviceID, discovery.Query{
+			Properties: map[string]string{"filter": "a", "sort": "b", "offset": "c", "limit": "d"},
+		}).Return(nil, nil)
+
+		_, err := test.wrapper.SearchPresentations(ctx, SearchPresentationsRequestObject{ServiceID: serviceID})
+
+		assert.NoError(t, err)
+	})
+	t.Run("invalid filter", func(t *testing.T) {
+		filter := "a="
+		test := newMockContext(t)
+
+		_, err := test.wrapper.SearchPresentations(ctx, SearchPresentationsRequestObject{
+			ServiceID: serviceID,
+			Params:    SearchPresentationsParams{Filter: &filter},
+		})
+
+		assert.ErrorIs(t, err, discovery.ErrInvalidQuery)
+		assert.Equal(t, http.StatusBadRequest, test.wrapper.ResolveStatusCode(err))
+	})
+	t.Run("negative offset", func(t *testing.T) {
+		offset := -1
+		test := newMockContext(t)
+
+		_, err := test.wrapper.SearchPresentations(ctx, SearchPresentationsRequestObject{
+			ServiceID: serviceID,
+			Params:    SearchPresentationsParams{Offset: &offset},
+		})
+
+		assert.EqualError(t, err, "offset must not be negative")
+	})
 	t.Run("error", func(t *testing.T) {
 		test := newMockContext(t)
 		test.client.EXPECT().Search(serviceID, expectedQuery).Return(nil, discovery.ErrServiceNotFound)
@@ -245,10 +322,127 @@ func TestWrapper_GetServiceActivation(t *testing.T) {
 	})
 }
 
+func TestWrapper_PutServiceDefinition(t *testing.T) {
+	definition := map[string]interface{}{
+		"id":                        serviceID,
+		"endpoint":                  "https://example.com/discovery/wonderland",
+		"presentation_max_validity": 3600,
+		"presentation_definition": map[string]interface{}{
+			"id": "wonderland_pd",
+			"input_descriptors": []interface{}{
+				map[string]interface{}{
+					"id": "1",
+					"constraints": map[string]interface{}{
+						"fields": []interface{}{
+							map[string]interface{}{
+								"path": []string{"$.issuer"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	t.Run("ok", func(t *testing.T) {
+		test := newMockContext(t)
+		test.client.EXPECT().PutServiceDefinition(gomock.Any(), gomock.Any(), true).DoAndReturn(func(_ context.Context, actual discovery.ServiceDefinition, _ bool) error {
+			assert.Equal(t, serviceID, actual.ID)
+			assert.Equal(t, "https://example.com/discovery/wonderland", actual.Endpoint)
+			assert.Equal(t, 3600, actual.PresentationMaxValidity)
+			assert.Len(t, actual.PresentationDefinition.InputDescriptors, 1)
+			return nil
+		})
+
+		response, err := test.wrapper.PutServiceDefinition(nil, PutServiceDefinitionRequestObject{
+			ServiceID: serviceID,
+			Body: &PutServiceDefinitionJSONRequestBody{
+				Definition: definition,
+				Server:     to.Ptr(true),
+			},
+		})
+
+		assert.NoError(t, err)
+		assert.IsType(t, PutServiceDefinition200Response{}, response)
+	})
+	t.Run("invalid definition", func(t *testing.T) {
+		test := newMockContext(t)
+
+		_, err := test.wrapper.PutServiceDefinition(nil, PutServiceDefinitionRequestObject{
+			ServiceID: serviceID,
+			Body: &PutServiceDefinitionJSONRequestBody{
+				Definition: map[string]interface{}{"id": serviceID},
+			},
+		})
+
+		assert.ErrorContains(t, err, "invalid service definition")
+		assert.ErrorIs(t, err, core.Error(http.StatusBadRequest, ""))
+	})
+	t.Run("ID does not match path", func(t *testing.T) {
+		test := newMockContext(t)
+
+		_, err := test.wrapper.PutServiceDefinition(nil, PutServiceDefinitionRequestObject{
+			ServiceID: "other",
+			Body: &PutServiceDefinitionJSONRequestBody{
+				Definition: definition,
+			},
+		})
+
+		assert.EqualError(t, err, "service definition ID (wonderland) does not match service ID in path (other)")
+		assert.ErrorIs(t, err, core.Error(http.StatusBadRequest, ""))
+	})
+	t.Run("missing body", func(t *testing.T) {
+		test := newMockContext(t)
+
+		_, err := test.wrapper.PutServiceDefinition(nil, PutServiceDefinitionRequestObject{
+			ServiceID: serviceID,
+		})
+
+		assert.EqualError(t, err, "missing service definition")
+	})
+	t.Run("error", func(t *testing.T) {
+		test := newMockContext(t)
+		test.client.EXPECT().PutServiceDefinition(gomock.Any(), gomock.Any(), false).Return(discovery.ErrReadOnlyServiceDefinition)
+
+		_, err := test.wrapper.PutServiceDefinition(nil, PutServiceDefinitionRequestObject{
+			ServiceID: serviceID,
+			Body: &PutServiceDefinitionJSONRequestBody{
+				Definition: definition,
+			},
+		})
+
+		assert.ErrorIs(t, err, discovery.ErrReadOnlyServiceDefinition)
+	})
+}
+
+func TestWrapper_RetireServiceDefinition(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		test := newMockContext(t)
+		test.client.EXPECT().RetireServiceDefinition(gomock.Any(), serviceID).Return(nil)
+
+		response, err := test.wrapper.RetireServiceDefinition(nil, RetireServiceDefinitionRequestObject{
+			ServiceID: serviceID,
+		})
+
+		assert.NoError(t, err)
+		assert.IsType(t, RetireServiceDefinition200Response{}, response)
+	})
+	t.Run("error", func(t *testing.T) {
+		test := newMockContext(t)
+		test.client.EXPECT().RetireServiceDefinition(gomock.Any(), serviceID).Return(discovery.ErrServiceNotFound)
+
+		_, err := test.wrapper.RetireServiceDefinition(nil, RetireServiceDefinitionRequestObject{
+			ServiceID: serviceID,
+		})
+
+		assert.ErrorIs(t, err, discovery.ErrServiceNotFound)
+	})
+}
+
 func TestWrapper_ResolveStatusCode(t *testing.T) {
 	expected := map[error]int{
-		errors.New("foo"):            http.StatusInternalServerError,
-		discovery.ErrServiceNotFound: http.StatusNotFound,
+		errors.New("foo"):                      http.StatusInternalServerError,
+		discovery.ErrServiceNotFound:           http.StatusNotFound,
+		discovery.ErrReadOnlyServiceDefinition: http.StatusConflict,
 	}
 	wrapper := Wrapper{}
 	for err, expectedCode := range expected {
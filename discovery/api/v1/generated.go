@@ -45,11 +45,35 @@ type ServiceActivationRequest struct {
 	RegistrationParameters *map[string]interface{} `json:"registrationParameters,omitempty"`
 }
 
+// ServiceDefinitionRequest Request to add or update a Discovery Service definition.
+type ServiceDefinitionRequest struct {
+	// Definition The Discovery Service definition, see ServiceDefinition.
+	Definition map[string]interface{} `json:"definition"`
+
+	// Server Whether the node acts as Discovery Server for the service. Defaults to false.
+	Server *bool `json:"server,omitempty"`
+}
+
 // SearchPresentationsParams defines parameters for SearchPresentations.
 type SearchPresentationsParams struct {
+	// Filter Filter expression the credentials must match, see the description of the operation.
+	Filter *string `form:"$filter,omitempty" json:"$filter,omitempty"`
+
+	// Sort Comma-separated list of JSON paths to sort the results on. Prefix a path with '-' to sort descending.
+	// Presentations are sorted on the values of the first credential that matches the filter.
+	Sort *string `form:"$sort,omitempty" json:"$sort,omitempty"`
+
+	// Offset Number of results to skip.
+	Offset *int `form:"$offset,omitempty" json:"$offset,omitempty"`
+
+	// Limit Maximum number of results to return. If not set, all results are returned.
+	Limit *int               `form:"$limit,omitempty" json:"$limit,omitempty"`
 	Query *map[string]string `form:"query,omitempty" json:"query,omitempty"`
 }
 
+// PutServiceDefinitionJSONRequestBody defines body for PutServiceDefinition for application/json ContentType.
+type PutServiceDefinitionJSONRequestBody = ServiceDefinitionRequest
+
 // ActivateServiceForSubjectJSONRequestBody defines body for ActivateServiceForSubject for application/json ContentType.
 type ActivateServiceForSubjectJSONRequestBody = ServiceActivationRequest
 
@@ -58,9 +82,15 @@ type ServerInterface interface {
 	// Retrieves the list of Discovery Services.
 	// (GET /internal/discovery/v1)
 	GetServices(ctx echo.Context) error
+	// Retires a Discovery Service definition.
+	// (DELETE /internal/discovery/v1/{serviceID})
+	RetireServiceDefinition(ctx echo.Context, serviceID string) error
 	// Searches for presentations registered on the Discovery Service.
 	// (GET /internal/discovery/v1/{serviceID})
 	SearchPresentations(ctx echo.Context, serviceID string, params SearchPresentationsParams) error
+	// Adds or updates a Discovery Service definition.
+	// (PUT /internal/discovery/v1/{serviceID})
+	PutServiceDefinition(ctx echo.Context, serviceID string) error
 	// Remove a subject from the Discovery Service.
 	// (DELETE /internal/discovery/v1/{serviceID}/{subjectID})
 	DeactivateServiceForSubject(ctx echo.Context, serviceID string, subjectID string) error
@@ -88,6 +118,24 @@ func (w *ServerInterfaceWrapper) GetServices(ctx echo.Context) error {
 	return err
 }
 
+// RetireServiceDefinition converts echo context to params.
+func (w *ServerInterfaceWrapper) RetireServiceDefinition(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "serviceID" -------------
+	var serviceID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "serviceID", ctx.Param("serviceID"), &serviceID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter serviceID: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RetireServiceDefinition(ctx, serviceID)
+	return err
+}
+
 // SearchPresentations converts echo context to params.
 func (w *ServerInterfaceWrapper) SearchPresentations(ctx echo.Context) error {
 	var err error
@@ -103,6 +151,34 @@ func (w *ServerInterfaceWrapper) SearchPresentations(ctx echo.Context) error {
 
 	// Parameter object where we will unmarshal all parameters from the context
 	var params SearchPresentationsParams
+	// ------------- Optional query parameter "$filter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "$filter", ctx.QueryParams(), &params.Filter)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter $filter: %s", err))
+	}
+
+	// ------------- Optional query parameter "$sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "$sort", ctx.QueryParams(), &params.Sort)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter $sort: %s", err))
+	}
+
+	// ------------- Optional query parameter "$offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "$offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter $offset: %s", err))
+	}
+
+	// ------------- Optional query parameter "$limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "$limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter $limit: %s", err))
+	}
+
 	// ------------- Optional query parameter "query" -------------
 
 	err = runtime.BindQueryParameter("form", true, false, "query", ctx.QueryParams(), &params.Query)
@@ -115,6 +191,24 @@ func (w *ServerInterfaceWrapper) SearchPresentations(ctx echo.Context) error {
 	return err
 }
 
+// PutServiceDefinition converts echo context to params.
+func (w *ServerInterfaceWrapper) PutServiceDefinition(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "serviceID" -------------
+	var serviceID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "serviceID", ctx.Param("serviceID"), &serviceID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter serviceID: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PutServiceDefinition(ctx, serviceID)
+	return err
+}
+
 // DeactivateServiceForSubject converts echo context to params.
 func (w *ServerInterfaceWrapper) DeactivateServiceForSubject(ctx echo.Context) error {
 	var err error
@@ -213,7 +307,9 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	}
 
 	router.GET(baseURL+"/internal/discovery/v1", wrapper.GetServices)
+	router.DELETE(baseURL+"/internal/discovery/v1/:serviceID", wrapper.RetireServiceDefinition)
 	router.GET(baseURL+"/internal/discovery/v1/:serviceID", wrapper.SearchPresentations)
+	router.PUT(baseURL+"/internal/discovery/v1/:serviceID", wrapper.PutServiceDefinition)
 	router.DELETE(baseURL+"/internal/discovery/v1/:serviceID/:subjectID", wrapper.DeactivateServiceForSubject)
 	router.GET(baseURL+"/internal/discovery/v1/:serviceID/:subjectID", wrapper.GetServiceActivation)
 	router.POST(baseURL+"/internal/discovery/v1/:serviceID/:subjectID", wrapper.ActivateServiceForSubject)
@@ -257,6 +353,43 @@ func (response GetServicesdefaultApplicationProblemPlusJSONResponse) VisitGetSer
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
+type RetireServiceDefinitionRequestObject struct {
+	ServiceID string `json:"serviceID"`
+}
+
+type RetireServiceDefinitionResponseObject interface {
+	VisitRetireServiceDefinitionResponse(w http.ResponseWriter) error
+}
+
+type RetireServiceDefinition200Response struct {
+}
+
+func (response RetireServiceDefinition200Response) VisitRetireServiceDefinitionResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type RetireServiceDefinitiondefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
+}
+
+func (response RetireServiceDefinitiondefaultApplicationProblemPlusJSONResponse) VisitRetireServiceDefinitionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
 type SearchPresentationsRequestObject struct {
 	ServiceID string `json:"serviceID"`
 	Params    SearchPresentationsParams
@@ -296,6 +429,44 @@ func (response SearchPresentationsdefaultApplicationProblemPlusJSONResponse) Vis
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
+type PutServiceDefinitionRequestObject struct {
+	ServiceID string `json:"serviceID"`
+	Body      *PutServiceDefinitionJSONRequestBody
+}
+
+type PutServiceDefinitionResponseObject interface {
+	VisitPutServiceDefinitionResponse(w http.ResponseWriter) error
+}
+
+type PutServiceDefinition200Response struct {
+}
+
+func (response PutServiceDefinition200Response) VisitPutServiceDefinitionResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type PutServiceDefinitiondefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
+}
+
+func (response PutServiceDefinitiondefaultApplicationProblemPlusJSONResponse) VisitPutServiceDefinitionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
 type DeactivateServiceForSubjectRequestObject struct {
 	ServiceID string `json:"serviceID"`
 	SubjectID string `json:"subjectID"`
@@ -442,9 +613,15 @@ type StrictServerInterface interface {
 	// Retrieves the list of Discovery Services.
 	// (GET /internal/discovery/v1)
 	GetServices(ctx context.Context, request GetServicesRequestObject) (GetServicesResponseObject, error)
+	// Retires a Discovery Service definition.
+	// (DELETE /internal/discovery/v1/{serviceID})
+	RetireServiceDefinition(ctx context.Context, request RetireServiceDefinitionRequestObject) (RetireServiceDefinitionResponseObject, error)
 	// Searches for presentations registered on the Discovery Service.
 	// (GET /internal/discovery/v1/{serviceID})
 	SearchPresentations(ctx context.Context, request SearchPresentationsRequestObject) (SearchPresentationsResponseObject, error)
+	// Adds or updates a Discovery Service definition.
+	// (PUT /internal/discovery/v1/{serviceID})
+	PutServiceDefinition(ctx context.Context, request PutServiceDefinitionRequestObject) (PutServiceDefinitionResponseObject, error)
 	// Remove a subject from the Discovery Service.
 	// (DELETE /internal/discovery/v1/{serviceID}/{subjectID})
 	DeactivateServiceForSubject(ctx context.Context, request DeactivateServiceForSubjectRequestObject) (DeactivateServiceForSubjectResponseObject, error)
@@ -491,6 +668,31 @@ func (sh *strictHandler) GetServices(ctx echo.Context) error {
 	return nil
 }
 
+// RetireServiceDefinition operation middleware
+func (sh *strictHandler) RetireServiceDefinition(ctx echo.Context, serviceID string) error {
+	var request RetireServiceDefinitionRequestObject
+
+	request.ServiceID = serviceID
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RetireServiceDefinition(ctx.Request().Context(), request.(RetireServiceDefinitionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RetireServiceDefinition")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(RetireServiceDefinitionResponseObject); ok {
+		return validResponse.VisitRetireServiceDefinitionResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // SearchPresentations operation middleware
 func (sh *strictHandler) SearchPresentations(ctx echo.Context, serviceID string, params SearchPresentationsParams) error {
 	var request SearchPresentationsRequestObject
@@ -517,6 +719,37 @@ func (sh *strictHandler) SearchPresentations(ctx echo.Context, serviceID string,
 	return nil
 }
 
+// PutServiceDefinition operation middleware
+func (sh *strictHandler) PutServiceDefinition(ctx echo.Context, serviceID string) error {
+	var request PutServiceDefinitionRequestObject
+
+	request.ServiceID = serviceID
+
+	var body PutServiceDefinitionJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PutServiceDefinition(ctx.Request().Context(), request.(PutServiceDefinitionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutServiceDefinition")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PutServiceDefinitionResponseObject); ok {
+		return validResponse.VisitPutServiceDefinitionResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // DeactivateServiceForSubject operation middleware
 func (sh *strictHandler) DeactivateServiceForSubject(ctx echo.Context, serviceID string, subjectID string) error {
 	var request DeactivateServiceForSubjectRequestObject
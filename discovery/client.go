@@ -36,8 +36,10 @@ import (
 	"github.com/nuts-foundation/nuts-node/vcr/types"
 	"github.com/nuts-foundation/nuts-node/vdr/didsubject"
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+	"net/url"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -45,12 +47,16 @@ import (
 // It can refresh registered Verifiable Presentations when they are about to expire.
 type clientRegistrationManager struct {
 	services       map[string]ServiceDefinition
+	servicesMux    sync.RWMutex
 	store          *sqlStore
 	client         client.HTTPClient
 	vcr            vcr.VCR
 	subjectManager didsubject.Manager
 	didResolver    resolver.DIDResolver
 	verifier       presentationVerifier
+	events         *eventPublisher
+	// publicURL is the URL of the node itself, if set. A replica doesn't register presentations on itself.
+	publicURL *url.URL
 }
 
 func newRegistrationManager(services map[string]ServiceDefinition, store *sqlStore, client client.HTTPClient, vcr vcr.VCR, subjectManager didsubject.Manager, didResolver resolver.DIDResolver, verifier presentationVerifier) *clientRegistrationManager {
@@ -65,6 +71,20 @@ func newRegistrationManager(services map[string]ServiceDefinition, store *sqlSto
 	}
 }
 
+// setServices replaces the Discovery Services the manager manages registrations for.
+func (r *clientRegistrationManager) setServices(services map[string]ServiceDefinition) {
+	r.servicesMux.Lock()
+	defer r.servicesMux.Unlock()
+	r.services = services
+}
+
+func (r *clientRegistrationManager) service(serviceID string) (ServiceDefinition, bool) {
+	r.servicesMux.RLock()
+	defer r.servicesMux.RUnlock()
+	service, exists := r.services[serviceID]
+	return service, exists
+}
+
 func (r *clientRegistrationManager) activate(ctx context.Context, serviceID, subjectID string, parameters map[string]interface{}) error {
 	service, subjectDIDs, err := r.getServiceAndSubject(ctx, serviceID, subjectID)
 	if err != nil {
@@ -200,7 +220,7 @@ func (r *clientRegistrationManager) deactivate(ctx context.Context, serviceID, s
 
 // getServiceAndSubject returns the service and subject, or ErrServiceNotFound / didsubject.ErrSubjectNotFound if either does not exist
 func (r *clientRegistrationManager) getServiceAndSubject(ctx context.Context, serviceID, subjectID string) (ServiceDefinition, []did.DID, error) {
-	service, serviceExists := r.services[serviceID]
+	service, serviceExists := r.service(serviceID)
 	if !serviceExists {
 		return ServiceDefinition{}, nil, ErrServiceNotFound
 	}
@@ -218,7 +238,7 @@ func (r *clientRegistrationManager) deregisterPresentation(ctx context.Context,
 	if err != nil {
 		return err
 	}
-	return r.client.Register(ctx, service.Endpoint, *presentation)
+	return registerOnService(ctx, r.client, service, r.publicURL, *presentation)
 }
 
 func (r *clientRegistrationManager) registerPresentation(ctx context.Context, subjectDID did.DID, service ServiceDefinition, parameters map[string]interface{}) error {
@@ -226,7 +246,7 @@ func (r *clientRegistrationManager) registerPresentation(ctx context.Context, su
 	if err != nil {
 		return err
 	}
-	return r.client.Register(ctx, service.Endpoint, *presentation)
+	return registerOnService(ctx, r.client, service, r.publicURL, *presentation)
 }
 
 func (r *clientRegistrationManager) findCredentialsAndBuildPresentation(ctx context.Context, subjectDID did.DID, service ServiceDefinition, parameters map[string]interface{}) (*vc.VerifiablePresentation, error) {
@@ -320,7 +340,7 @@ func (r *clientRegistrationManager) refresh(ctx context.Context, now time.Time)
 }
 
 // validate validates all presentations that are not yet validated
-func (r *clientRegistrationManager) validate() error {
+func (r *clientRegistrationManager) validate(ctx context.Context) error {
 	errMsg := "background verification of presentation failed (service: %s, id: %s)"
 	// find all unvalidated entries in store
 	presentations, err := r.store.allPresentations(false)
@@ -334,12 +354,12 @@ func (r *clientRegistrationManager) validate() error {
 			log.Logger().WithError(err).Warnf(errMsg, presentation.ServiceID, presentation.ID)
 			continue
 		}
-		service, exists := r.services[presentation.ServiceID]
+		service, exists := r.service(presentation.ServiceID)
 		if !exists {
 			log.Logger().WithError(err).Warnf("service not found for background validation: %s", presentation.ServiceID)
 			continue
 		}
-		if err = r.verifier(service, *verifiablePresentation); err != nil {
+		if err = r.verifier(ctx, service, *verifiablePresentation); err != nil {
 			log.Logger().WithError(err).Warnf(errMsg, presentation.ServiceID, presentation.ID)
 			continue
 		}
@@ -354,7 +374,7 @@ func (r *clientRegistrationManager) validate() error {
 }
 
 // removeRevoked removes all revoked presentations from the store
-func (r *clientRegistrationManager) removeRevoked() error {
+func (r *clientRegistrationManager) removeRevoked(ctx context.Context) error {
 	errMsg := "background revocation check of presentation failed (id: %s)"
 	// find all validated entries in store
 	presentations, err := r.store.allPresentations(true)
@@ -368,7 +388,7 @@ func (r *clientRegistrationManager) removeRevoked() error {
 			log.Logger().WithError(err).Warnf(errMsg, presentation.ID)
 			continue
 		}
-		_, err = r.vcr.Verifier().VerifyVP(*verifiablePresentation, true, true, nil)
+		_, err = r.vcr.Verifier().VerifyVP(ctx, *verifiablePresentation, true, true, nil)
 		if err != nil && !errors.Is(err, types.ErrRevoked) {
 			log.Logger().WithError(err).Warnf(errMsg, presentation.ID)
 			continue
@@ -377,7 +397,9 @@ func (r *clientRegistrationManager) removeRevoked() error {
 			log.Logger().WithError(err).Infof("removing revoked presentation (id: %s)", presentation.ID)
 			if err = r.store.deletePresentationRecord(presentation.ID); err != nil {
 				log.Logger().WithError(err).Warnf("failed to remove revoked presentation from discovery service (id: %s)", presentation.ID)
+				continue
 			}
+			r.events.publish(newEvent(EventRevoked, presentation, nil))
 		}
 	}
 	return nil
@@ -386,10 +408,19 @@ func (r *clientRegistrationManager) removeRevoked() error {
 // clientUpdater is responsible for updating the local copy of Discovery Services
 // Callers should only call update().
 type clientUpdater struct {
-	services map[string]ServiceDefinition
-	store    *sqlStore
-	client   client.HTTPClient
-	verifier presentationVerifier
+	services    map[string]ServiceDefinition
+	servicesMux sync.RWMutex
+	store       *sqlStore
+	client      client.HTTPClient
+	verifier    presentationVerifier
+	// onChange is called with the new timestamp of a service when presentations were loaded from the Discovery Service, if set.
+	onChange func(serviceID string, timestamp int)
+	// publicURL is the URL of the node itself, if set. A replica doesn't load presentations from itself.
+	publicURL *url.URL
+	events    *eventPublisher
+	// serviceMux holds a *sync.Mutex per service, so the local copy of a service is updated by one caller at a time
+	// (e.g. the refresh loop and a change notification).
+	serviceMux sync.Map
 }
 
 func newClientUpdater(services map[string]ServiceDefinition, store *sqlStore, verifier presentationVerifier, client client.HTTPClient) *clientUpdater {
@@ -401,10 +432,20 @@ func newClientUpdater(services map[string]ServiceDefinition, store *sqlStore, ve
 	}
 }
 
+// setServices replaces the Discovery Services the updater keeps a local copy of.
+func (u *clientUpdater) setServices(services map[string]ServiceDefinition) {
+	u.servicesMux.Lock()
+	defer u.servicesMux.Unlock()
+	u.services = services
+}
+
 func (u *clientUpdater) update(ctx context.Context) error {
 	log.Logger().Debug("Checking for new Verifiable Presentations from Discovery Services")
+	u.servicesMux.RLock()
+	services := u.services
+	u.servicesMux.RUnlock()
 	var result error = nil
-	for _, service := range u.services {
+	for _, service := range services {
 		if err := u.updateService(ctx, service); err != nil {
 			result = errors.Join(result, err)
 		}
@@ -413,6 +454,9 @@ func (u *clientUpdater) update(ctx context.Context) error {
 }
 
 func (u *clientUpdater) updateService(ctx context.Context, service ServiceDefinition) error {
+	mux, _ := u.serviceMux.LoadOrStore(service.ID, &sync.Mutex{})
+	mux.(*sync.Mutex).Lock()
+	defer mux.(*sync.Mutex).Unlock()
 	currentTimestamp, err := u.store.getTimestamp(service.ID)
 	if err != nil {
 		return err
@@ -420,7 +464,7 @@ func (u *clientUpdater) updateService(ctx context.Context, service ServiceDefini
 	log.Logger().
 		WithField("discoveryService", service.ID).
 		Tracef("Checking for new Verifiable Presentations from Discovery Service (timestamp: %d)", currentTimestamp)
-	presentations, seed, serverTimestamp, err := u.client.Get(ctx, service.Endpoint, currentTimestamp)
+	presentations, seed, serverTimestamp, err := getFromService(ctx, u.client, service, u.publicURL, currentTimestamp)
 	if err != nil {
 		return fmt.Errorf("failed to get presentations from discovery service (id=%s): %w", service.ID, err)
 	}
@@ -442,14 +486,21 @@ func (u *clientUpdater) updateService(ctx context.Context, service ServiceDefini
 		if exists {
 			continue
 		}
+		eventType, err := addedEventType(u.store, service.ID, credentialSubjectID.String(), presentation)
+		if err != nil {
+			return err
+		}
 
 		// always add the presentation, even if it's not valid
 		// it won't be returned in a search if invalid
 		// the validator will set the validated flag to true when it's valid
 		// it'll also remove it from the store if it's invalidated later
-		if record, err := u.store.add(service.ID, presentation, seed, serverTimestamp); err != nil {
+		record, err := u.store.add(service.ID, presentation, seed, serverTimestamp)
+		if err != nil {
 			return fmt.Errorf("failed to store presentation (service=%s, id=%s): %w", service.ID, presentation.ID, err)
-		} else if err = u.verifier(service, presentation); err == nil {
+		}
+		u.events.publishAdded(eventType, *record, presentation)
+		if err = u.verifier(ctx, service, presentation); err == nil {
 			// valid, immediately activate
 			if err = u.store.updateValidated([]presentationRecord{*record}); err != nil {
 				return fmt.Errorf("failed to update validated flag (service=%s, id=%s): %w", service.ID, presentation.ID, err)
@@ -463,5 +514,42 @@ func (u *clientUpdater) updateService(ctx context.Context, service ServiceDefini
 			WithField("presentationID", presentation.ID).
 			Trace("Loaded new Verifiable Presentation from Discovery Service")
 	}
+	if len(presentations) > 0 && u.onChange != nil {
+		u.onChange(service.ID, serverTimestamp)
+	}
 	return nil
 }
+
+// registerOnService registers the presentation on the Discovery Server of the service.
+// If the server is unavailable, it fails over to the replicas of the service (other than self), which forward the presentation to the leader.
+func registerOnService(ctx context.Context, httpClient client.HTTPClient, service ServiceDefinition, self *url.URL, presentation vc.VerifiablePresentation) error {
+	var err error
+	for _, endpoint := range service.endpoints(self) {
+		err = httpClient.Register(ctx, endpoint, presentation)
+		if !errors.Is(err, client.ErrServiceUnavailable) {
+			return err
+		}
+		log.Logger().WithError(err).Infof("Discovery Server is unavailable (service=%s, url=%s)", service.ID, endpoint)
+	}
+	return err
+}
+
+// getFromService retrieves the presentations registered after the given timestamp from the Discovery Server of the service.
+// If the server can't be queried, it fails over to the replicas of the service.
+// Replicas use the seed of the leader, and the timestamps they assign to replicated presentations are never lower than those assigned by the leader.
+// So a client that retrieved presentations up to some timestamp from one server, can continue from that timestamp at another server.
+// Replicas hosted by self are skipped.
+func getFromService(ctx context.Context, httpClient client.HTTPClient, service ServiceDefinition, self *url.URL, startAfter int) (map[string]vc.VerifiablePresentation, string, int, error) {
+	var result error
+	for _, endpoint := range service.endpoints(self) {
+		presentations, seed, timestamp, err := httpClient.Get(ctx, endpoint, startAfter)
+		if err == nil {
+			return presentations, seed, timestamp, nil
+		}
+		result = errors.Join(result, err)
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, "", 0, result
+}
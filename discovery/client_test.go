@@ -27,6 +27,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/audit"
 	"github.com/nuts-foundation/nuts-node/discovery/api/server/client"
 	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/nuts-foundation/nuts-node/test"
 	"github.com/nuts-foundation/nuts-node/vcr"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
 	"github.com/nuts-foundation/nuts-node/vcr/holder"
@@ -38,6 +39,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -406,7 +409,7 @@ func Test_defaultClientRegistrationManager_validate(t *testing.T) {
 		{
 			name: "verification failed",
 			setupManager: func(ctx testContext) *clientRegistrationManager {
-				return newRegistrationManager(testDefinitions(), ctx.store, ctx.invoker, ctx.vcr, ctx.subjectManager, ctx.didResolver, func(service ServiceDefinition, vp vc.VerifiablePresentation) error {
+				return newRegistrationManager(testDefinitions(), ctx.store, ctx.invoker, ctx.vcr, ctx.subjectManager, ctx.didResolver, func(_ context.Context, service ServiceDefinition, vp vc.VerifiablePresentation) error {
 					return errors.New("verification failed")
 				})
 			},
@@ -428,7 +431,7 @@ func Test_defaultClientRegistrationManager_validate(t *testing.T) {
 			require.NoError(t, err)
 			manager := tt.setupManager(ctx)
 
-			err = manager.validate()
+			err = manager.validate(context.Background())
 			require.NoError(t, err)
 
 			presentations, err := ctx.store.allPresentations(true)
@@ -443,9 +446,10 @@ func Test_defaultClientRegistrationManager_removeRevoked(t *testing.T) {
 	require.NoError(t, storageEngine.Start())
 
 	tests := []struct {
-		name          string
-		verifyVPError error
-		expectedLen   int
+		name           string
+		verifyVPError  error
+		expectedLen    int
+		expectedEvents int
 	}{
 		{
 			name:          "ok - not revoked",
@@ -453,9 +457,10 @@ func Test_defaultClientRegistrationManager_removeRevoked(t *testing.T) {
 			expectedLen:   1,
 		},
 		{
-			name:          "ok - revoked",
-			verifyVPError: types.ErrRevoked,
-			expectedLen:   0,
+			name:           "ok - revoked",
+			verifyVPError:  types.ErrRevoked,
+			expectedLen:    0,
+			expectedEvents: 1,
 		},
 		{
 			name:          "error",
@@ -469,18 +474,26 @@ func Test_defaultClientRegistrationManager_removeRevoked(t *testing.T) {
 			ctx := newTestContext(t)
 			_, err := ctx.store.add(testServiceID, vpAlice, testSeed, 1)
 			require.NoError(t, err)
-			require.NoError(t, ctx.manager.validate())
+			require.NoError(t, ctx.manager.validate(context.Background()))
 
 			mockVerifier := verifier.NewMockVerifier(ctx.ctrl)
 			ctx.vcr.EXPECT().Verifier().Return(mockVerifier).AnyTimes()
-			mockVerifier.EXPECT().VerifyVP(gomock.Any(), true, true, nil).Return(nil, tt.verifyVPError)
+			mockVerifier.EXPECT().VerifyVP(gomock.Any(), gomock.Any(), true, true, nil).Return(nil, tt.verifyVPError)
 
-			err = ctx.manager.removeRevoked()
+			ctx.manager.events = &eventPublisher{queue: make(chan Event, 1)}
+
+			err = ctx.manager.removeRevoked(context.Background())
 			require.NoError(t, err)
 
 			presentations, err := ctx.store.allPresentations(true)
 			require.NoError(t, err)
 			assert.Len(t, presentations, tt.expectedLen)
+			require.Len(t, ctx.manager.events.queue, tt.expectedEvents)
+			if tt.expectedEvents > 0 {
+				event := <-ctx.manager.events.queue
+				assert.Equal(t, EventRevoked, event.Type)
+				assert.Equal(t, vpAlice.ID.String(), event.PresentationID)
+			}
 		})
 	}
 }
@@ -513,8 +526,22 @@ func Test_clientUpdater_updateService(t *testing.T) {
 
 		httpClient.EXPECT().Get(ctx, serviceDefinition.Endpoint, 0).Return(map[string]vc.VerifiablePresentation{"1": vpAlice}, testSeed, 1, nil)
 
+		var changedServiceID string
+		var changedTimestamp int
+		updater.onChange = func(serviceID string, timestamp int) {
+			changedServiceID = serviceID
+			changedTimestamp = timestamp
+		}
+		updater.events = &eventPublisher{queue: make(chan Event, 10)}
+
 		require.NoError(t, updater.updateService(ctx, testDefinitions()[testServiceID]))
 
+		assert.Equal(t, testServiceID, changedServiceID)
+		assert.Equal(t, 1, changedTimestamp)
+		require.Len(t, updater.events.queue, 1)
+		event := <-updater.events.queue
+		assert.Equal(t, EventRegistered, event.Type)
+		assert.Equal(t, 1, event.Timestamp)
 		t.Run("ignores duplicates", func(t *testing.T) {
 			httpClient.EXPECT().Get(ctx, serviceDefinition.Endpoint, 1).Return(map[string]vc.VerifiablePresentation{"1": vpAlice}, testSeed, 1, nil)
 
@@ -531,7 +558,7 @@ func Test_clientUpdater_updateService(t *testing.T) {
 		resetStore(t, storageEngine.GetSQLDatabase())
 		ctrl := gomock.NewController(t)
 		httpClient := client.NewMockHTTPClient(ctrl)
-		updater := newClientUpdater(testDefinitions(), store, func(_ ServiceDefinition, vp vc.VerifiablePresentation) error {
+		updater := newClientUpdater(testDefinitions(), store, func(_ context.Context, _ ServiceDefinition, vp vc.VerifiablePresentation) error {
 			if *vp.ID == *vpAlice.ID {
 				return errors.New("invalid presentation")
 			}
@@ -588,6 +615,144 @@ func Test_clientUpdater_updateService(t *testing.T) {
 		require.NoError(t, err)
 		require.False(t, exists)
 	})
+	t.Run("concurrent updates of a service are serialized", func(t *testing.T) {
+		resetStore(t, storageEngine.GetSQLDatabase())
+		ctrl := gomock.NewController(t)
+		httpClient := client.NewMockHTTPClient(ctrl)
+		updater := newClientUpdater(testDefinitions(), store, alwaysOkVerifier, httpClient)
+		var running atomic.Int32
+		httpClient.EXPECT().Get(ctx, serviceDefinition.Endpoint, gomock.Any()).Times(2).DoAndReturn(func(_ context.Context, _ string, _ int) (map[string]vc.VerifiablePresentation, string, int, error) {
+			assert.Equal(t, int32(1), running.Add(1))
+			time.Sleep(10 * time.Millisecond)
+			running.Add(-1)
+			return map[string]vc.VerifiablePresentation{}, testSeed, 0, nil
+		})
+
+		wg := sync.WaitGroup{}
+		for i := 0; i < 2; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, updater.updateService(ctx, serviceDefinition))
+			}()
+		}
+		wg.Wait()
+	})
+}
+
+func Test_clientUpdater_updateService_failover(t *testing.T) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	resetStore(t, storageEngine.GetSQLDatabase())
+	store, err := newSQLStore(storageEngine.GetSQLDatabase(), testDefinitions())
+	require.NoError(t, err)
+	ctx := context.Background()
+	serviceDefinition := testDefinitions()[testServiceID]
+	serviceDefinition.Endpoints = []string{"http://replica.example.com/usecase"}
+	ctrl := gomock.NewController(t)
+	httpClient := client.NewMockHTTPClient(ctrl)
+	updater := newClientUpdater(testDefinitions(), store, alwaysOkVerifier, httpClient)
+	httpClient.EXPECT().Get(ctx, serviceDefinition.Endpoint, 0).Return(nil, "", 0, client.ErrServiceUnavailable)
+	httpClient.EXPECT().Get(ctx, "http://replica.example.com/usecase", 0).Return(map[string]vc.VerifiablePresentation{"1": vpAlice}, testSeed, 1, nil)
+
+	err = updater.updateService(ctx, serviceDefinition)
+
+	require.NoError(t, err)
+	presentations, err := store.allPresentations(true)
+	require.NoError(t, err)
+	assert.Len(t, presentations, 1)
+	timestamp, err := store.getTimestamp(testServiceID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, timestamp)
+}
+
+func Test_registerOnService(t *testing.T) {
+	ctx := context.Background()
+	serviceDefinition := testDefinitions()[testServiceID]
+	serviceDefinition.Endpoints = []string{"http://replica.example.com/usecase"}
+
+	t.Run("ok", func(t *testing.T) {
+		httpClient := client.NewMockHTTPClient(gomock.NewController(t))
+		httpClient.EXPECT().Register(ctx, serviceDefinition.Endpoint, vpAlice).Return(nil)
+
+		err := registerOnService(ctx, httpClient, serviceDefinition, nil, vpAlice)
+
+		assert.NoError(t, err)
+	})
+	t.Run("leader unavailable", func(t *testing.T) {
+		httpClient := client.NewMockHTTPClient(gomock.NewController(t))
+		httpClient.EXPECT().Register(ctx, serviceDefinition.Endpoint, vpAlice).Return(client.ErrServiceUnavailable)
+		httpClient.EXPECT().Register(ctx, "http://replica.example.com/usecase", vpAlice).Return(nil)
+
+		err := registerOnService(ctx, httpClient, serviceDefinition, nil, vpAlice)
+
+		assert.NoError(t, err)
+	})
+	t.Run("all unavailable", func(t *testing.T) {
+		httpClient := client.NewMockHTTPClient(gomock.NewController(t))
+		httpClient.EXPECT().Register(ctx, gomock.Any(), vpAlice).Return(client.ErrServiceUnavailable).Times(2)
+
+		err := registerOnService(ctx, httpClient, serviceDefinition, nil, vpAlice)
+
+		assert.ErrorIs(t, err, client.ErrServiceUnavailable)
+	})
+	t.Run("replica doesn't register on itself", func(t *testing.T) {
+		httpClient := client.NewMockHTTPClient(gomock.NewController(t))
+		httpClient.EXPECT().Register(ctx, serviceDefinition.Endpoint, vpAlice).Return(client.ErrServiceUnavailable)
+
+		err := registerOnService(ctx, httpClient, serviceDefinition, test.MustParseURL("http://replica.example.com"), vpAlice)
+
+		assert.ErrorIs(t, err, client.ErrServiceUnavailable)
+	})
+	t.Run("rejected by leader", func(t *testing.T) {
+		httpClient := client.NewMockHTTPClient(gomock.NewController(t))
+		httpClient.EXPECT().Register(ctx, serviceDefinition.Endpoint, vpAlice).Return(assert.AnError)
+
+		err := registerOnService(ctx, httpClient, serviceDefinition, nil, vpAlice)
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func Test_getFromService(t *testing.T) {
+	ctx := context.Background()
+	serviceDefinition := testDefinitions()[testServiceID]
+	serviceDefinition.Endpoints = []string{"http://replica.example.com/usecase", serviceDefinition.Endpoint}
+
+	t.Run("all fail", func(t *testing.T) {
+		httpClient := client.NewMockHTTPClient(gomock.NewController(t))
+		httpClient.EXPECT().Get(ctx, serviceDefinition.Endpoint, 5).Return(nil, "", 0, assert.AnError)
+		httpClient.EXPECT().Get(ctx, "http://replica.example.com/usecase", 5).Return(nil, "", 0, errors.New("other"))
+
+		_, _, _, err := getFromService(ctx, httpClient, serviceDefinition, nil, 5)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "other")
+	})
+	t.Run("replica doesn't load from itself", func(t *testing.T) {
+		httpClient := client.NewMockHTTPClient(gomock.NewController(t))
+		httpClient.EXPECT().Get(ctx, serviceDefinition.Endpoint, 5).Return(nil, "", 0, assert.AnError)
+
+		_, _, _, err := getFromService(ctx, httpClient, serviceDefinition, test.MustParseURL("http://replica.example.com"), 5)
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestServiceDefinition_endpoints(t *testing.T) {
+	definition := ServiceDefinition{
+		Endpoint:  "https://leader.example.com/usecase",
+		Endpoints: []string{"https://replica1.example.com/usecase", "https://leader.example.com/usecase", "https://replica2.example.com/usecase"},
+	}
+	t.Run("leader first, without duplicates", func(t *testing.T) {
+		assert.Equal(t, []string{"https://leader.example.com/usecase", "https://replica1.example.com/usecase", "https://replica2.example.com/usecase"}, definition.endpoints(nil))
+	})
+	t.Run("replica excludes itself", func(t *testing.T) {
+		assert.Equal(t, []string{"https://leader.example.com/usecase", "https://replica2.example.com/usecase"}, definition.endpoints(test.MustParseURL("https://replica1.example.com")))
+	})
+	t.Run("leader is kept", func(t *testing.T) {
+		assert.Equal(t, []string{"https://leader.example.com/usecase", "https://replica1.example.com/usecase", "https://replica2.example.com/usecase"}, definition.endpoints(test.MustParseURL("https://leader.example.com")))
+	})
 }
 
 func Test_clientUpdater_update(t *testing.T) {
@@ -622,6 +787,6 @@ func Test_clientUpdater_update(t *testing.T) {
 	})
 }
 
-func alwaysOkVerifier(_ ServiceDefinition, _ vc.VerifiablePresentation) error {
+func alwaysOkVerifier(_ context.Context, _ ServiceDefinition, _ vc.VerifiablePresentation) error {
 	return nil
 }
@@ -38,6 +38,13 @@ func FlagSet() *pflag.FlagSet {
 			"refreshing Verifiable Presentations of local DIDs and loading changes, updating the local copy. "+
 			"It only will actually refresh registrations of local DIDs that about to expire (less than 1/4th of their lifetime left). "+
 			"Specified as Golang duration (e.g. 1m, 1h30m).")
+	flagSet.Bool("discovery.client.subscribe", defs.Client.Subscribe,
+		"If enabled, the client subscribes to changes of the Discovery Services it uses, so new registrations are loaded immediately. "+
+			"If the Discovery Server doesn't support subscriptions, changes are loaded at the refresh interval. "+
+			"Subscriptions are disabled if the refresh interval is 0.")
+	flagSet.StringSlice("discovery.events.webhooks", defs.Events.Webhooks,
+		"URLs to which events about presentations on Discovery Services (registered, updated, retracted, expired and revoked) are POSTed. "+
+			"Events are also published on the DISCOVERY NATS stream.")
 	flagSet.Duration("discovery.client.refresh_interval", 0, "Deprecated, use refresh_interval instead.")
 	_ = flagSet.MarkDeprecated("discovery.client.refresh_interval", "Use refreshinterval instead.")
 	return flagSet
@@ -25,6 +25,13 @@ type Config struct {
 	Server      ServerConfig             `koanf:"server"`
 	Client      ClientConfig             `koanf:"client"`
 	Definitions ServiceDefinitionsConfig `koanf:"definitions"`
+	Events      EventsConfig             `koanf:"events"`
+}
+
+// EventsConfig holds the config for Discovery Service events.
+type EventsConfig struct {
+	// Webhooks specifies the URLs to which Discovery Service events are POSTed.
+	Webhooks []string `koanf:"webhooks"`
 }
 
 // ServiceDefinitionsConfig holds the config for loading Service Definitions.
@@ -42,6 +49,9 @@ type ServerConfig struct {
 type ClientConfig struct {
 	// RefreshInterval specifies how often the client should refresh the Discovery Services.
 	RefreshInterval time.Duration `koanf:"refreshinterval"`
+	// Subscribe specifies whether the client should subscribe to changes of the Discovery Services,
+	// to load new registrations immediately instead of at the next refresh.
+	Subscribe bool `koanf:"subscribe"`
 	// RefreshIntervalOld is deprecated, use RefreshInterval instead. It's there for backwards compatibility; remove in v7.
 	RefreshIntervalOld time.Duration `koanf:"refresh_interval"`
 }
@@ -52,6 +62,7 @@ func DefaultConfig() Config {
 		Server: ServerConfig{},
 		Client: ClientConfig{
 			RefreshInterval: 10 * time.Minute,
+			Subscribe:       true,
 		},
 		Definitions: ServiceDefinitionsConfig{Directory: "./config/discovery"},
 	}
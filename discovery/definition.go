@@ -25,6 +25,8 @@ import (
 	"github.com/nuts-foundation/nuts-node/vcr/pe"
 	v2 "github.com/nuts-foundation/nuts-node/vcr/pe/schema/v2"
 	"github.com/santhosh-tekuri/jsonschema"
+	"net/url"
+	"slices"
 )
 
 //go:embed *.json
@@ -52,7 +54,11 @@ type ServiceDefinition struct {
 	// If empty, all methods are supported.
 	DIDMethods []string `json:"did_methods,omitempty"`
 	// Endpoint is the endpoint where the use case list is served.
+	// If the list is replicated, it's the endpoint of the leader, which accepts registrations.
 	Endpoint string `json:"endpoint"`
+	// Endpoints contains the endpoints of Discovery Servers that replicate the use case list of the leader.
+	// Clients fail over to these endpoints when the leader is unavailable.
+	Endpoints []string `json:"endpoints,omitempty"`
 	// PresentationDefinition specifies the Presentation ServiceDefinition submissions to the list must conform to,
 	// according to the Presentation Exchange specification.
 	PresentationDefinition pe.PresentationDefinition `json:"presentation_definition"`
@@ -72,3 +78,21 @@ func ParseServiceDefinition(data []byte) (*ServiceDefinition, error) {
 	}
 	return &definition, nil
 }
+
+// endpoints returns the endpoint of the leader, followed by the endpoints of the replicas.
+// Replica endpoints hosted by the given (public) URL of the node itself are left out, so a replica doesn't load from or register on itself.
+func (s ServiceDefinition) endpoints(self *url.URL) []string {
+	result := []string{s.Endpoint}
+	for _, endpoint := range s.Endpoints {
+		if slices.Contains(result, endpoint) {
+			continue
+		}
+		if self != nil {
+			if parsed, err := url.Parse(endpoint); err == nil && parsed.Host == self.Host {
+				continue
+			}
+		}
+		result = append(result, endpoint)
+	}
+	return result
+}
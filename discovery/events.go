@@ -0,0 +1,266 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package discovery
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/discovery/log"
+	"github.com/nuts-foundation/nuts-node/events"
+	strictHttp "github.com/nuts-foundation/nuts-node/http/client"
+)
+
+// eventQueueSize specifies how many events can be queued for delivery, before new events are buffered until the queue has room again.
+const eventQueueSize = 1000
+
+// webhookMaxAttempts specifies how many times delivery of an event to a webhook is attempted.
+const webhookMaxAttempts = 5
+
+// webhookRetryInterval is the time to wait before retrying delivery to a webhook. It doubles after every attempt.
+var webhookRetryInterval = time.Second
+
+// EventType is the type of a change to a presentation on a Discovery Service.
+type EventType string
+
+const (
+	// EventRegistered indicates a subject registered a presentation on the Discovery Service.
+	EventRegistered EventType = "registered"
+	// EventUpdated indicates a subject replaced its presentation on the Discovery Service with a new one.
+	EventUpdated EventType = "updated"
+	// EventRetracted indicates a subject retracted its presentation from the Discovery Service.
+	EventRetracted EventType = "retracted"
+	// EventExpired indicates a presentation was removed from the Discovery Service because it expired.
+	EventExpired EventType = "expired"
+	// EventRevoked indicates a presentation was removed from the Discovery Service because a credential in it was revoked.
+	EventRevoked EventType = "revoked"
+	// EventInvalidated indicates a presentation was removed from the Discovery Service because it doesn't match the changed service definition.
+	EventInvalidated EventType = "invalidated"
+)
+
+// Event describes a change to a presentation on a Discovery Service.
+// Events are published on the DISCOVERY NATS stream and POSTed to the configured webhooks.
+type Event struct {
+	Type                EventType `json:"type"`
+	ServiceID           string    `json:"serviceID"`
+	CredentialSubjectID string    `json:"credentialSubjectID"`
+	PresentationID      string    `json:"presentationID"`
+	// Timestamp is the (Lamport) timestamp of the presentation on the Discovery Service.
+	Timestamp int `json:"timestamp"`
+	// Presentation is the registered presentation. It's only set for registered and updated events.
+	Presentation *vc.VerifiablePresentation `json:"presentation,omitempty"`
+}
+
+// newEvent creates an event for the given presentation record.
+func newEvent(eventType EventType, record presentationRecord, presentation *vc.VerifiablePresentation) Event {
+	return Event{
+		Type:                eventType,
+		ServiceID:           record.ServiceID,
+		CredentialSubjectID: record.CredentialSubjectID,
+		PresentationID:      record.PresentationID,
+		Timestamp:           record.LamportTimestamp,
+		Presentation:        presentation,
+	}
+}
+
+// addedEventType returns the type of the event for the given presentation that's about to be added to a Discovery Service,
+// which replaces the presentation of the subject that's currently registered (if any).
+func addedEventType(store *sqlStore, serviceID string, credentialSubjectID string, presentation vc.VerifiablePresentation) (EventType, error) {
+	if presentation.IsType(retractionPresentationType) {
+		return EventRetracted, nil
+	}
+	previous, err := store.findPresentation(serviceID, credentialSubjectID)
+	if err != nil {
+		return "", err
+	}
+	if previous == nil {
+		return EventRegistered, nil
+	}
+	// a registration after a retraction is a new registration
+	previousPresentation, err := vc.ParseVerifiablePresentation(previous.PresentationRaw)
+	if err != nil || previousPresentation.IsType(retractionPresentationType) {
+		return EventRegistered, nil
+	}
+	return EventUpdated, nil
+}
+
+// eventPublisher delivers Discovery Service events to the NATS stream and webhooks.
+// Events are delivered in the background, so publishing never blocks registration or loading presentations.
+type eventPublisher struct {
+	eventManager events.Event
+	webhooks     []string
+	httpClient   core.HTTPRequestDoer
+	queue        chan Event
+	streamReady  bool
+	// overflow holds the events published while the queue was full, in order. They're moved to the queue when it has room again.
+	overflow    []Event
+	overflowMux sync.Mutex
+}
+
+func newEventPublisher(eventManager events.Event, webhooks []string, timeout time.Duration) *eventPublisher {
+	return &eventPublisher{
+		eventManager: eventManager,
+		webhooks:     webhooks,
+		httpClient:   strictHttp.New(timeout),
+		queue:        make(chan Event, eventQueueSize),
+	}
+}
+
+// publish queues the event for delivery. If the queue is full, the event is buffered until the queue has room again.
+// It does nothing if the publisher is nil.
+func (p *eventPublisher) publish(event Event) {
+	if p == nil {
+		return
+	}
+	p.overflowMux.Lock()
+	defer p.overflowMux.Unlock()
+	if len(p.overflow) == 0 {
+		select {
+		case p.queue <- event:
+			return
+		default:
+			log.Logger().Warnf("Discovery Service event queue is full, buffering events until delivery catches up (type=%s, service=%s, presentation=%s)", event.Type, event.ServiceID, event.PresentationID)
+		}
+	}
+	// keep events in order: once events are buffered, new events are buffered as well
+	p.overflow = append(p.overflow, event)
+}
+
+// drainOverflow moves buffered events to the queue, as long as it has room.
+func (p *eventPublisher) drainOverflow() {
+	p.overflowMux.Lock()
+	defer p.overflowMux.Unlock()
+	for len(p.overflow) > 0 {
+		select {
+		case p.queue <- p.overflow[0]:
+			p.overflow = p.overflow[1:]
+		default:
+			return
+		}
+	}
+	p.overflow = nil
+}
+
+// publishAdded publishes the event for a presentation that was added to a Discovery Service.
+func (p *eventPublisher) publishAdded(eventType EventType, record presentationRecord, presentation vc.VerifiablePresentation) {
+	if eventType == EventRetracted {
+		p.publish(newEvent(eventType, record, nil))
+	} else {
+		p.publish(newEvent(eventType, record, &presentation))
+	}
+}
+
+// run delivers queued events until the given context is done.
+func (p *eventPublisher) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-p.queue:
+			p.deliver(ctx, event)
+			p.drainOverflow()
+		}
+	}
+}
+
+func (p *eventPublisher) deliver(ctx context.Context, event Event) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		log.Logger().WithError(err).Errorf("Failed to marshal Discovery Service event (type=%s, service=%s)", event.Type, event.ServiceID)
+		return
+	}
+	if p.eventManager != nil {
+		if err = p.publishOnStream(ctx, data); err != nil {
+			log.Logger().WithError(err).Errorf("Failed to publish Discovery Service event on NATS (type=%s, service=%s)", event.Type, event.ServiceID)
+		}
+	}
+	for _, webhook := range p.webhooks {
+		p.deliverToWebhook(ctx, webhook, event, data)
+	}
+}
+
+// deliverToWebhook POSTs the event to the webhook, retrying with increasing intervals if it fails,
+// until webhookMaxAttempts is reached or the given context is done.
+func (p *eventPublisher) deliverToWebhook(ctx context.Context, webhook string, event Event, data []byte) {
+	interval := webhookRetryInterval
+	for attempt := 1; ; attempt++ {
+		err := p.callWebhook(ctx, webhook, data)
+		if err == nil {
+			return
+		}
+		if attempt == webhookMaxAttempts {
+			log.Logger().WithError(err).Errorf("Failed to deliver Discovery Service event to webhook, giving up after %d attempts (type=%s, service=%s, url=%s)", attempt, event.Type, event.ServiceID, webhook)
+			return
+		}
+		log.Logger().WithError(err).Warnf("Failed to deliver Discovery Service event to webhook, retrying in %s (type=%s, service=%s, url=%s)", interval, event.Type, event.ServiceID, webhook)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+		interval *= 2
+	}
+}
+
+func (p *eventPublisher) publishOnStream(ctx context.Context, data []byte) error {
+	_, js, err := p.eventManager.Pool().Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	if !p.streamReady {
+		// streams are created when subscribing, but there might not be a subscriber yet
+		config := p.eventManager.GetStream(events.DiscoveryStream).Config()
+		_, err = js.StreamInfo(config.Name)
+		if errors.Is(err, nats.ErrStreamNotFound) {
+			_, err = js.AddStream(config)
+		}
+		if err != nil {
+			return err
+		}
+		p.streamReady = true
+	}
+	_, err = js.Publish(events.DiscoverySubject, data, nats.Context(ctx))
+	return err
+}
+
+func (p *eventPublisher) callWebhook(ctx context.Context, webhook string, data []byte) error {
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpResponse, err := p.httpClient.Do(httpRequest)
+	if err != nil {
+		return err
+	}
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode < 200 || httpResponse.StatusCode > 299 {
+		return fmt.Errorf("non-OK response from webhook: %d", httpResponse.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/events"
+	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_addedEventType(t *testing.T) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	retractionVP := createPresentationCustom(aliceDID, func(claims map[string]interface{}, vp *vc.VerifiablePresentation) {
+		vp.Type = append(vp.Type, retractionPresentationType)
+		claims["retract_jti"] = vpAlice.ID.String()
+	})
+	vpAlice2 := createPresentation(aliceDID, vcAlice)
+
+	t.Run("registered", func(t *testing.T) {
+		store := setupStore(t, storageEngine.GetSQLDatabase())
+
+		eventType, err := addedEventType(store, testServiceID, aliceDID.String(), vpAlice)
+
+		require.NoError(t, err)
+		assert.Equal(t, EventRegistered, eventType)
+	})
+	t.Run("updated", func(t *testing.T) {
+		store := setupStore(t, storageEngine.GetSQLDatabase())
+		_, err := store.add(testServiceID, vpAlice, testSeed, 0)
+		require.NoError(t, err)
+
+		eventType, err := addedEventType(store, testServiceID, aliceDID.String(), vpAlice2)
+
+		require.NoError(t, err)
+		assert.Equal(t, EventUpdated, eventType)
+	})
+	t.Run("retracted", func(t *testing.T) {
+		store := setupStore(t, storageEngine.GetSQLDatabase())
+		_, err := store.add(testServiceID, vpAlice, testSeed, 0)
+		require.NoError(t, err)
+
+		eventType, err := addedEventType(store, testServiceID, aliceDID.String(), retractionVP)
+
+		require.NoError(t, err)
+		assert.Equal(t, EventRetracted, eventType)
+	})
+	t.Run("registered after retraction", func(t *testing.T) {
+		store := setupStore(t, storageEngine.GetSQLDatabase())
+		_, err := store.add(testServiceID, retractionVP, testSeed, 0)
+		require.NoError(t, err)
+
+		eventType, err := addedEventType(store, testServiceID, aliceDID.String(), vpAlice2)
+
+		require.NoError(t, err)
+		assert.Equal(t, EventRegistered, eventType)
+	})
+	t.Run("other service", func(t *testing.T) {
+		store := setupStore(t, storageEngine.GetSQLDatabase())
+		_, err := store.add(testServiceID, vpAlice, testSeed, 0)
+		require.NoError(t, err)
+
+		eventType, err := addedEventType(store, "other", aliceDID.String(), vpAlice2)
+
+		require.NoError(t, err)
+		assert.Equal(t, EventRegistered, eventType)
+	})
+}
+
+func Test_eventPublisher(t *testing.T) {
+	event := Event{
+		Type:                EventRegistered,
+		ServiceID:           testServiceID,
+		CredentialSubjectID: aliceDID.String(),
+		PresentationID:      vpAlice.ID.String(),
+		Timestamp:           1,
+		Presentation:        &vpAlice,
+	}
+
+	t.Run("webhook", func(t *testing.T) {
+		received := make(chan Event, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			assert.Equal(t, http.MethodPost, request.Method)
+			assert.Equal(t, "application/json", request.Header.Get("Content-Type"))
+			data, _ := io.ReadAll(request.Body)
+			var actual Event
+			assert.NoError(t, json.Unmarshal(data, &actual))
+			received <- actual
+			writer.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+		publisher := newEventPublisher(nil, []string{server.URL}, time.Second)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go publisher.run(ctx)
+
+		publisher.publish(event)
+
+		select {
+		case actual := <-received:
+			assert.Equal(t, EventRegistered, actual.Type)
+			assert.Equal(t, testServiceID, actual.ServiceID)
+			assert.Equal(t, aliceDID.String(), actual.CredentialSubjectID)
+			assert.Equal(t, vpAlice.ID.String(), actual.PresentationID)
+			assert.Equal(t, 1, actual.Timestamp)
+			require.NotNil(t, actual.Presentation)
+			assert.Equal(t, vpAlice.Raw(), actual.Presentation.Raw())
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for webhook")
+		}
+	})
+	t.Run("webhook returns error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			writer.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+		publisher := newEventPublisher(nil, []string{server.URL}, time.Second)
+
+		err := publisher.callWebhook(context.Background(), server.URL, []byte("{}"))
+
+		assert.EqualError(t, err, "non-OK response from webhook: 500")
+	})
+	t.Run("NATS", func(t *testing.T) {
+		eventManager := events.NewTestManager(t)
+		publisher := newEventPublisher(eventManager, nil, time.Second)
+
+		publisher.deliver(context.Background(), event)
+
+		_, js, err := eventManager.Pool().Acquire(context.Background())
+		require.NoError(t, err)
+		msg, err := js.GetLastMsg(events.DiscoveryStream, events.DiscoverySubject)
+		require.NoError(t, err)
+		var actual Event
+		require.NoError(t, json.Unmarshal(msg.Data, &actual))
+		assert.Equal(t, EventRegistered, actual.Type)
+		assert.Equal(t, vpAlice.ID.String(), actual.PresentationID)
+	})
+	t.Run("webhook is retried", func(t *testing.T) {
+		defer func(old time.Duration) {
+			webhookRetryInterval = old
+		}(webhookRetryInterval)
+		webhookRetryInterval = time.Millisecond
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			if calls.Add(1) < 3 {
+				writer.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			writer.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+		publisher := newEventPublisher(nil, []string{server.URL}, time.Second)
+
+		publisher.deliver(context.Background(), event)
+
+		assert.Equal(t, int32(3), calls.Load())
+	})
+	t.Run("webhook retries are limited", func(t *testing.T) {
+		defer func(old time.Duration) {
+			webhookRetryInterval = old
+		}(webhookRetryInterval)
+		webhookRetryInterval = time.Millisecond
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			calls.Add(1)
+			writer.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+		publisher := newEventPublisher(nil, []string{server.URL}, time.Second)
+
+		publisher.deliver(context.Background(), event)
+
+		assert.Equal(t, int32(webhookMaxAttempts), calls.Load())
+	})
+	t.Run("queue full", func(t *testing.T) {
+		publisher := &eventPublisher{queue: make(chan Event, 1)}
+		second := event
+		second.Timestamp = 2
+		third := event
+		third.Timestamp = 3
+
+		publisher.publish(event)
+		publisher.publish(second)
+		publisher.publish(third)
+
+		assert.Len(t, publisher.queue, 1)
+		assert.Len(t, publisher.overflow, 2)
+		// buffered events are queued in order when the queue has room again
+		for _, expected := range []int{1, 2, 3} {
+			assert.Equal(t, expected, (<-publisher.queue).Timestamp)
+			publisher.drainOverflow()
+		}
+		assert.Empty(t, publisher.overflow)
+	})
+	t.Run("nil publisher", func(t *testing.T) {
+		var publisher *eventPublisher
+
+		publisher.publish(event)
+	})
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/discovery/log"
+	"github.com/nuts-foundation/nuts-node/vdr/didsubject"
+)
+
+var _ didsubject.SubjectDataExporter = &Module{}
+
+// serviceActivation is the exported form of a subject's activation on a Discovery Service.
+type serviceActivation struct {
+	ServiceID  string                 `json:"serviceID"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+}
+
+// ExportSubjectData exports the Discovery Services the subject is activated on, so the activations can be restored on another node.
+func (m *Module) ExportSubjectData(_ context.Context, subject string, _ []did.DID) ([]byte, error) {
+	registrations, err := m.store.getSubjectRegistrations(subject)
+	if err != nil {
+		return nil, err
+	}
+	if len(registrations) == 0 {
+		return nil, nil
+	}
+	activations := make([]serviceActivation, len(registrations))
+	for i, registration := range registrations {
+		activations[i] = serviceActivation{
+			ServiceID:  registration.ServiceID,
+			Parameters: registration.Parameters,
+		}
+	}
+	return json.Marshal(activations)
+}
+
+// ImportSubjectData activates the subject on the exported Discovery Services.
+// The subject is registered on the next refresh of the registrations, so importing doesn't depend on the Discovery Server being available.
+// Activations of services unknown to this node are skipped.
+func (m *Module) ImportSubjectData(_ context.Context, subject string, data []byte) error {
+	var activations []serviceActivation
+	if err := json.Unmarshal(data, &activations); err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, activation := range activations {
+		if _, exists, _ := m.definition(activation.ServiceID); !exists {
+			log.Logger().WithField(core.LogFieldDIDSubject, subject).
+				Warnf("Subject was activated on unknown Discovery Service, not activating it (service=%s)", activation.ServiceID)
+			continue
+		}
+		parameters := activation.Parameters
+		if parameters == nil {
+			parameters = make(map[string]interface{})
+		}
+		// the authorization server of the subject moved to this node, unless it was set to a custom URL
+		if authServerURL, ok := parameters[authServerURLField].(string); !ok || strings.HasSuffix(authServerURL, "/oauth2/"+subject) {
+			parameters[authServerURLField] = m.publicURL.JoinPath("/oauth2/", subject).String()
+		}
+		if err := m.store.updatePresentationRefreshTime(activation.ServiceID, subject, parameters, &now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RemoveSubjectData deactivates the subject on the Discovery Services it was activated on by ImportSubjectData.
+func (m *Module) RemoveSubjectData(_ context.Context, subject string, data []byte) error {
+	var activations []serviceActivation
+	if err := json.Unmarshal(data, &activations); err != nil {
+		return err
+	}
+	for _, activation := range activations {
+		if err := m.store.updatePresentationRefreshTime(activation.ServiceID, subject, nil, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
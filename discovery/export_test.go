@@ -0,0 +1,122 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModule_ExportSubjectData(t *testing.T) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	t.Run("ok", func(t *testing.T) {
+		m, _ := setupModule(t, storageEngine, func(module *Module) {
+			module.config.Client.RefreshInterval = 0
+		})
+		next := time.Now()
+		require.NoError(t, m.store.updatePresentationRefreshTime(testServiceID, aliceSubject, map[string]interface{}{"test": "value"}, &next))
+
+		data, err := m.ExportSubjectData(context.Background(), aliceSubject, nil)
+
+		require.NoError(t, err)
+		var activations []serviceActivation
+		require.NoError(t, json.Unmarshal(data, &activations))
+		require.Len(t, activations, 1)
+		assert.Equal(t, testServiceID, activations[0].ServiceID)
+		assert.Equal(t, "value", activations[0].Parameters["test"])
+	})
+	t.Run("not activated", func(t *testing.T) {
+		m, _ := setupModule(t, storageEngine)
+
+		data, err := m.ExportSubjectData(context.Background(), aliceSubject, nil)
+
+		require.NoError(t, err)
+		assert.Nil(t, data)
+	})
+}
+
+func TestModule_ImportSubjectData(t *testing.T) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	t.Run("ok", func(t *testing.T) {
+		m, _ := setupModule(t, storageEngine, func(module *Module) {
+			module.config.Client.RefreshInterval = 0
+		})
+		data, _ := json.Marshal([]serviceActivation{
+			{ServiceID: testServiceID, Parameters: map[string]interface{}{"test": "value", authServerURLField: "https://other.example.com/oauth2/alice"}},
+			{ServiceID: "unknown"},
+		})
+
+		err := m.ImportSubjectData(context.Background(), aliceSubject, data)
+
+		require.NoError(t, err)
+		registrations, err := m.store.getSubjectRegistrations(aliceSubject)
+		require.NoError(t, err)
+		require.Len(t, registrations, 1)
+		assert.Equal(t, testServiceID, registrations[0].ServiceID)
+		assert.Equal(t, "value", registrations[0].Parameters["test"])
+		assert.Equal(t, m.publicURL.JoinPath("/oauth2/alice").String(), registrations[0].Parameters[authServerURLField])
+	})
+	t.Run("custom authServerURL is kept", func(t *testing.T) {
+		m, _ := setupModule(t, storageEngine, func(module *Module) {
+			module.config.Client.RefreshInterval = 0
+		})
+		data, _ := json.Marshal([]serviceActivation{
+			{ServiceID: testServiceID, Parameters: map[string]interface{}{authServerURLField: "https://custom.example.com/as"}},
+		})
+
+		err := m.ImportSubjectData(context.Background(), aliceSubject, data)
+
+		require.NoError(t, err)
+		registrations, err := m.store.getSubjectRegistrations(aliceSubject)
+		require.NoError(t, err)
+		require.Len(t, registrations, 1)
+		assert.Equal(t, "https://custom.example.com/as", registrations[0].Parameters[authServerURLField])
+	})
+	t.Run("invalid data", func(t *testing.T) {
+		m, _ := setupModule(t, storageEngine)
+
+		err := m.ImportSubjectData(context.Background(), aliceSubject, []byte("{"))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestModule_RemoveSubjectData(t *testing.T) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	m, _ := setupModule(t, storageEngine, func(module *Module) {
+		module.config.Client.RefreshInterval = 0
+	})
+	data, _ := json.Marshal([]serviceActivation{{ServiceID: testServiceID}})
+	require.NoError(t, m.ImportSubjectData(context.Background(), aliceSubject, data))
+
+	err := m.RemoveSubjectData(context.Background(), aliceSubject, data)
+
+	require.NoError(t, err)
+	registrations, err := m.store.getSubjectRegistrations(aliceSubject)
+	require.NoError(t, err)
+	assert.Empty(t, registrations)
+}
@@ -27,6 +27,9 @@ import (
 // ErrServiceNotFound is returned when a service (ID) is not found in the discovery service.
 var ErrServiceNotFound = errors.New("discovery service not found")
 
+// ErrReadOnlyServiceDefinition is returned when a service definition that is loaded from the definitions directory is changed at runtime.
+var ErrReadOnlyServiceDefinition = errors.New("service definition is loaded from the definitions directory and can't be changed at runtime")
+
 // ErrPresentationAlreadyExists is returned when a presentation is added to the discovery service,
 // but a presentation with this ID already exists.
 var ErrPresentationAlreadyExists = errors.New("presentation already exists")
@@ -34,6 +37,10 @@ var ErrPresentationAlreadyExists = errors.New("presentation already exists")
 // ErrPresentationRegistrationFailed indicates registration of a presentation on a remote Discovery Service failed.
 var ErrPresentationRegistrationFailed = errors.New("registration of Verifiable Presentation on remote Discovery Service failed")
 
+// ErrRegistrationQueued is returned by a replica of a Discovery Service when the leader is unavailable.
+// The presentation was accepted, and is registered on the leader when it becomes available again.
+var ErrRegistrationQueued = errors.New("leader of Discovery Service is unavailable, presentation is queued for registration")
+
 // ErrDIDMethodsNotSupported indicates that a received VP does not match the supported DID Methods of the service.
 var ErrDIDMethodsNotSupported = errors.New("DID methods not supported")
 
@@ -49,18 +56,27 @@ type Server interface {
 	// Register registers a presentation on the given Discovery Service.
 	// If the presentation is not valid, or it does not conform to the Service ServiceDefinition, it returns an error.
 	// If the node is not configured as server for the given serviceID, the call will be forwarded to the configured server.
+	// If the node is a replica of the service and the leader is unavailable, the presentation is queued and ErrRegistrationQueued is returned.
 	Register(context context.Context, serviceID string, presentation vc.VerifiablePresentation) error
 	// Get retrieves the presentations for the given service, starting from the given timestamp.
 	// If the node is not configured as server for the given serviceID, the call will be forwarded to the configured server.
 	Get(context context.Context, serviceID string, startAfter int) (map[string]vc.VerifiablePresentation, string, int, error)
+	// Subscribe subscribes to changes of the given Discovery Service.
+	// The returned channel receives the current timestamp of the service, and the new timestamp each time a presentation is registered.
+	// Unconsumed timestamps are replaced by newer ones. The channel is closed when the given context is done.
+	// Subscriptions aren't forwarded: if the node is not configured as server for the given serviceID, it returns ErrServiceNotFound.
+	// It returns ErrTooManySubscribers if the maximum number of subscribers, in total or for the client's IP address (see RemoteIPContextKey), is reached.
+	Subscribe(ctx context.Context, serviceID string) (<-chan int, error)
 }
 
 // Client defines the API for Discovery Clients.
 type Client interface {
 	// Search searches for presentations which credential(s) match the given query.
-	// Query parameters are formatted as simple JSON paths, e.g. "issuer" or "credentialSubject.name".
-	// It returns an ErrServiceNotFound if the service invalid/unknown.
-	Search(serviceID string, query map[string]string) ([]SearchResult, error)
+	// The query's properties and filter use simple JSON paths, e.g. "issuer" or "credentialSubject.name". See ParseFilter for the expression syntax.
+	// Results are sorted and paginated as specified by the query.
+	// It returns an ErrServiceNotFound if the service invalid/unknown,
+	// or an ErrQueryTooBroad if the query selects too many presentations to evaluate its filter or sort on.
+	Search(serviceID string, query Query) ([]SearchResult, error)
 
 	// ActivateServiceForSubject causes a subject to be registered for a Discovery Service.
 	// Registration of all DIDs of the subject will be attempted immediately, and automatically refreshed.
@@ -78,6 +94,17 @@ type Client interface {
 	// Services returns the list of services that are registered on this client.
 	Services() []ServiceDefinition
 
+	// PutServiceDefinition adds a service definition to the running node, or updates a service definition that was added through this function.
+	// The definition is persisted, so it is loaded again when the node restarts. If server is true, the node acts as Discovery Server for the service.
+	// If the Presentation Definition or DID methods of an existing definition change, the presentations on the service are validated again.
+	// It returns an ErrReadOnlyServiceDefinition if the definition is loaded from the definitions directory.
+	PutServiceDefinition(ctx context.Context, definition ServiceDefinition, server bool) error
+
+	// RetireServiceDefinition removes a service definition that was added through PutServiceDefinition.
+	// The local copy of the service and the activations of subjects on the service are removed as well.
+	// It returns an ErrServiceNotFound if the service is unknown, or an ErrReadOnlyServiceDefinition if the definition is loaded from the definitions directory.
+	RetireServiceDefinition(ctx context.Context, serviceID string) error
+
 	// GetServiceActivation returns the activation status of a subject on a Discovery Service.
 	// The boolean indicates whether the subject is activated on the Discovery Service (ActivateServiceForSubject() has been called).
 	// It also returns the Verifiable Presentations for all DIDs of the subject that are registered on the Discovery Service, if any.
@@ -99,11 +126,14 @@ type SearchResult struct {
 	Parameters map[string]interface{} `json:"registrationParameters"`
 }
 
-type presentationVerifier func(definition ServiceDefinition, presentation vc.VerifiablePresentation) error
+type presentationVerifier func(ctx context.Context, definition ServiceDefinition, presentation vc.VerifiablePresentation) error
 
 // XForwardedHostContextKey is the context key for the X-Forwarded-Host header.
 type XForwardedHostContextKey struct{}
 
+// RemoteIPContextKey is the context key for the IP address of the client that made the request.
+type RemoteIPContextKey struct{}
+
 // RegistrationRefreshError is returned from GetServiceRefreshError.
 type RegistrationRefreshError struct {
 	Underlying error
@@ -72,6 +72,21 @@ func (mr *MockServerMockRecorder) Register(arg0, serviceID, presentation any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockServer)(nil).Register), arg0, serviceID, presentation)
 }
 
+// Subscribe mocks base method.
+func (m *MockServer) Subscribe(ctx context.Context, serviceID string) (<-chan int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", ctx, serviceID)
+	ret0, _ := ret[0].(<-chan int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockServerMockRecorder) Subscribe(ctx, serviceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockServer)(nil).Subscribe), ctx, serviceID)
+}
+
 // MockClient is a mock of Client interface.
 type MockClient struct {
 	ctrl     *gomock.Controller
@@ -140,8 +155,36 @@ func (mr *MockClientMockRecorder) GetServiceActivation(ctx, serviceID, subjectID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceActivation", reflect.TypeOf((*MockClient)(nil).GetServiceActivation), ctx, serviceID, subjectID)
 }
 
+// PutServiceDefinition mocks base method.
+func (m *MockClient) PutServiceDefinition(ctx context.Context, definition ServiceDefinition, server bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutServiceDefinition", ctx, definition, server)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutServiceDefinition indicates an expected call of PutServiceDefinition.
+func (mr *MockClientMockRecorder) PutServiceDefinition(ctx, definition, server any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutServiceDefinition", reflect.TypeOf((*MockClient)(nil).PutServiceDefinition), ctx, definition, server)
+}
+
+// RetireServiceDefinition mocks base method.
+func (m *MockClient) RetireServiceDefinition(ctx context.Context, serviceID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetireServiceDefinition", ctx, serviceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetireServiceDefinition indicates an expected call of RetireServiceDefinition.
+func (mr *MockClientMockRecorder) RetireServiceDefinition(ctx, serviceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetireServiceDefinition", reflect.TypeOf((*MockClient)(nil).RetireServiceDefinition), ctx, serviceID)
+}
+
 // Search mocks base method.
-func (m *MockClient) Search(serviceID string, query map[string]string) ([]SearchResult, error) {
+func (m *MockClient) Search(serviceID string, query Query) ([]SearchResult, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Search", serviceID, query)
 	ret0, _ := ret[0].([]SearchResult)
@@ -28,14 +28,17 @@ import (
 	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/discovery/api/server/client"
 	"github.com/nuts-foundation/nuts-node/discovery/log"
+	"github.com/nuts-foundation/nuts-node/events"
 	"github.com/nuts-foundation/nuts-node/storage"
 	"github.com/nuts-foundation/nuts-node/vcr"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
 	"github.com/nuts-foundation/nuts-node/vdr/didsubject"
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+	"maps"
 	"net/url"
 	"os"
 	"path"
+	"reflect"
 	"slices"
 	"strings"
 	"sync"
@@ -44,6 +47,9 @@ import (
 
 const ModuleName = "Discovery"
 
+// subscriptionRetryInterval specifies how long to wait before resubscribing to a Discovery Service after the subscription ended or failed.
+var subscriptionRetryInterval = time.Minute
+
 // ErrInvalidPresentation is returned when a client tries to register a Verifiable Presentation that is invalid.
 var ErrInvalidPresentation = errors.New("presentation is invalid for registration")
 
@@ -68,15 +74,17 @@ var _ Client = &Module{}
 var retractionPresentationType = ssi.MustParseURI("RetractedVerifiablePresentation")
 
 // New creates a new Module.
-func New(storageInstance storage.Engine, vcrInstance vcr.VCR, subjectManager didsubject.Manager, didResolver resolver.DIDResolver) *Module {
+func New(storageInstance storage.Engine, vcrInstance vcr.VCR, subjectManager didsubject.Manager, didResolver resolver.DIDResolver, eventManager events.Event) *Module {
 	m := &Module{
 		storageInstance: storageInstance,
 		vcrInstance:     vcrInstance,
 		subjectManager:  subjectManager,
 		didResolver:     didResolver,
+		eventManager:    eventManager,
 	}
 	m.ctx, m.cancel = context.WithCancel(context.Background())
 	m.routines = new(sync.WaitGroup)
+	m.notifier = newServiceNotifier()
 	return m
 }
 
@@ -93,10 +101,21 @@ type Module struct {
 	subjectManager      didsubject.Manager
 	didResolver         resolver.DIDResolver
 	clientUpdater       *clientUpdater
+	notifier            *serviceNotifier
 	ctx                 context.Context
 	cancel              context.CancelFunc
 	routines            *sync.WaitGroup
 	publicURL           *url.URL
+	// runtimeDefinitions contains the IDs of the definitions that were added at runtime, which can be updated or retired.
+	runtimeDefinitions map[string]bool
+	// definitionsMux guards the definition maps, which are replaced (not modified) when a definition is added, updated or retired.
+	definitionsMux sync.RWMutex
+	// subscriptions contains the cancel functions of the subscriptions to Discovery Services, guarded by definitionsMux.
+	subscriptions map[string]context.CancelFunc
+	eventManager  events.Event
+	events        *eventPublisher
+	// revalidateMux makes sure presentations are revalidated against one service definition at a time.
+	revalidateMux sync.Mutex
 }
 
 func (m *Module) Configure(serverConfig core.ServerConfig) error {
@@ -107,6 +126,7 @@ func (m *Module) Configure(serverConfig core.ServerConfig) error {
 	}
 
 	m.httpClient = client.New(serverConfig.HTTPClient.Timeout)
+	m.events = newEventPublisher(m.eventManager, m.config.Events.Webhooks, serverConfig.HTTPClient.Timeout)
 
 	return m.loadDefinitions()
 
@@ -151,8 +171,28 @@ func (m *Module) Start() error {
 	if err != nil {
 		return err
 	}
+	if err = m.loadRuntimeDefinitions(); err != nil {
+		return fmt.Errorf("failed to load discovery definitions from database: %w", err)
+	}
 	m.clientUpdater = newClientUpdater(m.allDefinitions, m.store, m.verifyRegistration, m.httpClient)
 	m.registrationManager = newRegistrationManager(m.allDefinitions, m.store, m.httpClient, m.vcrInstance, m.subjectManager, m.didResolver, m.verifyRegistration)
+	// replicas notify their subscribers of presentations replicated from the leader
+	m.clientUpdater.onChange = m.notifier.notify
+	m.clientUpdater.publicURL = m.publicURL
+	m.registrationManager.publicURL = m.publicURL
+	m.clientUpdater.events = m.events
+	m.registrationManager.events = m.events
+	m.store.onExpired = func(records []presentationRecord) {
+		for _, record := range records {
+			m.events.publish(newEvent(EventExpired, record, nil))
+		}
+	}
+	publisher := m.events
+	m.routines.Add(1)
+	go func() {
+		defer m.routines.Done()
+		publisher.run(m.ctx)
+	}()
 	if m.config.Client.RefreshInterval > 0 || m.config.Client.RefreshIntervalOld > 0 {
 		m.routines.Add(1)
 		go func() {
@@ -160,9 +200,91 @@ func (m *Module) Start() error {
 			m.update()
 		}()
 	}
+	m.subscriptions = make(map[string]context.CancelFunc)
+	for _, definition := range m.allDefinitions {
+		m.startSubscription(definition)
+	}
 	return nil
 }
 
+// loadRuntimeDefinitions adds the definitions that were added at runtime to the definitions loaded from the definitions directory.
+// Definitions from the definitions directory take precedence.
+func (m *Module) loadRuntimeDefinitions() error {
+	runtimeDefinitions, runtimeServerDefinitions, err := m.store.getDefinitions()
+	if err != nil {
+		return err
+	}
+	allDefinitions := make(map[string]ServiceDefinition, len(m.allDefinitions)+len(runtimeDefinitions))
+	serverDefinitions := make(map[string]ServiceDefinition, len(m.serverDefinitions)+len(runtimeServerDefinitions))
+	maps.Copy(allDefinitions, m.allDefinitions)
+	maps.Copy(serverDefinitions, m.serverDefinitions)
+	m.runtimeDefinitions = make(map[string]bool)
+	for serviceID, definition := range runtimeDefinitions {
+		if _, exists := allDefinitions[serviceID]; exists {
+			log.Logger().Warnf("Ignoring service definition added at runtime, since it's also loaded from the definitions directory (service=%s)", serviceID)
+			continue
+		}
+		allDefinitions[serviceID] = definition
+		if _, isServer := runtimeServerDefinitions[serviceID]; isServer {
+			serverDefinitions[serviceID] = definition
+		}
+		m.runtimeDefinitions[serviceID] = true
+	}
+	m.allDefinitions = allDefinitions
+	m.serverDefinitions = serverDefinitions
+	return nil
+}
+
+// startSubscription subscribes to changes of the given Discovery Service, if the node is a client or replica of the service and subscriptions are enabled.
+// The caller must hold definitionsMux or make sure there are no concurrent changes to the definitions.
+func (m *Module) startSubscription(definition ServiceDefinition) {
+	if !m.config.Client.Subscribe || (m.config.Client.RefreshInterval <= 0 && m.config.Client.RefreshIntervalOld <= 0) {
+		return
+	}
+	if _, isServer := m.serverDefinitions[definition.ID]; isServer && !m.isReplica(definition) {
+		// local copy is updated on registration
+		return
+	}
+	ctx, cancel := context.WithCancel(m.ctx)
+	m.subscriptions[definition.ID] = cancel
+	m.routines.Add(1)
+	go func() {
+		defer m.routines.Done()
+		m.subscribe(ctx, definition)
+	}()
+}
+
+// stopSubscription stops the subscription to changes of the given Discovery Service, if any.
+// The caller must hold definitionsMux.
+func (m *Module) stopSubscription(serviceID string) {
+	if cancel, exists := m.subscriptions[serviceID]; exists {
+		cancel()
+		delete(m.subscriptions, serviceID)
+	}
+}
+
+// isReplica returns whether the node replicates the given service from another Discovery Server (the leader), if it acts as Discovery Server for it.
+// That's the case when the service has replicas and its endpoint is hosted by another node.
+func (m *Module) isReplica(definition ServiceDefinition) bool {
+	if len(definition.Endpoints) == 0 {
+		return false
+	}
+	endpoint, err := url.Parse(definition.Endpoint)
+	if err != nil {
+		return false
+	}
+	return endpoint.Host != m.publicURL.Host
+}
+
+// definition returns the definition of the given service, whether it exists and whether the node acts as Discovery Server for it.
+func (m *Module) definition(serviceID string) (ServiceDefinition, bool, bool) {
+	m.definitionsMux.RLock()
+	defer m.definitionsMux.RUnlock()
+	definition, exists := m.allDefinitions[serviceID]
+	_, isServer := m.serverDefinitions[serviceID]
+	return definition, exists, isServer
+}
+
 func (m *Module) Shutdown() error {
 	m.cancel()
 	m.routines.Wait()
@@ -181,53 +303,110 @@ func (m *Module) Config() interface{} {
 // See interface.go for more information.
 func (m *Module) Register(context context.Context, serviceID string, presentation vc.VerifiablePresentation) error {
 	// First, simple sanity checks
-	_, isServer := m.serverDefinitions[serviceID]
+	definition, exists, isServer := m.definition(serviceID)
 	if !isServer {
 		// forward to configured server
-		service, exists := m.allDefinitions[serviceID]
 		if !exists {
 			return ErrServiceNotFound
 		}
 
 		// check If X-Forwarded-Host header is set, if set it must not be the same as service.Endpoint
-		if cycleDetected(context, service) {
+		if cycleDetected(context, definition) {
 			return errCyclicForwardingDetected
 		}
 
 		// forward to configured server
 		log.Logger().Infof("Forwarding Register request to configured server (service=%s)", serviceID)
-		return m.httpClient.Register(context, service.Endpoint, presentation)
+		return registerOnService(context, m.httpClient, definition, m.publicURL, presentation)
 	}
-	definition := m.allDefinitions[serviceID]
-	if err := m.verifyRegistration(definition, presentation); err != nil {
+	if err := m.verifyRegistration(context, definition, presentation); err != nil {
 		return err
 	}
+	if m.isReplica(definition) {
+		return m.registerOnLeader(context, definition, presentation)
+	}
 
 	// Check if the presentation already exists
 	credentialSubjectID, err := credential.PresentationSigner(presentation)
 	if err != nil {
 		return err
 	}
-	exists, err := m.store.exists(definition.ID, credentialSubjectID.String(), presentation.ID.String())
+	exists, err = m.store.exists(definition.ID, credentialSubjectID.String(), presentation.ID.String())
 	if err != nil {
 		return err
 	}
 	if exists {
 		return errors.Join(ErrInvalidPresentation, ErrPresentationAlreadyExists)
 	}
+	eventType, err := addedEventType(m.store, serviceID, credentialSubjectID.String(), presentation)
+	if err != nil {
+		return err
+	}
 	record, err := m.store.add(serviceID, presentation, "", 0)
 	if err != nil {
 		return err
 	}
+	m.events.publishAdded(eventType, *record, presentation)
 	// also update validated flag since validation is already done
 	if err = m.store.updateValidated([]presentationRecord{*record}); err != nil {
 		log.Logger().WithError(err).Errorf("failed to update validated flag for presentation (id: %s)", record.ID)
 	}
+	m.notifier.notify(serviceID, record.LamportTimestamp)
 
 	return nil
 }
 
-func (m *Module) verifyRegistration(definition ServiceDefinition, presentation vc.VerifiablePresentation) error {
+// registerOnLeader forwards a verified presentation registered on a replica to the leader of the Discovery Service.
+// If the leader is unavailable, the presentation is stored in the outbox and forwarded later (see forwardOutbox),
+// and ErrRegistrationQueued is returned to inform the caller.
+// The presentation is added to the replica when it's replicated from the leader.
+func (m *Module) registerOnLeader(ctx context.Context, definition ServiceDefinition, presentation vc.VerifiablePresentation) error {
+	if cycleDetected(ctx, definition) {
+		return errCyclicForwardingDetected
+	}
+	log.Logger().Infof("Forwarding Register request to leader (service=%s)", definition.ID)
+	err := m.httpClient.Register(ctx, definition.Endpoint, presentation)
+	if errors.Is(err, client.ErrServiceUnavailable) {
+		log.Logger().WithError(err).Warnf("Leader of Discovery Service is unavailable, presentation will be forwarded later (service=%s, id=%s)", definition.ID, presentation.ID)
+		if err = m.store.addToOutbox(definition.ID, presentation); err != nil {
+			return err
+		}
+		return ErrRegistrationQueued
+	}
+	return err
+}
+
+// forwardOutbox forwards the presentations that were registered while the leader of the Discovery Service was unavailable.
+func (m *Module) forwardOutbox(ctx context.Context) error {
+	records, err := m.store.getOutbox()
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		presentation, err := vc.ParseVerifiablePresentation(record.PresentationRaw)
+		if err != nil {
+			log.Logger().WithError(err).Warnf("Removing invalid presentation from outbox (service=%s, id=%s)", record.ServiceID, record.PresentationID)
+		} else if definition, _, isServer := m.definition(record.ServiceID); isServer && m.isReplica(definition) {
+			err = m.httpClient.Register(ctx, definition.Endpoint, *presentation)
+			if errors.Is(err, client.ErrServiceUnavailable) {
+				// try again later
+				continue
+			}
+			if err != nil {
+				log.Logger().WithError(err).Warnf("Leader rejected presentation from outbox (service=%s, id=%s)", record.ServiceID, record.PresentationID)
+			}
+		} else if err = m.Register(ctx, record.ServiceID, *presentation); err != nil {
+			// the node was promoted to leader, or doesn't serve the service anymore
+			log.Logger().WithError(err).Warnf("Failed to register presentation from outbox (service=%s, id=%s)", record.ServiceID, record.PresentationID)
+		}
+		if err = m.store.removeFromOutbox(record.ServiceID, record.PresentationID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Module) verifyRegistration(ctx context.Context, definition ServiceDefinition, presentation vc.VerifiablePresentation) error {
 	// First, simple sanity checks
 	if presentation.Format() != vc.JWTPresentationProofFormat {
 		return errors.Join(ErrInvalidPresentation, errUnsupportedPresentationFormat)
@@ -247,6 +426,20 @@ func (m *Module) verifyRegistration(definition ServiceDefinition, presentation v
 	if time.Until(expiration) > time.Duration(definition.PresentationMaxValidity)*time.Second {
 		return errors.Join(ErrInvalidPresentation, fmt.Errorf("presentation is valid for too long (max %s)", time.Duration(definition.PresentationMaxValidity)*time.Second))
 	}
+	if err := m.matchesDefinition(definition, presentation); err != nil {
+		return err
+	}
+	// Check signature of presentation and contained credential(s)
+	_, err := m.vcrInstance.Verifier().VerifyVP(ctx, presentation, true, true, nil)
+	if err != nil {
+		return errors.Join(ErrInvalidPresentation, fmt.Errorf("presentation verification failed: %w", err))
+	}
+	return nil
+}
+
+// matchesDefinition checks whether the presentation meets the requirements of the service definition:
+// the DID method of its signer and the credentials it contains. It doesn't verify the signatures.
+func (m *Module) matchesDefinition(definition ServiceDefinition, presentation vc.VerifiablePresentation) error {
 	credentialSubjectID, err := credential.PresentationSigner(presentation)
 	if err != nil {
 		return err
@@ -265,11 +458,6 @@ func (m *Module) verifyRegistration(definition ServiceDefinition, presentation v
 	if err != nil {
 		return errors.Join(ErrInvalidPresentation, err)
 	}
-	// Check signature of presentation and contained credential(s)
-	_, err = m.vcrInstance.Verifier().VerifyVP(presentation, true, true, nil)
-	if err != nil {
-		return errors.Join(ErrInvalidPresentation, fmt.Errorf("presentation verification failed: %w", err))
-	}
 	return nil
 }
 
@@ -317,7 +505,7 @@ func (m *Module) validateRetraction(serviceID string, presentation vc.Verifiable
 		return err
 	}
 	if !exists {
-		if _, ok = m.serverDefinitions[serviceID]; ok { // only throw an error if acting as server for this service, see https://github.com/nuts-foundation/nuts-node/issues/3691
+		if _, _, isServer := m.definition(serviceID); isServer { // only throw an error if acting as server for this service, see https://github.com/nuts-foundation/nuts-node/issues/3691
 			return errRetractionReferencesUnknownPresentation
 		}
 		log.Logger().Warnf("Ignored retraction (ID=%s) signed by (did=%s) for (service=%s) that references a VP (retractedJTI=%s) that does not exist (anymore).", presentation.ID, signerDID.String(), serviceID, retractJTI)
@@ -328,10 +516,9 @@ func (m *Module) validateRetraction(serviceID string, presentation vc.Verifiable
 // Get is a Discovery Server function that retrieves the presentations for the given service, starting at timestamp+1.
 // See interface.go for more information.
 func (m *Module) Get(context context.Context, serviceID string, startAfter int) (map[string]vc.VerifiablePresentation, string, int, error) {
-	_, exists := m.serverDefinitions[serviceID]
-	if !exists {
+	service, exists, isServer := m.definition(serviceID)
+	if !isServer {
 		// forward to configured server
-		service, exists := m.allDefinitions[serviceID]
 		if !exists {
 			return nil, "", 0, ErrServiceNotFound
 		}
@@ -342,11 +529,26 @@ func (m *Module) Get(context context.Context, serviceID string, startAfter int)
 		}
 
 		log.Logger().Infof("Forwarding Get request to configured server (service=%s)", serviceID)
-		return m.httpClient.Get(context, service.Endpoint, startAfter)
+		return getFromService(context, m.httpClient, service, m.publicURL, startAfter)
 	}
 	return m.store.get(serviceID, startAfter)
 }
 
+// Subscribe is a Discovery Server function that subscribes to changes of the given service.
+// See interface.go for more information.
+func (m *Module) Subscribe(ctx context.Context, serviceID string) (<-chan int, error) {
+	if _, _, isServer := m.definition(serviceID); !isServer {
+		// subscriptions aren't forwarded, clients should subscribe to the configured server directly
+		return nil, ErrServiceNotFound
+	}
+	timestamp, err := m.store.getTimestamp(serviceID)
+	if err != nil {
+		return nil, err
+	}
+	remoteIP, _ := ctx.Value(RemoteIPContextKey{}).(string)
+	return m.notifier.subscribe(ctx, m.ctx, serviceID, remoteIP, timestamp)
+}
+
 func cycleDetected(ctx context.Context, service ServiceDefinition) bool {
 	host := forwardedHost(ctx)
 	if host == "" {
@@ -393,7 +595,8 @@ func (m *Module) ActivateServiceForSubject(ctx context.Context, serviceID, subje
 	}
 
 	log.Logger().Infof("Successfully activated service for subject (subject=%s,service=%s)", subjectID, serviceID)
-	err = m.clientUpdater.updateService(ctx, m.allDefinitions[serviceID])
+	definition, _, _ := m.definition(serviceID)
+	err = m.clientUpdater.updateService(ctx, definition)
 	if err != nil {
 		log.Logger().Infof("Failed to update local copy of Discovery Service (service=%s): %s", serviceID, err)
 	}
@@ -408,6 +611,8 @@ func (m *Module) DeactivateServiceForSubject(ctx context.Context, serviceID, sub
 }
 
 func (m *Module) Services() []ServiceDefinition {
+	m.definitionsMux.RLock()
+	defer m.definitionsMux.RUnlock()
 	result := make([]ServiceDefinition, 0, len(m.allDefinitions))
 	for _, definition := range m.allDefinitions {
 		result = append(result, definition)
@@ -415,6 +620,137 @@ func (m *Module) Services() []ServiceDefinition {
 	return result
 }
 
+// PutServiceDefinition is a Discovery Client function that adds or updates a service definition at runtime.
+// See interface.go for more information.
+func (m *Module) PutServiceDefinition(ctx context.Context, definition ServiceDefinition, server bool) error {
+	m.definitionsMux.Lock()
+	previous, exists := m.allDefinitions[definition.ID]
+	if exists && !m.runtimeDefinitions[definition.ID] {
+		m.definitionsMux.Unlock()
+		return ErrReadOnlyServiceDefinition
+	}
+	if err := m.store.putDefinition(definition, server); err != nil {
+		m.definitionsMux.Unlock()
+		return fmt.Errorf("failed to store service definition (service=%s): %w", definition.ID, err)
+	}
+	allDefinitions := maps.Clone(m.allDefinitions)
+	serverDefinitions := maps.Clone(m.serverDefinitions)
+	runtimeDefinitions := maps.Clone(m.runtimeDefinitions)
+	allDefinitions[definition.ID] = definition
+	delete(serverDefinitions, definition.ID)
+	if server {
+		serverDefinitions[definition.ID] = definition
+	}
+	runtimeDefinitions[definition.ID] = true
+	m.setDefinitions(allDefinitions, serverDefinitions, runtimeDefinitions)
+	if !server {
+		// clients must subscribe to the Discovery Server of the service
+		m.notifier.closeService(definition.ID)
+	}
+	// restart the subscription, the endpoint might have changed
+	m.stopSubscription(definition.ID)
+	m.startSubscription(definition)
+	m.definitionsMux.Unlock()
+	if exists {
+		log.Logger().Infof("Updated service definition (service=%s)", definition.ID)
+	} else {
+		log.Logger().Infof("Added service definition (service=%s)", definition.ID)
+	}
+
+	// Presentations on the service might not match the new definition (anymore), so validate them again in the background.
+	if exists && (!reflect.DeepEqual(previous.PresentationDefinition, definition.PresentationDefinition) || !slices.Equal(previous.DIDMethods, definition.DIDMethods)) {
+		m.routines.Add(1)
+		go func() {
+			defer m.routines.Done()
+			if err := m.revalidate(definition.ID); err != nil {
+				log.Logger().WithError(err).Errorf("Failed to validate presentations against changed service definition (service=%s)", definition.ID)
+			}
+		}()
+	}
+	if !server {
+		if err := m.clientUpdater.updateService(ctx, definition); err != nil {
+			log.Logger().Infof("Failed to update local copy of Discovery Service (service=%s): %s", definition.ID, err)
+		}
+	}
+	return nil
+}
+
+// revalidate removes the presentations on the service that don't match its current definition, e.g. after the definition changed.
+// Presentations that weren't validated yet are validated against the current definition by the refresh loop.
+func (m *Module) revalidate(serviceID string) error {
+	m.revalidateMux.Lock()
+	defer m.revalidateMux.Unlock()
+	definition, exists, _ := m.definition(serviceID)
+	if !exists {
+		// retired in the meantime
+		return nil
+	}
+	records, err := m.store.servicePresentations(serviceID)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		if m.ctx.Err() != nil {
+			return m.ctx.Err()
+		}
+		presentation, err := vc.ParseVerifiablePresentation(record.PresentationRaw)
+		if err != nil {
+			log.Logger().WithError(err).Warnf("Failed to parse presentation (service=%s, id=%s)", serviceID, record.PresentationID)
+			continue
+		}
+		if presentation.IsType(retractionPresentationType) {
+			continue
+		}
+		err = m.matchesDefinition(definition, *presentation)
+		if err == nil {
+			continue
+		}
+		log.Logger().WithError(err).Infof("Removing presentation that doesn't match the service definition (service=%s, id=%s)", serviceID, record.PresentationID)
+		if err = m.store.deletePresentationRecord(record.ID); err != nil {
+			return fmt.Errorf("failed to remove presentation (service=%s, id=%s): %w", serviceID, record.PresentationID, err)
+		}
+		m.events.publish(newEvent(EventInvalidated, record, nil))
+	}
+	return nil
+}
+
+// RetireServiceDefinition is a Discovery Client function that removes a service definition that was added at runtime.
+// See interface.go for more information.
+func (m *Module) RetireServiceDefinition(_ context.Context, serviceID string) error {
+	m.definitionsMux.Lock()
+	defer m.definitionsMux.Unlock()
+	if _, exists := m.allDefinitions[serviceID]; !exists {
+		return ErrServiceNotFound
+	}
+	if !m.runtimeDefinitions[serviceID] {
+		return ErrReadOnlyServiceDefinition
+	}
+	if err := m.store.deleteDefinition(serviceID); err != nil {
+		return fmt.Errorf("failed to delete service definition (service=%s): %w", serviceID, err)
+	}
+	allDefinitions := maps.Clone(m.allDefinitions)
+	serverDefinitions := maps.Clone(m.serverDefinitions)
+	runtimeDefinitions := maps.Clone(m.runtimeDefinitions)
+	delete(allDefinitions, serviceID)
+	delete(serverDefinitions, serviceID)
+	delete(runtimeDefinitions, serviceID)
+	m.setDefinitions(allDefinitions, serverDefinitions, runtimeDefinitions)
+	m.stopSubscription(serviceID)
+	m.notifier.closeService(serviceID)
+	log.Logger().Infof("Retired service definition (service=%s)", serviceID)
+	return nil
+}
+
+// setDefinitions replaces the service definitions of the module and its components.
+// The caller must hold definitionsMux.
+func (m *Module) setDefinitions(allDefinitions, serverDefinitions map[string]ServiceDefinition, runtimeDefinitions map[string]bool) {
+	m.allDefinitions = allDefinitions
+	m.serverDefinitions = serverDefinitions
+	m.runtimeDefinitions = runtimeDefinitions
+	m.clientUpdater.setServices(allDefinitions)
+	m.registrationManager.setServices(allDefinitions)
+}
+
 // GetServiceActivation is a Discovery Client function that retrieves the activation status of a service for a subject.
 // See interface.go for more information.
 func (m *Module) GetServiceActivation(ctx context.Context, serviceID, subjectID string) (bool, []vc.VerifiablePresentation, error) {
@@ -481,8 +817,8 @@ func loadDefinitions(directory string) (map[string]ServiceDefinition, error) {
 
 // Search is a Discovery Client function that searches for presentations which credential(s) match the given query.
 // See interface.go for more information.
-func (m *Module) Search(serviceID string, query map[string]string) ([]SearchResult, error) {
-	service, exists := m.allDefinitions[serviceID]
+func (m *Module) Search(serviceID string, query Query) ([]SearchResult, error) {
+	service, exists, _ := m.definition(serviceID)
 	if !exists {
 		return nil, ErrServiceNotFound
 	}
@@ -559,17 +895,22 @@ func (m *Module) update() {
 		if err != nil {
 			log.Logger().WithError(err).Errorf("Failed to refresh own Verifiable Presentations on Discovery Service")
 		}
+		// forward presentations registered on replicas while the leader was unavailable
+		err = m.forwardOutbox(ctx)
+		if err != nil {
+			log.Logger().WithError(err).Errorf("Failed to forward presentations to leader of Discovery Service")
+		}
 		err = m.clientUpdater.update(m.ctx)
 		if err != nil {
 			log.Logger().WithError(err).Errorf("Failed to load latest Verifiable Presentations from Discovery Service")
 		}
 		// updateValidated all presentations not yet validated
-		err = m.registrationManager.validate()
+		err = m.registrationManager.validate(m.ctx)
 		if err != nil {
 			log.Logger().WithError(err).Errorf("Failed to validate presentations")
 		}
 		// purge list
-		err = m.registrationManager.removeRevoked()
+		err = m.registrationManager.removeRevoked(m.ctx)
 		if err != nil {
 			log.Logger().WithError(err).Errorf("Failed to remove revoked presentations")
 		}
@@ -585,6 +926,34 @@ func (m *Module) update() {
 	}
 }
 
+// subscribe subscribes to changes of the given Discovery Service, updating the local copy when the service changes.
+// When the subscription ends or fails, it resubscribes after subscriptionRetryInterval until the given context is done.
+// If the service has replicas, it subscribes to the next Discovery Server of the service when subscribing fails.
+func (m *Module) subscribe(ctx context.Context, definition ServiceDefinition) {
+	endpoints := definition.endpoints(m.publicURL)
+	i := 0
+	for {
+		err := m.httpClient.Subscribe(ctx, endpoints[i], func(timestamp int) {
+			log.Logger().
+				WithField("discoveryService", definition.ID).
+				Tracef("Discovery Service changed (timestamp: %d)", timestamp)
+			if err := m.clientUpdater.updateService(ctx, definition); err != nil {
+				log.Logger().WithError(err).Errorf("Failed to load latest Verifiable Presentations from Discovery Service (service=%s)", definition.ID)
+			}
+		})
+		if err != nil {
+			// the Discovery Server might not support subscriptions, changes are then loaded at the refresh interval
+			log.Logger().WithError(err).Debugf("Unable to subscribe to Discovery Service (service=%s, url=%s)", definition.ID, endpoints[i])
+			i = (i + 1) % len(endpoints)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(subscriptionRetryInterval):
+		}
+	}
+}
+
 // validateAudience checks if the given audience of the presentation matches the service ID.
 func validateAudience(service ServiceDefinition, audience []string) error {
 	for _, audienceID := range audience {
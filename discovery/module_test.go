@@ -26,6 +26,7 @@ import (
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/go-did/vc"
 	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/core/to"
 	"github.com/nuts-foundation/nuts-node/discovery/api/server/client"
 	"github.com/nuts-foundation/nuts-node/storage"
 	"github.com/nuts-foundation/nuts-node/test"
@@ -40,6 +41,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"gorm.io/gorm"
+	"net/http"
+	"net/http/httptest"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -62,10 +65,18 @@ func Test_Module_Register(t *testing.T) {
 
 	t.Run("registration", func(t *testing.T) {
 		t.Run("ok", func(t *testing.T) {
+			webhookEvents := make(chan Event, 10)
+			webhook := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				var event Event
+				_ = json.NewDecoder(request.Body).Decode(&event)
+				webhookEvents <- event
+			}))
+			t.Cleanup(webhook.Close)
 			m, testContext := setupModule(t, storageEngine, func(module *Module) {
 				module.config.Client.RefreshInterval = 0
+				module.events.webhooks = []string{webhook.URL}
 			})
-			testContext.verifier.EXPECT().VerifyVP(gomock.Any(), true, true, nil).Times(2)
+			testContext.verifier.EXPECT().VerifyVP(gomock.Any(), gomock.Any(), true, true, nil).Times(2)
 
 			err := m.Register(ctx, testServiceID, vpAlice)
 			require.NoError(t, err)
@@ -74,6 +85,15 @@ func Test_Module_Register(t *testing.T) {
 			require.NoError(t, err)
 			assert.Equal(t, 1, timestamp)
 			assert.NotEmpty(t, seed)
+			select {
+			case event := <-webhookEvents:
+				assert.Equal(t, EventRegistered, event.Type)
+				assert.Equal(t, testServiceID, event.ServiceID)
+				assert.Equal(t, vpAlice.ID.String(), event.PresentationID)
+				assert.Equal(t, 1, event.Timestamp)
+			case <-time.After(5 * time.Second):
+				t.Fatal("timeout waiting for event")
+			}
 
 			t.Run("already exists", func(t *testing.T) {
 				err = m.Register(ctx, testServiceID, vpAlice)
@@ -96,9 +116,57 @@ func Test_Module_Register(t *testing.T) {
 
 			assert.NoError(t, err)
 		})
+		t.Run("replica", func(t *testing.T) {
+			t.Run("forwarded to leader", func(t *testing.T) {
+				m, testContext := setupModule(t, storageEngine, asReplica)
+				testContext.verifier.EXPECT().VerifyVP(gomock.Any(), gomock.Any(), true, true, nil)
+				m.httpClient.(*client.MockHTTPClient).EXPECT().Register(gomock.Any(), "https://leader.example.com/usecase", vpAlice).Return(nil)
+
+				err := m.Register(ctx, testServiceID, vpAlice)
+
+				require.NoError(t, err)
+				outbox, err := m.store.getOutbox()
+				require.NoError(t, err)
+				assert.Empty(t, outbox)
+				// added to the replica when it's replicated from the leader
+				_, _, timestamp, err := m.Get(ctx, testServiceID, 0)
+				require.NoError(t, err)
+				assert.Equal(t, 0, timestamp)
+			})
+			t.Run("rejected by leader", func(t *testing.T) {
+				m, testContext := setupModule(t, storageEngine, asReplica)
+				testContext.verifier.EXPECT().VerifyVP(gomock.Any(), gomock.Any(), true, true, nil)
+				m.httpClient.(*client.MockHTTPClient).EXPECT().Register(gomock.Any(), "https://leader.example.com/usecase", vpAlice).Return(assert.AnError)
+
+				err := m.Register(ctx, testServiceID, vpAlice)
+
+				assert.ErrorIs(t, err, assert.AnError)
+			})
+			t.Run("leader unavailable", func(t *testing.T) {
+				m, testContext := setupModule(t, storageEngine, asReplica)
+				testContext.verifier.EXPECT().VerifyVP(gomock.Any(), gomock.Any(), true, true, nil)
+				m.httpClient.(*client.MockHTTPClient).EXPECT().Register(gomock.Any(), "https://leader.example.com/usecase", vpAlice).Return(client.ErrServiceUnavailable)
+
+				err := m.Register(ctx, testServiceID, vpAlice)
+
+				assert.ErrorIs(t, err, ErrRegistrationQueued)
+				outbox, err := m.store.getOutbox()
+				require.NoError(t, err)
+				require.Len(t, outbox, 1)
+				assert.Equal(t, vpAlice.ID.String(), outbox[0].PresentationID)
+			})
+			t.Run("invalid presentation", func(t *testing.T) {
+				m, testContext := setupModule(t, storageEngine, asReplica)
+				testContext.verifier.EXPECT().VerifyVP(gomock.Any(), gomock.Any(), true, true, nil).Return(nil, assert.AnError)
+
+				err := m.Register(ctx, testServiceID, vpAlice)
+
+				assert.ErrorIs(t, err, ErrInvalidPresentation)
+			})
+		})
 		t.Run("VP verification fails (e.g. invalid signature)", func(t *testing.T) {
 			m, testContext := setupModule(t, storageEngine)
-			testContext.verifier.EXPECT().VerifyVP(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("failed"))
+			testContext.verifier.EXPECT().VerifyVP(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("failed"))
 
 			err := m.Register(ctx, testServiceID, vpAlice)
 			require.EqualError(t, err, "presentation is invalid for registration\npresentation verification failed: failed")
@@ -207,7 +275,7 @@ func Test_Module_Register(t *testing.T) {
 				// disable updater
 				module.config.Client.RefreshInterval = 0
 			})
-			testContext.verifier.EXPECT().VerifyVP(gomock.Any(), true, true, nil).Times(2)
+			testContext.verifier.EXPECT().VerifyVP(gomock.Any(), gomock.Any(), true, true, nil).Times(2)
 
 			err := m.Register(ctx, testServiceID, vpAlice)
 			require.NoError(t, err)
@@ -219,10 +287,10 @@ func Test_Module_Register(t *testing.T) {
 				// disable updater
 				module.config.Client.RefreshInterval = 0
 			})
-			testContext.verifier.EXPECT().VerifyVP(gomock.Any(), true, true, nil)
+			testContext.verifier.EXPECT().VerifyVP(gomock.Any(), gomock.Any(), true, true, nil)
 			definition := m.serverDefinitions[testServiceID]
 			delete(m.serverDefinitions, testServiceID)
-			err := m.verifyRegistration(definition, vpAliceRetract)
+			err := m.verifyRegistration(context.Background(), definition, vpAliceRetract)
 			assert.NoError(t, err)
 		})
 		t.Run("non-existent presentation as server", func(t *testing.T) {
@@ -342,7 +410,7 @@ func setupModule(t *testing.T, storageInstance storage.Engine, visitors ...func(
 	mockVCR.EXPECT().Verifier().Return(mockVerifier).AnyTimes()
 	mockSubjectManager := didsubject.NewMockManager(ctrl)
 	mockDIDResolver := resolver.NewMockDIDResolver(ctrl)
-	m := New(storageInstance, mockVCR, mockSubjectManager, mockDIDResolver)
+	m := New(storageInstance, mockVCR, mockSubjectManager, mockDIDResolver, nil)
 	m.config = DefaultConfig()
 	m.publicURL = test.MustParseURL("https://example.com")
 	require.NoError(t, m.Configure(core.TestServerConfig()))
@@ -351,6 +419,10 @@ func setupModule(t *testing.T, storageInstance storage.Engine, visitors ...func(
 	httpClient.EXPECT().Get(gomock.Any(), "http://example.com/other", gomock.Any()).Return(nil, testSeed, 0, nil).AnyTimes()
 	httpClient.EXPECT().Get(gomock.Any(), "http://example.com/usecase", gomock.Any()).Return(nil, testSeed, 0, nil).AnyTimes()
 	httpClient.EXPECT().Get(gomock.Any(), "http://example.com/unsupported", gomock.Any()).Return(nil, testSeed, 0, nil).AnyTimes()
+	httpClient.EXPECT().Subscribe(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, _ string, _ func(int)) error {
+		<-ctx.Done()
+		return nil
+	}).AnyTimes()
 	// set seed in DB otherwise behaviour is unpredictable due to background processes
 	if m.store != nil {
 		require.NoError(t, m.store.db.Transaction(func(tx *gorm.DB) error {
@@ -461,14 +533,14 @@ func TestModule_Search(t *testing.T) {
 		m, ctx := setupModule(t, storageEngine, func(module *Module) {
 			module.config.Client.RefreshInterval = 0
 		})
-		ctx.verifier.EXPECT().VerifyVP(gomock.Any(), true, true, nil)
+		ctx.verifier.EXPECT().VerifyVP(gomock.Any(), gomock.Any(), true, true, nil)
 		_, err := m.store.add(testServiceID, vpAlice, testSeed, 1)
 		require.NoError(t, err)
-		require.NoError(t, m.registrationManager.validate())
+		require.NoError(t, m.registrationManager.validate(context.Background()))
 
-		results, err := m.Search(testServiceID, map[string]string{
+		results, err := m.Search(testServiceID, Query{Properties: map[string]string{
 			"credentialSubject.person.givenName": "Alice",
-		})
+		}})
 		assert.NoError(t, err)
 		expectedJSON, _ := json.Marshal([]SearchResult{
 			{
@@ -485,7 +557,7 @@ func TestModule_Search(t *testing.T) {
 	})
 	t.Run("unknown service ID", func(t *testing.T) {
 		m, _ := setupModule(t, storageEngine)
-		_, err := m.Search("unknown", nil)
+		_, err := m.Search("unknown", Query{})
 		assert.ErrorIs(t, err, ErrServiceNotFound)
 	})
 }
@@ -521,10 +593,11 @@ func TestModule_update(t *testing.T) {
 			mockVerifier := verifier.NewMockVerifier(ctrl)
 			mockVCR := vcr.NewMockVCR(ctrl)
 			mockVCR.EXPECT().Verifier().Return(mockVerifier).AnyTimes()
-			m := New(storageEngine, mockVCR, nil, nil)
+			m := New(storageEngine, mockVCR, nil, nil, nil)
 			m.config = DefaultConfig()
 			m.publicURL = test.MustParseURL("https://example.com")
 			m.config.Client.RefreshInterval = tt.refreshInterval
+			m.config.Client.Subscribe = false
 			require.NoError(t, m.Configure(core.TestServerConfig()))
 			m.allDefinitions = testDefinitions()
 			httpClient := client.NewMockHTTPClient(ctrl)
@@ -545,7 +618,7 @@ func TestModule_update(t *testing.T) {
 			vpWg.Add(tt.expectedVerifyVPCalls)
 			vpCounter := atomic.Int64{}
 			vpCounter.Add(int64(tt.expectedVerifyVPCalls))
-			mockVerifier.EXPECT().VerifyVP(gomock.Any(), true, true, nil).DoAndReturn(func(_, _, _, _ interface{}) ([]vc.VerifiableCredential, error) {
+			mockVerifier.EXPECT().VerifyVP(gomock.Any(), gomock.Any(), true, true, nil).DoAndReturn(func(_, _, _, _, _ interface{}) ([]vc.VerifiableCredential, error) {
 				if vpCounter.Load() != int64(0) {
 					vpWg.Done()
 					vpCounter.Add(int64(-1))
@@ -567,6 +640,116 @@ func TestModule_update(t *testing.T) {
 	}
 }
 
+func TestModule_Subscribe(t *testing.T) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	t.Run("receives current timestamp and registrations", func(t *testing.T) {
+		m, _ := setupModule(t, storageEngine)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		timestamps, err := m.Subscribe(ctx, testServiceID)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, <-timestamps)
+		// register a presentation
+		m.vcrInstance.(*vcr.MockVCR).Verifier().(*verifier.MockVerifier).EXPECT().VerifyVP(gomock.Any(), gomock.Any(), true, true, nil)
+		require.NoError(t, m.Register(context.Background(), testServiceID, vpAlice))
+		assert.Equal(t, 1, <-timestamps)
+		// channel is closed when the context is done
+		cancel()
+		_, ok := <-timestamps
+		assert.False(t, ok)
+	})
+	t.Run("not a server for the service", func(t *testing.T) {
+		m, _ := setupModule(t, storageEngine)
+
+		_, err := m.Subscribe(context.Background(), "other")
+
+		assert.ErrorIs(t, err, ErrServiceNotFound)
+	})
+}
+
+func TestModule_subscribe(t *testing.T) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	t.Run("Start() subscribes to services not served by this node", func(t *testing.T) {
+		subscribed := make(chan string, 3)
+		setupModule(t, storageEngine, func(module *Module) {
+			httpClient := client.NewMockHTTPClient(gomock.NewController(t))
+			httpClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, testSeed, 0, nil).AnyTimes()
+			httpClient.EXPECT().Subscribe(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, endpoint string, _ func(int)) error {
+				subscribed <- endpoint
+				<-ctx.Done()
+				return nil
+			}).Times(2)
+			module.httpClient = httpClient
+		})
+
+		endpoints := []string{<-subscribed, <-subscribed}
+
+		assert.ElementsMatch(t, []string{"http://example.com/other", "http://example.com/unsupported"}, endpoints)
+	})
+	t.Run("change updates local copy", func(t *testing.T) {
+		m, _ := setupModule(t, storageEngine, func(module *Module) {
+			module.config.Client.RefreshInterval = 0
+		})
+		updated := make(chan struct{})
+		httpClient := client.NewMockHTTPClient(gomock.NewController(t))
+		httpClient.EXPECT().Subscribe(gomock.Any(), "http://example.com/other", gomock.Any()).DoAndReturn(func(ctx context.Context, _ string, onChange func(int)) error {
+			onChange(1)
+			<-ctx.Done()
+			return nil
+		})
+		httpClient.EXPECT().Get(gomock.Any(), "http://example.com/other", 0).DoAndReturn(func(_ context.Context, _ string, _ int) (map[string]vc.VerifiablePresentation, string, int, error) {
+			close(updated)
+			return nil, testSeed, 1, nil
+		})
+		m.httpClient = httpClient
+		m.clientUpdater.client = httpClient
+		done := make(chan struct{})
+
+		go func() {
+			m.subscribe(m.ctx, m.allDefinitions["other"])
+			close(done)
+		}()
+
+		<-updated
+		m.cancel()
+		<-done
+	})
+	t.Run("resubscribes after failure", func(t *testing.T) {
+		subscriptionRetryInterval = time.Millisecond
+		t.Cleanup(func() {
+			subscriptionRetryInterval = time.Minute
+		})
+		m, _ := setupModule(t, storageEngine, func(module *Module) {
+			module.config.Client.RefreshInterval = 0
+		})
+		resubscribed := make(chan struct{})
+		httpClient := client.NewMockHTTPClient(gomock.NewController(t))
+		gomock.InOrder(
+			httpClient.EXPECT().Subscribe(gomock.Any(), "http://example.com/other", gomock.Any()).Return(errors.New("not supported")),
+			httpClient.EXPECT().Subscribe(gomock.Any(), "http://example.com/other", gomock.Any()).DoAndReturn(func(ctx context.Context, _ string, _ func(int)) error {
+				close(resubscribed)
+				<-ctx.Done()
+				return nil
+			}),
+		)
+		m.httpClient = httpClient
+		done := make(chan struct{})
+
+		go func() {
+			m.subscribe(m.ctx, m.allDefinitions["other"])
+			close(done)
+		}()
+
+		<-resubscribed
+		m.cancel()
+		<-done
+	})
+}
+
 func TestModule_ActivateServiceForSubject(t *testing.T) {
 	t.Run("ok, syncs VPs immediately after registration", func(t *testing.T) {
 		storageEngine := storage.NewTestStorageEngine(t)
@@ -673,6 +856,296 @@ func TestModule_Services(t *testing.T) {
 	})
 }
 
+func TestModule_forwardOutbox(t *testing.T) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	ctx := context.Background()
+
+	t.Run("leader available", func(t *testing.T) {
+		m, _ := setupModule(t, storageEngine, asReplica)
+		require.NoError(t, m.store.addToOutbox(testServiceID, vpAlice))
+		require.NoError(t, m.store.addToOutbox(testServiceID, vpBob))
+		httpClient := m.httpClient.(*client.MockHTTPClient)
+		httpClient.EXPECT().Register(gomock.Any(), "https://leader.example.com/usecase", vpAlice).Return(nil)
+		httpClient.EXPECT().Register(gomock.Any(), "https://leader.example.com/usecase", vpBob).Return(assert.AnError)
+
+		err := m.forwardOutbox(ctx)
+
+		require.NoError(t, err)
+		outbox, err := m.store.getOutbox()
+		require.NoError(t, err)
+		assert.Empty(t, outbox)
+	})
+	t.Run("leader unavailable", func(t *testing.T) {
+		m, _ := setupModule(t, storageEngine, asReplica)
+		require.NoError(t, m.store.addToOutbox(testServiceID, vpAlice))
+		m.httpClient.(*client.MockHTTPClient).EXPECT().Register(gomock.Any(), "https://leader.example.com/usecase", vpAlice).Return(client.ErrServiceUnavailable)
+
+		err := m.forwardOutbox(ctx)
+
+		require.NoError(t, err)
+		outbox, err := m.store.getOutbox()
+		require.NoError(t, err)
+		assert.Len(t, outbox, 1)
+	})
+	t.Run("promoted to leader", func(t *testing.T) {
+		m, testContext := setupModule(t, storageEngine, func(module *Module) {
+			module.config.Client.RefreshInterval = 0
+		})
+		testContext.verifier.EXPECT().VerifyVP(gomock.Any(), gomock.Any(), true, true, nil)
+		require.NoError(t, m.store.addToOutbox(testServiceID, vpAlice))
+
+		err := m.forwardOutbox(ctx)
+
+		require.NoError(t, err)
+		outbox, err := m.store.getOutbox()
+		require.NoError(t, err)
+		assert.Empty(t, outbox)
+		presentations, _, _, err := m.Get(ctx, testServiceID, 0)
+		require.NoError(t, err)
+		assert.Len(t, presentations, 1)
+	})
+}
+
+func TestModule_isReplica(t *testing.T) {
+	m := &Module{publicURL: test.MustParseURL("https://replica.example.com")}
+	definition := ServiceDefinition{Endpoint: "https://leader.example.com/discovery/usecase"}
+
+	assert.False(t, m.isReplica(definition), "no replicas")
+	definition.Endpoints = []string{"https://replica.example.com/discovery/usecase"}
+	assert.True(t, m.isReplica(definition))
+	definition.Endpoint = "https://replica.example.com/discovery/usecase"
+	assert.False(t, m.isReplica(definition), "leader")
+}
+
+// asReplica makes the module a replica of the test service, which is led by another Discovery Server.
+func asReplica(module *Module) {
+	module.config.Client.RefreshInterval = 0
+	definition := module.allDefinitions[testServiceID]
+	definition.Endpoint = "https://leader.example.com/usecase"
+	definition.Endpoints = []string{module.publicURL.JoinPath("discovery", testServiceID).String()}
+	module.allDefinitions[testServiceID] = definition
+	module.serverDefinitions[testServiceID] = definition
+}
+
+func TestModule_PutServiceDefinition(t *testing.T) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	t.Run("add", func(t *testing.T) {
+		m, _ := setupModule(t, storageEngine, func(module *Module) {
+			module.config.Client.RefreshInterval = 0
+			module.httpClient.(*client.MockHTTPClient).EXPECT().Get(gomock.Any(), "http://example.com/runtime", 0).Return(nil, testSeed, 0, nil)
+		})
+		definition := testDefinitions()["other"]
+		definition.ID = "runtime"
+		definition.Endpoint = "http://example.com/runtime"
+
+		err := m.PutServiceDefinition(context.Background(), definition, false)
+
+		require.NoError(t, err)
+		assert.Len(t, m.Services(), 4)
+		actual, exists, isServer := m.definition("runtime")
+		assert.True(t, exists)
+		assert.False(t, isServer)
+		assert.Equal(t, definition, actual)
+		stored, _, err := m.store.getDefinitions()
+		require.NoError(t, err)
+		assert.Equal(t, definition, stored["runtime"])
+		results, err := m.Search("runtime", Query{})
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	})
+	t.Run("add as server", func(t *testing.T) {
+		m, _ := setupModule(t, storageEngine, func(module *Module) {
+			module.config.Client.RefreshInterval = 0
+		})
+		definition := testDefinitions()["other"]
+		definition.ID = "runtime"
+
+		err := m.PutServiceDefinition(context.Background(), definition, true)
+
+		require.NoError(t, err)
+		_, _, isServer := m.definition("runtime")
+		assert.True(t, isServer)
+		_, _, served, err := m.store.get("runtime", 0)
+		require.NoError(t, err)
+		assert.Equal(t, 0, served)
+	})
+	t.Run("subscriptions are closed when no longer served", func(t *testing.T) {
+		m, _ := setupModule(t, storageEngine, func(module *Module) {
+			module.config.Client.RefreshInterval = 0
+		})
+		definition := testDefinitions()["other"]
+		definition.ID = "runtime"
+		require.NoError(t, m.PutServiceDefinition(context.Background(), definition, true))
+		subscriber, err := m.Subscribe(context.Background(), "runtime")
+		require.NoError(t, err)
+		<-subscriber
+
+		err = m.PutServiceDefinition(context.Background(), definition, false)
+
+		require.NoError(t, err)
+		_, ok := <-subscriber
+		assert.False(t, ok)
+	})
+	t.Run("update re-validates presentations", func(t *testing.T) {
+		m, ctx := setupModule(t, storageEngine, func(module *Module) {
+			module.config.Client.RefreshInterval = 0
+		}, withRuntimeDefinition(t, testServiceID))
+		ctx.verifier.EXPECT().VerifyVP(gomock.Any(), gomock.Any(), true, true, nil)
+		_, err := m.store.add(testServiceID, vpAlice, testSeed, 1)
+		require.NoError(t, err)
+		require.NoError(t, m.registrationManager.validate(context.Background()))
+		query := Query{Properties: map[string]string{"credentialSubject.person.givenName": "Alice"}}
+		results, err := m.Search(testServiceID, query)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+
+		t.Run("unchanged Presentation Definition", func(t *testing.T) {
+			definition := testDefinitions()[testServiceID]
+			definition.PresentationMaxValidity = 3600
+
+			err = m.PutServiceDefinition(context.Background(), definition, false)
+
+			require.NoError(t, err)
+			results, err = m.Search(testServiceID, query)
+			require.NoError(t, err)
+			assert.Len(t, results, 1)
+		})
+		t.Run("changed Presentation Definition", func(t *testing.T) {
+			m.events = &eventPublisher{queue: make(chan Event, 10)}
+			definition := testDefinitions()[testServiceID]
+			definition.PresentationDefinition.InputDescriptors[0].Constraints.Fields[0].Filter.Pattern = to.Ptr("did:example:other")
+
+			err = m.PutServiceDefinition(context.Background(), definition, false)
+
+			require.NoError(t, err)
+			// presentations are validated in the background
+			require.Eventually(t, func() bool {
+				presentations, err := m.store.servicePresentations(testServiceID)
+				return err == nil && len(presentations) == 0
+			}, 5*time.Second, 10*time.Millisecond)
+			results, err = m.Search(testServiceID, query)
+			require.NoError(t, err)
+			assert.Empty(t, results)
+			require.Len(t, m.events.queue, 1)
+			event := <-m.events.queue
+			assert.Equal(t, EventInvalidated, event.Type)
+			assert.Equal(t, vpAlice.ID.String(), event.PresentationID)
+		})
+	})
+	t.Run("definition from definitions directory", func(t *testing.T) {
+		m, _ := setupModule(t, storageEngine)
+
+		err := m.PutServiceDefinition(context.Background(), testDefinitions()[testServiceID], false)
+
+		assert.ErrorIs(t, err, ErrReadOnlyServiceDefinition)
+	})
+}
+
+func TestModule_RetireServiceDefinition(t *testing.T) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	t.Run("ok", func(t *testing.T) {
+		m, _ := setupModule(t, storageEngine, func(module *Module) {
+			module.config.Client.RefreshInterval = 0
+		}, withRuntimeDefinition(t, "other"))
+		_, err := m.store.add("other", vpAlice, testSeed, 1)
+		require.NoError(t, err)
+		require.NoError(t, m.store.updatePresentationRefreshTime("other", aliceSubject, nil, to.Ptr(time.Now())))
+
+		err = m.RetireServiceDefinition(context.Background(), "other")
+
+		require.NoError(t, err)
+		assert.Len(t, m.Services(), 2)
+		_, err = m.Search("other", Query{})
+		assert.ErrorIs(t, err, ErrServiceNotFound)
+		presentations, err := m.store.allPresentations(false)
+		require.NoError(t, err)
+		assert.Empty(t, presentations)
+		refreshRecord, err := m.store.getPresentationRefreshRecord("other", aliceSubject)
+		require.NoError(t, err)
+		assert.Nil(t, refreshRecord)
+		stored, _, err := m.store.getDefinitions()
+		require.NoError(t, err)
+		assert.Empty(t, stored)
+	})
+	t.Run("subscriptions are closed", func(t *testing.T) {
+		m, _ := setupModule(t, storageEngine, func(module *Module) {
+			module.config.Client.RefreshInterval = 0
+		})
+		definition := testDefinitions()["other"]
+		definition.ID = "runtime"
+		require.NoError(t, m.PutServiceDefinition(context.Background(), definition, true))
+		subscriber, err := m.Subscribe(context.Background(), "runtime")
+		require.NoError(t, err)
+		<-subscriber
+
+		err = m.RetireServiceDefinition(context.Background(), "runtime")
+
+		require.NoError(t, err)
+		_, ok := <-subscriber
+		assert.False(t, ok)
+	})
+	t.Run("unknown service", func(t *testing.T) {
+		m, _ := setupModule(t, storageEngine)
+
+		err := m.RetireServiceDefinition(context.Background(), "unknown")
+
+		assert.ErrorIs(t, err, ErrServiceNotFound)
+	})
+	t.Run("definition from definitions directory", func(t *testing.T) {
+		m, _ := setupModule(t, storageEngine)
+
+		err := m.RetireServiceDefinition(context.Background(), testServiceID)
+
+		assert.ErrorIs(t, err, ErrReadOnlyServiceDefinition)
+	})
+}
+
+func TestModule_loadRuntimeDefinitions(t *testing.T) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	t.Run("ok", func(t *testing.T) {
+		m, _ := setupModule(t, storageEngine, func(module *Module) {
+			store, err := newSQLStore(module.storageInstance.GetSQLDatabase(), nil)
+			require.NoError(t, err)
+			definition := testDefinitions()["other"]
+			definition.ID = "runtime"
+			require.NoError(t, store.putDefinition(definition, true))
+		})
+
+		_, exists, isServer := m.definition("runtime")
+		assert.True(t, exists)
+		assert.True(t, isServer)
+		assert.True(t, m.runtimeDefinitions["runtime"])
+	})
+	t.Run("definitions directory takes precedence", func(t *testing.T) {
+		m, _ := setupModule(t, storageEngine, func(module *Module) {
+			store, err := newSQLStore(module.storageInstance.GetSQLDatabase(), nil)
+			require.NoError(t, err)
+			definition := testDefinitions()["other"]
+			definition.Endpoint = "http://example.com/runtime"
+			require.NoError(t, store.putDefinition(definition, false))
+		})
+
+		definition, _, _ := m.definition("other")
+		assert.Equal(t, testDefinitions()["other"].Endpoint, definition.Endpoint)
+		assert.False(t, m.runtimeDefinitions["other"])
+	})
+}
+
+// withRuntimeDefinition turns the given test service definition into a definition that was added at runtime.
+func withRuntimeDefinition(t *testing.T, serviceID string) func(module *Module) {
+	return func(module *Module) {
+		store, err := newSQLStore(module.storageInstance.GetSQLDatabase(), nil)
+		require.NoError(t, err)
+		require.NoError(t, store.putDefinition(module.allDefinitions[serviceID], false))
+		delete(module.allDefinitions, serviceID)
+		delete(module.serverDefinitions, serviceID)
+	}
+}
+
 func TestModule_GetServiceActivation(t *testing.T) {
 	storageEngine := storage.NewTestStorageEngine(t)
 	require.NoError(t, storageEngine.Start())
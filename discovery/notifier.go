@@ -0,0 +1,131 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+package discovery
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// maxSubscribers is the maximum number of subscribers of all Discovery Services served by this node.
+var maxSubscribers = 1000
+
+// maxSubscribersPerIP is the maximum number of subscribers from a single IP address.
+var maxSubscribersPerIP = 10
+
+// ErrTooManySubscribers is returned when a subscription is refused because the maximum number of subscribers is reached.
+var ErrTooManySubscribers = errors.New("too many subscribers")
+
+// serviceNotifier notifies subscribers of Discovery Services served by this node of new registrations.
+// Subscribers only receive the latest timestamp of the service: if a subscriber hasn't consumed the previous notification yet,
+// it's replaced by the newer one.
+type serviceNotifier struct {
+	mux sync.Mutex
+	// subscribers contains the subscribers per service, and the functions that end their subscription.
+	subscribers map[string]map[chan int]context.CancelFunc
+	// subscribersPerIP counts the subscribers per remote IP address.
+	subscribersPerIP map[string]int
+	count            int
+}
+
+func newServiceNotifier() *serviceNotifier {
+	return &serviceNotifier{
+		subscribers:      make(map[string]map[chan int]context.CancelFunc),
+		subscribersPerIP: make(map[string]int),
+	}
+}
+
+// subscribe returns a channel that receives the timestamp of the given service when it changes,
+// starting with the given (current) timestamp.
+// The channel is closed when either of the given contexts is done, or when the subscriptions of the service are closed.
+// It returns ErrTooManySubscribers if the maximum number of subscribers (in total, or for the given remote IP) is reached.
+func (n *serviceNotifier) subscribe(ctx context.Context, moduleCtx context.Context, serviceID string, remoteIP string, timestamp int) (<-chan int, error) {
+	n.mux.Lock()
+	if n.count >= maxSubscribers || n.subscribersPerIP[remoteIP] >= maxSubscribersPerIP {
+		n.mux.Unlock()
+		return nil, ErrTooManySubscribers
+	}
+	subscriber := make(chan int, 1)
+	subscriber <- timestamp
+	ctx, cancel := context.WithCancel(ctx)
+	if n.subscribers[serviceID] == nil {
+		n.subscribers[serviceID] = make(map[chan int]context.CancelFunc)
+	}
+	n.subscribers[serviceID][subscriber] = cancel
+	n.subscribersPerIP[remoteIP]++
+	n.count++
+	n.mux.Unlock()
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-moduleCtx.Done():
+		}
+		cancel()
+		n.mux.Lock()
+		defer n.mux.Unlock()
+		delete(n.subscribers[serviceID], subscriber)
+		if len(n.subscribers[serviceID]) == 0 {
+			delete(n.subscribers, serviceID)
+		}
+		n.subscribersPerIP[remoteIP]--
+		if n.subscribersPerIP[remoteIP] == 0 {
+			delete(n.subscribersPerIP, remoteIP)
+		}
+		n.count--
+		close(subscriber)
+	}()
+	return subscriber, nil
+}
+
+// closeService ends the subscriptions of the given service, e.g. when it's no longer served by this node.
+func (n *serviceNotifier) closeService(serviceID string) {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+	for _, cancel := range n.subscribers[serviceID] {
+		cancel()
+	}
+}
+
+// notify sends the new timestamp of the given service to its subscribers.
+func (n *serviceNotifier) notify(serviceID string, timestamp int) {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+	for subscriber := range n.subscribers[serviceID] {
+		offer(subscriber, timestamp)
+	}
+}
+
+// offer sends the timestamp to the subscriber without blocking, replacing an unconsumed timestamp.
+// It must be called while holding the notifier's lock, to prevent sending on a closed channel.
+func offer(subscriber chan int, timestamp int) {
+	select {
+	case subscriber <- timestamp:
+		return
+	default:
+	}
+	// drain the unconsumed timestamp, then retry
+	select {
+	case <-subscriber:
+	default:
+	}
+	select {
+	case subscriber <- timestamp:
+	default:
+	}
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+package discovery
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_serviceNotifier(t *testing.T) {
+	t.Run("subscriber receives latest timestamp", func(t *testing.T) {
+		notifier := newServiceNotifier()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		subscriber, _ := notifier.subscribe(ctx, context.Background(), "service", "1.2.3.4", 1)
+
+		notifier.notify("service", 2)
+		notifier.notify("service", 3)
+		notifier.notify("other", 4)
+
+		assert.Equal(t, 3, <-subscriber)
+		assert.Len(t, subscriber, 0)
+	})
+	t.Run("subscriber receives initial timestamp", func(t *testing.T) {
+		notifier := newServiceNotifier()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		subscriber, _ := notifier.subscribe(ctx, context.Background(), "service", "1.2.3.4", 1)
+
+		assert.Equal(t, 1, <-subscriber)
+	})
+	t.Run("subscription ends when context is done", func(t *testing.T) {
+		notifier := newServiceNotifier()
+		ctx, cancel := context.WithCancel(context.Background())
+		subscriber, _ := notifier.subscribe(ctx, context.Background(), "service", "1.2.3.4", 1)
+		<-subscriber
+
+		cancel()
+
+		_, ok := <-subscriber
+		assert.False(t, ok)
+		notifier.mux.Lock()
+		defer notifier.mux.Unlock()
+		assert.Empty(t, notifier.subscribers)
+		assert.Empty(t, notifier.subscribersPerIP)
+		assert.Zero(t, notifier.count)
+	})
+	t.Run("subscription ends when module context is done", func(t *testing.T) {
+		notifier := newServiceNotifier()
+		moduleCtx, cancel := context.WithCancel(context.Background())
+		subscriber, _ := notifier.subscribe(context.Background(), moduleCtx, "service", "1.2.3.4", 1)
+		<-subscriber
+
+		cancel()
+
+		_, ok := <-subscriber
+		assert.False(t, ok)
+	})
+	t.Run("subscriptions end when the service is closed", func(t *testing.T) {
+		notifier := newServiceNotifier()
+		subscriber, _ := notifier.subscribe(context.Background(), context.Background(), "service", "1.2.3.4", 1)
+		other, _ := notifier.subscribe(context.Background(), context.Background(), "other", "1.2.3.4", 1)
+		<-subscriber
+
+		notifier.closeService("service")
+
+		_, ok := <-subscriber
+		assert.False(t, ok)
+		assert.Equal(t, 1, <-other)
+	})
+	t.Run("too many subscribers from the same IP", func(t *testing.T) {
+		notifier := newServiceNotifier()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		for i := 0; i < maxSubscribersPerIP; i++ {
+			_, err := notifier.subscribe(ctx, context.Background(), "service", "1.2.3.4", 1)
+			require.NoError(t, err)
+		}
+
+		_, err := notifier.subscribe(ctx, context.Background(), "service", "1.2.3.4", 1)
+
+		assert.ErrorIs(t, err, ErrTooManySubscribers)
+		t.Run("other IPs can still subscribe", func(t *testing.T) {
+			_, err := notifier.subscribe(ctx, context.Background(), "service", "5.6.7.8", 1)
+
+			assert.NoError(t, err)
+		})
+	})
+	t.Run("too many subscribers in total", func(t *testing.T) {
+		defer func(old int) { maxSubscribers = old }(maxSubscribers)
+		maxSubscribers = 2
+		notifier := newServiceNotifier()
+		ctx, cancel := context.WithCancel(context.Background())
+		subscriberA, err := notifier.subscribe(ctx, context.Background(), "service", "1.2.3.4", 1)
+		require.NoError(t, err)
+		subscriberB, err := notifier.subscribe(ctx, context.Background(), "other", "5.6.7.8", 1)
+		require.NoError(t, err)
+
+		_, err = notifier.subscribe(context.Background(), context.Background(), "service", "9.10.11.12", 1)
+
+		assert.ErrorIs(t, err, ErrTooManySubscribers)
+		t.Run("subscribers that left make room", func(t *testing.T) {
+			cancel()
+			for range subscriberA {
+			}
+			for range subscriberB {
+			}
+
+			_, err := notifier.subscribe(context.Background(), context.Background(), "service", "9.10.11.12", 1)
+
+			assert.NoError(t, err)
+		})
+	})
+}
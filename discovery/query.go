@@ -0,0 +1,600 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+package discovery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
+)
+
+// ErrInvalidQuery is returned when a search query can't be parsed.
+var ErrInvalidQuery = errors.New("invalid query")
+
+// ErrQueryTooBroad is returned when a search query selects more than maxSearchCandidates presentations to evaluate its filter or sort on.
+var ErrQueryTooBroad = fmt.Errorf("%w: query selects too many presentations, narrow it down", ErrInvalidQuery)
+
+// maxFilterDepth is the maximum nesting depth (parentheses and NOT) of a filter expression.
+const maxFilterDepth = 32
+
+// Query is a search query for presentations registered on a Discovery Service.
+type Query struct {
+	// Properties maps JSON paths to the string values a credential in the presentation must have, evaluated by the database.
+	// Wildcards (*) are supported at the start and end of the value; a single wildcard matches any (non-nil) value.
+	Properties map[string]string
+	// Filter is the expression a credential in the presentation must match for the presentation to be included.
+	// If nil, all presentations are included.
+	Filter Expression
+	// Sort specifies the order of the results.
+	// Presentations are ordered by the value at the given path of the first credential that matches the filter.
+	// Presentations without a value are placed last.
+	Sort []SortField
+	// Offset specifies the number of results to skip.
+	Offset int
+	// Limit specifies the maximum number of results. If 0, all results are returned.
+	Limit int
+}
+
+// SortField specifies a JSON path to order search results by.
+type SortField struct {
+	Path       string
+	Descending bool
+}
+
+// Expression is a boolean expression that is evaluated on a credential, represented as JSON object.
+type Expression interface {
+	// Matches returns whether the credential matches the expression.
+	Matches(credential map[string]interface{}) bool
+}
+
+// And matches if all expressions match.
+type And []Expression
+
+func (a And) Matches(credential map[string]interface{}) bool {
+	for _, expression := range a {
+		if !expression.Matches(credential) {
+			return false
+		}
+	}
+	return true
+}
+
+// Or matches if any of the expressions match.
+type Or []Expression
+
+func (o Or) Matches(credential map[string]interface{}) bool {
+	for _, expression := range o {
+		if expression.Matches(credential) {
+			return true
+		}
+	}
+	return false
+}
+
+// Not matches if the expression doesn't match.
+type Not struct {
+	Expression Expression
+}
+
+func (n Not) Matches(credential map[string]interface{}) bool {
+	return !n.Expression.Matches(credential)
+}
+
+// Operator is a comparison operator.
+type Operator string
+
+const (
+	// OperatorEquals matches values equal to the given value.
+	// Wildcards (*) are supported at the start and end of the value; a single wildcard matches any (non-nil) value.
+	OperatorEquals Operator = "="
+	// OperatorNotEquals matches if OperatorEquals doesn't match.
+	OperatorNotEquals Operator = "!="
+	// OperatorMatches is like OperatorEquals, but case-insensitive.
+	OperatorMatches            Operator = "~"
+	OperatorLessThan           Operator = "<"
+	OperatorLessThanOrEqual    Operator = "<="
+	OperatorGreaterThan        Operator = ">"
+	OperatorGreaterThanOrEqual Operator = ">="
+)
+
+// pathAliases maps Verifiable Credentials Data Model 2.0 property names to their 1.1 counterparts,
+// so they can be used to query either.
+var pathAliases = map[string]string{
+	"validFrom":  "issuanceDate",
+	"validUntil": "expirationDate",
+}
+
+// Comparison matches if the value at the given JSON path compares to the given value using the operator.
+// Range operators compare numerically if both values are numbers, chronologically if both values are dates (RFC3339 or yyyy-mm-dd),
+// and lexicographically otherwise.
+type Comparison struct {
+	// Path is a simple JSON path (child selectors only, e.g. credentialSubject.organization.name), without the '$.' prefix.
+	Path     string
+	Operator Operator
+	Value    string
+}
+
+func (c Comparison) Matches(credential map[string]interface{}) bool {
+	if c.Operator == OperatorNotEquals {
+		return !Comparison{Path: c.Path, Operator: OperatorEquals, Value: c.Value}.Matches(credential)
+	}
+	for _, value := range resolvePath(credential, c.Path) {
+		if c.matchesValue(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c Comparison) matchesValue(value interface{}) bool {
+	if value == nil {
+		return false
+	}
+	if (c.Operator == OperatorEquals || c.Operator == OperatorMatches) && strings.TrimSpace(c.Value) == "*" {
+		return true
+	}
+	actual, ok := scalarString(value)
+	if !ok {
+		return false
+	}
+	switch c.Operator {
+	case OperatorEquals:
+		return matchWildcard(actual, c.Value)
+	case OperatorMatches:
+		return matchWildcard(strings.ToLower(actual), strings.ToLower(c.Value))
+	}
+	result := compareValues(actual, c.Value)
+	switch c.Operator {
+	case OperatorLessThan:
+		return result < 0
+	case OperatorLessThanOrEqual:
+		return result <= 0
+	case OperatorGreaterThan:
+		return result > 0
+	case OperatorGreaterThanOrEqual:
+		return result >= 0
+	}
+	return false
+}
+
+// resolvePath returns the values at the given JSON path.
+// If the path traverses an array at the top level (e.g. type or credentialSubject), all elements are traversed.
+func resolvePath(document map[string]interface{}, path string) []interface{} {
+	current := []interface{}{document}
+	for i, key := range strings.Split(path, ".") {
+		var next []interface{}
+		for _, value := range current {
+			object, ok := value.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			child, exists := object[key]
+			if !exists && i == 0 {
+				child, exists = object[pathAliases[key]]
+			}
+			if !exists {
+				continue
+			}
+			if elements, isArray := child.([]interface{}); isArray && i == 0 {
+				next = append(next, elements...)
+			} else {
+				next = append(next, child)
+			}
+		}
+		current = next
+	}
+	return current
+}
+
+func scalarString(value interface{}) (string, bool) {
+	switch typed := value.(type) {
+	case string:
+		return typed, true
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(typed), true
+	}
+	return "", false
+}
+
+func matchWildcard(actual string, pattern string) bool {
+	prefix := strings.HasPrefix(pattern, "*")
+	suffix := strings.HasSuffix(pattern, "*")
+	pattern = strings.TrimSuffix(strings.TrimPrefix(pattern, "*"), "*")
+	switch {
+	case prefix && suffix:
+		return strings.Contains(actual, pattern)
+	case prefix:
+		return strings.HasSuffix(actual, pattern)
+	case suffix:
+		return strings.HasPrefix(actual, pattern)
+	default:
+		return actual == pattern
+	}
+}
+
+// compareValues compares two values numerically, chronologically or lexicographically, depending on whether both are numbers, dates or neither.
+func compareValues(a string, b string) int {
+	if aNumber, err := strconv.ParseFloat(a, 64); err == nil {
+		if bNumber, err := strconv.ParseFloat(b, 64); err == nil {
+			switch {
+			case aNumber < bNumber:
+				return -1
+			case aNumber > bNumber:
+				return 1
+			}
+			return 0
+		}
+	}
+	if aTime, ok := parseDate(a); ok {
+		if bTime, ok := parseDate(b); ok {
+			return aTime.Compare(bTime)
+		}
+	}
+	return strings.Compare(a, b)
+}
+
+func parseDate(value string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339Nano, time.DateOnly} {
+		if result, err := time.Parse(layout, value); err == nil {
+			return result, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// credentialToMap converts a credential to a JSON object, for evaluating expressions.
+func credentialToMap(credential vc.VerifiableCredential) (map[string]interface{}, error) {
+	var data []byte
+	var err error
+	if sdjwt.IsEnveloped(credential) {
+		// SD-JWT VCs are matched on the claims they disclose
+		var disclosed *vc.VerifiableCredential
+		if disclosed, err = sdjwt.Unwrap(credential); err != nil {
+			return nil, err
+		}
+		credential = *disclosed
+	}
+	if credential.Format() == vc.JWTCredentialProofFormat {
+		// JWT-VCs marshal to a JSON string, marshal an alias to get a JSON object with the VC properties instead.
+		type Alias vc.VerifiableCredential
+		data, err = json.Marshal(Alias(credential))
+	} else {
+		data, err = json.Marshal(credential)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var result map[string]interface{}
+	err = json.Unmarshal(data, &result)
+	return result, err
+}
+
+// searchCandidate is a presentation selected by the database, on which the query is evaluated.
+type searchCandidate struct {
+	presentation vc.VerifiablePresentation
+	// credentialIDs contains the IDs of the credentials in the presentation that were selected by the database.
+	// If nil, all credentials in the presentation are evaluated.
+	credentialIDs map[string]bool
+}
+
+// matchingCredentials returns the candidate's credentials that match the filter, as JSON objects.
+func matchingCredentials(filter Expression, candidate searchCandidate) []map[string]interface{} {
+	var result []map[string]interface{}
+	for _, credential := range candidate.presentation.VerifiableCredential {
+		asMap, err := credentialToMap(credential)
+		if err != nil {
+			continue
+		}
+		if candidate.credentialIDs != nil {
+			if id, _ := asMap["id"].(string); !candidate.credentialIDs[id] {
+				continue
+			}
+		}
+		if filter == nil || filter.Matches(asMap) {
+			result = append(result, asMap)
+		}
+	}
+	return result
+}
+
+// apply filters, sorts and paginates the given presentations.
+func (q Query) apply(searchCandidates []searchCandidate) []vc.VerifiablePresentation {
+	type candidate struct {
+		presentation vc.VerifiablePresentation
+		credentials  []map[string]interface{}
+	}
+	var candidates []candidate
+	for _, searchCandidate := range searchCandidates {
+		credentials := matchingCredentials(q.Filter, searchCandidate)
+		if q.Filter != nil && len(credentials) == 0 {
+			continue
+		}
+		candidates = append(candidates, candidate{presentation: searchCandidate.presentation, credentials: credentials})
+	}
+	sortValue := func(c candidate, path string) (string, bool) {
+		for _, credential := range c.credentials {
+			for _, value := range resolvePath(credential, path) {
+				if result, ok := scalarString(value); ok {
+					return result, true
+				}
+			}
+		}
+		return "", false
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		for _, field := range q.Sort {
+			a, aOK := sortValue(candidates[i], field.Path)
+			b, bOK := sortValue(candidates[j], field.Path)
+			if aOK != bOK {
+				// presentations without value last
+				return aOK
+			}
+			if result := compareValues(a, b); result != 0 {
+				if field.Descending {
+					return result > 0
+				}
+				return result < 0
+			}
+		}
+		// deterministic order for pagination
+		return candidates[i].presentation.ID.String() < candidates[j].presentation.ID.String()
+	})
+	if q.Offset >= len(candidates) {
+		return nil
+	}
+	candidates = candidates[q.Offset:]
+	if q.Limit > 0 && q.Limit < len(candidates) {
+		candidates = candidates[:q.Limit]
+	}
+	result := make([]vc.VerifiablePresentation, len(candidates))
+	for i, c := range candidates {
+		result[i] = c.presentation
+	}
+	return result
+}
+
+// ParseFilter parses a filter expression, consisting of comparisons combined with AND, OR, NOT and parentheses, e.g.:
+//
+//	credentialSubject.organization.name~"*hospital*" AND (credentialSubject.organization.city=Arnhem OR credentialSubject.organization.city=Nijmegen)
+//
+// Comparisons consist of a JSON path, an operator (=, !=, ~, <, <=, >, >=) and a value.
+// Values containing whitespace, parentheses, quotes or operator characters must be enclosed in double quotes.
+// AND takes precedence over OR, and may be omitted.
+func ParseFilter(input string) (Expression, error) {
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidQuery, err)
+	}
+	p := &filterParser{tokens: tokens}
+	if p.peek().kind == tokenEOF {
+		return nil, nil
+	}
+	result, err := p.parseOr()
+	if err == nil && p.peek().kind != tokenEOF {
+		err = fmt.Errorf("unexpected '%s'", p.peek().text)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidQuery, err)
+	}
+	return result, nil
+}
+
+// ParseSort parses a comma-separated list of JSON paths to sort on. Paths prefixed with '-' are sorted descending.
+func ParseSort(input string) []SortField {
+	var result []SortField
+	for _, path := range strings.Split(input, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		descending := strings.HasPrefix(path, "-")
+		result = append(result, SortField{Path: strings.TrimPrefix(path, "-"), Descending: descending})
+	}
+	return result
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOperator
+	tokenOpen
+	tokenClose
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+const operatorCharacters = "=!~<>"
+
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenOpen, text: "("})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenClose, text: ")"})
+			i++
+		case r == '"':
+			var value strings.Builder
+			i++
+			terminated := false
+			for i < len(runes) && !terminated {
+				switch {
+				case runes[i] == '\\' && i+1 < len(runes):
+					value.WriteRune(runes[i+1])
+					i += 2
+				case runes[i] == '"':
+					terminated = true
+					i++
+				default:
+					value.WriteRune(runes[i])
+					i++
+				}
+			}
+			if !terminated {
+				return nil, errors.New("unterminated string")
+			}
+			tokens = append(tokens, token{kind: tokenString, text: value.String()})
+		case strings.ContainsRune(operatorCharacters, r):
+			operator := string(r)
+			if (r == '!' || r == '<' || r == '>') && i+1 < len(runes) && runes[i+1] == '=' {
+				operator += "="
+			}
+			if operator == "!" {
+				return nil, errors.New("expected '=' after '!'")
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: operator})
+			i += len(operator)
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && !strings.ContainsRune(`()"`+operatorCharacters, runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: string(runes[start:i])})
+		}
+	}
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens   []token
+	position int
+	// depth is the current nesting depth of parentheses and NOT.
+	depth int
+}
+
+func (p *filterParser) peek() token {
+	if p.position >= len(p.tokens) {
+		return token{kind: tokenEOF}
+	}
+	return p.tokens[p.position]
+}
+
+func (p *filterParser) next() token {
+	result := p.peek()
+	p.position++
+	return result
+}
+
+func (p *filterParser) isKeyword(t token, keyword string) bool {
+	return t.kind == tokenWord && strings.EqualFold(t.text, keyword)
+}
+
+func (p *filterParser) parseOr() (Expression, error) {
+	var result Or
+	for {
+		expression, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, expression)
+		if !p.isKeyword(p.peek(), "OR") {
+			break
+		}
+		p.next()
+	}
+	if len(result) == 1 {
+		return result[0], nil
+	}
+	return result, nil
+}
+
+func (p *filterParser) parseAnd() (Expression, error) {
+	var result And
+	for {
+		expression, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, expression)
+		next := p.peek()
+		if p.isKeyword(next, "AND") {
+			p.next()
+		} else if next.kind != tokenOpen && (next.kind != tokenWord || p.isKeyword(next, "OR")) {
+			break
+		}
+		// else: implicit AND
+	}
+	if len(result) == 1 {
+		return result[0], nil
+	}
+	return result, nil
+}
+
+func (p *filterParser) parseUnary() (Expression, error) {
+	current := p.next()
+	if p.isKeyword(current, "NOT") || current.kind == tokenOpen {
+		p.depth++
+		defer func() { p.depth-- }()
+		if p.depth > maxFilterDepth {
+			return nil, fmt.Errorf("expression is nested more than %d levels deep", maxFilterDepth)
+		}
+	}
+	switch {
+	case p.isKeyword(current, "NOT"):
+		expression, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return Not{Expression: expression}, nil
+	case current.kind == tokenOpen:
+		expression, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next().kind != tokenClose {
+			return nil, errors.New("expected ')'")
+		}
+		return expression, nil
+	case current.kind == tokenWord:
+		operator := p.next()
+		if operator.kind != tokenOperator {
+			return nil, fmt.Errorf("expected operator after '%s'", current.text)
+		}
+		value := p.next()
+		if value.kind != tokenWord && value.kind != tokenString {
+			return nil, fmt.Errorf("expected value after '%s%s'", current.text, operator.text)
+		}
+		return Comparison{Path: current.text, Operator: Operator(operator.text), Value: value.text}, nil
+	case current.kind == tokenEOF:
+		return nil, errors.New("unexpected end of expression")
+	default:
+		return nil, fmt.Errorf("unexpected '%s'", current.text)
+	}
+}
@@ -0,0 +1,184 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+package discovery
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilter(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		testCases := []struct {
+			input    string
+			expected Expression
+		}{
+			{
+				input:    "issuer=did:web:example.com",
+				expected: Comparison{Path: "issuer", Operator: OperatorEquals, Value: "did:web:example.com"},
+			},
+			{
+				input: `a!=b c~"d e" f<1 g<=2 h>3 i>=2024-01-01T00:00:00Z`,
+				expected: And{
+					Comparison{Path: "a", Operator: OperatorNotEquals, Value: "b"},
+					Comparison{Path: "c", Operator: OperatorMatches, Value: "d e"},
+					Comparison{Path: "f", Operator: OperatorLessThan, Value: "1"},
+					Comparison{Path: "g", Operator: OperatorLessThanOrEqual, Value: "2"},
+					Comparison{Path: "h", Operator: OperatorGreaterThan, Value: "3"},
+					Comparison{Path: "i", Operator: OperatorGreaterThanOrEqual, Value: "2024-01-01T00:00:00Z"},
+				},
+			},
+			{
+				input: "a=1 AND b=2 OR c=3",
+				expected: Or{
+					And{Comparison{Path: "a", Operator: OperatorEquals, Value: "1"}, Comparison{Path: "b", Operator: OperatorEquals, Value: "2"}},
+					Comparison{Path: "c", Operator: OperatorEquals, Value: "3"},
+				},
+			},
+			{
+				input: "a=1 and (b=2 or not c=3)",
+				expected: And{
+					Comparison{Path: "a", Operator: OperatorEquals, Value: "1"},
+					Or{
+						Comparison{Path: "b", Operator: OperatorEquals, Value: "2"},
+						Not{Expression: Comparison{Path: "c", Operator: OperatorEquals, Value: "3"}},
+					},
+				},
+			},
+			{
+				input:    `a="quoted \"value\" with (parentheses) and = sign"`,
+				expected: Comparison{Path: "a", Operator: OperatorEquals, Value: `quoted "value" with (parentheses) and = sign`},
+			},
+			{
+				input:    "  ",
+				expected: nil,
+			},
+		}
+		for _, testCase := range testCases {
+			t.Run(testCase.input, func(t *testing.T) {
+				actual, err := ParseFilter(testCase.input)
+
+				require.NoError(t, err)
+				assert.Equal(t, testCase.expected, actual)
+			})
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		testCases := map[string]string{
+			"a":           "invalid query: expected operator after 'a'",
+			"a=":          "invalid query: expected value after 'a='",
+			"a!b":         "invalid query: expected '=' after '!'",
+			`a="b`:        "invalid query: unterminated string",
+			"(a=b":        "invalid query: expected ')'",
+			"a=b)":        "invalid query: unexpected ')'",
+			"a=b OR":      "invalid query: unexpected end of expression",
+			"=b":          "invalid query: unexpected '='",
+			"a=b AND NOT": "invalid query: unexpected end of expression",
+		}
+		for input, expected := range testCases {
+			t.Run(input, func(t *testing.T) {
+				_, err := ParseFilter(input)
+
+				assert.ErrorIs(t, err, ErrInvalidQuery)
+				assert.EqualError(t, err, expected)
+			})
+		}
+	})
+	t.Run("nested too deeply", func(t *testing.T) {
+		_, err := ParseFilter(strings.Repeat("(", maxFilterDepth) + "a=b" + strings.Repeat(")", maxFilterDepth))
+		require.NoError(t, err)
+
+		_, err = ParseFilter(strings.Repeat("(", maxFilterDepth+1) + "a=b" + strings.Repeat(")", maxFilterDepth+1))
+		assert.EqualError(t, err, "invalid query: expression is nested more than 32 levels deep")
+		_, err = ParseFilter(strings.Repeat("NOT ", maxFilterDepth+1) + "a=b")
+		assert.EqualError(t, err, "invalid query: expression is nested more than 32 levels deep")
+	})
+}
+
+func TestParseSort(t *testing.T) {
+	assert.Equal(t, []SortField{{Path: "a"}, {Path: "b.c", Descending: true}}, ParseSort("a, -b.c,"))
+	assert.Empty(t, ParseSort(""))
+}
+
+func TestComparison_Matches(t *testing.T) {
+	credential := map[string]interface{}{
+		"type":           []interface{}{"VerifiableCredential", "NutsOrganizationCredential"},
+		"issuer":         "did:web:example.com",
+		"issuanceDate":   "2024-01-01T00:00:00Z",
+		"expirationDate": "2025-06-01T12:00:00Z",
+		"credentialSubject": []interface{}{
+			map[string]interface{}{
+				"organization": map[string]interface{}{
+					"name": "Care Clinic",
+					"city": "Arnhem",
+				},
+				"beds":   float64(25),
+				"active": true,
+				"roles":  []interface{}{"a", "b"},
+			},
+		},
+	}
+	testCases := []struct {
+		filter   string
+		expected bool
+	}{
+		{"issuer=did:web:example.com", true},
+		{"issuer=did:web:other.com", false},
+		{"issuer!=did:web:other.com", true},
+		{"type=NutsOrganizationCredential", true},
+		{"type!=NutsOrganizationCredential", false},
+		{"credentialSubject.organization.name=Care*", true},
+		{"credentialSubject.organization.name=*Clinic", true},
+		{`credentialSubject.organization.name="*re Cl*"`, true},
+		{"credentialSubject.organization.name=care*", false},
+		{"credentialSubject.organization.name~care*", true},
+		{`credentialSubject.organization.name~"care clinic"`, true},
+		{"credentialSubject.organization=*", true},
+		{"credentialSubject.organization.unknown=*", false},
+		{"credentialSubject.organization=Arnhem", false},
+		{"credentialSubject.beds=25", true},
+		{"credentialSubject.beds>9", true},
+		{"credentialSubject.beds<100", true},
+		{"credentialSubject.beds>=25", true},
+		{"credentialSubject.beds>25", false},
+		{"credentialSubject.active=true", true},
+		{"credentialSubject.active<true", false},
+		{"credentialSubject.roles=a", false},
+		{"validFrom<=2024-01-01", true},
+		{"validFrom>2024-01-01", false},
+		{"validUntil>2025-06-01", true},
+		{"validUntil>=2025-06-01T13:00:00+02:00", true},
+		{"validUntil<2025-06-01T12:00:00Z", false},
+		{"expirationDate<2026-01-01", true},
+		{"credentialSubject.organization.city>Amsterdam", true},
+		{"credentialSubject.organization.city<Amsterdam", false},
+		{"credentialSubject.organization.city=Arnhem AND NOT issuer=did:web:example.com", false},
+		{"credentialSubject.organization.city=Nijmegen OR issuer=did:web:example.com", true},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.filter, func(t *testing.T) {
+			filter, err := ParseFilter(testCase.filter)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.expected, filter.Matches(credential))
+		})
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/google/uuid"
 	"github.com/nuts-foundation/go-did/vc"
@@ -147,8 +148,37 @@ func (l presentationRefreshError) TableName() string {
 	return "discovery_presentation_error"
 }
 
+// serviceDefinitionRecord is a Discovery Service definition that was added at runtime.
+type serviceDefinitionRecord struct {
+	ID string `gorm:"primaryKey"`
+	// Definition is the JSON-encoded ServiceDefinition.
+	Definition string
+	// Server indicates whether the node acts as Discovery Server for the service.
+	Server SQLBool
+}
+
+// TableName returns the table name for this DTO.
+func (d serviceDefinitionRecord) TableName() string {
+	return "discovery_service_definition"
+}
+
+// outboxRecord is a presentation registered on a replica of a Discovery Service, which still needs to be forwarded to the leader.
+type outboxRecord struct {
+	ServiceID              string `gorm:"primaryKey"`
+	PresentationID         string `gorm:"primaryKey"`
+	PresentationRaw        string
+	PresentationExpiration int64
+}
+
+// TableName returns the table name for this DTO.
+func (o outboxRecord) TableName() string {
+	return "discovery_presentation_outbox"
+}
+
 type sqlStore struct {
 	db *gorm.DB
+	// onExpired is called with the presentations that were removed because they expired, if set.
+	onExpired func(records []presentationRecord)
 }
 
 func newSQLStore(db *gorm.DB, clientDefinitions map[string]ServiceDefinition) (*sqlStore, error) {
@@ -261,40 +291,196 @@ func (s *sqlStore) get(serviceID string, startAfter int) (map[string]vc.Verifiab
 	return presentations, service.Seed, service.LastLamportTimestamp, nil
 }
 
+// maxSearchCandidates is the maximum number of presentations a search loads to evaluate its filter or sort on.
+var maxSearchCandidates = 10000
+
 // search searches for presentations, registered on the given service, matching the given query.
-// The query is a map of JSON paths and expected string values, matched against the presentation's credentials.
-// Wildcard matching is supported by prefixing or suffixing the value with an asterisk (*).
-// It returns the presentations which contain credentials that match the given query.
-func (s *sqlStore) search(serviceID string, query map[string]string, allowUnvalidated bool) ([]vc.VerifiablePresentation, error) {
-	// first only select columns also used in group by clause
-	// if the query is empty, there's no need to do a join
-	stmt := s.db.Model(&presentationRecord{}).Select("discovery_presentation.id").
-		Where("service_id = ?", serviceID)
-	if !allowUnvalidated {
-		stmt = stmt.Where("validated != 0")
-	}
-	if len(query) > 0 {
-		stmt = applyQuery(stmt, query)
+// The database selects the presentations matching the query's properties and the part of the filter it can evaluate.
+// If the query has a filter or sort, they're evaluated on the selected presentations, which returns ErrQueryTooBroad if there are more than maxSearchCandidates.
+// Otherwise, the database paginates the results.
+// It returns the presentations which contain credentials that match the query, sorted and paginated as specified by the query.
+func (s *sqlStore) search(serviceID string, query Query, allowUnvalidated bool) ([]vc.VerifiablePresentation, error) {
+	filterClause, filterArgs, narrowed := narrowFilter(query.Filter)
+	joinCredentials := len(query.Properties) > 0 || narrowed
+	// selection builds a new statement each time, since Gorm statements can't be reused
+	selection := func() *gorm.DB {
+		stmt := s.db.Model(&presentationRecord{}).
+			Where("service_id = ? AND presentation_expiration > ?", serviceID, time.Now().Unix())
+		if !allowUnvalidated {
+			stmt = stmt.Where("validated != 0")
+		}
+		// if the query is empty, there's no need to do a join
+		if joinCredentials {
+			stmt = applyQuery(stmt, query.Properties)
+			if narrowed {
+				stmt = stmt.Where(filterClause, filterArgs...)
+			}
+		}
+		return stmt
 	}
-	stmt = stmt.Group("discovery_presentation.id")
+	// first only select columns also used in group by clause
+	selectedIDs := selection().Select("discovery_presentation.id").Group("discovery_presentation.id")
+	main := s.db.Model(&presentationRecord{}).Where("id in (?)", selectedIDs).Order("presentation_id")
 
+	evaluate := query.Filter != nil || len(query.Sort) > 0
+	if !evaluate {
+		if query.Offset > 0 {
+			main = main.Offset(query.Offset)
+		}
+		if query.Limit > 0 {
+			main = main.Limit(query.Limit)
+		}
+	} else {
+		var count int64
+		if err := s.db.Table("(?) AS candidates", selectedIDs).Count(&count).Error; err != nil {
+			return nil, err
+		}
+		if count > int64(maxSearchCandidates) {
+			return nil, ErrQueryTooBroad
+		}
+	}
 	var matches []presentationRecord
-	main := s.db.Preload("Credentials").Preload("Credentials.Credential").Model(&presentationRecord{}).Where("id in (?)", stmt)
 	if err := main.Find(&matches).Error; err != nil {
 		return nil, err
 	}
+	// the credentials selected by the database, so the filter and sort are only evaluated on those
+	var selectedCredentials map[string]map[string]bool
+	if evaluate && joinCredentials {
+		var pairs []struct {
+			PresentationID string
+			CredentialID   string
+		}
+		if err := selection().Select("discovery_presentation.id AS presentation_id, credential.id AS credential_id").Find(&pairs).Error; err != nil {
+			return nil, err
+		}
+		selectedCredentials = make(map[string]map[string]bool)
+		for _, pair := range pairs {
+			if selectedCredentials[pair.PresentationID] == nil {
+				selectedCredentials[pair.PresentationID] = make(map[string]bool)
+			}
+			selectedCredentials[pair.PresentationID][pair.CredentialID] = true
+		}
+	}
 	var results []vc.VerifiablePresentation
+	var candidates []searchCandidate
 	for _, match := range matches {
-		if match.PresentationExpiration <= time.Now().Unix() {
-			continue
-		}
 		presentation, err := vc.ParseVerifiablePresentation(match.PresentationRaw)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse presentation '%s': %w", match.PresentationID, err)
 		}
-		results = append(results, *presentation)
+		if !evaluate {
+			results = append(results, *presentation)
+			continue
+		}
+		candidate := searchCandidate{presentation: *presentation}
+		if selectedCredentials != nil {
+			// presentations without selected credentials don't match, an empty (non-nil) set excludes all credentials
+			candidate.credentialIDs = selectedCredentials[match.ID]
+			if candidate.credentialIDs == nil {
+				candidate.credentialIDs = map[string]bool{}
+			}
+		}
+		candidates = append(candidates, candidate)
+	}
+	if !evaluate {
+		return results, nil
+	}
+	return query.apply(candidates), nil
+}
+
+// filterPropertyColumns maps JSON paths of credential properties to the columns they're stored in, for evaluating filters.
+// The type column isn't included, since it only contains one of the credential's types.
+var filterPropertyColumns = map[string]string{
+	"id":                   "credential.id",
+	"issuer":               "credential.issuer",
+	"credentialSubject.id": "credential.subject_id",
+}
+
+// narrowFilter translates the filter to a SQL condition on the credential table, that selects (at least) the credentials matching the filter.
+// The condition might select more credentials (e.g. LIKE being case-insensitive on some databases),
+// since the filter is evaluated on the selected presentations afterwards.
+// It returns false if the filter can't be translated: the credential properties are indexed as strings,
+// so NOT, !=, range comparisons and comparisons on numbers or booleans can't be evaluated by the database.
+func narrowFilter(filter Expression) (string, []interface{}, bool) {
+	switch typed := filter.(type) {
+	case Comparison:
+		return narrowComparison(typed)
+	case And:
+		// comparisons that can't be translated are left out, which selects more credentials
+		var clauses []string
+		var args []interface{}
+		for _, expression := range typed {
+			if clause, clauseArgs, ok := narrowFilter(expression); ok {
+				clauses = append(clauses, "("+clause+")")
+				args = append(args, clauseArgs...)
+			}
+		}
+		return strings.Join(clauses, " AND "), args, len(clauses) > 0
+	case Or:
+		// all alternatives must be translated, otherwise credentials matching the others wouldn't be selected
+		var clauses []string
+		var args []interface{}
+		for _, expression := range typed {
+			clause, clauseArgs, ok := narrowFilter(expression)
+			if !ok {
+				return "", nil, false
+			}
+			clauses = append(clauses, "("+clause+")")
+			args = append(args, clauseArgs...)
+		}
+		return strings.Join(clauses, " OR "), args, len(clauses) > 0
+	}
+	return "", nil, false
+}
+
+func narrowComparison(comparison Comparison) (string, []interface{}, bool) {
+	if comparison.Operator != OperatorEquals && comparison.Operator != OperatorMatches {
+		return "", nil, false
+	}
+	column := filterPropertyColumns[comparison.Path]
+	if column == "" && !strings.HasPrefix(comparison.Path, "credentialSubject.") {
+		return "", nil, false
+	}
+	value := comparison.Value
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "*" || trimmed == "true" || trimmed == "false" {
+		// might match values that aren't indexed
+		return "", nil, false
+	}
+	if _, err := strconv.ParseFloat(trimmed, 64); err == nil {
+		return "", nil, false
+	}
+	lower := comparison.Operator == OperatorMatches
+	if lower && strings.IndexFunc(value, func(r rune) bool { return r > unicode.MaxASCII }) != -1 {
+		// LOWER() only supports ASCII on some databases
+		return "", nil, false
+	}
+	// sort out wildcard mode: prefix and postfix asterisks (*) are replaced with %, which then is used in a LIKE query.
+	// Otherwise, exact match (=) is used. Case-insensitive matches compare lowercase values.
+	op := "="
+	if strings.HasPrefix(value, "*") {
+		value = "%" + value[1:]
+		op = "LIKE"
+	}
+	if strings.HasSuffix(value, "*") {
+		value = value[:len(value)-1] + "%"
+		op = "LIKE"
+	}
+	if lower {
+		value = strings.ToLower(value)
+	}
+	if column != "" {
+		if lower {
+			column = "LOWER(" + column + ")"
+		}
+		return column + " " + op + " ?", []interface{}{value}, true
 	}
-	return results, nil
+	valueColumn := "cp.value"
+	if lower {
+		valueColumn = "LOWER(" + valueColumn + ")"
+	}
+	return "EXISTS (SELECT 1 FROM credential_prop cp WHERE cp.credential_id = credential.id AND cp.path = ? AND " + valueColumn + " " + op + " ?)",
+		[]interface{}{comparison.Path, value}, true
 }
 
 // applyQuery is like vcr/credential/store/sql.go#BuildSearchStatement but for searching VPs a group by is needed which also requires a sub query
@@ -408,23 +594,63 @@ func (s *sqlStore) exists(serviceID string, credentialSubjectID string, presenta
 	return count > 0, nil
 }
 
+// findPresentation returns the presentation of the given subject that is registered on a service.
+// It returns nil if the subject has no presentation registered on the service.
+func (s *sqlStore) findPresentation(serviceID string, credentialSubjectID string) (*presentationRecord, error) {
+	var result []presentationRecord
+	if err := s.db.Where("service_id = ? AND credential_subject_id = ?", serviceID, credentialSubjectID).
+		Limit(1).Find(&result).Error; err != nil {
+		return nil, fmt.Errorf("find presentation: %w", err)
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+	return &result[0], nil
+}
+
 func (s *sqlStore) prune() error {
-	num, err := s.removeExpired()
+	removed, err := s.removeExpired()
 	if err != nil {
 		return err
 	}
-	if num > 0 {
-		log.Logger().Debugf("Pruned %d expired presentations", num)
+	if len(removed) > 0 {
+		log.Logger().Debugf("Pruned %d expired presentations", len(removed))
+		if s.onExpired != nil {
+			s.onExpired(removed)
+		}
 	}
 	return nil
 }
 
-func (s *sqlStore) removeExpired() (int, error) {
-	result := s.db.Where("presentation_expiration < ?", time.Now().Unix()).Delete(presentationRecord{})
-	if result.Error != nil {
-		return 0, fmt.Errorf("prune presentations: %w", result.Error)
+// deleteBatchSize is the maximum number of records deleted by ID in a single query.
+var deleteBatchSize = 500
+
+// removeExpired removes all expired presentations and returns them.
+func (s *sqlStore) removeExpired() ([]presentationRecord, error) {
+	var expired []presentationRecord
+	if err := s.db.Where("presentation_expiration < ?", time.Now().Unix()).Find(&expired).Error; err != nil {
+		return nil, fmt.Errorf("prune presentations: %w", err)
 	}
-	return int(result.RowsAffected), nil
+	if len(expired) == 0 {
+		return nil, nil
+	}
+	ids := make([]string, len(expired))
+	for i, record := range expired {
+		ids[i] = record.ID
+	}
+	// delete in batches, since databases limit the number of parameters in a query
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		for batch := range slices.Chunk(ids, deleteBatchSize) {
+			if err := tx.Delete(&presentationRecord{}, "id IN ?", batch).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("prune presentations: %w", err)
+	}
+	return expired, nil
 }
 
 // allPresentations returns all presentations, the validated param can be used to select validated or unvalidated presentations
@@ -443,6 +669,16 @@ func (s *sqlStore) allPresentations(validated bool) ([]presentationRecord, error
 	return result, nil
 }
 
+// servicePresentations returns all presentations on the given service.
+func (s *sqlStore) servicePresentations(serviceID string) ([]presentationRecord, error) {
+	result := make([]presentationRecord, 0)
+	err := s.db.Where("service_id = ?", serviceID).Find(&result).Error
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // updateValidated sets the validated flag for the given presentations
 func (s *sqlStore) updateValidated(records []presentationRecord) error {
 	return s.db.Transaction(func(tx *gorm.DB) error {
@@ -505,6 +741,19 @@ func (s *sqlStore) getSubjectsToBeRefreshed(now time.Time) ([]refreshCandidate,
 	if err := s.db.Model(&presentationRefreshRecord{}).Find(&candidates, "next_refresh < ?", now.Unix()).Error; err != nil {
 		return nil, err
 	}
+	return toRefreshCandidates(candidates)
+}
+
+// getSubjectRegistrations returns the services the given subject should be registered on.
+func (s *sqlStore) getSubjectRegistrations(subjectID string) ([]refreshCandidate, error) {
+	var records []presentationRefreshRecord
+	if err := s.db.Model(&presentationRefreshRecord{}).Find(&records, "subject_id = ?", subjectID).Error; err != nil {
+		return nil, err
+	}
+	return toRefreshCandidates(records)
+}
+
+func toRefreshCandidates(candidates []presentationRefreshRecord) ([]refreshCandidate, error) {
 	result := make([]refreshCandidate, len(candidates))
 	for i, candidate := range candidates {
 		c := refreshCandidate{
@@ -582,9 +831,9 @@ func (s *sqlStore) getSubjectVPsOnService(serviceID string, subjectDIDs []did.DI
 	// get all VPs with a credential that has one of subjectDIDs as credentialSubject.id
 	var vps []vc.VerifiablePresentation
 	for _, subjectDID := range subjectDIDs {
-		loopVPs, err := s.search(serviceID, map[string]string{
+		loopVPs, err := s.search(serviceID, Query{Properties: map[string]string{
 			"credentialSubject.id": subjectDID.String(),
-		}, true)
+		}}, true)
 		if err != nil {
 			return nil, err
 		}
@@ -649,3 +898,93 @@ func (s *sqlStore) wipeOnSeedChange(serviceID string, seed string) error {
 		return nil
 	})
 }
+
+// getDefinitions returns the Discovery Service definitions that were added at runtime,
+// and the subset of definitions for which the node acts as Discovery Server.
+func (s *sqlStore) getDefinitions() (map[string]ServiceDefinition, map[string]ServiceDefinition, error) {
+	var records []serviceDefinitionRecord
+	if err := s.db.Find(&records).Error; err != nil {
+		return nil, nil, err
+	}
+	all := make(map[string]ServiceDefinition, len(records))
+	served := make(map[string]ServiceDefinition)
+	for _, record := range records {
+		var definition ServiceDefinition
+		if err := json.Unmarshal([]byte(record.Definition), &definition); err != nil {
+			return nil, nil, fmt.Errorf("invalid service definition (id=%s): %w", record.ID, err)
+		}
+		all[definition.ID] = definition
+		if record.Server.Bool() {
+			served[definition.ID] = definition
+		}
+	}
+	return all, served, nil
+}
+
+// putDefinition creates or updates a Discovery Service definition that was added at runtime.
+// It also creates the service record, if it doesn't exist yet.
+func (s *sqlStore) putDefinition(definition ServiceDefinition, server bool) error {
+	data, err := json.Marshal(definition)
+	if err != nil {
+		return err
+	}
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		record := serviceDefinitionRecord{
+			ID:         definition.ID,
+			Definition: string(data),
+			Server:     SQLBool(server),
+		}
+		if err := tx.Save(&record).Error; err != nil {
+			return err
+		}
+		return tx.FirstOrCreate(&serviceRecord{ID: definition.ID}, "id = ?", definition.ID).Error
+	})
+}
+
+// deleteDefinition removes a Discovery Service definition that was added at runtime,
+// together with the presentations and activations of the service.
+func (s *sqlStore) deleteDefinition(serviceID string) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		for _, record := range []interface{}{&presentationRefreshError{}, &presentationRefreshRecord{}, &presentationRecord{}, &outboxRecord{}} {
+			if err := tx.Where("service_id = ?", serviceID).Delete(record).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Delete(&serviceRecord{}, "id = ?", serviceID).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&serviceDefinitionRecord{}, "id = ?", serviceID).Error
+	})
+}
+
+// addToOutbox stores a presentation that needs to be forwarded to the leader of the Discovery Service.
+// If the presentation is already in the outbox, it is replaced.
+func (s *sqlStore) addToOutbox(serviceID string, presentation vc.VerifiablePresentation) error {
+	if presentation.ID == nil {
+		return errPresentationWithoutID
+	}
+	return s.db.Save(&outboxRecord{
+		ServiceID:              serviceID,
+		PresentationID:         presentation.ID.String(),
+		PresentationRaw:        presentation.Raw(),
+		PresentationExpiration: presentation.JWT().Expiration().Unix(),
+	}).Error
+}
+
+// getOutbox returns the presentations that still need to be forwarded to the leader of a Discovery Service.
+// Expired presentations are removed from the outbox.
+func (s *sqlStore) getOutbox() ([]outboxRecord, error) {
+	if err := s.db.Where("presentation_expiration < ?", time.Now().Unix()).Delete(&outboxRecord{}).Error; err != nil {
+		return nil, fmt.Errorf("prune outbox: %w", err)
+	}
+	var result []outboxRecord
+	if err := s.db.Order("presentation_expiration").Find(&result).Error; err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// removeFromOutbox removes a presentation from the outbox, after it was forwarded to the leader of the Discovery Service.
+func (s *sqlStore) removeFromOutbox(serviceID string, presentationID string) error {
+	return s.db.Delete(&outboxRecord{}, "service_id = ? AND presentation_id = ?", serviceID, presentationID).Error
+}
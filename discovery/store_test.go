@@ -213,7 +213,7 @@ func Test_sqlStore_search(t *testing.T) {
 
 	t.Run("empty database", func(t *testing.T) {
 		c := setupStore(t, storageEngine.GetSQLDatabase())
-		actualVPs, err := c.search(testServiceID, map[string]string{}, true)
+		actualVPs, err := c.search(testServiceID, Query{}, true)
 		require.NoError(t, err)
 		require.Len(t, actualVPs, 0)
 	})
@@ -225,9 +225,9 @@ func Test_sqlStore_search(t *testing.T) {
 			require.NoError(t, err)
 		}
 
-		actualVPs, err := c.search(testServiceID, map[string]string{
+		actualVPs, err := c.search(testServiceID, Query{Properties: map[string]string{
 			"credentialSubject.person.givenName": "Alice",
-		}, true)
+		}}, true)
 		require.NoError(t, err)
 		require.Len(t, actualVPs, 1)
 		assert.Equal(t, vpAlice.ID.String(), actualVPs[0].ID.String())
@@ -240,25 +240,106 @@ func Test_sqlStore_search(t *testing.T) {
 			require.NoError(t, err)
 		}
 
-		actualVPs, err := c.search(testServiceID, map[string]string{}, true)
+		actualVPs, err := c.search(testServiceID, Query{}, true)
 		require.NoError(t, err)
 		require.Len(t, actualVPs, 2)
 
 		t.Run("wildcard", func(t *testing.T) {
-			actualVPs, err = c.search(testServiceID, map[string]string{"credentialSubject.person.givenName": "*"}, true)
+			actualVPs, err = c.search(testServiceID, Query{Properties: map[string]string{"credentialSubject.person.givenName": "*"}}, true)
 			require.NoError(t, err)
 			require.Len(t, actualVPs, 2)
 		})
 		t.Run("wildcard postfix", func(t *testing.T) {
-			actualVPs, err = c.search(testServiceID, map[string]string{"credentialSubject.person.givenName": "A*"}, true)
+			actualVPs, err = c.search(testServiceID, Query{Properties: map[string]string{"credentialSubject.person.givenName": "A*"}}, true)
 			require.NoError(t, err)
 			require.Len(t, actualVPs, 1)
 		})
 		t.Run("validated", func(t *testing.T) {
-			actualVPs, err = c.search(testServiceID, map[string]string{}, false)
+			actualVPs, err = c.search(testServiceID, Query{}, false)
 			require.NoError(t, err)
 			require.Len(t, actualVPs, 0)
 		})
+		t.Run("filter", func(t *testing.T) {
+			search := func(t *testing.T, query Query) []string {
+				actualVPs, err := c.search(testServiceID, query, true)
+				require.NoError(t, err)
+				var ids []string
+				for _, vp := range actualVPs {
+					ids = append(ids, vp.ID.String())
+				}
+				return ids
+			}
+			filter := func(t *testing.T, expression string) Expression {
+				result, err := ParseFilter(expression)
+				require.NoError(t, err)
+				return result
+			}
+			t.Run("OR", func(t *testing.T) {
+				ids := search(t, Query{Filter: filter(t, "credentialSubject.person.givenName=Alice OR credentialSubject.person.familyName=Jomper")})
+				assert.ElementsMatch(t, []string{vpAlice.ID.String(), vpBob.ID.String()}, ids)
+			})
+			t.Run("case-insensitive", func(t *testing.T) {
+				ids := search(t, Query{Filter: filter(t, "credentialSubject.person.givenName~alice")})
+				assert.Equal(t, []string{vpAlice.ID.String()}, ids)
+			})
+			t.Run("case-sensitive", func(t *testing.T) {
+				ids := search(t, Query{Filter: filter(t, "credentialSubject.person.givenName=alice")})
+				assert.Empty(t, ids)
+			})
+			t.Run("NOT", func(t *testing.T) {
+				ids := search(t, Query{Filter: filter(t, "credentialSubject.person.familyName=* AND NOT credentialSubject.person.givenName=Alice")})
+				assert.Equal(t, []string{vpBob.ID.String()}, ids)
+			})
+			t.Run("OR with comparison that can't be evaluated by the database", func(t *testing.T) {
+				ids := search(t, Query{Filter: filter(t, "credentialSubject.person.givenName=Alice OR NOT credentialSubject.person.givenName=Alice")})
+				assert.ElementsMatch(t, []string{vpAlice.ID.String(), vpBob.ID.String()}, ids)
+			})
+			t.Run("properties and filter must match the same credential", func(t *testing.T) {
+				ids := search(t, Query{
+					Properties: map[string]string{"credentialSubject.person.givenName": "Alice"},
+					Filter:     filter(t, "NOT credentialSubject.person.givenName=Alice"),
+				})
+				assert.Empty(t, ids)
+			})
+			t.Run("too many presentations selected", func(t *testing.T) {
+				defer func(old int) { maxSearchCandidates = old }(maxSearchCandidates)
+				maxSearchCandidates = 1
+
+				_, err := c.search(testServiceID, Query{Filter: filter(t, "NOT credentialSubject.person.givenName=Alice")}, true)
+
+				assert.ErrorIs(t, err, ErrQueryTooBroad)
+				assert.ErrorIs(t, err, ErrInvalidQuery)
+				t.Run("narrowed down by the database", func(t *testing.T) {
+					ids := search(t, Query{Filter: filter(t, "credentialSubject.person.givenName=Alice NOT credentialSubject.person.familyName=Jomper")})
+
+					assert.Equal(t, []string{vpAlice.ID.String()}, ids)
+				})
+				t.Run("paginated by the database", func(t *testing.T) {
+					ids := search(t, Query{Limit: 1})
+
+					assert.Len(t, ids, 1)
+				})
+			})
+			t.Run("sort and paginate", func(t *testing.T) {
+				query := Query{
+					Filter: filter(t, "credentialSubject.person.givenName=*"),
+					Sort:   ParseSort("-credentialSubject.person.givenName"),
+				}
+				assert.Equal(t, []string{vpBob.ID.String(), vpAlice.ID.String()}, search(t, query))
+				query.Offset = 1
+				query.Limit = 1
+				assert.Equal(t, []string{vpAlice.ID.String()}, search(t, query))
+				query.Offset = 2
+				assert.Empty(t, search(t, query))
+			})
+			t.Run("paginate without filter", func(t *testing.T) {
+				all := search(t, Query{})
+				require.Len(t, all, 2)
+				assert.Equal(t, all[1:], search(t, Query{Offset: 1}))
+				assert.Equal(t, all[:1], search(t, Query{Limit: 1}))
+				assert.Empty(t, search(t, Query{Offset: 2, Limit: 1}))
+			})
+		})
 	})
 	t.Run("not found", func(t *testing.T) {
 		vps := []vc.VerifiablePresentation{vpAlice, vpBob}
@@ -267,14 +348,14 @@ func Test_sqlStore_search(t *testing.T) {
 			_, err := c.add(testServiceID, vp, testSeed, 0)
 			require.NoError(t, err)
 		}
-		actualVPs, err := c.search(testServiceID, map[string]string{
+		actualVPs, err := c.search(testServiceID, Query{Properties: map[string]string{
 			"credentialSubject.person.givenName": "Charlie",
-		}, true)
+		}}, true)
 		require.NoError(t, err)
 		require.Len(t, actualVPs, 0)
 
 		t.Run("wildcard", func(t *testing.T) {
-			actualVPs, err = c.search(testServiceID, map[string]string{"credentialSubject.person.noName": "*"}, true)
+			actualVPs, err = c.search(testServiceID, Query{Properties: map[string]string{"credentialSubject.person.noName": "*"}}, true)
 			require.NoError(t, err)
 			require.Len(t, actualVPs, 0)
 		})
@@ -282,6 +363,43 @@ func Test_sqlStore_search(t *testing.T) {
 	})
 }
 
+func Test_narrowFilter(t *testing.T) {
+	filter, err := ParseFilter(`issuer=did:web:example.com credentialSubject.name~"*Care*" credentialSubject.beds=25 credentialSubject.name=* validUntil=2025-01-01 type=Foo credentialSubject.name>a (a=b OR c=d) NOT credentialSubject.name=x credentialSubject.city~"*é*"`)
+	require.NoError(t, err)
+
+	clause, args, ok := narrowFilter(filter)
+
+	assert.True(t, ok)
+	assert.Equal(t, "(credential.issuer = ?) AND (EXISTS (SELECT 1 FROM credential_prop cp WHERE cp.credential_id = credential.id AND cp.path = ? AND LOWER(cp.value) LIKE ?))", clause)
+	assert.Equal(t, []interface{}{"did:web:example.com", "credentialSubject.name", "%care%"}, args)
+	t.Run("OR", func(t *testing.T) {
+		filter, err := ParseFilter(`issuer=a OR credentialSubject.id=b*`)
+		require.NoError(t, err)
+
+		clause, args, ok := narrowFilter(filter)
+
+		assert.True(t, ok)
+		assert.Equal(t, "(credential.issuer = ?) OR (credential.subject_id LIKE ?)", clause)
+		assert.Equal(t, []interface{}{"a", "b%"}, args)
+	})
+	t.Run("OR with comparison that can't be translated", func(t *testing.T) {
+		filter, err := ParseFilter(`issuer=a OR credentialSubject.name>b`)
+		require.NoError(t, err)
+
+		_, _, ok := narrowFilter(filter)
+
+		assert.False(t, ok)
+	})
+	t.Run("nothing to translate", func(t *testing.T) {
+		_, _, ok := narrowFilter(Not{Expression: Comparison{Path: "issuer", Operator: OperatorEquals, Value: "a"}})
+		assert.False(t, ok)
+		_, _, ok = narrowFilter(And{Comparison{Path: "issuer", Operator: OperatorNotEquals, Value: "a"}})
+		assert.False(t, ok)
+		_, _, ok = narrowFilter(nil)
+		assert.False(t, ok)
+	})
+}
+
 func Test_sqlStore_getSubjectsToBeRefreshed(t *testing.T) {
 	storageEngine := storage.NewTestStorageEngine(t)
 	require.NoError(t, storageEngine.Start())
@@ -449,10 +567,10 @@ func Test_sqlStore_wipeOnSeedChange(t *testing.T) {
 		err = c.wipeOnSeedChange(testServiceID, "other")
 		require.NoError(t, err)
 
-		vps, err := c.search(testServiceID, map[string]string{}, true)
+		vps, err := c.search(testServiceID, Query{}, true)
 		require.NoError(t, err)
 		require.Len(t, vps, 0)
-		vps, err = c.search("other", map[string]string{}, true)
+		vps, err = c.search("other", Query{}, true)
 		require.NoError(t, err)
 		require.Len(t, vps, 1)
 	})
@@ -511,6 +629,122 @@ func Test_sqlStore_delete(t *testing.T) {
 	assert.Len(t, result, 0)
 }
 
+func Test_sqlStore_servicePresentations(t *testing.T) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	t.Cleanup(func() {
+		_ = storageEngine.Shutdown()
+	})
+
+	c := setupStore(t, storageEngine.GetSQLDatabase())
+	_, err := c.add(testServiceID, vpAlice, testSeed, 0)
+	require.NoError(t, err)
+	_, err = c.add("other", vpBob, testSeed, 0)
+	require.NoError(t, err)
+
+	presentations, err := c.servicePresentations(testServiceID)
+
+	require.NoError(t, err)
+	require.Len(t, presentations, 1)
+	assert.Equal(t, vpAlice.ID.String(), presentations[0].PresentationID)
+}
+
+func Test_sqlStore_definitions(t *testing.T) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	t.Cleanup(func() {
+		_ = storageEngine.Shutdown()
+	})
+	definition := testDefinitions()["other"]
+	definition.ID = "runtime"
+
+	t.Run("put", func(t *testing.T) {
+		c := setupStore(t, storageEngine.GetSQLDatabase())
+
+		require.NoError(t, c.putDefinition(definition, false))
+
+		all, served, err := c.getDefinitions()
+		require.NoError(t, err)
+		assert.Equal(t, map[string]ServiceDefinition{"runtime": definition}, all)
+		assert.Empty(t, served)
+		timestamp, err := c.getTimestamp("runtime")
+		require.NoError(t, err)
+		assert.Equal(t, 0, timestamp)
+
+		t.Run("update", func(t *testing.T) {
+			updated := definition
+			updated.Endpoint = "http://example.com/runtime"
+
+			require.NoError(t, c.putDefinition(updated, true))
+
+			all, served, err := c.getDefinitions()
+			require.NoError(t, err)
+			assert.Equal(t, map[string]ServiceDefinition{"runtime": updated}, all)
+			assert.Equal(t, map[string]ServiceDefinition{"runtime": updated}, served)
+		})
+	})
+	t.Run("delete", func(t *testing.T) {
+		c := setupStore(t, storageEngine.GetSQLDatabase())
+		require.NoError(t, c.putDefinition(definition, false))
+		_, err := c.add("runtime", vpAlice, testSeed, 0)
+		require.NoError(t, err)
+		_, err = c.add(testServiceID, vpAlice, testSeed, 0)
+		require.NoError(t, err)
+		require.NoError(t, c.updatePresentationRefreshTime("runtime", aliceSubject, nil, to.Ptr(time.Now().Add(time.Second))))
+		require.NoError(t, c.setPresentationRefreshError("runtime", aliceSubject, assert.AnError))
+
+		err = c.deleteDefinition("runtime")
+
+		require.NoError(t, err)
+		all, _, err := c.getDefinitions()
+		require.NoError(t, err)
+		assert.Empty(t, all)
+		presentations, err := c.allPresentations(false)
+		require.NoError(t, err)
+		require.Len(t, presentations, 1)
+		assert.Equal(t, testServiceID, presentations[0].ServiceID)
+		refreshRecord, err := c.getPresentationRefreshRecord("runtime", aliceSubject)
+		require.NoError(t, err)
+		assert.Nil(t, refreshRecord)
+		assert.Nil(t, getPresentationRefreshError(t, c.db, "runtime", aliceSubject))
+		var count int64
+		require.NoError(t, c.db.Model(&serviceRecord{}).Where("id = ?", "runtime").Count(&count).Error)
+		assert.Zero(t, count)
+	})
+}
+
+func Test_sqlStore_outbox(t *testing.T) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	t.Cleanup(func() {
+		_ = storageEngine.Shutdown()
+	})
+	c := setupStore(t, storageEngine.GetSQLDatabase())
+	expiredVP := createPresentationCustom(aliceDID, func(claims map[string]interface{}, _ *vc.VerifiablePresentation) {
+		claims[jwt.ExpirationKey] = time.Now().Add(-time.Minute).Unix()
+	}, vcAlice)
+
+	require.NoError(t, c.addToOutbox(testServiceID, vpAlice))
+	require.NoError(t, c.addToOutbox(testServiceID, vpAlice))
+	require.NoError(t, c.addToOutbox("other", vpBob))
+	require.NoError(t, c.addToOutbox(testServiceID, expiredVP))
+
+	outbox, err := c.getOutbox()
+	require.NoError(t, err)
+	require.Len(t, outbox, 2)
+	assert.ElementsMatch(t, []string{vpAlice.ID.String(), vpBob.ID.String()}, []string{outbox[0].PresentationID, outbox[1].PresentationID})
+
+	t.Run("remove", func(t *testing.T) {
+		require.NoError(t, c.removeFromOutbox(testServiceID, vpAlice.ID.String()))
+
+		outbox, err := c.getOutbox()
+		require.NoError(t, err)
+		require.Len(t, outbox, 1)
+		assert.Equal(t, "other", outbox[0].ServiceID)
+		assert.Equal(t, vpBob.Raw(), outbox[0].PresentationRaw)
+	})
+}
+
 func setupStore(t *testing.T, db *gorm.DB) *sqlStore {
 	resetStore(t, db)
 	defs := testDefinitions()
@@ -521,7 +755,7 @@ func setupStore(t *testing.T, db *gorm.DB) *sqlStore {
 
 func resetStore(t *testing.T, db *gorm.DB) {
 	// related tables are emptied due to on-deletePresentationRecord-cascade clause
-	tableNames := []string{"discovery_service", "discovery_presentation", "discovery_credential", "credential", "credential_prop"}
+	tableNames := []string{"discovery_service_definition", "discovery_service", "discovery_presentation", "discovery_credential", "credential", "credential_prop"}
 	for _, tableName := range tableNames {
 		require.NoError(t, db.Exec("DELETE FROM "+tableName).Error)
 	}
@@ -536,3 +770,64 @@ func getPresentationRefreshError(t *testing.T, db *gorm.DB, serviceID string, su
 	}
 	return &row
 }
+
+func Test_sqlStore_prune(t *testing.T) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	t.Cleanup(func() {
+		_ = storageEngine.Shutdown()
+	})
+	c := setupStore(t, storageEngine.GetSQLDatabase())
+	var expired []presentationRecord
+	c.onExpired = func(records []presentationRecord) {
+		expired = append(expired, records...)
+	}
+	expiredVP := createPresentationCustom(aliceDID, func(claims map[string]interface{}, _ *vc.VerifiablePresentation) {
+		claims[jwt.ExpirationKey] = time.Now().Add(-time.Minute).Unix()
+	}, vcAlice)
+	_, err := c.add(testServiceID, expiredVP, testSeed, 0)
+	require.NoError(t, err)
+	_, err = c.add(testServiceID, vpBob, testSeed, 0)
+	require.NoError(t, err)
+
+	require.NoError(t, c.prune())
+
+	require.Len(t, expired, 1)
+	assert.Equal(t, expiredVP.ID.String(), expired[0].PresentationID)
+	assert.Equal(t, aliceDID.String(), expired[0].CredentialSubjectID)
+	presentations, err := c.allPresentations(false)
+	require.NoError(t, err)
+	assert.Len(t, presentations, 1)
+}
+
+func Test_sqlStore_removeExpired(t *testing.T) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	t.Cleanup(func() {
+		_ = storageEngine.Shutdown()
+	})
+	defer func(old int) {
+		deleteBatchSize = old
+	}(deleteBatchSize)
+	deleteBatchSize = 1
+	c := setupStore(t, storageEngine.GetSQLDatabase())
+	expiration := func(claims map[string]interface{}, _ *vc.VerifiablePresentation) {
+		claims[jwt.ExpirationKey] = time.Now().Add(-time.Minute).Unix()
+	}
+	_, err := c.add(testServiceID, vpBob, testSeed, 0)
+	require.NoError(t, err)
+	// adding presentations prunes expired ones, so store them directly
+	_, err = storePresentation(c.db, testServiceID, 2, createPresentationCustom(aliceDID, expiration, vcAlice))
+	require.NoError(t, err)
+	_, err = storePresentation(c.db, "other", 1, createPresentationCustom(bobDID, expiration, vcBob))
+	require.NoError(t, err)
+
+	removed, err := c.removeExpired()
+
+	require.NoError(t, err)
+	assert.Len(t, removed, 2)
+	presentations, err := c.allPresentations(false)
+	require.NoError(t, err)
+	require.Len(t, presentations, 1)
+	assert.Equal(t, vpBob.ID.String(), presentations[0].PresentationID)
+}
@@ -88,6 +88,16 @@ func (m *manager) Configure(config core.ServerConfig) error {
 		Storage:   nats.FileStorage,
 	}, true)
 
+	// register Discovery stream
+	m.streams[DiscoveryStream] = newStream(&nats.StreamConfig{
+		Name:      DiscoveryStream,
+		Subjects:  []string{"DISCOVERY.*"},
+		Retention: nats.LimitsPolicy,
+		MaxAge:    168 * time.Hour, // week
+		Discard:   nats.DiscardOld,
+		Storage:   nats.FileStorage,
+	}, true)
+
 	return nil
 }
 
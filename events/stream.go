@@ -32,6 +32,8 @@ const (
 	DataStream = "DATA"
 	// ReprocessStream is the stream name used to rebuild the VDR/VCR
 	ReprocessStream = "REPROCESS"
+	// DiscoveryStream is the stream name on which Discovery Service events are stored
+	DiscoveryStream = "DISCOVERY"
 )
 
 // Stream contains configuration for a NATS stream both on the server and client side
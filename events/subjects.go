@@ -21,3 +21,7 @@ package events
 // TransactionsSubject defines the NATS subject used for transactions
 // Payload: TransactionWithPayload
 const TransactionsSubject = "TRANSACTIONS.tx"
+
+// DiscoverySubject defines the NATS subject used for Discovery Service events
+// Payload: discovery.Event
+const DiscoverySubject = "DISCOVERY.presentation"
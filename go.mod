@@ -13,6 +13,7 @@ require (
 	github.com/cbroglie/mustache v1.4.0
 	github.com/chromedp/chromedp v0.13.6
 	github.com/dlclark/regexp2 v1.11.5
+	github.com/fsnotify/fsnotify v1.7.0
 	github.com/go-redis/redismock/v9 v9.2.0
 	github.com/goodsign/monday v1.0.2
 	github.com/google/uuid v1.6.0
@@ -93,7 +94,6 @@ require (
 	github.com/edsrzf/mmap-go v1.1.0 // indirect
 	github.com/eknkc/basex v1.0.1 // indirect
 	github.com/fatih/structs v1.1.0 // indirect
-	github.com/fsnotify/fsnotify v1.7.0 // indirect
 	github.com/fxamacker/cbor v1.5.1 // indirect
 	github.com/go-chi/chi/v5 v5.0.10 // indirect
 	github.com/go-co-op/gocron v1.28.3 // indirect
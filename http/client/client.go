@@ -83,6 +83,16 @@ func NewWithCache(timeout time.Duration) *StrictHTTPClient {
 	}
 }
 
+// NewWithCacheWithoutRedirects creates a new HTTP client like NewWithCache, but it doesn't follow redirects:
+// redirect responses are returned to the caller. Use it when requests may only be sent to specific (e.g. allow-listed) URLs.
+func NewWithCacheWithoutRedirects(timeout time.Duration) *StrictHTTPClient {
+	result := NewWithCache(timeout)
+	result.client.CheckRedirect = func(_ *http.Request, _ []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return result
+}
+
 // NewWithTLSConfig creates a new HTTP client with the given timeout and TLS configuration.
 // It copies the http.DefaultTransport and sets the TLSClientConfig to the given tls.Config.
 // As such, it can't be used in conjunction with the CachingRoundTripper.
@@ -92,6 +92,27 @@ func TestStrictHTTPClient(t *testing.T) {
 		assert.EqualError(t, err, "strictmode is enabled, but request is not over HTTPS")
 		assert.Equal(t, 0, rt.invocations)
 	})
+	t.Run("redirects are not followed", func(t *testing.T) {
+		var redirected bool
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			if request.URL.Path == "/target" {
+				redirected = true
+				return
+			}
+			http.Redirect(writer, request, "/target", http.StatusFound)
+		}))
+		defer server.Close()
+		DefaultCachingTransport = http.DefaultTransport
+		StrictMode = false
+
+		client := NewWithCacheWithoutRedirects(time.Second)
+		httpRequest, _ := http.NewRequest("GET", server.URL, nil)
+		response, err := client.Do(httpRequest)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusFound, response.StatusCode)
+		assert.False(t, redirected)
+	})
 }
 
 func TestLimitedReadAll(t *testing.T) {
@@ -28,6 +28,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/vcr/assets"
 	"github.com/piprate/json-gold/ld"
 	"io/fs"
+	"maps"
 	"net/url"
 )
 
@@ -154,6 +155,9 @@ const W3cVcContext = "https://www.w3.org/2018/credentials/v1"
 // W3cStatusList2021Context contains the StatusList2021 related context
 const W3cStatusList2021Context = "https://w3id.org/vc/status-list/2021/v1"
 
+// W3cBitstringStatusListContext contains the Bitstring Status List related context for the VC 1.1 data model
+const W3cBitstringStatusListContext = "https://www.w3.org/ns/credentials/status/v1"
+
 // Jws2020Context contains the JsonWebToken2020 Proof type context
 const Jws2020Context = "https://w3c-ccg.github.io/lds-jws2020/contexts/lds-jws2020-v1.json"
 
@@ -182,10 +186,19 @@ func DefaultAllowList() []string {
 	return []string{SchemaOrgContext, W3cVcContext, Jws2020Context, W3cStatusList2021Context}
 }
 
+// builtInContexts maps contexts of specifications implemented by the node to embedded files.
+// They're always available, in addition to the configured mapping (which takes precedence).
+var builtInContexts = map[string]string{
+	W3cBitstringStatusListContext: "assets/contexts/w3c-bitstringstatuslist.ldjson",
+}
+
 // NewContextLoader creates a new JSON-LD context loader with the embedded FS as first loader.
 // It loads the most used context from the embedded FS. This ensures the contents cannot be altered.
 // If allowExternalCalls is set to true, it also loads external context from the internet.
 func NewContextLoader(allowUnlistedExternalCalls bool, contexts ContextsConfig) (ld.DocumentLoader, error) {
+	localFileMapping := maps.Clone(builtInContexts)
+	maps.Copy(localFileMapping, contexts.LocalFileMapping)
+	contexts.LocalFileMapping = localFileMapping
 	// Build the documentLoader chain:
 	// Start with rewriting all context urls to their mapped counterparts
 	loader := NewMappedDocumentLoader(contexts.LocalFileMapping,
@@ -29,6 +29,7 @@ import (
 	ssi "github.com/nuts-foundation/go-did"
 	"github.com/piprate/json-gold/ld"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 //go:embed test/*
@@ -139,6 +140,16 @@ func TestNewContextLoader(t *testing.T) {
 		assert.Equal(t, "assets/contexts/schema-org-v13.ldjson", doc.DocumentURL)
 	})
 
+	t.Run("built-in contexts are loaded when not configured", func(t *testing.T) {
+		cfg := DefaultContextConfig()
+		cfg.LocalFileMapping = map[string]string{}
+		loader, err := NewContextLoader(false, cfg)
+		require.NoError(t, err)
+		doc, err := loader.LoadDocument(W3cBitstringStatusListContext)
+		require.NoError(t, err)
+		assert.Equal(t, "assets/contexts/w3c-bitstringstatuslist.ldjson", doc.DocumentURL)
+	})
+
 	t.Run("it fails requesting an external doc when allowingExternalCalls is false", func(t *testing.T) {
 		loader, err := NewContextLoader(false, DefaultContextConfig())
 		assert.NoError(t, err)
@@ -21,10 +21,14 @@ package v1
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/nuts-node/audit"
 	"github.com/nuts-foundation/nuts-node/network/log"
+	"io"
 	"net/http"
 	"time"
 
@@ -32,10 +36,21 @@ import (
 	hash2 "github.com/nuts-foundation/nuts-node/crypto/hash"
 	"github.com/nuts-foundation/nuts-node/network"
 	"github.com/nuts-foundation/nuts-node/network/dag"
+	"github.com/nuts-foundation/nuts-node/network/transport"
+	"github.com/nuts-foundation/nuts-node/network/transport/grpc"
 )
 
 var _ StrictServerInterface = (*Wrapper)(nil)
 
+// defaultSearchLimit is the number of transactions returned by SearchTransactions if no limit is given.
+const defaultSearchLimit = 100
+
+// maxSearchLimit is the maximum number of transactions returned by SearchTransactions.
+const maxSearchLimit = 1000
+
+// keepAliveInterval specifies how often a comment is sent on event streams, to prevent (reverse) proxies from closing idle connections.
+var keepAliveInterval = 30 * time.Second
+
 // Wrapper implements the ServerInterface for the network API.
 type Wrapper struct {
 	Service network.Transactions
@@ -68,7 +83,12 @@ func (a *Wrapper) Routes(router core.EchoRouter) {
 // ResolveStatusCode maps errors returned by this API to specific HTTP status codes.
 func (a *Wrapper) ResolveStatusCode(err error) int {
 	return core.ResolveStatusCode(err, map[error]int{
-		network.ErrDIDNutsDisabled: http.StatusBadRequest,
+		network.ErrDIDNutsDisabled:        http.StatusBadRequest,
+		dag.ErrInvalidSnapshot:            http.StatusBadRequest,
+		dag.ErrPreviousTransactionMissing: http.StatusBadRequest,
+		dag.ErrInvalidLamportClockValue:   http.StatusBadRequest,
+		grpc.ErrInvalidPeerRule:           http.StatusBadRequest,
+		network.ErrPeerRuleNotFound:       http.StatusNotFound,
 	})
 }
 
@@ -161,6 +181,32 @@ func (a *Wrapper) GetAddressBook(_ context.Context, _ GetAddressBookRequestObjec
 	return GetAddressBook200JSONResponse(results), nil
 }
 
+// ListPeerRules lists the rules that allow or deny connections with peers
+func (a *Wrapper) ListPeerRules(_ context.Context, _ ListPeerRulesRequestObject) (ListPeerRulesResponseObject, error) {
+	results := make(ListPeerRules200JSONResponse, 0)
+	for _, rule := range a.Service.PeerRules() {
+		results = append(results, PeerRule(rule))
+	}
+	return results, nil
+}
+
+// AddPeerRule adds a rule that allows or denies connections with peers
+func (a *Wrapper) AddPeerRule(_ context.Context, request AddPeerRuleRequestObject) (AddPeerRuleResponseObject, error) {
+	rule, err := a.Service.AddPeerRule(transport.PeerRule(*request.Body))
+	if err != nil {
+		return nil, err
+	}
+	return AddPeerRule200JSONResponse(rule), nil
+}
+
+// RemovePeerRule removes a rule that allows or denies connections with peers
+func (a *Wrapper) RemovePeerRule(_ context.Context, request RemovePeerRuleRequestObject) (RemovePeerRuleResponseObject, error) {
+	if err := a.Service.RemovePeerRule(request.Id); err != nil {
+		return nil, err
+	}
+	return RemovePeerRule204Response{}, nil
+}
+
 // RenderGraph visualizes the DAG as Graphviz/dot graph
 func (a Wrapper) RenderGraph(_ context.Context, request RenderGraphRequestObject) (RenderGraphResponseObject, error) {
 	start := toInt(request.Params.Start, 0)
@@ -233,6 +279,170 @@ func (a Wrapper) Reprocess(_ context.Context, request ReprocessRequestObject) (R
 	return Reprocess202Response{}, nil
 }
 
+// ExportSnapshot streams a snapshot archive of the DAG up to and including the requested clock value
+func (a Wrapper) ExportSnapshot(ctx context.Context, request ExportSnapshotRequestObject) (ExportSnapshotResponseObject, error) {
+	clock := toInt(request.Params.Clock, dag.MaxLamportClock)
+	if clock < 0 || clock > dag.MaxLamportClock {
+		return nil, core.InvalidInputError("invalid clock")
+	}
+	reader, writer := io.Pipe()
+	// the request context is cancelled when the handler returns, which unblocks the exporter if the response isn't read completely
+	context.AfterFunc(ctx, func() {
+		_ = reader.CloseWithError(ctx.Err())
+	})
+	go func() {
+		_, err := a.Service.ExportSnapshot(ctx, writer, uint32(clock))
+		if err != nil {
+			log.Logger().WithError(err).Error("Failed to export DAG snapshot")
+		}
+		_ = writer.CloseWithError(err)
+	}()
+	return ExportSnapshot200ApplicationoctetStreamResponse{Body: reader}, nil
+}
+
+// ImportSnapshot imports a snapshot archive into the DAG
+func (a Wrapper) ImportSnapshot(ctx context.Context, request ImportSnapshotRequestObject) (ImportSnapshotResponseObject, error) {
+	result, err := a.Service.ImportSnapshot(ctx, request.Body)
+	if err != nil {
+		return nil, err
+	}
+	return ImportSnapshot200JSONResponse{
+		Clock:        int(result.Clock),
+		Transactions: result.Transactions,
+		Xor:          result.XOR.String(),
+		Added:        result.Added,
+		Present:      result.Present,
+	}, nil
+}
+
+// SearchTransactions searches the DAG for transactions matching the given filters
+func (a Wrapper) SearchTransactions(ctx context.Context, request SearchTransactionsRequestObject) (SearchTransactionsResponseObject, error) {
+	params := request.Params
+	query, err := toTransactionQuery(params.ContentType, params.Signer, params.Did, params.Since, params.Until, params.Participant)
+	if err != nil {
+		return nil, err
+	}
+	var cursor *network.TransactionCursor
+	if params.Cursor != nil {
+		if cursor, err = network.ParseTransactionCursor(*params.Cursor); err != nil {
+			return nil, core.InvalidInputError("invalid cursor: %w", err)
+		}
+	}
+	limit := toInt(params.Limit, defaultSearchLimit)
+	if limit < 1 || limit > maxSearchLimit {
+		return nil, core.InvalidInputError("invalid limit, must be between 1 and %d", maxSearchLimit)
+	}
+	transactions, next, err := a.Service.FindTransactions(ctx, query, cursor, int(limit))
+	if err != nil {
+		return nil, err
+	}
+	result := SearchTransactions200JSONResponse{
+		Transactions: make([]TransactionSummary, len(transactions)),
+	}
+	for i, transaction := range transactions {
+		result.Transactions[i] = toTransactionSummary(transaction)
+	}
+	if next != nil {
+		nextStr := next.String()
+		result.Next = &nextStr
+	}
+	return result, nil
+}
+
+// StreamTransactions streams the transactions matching the given filters as Server-Sent Events, as they're added to the DAG
+func (a Wrapper) StreamTransactions(ctx context.Context, request StreamTransactionsRequestObject) (StreamTransactionsResponseObject, error) {
+	params := request.Params
+	query, err := toTransactionQuery(params.ContentType, params.Signer, params.Did, params.Since, params.Until, params.Participant)
+	if err != nil {
+		return nil, err
+	}
+	transactions, err := a.Service.StreamTransactions(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return transactionStreamResponse{transactions: transactions}, nil
+}
+
+// transactionStreamResponse writes the transactions of a stream as Server-Sent Events, until the stream ends.
+type transactionStreamResponse struct {
+	transactions <-chan dag.Transaction
+}
+
+func (t transactionStreamResponse) VisitStreamTransactionsResponse(w http.ResponseWriter) error {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return errors.New("response writer does not support streaming")
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case transaction, ok := <-t.transactions:
+			if !ok {
+				return nil
+			}
+			data, _ := json.Marshal(toTransactionSummary(transaction))
+			if _, err := fmt.Fprintf(w, "event: transaction\ndata: %s\n\n", data); err != nil {
+				return err
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return err
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+func toTransactionQuery(contentType *string, signer *string, didStr *string, since *time.Time, until *time.Time, participant *string) (network.TransactionQuery, error) {
+	var query network.TransactionQuery
+	if contentType != nil {
+		query.ContentType = *contentType
+	}
+	if signer != nil {
+		query.SignerKeyID = *signer
+	}
+	if didStr != nil {
+		parsed, err := did.ParseDID(*didStr)
+		if err != nil {
+			return query, core.InvalidInputError("invalid did: %w", err)
+		}
+		query.DID = parsed
+	}
+	query.Since = since
+	query.Until = until
+	if participant != nil {
+		parsed, err := did.ParseDID(*participant)
+		if err != nil {
+			return query, core.InvalidInputError("invalid participant: %w", err)
+		}
+		query.Participant = parsed
+	}
+	return query, nil
+}
+
+func toTransactionSummary(transaction dag.Transaction) TransactionSummary {
+	prevs := make([]string, len(transaction.Previous()))
+	for i, prev := range transaction.Previous() {
+		prevs[i] = prev.String()
+	}
+	return TransactionSummary{
+		Ref:         transaction.Ref().String(),
+		Clock:       int(transaction.Clock()),
+		ContentType: transaction.PayloadType(),
+		Signer:      transaction.SigningKeyID(),
+		SigningTime: transaction.SigningTime(),
+		PayloadHash: transaction.PayloadHash().String(),
+		Prevs:       prevs,
+		Private:     len(transaction.PAL()) > 0,
+		Transaction: string(transaction.Data()),
+	}
+}
+
 func parseHash(hashAsString string) (hash2.SHA256Hash, error) {
 	hash, err := hash2.ParseHex(hashAsString)
 	if err != nil {
@@ -20,14 +20,19 @@ package v1
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/nuts-foundation/go-did/did"
 	httpTest "github.com/nuts-foundation/nuts-node/test/http"
 	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/nuts-foundation/nuts-node/network/transport"
+	"github.com/nuts-foundation/nuts-node/network/transport/grpc"
 
 	"github.com/nuts-foundation/nuts-node/crypto/hash"
 	"github.com/nuts-foundation/nuts-node/network"
@@ -290,6 +295,82 @@ func TestWrapper_GetAddressBook(t *testing.T) {
 	})
 }
 
+func TestWrapper_ListPeerRules(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		networkClient := network.NewMockTransactions(gomock.NewController(t))
+		wrapper := &Wrapper{Service: networkClient}
+		rule := transport.PeerRule{ID: "1", Action: transport.DenyPeerRule, CIDR: "10.0.0.0/8"}
+		networkClient.EXPECT().PeerRules().Return([]transport.PeerRule{rule})
+
+		resp, err := wrapper.ListPeerRules(nil, ListPeerRulesRequestObject{})
+
+		require.NoError(t, err)
+		assert.Equal(t, ListPeerRules200JSONResponse{PeerRule(rule)}, resp)
+	})
+	t.Run("no rules", func(t *testing.T) {
+		networkClient := network.NewMockTransactions(gomock.NewController(t))
+		wrapper := &Wrapper{Service: networkClient}
+		networkClient.EXPECT().PeerRules().Return(nil)
+
+		resp, err := wrapper.ListPeerRules(nil, ListPeerRulesRequestObject{})
+
+		require.NoError(t, err)
+		assert.NotNil(t, resp)
+		assert.Empty(t, resp)
+	})
+}
+
+func TestWrapper_AddPeerRule(t *testing.T) {
+	rule := transport.PeerRule{Action: transport.AllowPeerRule, NodeDID: "did:nuts:123"}
+	t.Run("ok", func(t *testing.T) {
+		networkClient := network.NewMockTransactions(gomock.NewController(t))
+		wrapper := &Wrapper{Service: networkClient}
+		added := rule
+		added.ID = "1"
+		networkClient.EXPECT().AddPeerRule(rule).Return(added, nil)
+		body := PeerRule(rule)
+
+		resp, err := wrapper.AddPeerRule(nil, AddPeerRuleRequestObject{Body: &body})
+
+		require.NoError(t, err)
+		assert.Equal(t, AddPeerRule200JSONResponse(added), resp)
+	})
+	t.Run("error - invalid rule", func(t *testing.T) {
+		networkClient := network.NewMockTransactions(gomock.NewController(t))
+		wrapper := &Wrapper{Service: networkClient}
+		networkClient.EXPECT().AddPeerRule(gomock.Any()).Return(transport.PeerRule{}, fmt.Errorf("%w: invalid cidr", grpc.ErrInvalidPeerRule))
+		body := PeerRule{Action: transport.AllowPeerRule, CIDR: "invalid"}
+
+		_, err := wrapper.AddPeerRule(nil, AddPeerRuleRequestObject{Body: &body})
+
+		assert.ErrorIs(t, err, grpc.ErrInvalidPeerRule)
+		assert.Equal(t, http.StatusBadRequest, wrapper.ResolveStatusCode(err))
+	})
+}
+
+func TestWrapper_RemovePeerRule(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		networkClient := network.NewMockTransactions(gomock.NewController(t))
+		wrapper := &Wrapper{Service: networkClient}
+		networkClient.EXPECT().RemovePeerRule("1").Return(nil)
+
+		resp, err := wrapper.RemovePeerRule(nil, RemovePeerRuleRequestObject{Id: "1"})
+
+		require.NoError(t, err)
+		assert.Equal(t, RemovePeerRule204Response{}, resp)
+	})
+	t.Run("error - not found", func(t *testing.T) {
+		networkClient := network.NewMockTransactions(gomock.NewController(t))
+		wrapper := &Wrapper{Service: networkClient}
+		networkClient.EXPECT().RemovePeerRule("1").Return(network.ErrPeerRuleNotFound)
+
+		_, err := wrapper.RemovePeerRule(nil, RemovePeerRuleRequestObject{Id: "1"})
+
+		assert.ErrorIs(t, err, network.ErrPeerRuleNotFound)
+		assert.Equal(t, http.StatusNotFound, wrapper.ResolveStatusCode(err))
+	})
+}
+
 func TestApiWrapper_Reprocess(t *testing.T) {
 	t.Run("error - missing type", func(t *testing.T) {
 		mockCtrl := gomock.NewController(t)
@@ -318,6 +399,242 @@ func TestApiWrapper_Reprocess(t *testing.T) {
 	})
 }
 
+func TestWrapper_ExportSnapshot(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		networkClient := network.NewMockTransactions(mockCtrl)
+		wrapper := &Wrapper{Service: networkClient}
+		clock := 10
+		networkClient.EXPECT().ExportSnapshot(gomock.Any(), gomock.Any(), uint32(10)).DoAndReturn(func(_ context.Context, writer io.Writer, _ uint32) (*dag.Snapshot, error) {
+			_, err := writer.Write([]byte("snapshot"))
+			return &dag.Snapshot{}, err
+		})
+
+		resp, err := wrapper.ExportSnapshot(context.Background(), ExportSnapshotRequestObject{Params: ExportSnapshotParams{Clock: &clock}})
+
+		require.NoError(t, err)
+		data, err := io.ReadAll(resp.(ExportSnapshot200ApplicationoctetStreamResponse).Body)
+		require.NoError(t, err)
+		assert.Equal(t, "snapshot", string(data))
+	})
+	t.Run("entire DAG", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		networkClient := network.NewMockTransactions(mockCtrl)
+		wrapper := &Wrapper{Service: networkClient}
+		networkClient.EXPECT().ExportSnapshot(gomock.Any(), gomock.Any(), uint32(dag.MaxLamportClock)).Return(&dag.Snapshot{}, nil)
+
+		resp, err := wrapper.ExportSnapshot(context.Background(), ExportSnapshotRequestObject{})
+
+		require.NoError(t, err)
+		_, err = io.ReadAll(resp.(ExportSnapshot200ApplicationoctetStreamResponse).Body)
+		assert.NoError(t, err)
+	})
+	t.Run("error - export fails", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		networkClient := network.NewMockTransactions(mockCtrl)
+		wrapper := &Wrapper{Service: networkClient}
+		networkClient.EXPECT().ExportSnapshot(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("failed"))
+
+		resp, err := wrapper.ExportSnapshot(context.Background(), ExportSnapshotRequestObject{})
+
+		require.NoError(t, err)
+		_, err = io.ReadAll(resp.(ExportSnapshot200ApplicationoctetStreamResponse).Body)
+		assert.EqualError(t, err, "failed")
+	})
+	t.Run("error - invalid clock", func(t *testing.T) {
+		wrapper := &Wrapper{}
+		clock := -1
+
+		resp, err := wrapper.ExportSnapshot(context.Background(), ExportSnapshotRequestObject{Params: ExportSnapshotParams{Clock: &clock}})
+
+		assert.Nil(t, resp)
+		assert.EqualError(t, err, "invalid clock")
+	})
+}
+
+func TestWrapper_ImportSnapshot(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		networkClient := network.NewMockTransactions(mockCtrl)
+		wrapper := &Wrapper{Service: networkClient}
+		body := strings.NewReader("snapshot")
+		xor := hash.SHA256Sum([]byte("xor"))
+		networkClient.EXPECT().ImportSnapshot(gomock.Any(), body).Return(&dag.SnapshotImportResult{
+			Snapshot: dag.Snapshot{Clock: 5, Transactions: 10, XOR: xor},
+			Added:    8,
+			Present:  2,
+		}, nil)
+
+		resp, err := wrapper.ImportSnapshot(context.Background(), ImportSnapshotRequestObject{Body: body})
+
+		require.NoError(t, err)
+		assert.Equal(t, ImportSnapshot200JSONResponse{Clock: 5, Transactions: 10, Xor: xor.String(), Added: 8, Present: 2}, resp)
+	})
+	t.Run("error - invalid snapshot", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		networkClient := network.NewMockTransactions(mockCtrl)
+		wrapper := &Wrapper{Service: networkClient}
+		networkClient.EXPECT().ImportSnapshot(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("%w: missing trailer", dag.ErrInvalidSnapshot))
+
+		resp, err := wrapper.ImportSnapshot(context.Background(), ImportSnapshotRequestObject{Body: strings.NewReader("")})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, dag.ErrInvalidSnapshot)
+		assert.Equal(t, http.StatusBadRequest, wrapper.ResolveStatusCode(err))
+	})
+}
+
+func TestWrapper_SearchTransactions(t *testing.T) {
+	transaction, _, _ := dag.CreateTestTransaction(1)
+	t.Run("ok", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		networkClient := network.NewMockTransactions(mockCtrl)
+		wrapper := &Wrapper{Service: networkClient}
+		contentType := "application/did+json"
+		didStr := "did:nuts:123"
+		since := time.Now().Add(-time.Hour)
+		expectedDID := did.MustParseDID(didStr)
+		expectedQuery := network.TransactionQuery{
+			ContentType: contentType,
+			DID:         &expectedDID,
+			Since:       &since,
+		}
+		networkClient.EXPECT().FindTransactions(gomock.Any(), expectedQuery, nil, defaultSearchLimit).Return([]dag.Transaction{transaction}, nil, nil)
+
+		resp, err := wrapper.SearchTransactions(context.Background(), SearchTransactionsRequestObject{Params: SearchTransactionsParams{
+			ContentType: &contentType,
+			Did:         &didStr,
+			Since:       &since,
+		}})
+
+		require.NoError(t, err)
+		result := resp.(SearchTransactions200JSONResponse)
+		assert.Nil(t, result.Next)
+		require.Len(t, result.Transactions, 1)
+		summary := result.Transactions[0]
+		assert.Equal(t, transaction.Ref().String(), summary.Ref)
+		assert.Equal(t, int(transaction.Clock()), summary.Clock)
+		assert.Equal(t, transaction.PayloadType(), summary.ContentType)
+		assert.Equal(t, transaction.SigningKeyID(), summary.Signer)
+		assert.Equal(t, transaction.PayloadHash().String(), summary.PayloadHash)
+		assert.Len(t, summary.Prevs, len(transaction.Previous()))
+		assert.False(t, summary.Private)
+		assert.Equal(t, string(transaction.Data()), summary.Transaction)
+	})
+	t.Run("ok - with cursor and next page", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		networkClient := network.NewMockTransactions(mockCtrl)
+		wrapper := &Wrapper{Service: networkClient}
+		cursor := network.TransactionCursor{Clock: 5, Ref: hash.SHA256Sum([]byte("5"))}
+		next := network.TransactionCursor{Clock: transaction.Clock(), Ref: transaction.Ref()}
+		cursorStr := cursor.String()
+		limit := 1
+		networkClient.EXPECT().FindTransactions(gomock.Any(), network.TransactionQuery{}, &cursor, 1).Return([]dag.Transaction{transaction}, &next, nil)
+
+		resp, err := wrapper.SearchTransactions(context.Background(), SearchTransactionsRequestObject{Params: SearchTransactionsParams{
+			Cursor: &cursorStr,
+			Limit:  &limit,
+		}})
+
+		require.NoError(t, err)
+		result := resp.(SearchTransactions200JSONResponse)
+		require.NotNil(t, result.Next)
+		assert.Equal(t, next.String(), *result.Next)
+	})
+	t.Run("error - invalid DID", func(t *testing.T) {
+		wrapper := &Wrapper{Service: network.NewMockTransactions(gomock.NewController(t))}
+		invalid := "not a DID"
+
+		resp, err := wrapper.SearchTransactions(context.Background(), SearchTransactionsRequestObject{Params: SearchTransactionsParams{Did: &invalid}})
+
+		assert.Nil(t, resp)
+		assert.ErrorContains(t, err, "invalid did")
+	})
+	t.Run("error - invalid participant", func(t *testing.T) {
+		wrapper := &Wrapper{Service: network.NewMockTransactions(gomock.NewController(t))}
+		invalid := "not a DID"
+
+		resp, err := wrapper.SearchTransactions(context.Background(), SearchTransactionsRequestObject{Params: SearchTransactionsParams{Participant: &invalid}})
+
+		assert.Nil(t, resp)
+		assert.ErrorContains(t, err, "invalid participant")
+	})
+	t.Run("error - invalid cursor", func(t *testing.T) {
+		wrapper := &Wrapper{Service: network.NewMockTransactions(gomock.NewController(t))}
+		invalid := "invalid"
+
+		resp, err := wrapper.SearchTransactions(context.Background(), SearchTransactionsRequestObject{Params: SearchTransactionsParams{Cursor: &invalid}})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, network.ErrInvalidTransactionCursor)
+	})
+	t.Run("error - invalid limit", func(t *testing.T) {
+		wrapper := &Wrapper{Service: network.NewMockTransactions(gomock.NewController(t))}
+		for _, limit := range []int{0, maxSearchLimit + 1} {
+			resp, err := wrapper.SearchTransactions(context.Background(), SearchTransactionsRequestObject{Params: SearchTransactionsParams{Limit: &limit}})
+
+			assert.Nil(t, resp)
+			assert.ErrorContains(t, err, "invalid limit")
+		}
+	})
+	t.Run("error - search failed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		networkClient := network.NewMockTransactions(mockCtrl)
+		wrapper := &Wrapper{Service: networkClient}
+		networkClient.EXPECT().FindTransactions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil, errors.New("failed"))
+
+		resp, err := wrapper.SearchTransactions(context.Background(), SearchTransactionsRequestObject{})
+
+		assert.Nil(t, resp)
+		assert.EqualError(t, err, "failed")
+	})
+}
+
+func TestWrapper_StreamTransactions(t *testing.T) {
+	transaction, _, _ := dag.CreateTestTransaction(1)
+	t.Run("ok", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		networkClient := network.NewMockTransactions(mockCtrl)
+		wrapper := &Wrapper{Service: networkClient}
+		contentType := "application/did+json"
+		transactions := make(chan dag.Transaction, 1)
+		transactions <- transaction
+		close(transactions)
+		networkClient.EXPECT().StreamTransactions(gomock.Any(), network.TransactionQuery{ContentType: contentType}).Return(transactions, nil)
+
+		resp, err := wrapper.StreamTransactions(context.Background(), StreamTransactionsRequestObject{Params: StreamTransactionsParams{ContentType: &contentType}})
+		require.NoError(t, err)
+		recorder := httptest.NewRecorder()
+		err = resp.VisitStreamTransactionsResponse(recorder)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "text/event-stream", recorder.Header().Get("Content-Type"))
+		assert.Contains(t, recorder.Body.String(), "event: transaction\ndata: {")
+		assert.Contains(t, recorder.Body.String(), transaction.Ref().String())
+	})
+	t.Run("error - invalid DID", func(t *testing.T) {
+		wrapper := &Wrapper{Service: network.NewMockTransactions(gomock.NewController(t))}
+		invalid := "not a DID"
+
+		resp, err := wrapper.StreamTransactions(context.Background(), StreamTransactionsRequestObject{Params: StreamTransactionsParams{Did: &invalid}})
+
+		assert.Nil(t, resp)
+		assert.ErrorContains(t, err, "invalid did")
+	})
+	t.Run("error - stream failed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		networkClient := network.NewMockTransactions(mockCtrl)
+		wrapper := &Wrapper{Service: networkClient}
+		networkClient.EXPECT().StreamTransactions(gomock.Any(), gomock.Any()).Return(nil, network.ErrDIDNutsDisabled)
+
+		resp, err := wrapper.StreamTransactions(context.Background(), StreamTransactionsRequestObject{})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, network.ErrDIDNutsDisabled)
+	})
+}
+
 func TestWrapper_ListEvents(t *testing.T) {
 	tx, _, _ := dag.CreateTestTransaction(0)
 	sTime := time.Date(2022, time.December, 5, 18, 23, 45, 67, &time.Location{})
@@ -128,6 +128,41 @@ func (hb HTTPClient) Reprocess(contentType string) error {
 	return nil
 }
 
+// ExportSnapshot retrieves a snapshot archive of the DAG and writes it to the given writer.
+// If clock is nil, the entire DAG is exported.
+func (hb HTTPClient) ExportSnapshot(writer io.Writer, clock *int) error {
+	ctx := context.Background()
+	response, err := hb.client().ExportSnapshot(ctx, &ExportSnapshotParams{Clock: clock})
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if err = core.TestResponseCode(http.StatusOK, response); err != nil {
+		return err
+	}
+	_, err = io.Copy(writer, response.Body)
+	return err
+}
+
+// ImportSnapshot uploads a snapshot archive to be imported into the node's DAG.
+func (hb HTTPClient) ImportSnapshot(reader io.Reader) (*SnapshotImportResult, error) {
+	ctx := context.Background()
+	response, err := hb.client().ImportSnapshotWithBody(ctx, "application/octet-stream", reader)
+	if err != nil {
+		return nil, err
+	}
+	if err = core.TestResponseCode(http.StatusOK, response); err != nil {
+		return nil, err
+	}
+	responseData, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	result := new(SnapshotImportResult)
+	err = json.Unmarshal(responseData, result)
+	return result, err
+}
+
 func (hb HTTPClient) client() ClientInterface {
 	response, err := NewClientWithResponses(hb.GetAddress(), WithHTTPClient(core.MustCreateInternalHTTPClient(hb.ClientConfig, hb.TokenGenerator)))
 	if err != nil {
@@ -4,6 +4,7 @@
 package v1
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -72,6 +73,61 @@ type EventSubscriber struct {
 	Name string `json:"name"`
 }
 
+// SnapshotImportResult Result of importing a DAG snapshot.
+type SnapshotImportResult struct {
+	// Added Number of transactions that were added to the DAG.
+	Added int `json:"added"`
+
+	// Clock Highest Lamport clock value of the transactions in the snapshot.
+	Clock int `json:"clock"`
+
+	// Present Number of transactions that were skipped since they were already present on the DAG.
+	Present int `json:"present"`
+
+	// Transactions Number of transactions in the snapshot.
+	Transactions int `json:"transactions"`
+
+	// Xor XOR of the references of all transactions in the snapshot.
+	Xor string `json:"xor"`
+}
+
+// TransactionSearchResult Page of transactions that match a search.
+type TransactionSearchResult struct {
+	// Next Cursor to retrieve the next page of results. It is absent when the end of the DAG has been reached.
+	Next         *string              `json:"next,omitempty"`
+	Transactions []TransactionSummary `json:"transactions"`
+}
+
+// TransactionSummary Describes a transaction on the DAG.
+type TransactionSummary struct {
+	// Clock Lamport clock value of the transaction.
+	Clock int `json:"clock"`
+
+	// ContentType Payload type of the transaction.
+	ContentType string `json:"contentType"`
+
+	// PayloadHash SHA-256 hash of the payload.
+	PayloadHash string `json:"payloadHash"`
+
+	// Prevs References of the previous transactions.
+	Prevs []string `json:"prevs"`
+
+	// Private Whether the transaction is private (has a PAL).
+	Private bool `json:"private"`
+
+	// Ref Reference of the transaction.
+	Ref string `json:"ref"`
+
+	// Signer ID of the key that signed the transaction.
+	Signer string `json:"signer"`
+
+	// SigningTime Time at which the transaction was signed.
+	SigningTime time.Time `json:"signingTime"`
+
+	// Transaction The transaction in JWS compact serialization.
+	Transaction string `json:"transaction"`
+}
+
 // RenderGraphParams defines parameters for RenderGraph.
 type RenderGraphParams struct {
 	// Start Lamport Clock value from where to start rendering (inclusive). If omitted, rendering starts at the root.
@@ -81,12 +137,66 @@ type RenderGraphParams struct {
 	End *int `form:"end,omitempty" json:"end,omitempty"`
 }
 
+// StreamTransactionsParams defines parameters for StreamTransactions.
+type StreamTransactionsParams struct {
+	// ContentType Payload type of the transactions, e.g. application/did+json.
+	ContentType *string `form:"contentType,omitempty" json:"contentType,omitempty"`
+
+	// Signer ID of the key that signed the transactions.
+	Signer *string `form:"signer,omitempty" json:"signer,omitempty"`
+
+	// Did DID of the key that signed the transactions.
+	Did *string `form:"did,omitempty" json:"did,omitempty"`
+
+	// Since Only transactions signed at or after this time are returned (RFC3339).
+	Since *time.Time `form:"since,omitempty" json:"since,omitempty"`
+
+	// Until Only transactions signed before this time are returned (RFC3339).
+	Until *time.Time `form:"until,omitempty" json:"until,omitempty"`
+
+	// Participant DID of a participant of the private transactions. Only private transactions of which the node is a participant itself can be matched, since other nodes' PALs can't be decrypted.
+	Participant *string `form:"participant,omitempty" json:"participant,omitempty"`
+}
+
+// SearchTransactionsParams defines parameters for SearchTransactions.
+type SearchTransactionsParams struct {
+	// ContentType Payload type of the transactions, e.g. application/did+json.
+	ContentType *string `form:"contentType,omitempty" json:"contentType,omitempty"`
+
+	// Signer ID of the key that signed the transactions.
+	Signer *string `form:"signer,omitempty" json:"signer,omitempty"`
+
+	// Did DID of the key that signed the transactions.
+	Did *string `form:"did,omitempty" json:"did,omitempty"`
+
+	// Since Only transactions signed at or after this time are returned (RFC3339).
+	Since *time.Time `form:"since,omitempty" json:"since,omitempty"`
+
+	// Until Only transactions signed before this time are returned (RFC3339).
+	Until *time.Time `form:"until,omitempty" json:"until,omitempty"`
+
+	// Participant DID of a participant of the private transactions. Only private transactions of which the node is a participant itself can be matched, since other nodes' PALs can't be decrypted.
+	Participant *string `form:"participant,omitempty" json:"participant,omitempty"`
+
+	// Cursor Cursor returned by a previous call (as `next`), to retrieve the next page of results.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Maximum number of transactions to return, defaults to 100.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
 // ReprocessParams defines parameters for Reprocess.
 type ReprocessParams struct {
 	// Type the transaction content-type that must be reprocessed
 	Type *string `form:"type,omitempty" json:"type,omitempty"`
 }
 
+// ExportSnapshotParams defines parameters for ExportSnapshot.
+type ExportSnapshotParams struct {
+	// Clock Highest Lamport clock value (inclusive) of the transactions to export. If omitted, the entire DAG is exported.
+	Clock *int `form:"clock,omitempty" json:"clock,omitempty"`
+}
+
 // ListTransactionsParams defines parameters for ListTransactions.
 type ListTransactionsParams struct {
 	// Start Inclusive start of range (in lamport clock); default=0
@@ -96,6 +206,9 @@ type ListTransactionsParams struct {
 	End *int `form:"end,omitempty" json:"end,omitempty"`
 }
 
+// AddPeerRuleJSONRequestBody defines body for AddPeerRule for application/json ContentType.
+type AddPeerRuleJSONRequestBody = PeerRule
+
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -172,6 +285,17 @@ type ClientInterface interface {
 	// GetAddressBook request
 	GetAddressBook(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// ListPeerRules request
+	ListPeerRules(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AddPeerRuleWithBody request with any body
+	AddPeerRuleWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AddPeerRule(ctx context.Context, body AddPeerRuleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RemovePeerRule request
+	RemovePeerRule(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// RenderGraph request
 	RenderGraph(ctx context.Context, params *RenderGraphParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -181,9 +305,21 @@ type ClientInterface interface {
 	// ListEvents request
 	ListEvents(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// StreamTransactions request
+	StreamTransactions(ctx context.Context, params *StreamTransactionsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SearchTransactions request
+	SearchTransactions(ctx context.Context, params *SearchTransactionsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// Reprocess request
 	Reprocess(ctx context.Context, params *ReprocessParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// ExportSnapshot request
+	ExportSnapshot(ctx context.Context, params *ExportSnapshotParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ImportSnapshotWithBody request with any body
+	ImportSnapshotWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// ListTransactions request
 	ListTransactions(ctx context.Context, params *ListTransactionsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -206,6 +342,54 @@ func (c *Client) GetAddressBook(ctx context.Context, reqEditors ...RequestEditor
 	return c.Client.Do(req)
 }
 
+func (c *Client) ListPeerRules(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListPeerRulesRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddPeerRuleWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddPeerRuleRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddPeerRule(ctx context.Context, body AddPeerRuleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddPeerRuleRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RemovePeerRule(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRemovePeerRuleRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) RenderGraph(ctx context.Context, params *RenderGraphParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewRenderGraphRequest(c.Server, params)
 	if err != nil {
@@ -242,6 +426,30 @@ func (c *Client) ListEvents(ctx context.Context, reqEditors ...RequestEditorFn)
 	return c.Client.Do(req)
 }
 
+func (c *Client) StreamTransactions(ctx context.Context, params *StreamTransactionsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStreamTransactionsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SearchTransactions(ctx context.Context, params *SearchTransactionsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSearchTransactionsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) Reprocess(ctx context.Context, params *ReprocessParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewReprocessRequest(c.Server, params)
 	if err != nil {
@@ -254,6 +462,30 @@ func (c *Client) Reprocess(ctx context.Context, params *ReprocessParams, reqEdit
 	return c.Client.Do(req)
 }
 
+func (c *Client) ExportSnapshot(ctx context.Context, params *ExportSnapshotParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewExportSnapshotRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ImportSnapshotWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewImportSnapshotRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) ListTransactions(ctx context.Context, params *ListTransactionsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewListTransactionsRequest(c.Server, params)
 	if err != nil {
@@ -317,6 +549,107 @@ func NewGetAddressBookRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
+// NewListPeerRulesRequest generates requests for ListPeerRules
+func NewListPeerRulesRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/network/v1/addressbook/rules")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAddPeerRuleRequest calls the generic AddPeerRule builder with application/json body
+func NewAddPeerRuleRequest(server string, body AddPeerRuleJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAddPeerRuleRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewAddPeerRuleRequestWithBody generates requests for AddPeerRule with any type of body
+func NewAddPeerRuleRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/network/v1/addressbook/rules")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewRemovePeerRuleRequest generates requests for RemovePeerRule
+func NewRemovePeerRuleRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/network/v1/addressbook/rules/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewRenderGraphRequest generates requests for RenderGraph
 func NewRenderGraphRequest(server string, params *RenderGraphParams) (*http.Request, error) {
 	var err error
@@ -436,8 +769,8 @@ func NewListEventsRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewReprocessRequest generates requests for Reprocess
-func NewReprocessRequest(server string, params *ReprocessParams) (*http.Request, error) {
+// NewStreamTransactionsRequest generates requests for StreamTransactions
+func NewStreamTransactionsRequest(server string, params *StreamTransactionsParams) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -445,7 +778,7 @@ func NewReprocessRequest(server string, params *ReprocessParams) (*http.Request,
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/internal/network/v1/reprocess")
+	operationPath := fmt.Sprintf("/internal/network/v1/explorer/stream")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -458,9 +791,9 @@ func NewReprocessRequest(server string, params *ReprocessParams) (*http.Request,
 	if params != nil {
 		queryValues := queryURL.Query()
 
-		if params.Type != nil {
+		if params.ContentType != nil {
 
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "type", runtime.ParamLocationQuery, *params.Type); err != nil {
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "contentType", runtime.ParamLocationQuery, *params.ContentType); err != nil {
 				return nil, err
 			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 				return nil, err
@@ -474,42 +807,9 @@ func NewReprocessRequest(server string, params *ReprocessParams) (*http.Request,
 
 		}
 
-		queryURL.RawQuery = queryValues.Encode()
-	}
-
-	req, err := http.NewRequest("POST", queryURL.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	return req, nil
-}
-
-// NewListTransactionsRequest generates requests for ListTransactions
-func NewListTransactionsRequest(server string, params *ListTransactionsParams) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/internal/network/v1/transaction")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
-	if err != nil {
-		return nil, err
-	}
-
-	if params != nil {
-		queryValues := queryURL.Query()
-
-		if params.Start != nil {
+		if params.Signer != nil {
 
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "start", runtime.ParamLocationQuery, *params.Start); err != nil {
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "signer", runtime.ParamLocationQuery, *params.Signer); err != nil {
 				return nil, err
 			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 				return nil, err
@@ -523,9 +823,9 @@ func NewListTransactionsRequest(server string, params *ListTransactionsParams) (
 
 		}
 
-		if params.End != nil {
+		if params.Did != nil {
 
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "end", runtime.ParamLocationQuery, *params.End); err != nil {
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "did", runtime.ParamLocationQuery, *params.Did); err != nil {
 				return nil, err
 			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 				return nil, err
@@ -539,12 +839,413 @@ func NewListTransactionsRequest(server string, params *ListTransactionsParams) (
 
 		}
 
-		queryURL.RawQuery = queryValues.Encode()
-	}
+		if params.Since != nil {
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
-		return nil, err
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "since", runtime.ParamLocationQuery, *params.Since); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Until != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "until", runtime.ParamLocationQuery, *params.Until); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Participant != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "participant", runtime.ParamLocationQuery, *params.Participant); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSearchTransactionsRequest generates requests for SearchTransactions
+func NewSearchTransactionsRequest(server string, params *SearchTransactionsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/network/v1/explorer/transaction")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.ContentType != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "contentType", runtime.ParamLocationQuery, *params.ContentType); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Signer != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "signer", runtime.ParamLocationQuery, *params.Signer); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Did != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "did", runtime.ParamLocationQuery, *params.Did); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Since != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "since", runtime.ParamLocationQuery, *params.Since); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Until != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "until", runtime.ParamLocationQuery, *params.Until); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Participant != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "participant", runtime.ParamLocationQuery, *params.Participant); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewReprocessRequest generates requests for Reprocess
+func NewReprocessRequest(server string, params *ReprocessParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/network/v1/reprocess")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Type != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "type", runtime.ParamLocationQuery, *params.Type); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewExportSnapshotRequest generates requests for ExportSnapshot
+func NewExportSnapshotRequest(server string, params *ExportSnapshotParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/network/v1/snapshot")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Clock != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "clock", runtime.ParamLocationQuery, *params.Clock); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewImportSnapshotRequestWithBody generates requests for ImportSnapshot with any type of body
+func NewImportSnapshotRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/network/v1/snapshot")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListTransactionsRequest generates requests for ListTransactions
+func NewListTransactionsRequest(server string, params *ListTransactionsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/network/v1/transaction")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Start != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "start", runtime.ParamLocationQuery, *params.Start); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.End != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "end", runtime.ParamLocationQuery, *params.End); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
 
 	return req, nil
@@ -664,6 +1365,17 @@ type ClientWithResponsesInterface interface {
 	// GetAddressBookWithResponse request
 	GetAddressBookWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAddressBookResponse, error)
 
+	// ListPeerRulesWithResponse request
+	ListPeerRulesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListPeerRulesResponse, error)
+
+	// AddPeerRuleWithBodyWithResponse request with any body
+	AddPeerRuleWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddPeerRuleResponse, error)
+
+	AddPeerRuleWithResponse(ctx context.Context, body AddPeerRuleJSONRequestBody, reqEditors ...RequestEditorFn) (*AddPeerRuleResponse, error)
+
+	// RemovePeerRuleWithResponse request
+	RemovePeerRuleWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*RemovePeerRuleResponse, error)
+
 	// RenderGraphWithResponse request
 	RenderGraphWithResponse(ctx context.Context, params *RenderGraphParams, reqEditors ...RequestEditorFn) (*RenderGraphResponse, error)
 
@@ -673,9 +1385,21 @@ type ClientWithResponsesInterface interface {
 	// ListEventsWithResponse request
 	ListEventsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListEventsResponse, error)
 
+	// StreamTransactionsWithResponse request
+	StreamTransactionsWithResponse(ctx context.Context, params *StreamTransactionsParams, reqEditors ...RequestEditorFn) (*StreamTransactionsResponse, error)
+
+	// SearchTransactionsWithResponse request
+	SearchTransactionsWithResponse(ctx context.Context, params *SearchTransactionsParams, reqEditors ...RequestEditorFn) (*SearchTransactionsResponse, error)
+
 	// ReprocessWithResponse request
 	ReprocessWithResponse(ctx context.Context, params *ReprocessParams, reqEditors ...RequestEditorFn) (*ReprocessResponse, error)
 
+	// ExportSnapshotWithResponse request
+	ExportSnapshotWithResponse(ctx context.Context, params *ExportSnapshotParams, reqEditors ...RequestEditorFn) (*ExportSnapshotResponse, error)
+
+	// ImportSnapshotWithBodyWithResponse request with any body
+	ImportSnapshotWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ImportSnapshotResponse, error)
+
 	// ListTransactionsWithResponse request
 	ListTransactionsWithResponse(ctx context.Context, params *ListTransactionsParams, reqEditors ...RequestEditorFn) (*ListTransactionsResponse, error)
 
@@ -708,6 +1432,101 @@ func (r GetAddressBookResponse) StatusCode() int {
 	return 0
 }
 
+type ListPeerRulesResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *[]PeerRule
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r ListPeerRulesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListPeerRulesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AddPeerRuleResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *PeerRule
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r AddPeerRuleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AddPeerRuleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RemovePeerRuleResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r RemovePeerRuleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RemovePeerRuleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type RenderGraphResponse struct {
 	Body                          []byte
 	HTTPResponse                  *http.Response
@@ -796,20 +1615,146 @@ func (r ListEventsResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r ListEventsResponse) StatusCode() int {
+func (r ListEventsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type StreamTransactionsResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r StreamTransactionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r StreamTransactionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SearchTransactionsResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *TransactionSearchResult
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r SearchTransactionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SearchTransactionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ReprocessResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r ReprocessResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReprocessResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ExportSnapshotResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r ExportSnapshotResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ExportSnapshotResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type ReprocessResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
+type ImportSnapshotResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *SnapshotImportResult
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
 }
 
 // Status returns HTTPResponse.Status
-func (r ReprocessResponse) Status() string {
+func (r ImportSnapshotResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -817,7 +1762,7 @@ func (r ReprocessResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r ReprocessResponse) StatusCode() int {
+func (r ImportSnapshotResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
@@ -927,6 +1872,41 @@ func (c *ClientWithResponses) GetAddressBookWithResponse(ctx context.Context, re
 	return ParseGetAddressBookResponse(rsp)
 }
 
+// ListPeerRulesWithResponse request returning *ListPeerRulesResponse
+func (c *ClientWithResponses) ListPeerRulesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListPeerRulesResponse, error) {
+	rsp, err := c.ListPeerRules(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListPeerRulesResponse(rsp)
+}
+
+// AddPeerRuleWithBodyWithResponse request with arbitrary body returning *AddPeerRuleResponse
+func (c *ClientWithResponses) AddPeerRuleWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddPeerRuleResponse, error) {
+	rsp, err := c.AddPeerRuleWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddPeerRuleResponse(rsp)
+}
+
+func (c *ClientWithResponses) AddPeerRuleWithResponse(ctx context.Context, body AddPeerRuleJSONRequestBody, reqEditors ...RequestEditorFn) (*AddPeerRuleResponse, error) {
+	rsp, err := c.AddPeerRule(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddPeerRuleResponse(rsp)
+}
+
+// RemovePeerRuleWithResponse request returning *RemovePeerRuleResponse
+func (c *ClientWithResponses) RemovePeerRuleWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*RemovePeerRuleResponse, error) {
+	rsp, err := c.RemovePeerRule(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRemovePeerRuleResponse(rsp)
+}
+
 // RenderGraphWithResponse request returning *RenderGraphResponse
 func (c *ClientWithResponses) RenderGraphWithResponse(ctx context.Context, params *RenderGraphParams, reqEditors ...RequestEditorFn) (*RenderGraphResponse, error) {
 	rsp, err := c.RenderGraph(ctx, params, reqEditors...)
@@ -954,77 +1934,351 @@ func (c *ClientWithResponses) ListEventsWithResponse(ctx context.Context, reqEdi
 	return ParseListEventsResponse(rsp)
 }
 
-// ReprocessWithResponse request returning *ReprocessResponse
-func (c *ClientWithResponses) ReprocessWithResponse(ctx context.Context, params *ReprocessParams, reqEditors ...RequestEditorFn) (*ReprocessResponse, error) {
-	rsp, err := c.Reprocess(ctx, params, reqEditors...)
-	if err != nil {
-		return nil, err
+// StreamTransactionsWithResponse request returning *StreamTransactionsResponse
+func (c *ClientWithResponses) StreamTransactionsWithResponse(ctx context.Context, params *StreamTransactionsParams, reqEditors ...RequestEditorFn) (*StreamTransactionsResponse, error) {
+	rsp, err := c.StreamTransactions(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStreamTransactionsResponse(rsp)
+}
+
+// SearchTransactionsWithResponse request returning *SearchTransactionsResponse
+func (c *ClientWithResponses) SearchTransactionsWithResponse(ctx context.Context, params *SearchTransactionsParams, reqEditors ...RequestEditorFn) (*SearchTransactionsResponse, error) {
+	rsp, err := c.SearchTransactions(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSearchTransactionsResponse(rsp)
+}
+
+// ReprocessWithResponse request returning *ReprocessResponse
+func (c *ClientWithResponses) ReprocessWithResponse(ctx context.Context, params *ReprocessParams, reqEditors ...RequestEditorFn) (*ReprocessResponse, error) {
+	rsp, err := c.Reprocess(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReprocessResponse(rsp)
+}
+
+// ExportSnapshotWithResponse request returning *ExportSnapshotResponse
+func (c *ClientWithResponses) ExportSnapshotWithResponse(ctx context.Context, params *ExportSnapshotParams, reqEditors ...RequestEditorFn) (*ExportSnapshotResponse, error) {
+	rsp, err := c.ExportSnapshot(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseExportSnapshotResponse(rsp)
+}
+
+// ImportSnapshotWithBodyWithResponse request with arbitrary body returning *ImportSnapshotResponse
+func (c *ClientWithResponses) ImportSnapshotWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ImportSnapshotResponse, error) {
+	rsp, err := c.ImportSnapshotWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseImportSnapshotResponse(rsp)
+}
+
+// ListTransactionsWithResponse request returning *ListTransactionsResponse
+func (c *ClientWithResponses) ListTransactionsWithResponse(ctx context.Context, params *ListTransactionsParams, reqEditors ...RequestEditorFn) (*ListTransactionsResponse, error) {
+	rsp, err := c.ListTransactions(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListTransactionsResponse(rsp)
+}
+
+// GetTransactionWithResponse request returning *GetTransactionResponse
+func (c *ClientWithResponses) GetTransactionWithResponse(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*GetTransactionResponse, error) {
+	rsp, err := c.GetTransaction(ctx, ref, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTransactionResponse(rsp)
+}
+
+// GetTransactionPayloadWithResponse request returning *GetTransactionPayloadResponse
+func (c *ClientWithResponses) GetTransactionPayloadWithResponse(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*GetTransactionPayloadResponse, error) {
+	rsp, err := c.GetTransactionPayload(ctx, ref, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTransactionPayloadResponse(rsp)
+}
+
+// ParseGetAddressBookResponse parses an HTTP response from a GetAddressBookWithResponse call
+func ParseGetAddressBookResponse(rsp *http.Response) (*GetAddressBookResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAddressBookResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Contact
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListPeerRulesResponse parses an HTTP response from a ListPeerRulesWithResponse call
+func ParseListPeerRulesResponse(rsp *http.Response) (*ListPeerRulesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListPeerRulesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []PeerRule
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAddPeerRuleResponse parses an HTTP response from a AddPeerRuleWithResponse call
+func ParseAddPeerRuleResponse(rsp *http.Response) (*AddPeerRuleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AddPeerRuleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PeerRule
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRemovePeerRuleResponse parses an HTTP response from a RemovePeerRuleWithResponse call
+func ParseRemovePeerRuleResponse(rsp *http.Response) (*RemovePeerRuleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RemovePeerRuleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRenderGraphResponse parses an HTTP response from a RenderGraphWithResponse call
+func ParseRenderGraphResponse(rsp *http.Response) (*RenderGraphResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RenderGraphResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
 	}
-	return ParseReprocessResponse(rsp)
+
+	return response, nil
 }
 
-// ListTransactionsWithResponse request returning *ListTransactionsResponse
-func (c *ClientWithResponses) ListTransactionsWithResponse(ctx context.Context, params *ListTransactionsParams, reqEditors ...RequestEditorFn) (*ListTransactionsResponse, error) {
-	rsp, err := c.ListTransactions(ctx, params, reqEditors...)
+// ParseGetPeerDiagnosticsResponse parses an HTTP response from a GetPeerDiagnosticsWithResponse call
+func ParseGetPeerDiagnosticsResponse(rsp *http.Response) (*GetPeerDiagnosticsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseListTransactionsResponse(rsp)
-}
 
-// GetTransactionWithResponse request returning *GetTransactionResponse
-func (c *ClientWithResponses) GetTransactionWithResponse(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*GetTransactionResponse, error) {
-	rsp, err := c.GetTransaction(ctx, ref, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &GetPeerDiagnosticsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseGetTransactionResponse(rsp)
-}
 
-// GetTransactionPayloadWithResponse request returning *GetTransactionPayloadResponse
-func (c *ClientWithResponses) GetTransactionPayloadWithResponse(ctx context.Context, ref string, reqEditors ...RequestEditorFn) (*GetTransactionPayloadResponse, error) {
-	rsp, err := c.GetTransactionPayload(ctx, ref, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest map[string]PeerDiagnostics
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
 	}
-	return ParseGetTransactionPayloadResponse(rsp)
+
+	return response, nil
 }
 
-// ParseGetAddressBookResponse parses an HTTP response from a GetAddressBookWithResponse call
-func ParseGetAddressBookResponse(rsp *http.Response) (*GetAddressBookResponse, error) {
+// ParseListEventsResponse parses an HTTP response from a ListEventsWithResponse call
+func ParseListEventsResponse(rsp *http.Response) (*ListEventsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetAddressBookResponse{
+	response := &ListEventsResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest []Contact
+		var dest []EventSubscriber
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
 	}
 
 	return response, nil
 }
 
-// ParseRenderGraphResponse parses an HTTP response from a RenderGraphWithResponse call
-func ParseRenderGraphResponse(rsp *http.Response) (*RenderGraphResponse, error) {
+// ParseStreamTransactionsResponse parses an HTTP response from a StreamTransactionsWithResponse call
+func ParseStreamTransactionsResponse(rsp *http.Response) (*StreamTransactionsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &RenderGraphResponse{
+	response := &StreamTransactionsResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
@@ -1051,22 +2305,22 @@ func ParseRenderGraphResponse(rsp *http.Response) (*RenderGraphResponse, error)
 	return response, nil
 }
 
-// ParseGetPeerDiagnosticsResponse parses an HTTP response from a GetPeerDiagnosticsWithResponse call
-func ParseGetPeerDiagnosticsResponse(rsp *http.Response) (*GetPeerDiagnosticsResponse, error) {
+// ParseSearchTransactionsResponse parses an HTTP response from a SearchTransactionsWithResponse call
+func ParseSearchTransactionsResponse(rsp *http.Response) (*SearchTransactionsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetPeerDiagnosticsResponse{
+	response := &SearchTransactionsResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest map[string]PeerDiagnostics
+		var dest TransactionSearchResult
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1093,27 +2347,36 @@ func ParseGetPeerDiagnosticsResponse(rsp *http.Response) (*GetPeerDiagnosticsRes
 	return response, nil
 }
 
-// ParseListEventsResponse parses an HTTP response from a ListEventsWithResponse call
-func ParseListEventsResponse(rsp *http.Response) (*ListEventsResponse, error) {
+// ParseReprocessResponse parses an HTTP response from a ReprocessWithResponse call
+func ParseReprocessResponse(rsp *http.Response) (*ReprocessResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &ListEventsResponse{
+	response := &ReprocessResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest []EventSubscriber
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON200 = &dest
+	return response, nil
+}
+
+// ParseExportSnapshotResponse parses an HTTP response from a ExportSnapshotWithResponse call
+func ParseExportSnapshotResponse(rsp *http.Response) (*ExportSnapshotResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ExportSnapshotResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
 
+	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
 		var dest struct {
 			// Detail A human-readable explanation specific to this occurrence of the problem.
@@ -1135,19 +2398,45 @@ func ParseListEventsResponse(rsp *http.Response) (*ListEventsResponse, error) {
 	return response, nil
 }
 
-// ParseReprocessResponse parses an HTTP response from a ReprocessWithResponse call
-func ParseReprocessResponse(rsp *http.Response) (*ReprocessResponse, error) {
+// ParseImportSnapshotResponse parses an HTTP response from a ImportSnapshotWithResponse call
+func ParseImportSnapshotResponse(rsp *http.Response) (*ImportSnapshotResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &ReprocessResponse{
+	response := &ImportSnapshotResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SnapshotImportResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
 	return response, nil
 }
 
@@ -1268,6 +2557,15 @@ type ServerInterface interface {
 	// Get the address book, listing which nodes will be connected to.
 	// (GET /internal/network/v1/addressbook)
 	GetAddressBook(ctx echo.Context) error
+	// Lists the rules that allow or deny connections with peers.
+	// (GET /internal/network/v1/addressbook/rules)
+	ListPeerRules(ctx echo.Context) error
+	// Adds a rule that allows or denies connections with peers.
+	// (POST /internal/network/v1/addressbook/rules)
+	AddPeerRule(ctx echo.Context) error
+	// Removes a rule that allows or denies connections with peers.
+	// (DELETE /internal/network/v1/addressbook/rules/{id})
+	RemovePeerRule(ctx echo.Context, id string) error
 	// Visualizes the DAG as a graph
 	// (GET /internal/network/v1/diagnostics/graph)
 	RenderGraph(ctx echo.Context, params RenderGraphParams) error
@@ -1277,9 +2575,21 @@ type ServerInterface interface {
 	// Lists the state of the internal events
 	// (GET /internal/network/v1/events)
 	ListEvents(ctx echo.Context) error
+	// Streams new transactions as they are added to the DAG
+	// (GET /internal/network/v1/explorer/stream)
+	StreamTransactions(ctx echo.Context, params StreamTransactionsParams) error
+	// Searches transactions on the DAG
+	// (GET /internal/network/v1/explorer/transaction)
+	SearchTransactions(ctx echo.Context, params SearchTransactionsParams) error
 	// Reprocess all transactions of the given type, verify and process
 	// (POST /internal/network/v1/reprocess)
 	Reprocess(ctx echo.Context, params ReprocessParams) error
+	// Exports a snapshot of the DAG
+	// (GET /internal/network/v1/snapshot)
+	ExportSnapshot(ctx echo.Context, params ExportSnapshotParams) error
+	// Imports a snapshot of the DAG
+	// (POST /internal/network/v1/snapshot)
+	ImportSnapshot(ctx echo.Context) error
 	// Lists transactions on the DAG
 	// (GET /internal/network/v1/transaction)
 	ListTransactions(ctx echo.Context, params ListTransactionsParams) error
@@ -1303,56 +2613,220 @@ func (w *ServerInterfaceWrapper) GetAddressBook(ctx echo.Context) error {
 	ctx.Set(JwtBearerAuthScopes, []string{})
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetAddressBook(ctx)
+	err = w.Handler.GetAddressBook(ctx)
+	return err
+}
+
+// ListPeerRules converts echo context to params.
+func (w *ServerInterfaceWrapper) ListPeerRules(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ListPeerRules(ctx)
+	return err
+}
+
+// AddPeerRule converts echo context to params.
+func (w *ServerInterfaceWrapper) AddPeerRule(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.AddPeerRule(ctx)
+	return err
+}
+
+// RemovePeerRule converts echo context to params.
+func (w *ServerInterfaceWrapper) RemovePeerRule(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RemovePeerRule(ctx, id)
+	return err
+}
+
+// RenderGraph converts echo context to params.
+func (w *ServerInterfaceWrapper) RenderGraph(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RenderGraphParams
+	// ------------- Optional query parameter "start" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "start", ctx.QueryParams(), &params.Start)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter start: %s", err))
+	}
+
+	// ------------- Optional query parameter "end" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "end", ctx.QueryParams(), &params.End)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter end: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RenderGraph(ctx, params)
+	return err
+}
+
+// GetPeerDiagnostics converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPeerDiagnostics(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetPeerDiagnostics(ctx)
+	return err
+}
+
+// ListEvents converts echo context to params.
+func (w *ServerInterfaceWrapper) ListEvents(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ListEvents(ctx)
+	return err
+}
+
+// StreamTransactions converts echo context to params.
+func (w *ServerInterfaceWrapper) StreamTransactions(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params StreamTransactionsParams
+	// ------------- Optional query parameter "contentType" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "contentType", ctx.QueryParams(), &params.ContentType)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter contentType: %s", err))
+	}
+
+	// ------------- Optional query parameter "signer" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "signer", ctx.QueryParams(), &params.Signer)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter signer: %s", err))
+	}
+
+	// ------------- Optional query parameter "did" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "did", ctx.QueryParams(), &params.Did)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter did: %s", err))
+	}
+
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since", ctx.QueryParams(), &params.Since)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter since: %s", err))
+	}
+
+	// ------------- Optional query parameter "until" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "until", ctx.QueryParams(), &params.Until)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter until: %s", err))
+	}
+
+	// ------------- Optional query parameter "participant" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "participant", ctx.QueryParams(), &params.Participant)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter participant: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.StreamTransactions(ctx, params)
 	return err
 }
 
-// RenderGraph converts echo context to params.
-func (w *ServerInterfaceWrapper) RenderGraph(ctx echo.Context) error {
+// SearchTransactions converts echo context to params.
+func (w *ServerInterfaceWrapper) SearchTransactions(ctx echo.Context) error {
 	var err error
 
 	ctx.Set(JwtBearerAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params RenderGraphParams
-	// ------------- Optional query parameter "start" -------------
+	var params SearchTransactionsParams
+	// ------------- Optional query parameter "contentType" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "start", ctx.QueryParams(), &params.Start)
+	err = runtime.BindQueryParameter("form", true, false, "contentType", ctx.QueryParams(), &params.ContentType)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter start: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter contentType: %s", err))
 	}
 
-	// ------------- Optional query parameter "end" -------------
+	// ------------- Optional query parameter "signer" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "end", ctx.QueryParams(), &params.End)
+	err = runtime.BindQueryParameter("form", true, false, "signer", ctx.QueryParams(), &params.Signer)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter end: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter signer: %s", err))
 	}
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.RenderGraph(ctx, params)
-	return err
-}
+	// ------------- Optional query parameter "did" -------------
 
-// GetPeerDiagnostics converts echo context to params.
-func (w *ServerInterfaceWrapper) GetPeerDiagnostics(ctx echo.Context) error {
-	var err error
+	err = runtime.BindQueryParameter("form", true, false, "did", ctx.QueryParams(), &params.Did)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter did: %s", err))
+	}
 
-	ctx.Set(JwtBearerAuthScopes, []string{})
+	// ------------- Optional query parameter "since" -------------
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetPeerDiagnostics(ctx)
-	return err
-}
+	err = runtime.BindQueryParameter("form", true, false, "since", ctx.QueryParams(), &params.Since)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter since: %s", err))
+	}
 
-// ListEvents converts echo context to params.
-func (w *ServerInterfaceWrapper) ListEvents(ctx echo.Context) error {
-	var err error
+	// ------------- Optional query parameter "until" -------------
 
-	ctx.Set(JwtBearerAuthScopes, []string{})
+	err = runtime.BindQueryParameter("form", true, false, "until", ctx.QueryParams(), &params.Until)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter until: %s", err))
+	}
+
+	// ------------- Optional query parameter "participant" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "participant", ctx.QueryParams(), &params.Participant)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter participant: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.ListEvents(ctx)
+	err = w.Handler.SearchTransactions(ctx, params)
 	return err
 }
 
@@ -1376,6 +2850,37 @@ func (w *ServerInterfaceWrapper) Reprocess(ctx echo.Context) error {
 	return err
 }
 
+// ExportSnapshot converts echo context to params.
+func (w *ServerInterfaceWrapper) ExportSnapshot(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportSnapshotParams
+	// ------------- Optional query parameter "clock" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "clock", ctx.QueryParams(), &params.Clock)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter clock: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ExportSnapshot(ctx, params)
+	return err
+}
+
+// ImportSnapshot converts echo context to params.
+func (w *ServerInterfaceWrapper) ImportSnapshot(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ImportSnapshot(ctx)
+	return err
+}
+
 // ListTransactions converts echo context to params.
 func (w *ServerInterfaceWrapper) ListTransactions(ctx echo.Context) error {
 	var err error
@@ -1463,52 +2968,293 @@ func RegisterHandlers(router EchoRouter, si ServerInterface) {
 // can be served under a prefix.
 func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
 
-	wrapper := ServerInterfaceWrapper{
-		Handler: si,
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	router.GET(baseURL+"/internal/network/v1/addressbook", wrapper.GetAddressBook)
+	router.GET(baseURL+"/internal/network/v1/addressbook/rules", wrapper.ListPeerRules)
+	router.POST(baseURL+"/internal/network/v1/addressbook/rules", wrapper.AddPeerRule)
+	router.DELETE(baseURL+"/internal/network/v1/addressbook/rules/:id", wrapper.RemovePeerRule)
+	router.GET(baseURL+"/internal/network/v1/diagnostics/graph", wrapper.RenderGraph)
+	router.GET(baseURL+"/internal/network/v1/diagnostics/peers", wrapper.GetPeerDiagnostics)
+	router.GET(baseURL+"/internal/network/v1/events", wrapper.ListEvents)
+	router.GET(baseURL+"/internal/network/v1/explorer/stream", wrapper.StreamTransactions)
+	router.GET(baseURL+"/internal/network/v1/explorer/transaction", wrapper.SearchTransactions)
+	router.POST(baseURL+"/internal/network/v1/reprocess", wrapper.Reprocess)
+	router.GET(baseURL+"/internal/network/v1/snapshot", wrapper.ExportSnapshot)
+	router.POST(baseURL+"/internal/network/v1/snapshot", wrapper.ImportSnapshot)
+	router.GET(baseURL+"/internal/network/v1/transaction", wrapper.ListTransactions)
+	router.GET(baseURL+"/internal/network/v1/transaction/:ref", wrapper.GetTransaction)
+	router.GET(baseURL+"/internal/network/v1/transaction/:ref/payload", wrapper.GetTransactionPayload)
+
+}
+
+type GetAddressBookRequestObject struct {
+}
+
+type GetAddressBookResponseObject interface {
+	VisitGetAddressBookResponse(w http.ResponseWriter) error
+}
+
+type GetAddressBook200JSONResponse []Contact
+
+func (response GetAddressBook200JSONResponse) VisitGetAddressBookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListPeerRulesRequestObject struct {
+}
+
+type ListPeerRulesResponseObject interface {
+	VisitListPeerRulesResponse(w http.ResponseWriter) error
+}
+
+type ListPeerRules200JSONResponse []PeerRule
+
+func (response ListPeerRules200JSONResponse) VisitListPeerRulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListPeerRulesdefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
+}
+
+func (response ListPeerRulesdefaultApplicationProblemPlusJSONResponse) VisitListPeerRulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type AddPeerRuleRequestObject struct {
+	Body *AddPeerRuleJSONRequestBody
+}
+
+type AddPeerRuleResponseObject interface {
+	VisitAddPeerRuleResponse(w http.ResponseWriter) error
+}
+
+type AddPeerRule200JSONResponse PeerRule
+
+func (response AddPeerRule200JSONResponse) VisitAddPeerRuleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddPeerRuledefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
+}
+
+func (response AddPeerRuledefaultApplicationProblemPlusJSONResponse) VisitAddPeerRuleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RemovePeerRuleRequestObject struct {
+	Id string `json:"id"`
+}
+
+type RemovePeerRuleResponseObject interface {
+	VisitRemovePeerRuleResponse(w http.ResponseWriter) error
+}
+
+type RemovePeerRule204Response struct {
+}
+
+func (response RemovePeerRule204Response) VisitRemovePeerRuleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RemovePeerRuledefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
+}
+
+func (response RemovePeerRuledefaultApplicationProblemPlusJSONResponse) VisitRemovePeerRuleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RenderGraphRequestObject struct {
+	Params RenderGraphParams
+}
+
+type RenderGraphResponseObject interface {
+	VisitRenderGraphResponse(w http.ResponseWriter) error
+}
+
+type RenderGraph200TextvndGraphvizResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response RenderGraph200TextvndGraphvizResponse) VisitRenderGraphResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/vnd.graphviz")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type RenderGraphdefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
+}
+
+func (response RenderGraphdefaultApplicationProblemPlusJSONResponse) VisitRenderGraphResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetPeerDiagnosticsRequestObject struct {
+}
+
+type GetPeerDiagnosticsResponseObject interface {
+	VisitGetPeerDiagnosticsResponse(w http.ResponseWriter) error
+}
+
+type GetPeerDiagnostics200JSONResponse map[string]PeerDiagnostics
+
+func (response GetPeerDiagnostics200JSONResponse) VisitGetPeerDiagnosticsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPeerDiagnosticsdefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
 	}
+	StatusCode int
+}
 
-	router.GET(baseURL+"/internal/network/v1/addressbook", wrapper.GetAddressBook)
-	router.GET(baseURL+"/internal/network/v1/diagnostics/graph", wrapper.RenderGraph)
-	router.GET(baseURL+"/internal/network/v1/diagnostics/peers", wrapper.GetPeerDiagnostics)
-	router.GET(baseURL+"/internal/network/v1/events", wrapper.ListEvents)
-	router.POST(baseURL+"/internal/network/v1/reprocess", wrapper.Reprocess)
-	router.GET(baseURL+"/internal/network/v1/transaction", wrapper.ListTransactions)
-	router.GET(baseURL+"/internal/network/v1/transaction/:ref", wrapper.GetTransaction)
-	router.GET(baseURL+"/internal/network/v1/transaction/:ref/payload", wrapper.GetTransactionPayload)
+func (response GetPeerDiagnosticsdefaultApplicationProblemPlusJSONResponse) VisitGetPeerDiagnosticsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
 
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type GetAddressBookRequestObject struct {
+type ListEventsRequestObject struct {
 }
 
-type GetAddressBookResponseObject interface {
-	VisitGetAddressBookResponse(w http.ResponseWriter) error
+type ListEventsResponseObject interface {
+	VisitListEventsResponse(w http.ResponseWriter) error
 }
 
-type GetAddressBook200JSONResponse []Contact
+type ListEvents200JSONResponse []EventSubscriber
 
-func (response GetAddressBook200JSONResponse) VisitGetAddressBookResponse(w http.ResponseWriter) error {
+func (response ListEvents200JSONResponse) VisitListEventsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type RenderGraphRequestObject struct {
-	Params RenderGraphParams
+type ListEventsdefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
 }
 
-type RenderGraphResponseObject interface {
-	VisitRenderGraphResponse(w http.ResponseWriter) error
+func (response ListEventsdefaultApplicationProblemPlusJSONResponse) VisitListEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type RenderGraph200TextvndGraphvizResponse struct {
+type StreamTransactionsRequestObject struct {
+	Params StreamTransactionsParams
+}
+
+type StreamTransactionsResponseObject interface {
+	VisitStreamTransactionsResponse(w http.ResponseWriter) error
+}
+
+type StreamTransactions200TexteventStreamResponse struct {
 	Body          io.Reader
 	ContentLength int64
 }
 
-func (response RenderGraph200TextvndGraphvizResponse) VisitRenderGraphResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "text/vnd.graphviz")
+func (response StreamTransactions200TexteventStreamResponse) VisitStreamTransactionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/event-stream")
 	if response.ContentLength != 0 {
 		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
 	}
@@ -1521,7 +3267,7 @@ func (response RenderGraph200TextvndGraphvizResponse) VisitRenderGraphResponse(w
 	return err
 }
 
-type RenderGraphdefaultApplicationProblemPlusJSONResponse struct {
+type StreamTransactionsdefaultApplicationProblemPlusJSONResponse struct {
 	Body struct {
 		// Detail A human-readable explanation specific to this occurrence of the problem.
 		Detail string `json:"detail"`
@@ -1535,30 +3281,31 @@ type RenderGraphdefaultApplicationProblemPlusJSONResponse struct {
 	StatusCode int
 }
 
-func (response RenderGraphdefaultApplicationProblemPlusJSONResponse) VisitRenderGraphResponse(w http.ResponseWriter) error {
+func (response StreamTransactionsdefaultApplicationProblemPlusJSONResponse) VisitStreamTransactionsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(response.StatusCode)
 
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type GetPeerDiagnosticsRequestObject struct {
+type SearchTransactionsRequestObject struct {
+	Params SearchTransactionsParams
 }
 
-type GetPeerDiagnosticsResponseObject interface {
-	VisitGetPeerDiagnosticsResponse(w http.ResponseWriter) error
+type SearchTransactionsResponseObject interface {
+	VisitSearchTransactionsResponse(w http.ResponseWriter) error
 }
 
-type GetPeerDiagnostics200JSONResponse map[string]PeerDiagnostics
+type SearchTransactions200JSONResponse TransactionSearchResult
 
-func (response GetPeerDiagnostics200JSONResponse) VisitGetPeerDiagnosticsResponse(w http.ResponseWriter) error {
+func (response SearchTransactions200JSONResponse) VisitSearchTransactionsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetPeerDiagnosticsdefaultApplicationProblemPlusJSONResponse struct {
+type SearchTransactionsdefaultApplicationProblemPlusJSONResponse struct {
 	Body struct {
 		// Detail A human-readable explanation specific to this occurrence of the problem.
 		Detail string `json:"detail"`
@@ -1572,30 +3319,57 @@ type GetPeerDiagnosticsdefaultApplicationProblemPlusJSONResponse struct {
 	StatusCode int
 }
 
-func (response GetPeerDiagnosticsdefaultApplicationProblemPlusJSONResponse) VisitGetPeerDiagnosticsResponse(w http.ResponseWriter) error {
+func (response SearchTransactionsdefaultApplicationProblemPlusJSONResponse) VisitSearchTransactionsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(response.StatusCode)
 
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type ListEventsRequestObject struct {
+type ReprocessRequestObject struct {
+	Params ReprocessParams
 }
 
-type ListEventsResponseObject interface {
-	VisitListEventsResponse(w http.ResponseWriter) error
+type ReprocessResponseObject interface {
+	VisitReprocessResponse(w http.ResponseWriter) error
 }
 
-type ListEvents200JSONResponse []EventSubscriber
+type Reprocess202Response struct {
+}
 
-func (response ListEvents200JSONResponse) VisitListEventsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
+func (response Reprocess202Response) VisitReprocessResponse(w http.ResponseWriter) error {
+	w.WriteHeader(202)
+	return nil
+}
+
+type ExportSnapshotRequestObject struct {
+	Params ExportSnapshotParams
+}
+
+type ExportSnapshotResponseObject interface {
+	VisitExportSnapshotResponse(w http.ResponseWriter) error
+}
+
+type ExportSnapshot200ApplicationoctetStreamResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response ExportSnapshot200ApplicationoctetStreamResponse) VisitExportSnapshotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/octet-stream")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
 	w.WriteHeader(200)
 
-	return json.NewEncoder(w).Encode(response)
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
 }
 
-type ListEventsdefaultApplicationProblemPlusJSONResponse struct {
+type ExportSnapshotdefaultApplicationProblemPlusJSONResponse struct {
 	Body struct {
 		// Detail A human-readable explanation specific to this occurrence of the problem.
 		Detail string `json:"detail"`
@@ -1609,27 +3383,49 @@ type ListEventsdefaultApplicationProblemPlusJSONResponse struct {
 	StatusCode int
 }
 
-func (response ListEventsdefaultApplicationProblemPlusJSONResponse) VisitListEventsResponse(w http.ResponseWriter) error {
+func (response ExportSnapshotdefaultApplicationProblemPlusJSONResponse) VisitExportSnapshotResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(response.StatusCode)
 
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type ReprocessRequestObject struct {
-	Params ReprocessParams
+type ImportSnapshotRequestObject struct {
+	Body io.Reader
 }
 
-type ReprocessResponseObject interface {
-	VisitReprocessResponse(w http.ResponseWriter) error
+type ImportSnapshotResponseObject interface {
+	VisitImportSnapshotResponse(w http.ResponseWriter) error
 }
 
-type Reprocess202Response struct {
+type ImportSnapshot200JSONResponse SnapshotImportResult
+
+func (response ImportSnapshot200JSONResponse) VisitImportSnapshotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (response Reprocess202Response) VisitReprocessResponse(w http.ResponseWriter) error {
-	w.WriteHeader(202)
-	return nil
+type ImportSnapshotdefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
+}
+
+func (response ImportSnapshotdefaultApplicationProblemPlusJSONResponse) VisitImportSnapshotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
 type ListTransactionsRequestObject struct {
@@ -1771,6 +3567,15 @@ type StrictServerInterface interface {
 	// Get the address book, listing which nodes will be connected to.
 	// (GET /internal/network/v1/addressbook)
 	GetAddressBook(ctx context.Context, request GetAddressBookRequestObject) (GetAddressBookResponseObject, error)
+	// Lists the rules that allow or deny connections with peers.
+	// (GET /internal/network/v1/addressbook/rules)
+	ListPeerRules(ctx context.Context, request ListPeerRulesRequestObject) (ListPeerRulesResponseObject, error)
+	// Adds a rule that allows or denies connections with peers.
+	// (POST /internal/network/v1/addressbook/rules)
+	AddPeerRule(ctx context.Context, request AddPeerRuleRequestObject) (AddPeerRuleResponseObject, error)
+	// Removes a rule that allows or denies connections with peers.
+	// (DELETE /internal/network/v1/addressbook/rules/{id})
+	RemovePeerRule(ctx context.Context, request RemovePeerRuleRequestObject) (RemovePeerRuleResponseObject, error)
 	// Visualizes the DAG as a graph
 	// (GET /internal/network/v1/diagnostics/graph)
 	RenderGraph(ctx context.Context, request RenderGraphRequestObject) (RenderGraphResponseObject, error)
@@ -1780,9 +3585,21 @@ type StrictServerInterface interface {
 	// Lists the state of the internal events
 	// (GET /internal/network/v1/events)
 	ListEvents(ctx context.Context, request ListEventsRequestObject) (ListEventsResponseObject, error)
+	// Streams new transactions as they are added to the DAG
+	// (GET /internal/network/v1/explorer/stream)
+	StreamTransactions(ctx context.Context, request StreamTransactionsRequestObject) (StreamTransactionsResponseObject, error)
+	// Searches transactions on the DAG
+	// (GET /internal/network/v1/explorer/transaction)
+	SearchTransactions(ctx context.Context, request SearchTransactionsRequestObject) (SearchTransactionsResponseObject, error)
 	// Reprocess all transactions of the given type, verify and process
 	// (POST /internal/network/v1/reprocess)
 	Reprocess(ctx context.Context, request ReprocessRequestObject) (ReprocessResponseObject, error)
+	// Exports a snapshot of the DAG
+	// (GET /internal/network/v1/snapshot)
+	ExportSnapshot(ctx context.Context, request ExportSnapshotRequestObject) (ExportSnapshotResponseObject, error)
+	// Imports a snapshot of the DAG
+	// (POST /internal/network/v1/snapshot)
+	ImportSnapshot(ctx context.Context, request ImportSnapshotRequestObject) (ImportSnapshotResponseObject, error)
 	// Lists transactions on the DAG
 	// (GET /internal/network/v1/transaction)
 	ListTransactions(ctx context.Context, request ListTransactionsRequestObject) (ListTransactionsResponseObject, error)
@@ -1829,6 +3646,83 @@ func (sh *strictHandler) GetAddressBook(ctx echo.Context) error {
 	return nil
 }
 
+// ListPeerRules operation middleware
+func (sh *strictHandler) ListPeerRules(ctx echo.Context) error {
+	var request ListPeerRulesRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ListPeerRules(ctx.Request().Context(), request.(ListPeerRulesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListPeerRules")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ListPeerRulesResponseObject); ok {
+		return validResponse.VisitListPeerRulesResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// AddPeerRule operation middleware
+func (sh *strictHandler) AddPeerRule(ctx echo.Context) error {
+	var request AddPeerRuleRequestObject
+
+	var body AddPeerRuleJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.AddPeerRule(ctx.Request().Context(), request.(AddPeerRuleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AddPeerRule")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(AddPeerRuleResponseObject); ok {
+		return validResponse.VisitAddPeerRuleResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// RemovePeerRule operation middleware
+func (sh *strictHandler) RemovePeerRule(ctx echo.Context, id string) error {
+	var request RemovePeerRuleRequestObject
+
+	request.Id = id
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RemovePeerRule(ctx.Request().Context(), request.(RemovePeerRuleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RemovePeerRule")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(RemovePeerRuleResponseObject); ok {
+		return validResponse.VisitRemovePeerRuleResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // RenderGraph operation middleware
 func (sh *strictHandler) RenderGraph(ctx echo.Context, params RenderGraphParams) error {
 	var request RenderGraphRequestObject
@@ -1900,6 +3794,56 @@ func (sh *strictHandler) ListEvents(ctx echo.Context) error {
 	return nil
 }
 
+// StreamTransactions operation middleware
+func (sh *strictHandler) StreamTransactions(ctx echo.Context, params StreamTransactionsParams) error {
+	var request StreamTransactionsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.StreamTransactions(ctx.Request().Context(), request.(StreamTransactionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "StreamTransactions")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(StreamTransactionsResponseObject); ok {
+		return validResponse.VisitStreamTransactionsResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// SearchTransactions operation middleware
+func (sh *strictHandler) SearchTransactions(ctx echo.Context, params SearchTransactionsParams) error {
+	var request SearchTransactionsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.SearchTransactions(ctx.Request().Context(), request.(SearchTransactionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SearchTransactions")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(SearchTransactionsResponseObject); ok {
+		return validResponse.VisitSearchTransactionsResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // Reprocess operation middleware
 func (sh *strictHandler) Reprocess(ctx echo.Context, params ReprocessParams) error {
 	var request ReprocessRequestObject
@@ -1925,6 +3869,56 @@ func (sh *strictHandler) Reprocess(ctx echo.Context, params ReprocessParams) err
 	return nil
 }
 
+// ExportSnapshot operation middleware
+func (sh *strictHandler) ExportSnapshot(ctx echo.Context, params ExportSnapshotParams) error {
+	var request ExportSnapshotRequestObject
+
+	request.Params = params
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ExportSnapshot(ctx.Request().Context(), request.(ExportSnapshotRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ExportSnapshot")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ExportSnapshotResponseObject); ok {
+		return validResponse.VisitExportSnapshotResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// ImportSnapshot operation middleware
+func (sh *strictHandler) ImportSnapshot(ctx echo.Context) error {
+	var request ImportSnapshotRequestObject
+
+	request.Body = ctx.Request().Body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ImportSnapshot(ctx.Request().Context(), request.(ImportSnapshotRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ImportSnapshot")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ImportSnapshotResponseObject); ok {
+		return validResponse.VisitImportSnapshotResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // ListTransactions operation middleware
 func (sh *strictHandler) ListTransactions(ctx echo.Context, params ListTransactionsParams) error {
 	var request ListTransactionsRequestObject
@@ -27,6 +27,9 @@ import (
 // PeerDiagnostics defines the type for diagnostics of a peer
 type PeerDiagnostics transport.Diagnostics
 
+// PeerRule defines the type for a rule that allows or denies connections with peers
+type PeerRule transport.PeerRule
+
 // UnmarshalJSON is the custom JSON unmarshaler for PeerDiagnostics
 func (p *PeerDiagnostics) UnmarshalJSON(bytes []byte) error {
 	result := transport.Diagnostics{}
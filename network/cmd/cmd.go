@@ -19,8 +19,11 @@
 package cmd
 
 import (
+	"crypto/sha256"
 	"fmt"
+	"io"
 	"log"
+	"os"
 	"sort"
 	"strconv"
 	"strings"
@@ -52,6 +55,16 @@ func FlagSet() *pflag.FlagSet {
 	flagSet.IntSlice("network.protocols", defs.Protocols, "Specifies the list of network protocols to enable on the server. They are specified by version (1, 2). If not set, all protocols are enabled.")
 	flagSet.Int("network.v2.gossipinterval", defs.ProtocolV2.GossipInterval, "Interval (in milliseconds) that specifies how often the node should gossip its new hashes to other nodes.")
 	flagSet.Int("network.v2.diagnosticsinterval", defs.ProtocolV2.DiagnosticsInterval, "Interval (in milliseconds) that specifies how often the node should broadcast its diagnostic information to other nodes (specify 0 to disable).")
+	flagSet.Duration("network.archive.threshold", defs.Archive.Threshold, "Minimum age of a transaction before its payload is moved to the payload archive, from which it can still be read (Golang duration format, e.g. '8760h'). Archiving is disabled if 0.")
+	flagSet.Duration("network.archive.interval", defs.Archive.Interval, "Interval that specifies how often payloads are archived (in Golang duration format, e.g. '24h').")
+	flagSet.StringSlice("network.archive.contenttypes", defs.Archive.ContentTypes, "Payload types of the transactions whose payloads are archived (e.g. 'application/did+json'). If not set, payloads of all types are archived.")
+	flagSet.Float64("network.ratelimit.peermessages", defs.RateLimit.PeerMessages, "Maximum messages per second exchanged with a peer (inbound, outbound separately, 10s burst). Excess messages are delayed, the peer is backed off if it takes too long. Disabled if 0.")
+	flagSet.Int("network.ratelimit.peerbytes", defs.RateLimit.PeerBytes, "Maximum bytes per second exchanged with a peer (inbound, outbound separately, 10s burst). Excess messages are delayed, the peer is backed off if it takes too long. Disabled if 0.")
+	flagSet.Float64("network.ratelimit.globalmessages", defs.RateLimit.GlobalMessages, "Maximum messages per second exchanged with all peers combined (inbound, outbound separately, 10s burst). Excess messages are delayed. Disabled if 0.")
+	flagSet.Int("network.ratelimit.globalbytes", defs.RateLimit.GlobalBytes, "Maximum bytes per second exchanged with all peers combined (inbound, outbound separately, 10s burst). Excess messages are delayed. Disabled if 0.")
+	flagSet.Duration("network.ratelimit.backoff", defs.RateLimit.Backoff, "Time connections with a peer are refused after it exceeded its rate limit (in Golang duration format, e.g. '5m').")
+	flagSet.Int("network.peerscore.threshold", defs.PeerScore.Threshold, "Number of penalties (e.g. for sending invalid transactions) within the window at which a peer is disconnected and refused until its penalties expire. Disabled if 0.")
+	flagSet.Duration("network.peerscore.window", defs.PeerScore.Window, "Time a penalty counts towards a peer's score (in Golang duration format, e.g. '1h').")
 
 	return flagSet
 }
@@ -67,6 +80,7 @@ func Cmd() *cobra.Command {
 	cmd.AddCommand(payloadCommand())
 	cmd.AddCommand(peersCommand())
 	cmd.AddCommand(reprocessCommand())
+	cmd.AddCommand(snapshotCommand())
 	return cmd
 }
 
@@ -240,6 +254,71 @@ func reprocessCommand() *cobra.Command {
 	}
 }
 
+func snapshotCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Export or import a snapshot of the DAG, to bootstrap a new node without replaying the whole DAG over the network",
+	}
+	cmd.AddCommand(snapshotExportCommand())
+	cmd.AddCommand(snapshotImportCommand())
+	return cmd
+}
+
+func snapshotExportCommand() *cobra.Command {
+	var clock string
+	cmd := &cobra.Command{
+		Use:   "export [file]",
+		Short: "Exports the transactions and payloads on the DAG to a snapshot archive",
+		Long: "Exports the transactions and payloads on the DAG to a snapshot archive. " +
+			"Exporting a large DAG can take a while, so you might need to increase the client timeout (--timeout).",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientConfig := core.NewClientConfigForCommand(cmd)
+			file, err := os.Create(args[0])
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			checksum := sha256.New()
+			if err = httpClient(clientConfig).ExportSnapshot(io.MultiWriter(file, checksum), convertRange(clock)); err != nil {
+				return fmt.Errorf("unable to export snapshot: %w", err)
+			}
+			if err = file.Close(); err != nil {
+				return err
+			}
+			cmd.Printf("Exported DAG snapshot to %s (SHA-256: %x)\n", args[0], checksum.Sum(nil))
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&clock, "clock", "", "highest lamport clock value (inclusive) to export, defaults to the entire DAG")
+	return cmd
+}
+
+func snapshotImportCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "import [file]",
+		Short: "Imports a snapshot archive into the DAG",
+		Long: "Imports a snapshot archive into the DAG. The archive must be signed by a node with a TLS certificate trusted by the truststore. " +
+			"Transactions are verified as if they were received from peers. " +
+			"Importing a large snapshot can take a while, so you might need to increase the client timeout (--timeout).",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientConfig := core.NewClientConfigForCommand(cmd)
+			file, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			result, err := httpClient(clientConfig).ImportSnapshot(file)
+			if err != nil {
+				return fmt.Errorf("unable to import snapshot: %w", err)
+			}
+			cmd.Printf("Imported DAG snapshot up to clock %d: %d transactions added, %d already present\n", result.Clock, result.Added, result.Present)
+			return nil
+		},
+	}
+}
+
 // Sorts the transactions by provided flag or by time.
 func sortTransactions(transactions []dag.Transaction, sortFlag string) {
 	sort.Slice(transactions, func(i, j int) bool {
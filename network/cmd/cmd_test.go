@@ -24,6 +24,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path"
 	"strings"
 	"testing"
 	"time"
@@ -281,3 +283,81 @@ func TestCmd_Reprocess(t *testing.T) {
 		assert.EqualError(t, cmd.Execute(), "unable to reprocess transactions: Post \"http:///internal/network/v1/reprocess?type=application%2Fdid%2Bjson\": http: no Host in request URL")
 	})
 }
+
+func TestCmd_Snapshot(t *testing.T) {
+	t.Run("export", func(t *testing.T) {
+		cmd := Cmd()
+		handler := &http2.Handler{StatusCode: http.StatusOK, ResponseData: "snapshot"}
+		s := httptest.NewServer(handler)
+		t.Setenv("NUTS_ADDRESS", s.URL)
+		cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+		defer s.Close()
+		file := path.Join(t.TempDir(), "snapshot.gz")
+		outBuf := new(bytes.Buffer)
+		cmd.SetOut(outBuf)
+		cmd.SetArgs([]string{"snapshot", "export", file, "--clock", "100"})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "100", handler.RequestQuery.Get("clock"))
+		data, _ := os.ReadFile(file)
+		assert.Equal(t, "snapshot", string(data))
+		assert.Contains(t, outBuf.String(), "SHA-256: "+hash.SHA256Sum([]byte("snapshot")).String())
+	})
+	t.Run("export - http error", func(t *testing.T) {
+		cmd := Cmd()
+		handler := &http2.Handler{StatusCode: http.StatusInternalServerError}
+		s := httptest.NewServer(handler)
+		t.Setenv("NUTS_ADDRESS", s.URL)
+		cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+		defer s.Close()
+		cmd.SetArgs([]string{"snapshot", "export", path.Join(t.TempDir(), "snapshot.gz")})
+
+		err := cmd.Execute()
+
+		assert.ErrorContains(t, err, "unable to export snapshot")
+	})
+	t.Run("import", func(t *testing.T) {
+		cmd := Cmd()
+		handler := &http2.Handler{StatusCode: http.StatusOK, ResponseData: v1.SnapshotImportResult{Clock: 100, Added: 10, Present: 5}}
+		s := httptest.NewServer(handler)
+		t.Setenv("NUTS_ADDRESS", s.URL)
+		cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+		defer s.Close()
+		file := path.Join(t.TempDir(), "snapshot.gz")
+		require.NoError(t, os.WriteFile(file, []byte("snapshot"), 0600))
+		outBuf := new(bytes.Buffer)
+		cmd.SetOut(outBuf)
+		cmd.SetArgs([]string{"snapshot", "import", file})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "snapshot", string(handler.RequestData))
+		assert.Equal(t, "Imported DAG snapshot up to clock 100: 10 transactions added, 5 already present\n", outBuf.String())
+	})
+	t.Run("import - invalid snapshot", func(t *testing.T) {
+		cmd := Cmd()
+		handler := &http2.Handler{StatusCode: http.StatusBadRequest, ResponseData: "{\"detail\":\"invalid DAG snapshot\"}"}
+		s := httptest.NewServer(handler)
+		t.Setenv("NUTS_ADDRESS", s.URL)
+		cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+		defer s.Close()
+		file := path.Join(t.TempDir(), "snapshot.gz")
+		require.NoError(t, os.WriteFile(file, []byte("snapshot"), 0600))
+		cmd.SetArgs([]string{"snapshot", "import", file})
+
+		err := cmd.Execute()
+
+		assert.ErrorContains(t, err, "unable to import snapshot")
+	})
+	t.Run("import - file not found", func(t *testing.T) {
+		cmd := Cmd()
+		cmd.SetArgs([]string{"snapshot", "import", path.Join(t.TempDir(), "snapshot.gz")})
+
+		err := cmd.Execute()
+
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
@@ -19,6 +19,7 @@
 package network
 
 import (
+	"github.com/nuts-foundation/nuts-node/network/transport/grpc"
 	v2 "github.com/nuts-foundation/nuts-node/network/transport/v2"
 	"time"
 )
@@ -44,6 +45,25 @@ type Config struct {
 
 	// ProtocolV2 specifies config for protocol v2
 	ProtocolV2 v2.Config `koanf:"v2"`
+
+	// Archive specifies config for archiving transaction payloads
+	Archive ArchiveConfig `koanf:"archive"`
+
+	// RateLimit specifies the limits on the gRPC messages exchanged with peers
+	RateLimit grpc.RateLimitConfig `koanf:"ratelimit"`
+
+	// PeerScore specifies when peers are disconnected because they send invalid transactions
+	PeerScore grpc.PeerScoreConfig `koanf:"peerscore"`
+}
+
+// ArchiveConfig specifies which transaction payloads are moved from the DAG to the payload archive, and how often.
+type ArchiveConfig struct {
+	// Threshold is the minimum age of a transaction before its payload is archived. Archiving is disabled if 0.
+	Threshold time.Duration `koanf:"threshold"`
+	// Interval specifies how often payloads are archived.
+	Interval time.Duration `koanf:"interval"`
+	// ContentTypes limits archiving to payloads of the given types. If empty, payloads of all types are archived.
+	ContentTypes []string `koanf:"contenttypes"`
 }
 
 // IsProtocolEnabled returns true if the protocol is enabled, otherwise false.
@@ -67,5 +87,14 @@ func DefaultConfig() Config {
 		MaxBackoff:        24 * time.Hour,
 		ProtocolV2:        v2.DefaultConfig(),
 		EnableDiscovery:   true,
+		Archive: ArchiveConfig{
+			Interval: 24 * time.Hour,
+		},
+		RateLimit: grpc.RateLimitConfig{
+			Backoff: 5 * time.Minute,
+		},
+		PeerScore: grpc.PeerScoreConfig{
+			Window: time.Hour,
+		},
 	}
 }
@@ -0,0 +1,106 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package dag
+
+import (
+	"encoding/binary"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nuts-foundation/go-stoabs"
+)
+
+// archivePageSize is the number of Lamport clock values processed in a single DB transaction when archiving payloads.
+const archivePageSize = 1000
+
+// archiveProgressKey is the key of the metadata property that holds the first Lamport clock value that hasn't been
+// fully processed by ArchivePayloads. Transactions with a lower clock value aren't evaluated again.
+const archiveProgressKey = "archive_lc"
+
+// archiveContentTypesKey is the key of the metadata property that holds the content types of the ArchivePolicy
+// the archive progress applies to. If the content types change, all transactions are evaluated again.
+const archiveContentTypesKey = "archive_types"
+
+// ArchivePolicy specifies which payloads are moved from the DAG store to the payload archive.
+// Since archived payloads can still be read, the policy doesn't distinguish payloads that are unlikely to be needed
+// (e.g. of deactivated DID documents or private transactions this node can't decrypt) from other payloads:
+// all payloads that are old enough (and have one of the configured types) are archived.
+type ArchivePolicy struct {
+	// Threshold is the minimum age of a transaction (measured from its signing time) before its payload is archived.
+	Threshold time.Duration
+	// ContentTypes limits archiving to payloads of transactions with one of the given payload types.
+	// If empty, payloads of all types are archived.
+	ContentTypes []string
+}
+
+// isOldEnough returns true if the given transaction is older than the threshold at the given moment.
+func (p ArchivePolicy) isOldEnough(transaction Transaction, now time.Time) bool {
+	return !transaction.SigningTime().After(now.Add(-p.Threshold))
+}
+
+// matches returns true if the payload of the given transaction should be archived at the given moment.
+func (p ArchivePolicy) matches(transaction Transaction, now time.Time) bool {
+	if !p.isOldEnough(transaction, now) {
+		return false
+	}
+	return len(p.ContentTypes) == 0 || slices.Contains(p.ContentTypes, transaction.PayloadType())
+}
+
+// contentTypesKey returns the content types of the policy in a canonical form, to detect policy changes.
+func (p ArchivePolicy) contentTypesKey() []byte {
+	contentTypes := slices.Clone(p.ContentTypes)
+	slices.Sort(contentTypes)
+	return []byte(strings.Join(slices.Compact(contentTypes), "\n"))
+}
+
+// getArchiveProgress returns the first Lamport clock value that hasn't been fully processed for the given policy.
+func getArchiveProgress(tx stoabs.ReadTx, policy ArchivePolicy) (uint64, error) {
+	reader := tx.GetShelfReader(metadataShelf)
+	contentTypes, err := reader.Get(stoabs.BytesKey(archiveContentTypesKey))
+	if errors.Is(err, stoabs.ErrKeyNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if string(contentTypes) != string(policy.contentTypesKey()) {
+		return 0, nil
+	}
+	progress, err := reader.Get(stoabs.BytesKey(archiveProgressKey))
+	if errors.Is(err, stoabs.ErrKeyNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return bytesToCount(progress), nil
+}
+
+// setArchiveProgress stores the first Lamport clock value that hasn't been fully processed for the given policy.
+func setArchiveProgress(tx stoabs.WriteTx, policy ArchivePolicy, progress uint64) error {
+	writer := tx.GetShelfWriter(metadataShelf)
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, progress)
+	if err := writer.Put(stoabs.BytesKey(archiveProgressKey), value); err != nil {
+		return err
+	}
+	return writer.Put(stoabs.BytesKey(archiveContentTypesKey), policy.contentTypesKey())
+}
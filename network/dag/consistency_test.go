@@ -137,7 +137,7 @@ func createXorTreeRepairState(t testing.TB, tx Transaction) *state {
 func createStoppedState(t testing.TB) *state {
 	testDir := io.TestDirectory(t)
 	bboltStore := storage.CreateTestBBoltStore(t, path.Join(testDir, "test.db"))
-	s, err := NewState(bboltStore)
+	s, err := NewState(bboltStore, nil)
 	require.NoError(t, err)
 	t.Cleanup(func() {
 		s.Shutdown()
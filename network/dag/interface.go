@@ -36,6 +36,9 @@ var ErrTransactionNotFound = errors.New("transaction not found")
 // ErrPayloadNotFound is returned when the requested payload is not found
 var ErrPayloadNotFound = errors.New("payload not found")
 
+// ErrPayloadArchiveDisabled is returned when payloads are archived, but no payload archive is configured
+var ErrPayloadArchiveDisabled = errors.New("payload archive not configured")
+
 // State represents the Node transactional state. Mutations are done via this abstraction layer.
 // Notifications are also done via this layer
 type State interface {
@@ -51,6 +54,12 @@ type State interface {
 	// ReadPayload reads the contents for the specified payload, identified by the given hash. If contents can't be found,
 	// ErrPayloadNotFound is returned. If something (else) goes wrong an error is returned.
 	ReadPayload(ctx context.Context, payloadHash hash.SHA256Hash) ([]byte, error)
+	// ArchivePayloads moves the payloads of transactions that match the given policy from the DAG store to the payload archive.
+	// Archived payloads are still considered present and can be read using ReadPayload. It returns the number of archived payloads.
+	// Transactions are evaluated once they're older than the policy's threshold. The progress is persisted,
+	// so subsequent calls only evaluate newer transactions, unless the content types of the policy changed.
+	// If no payload archive is configured, ErrPayloadArchiveDisabled is returned.
+	ArchivePayloads(ctx context.Context, policy ArchivePolicy) (int, error)
 	// Add a transaction to the DAG. If it can't be added an error is returned.
 	// If the transaction already exists, nothing is added and no observers are notified.
 	// The payload may be passed as well. Allowing for better notification of observers
@@ -121,6 +130,9 @@ type PayloadStore interface {
 	readPayload(tx stoabs.ReadTx, payloadHash hash.SHA256Hash) ([]byte, error)
 	// WritePayload writes contents for the specified payload, identified by the given hash.
 	writePayload(tx stoabs.WriteTx, payloadHash hash.SHA256Hash, data []byte) error
+	// archivePayloads moves the specified payloads from the DAG store to the payload archive and returns the number of moved payloads.
+	// Payloads that aren't present in the DAG store are skipped.
+	archivePayloads(tx stoabs.WriteTx, payloadHashes []hash.SHA256Hash) (int, error)
 }
 
 // MaxLamportClock is the highest Lamport Clock value a transaction on the DAG can have.
@@ -58,6 +58,21 @@ func (mr *MockStateMockRecorder) Add(ctx, transactions, payload any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockState)(nil).Add), ctx, transactions, payload)
 }
 
+// ArchivePayloads mocks base method.
+func (m *MockState) ArchivePayloads(ctx context.Context, policy ArchivePolicy) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchivePayloads", ctx, policy)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchivePayloads indicates an expected call of ArchivePayloads.
+func (mr *MockStateMockRecorder) ArchivePayloads(ctx, policy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchivePayloads", reflect.TypeOf((*MockState)(nil).ArchivePayloads), ctx, policy)
+}
+
 // Configure mocks base method.
 func (m *MockState) Configure(config core.ServerConfig) error {
 	m.ctrl.T.Helper()
@@ -358,6 +373,21 @@ func (m *MockPayloadStore) EXPECT() *MockPayloadStoreMockRecorder {
 	return m.recorder
 }
 
+// archivePayloads mocks base method.
+func (m *MockPayloadStore) archivePayloads(tx stoabs.WriteTx, payloadHashes []hash.SHA256Hash) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "archivePayloads", tx, payloadHashes)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// archivePayloads indicates an expected call of archivePayloads.
+func (mr *MockPayloadStoreMockRecorder) archivePayloads(tx, payloadHashes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "archivePayloads", reflect.TypeOf((*MockPayloadStore)(nil).archivePayloads), tx, payloadHashes)
+}
+
 // isPayloadPresent mocks base method.
 func (m *MockPayloadStore) isPayloadPresent(tx stoabs.ReadTx, payloadHash hash.SHA256Hash) bool {
 	m.ctrl.T.Helper()
@@ -19,6 +19,7 @@
 package dag
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/nuts-foundation/go-stoabs"
@@ -34,7 +35,16 @@ func NewPayloadStore() PayloadStore {
 	return &payloadStore{}
 }
 
-type payloadStore struct{}
+// NewArchivingPayloadStore creates a new payload store that can move payloads to the given archive store.
+// Payloads that aren't found in the DAG store are read from the archive.
+func NewArchivingPayloadStore(archive stoabs.KVStore) PayloadStore {
+	return &payloadStore{archive: archive}
+}
+
+type payloadStore struct {
+	// archive contains the payloads that were moved out of the DAG store. It's nil if archiving is disabled.
+	archive stoabs.KVStore
+}
 
 func (store payloadStore) isPayloadPresent(tx stoabs.ReadTx, payloadHash hash.SHA256Hash) bool {
 	data, err := store.readPayload(tx, payloadHash)
@@ -50,7 +60,21 @@ func (store payloadStore) isPayloadPresent(tx stoabs.ReadTx, payloadHash hash.SH
 }
 
 func (store payloadStore) readPayload(tx stoabs.ReadTx, payloadHash hash.SHA256Hash) ([]byte, error) {
-	reader := tx.GetShelfReader(payloadsShelf)
+	data, err := readPayloadFromShelf(tx.GetShelfReader(payloadsShelf), payloadHash)
+	if errors.Is(err, ErrPayloadNotFound) && store.archive != nil {
+		// ReadShelf doesn't invoke the callback if the archive doesn't contain any payloads yet
+		archiveErr := store.archive.ReadShelf(context.Background(), payloadsShelf, func(reader stoabs.Reader) error {
+			data, err = readPayloadFromShelf(reader, payloadHash)
+			return nil
+		})
+		if archiveErr != nil {
+			return nil, fmt.Errorf("failed to read payload from archive (hash=%s): %w", payloadHash, archiveErr)
+		}
+	}
+	return data, err
+}
+
+func readPayloadFromShelf(reader stoabs.Reader, payloadHash hash.SHA256Hash) ([]byte, error) {
 	data, err := reader.Get(stoabs.NewHashKey(payloadHash))
 	if errors.Is(err, stoabs.ErrKeyNotFound) {
 		return nil, ErrPayloadNotFound
@@ -65,3 +89,44 @@ func (store payloadStore) writePayload(tx stoabs.WriteTx, payloadHash hash.SHA25
 	writer := tx.GetShelfWriter(payloadsShelf)
 	return writer.Put(stoabs.NewHashKey(payloadHash), data)
 }
+
+func (store payloadStore) archivePayloads(tx stoabs.WriteTx, payloadHashes []hash.SHA256Hash) (int, error) {
+	if store.archive == nil {
+		return 0, ErrPayloadArchiveDisabled
+	}
+	writer := tx.GetShelfWriter(payloadsShelf)
+	payloads := make(map[hash.SHA256Hash][]byte)
+	for _, payloadHash := range payloadHashes {
+		data, err := readPayloadFromShelf(writer, payloadHash)
+		if errors.Is(err, ErrPayloadNotFound) {
+			// not present or already archived
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		payloads[payloadHash] = data
+	}
+	if len(payloads) == 0 {
+		return 0, nil
+	}
+	// Payloads are first written to the archive and then removed from the DAG store.
+	// If the DAG transaction is rolled back, the payloads are present in both stores, which is harmless.
+	err := store.archive.WriteShelf(context.Background(), payloadsShelf, func(archiveWriter stoabs.Writer) error {
+		for payloadHash, data := range payloads {
+			if err := archiveWriter.Put(stoabs.NewHashKey(payloadHash), data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to write payloads to archive: %w", err)
+	}
+	for payloadHash := range payloads {
+		if err := writer.Delete(stoabs.NewHashKey(payloadHash)); err != nil {
+			return 0, err
+		}
+	}
+	return len(payloads), nil
+}
@@ -23,12 +23,14 @@ import (
 	"errors"
 	"fmt"
 	"github.com/stretchr/testify/require"
+	"path"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 
 	"github.com/nuts-foundation/go-stoabs"
+	"github.com/nuts-foundation/go-stoabs/bbolt"
 	"github.com/nuts-foundation/nuts-node/crypto/hash"
 	"github.com/nuts-foundation/nuts-node/test/io"
 )
@@ -63,6 +65,36 @@ func TestPayloadStore_ReadWrite(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestPayloadStore_archivePayloads(t *testing.T) {
+	testDirectory := io.TestDirectory(t)
+	db := createBBoltDB(testDirectory)
+	archive, _ := bbolt.CreateBBoltStore(path.Join(testDirectory, "archive"), stoabs.WithNoSync())
+	payloadStore := NewArchivingPayloadStore(archive).(*payloadStore)
+	payload := []byte("Hello, World!")
+	payloadHash := hash.SHA256Sum(payload)
+
+	err := db.Write(context.Background(), func(tx stoabs.WriteTx) error {
+		require.NoError(t, payloadStore.writePayload(tx, payloadHash, payload))
+
+		count, err := payloadStore.archivePayloads(tx, []hash.SHA256Hash{payloadHash, hash.SHA256Sum([]byte("unknown"))})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+		// removed from the DAG store, but still readable
+		_, err = readPayloadFromShelf(tx.GetShelfReader(payloadsShelf), payloadHash)
+		assert.ErrorIs(t, err, ErrPayloadNotFound)
+		data, err := payloadStore.readPayload(tx, payloadHash)
+		assert.NoError(t, err)
+		assert.Equal(t, payload, data)
+		assert.True(t, payloadStore.isPayloadPresent(tx, payloadHash))
+		// unknown payloads aren't found in the archive either
+		_, err = payloadStore.readPayload(tx, hash.SHA256Sum([]byte("unknown")))
+		assert.ErrorIs(t, err, ErrPayloadNotFound)
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
 func TestPayloadStore_readPayload(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	tx := stoabs.NewMockReadTx(ctrl)
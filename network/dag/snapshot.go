@@ -0,0 +1,376 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package dag
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/lestrrat-go/jwx/v2/cert"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/crypto/hash"
+)
+
+// SnapshotFormatVersion is the version of the snapshot archive format written by ExportSnapshot.
+const SnapshotFormatVersion = 1
+
+// snapshotPageSize is the number of Lamport clock values read from the DAG at once when exporting a snapshot.
+const snapshotPageSize = 1000
+
+// maxSnapshotRecordSize is the maximum size of a single (JSON encoded) record in a snapshot archive.
+const maxSnapshotRecordSize = 16 * 1024 * 1024
+
+// MaxSnapshotSize is the maximum size of a snapshot archive that can be imported, both compressed and uncompressed.
+const MaxSnapshotSize int64 = 4 * 1024 * 1024 * 1024
+
+// ErrInvalidSnapshot is returned when a snapshot archive can't be read or doesn't match its trailer.
+var ErrInvalidSnapshot = errors.New("invalid DAG snapshot")
+
+// SnapshotCertificateVerifier verifies the certificate chain (leaf first) of the node that signed a snapshot archive.
+type SnapshotCertificateVerifier func(chain []*x509.Certificate) error
+
+// Snapshot describes the contents of a DAG snapshot archive.
+type Snapshot struct {
+	// Clock is the highest Lamport clock value of the transactions in the archive.
+	Clock uint32 `json:"clock"`
+	// Transactions is the number of transactions in the archive.
+	Transactions int `json:"transactions"`
+	// XOR is the XOR of the references of all transactions in the archive.
+	// When the archive contains the DAG up to a page boundary, it equals State.XOR for that clock value.
+	XOR hash.SHA256Hash `json:"xor"`
+}
+
+// SnapshotImportResult describes the outcome of importing a DAG snapshot archive.
+type SnapshotImportResult struct {
+	Snapshot
+	// Added is the number of transactions that were added to the DAG.
+	Added int `json:"added"`
+	// Present is the number of transactions that were skipped since they were already present on the DAG.
+	Present int `json:"present"`
+}
+
+// snapshotRecord is a single line in a snapshot archive.
+// The first record contains the header, the last record the trailer and all records in between contain a transaction.
+// The header (snapshotHeader) and trailer (Snapshot) are JWSs signed by the exporting node.
+type snapshotRecord struct {
+	Header      string `json:"header,omitempty"`
+	Transaction string `json:"tx,omitempty"`
+	Payload     []byte `json:"payload,omitempty"`
+	Trailer     string `json:"trailer,omitempty"`
+}
+
+type snapshotHeader struct {
+	Version int    `json:"version"`
+	Clock   uint32 `json:"clock"`
+}
+
+// ExportSnapshot writes all transactions with a Lamport clock value up to and including the given clock to the writer,
+// together with their payloads (if present). Transactions are written in the order they need to be added to a DAG.
+// The archive is a gzip compressed stream of JSON records: a header, one record per transaction and a trailer
+// containing the number of transactions and the XOR of their references, to detect truncated or altered archives.
+// The header and trailer are signed with the given (node TLS) certificate, which is included in the signatures.
+// Every transaction is a signed JWS itself and payloads are bound to their transaction by hash.
+func ExportSnapshot(ctx context.Context, state State, writer io.Writer, clock uint32, certificate tls.Certificate) (*Snapshot, error) {
+	if len(certificate.Certificate) == 0 {
+		return nil, errors.New("exporting a DAG snapshot requires a TLS certificate to sign it")
+	}
+	_, highestClock := state.XOR(MaxLamportClock)
+	if clock > highestClock {
+		clock = highestClock
+	}
+	header, err := signSnapshotRecord(certificate, snapshotHeader{Version: SnapshotFormatVersion, Clock: clock})
+	if err != nil {
+		return nil, err
+	}
+	gzipWriter := gzip.NewWriter(writer)
+	encoder := json.NewEncoder(gzipWriter)
+	if err := encoder.Encode(snapshotRecord{Header: header}); err != nil {
+		return nil, err
+	}
+	result := Snapshot{}
+	for start := uint64(0); start <= uint64(clock); start += snapshotPageSize {
+		end := min(start+snapshotPageSize, uint64(clock)+1)
+		transactions, err := state.FindBetweenLC(ctx, uint32(start), uint32(end))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read transactions (start=%d, end=%d): %w", start, end, err)
+		}
+		for _, transaction := range transactions {
+			payload, err := state.ReadPayload(ctx, transaction.PayloadHash())
+			if err != nil && !errors.Is(err, ErrPayloadNotFound) {
+				return nil, fmt.Errorf("unable to read payload (tx=%s): %w", transaction.Ref(), err)
+			}
+			if err = encoder.Encode(snapshotRecord{Transaction: string(transaction.Data()), Payload: payload}); err != nil {
+				return nil, err
+			}
+			result.Clock = transaction.Clock()
+			result.Transactions++
+			result.XOR = result.XOR.Xor(transaction.Ref())
+		}
+	}
+	trailer, err := signSnapshotRecord(certificate, result)
+	if err != nil {
+		return nil, err
+	}
+	if err := encoder.Encode(snapshotRecord{Trailer: trailer}); err != nil {
+		return nil, err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ImportSnapshot reads a snapshot archive written by ExportSnapshot and adds its transactions to the DAG.
+// The archive is verified completely before any transaction is added: the signatures of the header and trailer
+// (of which the certificate chain is verified using verifyCertificate), the payload hashes, the transaction clock values
+// and whether the transactions match the trailer. To do so, the archive is buffered in a temporary file.
+// Transactions are then added through State.Add, so they're subject to the same verification (e.g. signature and prevs)
+// as transactions received from peers. Transactions that are already present on the DAG are skipped.
+// Transactions without PAL must be accompanied by their payload. If the archive is invalid, ErrInvalidSnapshot is returned.
+func ImportSnapshot(ctx context.Context, state State, reader io.Reader, verifyCertificate SnapshotCertificateVerifier) (*SnapshotImportResult, error) {
+	file, err := os.CreateTemp("", "nuts-dag-snapshot-*")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+	}()
+	size, err := io.Copy(file, io.LimitReader(reader, MaxSnapshotSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if size > MaxSnapshotSize {
+		return nil, fmt.Errorf("%w: archive exceeds maximum size of %d bytes", ErrInvalidSnapshot, MaxSnapshotSize)
+	}
+
+	// First pass: verify the archive without touching the DAG
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	if _, err = readSnapshot(ctx, file, verifyCertificate, func(_ Transaction, _ []byte) error {
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// Second pass: add the transactions
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	result := SnapshotImportResult{}
+	snapshot, err := readSnapshot(ctx, file, verifyCertificate, func(transaction Transaction, payload []byte) error {
+		present, err := state.IsPresent(ctx, transaction.Ref())
+		if err != nil {
+			return err
+		}
+		if present {
+			result.Present++
+			return nil
+		}
+		if err = state.Add(ctx, transaction, payload); err != nil {
+			return fmt.Errorf("unable to add transaction to DAG (tx=%s): %w", transaction.Ref(), err)
+		}
+		result.Added++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Snapshot = *snapshot
+	return &result, nil
+}
+
+// readSnapshot reads and verifies a snapshot archive, calling visit for every transaction in it.
+// It returns the snapshot's trailer, after verifying the transactions match it.
+func readSnapshot(ctx context.Context, reader io.Reader, verifyCertificate SnapshotCertificateVerifier, visit func(transaction Transaction, payload []byte) error) (*Snapshot, error) {
+	gzipReader, err := gzip.NewReader(reader)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+	defer gzipReader.Close()
+	data := &io.LimitedReader{R: gzipReader, N: MaxSnapshotSize + 1}
+	scanner := bufio.NewScanner(data)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxSnapshotRecordSize)
+
+	var header *snapshotHeader
+	var trailer *Snapshot
+	var signer *x509.Certificate
+	contents := Snapshot{}
+	for scanner.Scan() {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+		if trailer != nil {
+			return nil, fmt.Errorf("%w: data after trailer", ErrInvalidSnapshot)
+		}
+		var record snapshotRecord
+		if err = json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+		}
+		switch {
+		case header == nil:
+			if record.Header == "" {
+				return nil, fmt.Errorf("%w: missing header", ErrInvalidSnapshot)
+			}
+			header = new(snapshotHeader)
+			if signer, err = verifySnapshotRecord(record.Header, verifyCertificate, header); err != nil {
+				return nil, fmt.Errorf("%w: header: %w", ErrInvalidSnapshot, err)
+			}
+			if header.Version != SnapshotFormatVersion {
+				return nil, fmt.Errorf("%w: unsupported version: %d", ErrInvalidSnapshot, header.Version)
+			}
+		case record.Trailer != "":
+			trailer = new(Snapshot)
+			trailerSigner, err := verifySnapshotRecord(record.Trailer, verifyCertificate, trailer)
+			if err != nil {
+				return nil, fmt.Errorf("%w: trailer: %w", ErrInvalidSnapshot, err)
+			}
+			if !trailerSigner.Equal(signer) {
+				return nil, fmt.Errorf("%w: header and trailer are signed by different certificates", ErrInvalidSnapshot)
+			}
+		default:
+			transaction, err := readSnapshotTransaction(record)
+			if err != nil {
+				return nil, err
+			}
+			if transaction.Clock() > header.Clock {
+				return nil, fmt.Errorf("%w: transaction clock exceeds snapshot clock (tx=%s)", ErrInvalidSnapshot, transaction.Ref())
+			}
+			if err = visit(transaction, record.Payload); err != nil {
+				return nil, err
+			}
+			contents.Clock = transaction.Clock()
+			contents.Transactions++
+			contents.XOR = contents.XOR.Xor(transaction.Ref())
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+	if data.N <= 0 {
+		return nil, fmt.Errorf("%w: archive exceeds maximum size of %d bytes", ErrInvalidSnapshot, MaxSnapshotSize)
+	}
+	if trailer == nil {
+		return nil, fmt.Errorf("%w: missing trailer", ErrInvalidSnapshot)
+	}
+	if trailer.Transactions != contents.Transactions || trailer.Clock != contents.Clock || !trailer.XOR.Equals(contents.XOR) {
+		return nil, fmt.Errorf("%w: contents don't match trailer (transactions=%d, expected=%d)", ErrInvalidSnapshot, contents.Transactions, trailer.Transactions)
+	}
+	return trailer, nil
+}
+
+func readSnapshotTransaction(record snapshotRecord) (Transaction, error) {
+	transaction, err := ParseTransaction([]byte(record.Transaction))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+	if record.Payload != nil {
+		if !transaction.PayloadHash().Equals(hash.SHA256Sum(record.Payload)) {
+			return nil, fmt.Errorf("%w: payload doesn't match payload hash (tx=%s)", ErrInvalidSnapshot, transaction.Ref())
+		}
+	} else if len(transaction.PAL()) == 0 {
+		return nil, fmt.Errorf("%w: missing payload (tx=%s)", ErrInvalidSnapshot, transaction.Ref())
+	}
+	return transaction, nil
+}
+
+// signSnapshotRecord signs the JSON representation of the given value as JWS with the certificate's private key.
+// The certificate chain is included in the x5c header.
+func signSnapshotRecord(certificate tls.Certificate, value interface{}) (string, error) {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	leaf, err := x509.ParseCertificate(certificate.Certificate[0])
+	if err != nil {
+		return "", err
+	}
+	alg, err := crypto.SignatureAlgorithm(leaf.PublicKey)
+	if err != nil {
+		return "", err
+	}
+	chain := new(cert.Chain)
+	for _, der := range certificate.Certificate {
+		if err = chain.AddString(base64.StdEncoding.EncodeToString(der)); err != nil {
+			return "", err
+		}
+	}
+	headers := jws.NewHeaders()
+	if err = headers.Set(jws.X509CertChainKey, chain); err != nil {
+		return "", err
+	}
+	signed, err := jws.Sign(payload, jws.WithKey(alg, certificate.PrivateKey, jws.WithProtectedHeaders(headers)))
+	if err != nil {
+		return "", fmt.Errorf("unable to sign DAG snapshot: %w", err)
+	}
+	return string(signed), nil
+}
+
+// verifySnapshotRecord verifies a JWS created by signSnapshotRecord and unmarshals its payload into target.
+// It returns the certificate that signed the JWS.
+func verifySnapshotRecord(signed string, verifyCertificate SnapshotCertificateVerifier, target interface{}) (*x509.Certificate, error) {
+	message, err := jws.Parse([]byte(signed))
+	if err != nil {
+		return nil, err
+	}
+	if len(message.Signatures()) != 1 {
+		return nil, errors.New("expected exactly 1 signature")
+	}
+	chainHeader := message.Signatures()[0].ProtectedHeaders().X509CertChain()
+	if chainHeader == nil || chainHeader.Len() == 0 {
+		return nil, errors.New("missing x5c header")
+	}
+	chain := make([]*x509.Certificate, chainHeader.Len())
+	for i := range chain {
+		encoded, _ := chainHeader.Get(i)
+		der, err := base64.StdEncoding.DecodeString(string(encoded))
+		if err != nil {
+			return nil, err
+		}
+		if chain[i], err = x509.ParseCertificate(der); err != nil {
+			return nil, err
+		}
+	}
+	if err = verifyCertificate(chain); err != nil {
+		return nil, fmt.Errorf("untrusted certificate: %w", err)
+	}
+	alg, err := crypto.SignatureAlgorithm(chain[0].PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := jws.Verify([]byte(signed), jws.WithKey(alg, chain[0].PublicKey))
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(payload, target); err != nil {
+		return nil, err
+	}
+	return chain[0], nil
+}
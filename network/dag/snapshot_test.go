@@ -0,0 +1,342 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package dag
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nuts-foundation/nuts-node/crypto/hash"
+	testPKI "github.com/nuts-foundation/nuts-node/test/pki"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportSnapshot(t *testing.T) {
+	ctx := context.Background()
+	source, transactions := createSnapshotSourceState(t)
+	certificate, verifyCertificate := createSnapshotCertificate(t)
+
+	t.Run("entire DAG", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+
+		snapshot, err := ExportSnapshot(ctx, source, buf, MaxLamportClock, certificate)
+
+		require.NoError(t, err)
+		expectedXOR, _ := source.XOR(MaxLamportClock)
+		assert.Equal(t, uint32(2), snapshot.Clock)
+		assert.Equal(t, 4, snapshot.Transactions)
+		assert.Equal(t, expectedXOR, snapshot.XOR)
+		records := readSnapshotRecords(t, buf)
+		require.Len(t, records, 6)
+		var header snapshotHeader
+		signer, err := verifySnapshotRecord(records[0].Header, verifyCertificate, &header)
+		require.NoError(t, err)
+		assert.Equal(t, certificate.Leaf, signer)
+		assert.Equal(t, SnapshotFormatVersion, header.Version)
+		assert.Equal(t, uint32(2), header.Clock)
+		assert.Equal(t, string(transactions[0].Data()), records[1].Transaction)
+		assert.Equal(t, snapshotPayload(1), records[1].Payload)
+		var trailer Snapshot
+		_, err = verifySnapshotRecord(records[5].Trailer, verifyCertificate, &trailer)
+		require.NoError(t, err)
+		assert.Equal(t, *snapshot, trailer)
+	})
+	t.Run("up to clock", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+
+		snapshot, err := ExportSnapshot(ctx, source, buf, 1, certificate)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint32(1), snapshot.Clock)
+		assert.Equal(t, 3, snapshot.Transactions)
+		assert.Len(t, readSnapshotRecords(t, buf), 5)
+	})
+	t.Run("transaction without payload", func(t *testing.T) {
+		state := createSnapshotState(t)
+		pal := [][]byte{{1, 2, 3}}
+		tx := CreateSignedTestTransaction(1, time.Now(), pal, "application/did+json", true)
+		require.NoError(t, state.Add(ctx, tx, nil))
+		buf := new(bytes.Buffer)
+
+		snapshot, err := ExportSnapshot(ctx, state, buf, MaxLamportClock, certificate)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, snapshot.Transactions)
+		records := readSnapshotRecords(t, buf)
+		assert.Nil(t, records[1].Payload)
+	})
+	t.Run("no certificate", func(t *testing.T) {
+		_, err := ExportSnapshot(ctx, source, new(bytes.Buffer), MaxLamportClock, tls.Certificate{})
+
+		assert.EqualError(t, err, "exporting a DAG snapshot requires a TLS certificate to sign it")
+	})
+}
+
+func TestImportSnapshot(t *testing.T) {
+	ctx := context.Background()
+	source, transactions := createSnapshotSourceState(t)
+	certificate, verifyCertificate := createSnapshotCertificate(t)
+	archive := new(bytes.Buffer)
+	_, err := ExportSnapshot(ctx, source, archive, MaxLamportClock, certificate)
+	require.NoError(t, err)
+	header := func(clock uint32) string {
+		return signTestSnapshotRecord(t, certificate, snapshotHeader{Version: SnapshotFormatVersion, Clock: clock})
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		target := createSnapshotState(t)
+
+		result, err := ImportSnapshot(ctx, target, bytes.NewReader(archive.Bytes()), verifyCertificate)
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, result.Added)
+		assert.Equal(t, 0, result.Present)
+		assert.Equal(t, uint32(2), result.Clock)
+		sourceXOR, _ := source.XOR(MaxLamportClock)
+		targetXOR, _ := target.XOR(MaxLamportClock)
+		assert.Equal(t, sourceXOR, targetXOR)
+		payload, err := target.ReadPayload(ctx, transactions[3].PayloadHash())
+		require.NoError(t, err)
+		assert.Equal(t, snapshotPayload(4), payload)
+	})
+	t.Run("transactions already present are skipped", func(t *testing.T) {
+		target := createSnapshotState(t)
+		require.NoError(t, target.Add(ctx, transactions[0], snapshotPayload(1)))
+
+		result, err := ImportSnapshot(ctx, target, bytes.NewReader(archive.Bytes()), verifyCertificate)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.Added)
+		assert.Equal(t, 1, result.Present)
+	})
+	t.Run("missing prev", func(t *testing.T) {
+		target := createSnapshotState(t)
+		data := writeSnapshotRecords(t,
+			snapshotRecord{Header: header(2)},
+			snapshotRecord{Transaction: string(transactions[1].Data()), Payload: snapshotPayload(2)},
+			snapshotRecord{Trailer: signTestSnapshotRecord(t, certificate, Snapshot{Clock: 1, Transactions: 1, XOR: transactions[1].Ref()})},
+		)
+
+		_, err := ImportSnapshot(ctx, target, data, verifyCertificate)
+
+		assert.ErrorIs(t, err, ErrPreviousTransactionMissing)
+	})
+	t.Run("payload doesn't match payload hash", func(t *testing.T) {
+		target := createSnapshotState(t)
+		data := writeSnapshotRecords(t,
+			snapshotRecord{Header: header(2)},
+			snapshotRecord{Transaction: string(transactions[0].Data()), Payload: snapshotPayload(2)},
+		)
+
+		_, err := ImportSnapshot(ctx, target, data, verifyCertificate)
+
+		assert.ErrorIs(t, err, ErrInvalidSnapshot)
+		assert.ErrorContains(t, err, "payload doesn't match payload hash")
+		present, _ := target.IsPresent(ctx, transactions[0].Ref())
+		assert.False(t, present)
+	})
+	t.Run("missing payload", func(t *testing.T) {
+		data := writeSnapshotRecords(t,
+			snapshotRecord{Header: header(2)},
+			snapshotRecord{Transaction: string(transactions[0].Data())},
+		)
+
+		_, err := ImportSnapshot(ctx, createSnapshotState(t), data, verifyCertificate)
+
+		assert.ErrorIs(t, err, ErrInvalidSnapshot)
+		assert.ErrorContains(t, err, "missing payload")
+	})
+	t.Run("transaction clock exceeds snapshot clock", func(t *testing.T) {
+		data := writeSnapshotRecords(t,
+			snapshotRecord{Header: header(0)},
+			snapshotRecord{Transaction: string(transactions[0].Data()), Payload: snapshotPayload(1)},
+			snapshotRecord{Transaction: string(transactions[1].Data()), Payload: snapshotPayload(2)},
+		)
+
+		target := createSnapshotState(t)
+
+		_, err := ImportSnapshot(ctx, target, data, verifyCertificate)
+
+		assert.ErrorIs(t, err, ErrInvalidSnapshot)
+		assert.ErrorContains(t, err, "transaction clock exceeds snapshot clock")
+		present, _ := target.IsPresent(ctx, transactions[0].Ref())
+		assert.False(t, present, "no transactions should be added when the archive is invalid")
+	})
+	t.Run("missing header", func(t *testing.T) {
+		data := writeSnapshotRecords(t, snapshotRecord{Transaction: string(transactions[0].Data()), Payload: snapshotPayload(1)})
+
+		_, err := ImportSnapshot(ctx, createSnapshotState(t), data, verifyCertificate)
+
+		assert.ErrorIs(t, err, ErrInvalidSnapshot)
+		assert.ErrorContains(t, err, "missing header")
+	})
+	t.Run("unsupported version", func(t *testing.T) {
+		data := writeSnapshotRecords(t, snapshotRecord{Header: signTestSnapshotRecord(t, certificate, snapshotHeader{Version: 2})})
+
+		_, err := ImportSnapshot(ctx, createSnapshotState(t), data, verifyCertificate)
+
+		assert.ErrorIs(t, err, ErrInvalidSnapshot)
+		assert.ErrorContains(t, err, "unsupported version: 2")
+	})
+	t.Run("missing trailer (truncated archive)", func(t *testing.T) {
+		data := writeSnapshotRecords(t,
+			snapshotRecord{Header: header(2)},
+			snapshotRecord{Transaction: string(transactions[0].Data()), Payload: snapshotPayload(1)},
+		)
+
+		target := createSnapshotState(t)
+
+		_, err := ImportSnapshot(ctx, target, data, verifyCertificate)
+
+		assert.ErrorIs(t, err, ErrInvalidSnapshot)
+		assert.ErrorContains(t, err, "missing trailer")
+		present, _ := target.IsPresent(ctx, transactions[0].Ref())
+		assert.False(t, present, "no transactions should be added when the archive is invalid")
+	})
+	t.Run("contents don't match trailer", func(t *testing.T) {
+		data := writeSnapshotRecords(t,
+			snapshotRecord{Header: header(2)},
+			snapshotRecord{Transaction: string(transactions[0].Data()), Payload: snapshotPayload(1)},
+			snapshotRecord{Trailer: signTestSnapshotRecord(t, certificate, Snapshot{Transactions: 2, XOR: hash.SHA256Sum([]byte("other"))})},
+		)
+
+		_, err := ImportSnapshot(ctx, createSnapshotState(t), data, verifyCertificate)
+
+		assert.ErrorIs(t, err, ErrInvalidSnapshot)
+		assert.ErrorContains(t, err, "contents don't match trailer")
+	})
+	t.Run("untrusted certificate", func(t *testing.T) {
+		target := createSnapshotState(t)
+
+		_, err := ImportSnapshot(ctx, target, bytes.NewReader(archive.Bytes()), func(_ []*x509.Certificate) error {
+			return errors.New("unknown CA")
+		})
+
+		assert.ErrorIs(t, err, ErrInvalidSnapshot)
+		assert.ErrorContains(t, err, "header: untrusted certificate: unknown CA")
+		present, _ := target.IsPresent(ctx, transactions[0].Ref())
+		assert.False(t, present)
+	})
+	t.Run("unsigned header", func(t *testing.T) {
+		data := writeSnapshotRecords(t, snapshotRecord{Header: `{"version":1,"clock":2}`})
+
+		_, err := ImportSnapshot(ctx, createSnapshotState(t), data, verifyCertificate)
+
+		assert.ErrorIs(t, err, ErrInvalidSnapshot)
+		assert.ErrorContains(t, err, "header")
+	})
+	t.Run("header and trailer signed by different certificates", func(t *testing.T) {
+		otherCertificate, _ := createSnapshotCertificate(t)
+		data := writeSnapshotRecords(t,
+			snapshotRecord{Header: header(2)},
+			snapshotRecord{Transaction: string(transactions[0].Data()), Payload: snapshotPayload(1)},
+			snapshotRecord{Trailer: signTestSnapshotRecord(t, otherCertificate, Snapshot{Transactions: 1, XOR: transactions[0].Ref()})},
+		)
+
+		_, err := ImportSnapshot(ctx, createSnapshotState(t), data, func(_ []*x509.Certificate) error {
+			return nil
+		})
+
+		assert.ErrorIs(t, err, ErrInvalidSnapshot)
+		assert.ErrorContains(t, err, "header and trailer are signed by different certificates")
+	})
+	t.Run("not a gzip stream", func(t *testing.T) {
+		_, err := ImportSnapshot(ctx, createSnapshotState(t), bytes.NewReader([]byte("not a snapshot")), verifyCertificate)
+
+		assert.ErrorIs(t, err, ErrInvalidSnapshot)
+	})
+}
+
+// createSnapshotSourceState creates a DAG with root (clock 0), 2 transactions referring to root (clock 1)
+// and 1 transaction merging them (clock 2).
+func createSnapshotSourceState(t *testing.T) (State, []Transaction) {
+	state := createSnapshotState(t)
+	root := CreateTestTransactionWithJWK(1)
+	a := CreateTestTransactionWithJWK(2, root)
+	b := CreateTestTransactionWithJWK(3, root)
+	c := CreateTestTransactionWithJWK(4, a, b)
+	transactions := []Transaction{root, a, b, c}
+	for i, tx := range transactions {
+		require.NoError(t, state.Add(context.Background(), tx, snapshotPayload(uint32(i+1))))
+	}
+	return state, transactions
+}
+
+// createSnapshotCertificate creates a certificate to sign snapshots with,
+// and a SnapshotCertificateVerifier that only trusts that certificate.
+func createSnapshotCertificate(t *testing.T) (tls.Certificate, SnapshotCertificateVerifier) {
+	key, leaf, err := testPKI.BuildRootCert()
+	require.NoError(t, err)
+	certificate := tls.Certificate{Certificate: [][]byte{leaf.Raw}, PrivateKey: key, Leaf: leaf}
+	return certificate, func(chain []*x509.Certificate) error {
+		if !chain[0].Equal(leaf) {
+			return errors.New("unknown certificate")
+		}
+		return nil
+	}
+}
+
+func signTestSnapshotRecord(t *testing.T, certificate tls.Certificate, value interface{}) string {
+	signed, err := signSnapshotRecord(certificate, value)
+	require.NoError(t, err)
+	return signed
+}
+
+func createSnapshotState(t *testing.T) State {
+	return createState(t, NewPrevTransactionsVerifier(), NewTransactionSignatureVerifier(nil))
+}
+
+func snapshotPayload(num uint32) []byte {
+	payload := make([]byte, 4)
+	binary.BigEndian.PutUint32(payload, num)
+	return payload
+}
+
+func readSnapshotRecords(t *testing.T, buf *bytes.Buffer) []snapshotRecord {
+	reader, err := gzip.NewReader(buf)
+	require.NoError(t, err)
+	decoder := json.NewDecoder(reader)
+	var records []snapshotRecord
+	for decoder.More() {
+		var record snapshotRecord
+		require.NoError(t, decoder.Decode(&record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func writeSnapshotRecords(t *testing.T, records ...snapshotRecord) *bytes.Buffer {
+	buf := new(bytes.Buffer)
+	writer := gzip.NewWriter(buf)
+	encoder := json.NewEncoder(writer)
+	for _, record := range records {
+		require.NoError(t, encoder.Encode(record))
+	}
+	require.NoError(t, writer.Close())
+	return buf
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 const (
@@ -64,10 +65,14 @@ func (s *state) Migrate() error {
 }
 
 // NewState returns a new State. The State is used as entry point, it's methods will start transactions and will notify observers from within those transactions.
-func NewState(db stoabs.KVStore, verifiers ...Verifier) (State, error) {
+// If archive is not nil, payloads can be moved to it using ArchivePayloads.
+func NewState(db stoabs.KVStore, archive stoabs.KVStore, verifiers ...Verifier) (State, error) {
 	graph := newDAG(db)
 
 	payloadStore := NewPayloadStore()
+	if archive != nil {
+		payloadStore = NewArchivingPayloadStore(archive)
+	}
 	newState := &state{
 		db:           db,
 		graph:        graph,
@@ -253,7 +258,7 @@ func (s *state) loadState(ctx context.Context) {
 func (s *state) verifyTX(tx stoabs.ReadTx, transaction Transaction) error {
 	for _, verifier := range s.txVerifiers {
 		if err := verifier(tx, transaction); err != nil {
-			return fmt.Errorf("transaction verification failed (tx=%s): %w", transaction.Ref(), err)
+			return fmt.Errorf("%w (tx=%s): %w", ErrTransactionVerificationFailed, transaction.Ref(), err)
 		}
 	}
 	return nil
@@ -317,6 +322,64 @@ func (s *state) ReadPayload(ctx context.Context, hash hash.SHA256Hash) (payload
 	return
 }
 
+func (s *state) ArchivePayloads(ctx context.Context, policy ArchivePolicy) (int, error) {
+	now := time.Now()
+	var start uint64
+	err := s.db.Read(ctx, func(tx stoabs.ReadTx) error {
+		var err error
+		start, err = getArchiveProgress(tx, policy)
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to read payload archive progress: %w", err)
+	}
+	_, highestClock := s.XOR(MaxLamportClock)
+	archived := 0
+	// progress is the first clock value that contains a transaction that isn't old enough to be archived yet.
+	// Transactions from that clock value on are evaluated again the next time.
+	progress := start
+	persistedProgress := start
+	settled := true
+	for page := start; page <= uint64(highestClock); page += archivePageSize {
+		end := min(page+archivePageSize, uint64(highestClock)+1)
+		transactions, err := s.FindBetweenLC(ctx, uint32(page), uint32(end))
+		if err != nil {
+			return archived, err
+		}
+		var payloadHashes []hash.SHA256Hash
+		for _, transaction := range transactions {
+			if settled && !policy.isOldEnough(transaction, now) {
+				settled = false
+				progress = uint64(transaction.Clock())
+			}
+			if policy.matches(transaction, now) {
+				payloadHashes = append(payloadHashes, transaction.PayloadHash())
+			}
+		}
+		if settled {
+			progress = end
+		}
+		if len(payloadHashes) == 0 && progress == persistedProgress {
+			continue
+		}
+		err = s.db.Write(ctx, func(tx stoabs.WriteTx) error {
+			if len(payloadHashes) > 0 {
+				count, err := s.payloadStore.archivePayloads(tx, payloadHashes)
+				archived += count
+				if err != nil {
+					return err
+				}
+			}
+			return setArchiveProgress(tx, policy, progress)
+		}, stoabs.WithWriteLock())
+		if err != nil {
+			return archived, fmt.Errorf("failed to archive payloads (start=%d, end=%d): %w", page, end, err)
+		}
+		persistedProgress = progress
+	}
+	return archived, nil
+}
+
 func (s *state) Head(ctx context.Context) (hash.SHA256Hash, error) {
 	var head hash.SHA256Hash
 	var err error
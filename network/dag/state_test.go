@@ -177,6 +177,104 @@ func TestState_WritePayload(t *testing.T) {
 	})
 }
 
+func TestState_ArchivePayloads(t *testing.T) {
+	ctx := context.Background()
+	createStateWithArchive := func(t *testing.T) (State, stoabs.KVStore) {
+		testDir := io.TestDirectory(t)
+		archive := storage.CreateTestBBoltStore(t, path.Join(testDir, "archive.db"))
+		s, err := NewState(storage.CreateTestBBoltStore(t, path.Join(testDir, "test.db")), archive)
+		require.NoError(t, err)
+		require.NoError(t, s.Start())
+		t.Cleanup(func() {
+			_ = s.Shutdown()
+		})
+		return s, archive
+	}
+	oldPayload := []byte{0, 0, 0, 1}
+	newPayload := []byte{0, 0, 0, 2}
+	oldTX := CreateSignedTestTransaction(1, time.Now().Add(-48*time.Hour), nil, "application/did+json", true)
+	newTX := CreateSignedTestTransaction(2, time.Now(), nil, "application/did+json", true, oldTX)
+
+	t.Run("archives payloads older than threshold", func(t *testing.T) {
+		txState, archive := createStateWithArchive(t)
+		require.NoError(t, txState.Add(ctx, oldTX, oldPayload))
+		require.NoError(t, txState.Add(ctx, newTX, newPayload))
+
+		archived, err := txState.ArchivePayloads(ctx, ArchivePolicy{Threshold: 24 * time.Hour})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, archived)
+		// archived payload is read from the archive
+		payload, err := txState.ReadPayload(ctx, oldTX.PayloadHash())
+		require.NoError(t, err)
+		assert.Equal(t, oldPayload, payload)
+		present, err := txState.IsPayloadPresent(ctx, oldTX.PayloadHash())
+		require.NoError(t, err)
+		assert.True(t, present)
+		_ = archive.ReadShelf(ctx, payloadsShelf, func(reader stoabs.Reader) error {
+			data, _ := reader.Get(stoabs.NewHashKey(oldTX.PayloadHash()))
+			assert.Equal(t, oldPayload, data)
+			data, _ = reader.Get(stoabs.NewHashKey(newTX.PayloadHash()))
+			assert.Nil(t, data)
+			return nil
+		})
+		// newer payload is still in the DAG store
+		payload, err = txState.ReadPayload(ctx, newTX.PayloadHash())
+		require.NoError(t, err)
+		assert.Equal(t, newPayload, payload)
+		// archiving again doesn't move anything
+		archived, err = txState.ArchivePayloads(ctx, ArchivePolicy{Threshold: 24 * time.Hour})
+		require.NoError(t, err)
+		assert.Equal(t, 0, archived)
+	})
+	t.Run("progress is persisted", func(t *testing.T) {
+		txState, _ := createStateWithArchive(t)
+		require.NoError(t, txState.Add(ctx, oldTX, oldPayload))
+		require.NoError(t, txState.Add(ctx, newTX, newPayload))
+		policy := ArchivePolicy{Threshold: 24 * time.Hour}
+		progress := func(policy ArchivePolicy) uint64 {
+			var result uint64
+			require.NoError(t, txState.(*state).db.Read(ctx, func(tx stoabs.ReadTx) error {
+				var err error
+				result, err = getArchiveProgress(tx, policy)
+				return err
+			}))
+			return result
+		}
+
+		_, err := txState.ArchivePayloads(ctx, policy)
+
+		require.NoError(t, err)
+		// newTX isn't old enough yet, so it's evaluated again next time
+		assert.Equal(t, uint64(newTX.Clock()), progress(policy))
+		// other content types start from the root of the DAG
+		assert.Equal(t, uint64(0), progress(ArchivePolicy{Threshold: 24 * time.Hour, ContentTypes: []string{"application/vc+json"}}))
+
+		// once all transactions are old enough, the progress moves past the highest clock value
+		_, err = txState.ArchivePayloads(ctx, ArchivePolicy{Threshold: 0})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(newTX.Clock()+1), progress(policy))
+	})
+	t.Run("content type doesn't match", func(t *testing.T) {
+		txState, _ := createStateWithArchive(t)
+		require.NoError(t, txState.Add(ctx, oldTX, oldPayload))
+
+		archived, err := txState.ArchivePayloads(ctx, ArchivePolicy{Threshold: time.Hour, ContentTypes: []string{"application/vc+json"}})
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, archived)
+	})
+	t.Run("no archive configured", func(t *testing.T) {
+		txState := createState(t)
+		require.NoError(t, txState.Add(ctx, oldTX, oldPayload))
+
+		_, err := txState.ArchivePayloads(ctx, ArchivePolicy{Threshold: time.Hour})
+
+		assert.ErrorIs(t, err, ErrPayloadArchiveDisabled)
+	})
+}
+
 func TestState_Add(t *testing.T) {
 	t.Run("error for transaction verification failure", func(t *testing.T) {
 		ctx := context.Background()
@@ -187,7 +285,7 @@ func TestState_Add(t *testing.T) {
 
 		err := txState.Add(ctx, transaction{}, nil)
 
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, ErrTransactionVerificationFailed)
 	})
 
 	t.Run("error when Notifier.Save fails", func(t *testing.T) {
@@ -461,7 +559,7 @@ func Test_createStore(t *testing.T) {
 func createState(t testing.TB, verifier ...Verifier) State {
 	testDir := io.TestDirectory(t)
 	bboltStore := storage.CreateTestBBoltStore(t, path.Join(testDir, "test.db"))
-	s, err := NewState(bboltStore, verifier...)
+	s, err := NewState(bboltStore, nil, verifier...)
 	if err != nil {
 		t.Fatal("failed to create store: ", err)
 	}
@@ -33,6 +33,9 @@ import (
 // is missing.
 var ErrPreviousTransactionMissing = errors.New("transaction is referring to non-existing previous transaction")
 
+// ErrTransactionVerificationFailed is returned when a transaction is rejected by one of the transaction verifiers.
+var ErrTransactionVerificationFailed = errors.New("transaction verification failed")
+
 // ErrInvalidLamportClockValue indicates the lamport clock value for the transaction is wrong.
 var ErrInvalidLamportClockValue = errors.New("transaction has an invalid lamport clock value")
 
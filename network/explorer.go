@@ -0,0 +1,324 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package network
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/crypto/hash"
+	"github.com/nuts-foundation/nuts-node/network/dag"
+	"github.com/nuts-foundation/nuts-node/network/log"
+)
+
+// explorerPageSize is the number of Lamport clock values read from the DAG at once when searching transactions.
+const explorerPageSize = 1000
+
+// explorerMaxScannedClocks is the maximum number of Lamport clock values scanned by a single call to FindTransactions.
+const explorerMaxScannedClocks = 10 * explorerPageSize
+
+// transactionStreamBufferSize is the number of transactions that are buffered for a subscriber of StreamTransactions.
+// If the subscriber doesn't keep up, newer transactions are dropped.
+const transactionStreamBufferSize = 100
+
+// ErrInvalidTransactionCursor is returned when a transaction cursor can't be parsed.
+var ErrInvalidTransactionCursor = errors.New("invalid transaction cursor")
+
+// TransactionQuery specifies the criteria transactions must match to be returned by FindTransactions and StreamTransactions.
+// Criteria that aren't set match all transactions.
+type TransactionQuery struct {
+	// ContentType matches the payload type of the transaction.
+	ContentType string
+	// SignerKeyID matches the ID of the key that signed the transaction.
+	SignerKeyID string
+	// DID matches the DID of the key that signed the transaction.
+	DID *did.DID
+	// Since matches transactions that were signed at or after the given time.
+	Since *time.Time
+	// Until matches transactions that were signed before the given time.
+	Until *time.Time
+	// Participant matches private transactions with the given DID as participant.
+	// Only transactions of which the PAL can be decrypted by this node (as participant) can be matched.
+	Participant *did.DID
+}
+
+// matches returns whether the transaction matches the query, except for the participant (which requires decrypting the PAL).
+func (q TransactionQuery) matches(transaction dag.Transaction) bool {
+	if q.ContentType != "" && transaction.PayloadType() != q.ContentType {
+		return false
+	}
+	if q.SignerKeyID != "" && transaction.SigningKeyID() != q.SignerKeyID {
+		return false
+	}
+	if q.DID != nil {
+		keyID, err := did.ParseDIDURL(transaction.SigningKeyID())
+		if err != nil || !keyID.DID.Equals(*q.DID) {
+			return false
+		}
+	}
+	if q.Since != nil && transaction.SigningTime().Before(*q.Since) {
+		return false
+	}
+	if q.Until != nil && !transaction.SigningTime().Before(*q.Until) {
+		return false
+	}
+	if q.Participant != nil && len(transaction.PAL()) == 0 {
+		return false
+	}
+	return true
+}
+
+// endOfClockCursor returns a cursor that points after all transactions with the given Lamport clock value.
+func endOfClockCursor(clock uint32) *TransactionCursor {
+	result := TransactionCursor{Clock: clock}
+	for i := range result.Ref {
+		result.Ref[i] = 0xff
+	}
+	return &result
+}
+
+// TransactionCursor points to a transaction on the DAG. It is used to retrieve the next page of results of FindTransactions.
+type TransactionCursor struct {
+	// Clock is the Lamport clock value of the transaction.
+	Clock uint32
+	// Ref is the reference of the transaction.
+	Ref hash.SHA256Hash
+}
+
+// ParseTransactionCursor parses a cursor in the format produced by TransactionCursor.String.
+func ParseTransactionCursor(input string) (*TransactionCursor, error) {
+	clockStr, refStr, ok := strings.Cut(input, "-")
+	if !ok {
+		return nil, ErrInvalidTransactionCursor
+	}
+	clock, err := strconv.ParseUint(clockStr, 10, 32)
+	if err != nil {
+		return nil, ErrInvalidTransactionCursor
+	}
+	ref, err := hash.ParseHex(refStr)
+	if err != nil {
+		return nil, ErrInvalidTransactionCursor
+	}
+	return &TransactionCursor{Clock: uint32(clock), Ref: ref}, nil
+}
+
+// String returns the cursor as string, which can be parsed using ParseTransactionCursor.
+func (c TransactionCursor) String() string {
+	return fmt.Sprintf("%d-%s", c.Clock, c.Ref)
+}
+
+// precedes returns true if the transaction comes after the cursor, in the order transactions are returned by dag.State.FindBetweenLC.
+func (c TransactionCursor) precedes(transaction dag.Transaction) bool {
+	if transaction.Clock() != c.Clock {
+		return transaction.Clock() > c.Clock
+	}
+	return bytes.Compare(transaction.Ref().Slice(), c.Ref.Slice()) > 0
+}
+
+// FindTransactions returns the transactions that match the query, ordered by Lamport clock value and reference.
+// It starts after the given cursor (or at the root of the DAG if it's nil) and returns at most limit transactions.
+// It scans at most explorerMaxScannedClocks Lamport clock values, so a page may contain fewer than limit transactions
+// even though more transactions match. If the limit is reached or the scan stopped before the end of the DAG,
+// a cursor to retrieve the next page is returned.
+func (n *Network) FindTransactions(ctx context.Context, query TransactionQuery, cursor *TransactionCursor, limit int) ([]dag.Transaction, *TransactionCursor, error) {
+	if n.disabled {
+		return nil, nil, ErrDIDNutsDisabled
+	}
+	var keyAgreementKIDs []string
+	if query.Participant != nil {
+		var err error
+		if keyAgreementKIDs, err = n.nodeKeyAgreementKIDs(); err != nil {
+			return nil, nil, err
+		}
+	}
+	start := uint32(0)
+	if cursor != nil {
+		start = cursor.Clock
+	}
+	_, highestClock := n.state.XOR(dag.MaxLamportClock)
+	scanEnd := min(uint64(start)+explorerMaxScannedClocks, uint64(highestClock)+1)
+	result := make([]dag.Transaction, 0)
+	for page := uint64(start); page < scanEnd; page += explorerPageSize {
+		end := min(page+explorerPageSize, scanEnd)
+		transactions, err := n.state.FindBetweenLC(ctx, uint32(page), uint32(end))
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, transaction := range transactions {
+			if cursor != nil && !cursor.precedes(transaction) {
+				continue
+			}
+			if !query.matches(transaction) {
+				continue
+			}
+			if query.Participant != nil {
+				// This node only has the payload of a private transaction if it could decrypt its PAL,
+				// so the PAL of transactions without payload isn't decrypted.
+				present, err := n.state.IsPayloadPresent(ctx, transaction.PayloadHash())
+				if err != nil {
+					return nil, nil, err
+				}
+				if !present || !n.matchesParticipant(ctx, *query.Participant, keyAgreementKIDs, transaction) {
+					continue
+				}
+			}
+			result = append(result, transaction)
+			if len(result) == limit {
+				return result, &TransactionCursor{Clock: transaction.Clock(), Ref: transaction.Ref()}, nil
+			}
+		}
+	}
+	if scanEnd <= uint64(highestClock) {
+		return result, endOfClockCursor(uint32(scanEnd - 1)), nil
+	}
+	return result, nil, nil
+}
+
+// StreamTransactions returns a channel that receives the transactions that are added to the DAG and match the query.
+// The channel is closed when the context is done or the Network shuts down.
+func (n *Network) StreamTransactions(ctx context.Context, query TransactionQuery) (<-chan dag.Transaction, error) {
+	if n.disabled {
+		return nil, ErrDIDNutsDisabled
+	}
+	var keyAgreementKIDs []string
+	if query.Participant != nil {
+		var err error
+		if keyAgreementKIDs, err = n.nodeKeyAgreementKIDs(); err != nil {
+			return nil, err
+		}
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	if n.ctx != nil {
+		context.AfterFunc(n.ctx, cancel)
+	}
+	received := n.transactionStreamer.subscribe(ctx)
+	result := make(chan dag.Transaction)
+	go func() {
+		defer cancel()
+		defer close(result)
+		for transaction := range received {
+			if !query.matches(transaction) {
+				continue
+			}
+			if query.Participant != nil {
+				// Like FindTransactions, the PAL of transactions without payload isn't decrypted.
+				present, err := n.state.IsPayloadPresent(ctx, transaction.PayloadHash())
+				if err != nil {
+					log.Logger().
+						WithError(err).
+						WithField(core.LogFieldTransactionRef, transaction.Ref()).
+						Error("Unable to check whether transaction payload is present while streaming transactions")
+					continue
+				}
+				if !present || !n.matchesParticipant(ctx, *query.Participant, keyAgreementKIDs, transaction) {
+					continue
+				}
+			}
+			select {
+			case result <- transaction:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return result, nil
+}
+
+// matchesParticipant returns whether the given DID is a participant of the (private) transaction.
+// To do so, the PAL of the transaction is decrypted using the given keyAgreement keys of the node.
+func (n *Network) matchesParticipant(ctx context.Context, participant did.DID, keyAgreementKIDs []string, transaction dag.Transaction) bool {
+	pal, err := dag.EncryptedPAL(transaction.PAL()).Decrypt(ctx, keyAgreementKIDs, n.keyStore)
+	if err != nil {
+		log.Logger().
+			WithError(err).
+			WithField(core.LogFieldTransactionRef, transaction.Ref()).
+			Debug("Unable to decrypt PAL while searching transactions")
+		return false
+	}
+	return pal.Contains(participant)
+}
+
+// nodeKeyAgreementKIDs returns the IDs of the keyAgreement keys of the node DID, which are used to decrypt PALs.
+// If no node DID is configured, it returns no keys, so that no PAL can be decrypted.
+func (n *Network) nodeKeyAgreementKIDs() ([]string, error) {
+	if n.nodeDID.Empty() {
+		return nil, nil
+	}
+	document, _, err := n.didStore.Resolve(n.nodeDID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve node DID: %w", err)
+	}
+	result := make([]string, len(document.KeyAgreement))
+	for i, keyAgreement := range document.KeyAgreement {
+		result[i] = keyAgreement.ID.String()
+	}
+	return result, nil
+}
+
+// transactionStreamer distributes the transactions that are added to the DAG to the subscribers of StreamTransactions.
+type transactionStreamer struct {
+	mux         sync.Mutex
+	subscribers map[chan dag.Transaction]struct{}
+}
+
+func newTransactionStreamer() *transactionStreamer {
+	return &transactionStreamer{
+		subscribers: make(map[chan dag.Transaction]struct{}),
+	}
+}
+
+// subscribe returns a channel that receives new transactions. The channel is closed when the context is done.
+func (s *transactionStreamer) subscribe(ctx context.Context) <-chan dag.Transaction {
+	subscriber := make(chan dag.Transaction, transactionStreamBufferSize)
+	s.mux.Lock()
+	s.subscribers[subscriber] = struct{}{}
+	s.mux.Unlock()
+	go func() {
+		<-ctx.Done()
+		s.mux.Lock()
+		defer s.mux.Unlock()
+		delete(s.subscribers, subscriber)
+		close(subscriber)
+	}()
+	return subscriber
+}
+
+// receive is the dag.ReceiverFn that is subscribed to the DAG. It never blocks: if a subscriber's buffer is full, the transaction is dropped for that subscriber.
+func (s *transactionStreamer) receive(event dag.Event) (bool, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	for subscriber := range s.subscribers {
+		select {
+		case subscriber <- event.Transaction:
+		default:
+			log.Logger().
+				WithField(core.LogFieldTransactionRef, event.Hash).
+				Warn("Transaction stream subscriber doesn't keep up, dropped transaction")
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,336 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package network
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/crypto/hash"
+	"github.com/nuts-foundation/nuts-node/network/dag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTransactionQuery_matches(t *testing.T) {
+	signingTime := time.Now()
+	transaction := createExplorerTestTransaction(t, "did:nuts:123#key-1", signingTime, nil)
+	t.Run("empty query", func(t *testing.T) {
+		assert.True(t, TransactionQuery{}.matches(transaction))
+	})
+	t.Run("content type", func(t *testing.T) {
+		assert.True(t, TransactionQuery{ContentType: "application/did+json"}.matches(transaction))
+		assert.False(t, TransactionQuery{ContentType: "application/vc+json"}.matches(transaction))
+	})
+	t.Run("signer", func(t *testing.T) {
+		assert.True(t, TransactionQuery{SignerKeyID: "did:nuts:123#key-1"}.matches(transaction))
+		assert.False(t, TransactionQuery{SignerKeyID: "did:nuts:123#key-2"}.matches(transaction))
+	})
+	t.Run("DID", func(t *testing.T) {
+		matching := did.MustParseDID("did:nuts:123")
+		other := did.MustParseDID("did:nuts:456")
+		assert.True(t, TransactionQuery{DID: &matching}.matches(transaction))
+		assert.False(t, TransactionQuery{DID: &other}.matches(transaction))
+	})
+	t.Run("signing time", func(t *testing.T) {
+		before := signingTime.Add(-time.Minute)
+		after := signingTime.Add(time.Minute)
+		assert.True(t, TransactionQuery{Since: &before, Until: &after}.matches(transaction))
+		assert.False(t, TransactionQuery{Since: &after}.matches(transaction))
+		assert.False(t, TransactionQuery{Until: &before}.matches(transaction))
+	})
+	t.Run("participant - public transaction", func(t *testing.T) {
+		participant := did.MustParseDID("did:nuts:123")
+		assert.False(t, TransactionQuery{Participant: &participant}.matches(transaction))
+	})
+}
+
+func TestParseTransactionCursor(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		expected := TransactionCursor{Clock: 5, Ref: hash.SHA256Sum([]byte("5"))}
+
+		actual, err := ParseTransactionCursor(expected.String())
+
+		require.NoError(t, err)
+		assert.Equal(t, expected, *actual)
+	})
+	t.Run("error", func(t *testing.T) {
+		for _, input := range []string{"", "5", "a-" + hash.EmptyHash().String(), "5-invalid"} {
+			_, err := ParseTransactionCursor(input)
+			assert.ErrorIs(t, err, ErrInvalidTransactionCursor, input)
+		}
+	})
+}
+
+func TestNetwork_FindTransactions(t *testing.T) {
+	tx1, _, _ := dag.CreateTestTransaction(1)
+	tx2 := createExplorerTestTransaction(t, "did:nuts:123#key-1", time.Now(), nil, tx1)
+	tx3, _, _ := dag.CreateTestTransaction(3, tx2)
+
+	t.Run("ok - all", func(t *testing.T) {
+		cxt := createNetwork(t, gomock.NewController(t))
+		cxt.state.EXPECT().XOR(uint32(dag.MaxLamportClock)).Return(hash.EmptyHash(), uint32(2))
+		cxt.state.EXPECT().FindBetweenLC(gomock.Any(), uint32(0), uint32(3)).Return([]dag.Transaction{tx1, tx2, tx3}, nil)
+
+		transactions, next, err := cxt.network.FindTransactions(context.Background(), TransactionQuery{}, nil, 10)
+
+		require.NoError(t, err)
+		assert.Equal(t, []dag.Transaction{tx1, tx2, tx3}, transactions)
+		assert.Nil(t, next)
+	})
+	t.Run("ok - filtered", func(t *testing.T) {
+		cxt := createNetwork(t, gomock.NewController(t))
+		cxt.state.EXPECT().XOR(uint32(dag.MaxLamportClock)).Return(hash.EmptyHash(), uint32(2))
+		cxt.state.EXPECT().FindBetweenLC(gomock.Any(), uint32(0), uint32(3)).Return([]dag.Transaction{tx1, tx2, tx3}, nil)
+		id := did.MustParseDID("did:nuts:123")
+
+		transactions, _, err := cxt.network.FindTransactions(context.Background(), TransactionQuery{DID: &id}, nil, 10)
+
+		require.NoError(t, err)
+		assert.Equal(t, []dag.Transaction{tx2}, transactions)
+	})
+	t.Run("ok - paginated", func(t *testing.T) {
+		cxt := createNetwork(t, gomock.NewController(t))
+		cxt.state.EXPECT().XOR(uint32(dag.MaxLamportClock)).Return(hash.EmptyHash(), uint32(2)).Times(2)
+		cxt.state.EXPECT().FindBetweenLC(gomock.Any(), uint32(0), uint32(3)).Return([]dag.Transaction{tx1, tx2, tx3}, nil)
+
+		transactions, next, err := cxt.network.FindTransactions(context.Background(), TransactionQuery{}, nil, 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, []dag.Transaction{tx1, tx2}, transactions)
+		require.NotNil(t, next)
+		assert.Equal(t, TransactionCursor{Clock: tx2.Clock(), Ref: tx2.Ref()}, *next)
+
+		// next page starts at the clock value of the cursor
+		cxt.state.EXPECT().FindBetweenLC(gomock.Any(), uint32(1), uint32(3)).Return([]dag.Transaction{tx2, tx3}, nil)
+
+		transactions, next, err = cxt.network.FindTransactions(context.Background(), TransactionQuery{}, next, 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, []dag.Transaction{tx3}, transactions)
+		assert.Nil(t, next)
+	})
+	t.Run("ok - participant without node DID", func(t *testing.T) {
+		cxt := createNetwork(t, gomock.NewController(t))
+		privateTX, _, _ := dag.CreateTestTransactionEx(4, hash.SHA256Sum([]byte("4")), dag.EncryptedPAL{{1, 2, 3}})
+		cxt.state.EXPECT().XOR(uint32(dag.MaxLamportClock)).Return(hash.EmptyHash(), uint32(0))
+		cxt.state.EXPECT().FindBetweenLC(gomock.Any(), uint32(0), uint32(1)).Return([]dag.Transaction{privateTX}, nil)
+		cxt.state.EXPECT().IsPayloadPresent(gomock.Any(), privateTX.PayloadHash()).Return(true, nil)
+		participant := did.MustParseDID("did:nuts:123")
+
+		transactions, _, err := cxt.network.FindTransactions(context.Background(), TransactionQuery{Participant: &participant}, nil, 10)
+
+		require.NoError(t, err)
+		assert.Empty(t, transactions)
+	})
+	t.Run("ok - participant", func(t *testing.T) {
+		cxt := createNetwork(t, gomock.NewController(t))
+		participant := did.MustParseDID("did:nuts:123")
+		encryptedPAL := encryptExplorerTestPAL(t, cxt, participant)
+		withPayload, _, _ := dag.CreateTestTransactionEx(4, hash.SHA256Sum([]byte("4")), dag.EncryptedPAL{encryptedPAL})
+		withoutPayload, _, _ := dag.CreateTestTransactionEx(5, hash.SHA256Sum([]byte("5")), dag.EncryptedPAL{encryptedPAL})
+		cxt.state.EXPECT().XOR(uint32(dag.MaxLamportClock)).Return(hash.EmptyHash(), uint32(0))
+		cxt.state.EXPECT().FindBetweenLC(gomock.Any(), uint32(0), uint32(1)).Return([]dag.Transaction{withPayload, withoutPayload}, nil)
+		cxt.state.EXPECT().IsPayloadPresent(gomock.Any(), withPayload.PayloadHash()).Return(true, nil)
+		// the PAL of a transaction without payload isn't decrypted, since this node isn't a participant
+		cxt.state.EXPECT().IsPayloadPresent(gomock.Any(), withoutPayload.PayloadHash()).Return(false, nil)
+
+		transactions, _, err := cxt.network.FindTransactions(context.Background(), TransactionQuery{Participant: &participant}, nil, 10)
+
+		require.NoError(t, err)
+		assert.Equal(t, []dag.Transaction{withPayload}, transactions)
+	})
+	t.Run("ok - number of scanned clock values is bounded", func(t *testing.T) {
+		cxt := createNetwork(t, gomock.NewController(t))
+		cxt.state.EXPECT().XOR(uint32(dag.MaxLamportClock)).Return(hash.EmptyHash(), uint32(3*explorerMaxScannedClocks)).Times(2)
+		cxt.state.EXPECT().FindBetweenLC(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(explorerMaxScannedClocks / explorerPageSize)
+
+		transactions, next, err := cxt.network.FindTransactions(context.Background(), TransactionQuery{}, nil, 10)
+
+		require.NoError(t, err)
+		assert.Empty(t, transactions)
+		require.NotNil(t, next)
+		assert.Equal(t, uint32(explorerMaxScannedClocks-1), next.Clock)
+
+		// next page continues after the last scanned clock value
+		lastScanned := createExplorerTestTransactionWithClock(t, "did:nuts:123#key-1", nil, explorerMaxScannedClocks-1)
+		notScanned := createExplorerTestTransactionWithClock(t, "did:nuts:123#key-1", nil, explorerMaxScannedClocks)
+		cxt.state.EXPECT().FindBetweenLC(gomock.Any(), uint32(explorerMaxScannedClocks-1), uint32(explorerMaxScannedClocks-1+explorerPageSize)).
+			Return([]dag.Transaction{lastScanned, notScanned}, nil)
+		cxt.state.EXPECT().FindBetweenLC(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+		transactions, _, err = cxt.network.FindTransactions(context.Background(), TransactionQuery{}, next, 10)
+
+		require.NoError(t, err)
+		assert.Equal(t, []dag.Transaction{notScanned}, transactions)
+	})
+	t.Run("error - participant, unable to resolve node DID", func(t *testing.T) {
+		cxt := createNetwork(t, gomock.NewController(t), func(config *Config) {
+			config.NodeDID = nodeDID.String()
+		})
+		cxt.didStore.EXPECT().Resolve(*nodeDID, nil).Return(nil, nil, assert.AnError)
+		participant := did.MustParseDID("did:nuts:123")
+
+		_, _, err := cxt.network.FindTransactions(context.Background(), TransactionQuery{Participant: &participant}, nil, 10)
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("error - disabled", func(t *testing.T) {
+		cxt := createNetwork(t, gomock.NewController(t))
+		cxt.network.disabled = true
+
+		_, _, err := cxt.network.FindTransactions(context.Background(), TransactionQuery{}, nil, 10)
+
+		assert.ErrorIs(t, err, ErrDIDNutsDisabled)
+	})
+}
+
+func TestNetwork_StreamTransactions(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		cxt := createNetwork(t, gomock.NewController(t))
+		cxt.network.transactionStreamer = newTransactionStreamer()
+		ctx, cancel := context.WithCancel(context.Background())
+		matching := createExplorerTestTransaction(t, "did:nuts:123#key-1", time.Now(), nil)
+		other, _, _ := dag.CreateTestTransaction(1)
+		id := did.MustParseDID("did:nuts:123")
+
+		transactions, err := cxt.network.StreamTransactions(ctx, TransactionQuery{DID: &id})
+		require.NoError(t, err)
+		_, _ = cxt.network.transactionStreamer.receive(dag.Event{Transaction: other, Hash: other.Ref()})
+		_, _ = cxt.network.transactionStreamer.receive(dag.Event{Transaction: matching, Hash: matching.Ref()})
+
+		assert.Equal(t, matching, <-transactions)
+		cancel()
+		_, ok := <-transactions
+		assert.False(t, ok, "channel should be closed")
+	})
+	t.Run("ok - participant", func(t *testing.T) {
+		cxt := createNetwork(t, gomock.NewController(t))
+		cxt.network.transactionStreamer = newTransactionStreamer()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		participant := did.MustParseDID("did:nuts:123")
+		encryptedPAL := encryptExplorerTestPAL(t, cxt, participant)
+		withPayload, _, _ := dag.CreateTestTransactionEx(4, hash.SHA256Sum([]byte("4")), dag.EncryptedPAL{encryptedPAL})
+		withoutPayload, _, _ := dag.CreateTestTransactionEx(5, hash.SHA256Sum([]byte("5")), dag.EncryptedPAL{encryptedPAL})
+		// the PAL of a transaction without payload isn't decrypted, since this node isn't a participant
+		cxt.state.EXPECT().IsPayloadPresent(gomock.Any(), withoutPayload.PayloadHash()).Return(false, nil)
+		cxt.state.EXPECT().IsPayloadPresent(gomock.Any(), withPayload.PayloadHash()).Return(true, nil)
+
+		transactions, err := cxt.network.StreamTransactions(ctx, TransactionQuery{Participant: &participant})
+		require.NoError(t, err)
+		_, _ = cxt.network.transactionStreamer.receive(dag.Event{Transaction: withoutPayload, Hash: withoutPayload.Ref()})
+		_, _ = cxt.network.transactionStreamer.receive(dag.Event{Transaction: withPayload, Hash: withPayload.Ref()})
+
+		assert.Equal(t, withPayload, <-transactions)
+	})
+	t.Run("error - disabled", func(t *testing.T) {
+		cxt := createNetwork(t, gomock.NewController(t))
+		cxt.network.disabled = true
+
+		_, err := cxt.network.StreamTransactions(context.Background(), TransactionQuery{})
+
+		assert.ErrorIs(t, err, ErrDIDNutsDisabled)
+	})
+}
+
+func TestTransactionStreamer_receive(t *testing.T) {
+	t.Run("drops transactions if subscriber doesn't keep up", func(t *testing.T) {
+		streamer := newTransactionStreamer()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		subscriber := streamer.subscribe(ctx)
+		transaction, _, _ := dag.CreateTestTransaction(1)
+
+		for i := 0; i < transactionStreamBufferSize+1; i++ {
+			_, err := streamer.receive(dag.Event{Transaction: transaction})
+			require.NoError(t, err)
+		}
+
+		assert.Len(t, subscriber, transactionStreamBufferSize)
+	})
+	t.Run("unsubscribes when context is done", func(t *testing.T) {
+		streamer := newTransactionStreamer()
+		ctx, cancel := context.WithCancel(context.Background())
+		subscriber := streamer.subscribe(ctx)
+
+		cancel()
+
+		_, ok := <-subscriber
+		assert.False(t, ok, "channel should be closed")
+		streamer.mux.Lock()
+		defer streamer.mux.Unlock()
+		assert.Empty(t, streamer.subscribers)
+	})
+}
+
+// createExplorerTestTransaction creates a transaction signed with the given key ID, which (unlike dag.CreateTestTransaction) may be a DID URL.
+// encryptExplorerTestPAL registers a keyAgreement key for the node DID and returns a PAL containing the participant, encrypted with that key.
+func encryptExplorerTestPAL(t *testing.T, cxt *networkTestContext, participant did.DID) []byte {
+	cxt.network.nodeDID = *nodeDID
+	keyID := did.MustParseDIDURL(nodeDID.String() + "#key-agreement")
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	require.NoError(t, cxt.keyStorage.SavePrivateKey(context.Background(), keyID.String(), key))
+	require.NoError(t, cxt.keyStore.Link(context.Background(), keyID.String(), keyID.String(), "1"))
+	verificationMethod, err := did.NewVerificationMethod(keyID, ssi.JsonWebKey2020, *nodeDID, key.Public())
+	require.NoError(t, err)
+	document := &did.Document{ID: *nodeDID}
+	document.AddKeyAgreement(verificationMethod)
+	cxt.didStore.EXPECT().Resolve(*nodeDID, nil).Return(document, nil, nil)
+	encryptedPAL, err := crypto.EciesEncrypt(&key.PublicKey, []byte(participant.String()))
+	require.NoError(t, err)
+	return encryptedPAL
+}
+
+func createExplorerTestTransaction(t *testing.T, kid string, signingTime time.Time, pal dag.EncryptedPAL, prevs ...dag.Transaction) dag.Transaction {
+	var prevRefs []hash.SHA256Hash
+	var clock uint32
+	for _, prev := range prevs {
+		prevRefs = append(prevRefs, prev.Ref())
+		clock = max(clock, prev.Clock()+1)
+	}
+	return signExplorerTestTransaction(t, kid, signingTime, pal, prevRefs, clock)
+}
+
+// createExplorerTestTransactionWithClock creates a transaction with the given Lamport clock value (without prevs).
+func createExplorerTestTransactionWithClock(t *testing.T, kid string, pal dag.EncryptedPAL, clock uint32) dag.Transaction {
+	return signExplorerTestTransaction(t, kid, time.Now(), pal, nil, clock)
+}
+
+func signExplorerTestTransaction(t *testing.T, kid string, signingTime time.Time, pal dag.EncryptedPAL, prevRefs []hash.SHA256Hash, clock uint32) dag.Transaction {
+	unsigned, err := dag.NewTransaction(hash.SHA256Sum([]byte(kid)), "application/did+json", prevRefs, pal, clock)
+	require.NoError(t, err)
+	key, err := crypto.GenerateJWK()
+	require.NoError(t, err)
+	require.NoError(t, key.Set(jwk.KeyIDKey, kid))
+	transaction, err := dag.NewTransactionSigner(crypto.MemoryJWTSigner{Key: key}, kid, nil).Sign(audit.TestContext(), unsigned, signingTime)
+	require.NoError(t, err)
+	return transaction
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/crypto/hash"
 	"github.com/nuts-foundation/nuts-node/network/dag"
 	"github.com/nuts-foundation/nuts-node/network/transport"
+	"io"
 )
 
 // MaxReprocessBufferSize is the maximum number of events for Nats resulting from a Reprocess
@@ -48,10 +49,22 @@ type Transactions interface {
 	// ListTransactionsInRange returns all transactions known to this Network instance with lamport clock value between startInclusive and endExclusive.
 	// endExclusive must be larger than startInclusive.
 	ListTransactionsInRange(startInclusive uint32, endExclusive uint32) ([]dag.Transaction, error)
+	// FindTransactions returns the transactions that match the query, ordered by Lamport clock value and reference.
+	// It starts after the given cursor (or at the root of the DAG if it's nil) and returns at most limit transactions.
+	// The number of Lamport clock values scanned is bounded, so a page may contain fewer than limit transactions.
+	// If the limit is reached or the scan stopped before the end of the DAG, a cursor to retrieve the next page is returned.
+	FindTransactions(ctx context.Context, query TransactionQuery, cursor *TransactionCursor, limit int) ([]dag.Transaction, *TransactionCursor, error)
+	// StreamTransactions returns a channel that receives the transactions that are added to the DAG and match the query.
+	// The channel is closed when the context is done.
+	StreamTransactions(ctx context.Context, query TransactionQuery) (<-chan dag.Transaction, error)
 	// PeerDiagnostics returns a map containing diagnostic information of the node's peers. The key contains the remote peer's ID.
 	PeerDiagnostics() map[transport.PeerID]transport.Diagnostics
 	// Reprocess walks the DAG and publishes all transactions matching the contentType via Nats
 	Reprocess(ctx context.Context, contentType string) (*ReprocessReport, error)
+	// ExportSnapshot writes a snapshot archive of the DAG up to and including the given Lamport clock value to the writer.
+	ExportSnapshot(ctx context.Context, writer io.Writer, clock uint32) (*dag.Snapshot, error)
+	// ImportSnapshot reads a snapshot archive and adds its transactions to the DAG, verifying them as if they were received from peers.
+	ImportSnapshot(ctx context.Context, reader io.Reader) (*dag.SnapshotImportResult, error)
 	// WithPersistency returns a SubscriberOption for persistency. It allows the DAG KVStore to be used as persistent store for notifications.
 	// The notifications will then have ACID properties
 	WithPersistency() SubscriberOption
@@ -59,6 +72,12 @@ type Transactions interface {
 	DiscoverServices(updatedDID did.DID)
 	// AddressBook returns the list of contacts in the address book.
 	AddressBook() []transport.Contact
+	// PeerRules returns the rules that allow or deny connections with peers.
+	PeerRules() []transport.PeerRule
+	// AddPeerRule validates, persists and applies a new peer rule. It returns the rule with its generated ID.
+	AddPeerRule(rule transport.PeerRule) (transport.PeerRule, error)
+	// RemovePeerRule removes the peer rule with the given ID. It returns ErrPeerRuleNotFound if it doesn't exist.
+	RemovePeerRule(id string) error
 	// Disabled returns true if core.ServerConfig.DIDMethods does not contain 'nuts'
 	Disabled() bool
 }
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: network/interface.go
+// Source: interface.go
 //
 // Generated by this command:
 //
-//	mockgen -destination=network/mock.go -package=network -source=network/interface.go
+//	mockgen -destination=mock.go -package=network -source=interface.go
 //
 
 // Package network is a generated GoMock package.
@@ -11,6 +11,7 @@ package network
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 
 	did "github.com/nuts-foundation/go-did/did"
@@ -44,6 +45,21 @@ func (m *MockTransactions) EXPECT() *MockTransactionsMockRecorder {
 	return m.recorder
 }
 
+// AddPeerRule mocks base method.
+func (m *MockTransactions) AddPeerRule(rule transport.PeerRule) (transport.PeerRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPeerRule", rule)
+	ret0, _ := ret[0].(transport.PeerRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPeerRule indicates an expected call of AddPeerRule.
+func (mr *MockTransactionsMockRecorder) AddPeerRule(rule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPeerRule", reflect.TypeOf((*MockTransactions)(nil).AddPeerRule), rule)
+}
+
 // AddressBook mocks base method.
 func (m *MockTransactions) AddressBook() []transport.Contact {
 	m.ctrl.T.Helper()
@@ -99,6 +115,37 @@ func (mr *MockTransactionsMockRecorder) DiscoverServices(updatedDID any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverServices", reflect.TypeOf((*MockTransactions)(nil).DiscoverServices), updatedDID)
 }
 
+// ExportSnapshot mocks base method.
+func (m *MockTransactions) ExportSnapshot(ctx context.Context, writer io.Writer, clock uint32) (*dag.Snapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportSnapshot", ctx, writer, clock)
+	ret0, _ := ret[0].(*dag.Snapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportSnapshot indicates an expected call of ExportSnapshot.
+func (mr *MockTransactionsMockRecorder) ExportSnapshot(ctx, writer, clock any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportSnapshot", reflect.TypeOf((*MockTransactions)(nil).ExportSnapshot), ctx, writer, clock)
+}
+
+// FindTransactions mocks base method.
+func (m *MockTransactions) FindTransactions(ctx context.Context, query TransactionQuery, cursor *TransactionCursor, limit int) ([]dag.Transaction, *TransactionCursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTransactions", ctx, query, cursor, limit)
+	ret0, _ := ret[0].([]dag.Transaction)
+	ret1, _ := ret[1].(*TransactionCursor)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindTransactions indicates an expected call of FindTransactions.
+func (mr *MockTransactionsMockRecorder) FindTransactions(ctx, query, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTransactions", reflect.TypeOf((*MockTransactions)(nil).FindTransactions), ctx, query, cursor, limit)
+}
+
 // GetTransaction mocks base method.
 func (m *MockTransactions) GetTransaction(transactionRef hash.SHA256Hash) (dag.Transaction, error) {
 	m.ctrl.T.Helper()
@@ -129,6 +176,21 @@ func (mr *MockTransactionsMockRecorder) GetTransactionPayload(transactionRef any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionPayload", reflect.TypeOf((*MockTransactions)(nil).GetTransactionPayload), transactionRef)
 }
 
+// ImportSnapshot mocks base method.
+func (m *MockTransactions) ImportSnapshot(ctx context.Context, reader io.Reader) (*dag.SnapshotImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportSnapshot", ctx, reader)
+	ret0, _ := ret[0].(*dag.SnapshotImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportSnapshot indicates an expected call of ImportSnapshot.
+func (mr *MockTransactionsMockRecorder) ImportSnapshot(ctx, reader any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportSnapshot", reflect.TypeOf((*MockTransactions)(nil).ImportSnapshot), ctx, reader)
+}
+
 // ListTransactionsInRange mocks base method.
 func (m *MockTransactions) ListTransactionsInRange(startInclusive, endExclusive uint32) ([]dag.Transaction, error) {
 	m.ctrl.T.Helper()
@@ -158,6 +220,34 @@ func (mr *MockTransactionsMockRecorder) PeerDiagnostics() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerDiagnostics", reflect.TypeOf((*MockTransactions)(nil).PeerDiagnostics))
 }
 
+// PeerRules mocks base method.
+func (m *MockTransactions) PeerRules() []transport.PeerRule {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PeerRules")
+	ret0, _ := ret[0].([]transport.PeerRule)
+	return ret0
+}
+
+// PeerRules indicates an expected call of PeerRules.
+func (mr *MockTransactionsMockRecorder) PeerRules() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerRules", reflect.TypeOf((*MockTransactions)(nil).PeerRules))
+}
+
+// RemovePeerRule mocks base method.
+func (m *MockTransactions) RemovePeerRule(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePeerRule", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemovePeerRule indicates an expected call of RemovePeerRule.
+func (mr *MockTransactionsMockRecorder) RemovePeerRule(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePeerRule", reflect.TypeOf((*MockTransactions)(nil).RemovePeerRule), id)
+}
+
 // Reprocess mocks base method.
 func (m *MockTransactions) Reprocess(ctx context.Context, contentType string) (*ReprocessReport, error) {
 	m.ctrl.T.Helper()
@@ -173,6 +263,21 @@ func (mr *MockTransactionsMockRecorder) Reprocess(ctx, contentType any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reprocess", reflect.TypeOf((*MockTransactions)(nil).Reprocess), ctx, contentType)
 }
 
+// StreamTransactions mocks base method.
+func (m *MockTransactions) StreamTransactions(ctx context.Context, query TransactionQuery) (<-chan dag.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamTransactions", ctx, query)
+	ret0, _ := ret[0].(<-chan dag.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StreamTransactions indicates an expected call of StreamTransactions.
+func (mr *MockTransactionsMockRecorder) StreamTransactions(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamTransactions", reflect.TypeOf((*MockTransactions)(nil).StreamTransactions), ctx, query)
+}
+
 // Subscribe mocks base method.
 func (m *MockTransactions) Subscribe(name string, receiver dag.ReceiverFn, filters ...SubscriberOption) error {
 	m.ctrl.T.Helper()
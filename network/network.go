@@ -25,14 +25,17 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"slices"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/go-stoabs"
 	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/crypto/hash"
@@ -97,6 +100,14 @@ type Network struct {
 	// assumeNewNode indicates the node hasn't initially sync'd with the network.
 	assumeNewNode  bool
 	selfTestDialer tls.Dialer
+	// ctx is cancelled on shutdown, stopping the routines started by the Network
+	ctx                 context.Context
+	cancel              context.CancelFunc
+	routines            *sync.WaitGroup
+	transactionStreamer *transactionStreamer
+	// peerRulesStore persists the peer rules managed through the API
+	peerRulesStore stoabs.KVStore
+	peerRulesMux   sync.Mutex
 }
 
 // CheckHealth performs health checks for the network engine.
@@ -182,13 +193,21 @@ func (n *Network) Configure(config core.ServerConfig) error {
 		n.disabled = true
 		return nil
 	}
+	if n.config.Archive.Threshold > 0 && n.config.Archive.Interval <= 0 {
+		return errors.New("network.archive.interval must be greater than 0 when archiving is enabled")
+	}
 	var err error
 	dagStore, err := n.storeProvider.GetKVStore("data", storage.PersistentStorageClass)
 	if err != nil {
 		return fmt.Errorf("unable to create database: %w", err)
 	}
+	// The payload archive is always opened, so payloads archived earlier can still be read when archiving has been disabled.
+	archiveStore, err := n.storeProvider.GetKVStore("payloadarchive", storage.PersistentStorageClass)
+	if err != nil {
+		return fmt.Errorf("unable to create payload archive: %w", err)
+	}
 	nutsKeyResolver := dag.SourceTXKeyResolver{Resolver: n.didStore}
-	if n.state, err = dag.NewState(dagStore, dag.NewPrevTransactionsVerifier(), dag.NewTransactionSignatureVerifier(nutsKeyResolver)); err != nil {
+	if n.state, err = dag.NewState(dagStore, archiveStore, dag.NewPrevTransactionsVerifier(), dag.NewTransactionSignatureVerifier(nutsKeyResolver)); err != nil {
 		return fmt.Errorf("failed to configure state: %w", err)
 	}
 	// load state
@@ -265,6 +284,8 @@ func (n *Network) Configure(config core.ServerConfig) error {
 			grpc.WithBackoff(func() grpc.Backoff {
 				return grpc.BoundedBackoff(time.Second, n.config.MaxBackoff)
 			}),
+			grpc.WithRateLimits(n.config.RateLimit),
+			grpc.WithPeerScores(n.config.PeerScore),
 		}
 
 		otherNodes, err := n.findVendorDIDs()
@@ -312,6 +333,15 @@ func (n *Network) Configure(config core.ServerConfig) error {
 		}
 	}
 
+	// apply the peer rules managed through the API
+	n.peerRulesStore, err = n.storeProvider.GetKVStore("peerrules", storage.PersistentStorageClass)
+	if err != nil {
+		return fmt.Errorf("failed to open peer rules store: %w", err)
+	}
+	if err = n.loadPeerRules(); err != nil {
+		return fmt.Errorf("failed to load peer rules: %w", err)
+	}
+
 	// register callback from DAG to other engines, with payload only.
 	if _, err = n.state.Notifier("nats", n.emitEvents,
 		dag.WithPersistency(dagStore),
@@ -321,6 +351,14 @@ func (n *Network) Configure(config core.ServerConfig) error {
 		return err
 	}
 
+	// register callback for streaming new transactions to API clients
+	n.transactionStreamer = newTransactionStreamer()
+	if err = n.Subscribe("explorer", n.transactionStreamer.receive, WithSelectionFilter(func(event dag.Event) bool {
+		return event.Type == dag.TransactionEventType
+	})); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -414,9 +452,42 @@ func (n *Network) Start() error {
 			return fmt.Errorf("failed to start notifiers: %w", err)
 		}
 	}
+
+	n.ctx, n.cancel = context.WithCancel(context.Background())
+	n.routines = new(sync.WaitGroup)
+	if n.config.Archive.Threshold > 0 {
+		n.routines.Add(1)
+		go func() {
+			defer n.routines.Done()
+			n.archivePayloads()
+		}()
+	}
 	return nil
 }
 
+// archivePayloads periodically moves the payloads of old transactions to the payload archive, until the Network is shut down.
+func (n *Network) archivePayloads() {
+	policy := dag.ArchivePolicy{
+		Threshold:    n.config.Archive.Threshold,
+		ContentTypes: n.config.Archive.ContentTypes,
+	}
+	ticker := time.NewTicker(n.config.Archive.Interval)
+	defer ticker.Stop()
+	for {
+		archived, err := n.state.ArchivePayloads(n.ctx, policy)
+		if err != nil {
+			log.Logger().WithError(err).Error("Failed to archive transaction payloads")
+		} else if archived > 0 {
+			log.Logger().Infof("Archived %d transaction payloads", archived)
+		}
+		select {
+		case <-n.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 func (n *Network) connectToKnownNodes(nodeDID did.DID) error {
 	// Start connecting to bootstrap nodes
 	for _, bootstrapNode := range n.config.BootstrapNodes {
@@ -776,6 +847,10 @@ func (n *Network) Shutdown() error {
 	if n.disabled {
 		return nil
 	}
+	if n.cancel != nil {
+		n.cancel()
+		n.routines.Wait()
+	}
 	// Stop protocols and connection manager
 	for _, prot := range n.protocols {
 		prot.Stop()
@@ -828,6 +903,18 @@ func (n *Network) PeerDiagnostics() map[transport.PeerID]transport.Diagnostics {
 			}
 		}
 	}
+	// Add the rate limiting statistics, which also lists peers that are backed off (and thus disconnected)
+	for peerID, throttling := range n.connectionManager.Throttling() {
+		peerDiagnostics := result[peerID]
+		peerDiagnostics.Throttling = &throttling
+		result[peerID] = peerDiagnostics
+	}
+	// Add the penalty scores, which also lists peers that are refused because their score exceeds the threshold
+	for peerID, score := range n.connectionManager.PeerScores() {
+		peerDiagnostics := result[peerID]
+		peerDiagnostics.Score = score
+		result[peerID] = peerDiagnostics
+	}
 	return result
 }
 
@@ -921,6 +1008,49 @@ func (n *Network) Reprocess(ctx context.Context, contentType string) (*Reprocess
 	return new(ReprocessReport), nil
 }
 
+// ExportSnapshot writes a snapshot archive of the DAG up to and including the given Lamport clock value to the writer.
+func (n *Network) ExportSnapshot(ctx context.Context, writer io.Writer, clock uint32) (*dag.Snapshot, error) {
+	if n.disabled {
+		return nil, ErrDIDNutsDisabled
+	}
+	snapshot, err := dag.ExportSnapshot(ctx, n.state, writer, clock, n.certificate)
+	if err != nil {
+		return nil, err
+	}
+	log.Logger().Infof("Exported DAG snapshot (clock=%d, transactions=%d)", snapshot.Clock, snapshot.Transactions)
+	return snapshot, nil
+}
+
+// ImportSnapshot reads a snapshot archive and adds its transactions to the DAG.
+func (n *Network) ImportSnapshot(ctx context.Context, reader io.Reader) (*dag.SnapshotImportResult, error) {
+	if n.disabled {
+		return nil, ErrDIDNutsDisabled
+	}
+	log.Logger().Info("Starting import of DAG snapshot")
+	result, err := dag.ImportSnapshot(ctx, n.state, reader, n.verifySnapshotCertificate)
+	if err != nil {
+		return nil, err
+	}
+	log.Logger().Infof("Imported DAG snapshot (clock=%d, added=%d, present=%d)", result.Clock, result.Added, result.Present)
+	return result, nil
+}
+
+// verifySnapshotCertificate verifies the certificate of the node that signed a DAG snapshot,
+// using the truststore and certificate revocation lists that are used to authenticate peers.
+func (n *Network) verifySnapshotCertificate(chain []*x509.Certificate) error {
+	if n.trustStore == nil {
+		return errors.New("importing a DAG snapshot requires a TLS truststore")
+	}
+	verifiedChains, err := chain[0].Verify(x509.VerifyOptions{
+		Roots:         core.NewCertPool(n.trustStore.RootCAs),
+		Intermediates: core.NewCertPool(n.trustStore.IntermediateCAs),
+	})
+	if err != nil {
+		return err
+	}
+	return n.pkiValidator.CheckCRL(verifiedChains[0])
+}
+
 func (n *Network) collectDiagnosticsForPeers() transport.Diagnostics {
 	stateDiagnostics := n.state.Diagnostics()
 	transactionCount := uint(0)
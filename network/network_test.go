@@ -19,6 +19,7 @@
 package network
 
 import (
+	"bytes"
 	"context"
 	"crypto/ecdsa"
 	"crypto/elliptic"
@@ -201,6 +202,17 @@ func TestNetwork_Configure(t *testing.T) {
 		assert.Empty(t, test.network.nodeDID)
 	})
 
+	t.Run("error - archiving enabled without interval", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := createNetwork(t, ctrl)
+		ctx.network.config.Archive = ArchiveConfig{Threshold: time.Hour}
+
+		err := ctx.network.Configure(core.TestServerConfig(func(config *core.ServerConfig) {
+			config.Datadir = io.TestDirectory(t)
+		}))
+		assert.EqualError(t, err, "network.archive.interval must be greater than 0 when archiving is enabled")
+	})
 	t.Run("error - configured node DID invalid", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 
@@ -356,8 +368,9 @@ func TestNetwork_Configure(t *testing.T) {
 		ctx.network.connectionManager = nil
 		store := stoabs.NewMockKVStore(ctrl)
 		store.EXPECT().Read(gomock.Any(), gomock.Any())
-		prov.EXPECT().GetKVStore(gomock.Any(), gomock.Any()).Return(store, nil)
-		prov.EXPECT().GetKVStore(gomock.Any(), gomock.Any()).Return(nil, errors.New("failed"))
+		prov.EXPECT().GetKVStore("data", gomock.Any()).Return(store, nil)
+		prov.EXPECT().GetKVStore("payloadarchive", gomock.Any()).Return(stoabs.NewMockKVStore(ctrl), nil)
+		prov.EXPECT().GetKVStore("connections", gomock.Any()).Return(nil, errors.New("failed"))
 
 		err := ctx.network.Configure(core.TestServerConfig(func(config *core.ServerConfig) {
 			config.Strictmode = false
@@ -366,6 +379,26 @@ func TestNetwork_Configure(t *testing.T) {
 
 		assert.EqualError(t, err, "failed to open connections store: failed")
 	})
+	t.Run("error - unable to open peer rules store", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := createNetwork(t, ctrl)
+		ctx.protocol.EXPECT().Configure(gomock.Any()).AnyTimes()
+		prov := storage.NewMockProvider(ctrl)
+		ctx.network.storeProvider = prov
+		store := stoabs.NewMockKVStore(ctrl)
+		store.EXPECT().Read(gomock.Any(), gomock.Any())
+		prov.EXPECT().GetKVStore("data", gomock.Any()).Return(store, nil)
+		prov.EXPECT().GetKVStore("payloadarchive", gomock.Any()).Return(stoabs.NewMockKVStore(ctrl), nil)
+		prov.EXPECT().GetKVStore("peerrules", gomock.Any()).Return(nil, errors.New("failed"))
+
+		err := ctx.network.Configure(core.TestServerConfig(func(config *core.ServerConfig) {
+			config.Strictmode = false
+			config.Datadir = io.TestDirectory(t)
+		}))
+
+		assert.EqualError(t, err, "failed to open peer rules store: failed")
+	})
 }
 
 func TestNetwork_PeerDiagnostics(t *testing.T) {
@@ -394,6 +427,15 @@ func TestNetwork_PeerDiagnostics(t *testing.T) {
 		},
 	})
 	cxt.network.protocols = append(cxt.network.protocols, protocol2)
+	backoffUntil := time.Now().Add(time.Minute)
+	cxt.connectionManager.EXPECT().Throttling().Return(map[transport.PeerID]transport.Throttling{
+		"B": {Outbound: 2},
+		"C": {Inbound: 1, BackoffUntil: &backoffUntil},
+	})
+	cxt.connectionManager.EXPECT().PeerScores().Return(map[transport.PeerID]int{
+		"A": 2,
+		"D": 5,
+	})
 
 	err := cxt.start()
 	require.NoError(t, err)
@@ -401,7 +443,15 @@ func TestNetwork_PeerDiagnostics(t *testing.T) {
 	actual := cxt.network.PeerDiagnostics()
 
 	assert.Equal(t, "A", actual["A"].SoftwareID)
+	assert.Nil(t, actual["A"].Throttling)
 	assert.Equal(t, "B", actual["B"].SoftwareID)
+	assert.Equal(t, &transport.Throttling{Outbound: 2}, actual["B"].Throttling)
+	// C is backed off, so it's only listed with its rate limiting statistics
+	assert.Equal(t, &transport.Throttling{Inbound: 1, BackoffUntil: &backoffUntil}, actual["C"].Throttling)
+	assert.Equal(t, 2, actual["A"].Score)
+	assert.Equal(t, 0, actual["B"].Score)
+	// D is refused because of its score, so it's only listed with its score
+	assert.Equal(t, 5, actual["D"].Score)
 }
 
 func TestNetwork_CreateTransaction(t *testing.T) {
@@ -559,6 +609,31 @@ func TestNetwork_Start(t *testing.T) {
 
 		require.NoError(t, err)
 	})
+	t.Run("ok - archives payloads", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		cxt := createNetwork(t, ctrl, func(config *Config) {
+			config.Archive = ArchiveConfig{Threshold: time.Hour, Interval: time.Hour, ContentTypes: []string{"application/did+json"}}
+		})
+		archived := make(chan struct{})
+		cxt.state.EXPECT().ArchivePayloads(gomock.Any(), dag.ArchivePolicy{Threshold: time.Hour, ContentTypes: []string{"application/did+json"}}).
+			DoAndReturn(func(_ context.Context, _ dag.ArchivePolicy) (int, error) {
+				close(archived)
+				return 1, nil
+			})
+		cxt.protocol.EXPECT().Stop()
+		cxt.connectionManager.EXPECT().Stop()
+		cxt.state.EXPECT().Shutdown()
+
+		err := cxt.start()
+
+		require.NoError(t, err)
+		select {
+		case <-archived:
+		case <-time.After(5 * time.Second):
+			t.Fatal("payloads were not archived")
+		}
+		assert.NoError(t, cxt.network.Shutdown())
+	})
 	t.Run("error - cannot verify node DID's key material", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		cxt := createNetwork(t, ctrl)
@@ -979,6 +1054,80 @@ func TestNetwork_Reprocess(t *testing.T) {
 	})
 }
 
+func TestNetwork_Snapshot(t *testing.T) {
+	tx, _, _ := dag.CreateTestTransaction(0)
+
+	chain, keys, err := testPKI.BuildCertChain(nil, "", nil)
+	require.NoError(t, err)
+	certificate := tls.Certificate{Certificate: [][]byte{chain[0].Raw}, PrivateKey: keys[0], Leaf: chain[0]}
+	trustStore := &core.TrustStore{RootCAs: chain[3:], IntermediateCAs: chain[1:3]}
+
+	t.Run("export and import", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		cxt := createNetwork(t, ctrl)
+		cxt.network.certificate = certificate
+		cxt.network.trustStore = trustStore
+		cxt.state.EXPECT().XOR(uint32(dag.MaxLamportClock)).Return(tx.Ref(), uint32(0))
+		cxt.state.EXPECT().FindBetweenLC(gomock.Any(), uint32(0), uint32(1)).Return([]dag.Transaction{tx}, nil)
+		cxt.state.EXPECT().ReadPayload(gomock.Any(), tx.PayloadHash()).Return([]byte{0, 0, 0, 0}, nil)
+		buffer := new(bytes.Buffer)
+
+		snapshot, err := cxt.network.ExportSnapshot(context.Background(), buffer, dag.MaxLamportClock)
+		require.NoError(t, err)
+		assert.Equal(t, 1, snapshot.Transactions)
+
+		cxt.pkiValidator.EXPECT().CheckCRL(chain).Times(4) // header and trailer, verified twice
+		cxt.state.EXPECT().IsPresent(gomock.Any(), tx.Ref()).Return(true, nil)
+		result, err := cxt.network.ImportSnapshot(context.Background(), buffer)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Present)
+		assert.Equal(t, 0, result.Added)
+	})
+	t.Run("import - revoked certificate", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		cxt := createNetwork(t, ctrl)
+		cxt.network.certificate = certificate
+		cxt.network.trustStore = trustStore
+		cxt.state.EXPECT().XOR(uint32(dag.MaxLamportClock)).Return(tx.Ref(), uint32(0))
+		cxt.state.EXPECT().FindBetweenLC(gomock.Any(), uint32(0), uint32(1)).Return([]dag.Transaction{tx}, nil)
+		cxt.state.EXPECT().ReadPayload(gomock.Any(), tx.PayloadHash()).Return([]byte{0, 0, 0, 0}, nil)
+		buffer := new(bytes.Buffer)
+		_, err := cxt.network.ExportSnapshot(context.Background(), buffer, dag.MaxLamportClock)
+		require.NoError(t, err)
+		cxt.pkiValidator.EXPECT().CheckCRL(chain).Return(pki.ErrCertRevoked)
+
+		_, err = cxt.network.ImportSnapshot(context.Background(), buffer)
+
+		assert.ErrorIs(t, err, dag.ErrInvalidSnapshot)
+		assert.ErrorIs(t, err, pki.ErrCertRevoked)
+	})
+	t.Run("import - no truststore", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		cxt := createNetwork(t, ctrl)
+		cxt.network.certificate = certificate
+		cxt.state.EXPECT().XOR(uint32(dag.MaxLamportClock)).Return(tx.Ref(), uint32(0))
+		cxt.state.EXPECT().FindBetweenLC(gomock.Any(), uint32(0), uint32(1)).Return([]dag.Transaction{tx}, nil)
+		cxt.state.EXPECT().ReadPayload(gomock.Any(), tx.PayloadHash()).Return([]byte{0, 0, 0, 0}, nil)
+		buffer := new(bytes.Buffer)
+		_, err := cxt.network.ExportSnapshot(context.Background(), buffer, dag.MaxLamportClock)
+		require.NoError(t, err)
+
+		_, err = cxt.network.ImportSnapshot(context.Background(), buffer)
+
+		assert.ErrorContains(t, err, "importing a DAG snapshot requires a TLS truststore")
+	})
+	t.Run("disabled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		cxt := createNetwork(t, ctrl)
+		cxt.network.disabled = true
+
+		_, err := cxt.network.ExportSnapshot(context.Background(), new(bytes.Buffer), 0)
+		assert.ErrorIs(t, err, ErrDIDNutsDisabled)
+		_, err = cxt.network.ImportSnapshot(context.Background(), new(bytes.Buffer))
+		assert.ErrorIs(t, err, ErrDIDNutsDisabled)
+	})
+}
+
 func TestNetwork_Subscribers(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	cxt := createNetwork(t, ctrl)
@@ -0,0 +1,144 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/nuts-foundation/go-stoabs"
+	"github.com/nuts-foundation/nuts-node/network/log"
+	"github.com/nuts-foundation/nuts-node/network/transport"
+)
+
+// peerRulesShelf is the shelf in the peer rules store that holds the peer rules, keyed by their ID.
+const peerRulesShelf = "peerrules"
+
+// ErrPeerRuleNotFound is returned when the peer rule to remove doesn't exist.
+var ErrPeerRuleNotFound = errors.New("peer rule not found")
+
+func (n *Network) PeerRules() []transport.PeerRule {
+	if n.disabled {
+		return nil
+	}
+	n.peerRulesMux.Lock()
+	defer n.peerRulesMux.Unlock()
+	rules, err := n.readPeerRules(context.Background())
+	if err != nil {
+		log.Logger().WithError(err).Error("Failed to read peer rules")
+		return nil
+	}
+	return rules
+}
+
+func (n *Network) AddPeerRule(rule transport.PeerRule) (transport.PeerRule, error) {
+	if n.disabled {
+		return transport.PeerRule{}, ErrDIDNutsDisabled
+	}
+	n.peerRulesMux.Lock()
+	defer n.peerRulesMux.Unlock()
+	rules, err := n.readPeerRules(context.Background())
+	if err != nil {
+		return transport.PeerRule{}, err
+	}
+	rule.ID = uuid.NewString()
+	// Applying the rules validates the new rule, so it's only persisted if it's valid.
+	if err = n.connectionManager.SetPeerRules(append(rules, rule)); err != nil {
+		return transport.PeerRule{}, err
+	}
+	err = n.peerRulesStore.WriteShelf(context.Background(), peerRulesShelf, func(writer stoabs.Writer) error {
+		data, err := json.Marshal(rule)
+		if err != nil {
+			return err
+		}
+		return writer.Put(stoabs.BytesKey(rule.ID), data)
+	})
+	if err != nil {
+		// restore the rules as they're persisted
+		_ = n.connectionManager.SetPeerRules(rules)
+		return transport.PeerRule{}, fmt.Errorf("unable to persist peer rule: %w", err)
+	}
+	log.Logger().Infof("Added peer rule (id=%s, action=%s)", rule.ID, rule.Action)
+	return rule, nil
+}
+
+func (n *Network) RemovePeerRule(id string) error {
+	if n.disabled {
+		return ErrDIDNutsDisabled
+	}
+	n.peerRulesMux.Lock()
+	defer n.peerRulesMux.Unlock()
+	rules, err := n.readPeerRules(context.Background())
+	if err != nil {
+		return err
+	}
+	remaining := slices.DeleteFunc(slices.Clone(rules), func(rule transport.PeerRule) bool {
+		return rule.ID == id
+	})
+	if len(remaining) == len(rules) {
+		return ErrPeerRuleNotFound
+	}
+	err = n.peerRulesStore.WriteShelf(context.Background(), peerRulesShelf, func(writer stoabs.Writer) error {
+		return writer.Delete(stoabs.BytesKey(id))
+	})
+	if err != nil {
+		return fmt.Errorf("unable to remove peer rule: %w", err)
+	}
+	log.Logger().Infof("Removed peer rule (id=%s)", id)
+	return n.connectionManager.SetPeerRules(remaining)
+}
+
+// loadPeerRules applies the persisted peer rules to the connection manager.
+func (n *Network) loadPeerRules() error {
+	rules, err := n.readPeerRules(context.Background())
+	if err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+	return n.connectionManager.SetPeerRules(rules)
+}
+
+// readPeerRules reads the persisted peer rules, ordered by ID.
+func (n *Network) readPeerRules(ctx context.Context) ([]transport.PeerRule, error) {
+	var result []transport.PeerRule
+	err := n.peerRulesStore.ReadShelf(ctx, peerRulesShelf, func(reader stoabs.Reader) error {
+		return reader.Iterate(func(_ stoabs.Key, data []byte) error {
+			var rule transport.PeerRule
+			if err := json.Unmarshal(data, &rule); err != nil {
+				return err
+			}
+			result = append(result, rule)
+			return nil
+		}, stoabs.BytesKey{})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read peer rules: %w", err)
+	}
+	slices.SortFunc(result, func(a, b transport.PeerRule) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return result, nil
+}
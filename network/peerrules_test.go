@@ -0,0 +1,131 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package network
+
+import (
+	"testing"
+
+	"github.com/nuts-foundation/nuts-node/network/transport"
+	"github.com/nuts-foundation/nuts-node/network/transport/grpc"
+	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func createPeerRulesTestNetwork(t *testing.T) *networkTestContext {
+	cxt := createNetwork(t, gomock.NewController(t))
+	var err error
+	cxt.network.peerRulesStore, err = cxt.network.storeProvider.GetKVStore("peerrules", storage.PersistentStorageClass)
+	require.NoError(t, err)
+	return cxt
+}
+
+func TestNetwork_AddPeerRule(t *testing.T) {
+	rule := transport.PeerRule{Action: transport.DenyPeerRule, CIDR: "10.0.0.0/8"}
+	t.Run("ok", func(t *testing.T) {
+		cxt := createPeerRulesTestNetwork(t)
+		cxt.connectionManager.EXPECT().SetPeerRules(gomock.Len(1))
+
+		actual, err := cxt.network.AddPeerRule(rule)
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, actual.ID)
+		assert.Equal(t, []transport.PeerRule{actual}, cxt.network.PeerRules())
+	})
+	t.Run("ID in request is ignored", func(t *testing.T) {
+		cxt := createPeerRulesTestNetwork(t)
+		cxt.connectionManager.EXPECT().SetPeerRules(gomock.Any())
+		withID := rule
+		withID.ID = "custom"
+
+		actual, err := cxt.network.AddPeerRule(withID)
+
+		require.NoError(t, err)
+		assert.NotEqual(t, "custom", actual.ID)
+	})
+	t.Run("invalid rule is not persisted", func(t *testing.T) {
+		cxt := createPeerRulesTestNetwork(t)
+		cxt.connectionManager.EXPECT().SetPeerRules(gomock.Any()).Return(grpc.ErrInvalidPeerRule)
+
+		_, err := cxt.network.AddPeerRule(transport.PeerRule{Action: "block"})
+
+		assert.ErrorIs(t, err, grpc.ErrInvalidPeerRule)
+		assert.Empty(t, cxt.network.PeerRules())
+	})
+	t.Run("error - disabled", func(t *testing.T) {
+		cxt := createPeerRulesTestNetwork(t)
+		cxt.network.disabled = true
+
+		_, err := cxt.network.AddPeerRule(rule)
+
+		assert.ErrorIs(t, err, ErrDIDNutsDisabled)
+	})
+}
+
+func TestNetwork_RemovePeerRule(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		cxt := createPeerRulesTestNetwork(t)
+		cxt.connectionManager.EXPECT().SetPeerRules(gomock.Any()).Times(2)
+		first, _ := cxt.network.AddPeerRule(transport.PeerRule{Action: transport.DenyPeerRule, CIDR: "10.0.0.0/8"})
+		second, _ := cxt.network.AddPeerRule(transport.PeerRule{Action: transport.DenyPeerRule, CIDR: "192.168.0.0/16"})
+		cxt.connectionManager.EXPECT().SetPeerRules([]transport.PeerRule{second})
+
+		err := cxt.network.RemovePeerRule(first.ID)
+
+		require.NoError(t, err)
+		assert.Equal(t, []transport.PeerRule{second}, cxt.network.PeerRules())
+	})
+	t.Run("error - not found", func(t *testing.T) {
+		cxt := createPeerRulesTestNetwork(t)
+
+		err := cxt.network.RemovePeerRule("unknown")
+
+		assert.ErrorIs(t, err, ErrPeerRuleNotFound)
+	})
+	t.Run("error - disabled", func(t *testing.T) {
+		cxt := createPeerRulesTestNetwork(t)
+		cxt.network.disabled = true
+
+		err := cxt.network.RemovePeerRule("unknown")
+
+		assert.ErrorIs(t, err, ErrDIDNutsDisabled)
+	})
+}
+
+func TestNetwork_loadPeerRules(t *testing.T) {
+	t.Run("applies persisted rules", func(t *testing.T) {
+		cxt := createPeerRulesTestNetwork(t)
+		cxt.connectionManager.EXPECT().SetPeerRules(gomock.Any())
+		rule, err := cxt.network.AddPeerRule(transport.PeerRule{Action: transport.AllowPeerRule, NodeDID: "did:nuts:123"})
+		require.NoError(t, err)
+		cxt.connectionManager.EXPECT().SetPeerRules([]transport.PeerRule{rule})
+
+		err = cxt.network.loadPeerRules()
+
+		assert.NoError(t, err)
+	})
+	t.Run("no rules", func(t *testing.T) {
+		cxt := createPeerRulesTestNetwork(t)
+
+		err := cxt.network.loadPeerRules()
+
+		assert.NoError(t, err)
+	})
+}
@@ -52,6 +52,20 @@ type ConnectionManager interface {
 	// Contacts returns a slice containing the contacts that are currently known (to which we try to connect).
 	Contacts() []Contact
 
+	// Throttling returns the rate limiting statistics of the peers that are connected or backed off.
+	Throttling() map[PeerID]Throttling
+
+	// SetPeerRules replaces the rules that allow or deny connections with peers.
+	// Connected peers that are denied by the new rules are disconnected.
+	SetPeerRules(rules []PeerRule) error
+
+	// PenalizePeer increases the penalty score of the peer, e.g. because it sent an invalid transaction.
+	// If the score exceeds the configured threshold, the peer is disconnected and refused until its score drops below the threshold.
+	PenalizePeer(peerID PeerID, reason string)
+
+	// PeerScores returns the penalty scores of the peers that have been penalized.
+	PeerScores() map[PeerID]int
+
 	// RegisterObserver allows to register a callback function for stream state changes
 	RegisterObserver(callback StreamStateObserverFunc)
 
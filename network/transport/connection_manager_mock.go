@@ -82,6 +82,20 @@ func (mr *MockConnectionManagerMockRecorder) Diagnostics() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Diagnostics", reflect.TypeOf((*MockConnectionManager)(nil).Diagnostics))
 }
 
+// PeerScores mocks base method.
+func (m *MockConnectionManager) PeerScores() map[PeerID]int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PeerScores")
+	ret0, _ := ret[0].(map[PeerID]int)
+	return ret0
+}
+
+// PeerScores indicates an expected call of PeerScores.
+func (mr *MockConnectionManagerMockRecorder) PeerScores() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerScores", reflect.TypeOf((*MockConnectionManager)(nil).PeerScores))
+}
+
 // Peers mocks base method.
 func (m *MockConnectionManager) Peers() []Peer {
 	m.ctrl.T.Helper()
@@ -96,6 +110,18 @@ func (mr *MockConnectionManagerMockRecorder) Peers() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Peers", reflect.TypeOf((*MockConnectionManager)(nil).Peers))
 }
 
+// PenalizePeer mocks base method.
+func (m *MockConnectionManager) PenalizePeer(peerID PeerID, reason string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PenalizePeer", peerID, reason)
+}
+
+// PenalizePeer indicates an expected call of PenalizePeer.
+func (mr *MockConnectionManagerMockRecorder) PenalizePeer(peerID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PenalizePeer", reflect.TypeOf((*MockConnectionManager)(nil).PenalizePeer), peerID, reason)
+}
+
 // RegisterObserver mocks base method.
 func (m *MockConnectionManager) RegisterObserver(callback StreamStateObserverFunc) {
 	m.ctrl.T.Helper()
@@ -108,6 +134,20 @@ func (mr *MockConnectionManagerMockRecorder) RegisterObserver(callback any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterObserver", reflect.TypeOf((*MockConnectionManager)(nil).RegisterObserver), callback)
 }
 
+// SetPeerRules mocks base method.
+func (m *MockConnectionManager) SetPeerRules(rules []PeerRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPeerRules", rules)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPeerRules indicates an expected call of SetPeerRules.
+func (mr *MockConnectionManagerMockRecorder) SetPeerRules(rules any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPeerRules", reflect.TypeOf((*MockConnectionManager)(nil).SetPeerRules), rules)
+}
+
 // Start mocks base method.
 func (m *MockConnectionManager) Start() error {
 	m.ctrl.T.Helper()
@@ -133,3 +173,17 @@ func (mr *MockConnectionManagerMockRecorder) Stop() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockConnectionManager)(nil).Stop))
 }
+
+// Throttling mocks base method.
+func (m *MockConnectionManager) Throttling() map[PeerID]Throttling {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Throttling")
+	ret0, _ := ret[0].(map[PeerID]Throttling)
+	return ret0
+}
+
+// Throttling indicates an expected call of Throttling.
+func (mr *MockConnectionManagerMockRecorder) Throttling() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Throttling", reflect.TypeOf((*MockConnectionManager)(nil).Throttling))
+}
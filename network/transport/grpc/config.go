@@ -109,6 +109,28 @@ func WithBackoff(value func() Backoff) ConfigOption {
 	}
 }
 
+// WithRateLimits specifies the limits on the messages exchanged with peers.
+func WithRateLimits(value RateLimitConfig) ConfigOption {
+	return func(config *Config) error {
+		if value.enabled() && value.Backoff <= 0 {
+			return errors.New("rate limit backoff must be greater than 0 when rate limiting is enabled")
+		}
+		config.rateLimits = value
+		return nil
+	}
+}
+
+// WithPeerScores specifies when peers are disconnected because they misbehave.
+func WithPeerScores(value PeerScoreConfig) ConfigOption {
+	return func(config *Config) error {
+		if value.enabled() && value.Window <= 0 {
+			return errors.New("peer score window must be greater than 0 when peer scoring is enabled")
+		}
+		config.peerScores = value
+		return nil
+	}
+}
+
 // Config holds values for configuring the gRPC ConnectionManager.
 type Config struct {
 	// PeerID contains the ID of the local node.
@@ -136,6 +158,10 @@ type Config struct {
 	dialer dialer
 	// backoffCreator holds a function that creates a Backoff.
 	backoffCreator func() Backoff
+	// rateLimits specifies the limits on the messages exchanged with peers.
+	rateLimits RateLimitConfig
+	// peerScores specifies when peers are disconnected because they misbehave.
+	peerScores PeerScoreConfig
 }
 
 func (cfg Config) tlsEnabled() bool {
@@ -28,6 +28,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"testing"
+	"time"
 )
 
 func TestConfig_tlsEnabled(t *testing.T) {
@@ -59,6 +60,26 @@ func TestNewConfig(t *testing.T) {
 		assert.Equal(t, &tlsCert, cfg.serverCert)
 		assert.Same(t, ts.CertPool, cfg.trustStore)
 	})
+	t.Run("with rate limits", func(t *testing.T) {
+		rateLimits := RateLimitConfig{PeerMessages: 10, Backoff: time.Minute}
+		cfg, err := NewConfig(":1234", "foo", WithRateLimits(rateLimits))
+		require.NoError(t, err)
+		assert.Equal(t, rateLimits, cfg.rateLimits)
+	})
+	t.Run("error - rate limits without backoff", func(t *testing.T) {
+		_, err := NewConfig(":1234", "foo", WithRateLimits(RateLimitConfig{PeerMessages: 10}))
+		assert.EqualError(t, err, "rate limit backoff must be greater than 0 when rate limiting is enabled")
+	})
+	t.Run("with peer scores", func(t *testing.T) {
+		peerScores := PeerScoreConfig{Threshold: 10, Window: time.Hour}
+		cfg, err := NewConfig(":1234", "foo", WithPeerScores(peerScores))
+		require.NoError(t, err)
+		assert.Equal(t, peerScores, cfg.peerScores)
+	})
+	t.Run("error - peer scores without window", func(t *testing.T) {
+		_, err := NewConfig(":1234", "foo", WithPeerScores(PeerScoreConfig{Threshold: 10}))
+		assert.EqualError(t, err, "peer score window must be greater than 0 when peer scoring is enabled")
+	})
 	t.Run("error - invalid TLS config", func(t *testing.T) {
 		ts := &core.TrustStore{
 			CertPool: core.NewCertPool(x509Cert),
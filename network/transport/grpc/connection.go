@@ -291,7 +291,11 @@ func (mc *conn) startSending(protocol Protocol, stream Stream) {
 		}
 		// Connection closed, see if we need to close the gRPC stream
 		unwrappedStream := stream
-		if unwrappable, ok := stream.(interface{ Unwrap() Stream }); ok {
+		for {
+			unwrappable, ok := unwrappedStream.(interface{ Unwrap() Stream })
+			if !ok {
+				break
+			}
 			unwrappedStream = unwrappable.Unwrap()
 		}
 		clientStream, ok := unwrappedStream.(grpc.ClientStream)
@@ -123,6 +123,9 @@ func NewGRPCConnectionManager(config Config, connectionStore stoabs.KVStore, nod
 	}
 	cm.addressBook = newAddressBook(connectionStore, config.backoffCreator)
 	cm.registerPrometheusMetrics()
+	cm.rateLimiter = newRateLimiter(config.rateLimits, cm.throttledCounter)
+	cm.peerRules = &peerRules{}
+	cm.peerScores = newPeerScores(config.peerScores)
 	cm.ctx, cm.ctxCancel = context.WithCancel(context.Background())
 	cm.lastCertificateValidation.Store(&time.Time{})
 	if config.tlsEnabled() {
@@ -145,6 +148,10 @@ type grpcConnectionManager struct {
 	peersCounter        prometheus.Gauge
 	recvMessagesCounter *prometheus.CounterVec
 	sentMessagesCounter *prometheus.CounterVec
+	throttledCounter    *prometheus.CounterVec
+	rateLimiter         *rateLimiter
+	peerRules           *peerRules
+	peerScores          *peerScores
 
 	addressBook *addressBook
 
@@ -199,6 +206,13 @@ func (s *grpcConnectionManager) Start() error {
 		defer s.connectLoopWG.Done()
 		s.connectLoop()
 	}()
+	if s.config.peerScores.enabled() {
+		s.connectLoopWG.Add(1)
+		go func() {
+			defer s.connectLoopWG.Done()
+			s.prunePeerScoresLoop()
+		}()
+	}
 
 	// Start inbound
 	if s.config.listenAddress == "" {
@@ -255,6 +269,7 @@ func (s *grpcConnectionManager) Stop() {
 	prometheus.Unregister(s.peersCounter)
 	prometheus.Unregister(s.sentMessagesCounter)
 	prometheus.Unregister(s.recvMessagesCounter)
+	prometheus.Unregister(s.throttledCounter)
 }
 
 func (s *grpcConnectionManager) connectLoop() {
@@ -288,6 +303,20 @@ outerLoop:
 	connectWG.Wait()
 }
 
+// prunePeerScoresLoop periodically removes the penalties that fall outside the window, until the connection manager is stopped.
+func (s *grpcConnectionManager) prunePeerScoresLoop() {
+	ticker := time.NewTicker(peerScorePruneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.peerScores.pruneAll(time.Now())
+		}
+	}
+}
+
 func (s *grpcConnectionManager) connect(contact *contact) {
 	connection, isNew := s.connections.getOrRegister(s.ctx, contact.peer, true)
 	if !isNew {
@@ -337,6 +366,14 @@ func (s *grpcConnectionManager) connect(contact *contact) {
 			// backoff expires after a day. DID is probably abandoned/replaced, but try again later in case the node was misconfigured.
 			contact.backoff.Reset(time.Hour * 24)
 		}
+		if errors.Is(err, ErrPeerBackedOff) {
+			// peer exceeded its rate limit, don't call it again until its backoff expires
+			contact.backoff.Reset(s.config.rateLimits.Backoff)
+		}
+		if errors.Is(err, ErrPeerScoreExceeded) {
+			// peer misbehaved, don't call it again until (some of) its penalties expire
+			contact.backoff.Reset(s.config.peerScores.Window)
+		}
 		log.Logger().WithError(err).WithFields(connection.Peer().ToFields()).
 			Debug("Error while setting up outbound gRPC streams, disconnecting")
 	} else {
@@ -395,6 +432,70 @@ func (s *grpcConnectionManager) Contacts() []transport.Contact {
 	return s.addressBook.stats()
 }
 
+func (s *grpcConnectionManager) Throttling() map[transport.PeerID]transport.Throttling {
+	return s.rateLimiter.throttling()
+}
+
+func (s *grpcConnectionManager) SetPeerRules(rules []transport.PeerRule) error {
+	if err := s.peerRules.set(rules); err != nil {
+		return err
+	}
+	// For established outbound connections the peer's address is its NutsComm address,
+	// so rules with a CIDR only match if it contains an IP address. They're applied when reconnecting.
+	s.connections.forEach(func(conn Connection) {
+		peer := conn.Peer()
+		if !s.peerRules.isAllowed(peer, ipFromAddress(peer.Address)) {
+			log.Logger().WithFields(peer.ToFields()).Info("Disconnected peer, it is denied by peer rules")
+			conn.disconnect()
+		}
+	})
+	return nil
+}
+
+func (s *grpcConnectionManager) PenalizePeer(peerID transport.PeerID, reason string) {
+	// The penalty applies to the identity of the peer, so it can't be evaded by reconnecting with another peer ID.
+	peer := transport.Peer{ID: peerID}
+	if conn := s.connections.Get(ByPeerID(peerID)); conn != nil {
+		peer = conn.Peer()
+	}
+	identity := identifyPeer(peer, ipFromAddress(peer.Address))
+	if !s.peerScores.penalize(identity, peerID, time.Now()) {
+		log.Logger().
+			WithField(core.LogFieldPeerID, peerID).
+			Debugf("Penalized peer: %s", reason)
+		return
+	}
+	s.connections.forEach(func(conn Connection) {
+		connPeer := conn.Peer()
+		if identifyPeer(connPeer, ipFromAddress(connPeer.Address)) != identity {
+			return
+		}
+		log.Logger().
+			WithFields(connPeer.ToFields()).
+			Warnf("Disconnected peer, its penalty score exceeds the threshold (last penalty: %s)", reason)
+		conn.disconnect()
+	})
+}
+
+func (s *grpcConnectionManager) PeerScores() map[transport.PeerID]int {
+	return s.peerScores.scores(time.Now())
+}
+
+// checkPeerAllowed returns an error if connections with the peer, connected from the given IP address, must be refused,
+// because it's denied by the peer rules, it's backed off or its penalty score exceeds the threshold.
+func (s *grpcConnectionManager) checkPeerAllowed(peer transport.Peer, ip net.IP) error {
+	if !s.peerRules.isAllowed(peer, ip) {
+		return ErrPeerDenied
+	}
+	if s.rateLimiter.isBackedOff(identifyPeer(peer, ip)) {
+		return ErrPeerBackedOff
+	}
+	if s.peerScores.exceeded(identifyPeer(peer, ip), time.Now()) {
+		return ErrPeerScoreExceeded
+	}
+	return nil
+}
+
 func (s *grpcConnectionManager) Diagnostics() []core.DiagnosticResult {
 	return append(
 		[]core.DiagnosticResult{
@@ -515,9 +616,13 @@ func (s *grpcConnectionManager) openOutboundStream(connection Connection, protoc
 			return nil, fatalError{err}
 		}
 	}
+	ip := remoteIP(peerFromCtx)
+	if err = s.checkPeerAllowed(peer, ip); err != nil {
+		return nil, fatalError{err}
+	}
 	connection.setPeer(peer)
 
-	wrappedStream := s.wrapStream(clientStream, protocol)
+	wrappedStream := s.wrapStream(clientStream, protocol, peer, ip)
 	if !connection.registerStream(protocol, wrappedStream) {
 		// This can happen when the peer connected to us previously, and now we connect back to them.
 		log.Logger().
@@ -546,6 +651,14 @@ func (s *grpcConnectionManager) authenticate(nodeDID did.DID, peer transport.Pee
 	return peer, nil
 }
 
+// remoteIP returns the IP address the peer is connected from, or nil if it's unknown.
+func remoteIP(peerFromCtx *grpcPeer.Peer) net.IP {
+	if peerFromCtx == nil || peerFromCtx.Addr == nil {
+		return nil
+	}
+	return ipFromAddress(peerFromCtx.Addr.String())
+}
+
 func extractCertificate(peerFromCtx *grpcPeer.Peer) *x509.Certificate {
 	tlsInfo, isTLS := peerFromCtx.AuthInfo.(credentials.TLSInfo)
 	if !isTLS || len(tlsInfo.State.PeerCertificates) == 0 {
@@ -621,6 +734,14 @@ func (s *grpcConnectionManager) handleInboundStream(protocol Protocol, inboundSt
 	if err != nil {
 		return err
 	}
+	ip := remoteIP(peerFromCtx)
+	if err = s.checkPeerAllowed(peer, ip); err != nil {
+		log.Logger().
+			WithError(err).
+			WithFields(peer.ToFields()).
+			Debug("Refused inbound stream")
+		return err
+	}
 
 	// TODO: Need to authenticate PeerID, to make sure a second stream with a known PeerID is from the same node (maybe even connection).
 	//       Use address from peer context?
@@ -630,7 +751,7 @@ func (s *grpcConnectionManager) handleInboundStream(protocol Protocol, inboundSt
 		s.peersCounter.Inc()
 		defer s.peersCounter.Dec()
 	}
-	wrappedStream := s.wrapStream(inboundStream, protocol)
+	wrappedStream := s.wrapStream(inboundStream, protocol, peer, ip)
 	if !connection.registerStream(protocol, wrappedStream) {
 		return ErrAlreadyConnected
 	}
@@ -640,6 +761,10 @@ func (s *grpcConnectionManager) handleInboundStream(protocol Protocol, inboundSt
 	s.notifyObservers(peer, protocol, transport.StateDisconnected)
 
 	s.connections.remove(connection)
+	if st := connection.closeError(); st != nil && st.Code() == codes.ResourceExhausted {
+		// let the peer know why it's disconnected
+		return st.Err()
+	}
 	return nil
 }
 
@@ -661,13 +786,25 @@ func (s *grpcConnectionManager) constructMetadata(bootstrap bool) (metadata.MD,
 	return md, nil
 }
 
-func (s *grpcConnectionManager) wrapStream(stream Stream, protocol Protocol) prometheusStreamWrapper {
-	return prometheusStreamWrapper{
+func (s *grpcConnectionManager) wrapStream(stream Stream, protocol Protocol, peer transport.Peer, ip net.IP) Stream {
+	var result Stream = prometheusStreamWrapper{
 		stream:              stream,
 		protocol:            protocol,
 		recvMessagesCounter: s.recvMessagesCounter,
 		sentMessagesCounter: s.sentMessagesCounter,
 	}
+	if s.config.rateLimits.enabled() {
+		identity := identifyPeer(peer, ip)
+		result = rateLimitedStreamWrapper{
+			stream:  result,
+			limiter: s.rateLimiter,
+			peer:    s.rateLimiter.register(identity, peer.ID),
+		}
+		context.AfterFunc(stream.Context(), func() {
+			s.rateLimiter.unregister(identity)
+		})
+	}
+	return result
 }
 
 func (s *grpcConnectionManager) registerPrometheusMetrics() {
@@ -692,4 +829,11 @@ func (s *grpcConnectionManager) registerPrometheusMetrics() {
 		Help:      "Number of gRPC messages received per protocol and message type.",
 	}, []string{"protocol", "message_type"})
 	_ = prometheus.Register(s.recvMessagesCounter)
+	s.throttledCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "nuts",
+		Subsystem: "network_grpc",
+		Name:      "messages_throttled",
+		Help:      "Number of gRPC messages that exceeded a rate limit, per direction (inbound, outbound) and scope (peer, global).",
+	}, []string{"direction", "scope"})
+	_ = prometheus.Register(s.throttledCounter)
 }
@@ -943,6 +943,34 @@ func Test_grpcConnectionManager_openOutboundStream(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotNil(t, stream)
 	})
+	t.Run("denied by peer rules", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ctx := peer.NewContext(context.TODO(), grpcPeer)
+
+		cm, err := NewGRPCConnectionManager(Config{peerID: "server-peer-id"}, nil, *nodeDID, nil)
+		require.NoError(t, err)
+		defer cm.Stop()
+		require.NoError(t, cm.SetPeerRules([]transport.PeerRule{{ID: "1", Action: transport.DenyPeerRule, CertificateSubject: cert.Subject.String()}}))
+
+		meta, _ := cm.constructMetadata(false)
+
+		grpcStream := NewMockClientStream(ctrl)
+		grpcStream.EXPECT().Header().Return(meta, nil)
+		grpcStream.EXPECT().Context().Return(ctx)
+
+		grpcConn := NewMockConn(ctrl)
+		grpcConn.EXPECT().NewStream(gomock.Any(), gomock.Any(), "/grpc.Test/DoStuff", gomock.Any()).Return(grpcStream, nil)
+
+		conn := NewMockConnection(ctrl)
+		conn.EXPECT().verifyOrSetPeerID(transport.PeerID("server-peer-id")).Return(true)
+		conn.EXPECT().Peer().Return(transport.Peer{})
+
+		stream, err := cm.openOutboundStream(conn, protocol, grpcConn, metadata.MD{})
+
+		assert.ErrorIs(t, err, ErrPeerDenied)
+		assert.Nil(t, stream)
+	})
 	t.Run("server did not send ID", func(t *testing.T) {
 		serverCfg, serverListener := newBufconnConfig("")
 		server, err := NewGRPCConnectionManager(serverCfg, nil, did.DID{}, nil, &TestProtocol{})
@@ -1177,6 +1205,47 @@ func Test_grpcConnectionManager_handleInboundStream(t *testing.T) {
 		assert.Equal(t, err, ErrNodeDIDAuthFailed)
 		assert.Empty(t, cm.connections.list)
 	})
+	t.Run("backed off client", func(t *testing.T) {
+		cm, err := NewGRPCConnectionManager(Config{peerID: "server-peer-id", rateLimits: RateLimitConfig{PeerMessages: 0.01, Backoff: time.Minute}}, nil, *nodeDID, nil)
+		require.NoError(t, err)
+		defer cm.Stop()
+		// backoff applies to the peer's IP address, not its self-chosen peer ID
+		peer := cm.rateLimiter.register("ip:127.0.0.1", "client-peer-id")
+		_ = cm.rateLimiter.receive(context.Background(), peer, 1)
+		_ = cm.rateLimiter.receive(context.Background(), peer, 1)
+		cm.rateLimiter.unregister("ip:127.0.0.1")
+
+		err = cm.handleInboundStream(protocol, newServerStream("other-peer-id", "", nil))
+
+		assert.ErrorIs(t, err, ErrPeerBackedOff)
+		assert.Empty(t, cm.connections.list)
+		assert.NotNil(t, cm.Throttling()["client-peer-id"].BackoffUntil)
+	})
+	t.Run("denied client", func(t *testing.T) {
+		cm, err := NewGRPCConnectionManager(Config{peerID: "server-peer-id"}, nil, *nodeDID, nil)
+		require.NoError(t, err)
+		defer cm.Stop()
+		require.NoError(t, cm.SetPeerRules([]transport.PeerRule{{ID: "1", Action: transport.DenyPeerRule, CIDR: "127.0.0.0/8"}}))
+
+		err = cm.handleInboundStream(protocol, newServerStream("client-peer-id", "", nil))
+
+		assert.ErrorIs(t, err, ErrPeerDenied)
+		assert.Empty(t, cm.connections.list)
+	})
+	t.Run("client with penalty score exceeding threshold", func(t *testing.T) {
+		cm, err := NewGRPCConnectionManager(Config{peerID: "server-peer-id", peerScores: PeerScoreConfig{Threshold: 1, Window: time.Minute}}, nil, *nodeDID, nil)
+		require.NoError(t, err)
+		defer cm.Stop()
+		// the score applies to the peer's IP address, not its self-chosen peer ID
+		cm.connections.list = append(cm.connections.list, NewStubConnection(transport.Peer{ID: "old-peer-id", Address: "127.0.0.1:1234"}))
+		cm.PenalizePeer("old-peer-id", "invalid transaction")
+		cm.connections.list = nil
+
+		err = cm.handleInboundStream(protocol, newServerStream("client-peer-id", "", nil))
+
+		assert.ErrorIs(t, err, ErrPeerScoreExceeded)
+		assert.Empty(t, cm.connections.list)
+	})
 	t.Run("already connected client", func(t *testing.T) {
 		cm, err := NewGRPCConnectionManager(Config{peerID: "server-peer-id"}, nil, *nodeDID, nil)
 		defer cm.Stop()
@@ -1269,6 +1338,72 @@ func Test_grpcConnectionManager_revalidatePeers(t *testing.T) {
 	})
 }
 
+func Test_grpcConnectionManager_SetPeerRules(t *testing.T) {
+	t.Run("disconnects denied peers", func(t *testing.T) {
+		cm, err := NewGRPCConnectionManager(Config{}, nil, *nodeDID, nil)
+		require.NoError(t, err)
+		denied := NewStubConnection(transport.Peer{ID: "denied", Address: "10.0.0.1:5555"})
+		allowed := NewStubConnection(transport.Peer{ID: "allowed", Address: "192.168.0.1:5555"})
+		cm.connections.list = append(cm.connections.list, denied, allowed)
+
+		err = cm.SetPeerRules([]transport.PeerRule{{ID: "1", Action: transport.DenyPeerRule, CIDR: "10.0.0.0/8"}})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, denied.disconnectCalls)
+		assert.Equal(t, 0, allowed.disconnectCalls)
+	})
+	t.Run("invalid rule", func(t *testing.T) {
+		cm, err := NewGRPCConnectionManager(Config{}, nil, *nodeDID, nil)
+		require.NoError(t, err)
+		connection := NewStubConnection(transport.Peer{ID: "peer", Address: "10.0.0.1:5555"})
+		cm.connections.list = append(cm.connections.list, connection)
+
+		err = cm.SetPeerRules([]transport.PeerRule{{ID: "1", Action: transport.DenyPeerRule, CIDR: "invalid"}})
+
+		assert.ErrorIs(t, err, ErrInvalidPeerRule)
+		assert.Equal(t, 0, connection.disconnectCalls)
+	})
+}
+
+func Test_grpcConnectionManager_PenalizePeer(t *testing.T) {
+	t.Run("disconnects peer when threshold is reached", func(t *testing.T) {
+		cm, err := NewGRPCConnectionManager(Config{peerScores: PeerScoreConfig{Threshold: 2, Window: time.Minute}}, nil, *nodeDID, nil)
+		require.NoError(t, err)
+		connection := NewStubConnection(transport.Peer{ID: "peer", Address: "10.0.0.1:5555"})
+		sameIP := NewStubConnection(transport.Peer{ID: "peer2", Address: "10.0.0.1:6666"})
+		other := NewStubConnection(transport.Peer{ID: "other", Address: "10.0.0.2:5555"})
+		cm.connections.list = append(cm.connections.list, connection, sameIP, other)
+
+		cm.PenalizePeer("peer", "invalid transaction")
+		assert.Equal(t, 0, connection.disconnectCalls)
+		cm.PenalizePeer("peer2", "invalid transaction")
+
+		assert.Equal(t, 1, connection.disconnectCalls)
+		assert.Equal(t, 1, sameIP.disconnectCalls)
+		assert.Equal(t, 0, other.disconnectCalls)
+		assert.Equal(t, map[transport.PeerID]int{"peer2": 2}, cm.PeerScores())
+	})
+	t.Run("disconnected peer", func(t *testing.T) {
+		cm, err := NewGRPCConnectionManager(Config{peerScores: PeerScoreConfig{Threshold: 2, Window: time.Minute}}, nil, *nodeDID, nil)
+		require.NoError(t, err)
+
+		cm.PenalizePeer("peer", "invalid transaction")
+
+		assert.Equal(t, map[transport.PeerID]int{"peer": 1}, cm.PeerScores())
+	})
+	t.Run("scoring disabled", func(t *testing.T) {
+		cm, err := NewGRPCConnectionManager(Config{}, nil, *nodeDID, nil)
+		require.NoError(t, err)
+		connection := NewStubConnection(transport.Peer{ID: "peer"})
+		cm.connections.list = append(cm.connections.list, connection)
+
+		cm.PenalizePeer("peer", "invalid transaction")
+
+		assert.Equal(t, 0, connection.disconnectCalls)
+		assert.Empty(t, cm.PeerScores())
+	})
+}
+
 func newServerStream(clientPeerID transport.PeerID, nodeDID string, cert *x509.Certificate) *stubServerStream {
 	md := metadata.New(map[string]string{peerIDHeader: clientPeerID.String()})
 	if nodeDID != "" {
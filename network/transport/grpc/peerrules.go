@@ -0,0 +1,131 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/network/transport"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrPeerDenied is the error returned when connecting with a peer that is denied by the peer rules.
+var ErrPeerDenied = status.Error(codes.PermissionDenied, "peer is denied by peer rules")
+
+// ErrInvalidPeerRule is returned when a peer rule is invalid.
+var ErrInvalidPeerRule = errors.New("invalid peer rule")
+
+// peerRule is a transport.PeerRule with its criteria parsed.
+type peerRule struct {
+	transport.PeerRule
+	nodeDID *did.DID
+	network *net.IPNet
+}
+
+func parsePeerRule(rule transport.PeerRule) (peerRule, error) {
+	result := peerRule{PeerRule: rule}
+	if rule.Action != transport.AllowPeerRule && rule.Action != transport.DenyPeerRule {
+		return result, fmt.Errorf("%w: action must be '%s' or '%s'", ErrInvalidPeerRule, transport.AllowPeerRule, transport.DenyPeerRule)
+	}
+	if rule.NodeDID == "" && rule.CertificateSubject == "" && rule.CIDR == "" {
+		return result, fmt.Errorf("%w: at least one of nodeDID, certificateSubject or cidr must be set", ErrInvalidPeerRule)
+	}
+	var err error
+	if rule.NodeDID != "" {
+		if result.nodeDID, err = did.ParseDID(rule.NodeDID); err != nil {
+			return result, fmt.Errorf("%w: invalid nodeDID: %w", ErrInvalidPeerRule, err)
+		}
+	}
+	if rule.CIDR != "" {
+		if _, result.network, err = net.ParseCIDR(rule.CIDR); err != nil {
+			return result, fmt.Errorf("%w: invalid cidr: %w", ErrInvalidPeerRule, err)
+		}
+	}
+	return result, nil
+}
+
+// matches returns whether the peer, connected from or to the given IP address, matches all criteria of the rule.
+func (r peerRule) matches(peer transport.Peer, ip net.IP) bool {
+	if r.nodeDID != nil && !r.nodeDID.Equals(peer.NodeDID) {
+		return false
+	}
+	if r.CertificateSubject != "" && (peer.Certificate == nil || peer.Certificate.Subject.String() != r.CertificateSubject) {
+		return false
+	}
+	if r.network != nil && (ip == nil || !r.network.Contains(ip)) {
+		return false
+	}
+	return true
+}
+
+// peerRules holds the rules that allow or deny connections with peers.
+type peerRules struct {
+	mux   sync.RWMutex
+	rules []peerRule
+}
+
+// set validates and replaces the rules.
+func (p *peerRules) set(rules []transport.PeerRule) error {
+	parsed := make([]peerRule, len(rules))
+	for i, rule := range rules {
+		var err error
+		if parsed[i], err = parsePeerRule(rule); err != nil {
+			return err
+		}
+	}
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	p.rules = parsed
+	return nil
+}
+
+// isAllowed returns whether connections with the peer, connected from or to the given IP address, are allowed.
+// If the IP address is nil, rules with a CIDR don't match.
+func (p *peerRules) isAllowed(peer transport.Peer, ip net.IP) bool {
+	p.mux.RLock()
+	defer p.mux.RUnlock()
+	hasAllowRules := false
+	allowed := false
+	for _, rule := range p.rules {
+		switch rule.Action {
+		case transport.DenyPeerRule:
+			if rule.matches(peer, ip) {
+				return false
+			}
+		case transport.AllowPeerRule:
+			hasAllowRules = true
+			allowed = allowed || rule.matches(peer, ip)
+		}
+	}
+	return !hasAllowRules || allowed
+}
+
+// ipFromAddress returns the IP address of the given network address (host:port), or nil if it doesn't contain an IP address.
+func ipFromAddress(address string) net.IP {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		host = address
+	}
+	return net.ParseIP(host)
+}
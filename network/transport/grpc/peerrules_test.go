@@ -0,0 +1,145 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package grpc
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"testing"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/network/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parsePeerRule(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		rule, err := parsePeerRule(transport.PeerRule{Action: transport.DenyPeerRule, NodeDID: "did:nuts:123", CIDR: "10.0.0.0/8"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "did:nuts:123", rule.nodeDID.String())
+		assert.Equal(t, "10.0.0.0/8", rule.network.String())
+	})
+	t.Run("error - invalid action", func(t *testing.T) {
+		_, err := parsePeerRule(transport.PeerRule{Action: "block", CIDR: "10.0.0.0/8"})
+
+		assert.ErrorIs(t, err, ErrInvalidPeerRule)
+		assert.ErrorContains(t, err, "action must be 'allow' or 'deny'")
+	})
+	t.Run("error - no criteria", func(t *testing.T) {
+		_, err := parsePeerRule(transport.PeerRule{Action: transport.AllowPeerRule})
+
+		assert.ErrorIs(t, err, ErrInvalidPeerRule)
+	})
+	t.Run("error - invalid node DID", func(t *testing.T) {
+		_, err := parsePeerRule(transport.PeerRule{Action: transport.AllowPeerRule, NodeDID: "invalid"})
+
+		assert.ErrorIs(t, err, ErrInvalidPeerRule)
+		assert.ErrorContains(t, err, "invalid nodeDID")
+	})
+	t.Run("error - invalid CIDR", func(t *testing.T) {
+		_, err := parsePeerRule(transport.PeerRule{Action: transport.AllowPeerRule, CIDR: "10.0.0.1"})
+
+		assert.ErrorIs(t, err, ErrInvalidPeerRule)
+		assert.ErrorContains(t, err, "invalid cidr")
+	})
+}
+
+func Test_peerRule_matches(t *testing.T) {
+	certificate := &x509.Certificate{Subject: pkix.Name{CommonName: "node.example.com", Organization: []string{"Example"}}}
+	peer := transport.Peer{NodeDID: did.MustParseDID("did:nuts:123"), Certificate: certificate}
+	ip := net.ParseIP("10.0.0.1")
+	rule := func(r transport.PeerRule) peerRule {
+		r.Action = transport.DenyPeerRule
+		result, err := parsePeerRule(r)
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("node DID", func(t *testing.T) {
+		assert.True(t, rule(transport.PeerRule{NodeDID: "did:nuts:123"}).matches(peer, ip))
+		assert.False(t, rule(transport.PeerRule{NodeDID: "did:nuts:456"}).matches(peer, ip))
+	})
+	t.Run("certificate subject", func(t *testing.T) {
+		assert.True(t, rule(transport.PeerRule{CertificateSubject: "CN=node.example.com,O=Example"}).matches(peer, ip))
+		assert.False(t, rule(transport.PeerRule{CertificateSubject: "CN=other.example.com,O=Example"}).matches(peer, ip))
+		assert.False(t, rule(transport.PeerRule{CertificateSubject: "CN=node.example.com,O=Example"}).matches(transport.Peer{}, ip))
+	})
+	t.Run("CIDR", func(t *testing.T) {
+		assert.True(t, rule(transport.PeerRule{CIDR: "10.0.0.0/8"}).matches(peer, ip))
+		assert.False(t, rule(transport.PeerRule{CIDR: "192.168.0.0/16"}).matches(peer, ip))
+		assert.False(t, rule(transport.PeerRule{CIDR: "10.0.0.0/8"}).matches(peer, nil))
+	})
+	t.Run("all criteria must match", func(t *testing.T) {
+		assert.True(t, rule(transport.PeerRule{NodeDID: "did:nuts:123", CIDR: "10.0.0.0/8"}).matches(peer, ip))
+		assert.False(t, rule(transport.PeerRule{NodeDID: "did:nuts:123", CIDR: "192.168.0.0/16"}).matches(peer, ip))
+	})
+}
+
+func Test_peerRules_isAllowed(t *testing.T) {
+	peer := transport.Peer{NodeDID: did.MustParseDID("did:nuts:123")}
+	ip := net.ParseIP("10.0.0.1")
+
+	t.Run("no rules", func(t *testing.T) {
+		rules := &peerRules{}
+
+		assert.True(t, rules.isAllowed(peer, ip))
+	})
+	t.Run("denied", func(t *testing.T) {
+		rules := &peerRules{}
+		require.NoError(t, rules.set([]transport.PeerRule{{Action: transport.DenyPeerRule, CIDR: "10.0.0.0/8"}}))
+
+		assert.False(t, rules.isAllowed(peer, ip))
+		assert.True(t, rules.isAllowed(peer, net.ParseIP("192.168.0.1")))
+	})
+	t.Run("allow rules refuse peers that don't match", func(t *testing.T) {
+		rules := &peerRules{}
+		require.NoError(t, rules.set([]transport.PeerRule{{Action: transport.AllowPeerRule, NodeDID: "did:nuts:123"}}))
+
+		assert.True(t, rules.isAllowed(peer, ip))
+		assert.False(t, rules.isAllowed(transport.Peer{NodeDID: did.MustParseDID("did:nuts:456")}, ip))
+	})
+	t.Run("deny takes precedence over allow", func(t *testing.T) {
+		rules := &peerRules{}
+		require.NoError(t, rules.set([]transport.PeerRule{
+			{Action: transport.AllowPeerRule, NodeDID: "did:nuts:123"},
+			{Action: transport.DenyPeerRule, CIDR: "10.0.0.0/8"},
+		}))
+
+		assert.False(t, rules.isAllowed(peer, ip))
+	})
+	t.Run("invalid rules aren't applied", func(t *testing.T) {
+		rules := &peerRules{}
+		require.NoError(t, rules.set([]transport.PeerRule{{Action: transport.DenyPeerRule, CIDR: "10.0.0.0/8"}}))
+
+		err := rules.set([]transport.PeerRule{{Action: transport.DenyPeerRule, CIDR: "invalid"}})
+
+		assert.ErrorIs(t, err, ErrInvalidPeerRule)
+		assert.False(t, rules.isAllowed(peer, ip))
+	})
+}
+
+func Test_ipFromAddress(t *testing.T) {
+	assert.Equal(t, "10.0.0.1", ipFromAddress("10.0.0.1:5555").String())
+	assert.Equal(t, "::1", ipFromAddress("[::1]:5555").String())
+	assert.Equal(t, "10.0.0.1", ipFromAddress("10.0.0.1").String())
+	assert.Nil(t, ipFromAddress("node.example.com:5555"))
+}
@@ -0,0 +1,131 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package grpc
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nuts-foundation/nuts-node/network/transport"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrPeerScoreExceeded is the error returned when connecting with a peer whose penalty score exceeds the threshold.
+var ErrPeerScoreExceeded = status.Error(codes.PermissionDenied, "peer penalty score exceeds threshold")
+
+// PeerScoreConfig specifies when peers are disconnected because they misbehave (e.g. send invalid transactions).
+type PeerScoreConfig struct {
+	// Threshold specifies the penalty score at which a peer is disconnected and refused. Scoring is disabled if 0.
+	Threshold int `koanf:"threshold"`
+	// Window specifies how long a penalty counts towards a peer's score.
+	Window time.Duration `koanf:"window"`
+}
+
+func (c PeerScoreConfig) enabled() bool {
+	return c.Threshold > 0
+}
+
+// peerScorePruneInterval specifies how often penalties that fall outside the window are removed.
+const peerScorePruneInterval = time.Minute
+
+// peerPenalties holds the penalties of a single peer.
+type peerPenalties struct {
+	// peerID holds the ID of the peer when it was last penalized.
+	peerID transport.PeerID
+	times  []time.Time
+}
+
+// peerScores keeps track of the penalties of peers. A peer's score is the number of penalties within the configured window.
+// Peers are identified by their peerIdentity instead of the self-chosen peer ID,
+// so they can't reset their score by reconnecting with another peer ID.
+type peerScores struct {
+	config    PeerScoreConfig
+	mux       sync.Mutex
+	penalties map[peerIdentity]*peerPenalties
+}
+
+func newPeerScores(config PeerScoreConfig) *peerScores {
+	return &peerScores{
+		config:    config,
+		penalties: make(map[peerIdentity]*peerPenalties),
+	}
+}
+
+// penalize adds a penalty to the peer and returns whether its score now exceeds the threshold.
+func (p *peerScores) penalize(identity peerIdentity, peerID transport.PeerID, now time.Time) bool {
+	if !p.config.enabled() {
+		return false
+	}
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	times := append(p.prune(identity, now), now)
+	p.penalties[identity] = &peerPenalties{peerID: peerID, times: times}
+	return len(times) >= p.config.Threshold
+}
+
+// exceeded returns whether the score of the peer exceeds the threshold.
+func (p *peerScores) exceeded(identity peerIdentity, now time.Time) bool {
+	if !p.config.enabled() {
+		return false
+	}
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	return len(p.prune(identity, now)) >= p.config.Threshold
+}
+
+// scores returns the scores of the peers with penalties within the window, by the ID of the peer when it was last penalized.
+func (p *peerScores) scores(now time.Time) map[transport.PeerID]int {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	result := make(map[transport.PeerID]int)
+	for identity, penalties := range p.penalties {
+		if score := len(p.prune(identity, now)); score > 0 {
+			result[penalties.peerID] = score
+		}
+	}
+	return result
+}
+
+// pruneAll removes the penalties of all peers that fall outside the window, so peers that aren't penalized again don't linger.
+func (p *peerScores) pruneAll(now time.Time) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	for identity := range p.penalties {
+		p.prune(identity, now)
+	}
+}
+
+// prune removes the penalties of the peer that fall outside the window and returns the remaining ones. The caller must hold the lock.
+func (p *peerScores) prune(identity peerIdentity, now time.Time) []time.Time {
+	penalties, ok := p.penalties[identity]
+	if !ok {
+		return nil
+	}
+	i := 0
+	for i < len(penalties.times) && !penalties.times[i].After(now.Add(-p.config.Window)) {
+		i++
+	}
+	if i == len(penalties.times) {
+		delete(p.penalties, identity)
+		return nil
+	}
+	penalties.times = penalties.times[i:]
+	return penalties.times
+}
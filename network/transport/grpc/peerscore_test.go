@@ -0,0 +1,79 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package grpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nuts-foundation/nuts-node/network/transport"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeerScoreConfig_enabled(t *testing.T) {
+	assert.False(t, PeerScoreConfig{Window: time.Hour}.enabled())
+	assert.True(t, PeerScoreConfig{Threshold: 1}.enabled())
+}
+
+func Test_peerScores(t *testing.T) {
+	now := time.Now()
+	t.Run("threshold", func(t *testing.T) {
+		scores := newPeerScores(PeerScoreConfig{Threshold: 2, Window: time.Hour})
+
+		assert.False(t, scores.penalize("peer", "peer", now))
+		assert.False(t, scores.exceeded("peer", now))
+		assert.True(t, scores.penalize("peer", "peer", now))
+		assert.True(t, scores.exceeded("peer", now))
+		assert.False(t, scores.exceeded("other", now))
+		assert.Equal(t, map[transport.PeerID]int{"peer": 2}, scores.scores(now))
+	})
+	t.Run("penalties expire", func(t *testing.T) {
+		scores := newPeerScores(PeerScoreConfig{Threshold: 2, Window: time.Hour})
+		scores.penalize("peer", "peer", now)
+		scores.penalize("peer", "peer", now.Add(30*time.Minute))
+
+		assert.False(t, scores.exceeded("peer", now.Add(61*time.Minute)))
+		assert.Equal(t, map[transport.PeerID]int{"peer": 1}, scores.scores(now.Add(61*time.Minute)))
+		assert.Empty(t, scores.scores(now.Add(2*time.Hour)))
+	})
+	t.Run("peer reconnects with another peer ID", func(t *testing.T) {
+		scores := newPeerScores(PeerScoreConfig{Threshold: 2, Window: time.Hour})
+
+		assert.False(t, scores.penalize("ip:10.0.0.1", "peer1", now))
+		assert.True(t, scores.penalize("ip:10.0.0.1", "peer2", now))
+		assert.Equal(t, map[transport.PeerID]int{"peer2": 2}, scores.scores(now))
+	})
+	t.Run("pruneAll", func(t *testing.T) {
+		scores := newPeerScores(PeerScoreConfig{Threshold: 2, Window: time.Hour})
+		scores.penalize("peer1", "peer1", now)
+		scores.penalize("peer2", "peer2", now.Add(30*time.Minute))
+
+		scores.pruneAll(now.Add(61 * time.Minute))
+
+		assert.Len(t, scores.penalties, 1)
+		assert.Contains(t, scores.penalties, peerIdentity("peer2"))
+	})
+	t.Run("disabled", func(t *testing.T) {
+		scores := newPeerScores(PeerScoreConfig{})
+
+		assert.False(t, scores.penalize("peer", "peer", now))
+		assert.False(t, scores.exceeded("peer", now))
+		assert.Empty(t, scores.scores(now))
+	})
+}
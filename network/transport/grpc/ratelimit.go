@@ -0,0 +1,332 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package grpc
+
+import (
+	"context"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nuts-foundation/nuts-node/network/transport"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrRateLimitExceeded is the error returned when a peer keeps sending more messages or bytes than its rate limit allows.
+// The connection is closed and the peer is backed off.
+var ErrRateLimitExceeded = status.Error(codes.ResourceExhausted, "rate limit exceeded")
+
+// ErrPeerBackedOff is the error returned when connecting with a peer that is backed off, because it exceeded its rate limit.
+var ErrPeerBackedOff = status.Error(codes.ResourceExhausted, "peer is backed off because it exceeded its rate limit")
+
+const (
+	directionInbound  = "inbound"
+	directionOutbound = "outbound"
+	scopePeer         = "peer"
+	scopeGlobal       = "global"
+)
+
+// rateLimitBurst specifies the burst size of the limits, as the number of seconds worth of messages or bytes
+// at the configured rate that may be exchanged at once (e.g. when a peer responds to a query for transactions).
+const rateLimitBurst = 10 * time.Second
+
+// maxInboundDelay specifies how long reading inbound messages from a peer may be delayed to conform to its rate limit.
+// A peer whose messages would have to be delayed longer is disconnected and backed off.
+const maxInboundDelay = 10 * time.Second
+
+// RateLimitConfig specifies the token-bucket limits on the messages exchanged with peers.
+// The limits apply to inbound and outbound messages separately, and allow bursts of rateLimitBurst. A limit of 0 disables it.
+type RateLimitConfig struct {
+	// PeerMessages specifies the number of messages per second that may be exchanged with a single peer.
+	PeerMessages float64 `koanf:"peermessages"`
+	// PeerBytes specifies the number of bytes per second that may be exchanged with a single peer.
+	PeerBytes int `koanf:"peerbytes"`
+	// GlobalMessages specifies the number of messages per second that may be exchanged with all peers combined.
+	GlobalMessages float64 `koanf:"globalmessages"`
+	// GlobalBytes specifies the number of bytes per second that may be exchanged with all peers combined.
+	GlobalBytes int `koanf:"globalbytes"`
+	// Backoff specifies how long connections with a peer are refused after it kept exceeding its inbound rate limit.
+	Backoff time.Duration `koanf:"backoff"`
+}
+
+func (c RateLimitConfig) enabled() bool {
+	return c.PeerMessages > 0 || c.PeerBytes > 0 || c.GlobalMessages > 0 || c.GlobalBytes > 0
+}
+
+// tokenBuckets limits the number of messages and bytes. A nil limiter means the limit is disabled.
+type tokenBuckets struct {
+	messages *rate.Limiter
+	bytes    *rate.Limiter
+}
+
+func newTokenBuckets(messages float64, bytes int) tokenBuckets {
+	var result tokenBuckets
+	if messages > 0 {
+		result.messages = rate.NewLimiter(rate.Limit(messages), max(int(math.Ceil(messages*rateLimitBurst.Seconds())), 1))
+	}
+	if bytes > 0 {
+		// burst must allow at least a single message of the maximum size
+		result.bytes = rate.NewLimiter(rate.Limit(bytes), max(bytes*int(rateLimitBurst.Seconds()), MaxMessageSizeInBytes))
+	}
+	return result
+}
+
+// reserve takes the tokens for a message of the given size.
+// It returns how long to wait before the message conforms to the limits, and a function to cancel the reservation.
+func (b tokenBuckets) reserve(now time.Time, size int) (time.Duration, func()) {
+	var reservations []*rate.Reservation
+	if b.messages != nil {
+		reservations = append(reservations, b.messages.ReserveN(now, 1))
+	}
+	if b.bytes != nil {
+		reservations = append(reservations, b.bytes.ReserveN(now, min(size, b.bytes.Burst())))
+	}
+	var delay time.Duration
+	for _, reservation := range reservations {
+		delay = max(delay, reservation.DelayFrom(now))
+	}
+	return delay, func() {
+		for _, reservation := range reservations {
+			reservation.CancelAt(now)
+		}
+	}
+}
+
+// peerRateLimiter holds the rate limits and statistics of a single peer.
+type peerRateLimiter struct {
+	// peerID holds the ID of the peer's last registered stream, guarded by rateLimiter.mux.
+	peerID            transport.PeerID
+	inbound           tokenBuckets
+	outbound          tokenBuckets
+	inboundThrottled  atomic.Uint64
+	outboundThrottled atomic.Uint64
+	backoffUntil      atomic.Pointer[time.Time]
+	// streams holds the number of active streams with the peer, guarded by rateLimiter.mux.
+	streams int
+}
+
+func (p *peerRateLimiter) isBackedOff(now time.Time) bool {
+	until := p.backoffUntil.Load()
+	return until != nil && now.Before(*until)
+}
+
+// rateLimiter limits the messages exchanged with peers, per peer and for all peers combined.
+// Messages are delayed until they conform to the limits. Peers whose inbound messages would have to be delayed longer than
+// maxInboundDelay are disconnected and backed off. Peers are identified by their peerIdentity instead of the self-chosen peer ID,
+// so they can't evade their limits or backoff by reconnecting with another peer ID.
+type rateLimiter struct {
+	config           RateLimitConfig
+	inbound          tokenBuckets
+	outbound         tokenBuckets
+	throttledCounter *prometheus.CounterVec
+	mux              sync.Mutex
+	peers            map[peerIdentity]*peerRateLimiter
+}
+
+func newRateLimiter(config RateLimitConfig, throttledCounter *prometheus.CounterVec) *rateLimiter {
+	return &rateLimiter{
+		config:           config,
+		inbound:          newTokenBuckets(config.GlobalMessages, config.GlobalBytes),
+		outbound:         newTokenBuckets(config.GlobalMessages, config.GlobalBytes),
+		throttledCounter: throttledCounter,
+		peers:            make(map[peerIdentity]*peerRateLimiter),
+	}
+}
+
+// register returns the rate limiter for a new stream with the given peer. unregister must be called when the stream closes.
+func (r *rateLimiter) register(identity peerIdentity, peerID transport.PeerID) *peerRateLimiter {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	peer, ok := r.peers[identity]
+	if !ok {
+		peer = &peerRateLimiter{
+			inbound:  newTokenBuckets(r.config.PeerMessages, r.config.PeerBytes),
+			outbound: newTokenBuckets(r.config.PeerMessages, r.config.PeerBytes),
+		}
+		r.peers[identity] = peer
+	}
+	peer.peerID = peerID
+	peer.streams++
+	return peer
+}
+
+// unregister is called when a stream with the given peer closes.
+// The peer's statistics are kept while it's backed off.
+func (r *rateLimiter) unregister(identity peerIdentity) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	peer, ok := r.peers[identity]
+	if !ok {
+		return
+	}
+	peer.streams--
+	r.prune(identity, peer, time.Now())
+}
+
+// prune removes the peer if it has no active streams and isn't backed off. The caller must hold the lock.
+func (r *rateLimiter) prune(identity peerIdentity, peer *peerRateLimiter, now time.Time) {
+	if peer.streams <= 0 && !peer.isBackedOff(now) {
+		delete(r.peers, identity)
+	}
+}
+
+// isBackedOff returns whether connections with the given peer must be refused, because it exceeded its rate limit.
+func (r *rateLimiter) isBackedOff(identity peerIdentity) bool {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	peer, ok := r.peers[identity]
+	if !ok {
+		return false
+	}
+	now := time.Now()
+	r.prune(identity, peer, now)
+	return peer.isBackedOff(now)
+}
+
+// throttling returns the statistics of the peers that are connected or backed off, by the ID of their last registered stream.
+func (r *rateLimiter) throttling() map[transport.PeerID]transport.Throttling {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	now := time.Now()
+	result := make(map[transport.PeerID]transport.Throttling, len(r.peers))
+	for identity, peer := range r.peers {
+		r.prune(identity, peer, now)
+		if _, ok := r.peers[identity]; !ok {
+			continue
+		}
+		throttling := transport.Throttling{
+			Inbound:  peer.inboundThrottled.Load(),
+			Outbound: peer.outboundThrottled.Load(),
+		}
+		if peer.isBackedOff(now) {
+			throttling.BackoffUntil = peer.backoffUntil.Load()
+		}
+		result[peer.peerID] = throttling
+	}
+	return result
+}
+
+// receive applies the inbound rate limits to a message of the given size received from the peer.
+// It blocks until the message conforms to the peer's and global limits, which slows down the peer.
+// If the message would have to be delayed longer than maxInboundDelay to conform to the peer's limit,
+// the peer is backed off and ErrRateLimitExceeded is returned.
+func (r *rateLimiter) receive(ctx context.Context, peer *peerRateLimiter, size int) error {
+	now := time.Now()
+	peerDelay, peerCancel := peer.inbound.reserve(now, size)
+	if peerDelay > maxInboundDelay {
+		peerCancel()
+		peer.inboundThrottled.Add(1)
+		backoffUntil := now.Add(r.config.Backoff)
+		peer.backoffUntil.Store(&backoffUntil)
+		r.throttledCounter.WithLabelValues(directionInbound, scopePeer).Inc()
+		return ErrRateLimitExceeded
+	}
+	globalDelay, globalCancel := r.inbound.reserve(now, size)
+	if peerDelay > 0 {
+		r.throttledCounter.WithLabelValues(directionInbound, scopePeer).Inc()
+	}
+	if globalDelay > 0 {
+		r.throttledCounter.WithLabelValues(directionInbound, scopeGlobal).Inc()
+	}
+	if peerDelay > 0 || globalDelay > 0 {
+		peer.inboundThrottled.Add(1)
+	}
+	return wait(ctx, max(peerDelay, globalDelay), func() {
+		peerCancel()
+		globalCancel()
+	})
+}
+
+// send applies the outbound rate limits to a message of the given size that is sent to the peer.
+// It blocks until the message conforms to the peer's and global limits.
+func (r *rateLimiter) send(ctx context.Context, peer *peerRateLimiter, size int) error {
+	now := time.Now()
+	peerDelay, peerCancel := peer.outbound.reserve(now, size)
+	globalDelay, globalCancel := r.outbound.reserve(now, size)
+	if peerDelay > 0 {
+		r.throttledCounter.WithLabelValues(directionOutbound, scopePeer).Inc()
+	}
+	if globalDelay > 0 {
+		r.throttledCounter.WithLabelValues(directionOutbound, scopeGlobal).Inc()
+	}
+	if peerDelay > 0 || globalDelay > 0 {
+		peer.outboundThrottled.Add(1)
+	}
+	return wait(ctx, max(peerDelay, globalDelay), func() {
+		peerCancel()
+		globalCancel()
+	})
+}
+
+// wait blocks for the given delay. If the context is done before, the reservation is cancelled and the context's error is returned.
+func wait(ctx context.Context, delay time.Duration, cancel func()) error {
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		cancel()
+		return ctx.Err()
+	}
+}
+
+// rateLimitedStreamWrapper applies the rate limits of a peer to the messages sent and received over a stream.
+type rateLimitedStreamWrapper struct {
+	stream  Stream
+	limiter *rateLimiter
+	peer    *peerRateLimiter
+}
+
+func (r rateLimitedStreamWrapper) Unwrap() Stream {
+	return r.stream
+}
+
+func (r rateLimitedStreamWrapper) Context() context.Context {
+	return r.stream.Context()
+}
+
+func (r rateLimitedStreamWrapper) SendMsg(m interface{}) error {
+	if err := r.limiter.send(r.stream.Context(), r.peer, messageSize(m)); err != nil {
+		return err
+	}
+	return r.stream.SendMsg(m)
+}
+
+func (r rateLimitedStreamWrapper) RecvMsg(m interface{}) error {
+	if err := r.stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return r.limiter.receive(r.stream.Context(), r.peer, messageSize(m))
+}
+
+func messageSize(m interface{}) int {
+	if message, ok := m.(proto.Message); ok {
+		return proto.Size(message)
+	}
+	return 0
+}
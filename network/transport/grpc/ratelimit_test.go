@@ -0,0 +1,242 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nuts-foundation/nuts-node/network/transport"
+	"github.com/prometheus/client_golang/prometheus"
+	io_prometheus_client "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestThrottledCounter() *prometheus.CounterVec {
+	return prometheus.NewCounterVec(prometheus.CounterOpts{Namespace: "t", Subsystem: "es", Name: "t"}, []string{"direction", "scope"})
+}
+
+func throttledCount(counter *prometheus.CounterVec, direction string, scope string) float64 {
+	metric := &io_prometheus_client.Metric{}
+	_ = counter.WithLabelValues(direction, scope).Write(metric)
+	return *metric.Counter.Value
+}
+
+func TestRateLimitConfig_enabled(t *testing.T) {
+	assert.False(t, RateLimitConfig{Backoff: time.Minute}.enabled())
+	assert.True(t, RateLimitConfig{PeerMessages: 1}.enabled())
+	assert.True(t, RateLimitConfig{PeerBytes: 1}.enabled())
+	assert.True(t, RateLimitConfig{GlobalMessages: 1}.enabled())
+	assert.True(t, RateLimitConfig{GlobalBytes: 1}.enabled())
+}
+
+func TestRateLimiter_receive(t *testing.T) {
+	ctx := context.Background()
+	t.Run("peer exceeds message limit", func(t *testing.T) {
+		counter := newTestThrottledCounter()
+		// burst of 1 message, the next message would have to be delayed 100s
+		limiter := newRateLimiter(RateLimitConfig{PeerMessages: 0.01, Backoff: time.Minute}, counter)
+		peer := limiter.register("peer", "peer")
+
+		require.NoError(t, limiter.receive(ctx, peer, 10))
+		err := limiter.receive(ctx, peer, 10)
+
+		assert.ErrorIs(t, err, ErrRateLimitExceeded)
+		assert.True(t, limiter.isBackedOff("peer"))
+		assert.False(t, limiter.isBackedOff("other"))
+		assert.Equal(t, float64(1), throttledCount(counter, directionInbound, scopePeer))
+		throttling := limiter.throttling()["peer"]
+		assert.Equal(t, uint64(1), throttling.Inbound)
+		assert.NotNil(t, throttling.BackoffUntil)
+	})
+	t.Run("peer exceeds byte limit", func(t *testing.T) {
+		limiter := newRateLimiter(RateLimitConfig{PeerBytes: 1, Backoff: time.Minute}, newTestThrottledCounter())
+		peer := limiter.register("peer", "peer")
+
+		// burst allows a single message of the maximum size, the next message would have to be delayed 20s
+		require.NoError(t, limiter.receive(ctx, peer, MaxMessageSizeInBytes))
+		err := limiter.receive(ctx, peer, 20)
+
+		assert.ErrorIs(t, err, ErrRateLimitExceeded)
+	})
+	t.Run("peer limit delays message", func(t *testing.T) {
+		counter := newTestThrottledCounter()
+		limiter := newRateLimiter(RateLimitConfig{PeerMessages: 100, Backoff: time.Minute}, counter)
+		peer := limiter.register("peer", "peer")
+
+		// burst allows 10 seconds worth of messages
+		for i := 0; i < 1000; i++ {
+			require.NoError(t, limiter.receive(ctx, peer, 10))
+		}
+		start := time.Now()
+		err := limiter.receive(ctx, peer, 10)
+
+		require.NoError(t, err)
+		assert.Greater(t, time.Since(start), time.Millisecond)
+		assert.False(t, limiter.isBackedOff("peer"))
+		assert.Equal(t, float64(1), throttledCount(counter, directionInbound, scopePeer))
+		assert.Equal(t, uint64(1), limiter.throttling()["peer"].Inbound)
+	})
+	t.Run("global limit delays message", func(t *testing.T) {
+		counter := newTestThrottledCounter()
+		limiter := newRateLimiter(RateLimitConfig{GlobalMessages: 100, Backoff: time.Minute}, counter)
+		peer1 := limiter.register("peer1", "peer1")
+		peer2 := limiter.register("peer2", "peer2")
+
+		for i := 0; i < 1000; i++ {
+			require.NoError(t, limiter.receive(ctx, peer1, 10))
+		}
+		start := time.Now()
+		err := limiter.receive(ctx, peer2, 10)
+
+		require.NoError(t, err)
+		assert.Greater(t, time.Since(start), time.Millisecond)
+		assert.False(t, limiter.isBackedOff("peer2"))
+		assert.Equal(t, float64(1), throttledCount(counter, directionInbound, scopeGlobal))
+		assert.Equal(t, uint64(1), limiter.throttling()["peer2"].Inbound)
+	})
+	t.Run("context cancelled while waiting", func(t *testing.T) {
+		limiter := newRateLimiter(RateLimitConfig{GlobalMessages: 0.01, Backoff: time.Minute}, newTestThrottledCounter())
+		peer := limiter.register("peer", "peer")
+		require.NoError(t, limiter.receive(ctx, peer, 10))
+		cancelledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		err := limiter.receive(cancelledCtx, peer, 10)
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestRateLimiter_send(t *testing.T) {
+	ctx := context.Background()
+	t.Run("peer limit delays message", func(t *testing.T) {
+		counter := newTestThrottledCounter()
+		limiter := newRateLimiter(RateLimitConfig{PeerMessages: 100, Backoff: time.Minute}, counter)
+		peer := limiter.register("peer", "peer")
+
+		for i := 0; i < 1000; i++ {
+			require.NoError(t, limiter.send(ctx, peer, 10))
+		}
+		err := limiter.send(ctx, peer, 10)
+
+		require.NoError(t, err)
+		// outbound messages never cause the peer to be backed off
+		assert.False(t, limiter.isBackedOff("peer"))
+		assert.Equal(t, float64(1), throttledCount(counter, directionOutbound, scopePeer))
+		assert.Equal(t, uint64(1), limiter.throttling()["peer"].Outbound)
+	})
+	t.Run("context cancelled while waiting", func(t *testing.T) {
+		limiter := newRateLimiter(RateLimitConfig{GlobalBytes: 1, Backoff: time.Minute}, newTestThrottledCounter())
+		peer := limiter.register("peer", "peer")
+		require.NoError(t, limiter.send(ctx, peer, MaxMessageSizeInBytes))
+		cancelledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		err := limiter.send(cancelledCtx, peer, 10)
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestRateLimiter_register(t *testing.T) {
+	t.Run("streams with the same identity share the limits", func(t *testing.T) {
+		limiter := newRateLimiter(RateLimitConfig{PeerMessages: 0.01, Backoff: time.Minute}, newTestThrottledCounter())
+		peer1 := limiter.register("ip:10.0.0.1", "peer1")
+		peer2 := limiter.register("ip:10.0.0.1", "peer2")
+		require.NoError(t, limiter.receive(context.Background(), peer1, 10))
+
+		err := limiter.receive(context.Background(), peer2, 10)
+
+		assert.ErrorIs(t, err, ErrRateLimitExceeded)
+		assert.True(t, limiter.isBackedOff("ip:10.0.0.1"))
+		// statistics are reported by the ID of the last registered stream
+		assert.Contains(t, limiter.throttling(), transport.PeerID("peer2"))
+	})
+}
+
+func TestRateLimiter_unregister(t *testing.T) {
+	t.Run("removes peer when last stream closes", func(t *testing.T) {
+		limiter := newRateLimiter(RateLimitConfig{PeerMessages: 0.01, Backoff: time.Minute}, newTestThrottledCounter())
+		limiter.register("peer", "peer")
+		limiter.register("peer", "peer")
+
+		limiter.unregister("peer")
+		assert.Contains(t, limiter.throttling(), transport.PeerID("peer"))
+		limiter.unregister("peer")
+
+		assert.Empty(t, limiter.throttling())
+	})
+	t.Run("keeps backed off peer", func(t *testing.T) {
+		limiter := newRateLimiter(RateLimitConfig{PeerMessages: 0.01, Backoff: time.Minute}, newTestThrottledCounter())
+		peer := limiter.register("peer", "peer")
+		_ = limiter.receive(context.Background(), peer, 10)
+		_ = limiter.receive(context.Background(), peer, 10)
+
+		limiter.unregister("peer")
+
+		assert.True(t, limiter.isBackedOff("peer"))
+		assert.Contains(t, limiter.throttling(), transport.PeerID("peer"))
+	})
+	t.Run("removes peer when backoff expired", func(t *testing.T) {
+		limiter := newRateLimiter(RateLimitConfig{PeerMessages: 0.01, Backoff: time.Millisecond}, newTestThrottledCounter())
+		peer := limiter.register("peer", "peer")
+		_ = limiter.receive(context.Background(), peer, 10)
+		_ = limiter.receive(context.Background(), peer, 10)
+		limiter.unregister("peer")
+
+		time.Sleep(2 * time.Millisecond)
+
+		assert.False(t, limiter.isBackedOff("peer"))
+		assert.Empty(t, limiter.throttling())
+	})
+}
+
+func Test_RateLimitedStreamWrapper(t *testing.T) {
+	t.Run("RecvMsg", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		stream := NewMockStream(ctrl)
+		stream.EXPECT().Context().Return(context.Background()).AnyTimes()
+		stream.EXPECT().RecvMsg(gomock.Any()).Times(2)
+		limiter := newRateLimiter(RateLimitConfig{PeerMessages: 0.01, Backoff: time.Minute}, newTestThrottledCounter())
+		wrapper := rateLimitedStreamWrapper{stream: stream, limiter: limiter, peer: limiter.register("peer", "peer")}
+
+		assert.NoError(t, wrapper.RecvMsg(&TestMessage{}))
+		assert.ErrorIs(t, wrapper.RecvMsg(&TestMessage{}), ErrRateLimitExceeded)
+	})
+	t.Run("SendMsg", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		stream := NewMockStream(ctrl)
+		stream.EXPECT().Context().Return(context.Background()).AnyTimes()
+		stream.EXPECT().SendMsg(gomock.Any())
+		limiter := newRateLimiter(RateLimitConfig{PeerMessages: 0.01, Backoff: time.Minute}, newTestThrottledCounter())
+		wrapper := rateLimitedStreamWrapper{stream: stream, limiter: limiter, peer: limiter.register("peer", "peer")}
+
+		assert.NoError(t, wrapper.SendMsg(&TestMessage{}))
+	})
+	t.Run("Unwrap", func(t *testing.T) {
+		stream := NewMockStream(gomock.NewController(t))
+		wrapper := rateLimitedStreamWrapper{stream: stream}
+
+		assert.Equal(t, stream, wrapper.Unwrap())
+	})
+}
@@ -19,14 +19,37 @@
 package grpc
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/nuts-node/network/transport"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
+	"net"
 	"strings"
 )
 
+// peerIdentity identifies a peer for applying its limits. Unlike the peer ID, which is chosen by the peer itself,
+// it's derived from the peer's authenticated node DID, the TLS certificate it presented or its IP address.
+type peerIdentity string
+
+// identifyPeer returns the peerIdentity of the given peer, connected from the given IP address (which may be nil).
+// If neither is known, it falls back to the peer ID.
+func identifyPeer(peer transport.Peer, ip net.IP) peerIdentity {
+	switch {
+	case peer.Authenticated && !peer.NodeDID.Empty():
+		return peerIdentity(peer.NodeDID.String())
+	case peer.Certificate != nil:
+		fingerprint := sha256.Sum256(peer.Certificate.Raw)
+		return peerIdentity("cert:" + hex.EncodeToString(fingerprint[:]))
+	case ip != nil:
+		return peerIdentity("ip:" + ip.String())
+	default:
+		return peerIdentity("id:" + peer.ID.String())
+	}
+}
+
 func readMetadata(md metadata.MD) (transport.PeerID, did.DID, error) {
 	val := func(key string, required bool) (string, error) {
 		values := md.Get(key)
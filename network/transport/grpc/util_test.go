@@ -19,7 +19,11 @@
 package grpc
 
 import (
+	"crypto/x509"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/network/transport"
 	"github.com/stretchr/testify/require"
+	"net"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -69,3 +73,24 @@ func Test_readMetadata(t *testing.T) {
 		assert.Empty(t, nodeDID)
 	})
 }
+
+func Test_identifyPeer(t *testing.T) {
+	nodeDID := did.MustParseDID("did:nuts:test")
+	certificate := &x509.Certificate{Raw: []byte("certificate")}
+	ip := net.ParseIP("10.0.0.1")
+
+	t.Run("authenticated node DID", func(t *testing.T) {
+		peer := transport.Peer{ID: "peer", NodeDID: nodeDID, Authenticated: true, Certificate: certificate}
+		assert.Equal(t, peerIdentity("did:nuts:test"), identifyPeer(peer, ip))
+	})
+	t.Run("unauthenticated node DID uses certificate", func(t *testing.T) {
+		peer := transport.Peer{ID: "peer", NodeDID: nodeDID, Certificate: certificate}
+		assert.Equal(t, peerIdentity("cert:03d66dd08835c1ca3f128cceacd1f31ac94163096b20f445ae84285bc0832d72"), identifyPeer(peer, ip))
+	})
+	t.Run("IP address", func(t *testing.T) {
+		assert.Equal(t, peerIdentity("ip:10.0.0.1"), identifyPeer(transport.Peer{ID: "peer"}, ip))
+	})
+	t.Run("peer ID", func(t *testing.T) {
+		assert.Equal(t, peerIdentity("id:peer"), identifyPeer(transport.Peer{ID: "peer"}, nil))
+	})
+}
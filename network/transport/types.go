@@ -112,6 +112,46 @@ type Diagnostics struct {
 	NodeDID string `json:"nodeDID"`
 	// Address is NutsComm on outbound, or IP for inbound connections.
 	Address string `json:"address"`
+	// Throttling contains the statistics of the rate limiting of messages exchanged with the peer, if rate limiting is enabled.
+	Throttling *Throttling `json:"throttling,omitempty"`
+	// Score holds the penalty score of the peer, which increases when it sends invalid transactions.
+	Score int `json:"score,omitempty"`
+}
+
+// Throttling holds statistics of the rate limiting of messages exchanged with a peer.
+type Throttling struct {
+	// Inbound holds the number of messages received from the peer that were delayed by a rate limit, or caused it to be backed off.
+	Inbound uint64 `json:"inbound"`
+	// Outbound holds the number of messages sent to the peer that were delayed by a rate limit.
+	Outbound uint64 `json:"outbound"`
+	// BackoffUntil holds the time until which connections with the peer are refused, because it exceeded its rate limit.
+	BackoffUntil *time.Time `json:"backoffUntil,omitempty"`
+}
+
+// PeerRuleAction specifies whether a PeerRule allows or denies connections.
+type PeerRuleAction string
+
+const (
+	// AllowPeerRule allows connections with the peers matching the rule.
+	// If there are any allow rules, connections with peers that don't match one of them are denied.
+	AllowPeerRule PeerRuleAction = "allow"
+	// DenyPeerRule denies connections with the peers matching the rule.
+	DenyPeerRule PeerRuleAction = "deny"
+)
+
+// PeerRule allows or denies connections with the peers it matches. A peer matches the rule if it matches all of its criteria.
+// Deny rules take precedence over allow rules.
+type PeerRule struct {
+	// ID uniquely identifies the rule.
+	ID string `json:"id"`
+	// Action specifies whether connections with matching peers are allowed or denied.
+	Action PeerRuleAction `json:"action"`
+	// NodeDID matches peers that authenticated with the given node DID.
+	NodeDID string `json:"nodeDID,omitempty"`
+	// CertificateSubject matches peers that presented a TLS certificate with the given subject (e.g. CN=node.example.com,O=Example).
+	CertificateSubject string `json:"certificateSubject,omitempty"`
+	// CIDR matches peers connecting from or to an IP address in the given range (e.g. 10.0.0.0/8).
+	CIDR string `json:"cidr,omitempty"`
 }
 
 // Contact holds statistics of an outbound connector.
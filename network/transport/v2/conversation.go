@@ -43,6 +43,9 @@ type conversationID string
 
 var errIncorrectEnvelopeType = errors.New("checking wrong envelope type")
 
+// errInvalidTransaction is returned when a transaction received from a peer can't be parsed.
+var errInvalidTransaction = errors.New("received transaction is invalid")
+
 func newConversationID() conversationID {
 	return conversationID(uuid.New().String())
 }
@@ -276,7 +279,7 @@ func (envelope Envelope_TransactionList) parseTransactions(data handlerData) ([]
 	for _, transaction := range envelope.TransactionList.Transactions {
 		tx, err := dag.ParseTransaction(transaction.Data)
 		if err != nil {
-			return nil, fmt.Errorf("received transaction is invalid: %w", err)
+			return nil, fmt.Errorf("%w: %w", errInvalidTransaction, err)
 		}
 		transactions = append(transactions, tx)
 	}
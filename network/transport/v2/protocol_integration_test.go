@@ -136,7 +136,7 @@ func startNode(t *testing.T, name string, configurers ...func(config *Config)) *
 		t.Fatal(err)
 	}
 
-	ctx.state, _ = dag.NewState(bboltStore)
+	ctx.state, _ = dag.NewState(bboltStore, nil)
 	ctx.state.Notifier(t.Name(), func(event dag.Event) (bool, error) {
 		log.Logger().Infof("Transaction %s arrived at %s", string(event.Payload), name)
 		ctx.mux.Lock()
@@ -47,15 +47,16 @@ import (
 )
 
 type protocolMocks struct {
-	Controller       *gomock.Controller
-	State            *dag.MockState
-	PayloadScheduler *dag.MockNotifier
-	DIDResolver      *resolver.MockDIDResolver
-	Decrypter        *crypto.MockDecrypter
-	Gossip           *gossip.MockManager
-	ConnectionList   *grpc.MockConnectionList
-	Sender           *MockmessageSender
-	DagStore         *stoabs.MockKVStore
+	Controller        *gomock.Controller
+	State             *dag.MockState
+	PayloadScheduler  *dag.MockNotifier
+	DIDResolver       *resolver.MockDIDResolver
+	Decrypter         *crypto.MockDecrypter
+	Gossip            *gossip.MockManager
+	ConnectionList    *grpc.MockConnectionList
+	Sender            *MockmessageSender
+	DagStore          *stoabs.MockKVStore
+	ConnectionManager *transport.MockConnectionManager
 }
 
 func getMessageTypes() []isEnvelope_Message {
@@ -87,6 +88,7 @@ func newTestProtocol(t *testing.T, nodeDID *did.DID) (*protocol, protocolMocks)
 	connectionList := grpc.NewMockConnectionList(ctrl)
 	sender := NewMockmessageSender(ctrl)
 	storage := stoabs.NewMockKVStore(ctrl)
+	connectionManager := transport.NewMockConnectionManager(ctrl)
 
 	cfg := DefaultConfig()
 	cfg.Datadir = dirname
@@ -95,6 +97,7 @@ func newTestProtocol(t *testing.T, nodeDID *did.DID) (*protocol, protocolMocks)
 	proto.gManager = gMan
 	proto.cMan = newConversationManager(time.Second)
 	proto.connectionList = connectionList
+	proto.connectionManager = connectionManager
 	proto.sender = sender
 	proto.listHandler = newTransactionListHandler(context.Background(), proto.handleTransactionList)
 
@@ -102,15 +105,16 @@ func newTestProtocol(t *testing.T, nodeDID *did.DID) (*protocol, protocolMocks)
 	state.EXPECT().CorrectStateDetected().AnyTimes()
 
 	return proto, protocolMocks{
-		Controller:       ctrl,
-		State:            state,
-		PayloadScheduler: payloadScheduler,
-		DIDResolver:      didResolver,
-		Decrypter:        decrypter,
-		Gossip:           gMan,
-		ConnectionList:   connectionList,
-		Sender:           sender,
-		DagStore:         storage,
+		Controller:        ctrl,
+		State:             state,
+		PayloadScheduler:  payloadScheduler,
+		DIDResolver:       didResolver,
+		Decrypter:         decrypter,
+		Gossip:            gMan,
+		ConnectionList:    connectionList,
+		Sender:            sender,
+		DagStore:          storage,
+		ConnectionManager: connectionManager,
 	}
 }
 
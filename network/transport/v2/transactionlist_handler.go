@@ -85,7 +85,9 @@ func (p *protocol) handleTransactionList(ctx context.Context, connection grpc.Co
 		Tracef("Handling handleTransactionList from peer (message=%d/%d)", msg.MessageNumber, msg.TotalMessages)
 
 	// check if response matches earlier request
+	// (this also parses the transactions)
 	if _, err := p.cMan.check(subEnvelope, data); err != nil {
+		p.penalizeInvalidTransaction(connection, err)
 		return err
 	}
 
@@ -114,6 +116,7 @@ func (p *protocol) handleTransactionList(ctx context.Context, connection grpc.Co
 				xor, clock := p.state.XOR(dag.MaxLamportClock)
 				return p.sender.sendState(connection, xor, clock)
 			}
+			p.penalizeInvalidTransaction(connection, err)
 			return fmt.Errorf("unable to add received transaction to DAG (tx=%s): %w", tx.Ref(), err)
 		}
 	}
@@ -126,3 +129,10 @@ func (p *protocol) handleTransactionList(ctx context.Context, connection grpc.Co
 
 	return nil
 }
+
+// penalizeInvalidTransaction penalizes the peer if the error indicates it sent a transaction that couldn't be parsed or verified.
+func (p *protocol) penalizeInvalidTransaction(connection grpc.Connection, err error) {
+	if errors.Is(err, errInvalidTransaction) || errors.Is(err, dag.ErrTransactionVerificationFailed) {
+		p.connectionManager.PenalizePeer(connection.Peer().ID, err.Error())
+	}
+}
@@ -170,6 +170,18 @@ func TestProtocol_handleTransactionList(t *testing.T) {
 		assert.EqualError(t, err, fmt.Sprintf("unable to add received transaction to DAG (tx=%s): custom", tx.Ref().String()))
 	})
 
+	t.Run("error - verification failed, peer is penalized", func(t *testing.T) {
+		p, mocks := newTestProtocol(t, nil)
+		conversation := p.cMan.startConversation(request, peer)
+		envelope := envelopeWithConversation(conversation)
+		mocks.State.EXPECT().Add(context.Background(), tx, payload).Return(fmt.Errorf("%w: custom", dag.ErrTransactionVerificationFailed))
+		mocks.ConnectionManager.EXPECT().PenalizePeer(connection.Peer().ID, "transaction verification failed: custom")
+
+		err := p.handleTransactionList(context.Background(), connection, envelope)
+
+		assert.ErrorIs(t, err, dag.ErrTransactionVerificationFailed)
+	})
+
 	t.Run("error - missing payload for TX without PAL", func(t *testing.T) {
 		p, _ := newTestProtocol(t, nil)
 		conversation := p.cMan.startConversation(request, peer)
@@ -185,8 +197,9 @@ func TestProtocol_handleTransactionList(t *testing.T) {
 	})
 
 	t.Run("error - invalid transaction", func(t *testing.T) {
-		p, _ := newTestProtocol(t, nil)
+		p, mocks := newTestProtocol(t, nil)
 		conversation := p.cMan.startConversation(request, peer)
+		mocks.ConnectionManager.EXPECT().PenalizePeer(connection.Peer().ID, "received transaction is invalid: unable to parse transaction: invalid compact serialization format: invalid number of segments")
 
 		err := p.handleTransactionList(context.Background(), connection, &Envelope{Message: &Envelope_TransactionList{
 			TransactionList: &TransactionList{
@@ -0,0 +1,98 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+package v1
+
+import (
+	"context"
+	"errors"
+	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/policy"
+	"net/http"
+)
+
+var _ StrictServerInterface = (*Wrapper)(nil)
+var _ core.ErrorStatusCodeResolver = (*Wrapper)(nil)
+
+// Wrapper implements the generated interface from oapi-codegen
+type Wrapper struct {
+	Policy policy.PolicyManager
+}
+
+func (w *Wrapper) ResolveStatusCode(err error) int {
+	switch {
+	case errors.Is(err, policy.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, policy.ErrInvalidMapping):
+		return http.StatusBadRequest
+	case errors.Is(err, policy.ErrNotSupported):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (w *Wrapper) Routes(router core.EchoRouter) {
+	RegisterHandlers(router, NewStrictHandler(w, []StrictMiddlewareFunc{
+		func(f StrictHandlerFunc, operationID string) StrictHandlerFunc {
+			return func(ctx echo.Context, request interface{}) (response interface{}, err error) {
+				ctx.Set(core.OperationIDContextKey, operationID)
+				ctx.Set(core.ModuleNameContextKey, policy.ModuleName)
+				ctx.Set(core.StatusCodeResolverContextKey, w)
+				return f(ctx, request)
+			}
+		},
+		func(f StrictHandlerFunc, operationID string) StrictHandlerFunc {
+			return audit.StrictMiddleware(f, policy.ModuleName, operationID)
+		},
+	}))
+}
+
+func (w *Wrapper) ListScopes(_ context.Context, _ ListScopesRequestObject) (ListScopesResponseObject, error) {
+	scopes, err := w.Policy.Scopes()
+	if err != nil {
+		return nil, err
+	}
+	return ListScopes200JSONResponse(scopes), nil
+}
+
+func (w *Wrapper) GetScope(ctx context.Context, request GetScopeRequestObject) (GetScopeResponseObject, error) {
+	mapping, err := w.Policy.PresentationDefinitions(ctx, request.Scope)
+	if err != nil {
+		return nil, err
+	}
+	return GetScope200JSONResponse(mapping), nil
+}
+
+func (w *Wrapper) PutScope(_ context.Context, request PutScopeRequestObject) (PutScopeResponseObject, error) {
+	if request.Body == nil {
+		return nil, core.InvalidInputError("missing request body")
+	}
+	if err := w.Policy.PutPresentationDefinitions(request.Scope, *request.Body); err != nil {
+		return nil, err
+	}
+	return PutScope204Response{}, nil
+}
+
+func (w *Wrapper) DeleteScope(_ context.Context, request DeleteScopeRequestObject) (DeleteScopeResponseObject, error) {
+	if err := w.Policy.DeletePresentationDefinitions(request.Scope); err != nil {
+		return nil, err
+	}
+	return DeleteScope204Response{}, nil
+}
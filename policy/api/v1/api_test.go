@@ -0,0 +1,142 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/nuts-foundation/nuts-node/policy"
+	"github.com/nuts-foundation/nuts-node/vcr/pe"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var mapping = pe.WalletOwnerMapping{
+	pe.WalletOwnerOrganization: pe.PresentationDefinition{Id: "example"},
+}
+
+func TestWrapper_ListScopes(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		test := newMockContext(t)
+		test.policy.EXPECT().Scopes().Return([]string{"a", "b"}, nil)
+
+		response, err := test.wrapper.ListScopes(context.Background(), ListScopesRequestObject{})
+
+		require.NoError(t, err)
+		assert.Equal(t, ListScopes200JSONResponse{"a", "b"}, response)
+	})
+	t.Run("not supported", func(t *testing.T) {
+		test := newMockContext(t)
+		test.policy.EXPECT().Scopes().Return(nil, policy.ErrNotSupported)
+
+		_, err := test.wrapper.ListScopes(context.Background(), ListScopesRequestObject{})
+
+		assert.ErrorIs(t, err, policy.ErrNotSupported)
+		assert.Equal(t, http.StatusBadRequest, test.wrapper.ResolveStatusCode(err))
+	})
+}
+
+func TestWrapper_GetScope(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		test := newMockContext(t)
+		test.policy.EXPECT().PresentationDefinitions(gomock.Any(), "example-scope").Return(mapping, nil)
+
+		response, err := test.wrapper.GetScope(context.Background(), GetScopeRequestObject{Scope: "example-scope"})
+
+		require.NoError(t, err)
+		assert.Equal(t, GetScope200JSONResponse(mapping), response)
+	})
+	t.Run("not found", func(t *testing.T) {
+		test := newMockContext(t)
+		test.policy.EXPECT().PresentationDefinitions(gomock.Any(), "example-scope").Return(nil, policy.ErrNotFound)
+
+		_, err := test.wrapper.GetScope(context.Background(), GetScopeRequestObject{Scope: "example-scope"})
+
+		assert.ErrorIs(t, err, policy.ErrNotFound)
+		assert.Equal(t, http.StatusNotFound, test.wrapper.ResolveStatusCode(err))
+	})
+}
+
+func TestWrapper_PutScope(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		test := newMockContext(t)
+		test.policy.EXPECT().PutPresentationDefinitions("example-scope", mapping).Return(nil)
+
+		response, err := test.wrapper.PutScope(context.Background(), PutScopeRequestObject{Scope: "example-scope", Body: &mapping})
+
+		require.NoError(t, err)
+		assert.IsType(t, PutScope204Response{}, response)
+	})
+	t.Run("invalid mapping", func(t *testing.T) {
+		test := newMockContext(t)
+		test.policy.EXPECT().PutPresentationDefinitions("example-scope", mapping).Return(errors.Join(policy.ErrInvalidMapping, errors.New("missing input_descriptors")))
+
+		_, err := test.wrapper.PutScope(context.Background(), PutScopeRequestObject{Scope: "example-scope", Body: &mapping})
+
+		assert.ErrorIs(t, err, policy.ErrInvalidMapping)
+		assert.Equal(t, http.StatusBadRequest, test.wrapper.ResolveStatusCode(err))
+	})
+	t.Run("missing body", func(t *testing.T) {
+		test := newMockContext(t)
+
+		_, err := test.wrapper.PutScope(context.Background(), PutScopeRequestObject{Scope: "example-scope"})
+
+		assert.EqualError(t, err, "missing request body")
+	})
+}
+
+func TestWrapper_DeleteScope(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		test := newMockContext(t)
+		test.policy.EXPECT().DeletePresentationDefinitions("example-scope").Return(nil)
+
+		response, err := test.wrapper.DeleteScope(context.Background(), DeleteScopeRequestObject{Scope: "example-scope"})
+
+		require.NoError(t, err)
+		assert.IsType(t, DeleteScope204Response{}, response)
+	})
+	t.Run("not found", func(t *testing.T) {
+		test := newMockContext(t)
+		test.policy.EXPECT().DeletePresentationDefinitions("example-scope").Return(policy.ErrNotFound)
+
+		_, err := test.wrapper.DeleteScope(context.Background(), DeleteScopeRequestObject{Scope: "example-scope"})
+
+		assert.ErrorIs(t, err, policy.ErrNotFound)
+		assert.Equal(t, http.StatusNotFound, test.wrapper.ResolveStatusCode(err))
+	})
+}
+
+type mockContext struct {
+	ctrl    *gomock.Controller
+	policy  *policy.MockPolicyManager
+	wrapper Wrapper
+}
+
+func newMockContext(t *testing.T) mockContext {
+	ctrl := gomock.NewController(t)
+	policyManager := policy.NewMockPolicyManager(ctrl)
+	return mockContext{
+		ctrl:    ctrl,
+		policy:  policyManager,
+		wrapper: Wrapper{Policy: policyManager},
+	}
+}
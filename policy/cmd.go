@@ -27,5 +27,13 @@ func FlagSet() *pflag.FlagSet {
 	defCfg := defaultConfig()
 	flagSet := pflag.NewFlagSet("policy", pflag.ContinueOnError)
 	flagSet.String("policy.directory", defCfg.Directory, "Directory to read policy files from. Policy files are JSON files that contain a scope to PresentationDefinition mapping.")
+	flagSet.Bool("policy.ignoreunknownscopes", defCfg.IgnoreUnknownScopes, "When multiple (space-delimited) scopes are requested, ignore scopes for which no PresentationDefinition mapping exists. "+
+		"If not set, requesting an unknown scope fails the request. Ignored scopes are not granted.")
+	flagSet.String("policy.remote.url", defCfg.Remote.URL, "URL of a remote policy decision point (e.g. an OPA data API endpoint) to fetch the PresentationDefinitions for a scope from. "+
+		"When set, it's used instead of the policy files in policy.directory.")
+	flagSet.Duration("policy.remote.timeout", defCfg.Remote.Timeout, "Timeout for requests to the remote policy decision point.")
+	flagSet.Duration("policy.remote.cachettl", defCfg.Remote.CacheTTL, "Duration responses from the remote policy decision point are cached. Set to 0 to disable caching.")
+	flagSet.Bool("policy.remote.softfail", defCfg.Remote.SoftFail, "When set, a stale cached response is used when the remote policy decision point can't be reached. "+
+		"Otherwise (hard-fail), the request for which the policy is needed fails.")
 	return flagSet
 }
@@ -18,12 +18,39 @@
 
 package policy
 
+import "time"
+
 type Config struct {
 	// Directory is the directory where the policy files are stored
 	// policy files include a scope to presentation definition mapping
 	Directory string `koanf:"directory"`
+	// Remote holds the configuration for a remote policy decision point.
+	// If its URL is set, it's used instead of the policy files in Directory.
+	Remote RemoteConfig `koanf:"remote"`
+	// IgnoreUnknownScopes specifies whether scopes without a PresentationDefinition mapping are ignored when multiple scopes are requested.
+	// If false, requesting an unknown scope fails the request.
+	IgnoreUnknownScopes bool `koanf:"ignoreunknownscopes"`
+}
+
+// RemoteConfig holds the configuration for a remote policy decision point (PDP).
+type RemoteConfig struct {
+	// URL is the endpoint of the remote PDP. The scope is POSTed to it as OPA-style input document.
+	URL string `koanf:"url"`
+	// Timeout is the maximum duration of a request to the remote PDP.
+	Timeout time.Duration `koanf:"timeout"`
+	// CacheTTL specifies how long responses from the remote PDP are cached. Caching is disabled when set to 0.
+	CacheTTL time.Duration `koanf:"cachettl"`
+	// SoftFail specifies whether a stale cached response may be used when the remote PDP can't be reached.
+	// If false (hard-fail), errors of the remote PDP are returned to the caller.
+	SoftFail bool `koanf:"softfail"`
 }
 
 func defaultConfig() Config {
-	return Config{Directory: "./config/policy"}
+	return Config{
+		Directory: "./config/policy",
+		Remote: RemoteConfig{
+			Timeout:  5 * time.Second,
+			CacheTTL: time.Minute,
+		},
+	}
 }
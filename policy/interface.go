@@ -29,10 +29,40 @@ const ModuleName = "policy"
 
 var ErrNotFound = errors.New("not found")
 
+// ErrNotSupported is returned when an operation is not supported by the configured policy backend.
+var ErrNotSupported = errors.New("not supported by the configured policy backend")
+
+// ErrInvalidMapping is returned when a scope to PresentationDefinition mapping is invalid.
+var ErrInvalidMapping = errors.New("invalid PresentationDefinition mapping")
+
 // PDPBackend is the interface for the policy backend
 // Both the remote and local policy backend implement this interface
 type PDPBackend interface {
 	// PresentationDefinitions returns the PresentationDefinitions (mapped to a WalletOwnerType) for the given scope
-	// scopes are space delimited. It's up to the backend to decide how to handle this
+	// scopes are space delimited. The policy module resolves multiple scopes one by one,
+	// and composes the resulting PresentationDefinitions into a single PresentationDefinition per WalletOwnerType.
 	PresentationDefinitions(ctx context.Context, scope string) (pe.WalletOwnerMapping, error)
 }
+
+// ScopeResolver resolves the PresentationDefinitions for multiple (space-delimited) scopes.
+type ScopeResolver interface {
+	PDPBackend
+	// ResolveScopes returns the composed PresentationDefinitions for the given (space-delimited) scopes,
+	// and the scopes they were resolved for (without duplicates, in the requested order).
+	// Unknown scopes are not returned when the policy module is configured to ignore them.
+	ResolveScopes(ctx context.Context, scope string) (pe.WalletOwnerMapping, []string, error)
+}
+
+// PolicyManager manages the scope to PresentationDefinition mappings at runtime.
+type PolicyManager interface {
+	PDPBackend
+	// Scopes returns the scopes for which a PresentationDefinition mapping exists, sorted alphabetically.
+	Scopes() ([]string, error)
+	// PutPresentationDefinitions creates or replaces the PresentationDefinition mapping for the given scope.
+	// The mapping is validated before it's stored. Mappings created this way take precedence over mappings loaded from policy files,
+	// and they're persisted so they're retained when the node restarts.
+	PutPresentationDefinitions(scope string, mapping pe.WalletOwnerMapping) error
+	// DeletePresentationDefinitions deletes the PresentationDefinition mapping that was created for the given scope using PutPresentationDefinitions.
+	// Mappings loaded from policy files can't be deleted. It returns ErrNotFound if no mapping was created for the scope.
+	DeletePresentationDefinitions(scope string) error
+}
@@ -15,94 +15,241 @@
  * along with this program.  If not, see <https://www.gnu.org/licenses/>.
  *
  */
-
 package policy
 
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/fsnotify/fsnotify"
+	"github.com/nuts-foundation/nuts-node/policy/log"
 	"github.com/nuts-foundation/nuts-node/vcr/pe"
 	v2 "github.com/nuts-foundation/nuts-node/vcr/pe/schema/v2"
 	"io"
+	"io/fs"
+	"maps"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 )
 
 var _ PDPBackend = (*LocalPDP)(nil)
+var _ PolicyManager = (*LocalPDP)(nil)
 
-// New creates a new local policy backend
-func New() *LocalPDP {
-	return &LocalPDP{}
-}
+// reloadDelay is the time to wait after a change in the policy directory before reloading it.
+// Editors and deployment tools often write files in multiple steps, this prevents loading partially written files.
+var reloadDelay = 500 * time.Millisecond
 
 // LocalPDP is a backend for presentation definitions
 // It loads a file with the mapping from oauth scope to PEX Policy.
 // It allows access when the requester can present a submission according to the Presentation Definition.
 type LocalPDP struct {
-	config Config
 	// mapping holds the oauth scope to PEX Policy mapping
 	mapping map[string]validatingWalletOwnerMapping
+	// overrides holds the mappings that were uploaded at runtime. They take precedence over mapping.
+	overrides map[string]validatingWalletOwnerMapping
+	// overridesFile is the file the overrides are persisted to. If empty, overrides aren't persisted.
+	overridesFile string
+	mux           sync.RWMutex
+	watcher       *fsnotify.Watcher
+	routines      sync.WaitGroup
+}
+
+func (b *LocalPDP) PresentationDefinitions(_ context.Context, scope string) (pe.WalletOwnerMapping, error) {
+	b.mux.RLock()
+	defer b.mux.RUnlock()
+	result := pe.WalletOwnerMapping{}
+	mapping, exists := b.overrides[scope]
+	if !exists {
+		mapping, exists = b.mapping[scope]
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+	for walletOwnerType, policy := range mapping {
+		result[walletOwnerType] = policy
+	}
+	return result, nil
+}
+
+func (b *LocalPDP) Scopes() ([]string, error) {
+	b.mux.RLock()
+	defer b.mux.RUnlock()
+	result := make([]string, 0, len(b.mapping)+len(b.overrides))
+	for scope := range b.mapping {
+		result = append(result, scope)
+	}
+	for scope := range b.overrides {
+		if _, exists := b.mapping[scope]; !exists {
+			result = append(result, scope)
+		}
+	}
+	slices.Sort(result)
+	return result, nil
+}
+
+func (b *LocalPDP) PutPresentationDefinitions(scope string, mapping pe.WalletOwnerMapping) error {
+	if strings.TrimSpace(scope) == "" {
+		return fmt.Errorf("%w: empty scope", ErrInvalidMapping)
+	}
+	data, err := json.Marshal(mapping)
+	if err != nil {
+		return err
+	}
+	var validated validatingWalletOwnerMapping
+	if err := json.Unmarshal(data, &validated); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidMapping, err)
+	}
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	overrides := maps.Clone(b.overrides)
+	if overrides == nil {
+		overrides = make(map[string]validatingWalletOwnerMapping)
+	}
+	overrides[scope] = validated
+	return b.saveOverrides(overrides)
 }
 
-func (b *LocalPDP) Name() string {
-	return ModuleName
+func (b *LocalPDP) DeletePresentationDefinitions(scope string) error {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	if _, exists := b.overrides[scope]; !exists {
+		return ErrNotFound
+	}
+	overrides := maps.Clone(b.overrides)
+	delete(overrides, scope)
+	return b.saveOverrides(overrides)
 }
 
-func (b *LocalPDP) Configure(_ core.ServerConfig) error {
-	if b.config.Directory != "" {
-		_, err := os.Stat(b.config.Directory)
+// saveOverrides persists the given overrides and replaces the current overrides with them.
+// If persisting fails, the current overrides are retained. The caller must hold the write lock.
+func (b *LocalPDP) saveOverrides(overrides map[string]validatingWalletOwnerMapping) error {
+	if b.overridesFile != "" {
+		data, err := json.Marshal(overrides)
 		if err != nil {
-			if os.IsNotExist(err) && b.config.Directory == defaultConfig().Directory {
-				// assume this is the default config value and remove it
-				b.config.Directory = ""
-			} else {
-				return fmt.Errorf("failed to load policy from directory: %w", err)
-			}
+			return err
+		}
+		if err = writeFileAtomically(b.overridesFile, data); err != nil {
+			return fmt.Errorf("failed to persist policy overrides: %w", err)
 		}
 	}
-	if b.config.Directory != "" {
-		if err := b.loadFromDirectory(b.config.Directory); err != nil {
-			return fmt.Errorf("failed to load policy from directory: %w", err)
+	b.overrides = overrides
+	return nil
+}
+
+// loadOverrides loads the persisted overrides from the given file, and persists future changes to it.
+// A non-existing file is not an error: it's created when a mapping is uploaded.
+func (b *LocalPDP) loadOverrides(filename string) error {
+	overrides := make(map[string]validatingWalletOwnerMapping)
+	data, err := os.ReadFile(filename)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err == nil {
+		if err = json.Unmarshal(data, &overrides); err != nil {
+			return fmt.Errorf("failed to unmarshal policy overrides file %s: %w", filename, err)
 		}
 	}
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	b.overrides = overrides
+	b.overridesFile = filename
+	return nil
+}
 
+// loadFromDirectory traverses all .json files in the given directory and loads them.
+// The loaded mapping replaces the current mapping atomically: if any of the files is invalid, the current mapping is retained.
+func (b *LocalPDP) loadFromDirectory(directory string) error {
+	mapping, err := readDirectory(directory)
+	if err != nil {
+		return err
+	}
+	b.mux.Lock()
+	b.mapping = mapping
+	b.mux.Unlock()
 	return nil
 }
 
-func (b *LocalPDP) Config() interface{} {
-	return &b.config
+// watch starts watching the given directory for changes, and reloads it when a policy file changes.
+func (b *LocalPDP) watch(directory string) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err = watcher.Add(directory); err != nil {
+		_ = watcher.Close()
+		return err
+	}
+	b.watcher = watcher
+	b.routines.Add(1)
+	go func() {
+		defer b.routines.Done()
+		// reload is set when a policy file changed, and fires after reloadDelay (reset on every change)
+		var reload <-chan time.Time
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if !strings.HasSuffix(event.Name, ".json") || event.Op == fsnotify.Chmod {
+					continue
+				}
+				reload = time.After(reloadDelay)
+			case <-reload:
+				reload = nil
+				b.reload(directory)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Logger().WithError(err).Error("Error while watching policy directory")
+			}
+		}
+	}()
+	return nil
 }
 
-func (b *LocalPDP) PresentationDefinitions(_ context.Context, scope string) (pe.WalletOwnerMapping, error) {
-	result := pe.WalletOwnerMapping{}
-	mapping, exists := b.mapping[scope]
-	if !exists {
-		return nil, ErrNotFound
+// stopWatching stops watching the policy directory, if it was being watched.
+func (b *LocalPDP) stopWatching() error {
+	if b.watcher == nil {
+		return nil
 	}
-	for walletOwnerType, policy := range mapping {
-		result[walletOwnerType] = policy
+	err := b.watcher.Close()
+	b.routines.Wait()
+	b.watcher = nil
+	return err
+}
+
+func (b *LocalPDP) reload(directory string) {
+	if err := b.loadFromDirectory(directory); err != nil {
+		log.Logger().WithError(err).Errorf("Failed to reload policy from directory, keeping current policy (directory=%s)", directory)
+		return
 	}
-	return result, nil
+	log.Logger().Infof("Reloaded policy from directory (directory=%s)", directory)
 }
 
-// loadFromDirectory traverses all .json files in the given directory and loads them
-func (b *LocalPDP) loadFromDirectory(directory string) error {
+// readDirectory reads all .json files in the given directory into a new mapping.
+func readDirectory(directory string) (map[string]validatingWalletOwnerMapping, error) {
 	// open the directory
 	dir, err := os.Open(directory)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer dir.Close()
 
 	// read all the files in the directory
 	files, err := dir.Readdir(0)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// load all the files
+	result := make(map[string]validatingWalletOwnerMapping)
 	for _, file := range files {
 		if file.IsDir() {
 			continue
@@ -110,16 +257,16 @@ func (b *LocalPDP) loadFromDirectory(directory string) error {
 		if !strings.HasSuffix(file.Name(), ".json") {
 			continue
 		}
-		err := b.loadFromFile(fmt.Sprintf("%s/%s", directory, file.Name()))
+		err := readFile(fmt.Sprintf("%s/%s", directory, file.Name()), result)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
-	return nil
+	return result, nil
 }
 
-// LoadFromFile loads the mapping from the given file
-func (b *LocalPDP) loadFromFile(filename string) error {
+// readFile reads the mapping from the given file into target.
+func readFile(filename string, target map[string]validatingWalletOwnerMapping) error {
 	// read the bytes from the file
 	reader, err := os.Open(filename)
 	if err != nil {
@@ -137,18 +284,36 @@ func (b *LocalPDP) loadFromFile(filename string) error {
 	if err != nil {
 		return fmt.Errorf("failed to unmarshal PEX Policy mapping file %s: %w", filename, err)
 	}
-	if b.mapping == nil {
-		b.mapping = make(map[string]validatingWalletOwnerMapping)
-	}
 	for scope, defs := range result {
-		if _, exists := b.mapping[scope]; exists {
+		if _, exists := target[scope]; exists {
 			return fmt.Errorf("mapping for scope '%s' already exists (file=%s)", scope, filename)
 		}
-		b.mapping[scope] = defs
+		target[scope] = defs
 	}
 	return nil
 }
 
+// writeFileAtomically writes the data to a temporary file and renames it to the given filename,
+// so the file is never partially written.
+func writeFileAtomically(filename string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(filename), os.ModePerm); err != nil {
+		return err
+	}
+	tmpFile, err := os.CreateTemp(filepath.Dir(filename), filepath.Base(filename)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+	if _, err = tmpFile.Write(data); err != nil {
+		_ = tmpFile.Close()
+		return err
+	}
+	if err = tmpFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile.Name(), filename)
+}
+
 // validatingPresentationDefinition is an alias for PresentationDefinition that validates the JSON on unmarshal.
 type validatingWalletOwnerMapping pe.WalletOwnerMapping
 
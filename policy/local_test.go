@@ -20,40 +20,18 @@ package policy
 
 import (
 	"context"
+	"errors"
+	"os"
+	"path"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/nuts-foundation/nuts-node/vcr/pe"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-func TestStore_LoadFromFile(t *testing.T) {
-	t.Run("loads the mapping from the file", func(t *testing.T) {
-		store := LocalPDP{}
-
-		err := store.loadFromFile("test/definition_mapping.json")
-
-		require.NoError(t, err)
-		assert.Len(t, store.mapping, 1)
-		assert.NotNil(t, store.mapping["example-scope"])
-	})
-
-	t.Run("returns an error if the file doesn't exist", func(t *testing.T) {
-		store := LocalPDP{}
-
-		err := store.loadFromFile("test/doesntexist.json")
-
-		assert.Error(t, err)
-	})
-
-	t.Run("returns an error if a presentation definition is invalid", func(t *testing.T) {
-		store := LocalPDP{}
-
-		err := store.loadFromFile("test/invalid/invalid_definition_mapping.json")
-
-		assert.ErrorContains(t, err, "missing properties: \"input_descriptors\"")
-	})
-}
-
 func TestStore_PresentationDefinitions(t *testing.T) {
 	t.Run("err - not found", func(t *testing.T) {
 		store := LocalPDP{}
@@ -65,7 +43,7 @@ func TestStore_PresentationDefinitions(t *testing.T) {
 
 	t.Run("returns the presentation definition if the scope exists", func(t *testing.T) {
 		store := LocalPDP{}
-		err := store.loadFromFile("test/definition_mapping.json")
+		err := store.loadFromDirectory("test")
 		require.NoError(t, err)
 
 		result, err := store.PresentationDefinitions(context.Background(), "example-scope")
@@ -88,9 +66,27 @@ func Test_LocalPDP_loadFromDirectory(t *testing.T) {
 		err := store.loadFromDirectory("test")
 		require.NoError(t, err)
 
+		assert.Len(t, store.mapping, 1)
 		_, err = store.PresentationDefinitions(context.Background(), "example-scope")
 		require.NoError(t, err)
 	})
+	t.Run("directory doesn't exist", func(t *testing.T) {
+		store := LocalPDP{}
+
+		err := store.loadFromDirectory("test/doesntexist")
+
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+	t.Run("invalid presentation definition retains current mapping", func(t *testing.T) {
+		store := LocalPDP{}
+		require.NoError(t, store.loadFromDirectory("test"))
+
+		err := store.loadFromDirectory("test/invalid")
+
+		assert.ErrorContains(t, err, "missing properties: \"input_descriptors\"")
+		assert.Len(t, store.mapping, 1)
+		assert.NotNil(t, store.mapping["example-scope"])
+	})
 	t.Run("2 files, 3 scopes", func(t *testing.T) {
 		store := LocalPDP{}
 
@@ -112,3 +108,193 @@ func Test_LocalPDP_loadFromDirectory(t *testing.T) {
 		require.EqualError(t, err, "mapping for scope '1' already exists (file=test/2_files_duplicate/2.json)")
 	})
 }
+
+func TestLocalPDP_PutPresentationDefinitions(t *testing.T) {
+	mapping := pe.WalletOwnerMapping{
+		pe.WalletOwnerOrganization: pe.PresentationDefinition{
+			Id:               "example",
+			InputDescriptors: []*pe.InputDescriptor{{Id: "1", Constraints: &pe.Constraints{}}},
+		},
+	}
+	t.Run("new scope", func(t *testing.T) {
+		store := LocalPDP{}
+
+		err := store.PutPresentationDefinitions("new-scope", mapping)
+
+		require.NoError(t, err)
+		result, err := store.PresentationDefinitions(context.Background(), "new-scope")
+		require.NoError(t, err)
+		assert.Equal(t, "example", result[pe.WalletOwnerOrganization].Id)
+	})
+	t.Run("replaces scope loaded from file, retained after reload", func(t *testing.T) {
+		store := LocalPDP{}
+		require.NoError(t, store.loadFromDirectory("test"))
+
+		err := store.PutPresentationDefinitions("example-scope", mapping)
+		require.NoError(t, err)
+		require.NoError(t, store.loadFromDirectory("test"))
+
+		result, err := store.PresentationDefinitions(context.Background(), "example-scope")
+		require.NoError(t, err)
+		assert.Equal(t, "example", result[pe.WalletOwnerOrganization].Id)
+	})
+	t.Run("invalid mapping", func(t *testing.T) {
+		store := LocalPDP{}
+
+		err := store.PutPresentationDefinitions("new-scope", pe.WalletOwnerMapping{
+			pe.WalletOwnerOrganization: pe.PresentationDefinition{Id: "example"},
+		})
+
+		assert.ErrorIs(t, err, ErrInvalidMapping)
+		assert.ErrorContains(t, err, "input_descriptors")
+	})
+	t.Run("empty scope", func(t *testing.T) {
+		store := LocalPDP{}
+
+		err := store.PutPresentationDefinitions(" ", mapping)
+
+		assert.ErrorIs(t, err, ErrInvalidMapping)
+	})
+}
+
+func TestLocalPDP_DeletePresentationDefinitions(t *testing.T) {
+	mapping := pe.WalletOwnerMapping{
+		pe.WalletOwnerOrganization: pe.PresentationDefinition{
+			Id:               "example",
+			InputDescriptors: []*pe.InputDescriptor{{Id: "1", Constraints: &pe.Constraints{}}},
+		},
+	}
+	t.Run("restores scope loaded from file", func(t *testing.T) {
+		store := LocalPDP{}
+		require.NoError(t, store.loadFromDirectory("test"))
+		require.NoError(t, store.PutPresentationDefinitions("example-scope", mapping))
+
+		err := store.DeletePresentationDefinitions("example-scope")
+
+		require.NoError(t, err)
+		result, err := store.PresentationDefinitions(context.Background(), "example-scope")
+		require.NoError(t, err)
+		assert.NotEqual(t, "example", result[pe.WalletOwnerOrganization].Id)
+	})
+	t.Run("scope loaded from file can't be deleted", func(t *testing.T) {
+		store := LocalPDP{}
+		require.NoError(t, store.loadFromDirectory("test"))
+
+		err := store.DeletePresentationDefinitions("example-scope")
+
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestLocalPDP_loadOverrides(t *testing.T) {
+	mapping := pe.WalletOwnerMapping{
+		pe.WalletOwnerOrganization: pe.PresentationDefinition{
+			Id:               "example",
+			InputDescriptors: []*pe.InputDescriptor{{Id: "1", Constraints: &pe.Constraints{}}},
+		},
+	}
+	t.Run("persisted overrides are loaded", func(t *testing.T) {
+		filename := path.Join(t.TempDir(), "policy", "overrides.json")
+		store := LocalPDP{}
+		require.NoError(t, store.loadOverrides(filename))
+		require.NoError(t, store.PutPresentationDefinitions("new-scope", mapping))
+		require.NoError(t, store.PutPresentationDefinitions("deleted-scope", mapping))
+		require.NoError(t, store.DeletePresentationDefinitions("deleted-scope"))
+
+		restarted := LocalPDP{}
+		err := restarted.loadOverrides(filename)
+
+		require.NoError(t, err)
+		scopes, _ := restarted.Scopes()
+		assert.Equal(t, []string{"new-scope"}, scopes)
+	})
+	t.Run("file does not exist", func(t *testing.T) {
+		store := LocalPDP{}
+
+		err := store.loadOverrides(path.Join(t.TempDir(), "overrides.json"))
+
+		require.NoError(t, err)
+		scopes, _ := store.Scopes()
+		assert.Empty(t, scopes)
+	})
+	t.Run("invalid file", func(t *testing.T) {
+		filename := path.Join(t.TempDir(), "overrides.json")
+		require.NoError(t, os.WriteFile(filename, []byte(`{"scope": {"organization": {"id": "example"}}}`), 0644))
+		store := LocalPDP{}
+
+		err := store.loadOverrides(filename)
+
+		assert.ErrorContains(t, err, "failed to unmarshal policy overrides file")
+	})
+	t.Run("persisting fails", func(t *testing.T) {
+		dir := t.TempDir()
+		// the parent directory of the overrides file is a file, so the overrides can't be written
+		require.NoError(t, os.WriteFile(path.Join(dir, "policy"), []byte{}, 0644))
+		store := LocalPDP{overridesFile: path.Join(dir, "policy", "overrides.json")}
+
+		err := store.PutPresentationDefinitions("new-scope", mapping)
+
+		assert.ErrorContains(t, err, "failed to persist policy overrides")
+		scopes, _ := store.Scopes()
+		assert.Empty(t, scopes)
+	})
+}
+
+func TestLocalPDP_Scopes(t *testing.T) {
+	store := LocalPDP{}
+	require.NoError(t, store.loadFromDirectory("test/2_files"))
+	require.NoError(t, store.PutPresentationDefinitions("0", pe.WalletOwnerMapping{
+		pe.WalletOwnerOrganization: pe.PresentationDefinition{Id: "example", InputDescriptors: []*pe.InputDescriptor{{Id: "1", Constraints: &pe.Constraints{}}}},
+	}))
+
+	scopes, err := store.Scopes()
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0", "1", "2", "3"}, scopes)
+}
+
+func TestLocalPDP_watch(t *testing.T) {
+	reloadDelay = 10 * time.Millisecond
+	defer func() {
+		reloadDelay = 500 * time.Millisecond
+	}()
+	definitionMapping, err := os.ReadFile("test/definition_mapping.json")
+	require.NoError(t, err)
+	directory := t.TempDir()
+	require.NoError(t, os.WriteFile(path.Join(directory, "1.json"), definitionMapping, 0644))
+	store := LocalPDP{}
+	require.NoError(t, store.loadFromDirectory(directory))
+	require.NoError(t, store.watch(directory))
+	defer func() {
+		assert.NoError(t, store.stopWatching())
+	}()
+
+	t.Run("file added", func(t *testing.T) {
+		data := strings.ReplaceAll(string(definitionMapping), "example-scope", "other-scope")
+		require.NoError(t, os.WriteFile(path.Join(directory, "2.json"), []byte(data), 0644))
+
+		assert.Eventually(t, func() bool {
+			_, err := store.PresentationDefinitions(context.Background(), "other-scope")
+			return err == nil
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+	t.Run("invalid file retains current mapping", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path.Join(directory, "3.json"), definitionMapping, 0644))
+		// duplicate scope, wait for reload to be attempted
+		time.Sleep(100 * time.Millisecond)
+
+		_, err := store.PresentationDefinitions(context.Background(), "example-scope")
+		assert.NoError(t, err)
+		_, err = store.PresentationDefinitions(context.Background(), "other-scope")
+		assert.NoError(t, err)
+	})
+	t.Run("file removed", func(t *testing.T) {
+		require.NoError(t, os.Remove(path.Join(directory, "3.json")))
+		require.NoError(t, os.Remove(path.Join(directory, "2.json")))
+
+		assert.Eventually(t, func() bool {
+			_, err := store.PresentationDefinitions(context.Background(), "other-scope")
+			return errors.Is(err, ErrNotFound)
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+}
@@ -55,3 +55,140 @@ func (mr *MockPDPBackendMockRecorder) PresentationDefinitions(ctx, scope any) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresentationDefinitions", reflect.TypeOf((*MockPDPBackend)(nil).PresentationDefinitions), ctx, scope)
 }
+
+// MockScopeResolver is a mock of ScopeResolver interface.
+type MockScopeResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockScopeResolverMockRecorder
+	isgomock struct{}
+}
+
+// MockScopeResolverMockRecorder is the mock recorder for MockScopeResolver.
+type MockScopeResolverMockRecorder struct {
+	mock *MockScopeResolver
+}
+
+// NewMockScopeResolver creates a new mock instance.
+func NewMockScopeResolver(ctrl *gomock.Controller) *MockScopeResolver {
+	mock := &MockScopeResolver{ctrl: ctrl}
+	mock.recorder = &MockScopeResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScopeResolver) EXPECT() *MockScopeResolverMockRecorder {
+	return m.recorder
+}
+
+// PresentationDefinitions mocks base method.
+func (m *MockScopeResolver) PresentationDefinitions(ctx context.Context, scope string) (pe.WalletOwnerMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PresentationDefinitions", ctx, scope)
+	ret0, _ := ret[0].(pe.WalletOwnerMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PresentationDefinitions indicates an expected call of PresentationDefinitions.
+func (mr *MockScopeResolverMockRecorder) PresentationDefinitions(ctx, scope any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresentationDefinitions", reflect.TypeOf((*MockScopeResolver)(nil).PresentationDefinitions), ctx, scope)
+}
+
+// ResolveScopes mocks base method.
+func (m *MockScopeResolver) ResolveScopes(ctx context.Context, scope string) (pe.WalletOwnerMapping, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveScopes", ctx, scope)
+	ret0, _ := ret[0].(pe.WalletOwnerMapping)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ResolveScopes indicates an expected call of ResolveScopes.
+func (mr *MockScopeResolverMockRecorder) ResolveScopes(ctx, scope any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveScopes", reflect.TypeOf((*MockScopeResolver)(nil).ResolveScopes), ctx, scope)
+}
+
+// MockPolicyManager is a mock of PolicyManager interface.
+type MockPolicyManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockPolicyManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockPolicyManagerMockRecorder is the mock recorder for MockPolicyManager.
+type MockPolicyManagerMockRecorder struct {
+	mock *MockPolicyManager
+}
+
+// NewMockPolicyManager creates a new mock instance.
+func NewMockPolicyManager(ctrl *gomock.Controller) *MockPolicyManager {
+	mock := &MockPolicyManager{ctrl: ctrl}
+	mock.recorder = &MockPolicyManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPolicyManager) EXPECT() *MockPolicyManagerMockRecorder {
+	return m.recorder
+}
+
+// DeletePresentationDefinitions mocks base method.
+func (m *MockPolicyManager) DeletePresentationDefinitions(scope string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePresentationDefinitions", scope)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePresentationDefinitions indicates an expected call of DeletePresentationDefinitions.
+func (mr *MockPolicyManagerMockRecorder) DeletePresentationDefinitions(scope any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePresentationDefinitions", reflect.TypeOf((*MockPolicyManager)(nil).DeletePresentationDefinitions), scope)
+}
+
+// PresentationDefinitions mocks base method.
+func (m *MockPolicyManager) PresentationDefinitions(ctx context.Context, scope string) (pe.WalletOwnerMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PresentationDefinitions", ctx, scope)
+	ret0, _ := ret[0].(pe.WalletOwnerMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PresentationDefinitions indicates an expected call of PresentationDefinitions.
+func (mr *MockPolicyManagerMockRecorder) PresentationDefinitions(ctx, scope any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresentationDefinitions", reflect.TypeOf((*MockPolicyManager)(nil).PresentationDefinitions), ctx, scope)
+}
+
+// PutPresentationDefinitions mocks base method.
+func (m *MockPolicyManager) PutPresentationDefinitions(scope string, mapping pe.WalletOwnerMapping) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutPresentationDefinitions", scope, mapping)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutPresentationDefinitions indicates an expected call of PutPresentationDefinitions.
+func (mr *MockPolicyManagerMockRecorder) PutPresentationDefinitions(scope, mapping any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutPresentationDefinitions", reflect.TypeOf((*MockPolicyManager)(nil).PutPresentationDefinitions), scope, mapping)
+}
+
+// Scopes mocks base method.
+func (m *MockPolicyManager) Scopes() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Scopes")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Scopes indicates an expected call of Scopes.
+func (mr *MockPolicyManagerMockRecorder) Scopes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scopes", reflect.TypeOf((*MockPolicyManager)(nil).Scopes))
+}
@@ -0,0 +1,140 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+package policy
+
+import (
+	"context"
+	"fmt"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/vcr/pe"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+)
+
+var _ PDPBackend = (*Module)(nil)
+var _ core.Named = (*Module)(nil)
+var _ core.Configurable = (*Module)(nil)
+var _ core.Injectable = (*Module)(nil)
+var _ core.Runnable = (*Module)(nil)
+var _ PolicyManager = (*Module)(nil)
+var _ ScopeResolver = (*Module)(nil)
+
+// New creates a new policy module.
+// Depending on the configuration, it delegates to a local (file-based) or remote policy backend.
+func New() *Module {
+	return &Module{
+		config: defaultConfig(),
+	}
+}
+
+// Module is the policy engine. It resolves the PresentationDefinitions for a scope using the configured PDPBackend.
+type Module struct {
+	config  Config
+	backend PDPBackend
+}
+
+func (m *Module) Name() string {
+	return ModuleName
+}
+
+func (m *Module) Configure(config core.ServerConfig) error {
+	if m.config.Remote.URL != "" {
+		if _, err := url.ParseRequestURI(m.config.Remote.URL); err != nil {
+			return fmt.Errorf("invalid policy.remote.url: %w", err)
+		}
+		m.backend = NewRemotePDP(m.config.Remote, &http.Client{Timeout: m.config.Remote.Timeout})
+		return nil
+	}
+	local := &LocalPDP{}
+	if m.config.Directory != "" {
+		_, err := os.Stat(m.config.Directory)
+		if err != nil {
+			if os.IsNotExist(err) && m.config.Directory == defaultConfig().Directory {
+				// assume this is the default config value and remove it
+				m.config.Directory = ""
+			} else {
+				return fmt.Errorf("failed to load policy from directory: %w", err)
+			}
+		}
+	}
+	if m.config.Directory != "" {
+		if err := local.loadFromDirectory(m.config.Directory); err != nil {
+			return fmt.Errorf("failed to load policy from directory: %w", err)
+		}
+	}
+	if err := local.loadOverrides(path.Join(config.Datadir, "policy", "overrides.json")); err != nil {
+		return fmt.Errorf("failed to load policy overrides: %w", err)
+	}
+	m.backend = local
+	return nil
+}
+
+func (m *Module) Start() error {
+	if local, ok := m.backend.(*LocalPDP); ok && m.config.Directory != "" {
+		if err := local.watch(m.config.Directory); err != nil {
+			return fmt.Errorf("failed to watch policy directory: %w", err)
+		}
+	}
+	return nil
+}
+
+func (m *Module) Shutdown() error {
+	if local, ok := m.backend.(*LocalPDP); ok {
+		return local.stopWatching()
+	}
+	return nil
+}
+
+func (m *Module) Config() interface{} {
+	return &m.config
+}
+
+func (m *Module) PresentationDefinitions(ctx context.Context, scope string) (pe.WalletOwnerMapping, error) {
+	mapping, _, err := presentationDefinitionsForScopes(ctx, m.backend, scope, m.config.IgnoreUnknownScopes)
+	return mapping, err
+}
+
+func (m *Module) ResolveScopes(ctx context.Context, scope string) (pe.WalletOwnerMapping, []string, error) {
+	return presentationDefinitionsForScopes(ctx, m.backend, scope, m.config.IgnoreUnknownScopes)
+}
+
+func (m *Module) Scopes() ([]string, error) {
+	manager, ok := m.backend.(PolicyManager)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+	return manager.Scopes()
+}
+
+func (m *Module) PutPresentationDefinitions(scope string, mapping pe.WalletOwnerMapping) error {
+	manager, ok := m.backend.(PolicyManager)
+	if !ok {
+		return ErrNotSupported
+	}
+	return manager.PutPresentationDefinitions(scope, mapping)
+}
+
+func (m *Module) DeletePresentationDefinitions(scope string) error {
+	manager, ok := m.backend.(PolicyManager)
+	if !ok {
+		return ErrNotSupported
+	}
+	return manager.DeletePresentationDefinitions(scope)
+}
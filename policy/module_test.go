@@ -0,0 +1,138 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/vcr/pe"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModule_Configure(t *testing.T) {
+	t.Run("local", func(t *testing.T) {
+		module := New()
+		module.config.Directory = "test/2_files"
+
+		err := module.Configure(core.ServerConfig{})
+
+		require.NoError(t, err)
+		require.IsType(t, &LocalPDP{}, module.backend)
+		_, err = module.PresentationDefinitions(context.Background(), "1")
+		assert.NoError(t, err)
+	})
+	t.Run("local, default directory does not exist", func(t *testing.T) {
+		module := New()
+
+		err := module.Configure(core.ServerConfig{})
+
+		require.NoError(t, err)
+		assert.Empty(t, module.config.Directory)
+		_, err = module.PresentationDefinitions(context.Background(), "1")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+	t.Run("local, directory does not exist", func(t *testing.T) {
+		module := New()
+		module.config.Directory = "test/non-existing"
+
+		err := module.Configure(core.ServerConfig{})
+
+		assert.ErrorContains(t, err, "failed to load policy from directory")
+	})
+	t.Run("local, duplicate scope", func(t *testing.T) {
+		module := New()
+		module.config.Directory = "test/2_files_duplicate"
+
+		err := module.Configure(core.ServerConfig{})
+
+		assert.ErrorContains(t, err, "mapping for scope '1' already exists")
+	})
+	t.Run("local, overrides are loaded from data directory", func(t *testing.T) {
+		datadir := t.TempDir()
+		module := New()
+		module.config.Directory = "test/2_files"
+		require.NoError(t, module.Configure(core.ServerConfig{Datadir: datadir}))
+		require.NoError(t, module.PutPresentationDefinitions("new-scope", pe.WalletOwnerMapping{
+			pe.WalletOwnerOrganization: pe.PresentationDefinition{Id: "example", InputDescriptors: []*pe.InputDescriptor{{Id: "1", Constraints: &pe.Constraints{}}}},
+		}))
+
+		restarted := New()
+		restarted.config.Directory = "test/2_files"
+		err := restarted.Configure(core.ServerConfig{Datadir: datadir})
+
+		require.NoError(t, err)
+		_, err = restarted.PresentationDefinitions(context.Background(), "new-scope")
+		assert.NoError(t, err)
+	})
+	t.Run("remote", func(t *testing.T) {
+		module := New()
+		module.config.Directory = "test/2_files"
+		module.config.Remote.URL = "http://localhost:8181/v1/data/nuts/policy"
+
+		err := module.Configure(core.ServerConfig{})
+
+		require.NoError(t, err)
+		assert.IsType(t, &RemotePDP{}, module.backend)
+	})
+	t.Run("remote, invalid URL", func(t *testing.T) {
+		module := New()
+		module.config.Remote.URL = "::"
+
+		err := module.Configure(core.ServerConfig{})
+
+		assert.ErrorContains(t, err, "invalid policy.remote.url")
+	})
+}
+
+func TestModule_StartShutdown(t *testing.T) {
+	module := New()
+	module.config.Directory = "test/2_files"
+	require.NoError(t, module.Configure(core.ServerConfig{}))
+
+	require.NoError(t, module.Start())
+	assert.NotNil(t, module.backend.(*LocalPDP).watcher)
+	require.NoError(t, module.Shutdown())
+	assert.Nil(t, module.backend.(*LocalPDP).watcher)
+}
+
+func TestModule_Scopes(t *testing.T) {
+	t.Run("local", func(t *testing.T) {
+		module := New()
+		module.config.Directory = "test/2_files"
+		require.NoError(t, module.Configure(core.ServerConfig{}))
+
+		scopes, err := module.Scopes()
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1", "2", "3"}, scopes)
+	})
+	t.Run("remote", func(t *testing.T) {
+		module := New()
+		module.config.Remote.URL = "http://localhost:8181/v1/data/nuts/policy"
+		require.NoError(t, module.Configure(core.ServerConfig{}))
+
+		_, err := module.Scopes()
+
+		assert.ErrorIs(t, err, ErrNotSupported)
+		assert.ErrorIs(t, module.PutPresentationDefinitions("1", nil), ErrNotSupported)
+		assert.ErrorIs(t, module.DeletePresentationDefinitions("1"), ErrNotSupported)
+	})
+}
@@ -0,0 +1,197 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+package policy
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/http/client"
+	"github.com/nuts-foundation/nuts-node/policy/log"
+	"github.com/nuts-foundation/nuts-node/vcr/pe"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var _ PDPBackend = (*RemotePDP)(nil)
+
+// maxRemotePDPCacheEntries limits the number of scopes cached by the RemotePDP.
+// Scopes are client-controlled, so the least recently used entry is evicted when the cache is full.
+const maxRemotePDPCacheEntries = 1000
+
+// NewRemotePDP creates a new policy backend that fetches the PresentationDefinitions from a remote policy decision point.
+func NewRemotePDP(config RemoteConfig, httpClient core.HTTPRequestDoer) *RemotePDP {
+	return &RemotePDP{
+		config:     config,
+		httpClient: httpClient,
+		cache:      newRemotePDPCache(maxRemotePDPCacheEntries),
+		now:        time.Now,
+	}
+}
+
+// RemotePDP is a backend for presentation definitions that calls a remote policy decision point over HTTP.
+// It follows the contract of the OPA data API: the scope is POSTed as input document ({"input":{"scope":"..."}}),
+// and the PDP responds with the WalletOwnerMapping as result ({"result":{...}}).
+// An absent result (undefined decision in OPA) means the scope is not supported.
+type RemotePDP struct {
+	config     RemoteConfig
+	httpClient core.HTTPRequestDoer
+	cache      *remotePDPCache
+	now        func() time.Time
+}
+
+type remotePDPCacheEntry struct {
+	scope string
+	// mapping is nil if the remote PDP reported the scope as not found
+	mapping  pe.WalletOwnerMapping
+	cachedAt time.Time
+}
+
+// remotePDPCache is a size-limited LRU cache of remote PDP results, keyed by scope.
+type remotePDPCache struct {
+	maxEntries int
+	// entries is ordered from most to least recently used
+	entries *list.List
+	index   map[string]*list.Element
+	mux     sync.Mutex
+}
+
+func newRemotePDPCache(maxEntries int) *remotePDPCache {
+	return &remotePDPCache{
+		maxEntries: maxEntries,
+		entries:    list.New(),
+		index:      make(map[string]*list.Element),
+	}
+}
+
+func (c *remotePDPCache) get(scope string) (remotePDPCacheEntry, bool) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	element, ok := c.index[scope]
+	if !ok {
+		return remotePDPCacheEntry{}, false
+	}
+	c.entries.MoveToFront(element)
+	return element.Value.(remotePDPCacheEntry), true
+}
+
+func (c *remotePDPCache) put(entry remotePDPCacheEntry) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if element, ok := c.index[entry.scope]; ok {
+		element.Value = entry
+		c.entries.MoveToFront(element)
+		return
+	}
+	c.index[entry.scope] = c.entries.PushFront(entry)
+	for c.entries.Len() > c.maxEntries {
+		oldest := c.entries.Back()
+		c.entries.Remove(oldest)
+		delete(c.index, oldest.Value.(remotePDPCacheEntry).scope)
+	}
+}
+
+func (c *remotePDPCache) len() int {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.entries.Len()
+}
+
+type remotePDPRequest struct {
+	Input remotePDPInput `json:"input"`
+}
+
+type remotePDPInput struct {
+	Scope string `json:"scope"`
+}
+
+type remotePDPResponse struct {
+	Result *validatingWalletOwnerMapping `json:"result,omitempty"`
+}
+
+func (r *RemotePDP) PresentationDefinitions(ctx context.Context, scope string) (pe.WalletOwnerMapping, error) {
+	entry, cached := r.cache.get(scope)
+	if cached && r.now().Sub(entry.cachedAt) < r.config.CacheTTL {
+		return entry.toResult()
+	}
+
+	mapping, err := r.fetch(ctx, scope)
+	if err != nil {
+		if r.config.SoftFail && cached {
+			log.Logger().WithError(err).Warnf("Remote policy decision point failed, using stale cached policy (scope=%s)", scope)
+			return entry.toResult()
+		}
+		return nil, err
+	}
+	entry = remotePDPCacheEntry{scope: scope, mapping: mapping, cachedAt: r.now()}
+	if r.config.CacheTTL > 0 {
+		r.cache.put(entry)
+	}
+	return entry.toResult()
+}
+
+// fetch requests the WalletOwnerMapping for the given scope from the remote PDP.
+// It returns nil (and no error) if the PDP doesn't have a mapping for the scope.
+func (r *RemotePDP) fetch(ctx context.Context, scope string) (pe.WalletOwnerMapping, error) {
+	requestBody, _ := json.Marshal(remotePDPRequest{Input: remotePDPInput{Scope: scope}})
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, r.config.URL, bytes.NewReader(requestBody))
+	if err != nil {
+		return nil, err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set("Accept", "application/json")
+	httpResponse, err := r.httpClient.Do(httpRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to invoke remote policy decision point (url=%s): %w", r.config.URL, err)
+	}
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err := core.TestResponseCodeWithLog(http.StatusOK, httpResponse, log.Logger()); err != nil {
+		return nil, fmt.Errorf("non-OK response from remote policy decision point (url=%s): %w", r.config.URL, err)
+	}
+	responseData, err := io.ReadAll(io.LimitReader(httpResponse.Body, client.DefaultMaxHttpResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response from remote policy decision point (url=%s): %w", r.config.URL, err)
+	}
+	var result remotePDPResponse
+	if err := json.Unmarshal(responseData, &result); err != nil {
+		return nil, fmt.Errorf("invalid response from remote policy decision point (url=%s): %w", r.config.URL, err)
+	}
+	if result.Result == nil {
+		return nil, nil
+	}
+	return pe.WalletOwnerMapping(*result.Result), nil
+}
+
+func (e remotePDPCacheEntry) toResult() (pe.WalletOwnerMapping, error) {
+	if e.mapping == nil {
+		return nil, ErrNotFound
+	}
+	result := pe.WalletOwnerMapping{}
+	for walletOwnerType, policy := range e.mapping {
+		result[walletOwnerType] = policy
+	}
+	return result, nil
+}
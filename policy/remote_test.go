@@ -0,0 +1,200 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	testHTTP "github.com/nuts-foundation/nuts-node/test/http"
+	"github.com/nuts-foundation/nuts-node/vcr/pe"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemotePDP_PresentationDefinitions(t *testing.T) {
+	mappingData, err := os.ReadFile("test/definition_mapping.json")
+	require.NoError(t, err)
+	var mapping map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(mappingData, &mapping))
+	okResponse := `{"result":` + string(mapping["example-scope"]) + `}`
+	config := RemoteConfig{Timeout: time.Second, CacheTTL: time.Minute}
+
+	t.Run("ok", func(t *testing.T) {
+		handler := &testHTTP.Handler{StatusCode: http.StatusOK, ResponseData: okResponse}
+		server := httptest.NewServer(handler)
+		config := config
+		config.URL = server.URL
+		pdp := NewRemotePDP(config, server.Client())
+
+		result, err := pdp.PresentationDefinitions(context.Background(), "example-scope")
+
+		require.NoError(t, err)
+		require.Contains(t, result, pe.WalletOwnerOrganization)
+		assert.Equal(t, "pd_any_care_organization", result[pe.WalletOwnerOrganization].Id)
+		assert.Equal(t, http.MethodPost, handler.Request.Method)
+		assert.JSONEq(t, `{"input":{"scope":"example-scope"}}`, string(handler.RequestData))
+	})
+	t.Run("undefined result", func(t *testing.T) {
+		server := httptest.NewServer(&testHTTP.Handler{StatusCode: http.StatusOK, ResponseData: `{}`})
+		config := config
+		config.URL = server.URL
+		pdp := NewRemotePDP(config, server.Client())
+
+		_, err := pdp.PresentationDefinitions(context.Background(), "example-scope")
+
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+	t.Run("404", func(t *testing.T) {
+		server := httptest.NewServer(&testHTTP.Handler{StatusCode: http.StatusNotFound})
+		config := config
+		config.URL = server.URL
+		pdp := NewRemotePDP(config, server.Client())
+
+		_, err := pdp.PresentationDefinitions(context.Background(), "example-scope")
+
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+	t.Run("invalid mapping", func(t *testing.T) {
+		server := httptest.NewServer(&testHTTP.Handler{StatusCode: http.StatusOK, ResponseData: `{"result":{"organization":{"id":"1"}}}`})
+		config := config
+		config.URL = server.URL
+		pdp := NewRemotePDP(config, server.Client())
+
+		_, err := pdp.PresentationDefinitions(context.Background(), "example-scope")
+
+		assert.ErrorContains(t, err, "invalid response from remote policy decision point")
+		assert.ErrorContains(t, err, "missing properties: \"input_descriptors\"")
+	})
+	t.Run("server error", func(t *testing.T) {
+		server := httptest.NewServer(&testHTTP.Handler{StatusCode: http.StatusInternalServerError})
+		config := config
+		config.URL = server.URL
+		pdp := NewRemotePDP(config, server.Client())
+
+		_, err := pdp.PresentationDefinitions(context.Background(), "example-scope")
+
+		assert.ErrorContains(t, err, "non-OK response from remote policy decision point")
+	})
+	t.Run("caching", func(t *testing.T) {
+		handler := &testHTTP.Handler{StatusCode: http.StatusOK, ResponseData: okResponse}
+		server := httptest.NewServer(handler)
+		config := config
+		config.URL = server.URL
+		pdp := NewRemotePDP(config, server.Client())
+		now := time.Now()
+		pdp.now = func() time.Time { return now }
+
+		_, err := pdp.PresentationDefinitions(context.Background(), "example-scope")
+		require.NoError(t, err)
+		handler.StatusCode = http.StatusInternalServerError
+
+		t.Run("cached", func(t *testing.T) {
+			_, err := pdp.PresentationDefinitions(context.Background(), "example-scope")
+
+			assert.NoError(t, err)
+		})
+		t.Run("expired (hard-fail)", func(t *testing.T) {
+			pdp.now = func() time.Time { return now.Add(2 * time.Minute) }
+
+			_, err := pdp.PresentationDefinitions(context.Background(), "example-scope")
+
+			assert.Error(t, err)
+		})
+		t.Run("expired (soft-fail)", func(t *testing.T) {
+			pdp.now = func() time.Time { return now.Add(2 * time.Minute) }
+			pdp.config.SoftFail = true
+
+			result, err := pdp.PresentationDefinitions(context.Background(), "example-scope")
+
+			assert.NoError(t, err)
+			assert.Contains(t, result, pe.WalletOwnerOrganization)
+		})
+	})
+	t.Run("no caching when TTL is 0", func(t *testing.T) {
+		handler := &testHTTP.Handler{StatusCode: http.StatusOK, ResponseData: okResponse}
+		server := httptest.NewServer(handler)
+		config := config
+		config.URL = server.URL
+		config.CacheTTL = 0
+		config.SoftFail = true
+		pdp := NewRemotePDP(config, server.Client())
+
+		_, err := pdp.PresentationDefinitions(context.Background(), "example-scope")
+		require.NoError(t, err)
+		handler.StatusCode = http.StatusInternalServerError
+		_, err = pdp.PresentationDefinitions(context.Background(), "example-scope")
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, pdp.cache.len())
+	})
+	t.Run("soft-fail without cached entry", func(t *testing.T) {
+		config := config
+		config.URL = "http://localhost"
+		config.SoftFail = true
+		pdp := NewRemotePDP(config, &stubHTTPClient{err: errors.New("connection refused")})
+
+		_, err := pdp.PresentationDefinitions(context.Background(), "example-scope")
+
+		assert.ErrorContains(t, err, "connection refused")
+	})
+}
+
+func TestRemotePDPCache(t *testing.T) {
+	t.Run("evicts least recently used entry", func(t *testing.T) {
+		cache := newRemotePDPCache(2)
+		cache.put(remotePDPCacheEntry{scope: "a"})
+		cache.put(remotePDPCacheEntry{scope: "b"})
+		_, _ = cache.get("a")
+
+		cache.put(remotePDPCacheEntry{scope: "c"})
+
+		assert.Equal(t, 2, cache.len())
+		_, ok := cache.get("b")
+		assert.False(t, ok)
+		_, ok = cache.get("a")
+		assert.True(t, ok)
+		_, ok = cache.get("c")
+		assert.True(t, ok)
+	})
+	t.Run("update existing entry", func(t *testing.T) {
+		cache := newRemotePDPCache(2)
+		cache.put(remotePDPCacheEntry{scope: "a"})
+		cache.put(remotePDPCacheEntry{scope: "a", mapping: pe.WalletOwnerMapping{}})
+
+		entry, ok := cache.get("a")
+
+		assert.True(t, ok)
+		assert.NotNil(t, entry.mapping)
+		assert.Equal(t, 1, cache.len())
+	})
+}
+
+type stubHTTPClient struct {
+	err error
+}
+
+func (s stubHTTPClient) Do(_ *http.Request) (*http.Response, error) {
+	return nil, s.err
+}
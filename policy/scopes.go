@@ -0,0 +1,197 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/nuts-foundation/nuts-node/vcr/pe"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// presentationDefinitionsForScopes resolves the PresentationDefinitions for each of the given (space-delimited) scopes,
+// and composes them into a single WalletOwnerMapping.
+// If ignoreUnknown is true, scopes the backend has no mapping for are skipped. Otherwise, ErrNotFound is returned.
+// If none of the scopes is known, ErrNotFound is returned.
+// It also returns the scopes that were resolved, without duplicates and in the requested order.
+func presentationDefinitionsForScopes(ctx context.Context, backend PDPBackend, scope string, ignoreUnknown bool) (pe.WalletOwnerMapping, []string, error) {
+	var scopes []string
+	for _, curr := range strings.Fields(scope) {
+		if !slices.Contains(scopes, curr) {
+			scopes = append(scopes, curr)
+		}
+	}
+	if len(scopes) <= 1 {
+		mapping, err := backend.PresentationDefinitions(ctx, strings.Join(scopes, ""))
+		if err != nil {
+			return nil, nil, err
+		}
+		return mapping, scopes, nil
+	}
+	var resolvedScopes []string
+	var mappings []pe.WalletOwnerMapping
+	for _, curr := range scopes {
+		mapping, err := backend.PresentationDefinitions(ctx, curr)
+		if errors.Is(err, ErrNotFound) {
+			if ignoreUnknown {
+				continue
+			}
+			return nil, nil, fmt.Errorf("%w: scope '%s'", ErrNotFound, curr)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		resolvedScopes = append(resolvedScopes, curr)
+		mappings = append(mappings, mapping)
+	}
+	if len(mappings) == 0 {
+		return nil, nil, ErrNotFound
+	}
+	result, err := composeMappings(resolvedScopes, mappings)
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, resolvedScopes, nil
+}
+
+// composeMappings merges the WalletOwnerMappings of multiple scopes into one.
+// Per wallet owner type, the PresentationDefinitions of the scopes are combined so that a submission must satisfy all of them.
+func composeMappings(scopes []string, mappings []pe.WalletOwnerMapping) (pe.WalletOwnerMapping, error) {
+	if len(mappings) == 1 {
+		return mappings[0], nil
+	}
+	var walletOwnerTypes []pe.WalletOwnerType
+	for _, mapping := range mappings {
+		for walletOwnerType := range mapping {
+			if !slices.Contains(walletOwnerTypes, walletOwnerType) {
+				walletOwnerTypes = append(walletOwnerTypes, walletOwnerType)
+			}
+		}
+	}
+	result := pe.WalletOwnerMapping{}
+	for _, walletOwnerType := range walletOwnerTypes {
+		var definitionScopes []string
+		var definitions []pe.PresentationDefinition
+		for i, mapping := range mappings {
+			if definition, ok := mapping[walletOwnerType]; ok {
+				definitionScopes = append(definitionScopes, scopes[i])
+				definitions = append(definitions, definition)
+			}
+		}
+		if len(definitions) == 1 {
+			result[walletOwnerType] = definitions[0]
+			continue
+		}
+		definition, err := composePresentationDefinitions(definitionScopes, definitions)
+		if err != nil {
+			return nil, fmt.Errorf("unable to compose PresentationDefinitions for scopes '%s' (wallet owner type: %s): %w", strings.Join(definitionScopes, " "), walletOwnerType, err)
+		}
+		result[walletOwnerType] = *definition
+	}
+	return result, nil
+}
+
+// composePresentationDefinitions combines the given PresentationDefinitions into one, which requires all of them to be fulfilled:
+//   - the ID is the concatenation of the IDs of the PresentationDefinitions,
+//   - input descriptors are concatenated (identical input descriptors are included once, conflicting ones cause an error),
+//   - the format of a PresentationDefinition is moved to its input descriptors, unless they specify their own format,
+//   - if any of the PresentationDefinitions has submission requirements, the groups of each PresentationDefinition are prefixed with its scope to prevent collisions.
+//     PresentationDefinitions without submission requirements get a submission requirement that requires all of their input descriptors.
+func composePresentationDefinitions(scopes []string, definitions []pe.PresentationDefinition) (*pe.PresentationDefinition, error) {
+	var ids []string
+	useSubmissionRequirements := false
+	for _, definition := range definitions {
+		ids = append(ids, definition.Id)
+		if len(definition.SubmissionRequirements) > 0 {
+			useSubmissionRequirements = true
+		}
+	}
+	result := pe.PresentationDefinition{
+		Id: strings.Join(ids, " "),
+	}
+	inputDescriptors := map[string]*pe.InputDescriptor{}
+	for i, definition := range definitions {
+		scope := scopes[i]
+		for _, curr := range definition.InputDescriptors {
+			inputDescriptor := *curr
+			if inputDescriptor.Format == nil {
+				inputDescriptor.Format = definition.Format
+			}
+			inputDescriptor.Group = nil
+			if useSubmissionRequirements {
+				if len(definition.SubmissionRequirements) == 0 {
+					inputDescriptor.Group = []string{scope}
+				} else {
+					for _, group := range curr.Group {
+						inputDescriptor.Group = append(inputDescriptor.Group, scopedGroup(scope, group))
+					}
+				}
+			}
+			existing, exists := inputDescriptors[inputDescriptor.Id]
+			if !exists {
+				inputDescriptors[inputDescriptor.Id] = &inputDescriptor
+				result.InputDescriptors = append(result.InputDescriptors, &inputDescriptor)
+				continue
+			}
+			if !equalInputDescriptors(*existing, inputDescriptor) {
+				return nil, fmt.Errorf("conflicting input descriptors with id '%s'", inputDescriptor.Id)
+			}
+			existing.Group = append(existing.Group, inputDescriptor.Group...)
+		}
+		if !useSubmissionRequirements {
+			continue
+		}
+		if len(definition.SubmissionRequirements) == 0 {
+			result.SubmissionRequirements = append(result.SubmissionRequirements, &pe.SubmissionRequirement{
+				Name: scope,
+				Rule: "all",
+				From: scope,
+			})
+		}
+		for _, submissionRequirement := range definition.SubmissionRequirements {
+			result.SubmissionRequirements = append(result.SubmissionRequirements, scopedSubmissionRequirement(scope, *submissionRequirement))
+		}
+	}
+	return &result, nil
+}
+
+// equalInputDescriptors compares the given input descriptors, ignoring their groups.
+func equalInputDescriptors(a pe.InputDescriptor, b pe.InputDescriptor) bool {
+	a.Group = nil
+	b.Group = nil
+	return reflect.DeepEqual(a, b)
+}
+
+func scopedSubmissionRequirement(scope string, submissionRequirement pe.SubmissionRequirement) *pe.SubmissionRequirement {
+	if submissionRequirement.From != "" {
+		submissionRequirement.From = scopedGroup(scope, submissionRequirement.From)
+	}
+	var fromNested []*pe.SubmissionRequirement
+	for _, nested := range submissionRequirement.FromNested {
+		fromNested = append(fromNested, scopedSubmissionRequirement(scope, *nested))
+	}
+	submissionRequirement.FromNested = fromNested
+	return &submissionRequirement
+}
+
+func scopedGroup(scope string, group string) string {
+	return scope + ":" + group
+}
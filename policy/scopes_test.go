@@ -0,0 +1,161 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nuts-foundation/nuts-node/vcr/pe"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_presentationDefinitionsForScopes(t *testing.T) {
+	backend := &LocalPDP{}
+	require.NoError(t, backend.loadFromDirectory("test/2_files"))
+	ctx := context.Background()
+
+	t.Run("single scope", func(t *testing.T) {
+		result, scopes, err := presentationDefinitionsForScopes(ctx, backend, "1", false)
+
+		require.NoError(t, err)
+		assert.Equal(t, "pd_any_care_organization", result[pe.WalletOwnerOrganization].Id)
+		assert.Equal(t, []string{"1"}, scopes)
+	})
+	t.Run("multiple scopes", func(t *testing.T) {
+		result, _, err := presentationDefinitionsForScopes(ctx, backend, "1 3", false)
+
+		require.NoError(t, err)
+		definition := result[pe.WalletOwnerOrganization]
+		assert.Equal(t, "pd_any_care_organization pd_any_care_organization", definition.Id)
+		// both scopes require the same input descriptor
+		assert.Len(t, definition.InputDescriptors, 1)
+	})
+	t.Run("duplicate scope", func(t *testing.T) {
+		result, scopes, err := presentationDefinitionsForScopes(ctx, backend, "1 1", false)
+
+		require.NoError(t, err)
+		assert.Equal(t, "pd_any_care_organization", result[pe.WalletOwnerOrganization].Id)
+		assert.Equal(t, []string{"1"}, scopes)
+	})
+	t.Run("duplicate scope, multiple scopes", func(t *testing.T) {
+		result, scopes, err := presentationDefinitionsForScopes(ctx, backend, "1 3 1", false)
+
+		require.NoError(t, err)
+		assert.Equal(t, "pd_any_care_organization pd_any_care_organization", result[pe.WalletOwnerOrganization].Id)
+		assert.Equal(t, []string{"1", "3"}, scopes)
+	})
+	t.Run("unknown scope is rejected", func(t *testing.T) {
+		_, _, err := presentationDefinitionsForScopes(ctx, backend, "1 unknown", false)
+
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.ErrorContains(t, err, "scope 'unknown'")
+	})
+	t.Run("unknown scope is ignored", func(t *testing.T) {
+		result, scopes, err := presentationDefinitionsForScopes(ctx, backend, "1 unknown", true)
+
+		require.NoError(t, err)
+		assert.Equal(t, "pd_any_care_organization", result[pe.WalletOwnerOrganization].Id)
+		assert.Equal(t, []string{"1"}, scopes)
+	})
+	t.Run("all scopes unknown", func(t *testing.T) {
+		_, _, err := presentationDefinitionsForScopes(ctx, backend, "unknown1 unknown2", true)
+
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func Test_composeMappings(t *testing.T) {
+	ldpFormat := &pe.PresentationDefinitionClaimFormatDesignations{"ldp_vc": {"proof_type": {"JsonWebSignature2020"}}}
+	jwtFormat := &pe.PresentationDefinitionClaimFormatDesignations{"jwt_vc": {"alg": {"ES256"}}}
+	descriptor := func(id string, groups ...string) *pe.InputDescriptor {
+		return &pe.InputDescriptor{Id: id, Group: groups, Constraints: &pe.Constraints{}}
+	}
+	t.Run("wallet owner types", func(t *testing.T) {
+		result, err := composeMappings([]string{"a", "b"}, []pe.WalletOwnerMapping{
+			{pe.WalletOwnerOrganization: {Id: "a"}},
+			{pe.WalletOwnerOrganization: {Id: "b"}, pe.WalletOwnerUser: {Id: "b-user"}},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "a b", result[pe.WalletOwnerOrganization].Id)
+		assert.Equal(t, "b-user", result[pe.WalletOwnerUser].Id)
+	})
+	t.Run("format is moved to input descriptors", func(t *testing.T) {
+		withOwnFormat := descriptor("3")
+		withOwnFormat.Format = ldpFormat
+		result, err := composeMappings([]string{"a", "b"}, []pe.WalletOwnerMapping{
+			{pe.WalletOwnerOrganization: {Id: "a", Format: ldpFormat, InputDescriptors: []*pe.InputDescriptor{descriptor("1")}}},
+			{pe.WalletOwnerOrganization: {Id: "b", Format: jwtFormat, InputDescriptors: []*pe.InputDescriptor{descriptor("2"), withOwnFormat}}},
+		})
+
+		require.NoError(t, err)
+		definition := result[pe.WalletOwnerOrganization]
+		assert.Nil(t, definition.Format)
+		require.Len(t, definition.InputDescriptors, 3)
+		assert.Equal(t, ldpFormat, definition.InputDescriptors[0].Format)
+		assert.Equal(t, jwtFormat, definition.InputDescriptors[1].Format)
+		assert.Equal(t, ldpFormat, definition.InputDescriptors[2].Format)
+		assert.Empty(t, definition.SubmissionRequirements)
+	})
+	t.Run("identical input descriptors are deduplicated", func(t *testing.T) {
+		result, err := composeMappings([]string{"a", "b"}, []pe.WalletOwnerMapping{
+			{pe.WalletOwnerOrganization: {Id: "a", InputDescriptors: []*pe.InputDescriptor{descriptor("1")}}},
+			{pe.WalletOwnerOrganization: {Id: "b", InputDescriptors: []*pe.InputDescriptor{descriptor("1"), descriptor("2")}}},
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, result[pe.WalletOwnerOrganization].InputDescriptors, 2)
+	})
+	t.Run("conflicting input descriptors", func(t *testing.T) {
+		conflicting := descriptor("1")
+		conflicting.Purpose = "other"
+		_, err := composeMappings([]string{"a", "b"}, []pe.WalletOwnerMapping{
+			{pe.WalletOwnerOrganization: {Id: "a", InputDescriptors: []*pe.InputDescriptor{descriptor("1")}}},
+			{pe.WalletOwnerOrganization: {Id: "b", InputDescriptors: []*pe.InputDescriptor{conflicting}}},
+		})
+
+		assert.EqualError(t, err, "unable to compose PresentationDefinitions for scopes 'a b' (wallet owner type: organization): conflicting input descriptors with id '1'")
+	})
+	t.Run("submission requirements", func(t *testing.T) {
+		count := 1
+		result, err := composeMappings([]string{"a", "b"}, []pe.WalletOwnerMapping{
+			{pe.WalletOwnerOrganization: {Id: "a", InputDescriptors: []*pe.InputDescriptor{descriptor("1"), descriptor("2")}}},
+			{pe.WalletOwnerOrganization: {
+				Id:               "b",
+				InputDescriptors: []*pe.InputDescriptor{descriptor("3", "A"), descriptor("4", "A")},
+				SubmissionRequirements: []*pe.SubmissionRequirement{
+					{Rule: "pick", Count: &count, FromNested: []*pe.SubmissionRequirement{{Rule: "all", From: "A"}}},
+				},
+			}},
+		})
+
+		require.NoError(t, err)
+		definition := result[pe.WalletOwnerOrganization]
+		require.Len(t, definition.InputDescriptors, 4)
+		assert.Equal(t, []string{"a"}, definition.InputDescriptors[0].Group)
+		assert.Equal(t, []string{"a"}, definition.InputDescriptors[1].Group)
+		assert.Equal(t, []string{"b:A"}, definition.InputDescriptors[2].Group)
+		assert.Equal(t, []string{"b:A"}, definition.InputDescriptors[3].Group)
+		require.Len(t, definition.SubmissionRequirements, 2)
+		assert.Equal(t, pe.SubmissionRequirement{Name: "a", Rule: "all", From: "a"}, *definition.SubmissionRequirements[0])
+		assert.Equal(t, "pick", definition.SubmissionRequirements[1].Rule)
+		assert.Equal(t, "b:A", definition.SubmissionRequirements[1].FromNested[0].From)
+	})
+}
@@ -26,6 +26,8 @@ import (
 	"github.com/nuts-foundation/go-stoabs"
 	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/storage/log"
+	"gorm.io/gorm"
+	"os"
 )
 
 // KVBackedLeiaStore is a wrapper interface for a leia.Store that uses a stoabs.KVStore as backup for any documents added.
@@ -272,3 +274,78 @@ func storePresent(collection leia.Collection, config LeiaBackupConfiguration) bo
 
 	return issuedPresent
 }
+
+// IterateLeiaDocuments calls fn for every document of a collection in a (legacy) Leia store, e.g. to migrate it to SQL.
+// Documents are read from the backup store if it contains the collection's backup shelf,
+// otherwise from the Leia store at dbPath. The Leia store is only opened if it exists.
+// The SearchQuery of the config must resolve to a non-nil value for every document in the collection.
+func IterateLeiaDocuments(dbPath string, backup stoabs.KVStore, config LeiaBackupConfiguration, fn func(doc leia.Document) error) error {
+	kvStore := &kvBackedLeiaStore{backup: backup}
+	if kvStore.backupStorePresent(config.BackupShelf) {
+		return backup.ReadShelf(context.Background(), config.BackupShelf, func(reader stoabs.Reader) error {
+			return reader.Iterate(func(_ stoabs.Key, value []byte) error {
+				return fn(value)
+			}, stoabs.BytesKey{})
+		})
+	}
+	if _, err := os.Stat(dbPath); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	store, err := leia.NewStore(dbPath)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	query := leia.New(leia.NotNil(config.SearchQuery))
+	return store.Collection(config.CollectionType, config.CollectionName).Iterate(query, func(_ leia.Reference, value []byte) error {
+		return fn(value)
+	})
+}
+
+// leiaMigrationRecord records that a (legacy) Leia collection was migrated to SQL.
+type leiaMigrationRecord struct {
+	Name string `gorm:"primaryKey"`
+}
+
+// TableName returns the table name for this DTO.
+func (l leiaMigrationRecord) TableName() string {
+	return "leia_migration"
+}
+
+// MigrateLeiaCollection migrates the documents of a collection in a (legacy) Leia store (see IterateLeiaDocuments) to SQL,
+// by calling migrate for every document. Documents that can't be migrated (migrate returns an error) are skipped.
+// The migration is performed in a single transaction, which also records that the collection was migrated under the given name,
+// so it's only migrated once.
+func MigrateLeiaCollection(db *gorm.DB, name string, dbPath string, backup stoabs.KVStore, config LeiaBackupConfiguration, migrate func(tx *gorm.DB, doc leia.Document) error) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&leiaMigrationRecord{}).Where("name = ?", name).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+		migrated, skipped := 0, 0
+		err := IterateLeiaDocuments(dbPath, backup, config, func(doc leia.Document) error {
+			// use a savepoint, so a failed document doesn't abort the whole transaction (e.g. on Postgres)
+			if err := tx.Transaction(func(docTx *gorm.DB) error {
+				return migrate(docTx, doc)
+			}); err != nil {
+				log.Logger().
+					WithError(err).
+					Warnf("Unable to migrate document from Leia to SQL, skipping (collection=%s)", name)
+				skipped++
+				return nil
+			}
+			migrated++
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to migrate %s to SQL: %w", name, err)
+		}
+		if migrated > 0 || skipped > 0 {
+			log.Logger().Infof("Migrated %d documents from Leia to SQL, skipped %d (collection=%s)", migrated, skipped, name)
+		}
+		return tx.Create(&leiaMigrationRecord{Name: name}).Error
+	})
+}
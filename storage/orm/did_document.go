@@ -107,7 +107,7 @@ func (sqlDoc DidDocument) GenerateDIDDocument() (did.Document, error) {
 }
 
 // MigrationDocument is used to convert a did.Document + metadata to a DidDocument.
-// DEPRECATED: only intended to migrate owned did:nuts to SQL storage.
+// It's used to migrate owned did:nuts to SQL storage, and to import subjects exported by another node.
 type MigrationDocument struct {
 	// Raw contains the did.Document in bytes. For did:nuts this is must be equal to the payload in the network transaction.
 	Raw     []byte
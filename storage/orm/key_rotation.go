@@ -0,0 +1,39 @@
+/*
+ * Nuts node
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package orm
+
+import "gorm.io/gorm/schema"
+
+// KeyRotation is the gorm representation of the did_key_rotation table.
+// It tracks verification methods that were replaced by a key rotation and are to be removed once the grace period has passed.
+type KeyRotation struct {
+	VerificationMethodID string `gorm:"primaryKey;column:verification_method_id"`
+	DID                  string `gorm:"column:did"`
+	ReplacedBy           string `gorm:"column:replaced_by"`
+	// RemoveAt is the (unix) timestamp after which the verification method is removed from the DID document.
+	RemoveAt int64 `gorm:"column:remove_at"`
+	// NotifiedAt is the (unix) timestamp at which the key rotation listener was notified of the applied rotation, or 0 if not yet.
+	NotifiedAt int64 `gorm:"column:notified_at"`
+}
+
+func (k KeyRotation) TableName() string {
+	return "did_key_rotation"
+}
+
+var _ schema.Tabler = (*KeyRotation)(nil)
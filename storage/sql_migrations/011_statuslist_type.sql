@@ -0,0 +1,9 @@
+-- +goose Up
+-- status_list, status_list_credential: add status_type column.
+-- status_type is the credentialSubject.type of the status list credential: StatusList2021 or BitstringStatusList.
+alter table status_list add status_type varchar(50) NOT NULL DEFAULT 'StatusList2021';
+alter table status_list_credential add status_type varchar(50) NOT NULL DEFAULT 'StatusList2021';
+
+-- +goose Down
+alter table status_list drop column status_type;
+alter table status_list_credential drop column status_type;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- status_list: add status_purpose column, so an issuer can have separate status lists for 'revocation' and 'suspension'.
+alter table status_list add status_purpose varchar(25) NOT NULL DEFAULT 'revocation';
+
+-- +goose Down
+alter table status_list drop column status_purpose;
@@ -0,0 +1,37 @@
+-- +goose ENVSUB ON
+-- +goose Up
+-- audit_event: tamper-evident audit trail. Each event contains the hash of the previous event, forming a hash chain.
+create table audit_event
+(
+    -- sequence_number: position of the event in the hash chain, starting at 1.
+    sequence_number integer         not null    primary key,
+    -- created_at: seconds since Unix Epoch when the event occurred.
+    created_at      integer         not null,
+    -- actor: the user or service that performed the operation.
+    actor           varchar(500)    not null,
+    -- operation: the name of the operation that was performed.
+    operation       varchar(200)    not null,
+    -- event_type: the type of the event (e.g. SignJWT).
+    event_type      varchar(100)    not null,
+    -- module: the module that logged the event.
+    module          varchar(100)    not null,
+    -- key_id: ID of the key involved in the event, empty if none.
+    key_id          varchar(500)    not null,
+    -- subject: subject (e.g. DID) of the event, empty if none.
+    subject         varchar(500)    not null,
+    message         $TEXT_TYPE      not null,
+    -- previous_hash: hex encoded HMAC-SHA256 of the previous event, empty for the first event.
+    previous_hash   varchar(64)     not null,
+    -- hash: hex encoded HMAC-SHA256 over this event and previous_hash, with a key that is not stored in the database.
+    hash            varchar(64)     not null
+);
+
+create index idx_audit_event_actor on audit_event (actor);
+create index idx_audit_event_type on audit_event (event_type);
+create index idx_audit_event_created_at on audit_event (created_at);
+
+-- +goose Down
+drop index idx_audit_event_actor;
+drop index idx_audit_event_type;
+drop index idx_audit_event_created_at;
+drop table audit_event;
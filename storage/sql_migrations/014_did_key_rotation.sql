@@ -0,0 +1,22 @@
+-- +goose Up
+-- this table is used to track verification methods that were replaced by a key rotation.
+-- rotated verification methods remain in the DID document until the grace period has passed, so signatures made with them can still be verified.
+create table did_key_rotation
+(
+    -- verification_method_id references the rotated verification method
+    verification_method_id varchar(415) not null primary key,
+    -- did references the DID of the DID document the verification method is part of
+    did varchar(370) not null,
+    -- replaced_by is the ID of the verification method that replaced the rotated verification method
+    replaced_by varchar(415) not null,
+    -- remove_at is the (unix) timestamp after which the verification method is removed from the DID document and its private key is deleted
+    remove_at integer not null,
+    -- notified_at is the (unix) timestamp at which the key rotation was processed (e.g. credentials re-signed) after the DID document update was applied, 0 if not yet
+    notified_at integer not null default 0,
+    foreign key (did) references did (id) on delete cascade
+);
+
+create index did_key_rotation_remove_at_idx on did_key_rotation (remove_at);
+
+-- +goose Down
+drop table did_key_rotation;
@@ -0,0 +1,16 @@
+-- +goose ENVSUB ON
+-- +goose Up
+-- discovery_service_definition contains the Discovery Service definitions that were added at runtime (through the API),
+-- as opposed to the definitions loaded from the definitions directory.
+create table discovery_service_definition
+(
+    -- id is the ID of the Discovery Service.
+    id         varchar(200) not null primary key,
+    -- definition contains the JSON-encoded service definition.
+    definition $TEXT_TYPE   not null,
+    -- server indicates whether the node acts as Discovery Server for the service.
+    server     SMALLINT     NOT NULL DEFAULT 0
+);
+
+-- +goose Down
+drop table discovery_service_definition;
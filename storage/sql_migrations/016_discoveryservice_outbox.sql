@@ -0,0 +1,19 @@
+-- +goose ENVSUB ON
+-- +goose Up
+-- discovery_presentation_outbox contains presentations that were registered on a replica of a Discovery Service,
+-- but couldn't be forwarded to the leader yet because it was unavailable.
+create table discovery_presentation_outbox
+(
+    -- service_id is the ID of the Discovery Service the presentation was registered on.
+    service_id              varchar(200) not null,
+    -- presentation_id is the ID of the presentation (VerifiablePresentation.ID).
+    presentation_id         varchar(415) not null,
+    presentation_raw        $TEXT_TYPE   not null,
+    -- presentation_expiration is the (unix) timestamp after which the presentation is not forwarded anymore.
+    presentation_expiration integer      not null,
+    primary key (service_id, presentation_id),
+    constraint fk_discovery_presentation_outbox_service_id foreign key (service_id) references discovery_service (id) on delete cascade
+);
+
+-- +goose Down
+drop table discovery_presentation_outbox;
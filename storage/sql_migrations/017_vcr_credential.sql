@@ -0,0 +1,47 @@
+-- +goose ENVSUB ON
+-- +goose Up
+-- vcr_credential contains the public credentials (e.g. NutsOrganizationCredential) received through the Nuts network.
+create table vcr_credential
+(
+    id varchar(415) not null primary key,
+    constraint fk_vcr_credential foreign key (id) references credential (id)
+);
+
+-- vcr_credential_term contains the values of the credentials in vcr_credential, indexed on their JSON-LD IRI path.
+-- It is used to search for credentials using JSON-LD search terms.
+create table vcr_credential_term
+(
+    credential_id varchar(415) not null,
+    -- iri_path contains the expanded IRIs of the path to the value, separated by a space.
+    iri_path      varchar(255) not null,
+    -- value contains the (tokenized and/or transformed) value.
+    value         varchar(500) not null,
+    -- cascading delete: if the credential gets deleted, the terms get deleted as well
+    constraint fk_vcr_credential_term_credential_id foreign key (credential_id) references vcr_credential (id) on delete cascade
+);
+create index idx_vcr_credential_term_credential on vcr_credential_term (credential_id);
+create index idx_vcr_credential_term_value on vcr_credential_term (iri_path, value);
+
+-- credential_revocation contains the revocations of did:nuts credentials received through the Nuts network.
+create table credential_revocation
+(
+    -- id is the hex-encoded SHA-256 hash of the raw revocation, which makes storing a revocation idempotent.
+    id         varchar(64)  not null primary key,
+    -- subject_id is the ID of the revoked credential.
+    subject_id varchar(415) not null,
+    issuer     varchar(370) not null,
+    raw        $TEXT_TYPE   not null
+);
+create index idx_credential_revocation_subject on credential_revocation (subject_id);
+
+-- leia_migration records the (legacy) Leia collections that were migrated to SQL, so they're only migrated once.
+create table leia_migration
+(
+    name varchar(100) not null primary key
+);
+
+-- +goose Down
+drop table leia_migration;
+drop table credential_revocation;
+drop table vcr_credential_term;
+drop table vcr_credential;
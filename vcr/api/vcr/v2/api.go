@@ -38,6 +38,8 @@ import (
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
 	"github.com/nuts-foundation/nuts-node/vcr/holder"
 	"github.com/nuts-foundation/nuts-node/vcr/issuer"
+	"github.com/nuts-foundation/nuts-node/vcr/revocation"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"github.com/nuts-foundation/nuts-node/vcr/signature/proof"
 	vcrTypes "github.com/nuts-foundation/nuts-node/vcr/types"
 	"github.com/nuts-foundation/nuts-node/vcr/verifier"
@@ -82,6 +84,8 @@ func (w *Wrapper) ResolveStatusCode(err error) int {
 		vcrTypes.ErrNotFound:          http.StatusNotFound,
 		resolver.ErrServiceNotFound:   http.StatusPreconditionFailed,
 		vcrTypes.ErrRevoked:           http.StatusConflict,
+		vcrTypes.ErrSuspended:         http.StatusConflict,
+		vcrTypes.ErrNotSuspended:      http.StatusConflict,
 		resolver.ErrNotFound:          http.StatusBadRequest,
 		resolver.ErrKeyNotFound:       http.StatusBadRequest,
 		did.ErrInvalidDID:             http.StatusBadRequest,
@@ -152,11 +156,25 @@ func parseCredentialOptions(request IssueVCRequestObject) (*issuer.CredentialOpt
 	if request.Body.Format != nil {
 		options.Format = string(*request.Body.Format)
 	}
+	if request.Body.SelectivelyDisclosable != nil {
+		if options.Format != sdjwt.Format {
+			return nil, core.InvalidInputError("option 'selectivelyDisclosable' is only allowed for credential format: %s", sdjwt.Format)
+		}
+		options.SelectivelyDisclosable = *request.Body.SelectivelyDisclosable
+	}
+	if request.Body.CredentialSchema != nil {
+		if options.Format != vc.JWTCredentialProofFormat {
+			return nil, core.InvalidInputError("option 'credentialSchema' is only allowed for credential format: %s", vc.JWTCredentialProofFormat)
+		}
+		for _, schema := range *request.Body.CredentialSchema {
+			options.CredentialSchema = append(options.CredentialSchema, credential.CredentialSchema{ID: schema.Id, Type: string(schema.Type)})
+		}
+	}
 
 	// Valid CredentialOptions:
-	// All: Format
+	// All: Format, SelectivelyDisclosable (vc+sd-jwt only), CredentialSchema (jwt_vc only)
 	// did:nuts: PublishToNetwork, Visibility
-	// did:web: WithStatusList2021Revocation
+	// did:web: WithStatusList2021Revocation, WithStatusListSuspension, StatusListType
 	switch issuerDID.Method {
 	case "nuts":
 		options.Publish = true
@@ -187,11 +205,23 @@ func parseCredentialOptions(request IssueVCRequestObject) (*issuer.CredentialOpt
 		if request.Body.WithStatusList2021Revocation != nil {
 			return nil, core.InvalidInputError("illegal option 'withStatusList2021Revocation' requested for issuer's DID method: %s", issuerDID.Method)
 		}
+		if request.Body.WithStatusListSuspension != nil {
+			return nil, core.InvalidInputError("illegal option 'withStatusListSuspension' requested for issuer's DID method: %s", issuerDID.Method)
+		}
+		if request.Body.StatusListType != nil {
+			return nil, core.InvalidInputError("illegal option 'statusListType' requested for issuer's DID method: %s", issuerDID.Method)
+		}
 	case "web":
 		// check if statusList2021Entry should be added
 		if request.Body.WithStatusList2021Revocation != nil {
 			options.WithStatusListRevocation = *request.Body.WithStatusList2021Revocation
 		}
+		if request.Body.WithStatusListSuspension != nil {
+			options.WithStatusListSuspension = *request.Body.WithStatusListSuspension
+		}
+		if request.Body.StatusListType != nil {
+			options.StatusListType = revocation.StatusListType(*request.Body.StatusListType)
+		}
 		// non expiring credential MUST set a value for withStatusList2021Revocation
 		if request.Body.ExpirationDate == nil && request.Body.WithStatusList2021Revocation == nil {
 			return nil, core.InvalidInputError("withStatusList2021Revocation MUST be provided for credentials without expirationDate")
@@ -230,6 +260,32 @@ func (w Wrapper) RevokeVC(ctx context.Context, request RevokeVCRequestObject) (R
 	return RevokeVC204Response{}, nil
 }
 
+// SuspendVC handles the API request for suspending a credential.
+func (w Wrapper) SuspendVC(ctx context.Context, request SuspendVCRequestObject) (SuspendVCResponseObject, error) {
+	credentialID, err := ssi.ParseURI(request.Id)
+	if err != nil {
+		return nil, core.InvalidInputError("invalid credential id: %w", err)
+	}
+
+	if err = w.VCR.Issuer().Suspend(ctx, *credentialID); err != nil {
+		return nil, err
+	}
+	return SuspendVC204Response{}, nil
+}
+
+// UnsuspendVC handles the API request for lifting the suspension of a credential.
+func (w Wrapper) UnsuspendVC(ctx context.Context, request UnsuspendVCRequestObject) (UnsuspendVCResponseObject, error) {
+	credentialID, err := ssi.ParseURI(request.Id)
+	if err != nil {
+		return nil, core.InvalidInputError("invalid credential id: %w", err)
+	}
+
+	if err = w.VCR.Issuer().Unsuspend(ctx, *credentialID); err != nil {
+		return nil, err
+	}
+	return UnsuspendVC204Response{}, nil
+}
+
 // SearchIssuedVCs handles the API request for searching for issued VCs
 func (w *Wrapper) SearchIssuedVCs(ctx context.Context, request SearchIssuedVCsRequestObject) (SearchIssuedVCsResponseObject, error) {
 	issuerDID, err := did.ParseDID(request.Params.Issuer)
@@ -257,7 +313,7 @@ func (w *Wrapper) SearchIssuedVCs(ctx context.Context, request SearchIssuedVCsRe
 	if err != nil {
 		return nil, err
 	}
-	return SearchIssuedVCs200JSONResponse(SearchVCResults{result}), nil
+	return SearchIssuedVCs200JSONResponse(SearchVCResults{VerifiableCredentials: result}), nil
 }
 
 // VerifyVC handles API request to verify a  Verifiable Credential.
@@ -275,13 +331,31 @@ func (w *Wrapper) VerifyVC(ctx context.Context, request VerifyVCRequestObject) (
 		}
 	}
 
-	if err := w.VCR.Verifier().Verify(requestedVC, allowUntrustedIssuer, true, nil); err != nil {
+	report := w.VCR.Verifier().VerifyWithReport(ctx, requestedVC, allowUntrustedIssuer, true, nil)
+	checks := toVerificationChecks(report)
+	if err := report.Err(); err != nil {
 		errMsg := err.Error()
 
-		return VerifyVC200JSONResponse(VCVerificationResult{Validity: false, Message: &errMsg}), nil
+		return VerifyVC200JSONResponse(VCVerificationResult{Validity: false, Message: &errMsg, Checks: &checks}), nil
 	}
 
-	return VerifyVC200JSONResponse(VCVerificationResult{Validity: true}), nil
+	return VerifyVC200JSONResponse(VCVerificationResult{Validity: true, Checks: &checks}), nil
+}
+
+func toVerificationChecks(report verifier.VerificationReport) []VCVerificationCheck {
+	checks := make([]VCVerificationCheck, 0, len(report.Checks))
+	for _, result := range report.Checks {
+		check := VCVerificationCheck{
+			Check:  string(result.Check),
+			Status: VCVerificationCheckStatus(result.Status),
+		}
+		if result.Reason != "" {
+			reason := result.Reason
+			check.Reason = &reason
+		}
+		checks = append(checks, check)
+	}
+	return checks
 }
 
 // CreateVP handles API request to create a Verifiable Presentation for one or more Verifiable Credentials.
@@ -384,16 +458,31 @@ func (w *Wrapper) VerifyVP(ctx context.Context, request VerifyVPRequestObject) (
 		allowUntrustedIssuers = false
 	}
 
-	verifiedCredentials, err := w.VCR.Verifier().VerifyVP(request.Body.VerifiablePresentation, verifyCredentials, allowUntrustedIssuers, validAt)
-	if err != nil {
+	report := w.VCR.Verifier().VerifyVPWithReport(ctx, request.Body.VerifiablePresentation, verifyCredentials, allowUntrustedIssuers, validAt)
+	checks := toVerificationChecks(report.VerificationReport)
+	var credentialChecks *[]VPCredentialVerificationResult
+	if verifyCredentials {
+		results := make([]VPCredentialVerificationResult, 0, len(report.Credentials))
+		for _, credentialReport := range report.Credentials {
+			result := VPCredentialVerificationResult{Checks: toVerificationChecks(credentialReport.VerificationReport)}
+			if credentialReport.ID != nil {
+				id := credentialReport.ID.String()
+				result.Id = &id
+			}
+			results = append(results, result)
+		}
+		credentialChecks = &results
+	}
+	if err := report.Err(); err != nil {
 		if errors.Is(err, verifier.VerificationError{}) {
 			msg := err.Error()
-			return VerifyVP200JSONResponse(VPVerificationResult{Validity: false, Message: &msg}), nil
+			return VerifyVP200JSONResponse(VPVerificationResult{Validity: false, Message: &msg, Checks: &checks, CredentialChecks: credentialChecks}), nil
 		}
 		return nil, err
 	}
 
-	result := VPVerificationResult{Validity: true, Credentials: &verifiedCredentials}
+	verifiedCredentials := request.Body.VerifiablePresentation.VerifiableCredential
+	result := VPVerificationResult{Validity: true, Credentials: &verifiedCredentials, Checks: &checks, CredentialChecks: credentialChecks}
 	return VerifyVP200JSONResponse(result), nil
 }
 
@@ -427,7 +516,7 @@ func (w *Wrapper) LoadVC(ctx context.Context, request LoadVCRequestObject) (Load
 	}
 
 	// validate credential
-	if err = w.VCR.Verifier().Verify(*request.Body, true, true, nil); err != nil {
+	if err = w.VCR.Verifier().Verify(ctx, *request.Body, true, true, nil); err != nil {
 		if errors.Is(err, verifier.VerificationError{}) {
 			return nil, core.InvalidInputError("%w", err)
 		}
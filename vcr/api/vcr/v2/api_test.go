@@ -40,6 +40,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
 	"github.com/nuts-foundation/nuts-node/vcr/holder"
 	"github.com/nuts-foundation/nuts-node/vcr/issuer"
+	"github.com/nuts-foundation/nuts-node/vcr/revocation"
 	"github.com/nuts-foundation/nuts-node/vcr/signature/proof"
 	"github.com/nuts-foundation/nuts-node/vcr/verifier"
 	"github.com/nuts-foundation/nuts-node/vdr/didsubject"
@@ -316,6 +317,41 @@ func TestWrapper_IssueVC(t *testing.T) {
 				assert.EqualError(t, err, "illegal option 'withStatusList2021Revocation' requested for issuer's DID method: nuts")
 				assert.Nil(t, response)
 			})
+			t.Run("err - StatusListType provided", func(t *testing.T) {
+				testContext := newMockContext(t)
+
+				publishValue := false
+				statusListType := BitstringStatusList
+				request := IssueVCRequest{
+					Issuer:            expectedRequestedVC.Issuer.String(),
+					CredentialSubject: expectedRequestedVC.CredentialSubject,
+					PublishToNetwork:  &publishValue,
+					StatusListType:    &statusListType,
+				}
+				_ = request.Type.FromIssueVCRequestType0(expectedRequestedVC.Type[0].String())
+
+				response, err := testContext.client.IssueVC(testContext.requestCtx, IssueVCRequestObject{Body: &request})
+
+				assert.EqualError(t, err, "illegal option 'statusListType' requested for issuer's DID method: nuts")
+				assert.Nil(t, response)
+			})
+			t.Run("err - withStatusListSuspension provided", func(t *testing.T) {
+				testContext := newMockContext(t)
+
+				publishValue := false
+				request := IssueVCRequest{
+					Issuer:                   expectedRequestedVC.Issuer.String(),
+					CredentialSubject:        expectedRequestedVC.CredentialSubject,
+					PublishToNetwork:         &publishValue,
+					WithStatusListSuspension: &publishValue,
+				}
+				_ = request.Type.FromIssueVCRequestType0(expectedRequestedVC.Type[0].String())
+
+				response, err := testContext.client.IssueVC(testContext.requestCtx, IssueVCRequestObject{Body: &request})
+
+				assert.EqualError(t, err, "illegal option 'withStatusListSuspension' requested for issuer's DID method: nuts")
+				assert.Nil(t, response)
+			})
 
 		})
 		t.Run("did:web", func(t *testing.T) {
@@ -346,6 +382,47 @@ func TestWrapper_IssueVC(t *testing.T) {
 				assert.NoError(t, err)
 				assert.Equal(t, IssueVC200JSONResponse(expectedRequestedVC), response)
 			})
+			t.Run("ok with BitstringStatusList", func(t *testing.T) {
+				testContext := newMockContext(t)
+				withRevocation := true
+				statusListType := BitstringStatusList
+				request := IssueVCRequest{
+					CredentialSubject:            expectedRequestedVC.CredentialSubject,
+					Issuer:                       expectedRequestedVC.Issuer.String(),
+					WithStatusList2021Revocation: &withRevocation,
+					StatusListType:               &statusListType,
+				}
+				_ = request.Type.FromIssueVCRequestType0(expectedRequestedVC.Type[0].String())
+				testContext.mockIssuer.EXPECT().Issue(testContext.requestCtx, expectedRequestedVC, issuer.CredentialOptions{
+					WithStatusListRevocation: true,
+					StatusListType:           revocation.BitstringStatusListType,
+				}).Return(&expectedRequestedVC, nil)
+
+				response, err := testContext.client.IssueVC(testContext.requestCtx, IssueVCRequestObject{Body: &request})
+
+				assert.NoError(t, err)
+				assert.Equal(t, IssueVC200JSONResponse(expectedRequestedVC), response)
+			})
+			t.Run("ok with suspension", func(t *testing.T) {
+				testContext := newMockContext(t)
+				withRevocation := true
+				request := IssueVCRequest{
+					CredentialSubject:            expectedRequestedVC.CredentialSubject,
+					Issuer:                       expectedRequestedVC.Issuer.String(),
+					WithStatusList2021Revocation: &withRevocation,
+					WithStatusListSuspension:     &withRevocation,
+				}
+				_ = request.Type.FromIssueVCRequestType0(expectedRequestedVC.Type[0].String())
+				testContext.mockIssuer.EXPECT().Issue(testContext.requestCtx, expectedRequestedVC, issuer.CredentialOptions{
+					WithStatusListRevocation: true,
+					WithStatusListSuspension: true,
+				}).Return(&expectedRequestedVC, nil)
+
+				response, err := testContext.client.IssueVC(testContext.requestCtx, IssueVCRequestObject{Body: &request})
+
+				assert.NoError(t, err)
+				assert.Equal(t, IssueVC200JSONResponse(expectedRequestedVC), response)
+			})
 			t.Run("ok - without WithStatusList2021Revocation, with ExpirationDate", func(t *testing.T) {
 				testContext := newMockContext(t)
 
@@ -428,6 +505,90 @@ func TestWrapper_IssueVC(t *testing.T) {
 				assert.EqualError(t, err, "illegal option 'visibility' requested for issuer's DID method: web")
 				assert.Nil(t, response)
 			})
+			t.Run("ok - SD-JWT with selectively disclosable claims", func(t *testing.T) {
+				testContext := newMockContext(t)
+				withRevocation := true
+				format := VcSdJwt
+				disclosable := []string{"organization.city"}
+				request := IssueVCRequest{
+					CredentialSubject:            expectedRequestedVC.CredentialSubject,
+					Issuer:                       expectedRequestedVC.Issuer.String(),
+					WithStatusList2021Revocation: &withRevocation,
+					Format:                       &format,
+					SelectivelyDisclosable:       &disclosable,
+				}
+				_ = request.Type.FromIssueVCRequestType0(expectedRequestedVC.Type[0].String())
+				testContext.mockIssuer.EXPECT().Issue(testContext.requestCtx, expectedRequestedVC, issuer.CredentialOptions{
+					Format:                   "vc+sd-jwt",
+					SelectivelyDisclosable:   disclosable,
+					WithStatusListRevocation: true,
+				}).Return(&expectedRequestedVC, nil)
+
+				response, err := testContext.client.IssueVC(testContext.requestCtx, IssueVCRequestObject{Body: &request})
+
+				assert.NoError(t, err)
+				assert.Equal(t, IssueVC200JSONResponse(expectedRequestedVC), response)
+			})
+			t.Run("err - selectivelyDisclosable for non SD-JWT format", func(t *testing.T) {
+				testContext := newMockContext(t)
+				withRevocation := true
+				format := JwtVc
+				disclosable := []string{"organization.city"}
+				request := IssueVCRequest{
+					CredentialSubject:            expectedRequestedVC.CredentialSubject,
+					Issuer:                       expectedRequestedVC.Issuer.String(),
+					WithStatusList2021Revocation: &withRevocation,
+					Format:                       &format,
+					SelectivelyDisclosable:       &disclosable,
+				}
+				_ = request.Type.FromIssueVCRequestType0(expectedRequestedVC.Type[0].String())
+
+				response, err := testContext.client.IssueVC(testContext.requestCtx, IssueVCRequestObject{Body: &request})
+
+				assert.EqualError(t, err, "option 'selectivelyDisclosable' is only allowed for credential format: vc+sd-jwt")
+				assert.Nil(t, response)
+			})
+			t.Run("ok - JWT with credentialSchema", func(t *testing.T) {
+				testContext := newMockContext(t)
+				withRevocation := true
+				format := JwtVc
+				schemas := []CredentialSchema{{Id: "https://example.com/schema.json", Type: JsonSchema}}
+				request := IssueVCRequest{
+					CredentialSubject:            expectedRequestedVC.CredentialSubject,
+					Issuer:                       expectedRequestedVC.Issuer.String(),
+					WithStatusList2021Revocation: &withRevocation,
+					Format:                       &format,
+					CredentialSchema:             &schemas,
+				}
+				_ = request.Type.FromIssueVCRequestType0(expectedRequestedVC.Type[0].String())
+				testContext.mockIssuer.EXPECT().Issue(testContext.requestCtx, expectedRequestedVC, issuer.CredentialOptions{
+					Format:                   "jwt_vc",
+					WithStatusListRevocation: true,
+					CredentialSchema:         []credential.CredentialSchema{{ID: "https://example.com/schema.json", Type: "JsonSchema"}},
+				}).Return(&expectedRequestedVC, nil)
+
+				response, err := testContext.client.IssueVC(testContext.requestCtx, IssueVCRequestObject{Body: &request})
+
+				assert.NoError(t, err)
+				assert.Equal(t, IssueVC200JSONResponse(expectedRequestedVC), response)
+			})
+			t.Run("err - credentialSchema for non JWT format", func(t *testing.T) {
+				testContext := newMockContext(t)
+				withRevocation := true
+				schemas := []CredentialSchema{{Id: "https://example.com/schema.json", Type: JsonSchema}}
+				request := IssueVCRequest{
+					CredentialSubject:            expectedRequestedVC.CredentialSubject,
+					Issuer:                       expectedRequestedVC.Issuer.String(),
+					WithStatusList2021Revocation: &withRevocation,
+					CredentialSchema:             &schemas,
+				}
+				_ = request.Type.FromIssueVCRequestType0(expectedRequestedVC.Type[0].String())
+
+				response, err := testContext.client.IssueVC(testContext.requestCtx, IssueVCRequestObject{Body: &request})
+
+				assert.EqualError(t, err, "option 'credentialSchema' is only allowed for credential format: jwt_vc")
+				assert.Nil(t, response)
+			})
 		})
 
 	})
@@ -630,9 +791,31 @@ func TestWrapper_VerifyVC(t *testing.T) {
 
 	t.Run("valid vc", func(t *testing.T) {
 		testContext := newMockContext(t)
-		expectedResponse := VerifyVC200JSONResponse(VCVerificationResult{Validity: true})
-
-		testContext.mockVerifier.EXPECT().Verify(expectedVC, allowUntrusted, true, nil)
+		report := verifier.VerificationReport{Checks: []verifier.CheckResult{
+			{Check: verifier.CheckFormat, Status: verifier.CheckPassed},
+			{Check: verifier.CheckSchema, Status: verifier.CheckSkipped, Reason: "credential has no credentialSchema"},
+			{Check: verifier.CheckRevocation, Status: verifier.CheckPassed},
+			{Check: verifier.CheckCredentialStatus, Status: verifier.CheckIndeterminate, Reason: "status list could not be downloaded"},
+			{Check: verifier.CheckTrust, Status: verifier.CheckSkipped, Reason: "untrusted issuers are allowed"},
+			{Check: verifier.CheckValidityPeriod, Status: verifier.CheckPassed},
+			{Check: verifier.CheckIssuer, Status: verifier.CheckPassed},
+			{Check: verifier.CheckSignature, Status: verifier.CheckPassed},
+		}}
+		schemaReason := "credential has no credentialSchema"
+		credentialStatusReason := "status list could not be downloaded"
+		trustReason := "untrusted issuers are allowed"
+		expectedResponse := VerifyVC200JSONResponse(VCVerificationResult{Validity: true, Checks: &[]VCVerificationCheck{
+			{Check: "format", Status: Passed},
+			{Check: "schema", Status: Skipped, Reason: &schemaReason},
+			{Check: "revocation", Status: Passed},
+			{Check: "credentialStatus", Status: Indeterminate, Reason: &credentialStatusReason},
+			{Check: "trust", Status: Skipped, Reason: &trustReason},
+			{Check: "validityPeriod", Status: Passed},
+			{Check: "issuer", Status: Passed},
+			{Check: "signature", Status: Passed},
+		}})
+
+		testContext.mockVerifier.EXPECT().VerifyWithReport(gomock.Any(), expectedVC, allowUntrusted, true, nil).Return(report)
 
 		response, err := testContext.client.VerifyVC(testContext.requestCtx, VerifyVCRequestObject{Body: &expectedVerifyRequest})
 		assert.NoError(t, err)
@@ -640,10 +823,19 @@ func TestWrapper_VerifyVC(t *testing.T) {
 	})
 	t.Run("invalid vc", func(t *testing.T) {
 		testContext := newMockContext(t)
-		message := "invalid vc"
-		expectedResponse := VerifyVC200JSONResponse(VCVerificationResult{Validity: false, Message: &message})
+		vcrVerifier := verifier.NewVerifier(nil, nil, nil, nil, nil, revocation.NewStatusList2021(nil, nil, ""), nil, nil)
+		// a credential without issuance date fails format validation, so the other checks are skipped
+		report := vcrVerifier.VerifyWithReport(context.Background(), expectedVC, true, true, nil)
+		message := report.Err().Error()
+		formatReason := report.Checks[0].Reason
+		skippedReason := "check 'format' did not pass"
+		checks := []VCVerificationCheck{{Check: "format", Status: Failed, Reason: &formatReason}}
+		for _, name := range []string{"schema", "revocation", "credentialStatus", "trust", "validityPeriod", "issuer", "signature"} {
+			checks = append(checks, VCVerificationCheck{Check: name, Status: Skipped, Reason: &skippedReason})
+		}
+		expectedResponse := VerifyVC200JSONResponse(VCVerificationResult{Validity: false, Message: &message, Checks: &checks})
 
-		testContext.mockVerifier.EXPECT().Verify(expectedVC, true, true, nil).Return(errors.New(message))
+		testContext.mockVerifier.EXPECT().VerifyWithReport(gomock.Any(), expectedVC, true, true, nil).Return(report)
 
 		response, err := testContext.client.VerifyVC(testContext.requestCtx, VerifyVCRequestObject{Body: &expectedVerifyRequest})
 		assert.NoError(t, err)
@@ -669,9 +861,9 @@ func TestWrapper_VerifyVC(t *testing.T) {
 			VerificationOptions:  &options,
 		}
 		testContext := newMockContext(t)
-		expectedResponse := VerifyVC200JSONResponse(VCVerificationResult{Validity: true})
+		expectedResponse := VerifyVC200JSONResponse(VCVerificationResult{Validity: true, Checks: &[]VCVerificationCheck{}})
 
-		testContext.mockVerifier.EXPECT().Verify(expectedVC, true, true, nil)
+		testContext.mockVerifier.EXPECT().VerifyWithReport(gomock.Any(), expectedVC, true, true, nil)
 
 		response, err := testContext.client.VerifyVC(testContext.requestCtx, VerifyVCRequestObject{Body: &expectedVerifyRequest})
 		assert.NoError(t, err)
@@ -730,6 +922,72 @@ func TestWrapper_RevokeVC(t *testing.T) {
 	})
 }
 
+func TestWrapper_SuspendVC(t *testing.T) {
+	credentialID := "did:web:example.com:iam:123#abc"
+	credentialURI := ssi.MustParseURI(credentialID)
+
+	t.Run("ok", func(t *testing.T) {
+		testContext := newMockContext(t)
+		testContext.mockIssuer.EXPECT().Suspend(gomock.Any(), credentialURI).Return(nil)
+
+		response, err := testContext.client.SuspendVC(testContext.requestCtx, SuspendVCRequestObject{Id: credentialID})
+
+		assert.NoError(t, err)
+		assert.Equal(t, SuspendVC204Response{}, response)
+	})
+	t.Run("error - already suspended", func(t *testing.T) {
+		testContext := newMockContext(t)
+		testContext.mockIssuer.EXPECT().Suspend(gomock.Any(), credentialURI).Return(types.ErrSuspended)
+
+		response, err := testContext.client.SuspendVC(testContext.requestCtx, SuspendVCRequestObject{Id: credentialID})
+
+		assert.Nil(t, response)
+		assert.ErrorIs(t, err, types.ErrSuspended)
+		assert.Equal(t, http.StatusConflict, testContext.client.ResolveStatusCode(err))
+	})
+	t.Run("error - invalid credential id format", func(t *testing.T) {
+		testContext := newMockContext(t)
+
+		response, err := testContext.client.SuspendVC(testContext.requestCtx, SuspendVCRequestObject{Id: "%%"})
+
+		assert.Nil(t, response)
+		assert.EqualError(t, err, "invalid credential id: parse \"%%\": invalid URL escape \"%%\"")
+	})
+}
+
+func TestWrapper_UnsuspendVC(t *testing.T) {
+	credentialID := "did:web:example.com:iam:123#abc"
+	credentialURI := ssi.MustParseURI(credentialID)
+
+	t.Run("ok", func(t *testing.T) {
+		testContext := newMockContext(t)
+		testContext.mockIssuer.EXPECT().Unsuspend(gomock.Any(), credentialURI).Return(nil)
+
+		response, err := testContext.client.UnsuspendVC(testContext.requestCtx, UnsuspendVCRequestObject{Id: credentialID})
+
+		assert.NoError(t, err)
+		assert.Equal(t, UnsuspendVC204Response{}, response)
+	})
+	t.Run("error - not suspended", func(t *testing.T) {
+		testContext := newMockContext(t)
+		testContext.mockIssuer.EXPECT().Unsuspend(gomock.Any(), credentialURI).Return(types.ErrNotSuspended)
+
+		response, err := testContext.client.UnsuspendVC(testContext.requestCtx, UnsuspendVCRequestObject{Id: credentialID})
+
+		assert.Nil(t, response)
+		assert.ErrorIs(t, err, types.ErrNotSuspended)
+		assert.Equal(t, http.StatusConflict, testContext.client.ResolveStatusCode(err))
+	})
+	t.Run("error - invalid credential id format", func(t *testing.T) {
+		testContext := newMockContext(t)
+
+		response, err := testContext.client.UnsuspendVC(testContext.requestCtx, UnsuspendVCRequestObject{Id: "%%"})
+
+		assert.Nil(t, response)
+		assert.EqualError(t, err, "invalid credential id: parse \"%%\": invalid URL escape \"%%\"")
+	})
+}
+
 // parsedTimeStr returns the original (truncated) time and an RFC3339 string with an extra round of formatting/parsing
 func parsedTimeStr(t time.Time) (time.Time, string) {
 	formatted := t.Format(time.RFC3339)
@@ -743,7 +1001,7 @@ func TestWrapper_LoadVC(t *testing.T) {
 	t.Run("successful load", func(t *testing.T) {
 		testContext := newMockContext(t)
 		testContext.mockSubjectManager.EXPECT().ListDIDs(gomock.Any(), subjectID).Return([]did.DID{holderDID}, nil)
-		testContext.mockVerifier.EXPECT().Verify(gomock.Any(), true, true, nil).Return(nil)
+		testContext.mockVerifier.EXPECT().Verify(gomock.Any(), gomock.Any(), true, true, nil).Return(nil)
 		testContext.mockWallet.EXPECT().Put(gomock.Any(), testVC).Return(nil)
 
 		response, err := testContext.client.LoadVC(testContext.requestCtx, LoadVCRequestObject{SubjectID: subjectID, Body: &testVC})
@@ -762,7 +1020,7 @@ func TestWrapper_LoadVC(t *testing.T) {
 	t.Run("verification failed", func(t *testing.T) {
 		testContext := newMockContext(t)
 		testContext.mockSubjectManager.EXPECT().ListDIDs(gomock.Any(), subjectID).Return([]did.DID{holderDID}, nil)
-		testContext.mockVerifier.EXPECT().Verify(gomock.Any(), true, true, nil).Return(verifier.VerificationError{})
+		testContext.mockVerifier.EXPECT().Verify(gomock.Any(), gomock.Any(), true, true, nil).Return(verifier.VerificationError{})
 
 		_, err := testContext.client.LoadVC(testContext.requestCtx, LoadVCRequestObject{SubjectID: subjectID, Body: &testVC})
 
@@ -796,7 +1054,7 @@ func TestWrapper_LoadVC(t *testing.T) {
 	t.Run("wallet error", func(t *testing.T) {
 		testContext := newMockContext(t)
 		testContext.mockSubjectManager.EXPECT().ListDIDs(gomock.Any(), subjectID).Return([]did.DID{holderDID}, nil)
-		testContext.mockVerifier.EXPECT().Verify(gomock.Any(), true, true, nil).Return(nil)
+		testContext.mockVerifier.EXPECT().Verify(gomock.Any(), gomock.Any(), true, true, nil).Return(nil)
 		testContext.mockWallet.EXPECT().Put(gomock.Any(), testVC).Return(assert.AnError)
 
 		response, err := testContext.client.LoadVC(testContext.requestCtx, LoadVCRequestObject{SubjectID: subjectID, Body: &testVC})
@@ -1068,6 +1326,19 @@ func TestWrapper_VerifyVP(t *testing.T) {
 	vpBS, _ := json.Marshal(vp)
 	require.NoError(t, json.Unmarshal(vpBS, &vp))
 	expectedVCs := []VerifiableCredential{vp.VerifiableCredential[0]}
+	validReport := verifier.PresentationVerificationReport{
+		VerificationReport: verifier.VerificationReport{Checks: []verifier.CheckResult{
+			{Check: verifier.CheckHolder, Status: verifier.CheckPassed},
+			{Check: verifier.CheckSignature, Status: verifier.CheckPassed},
+		}},
+		Credentials: []verifier.CredentialVerificationReport{{
+			VerificationReport: verifier.VerificationReport{Checks: []verifier.CheckResult{
+				{Check: verifier.CheckFormat, Status: verifier.CheckPassed},
+			}},
+		}},
+	}
+	expectedChecks := []VCVerificationCheck{{Check: "holder", Status: Passed}, {Check: "signature", Status: Passed}}
+	expectedCredentialChecks := []VPCredentialVerificationResult{{Checks: []VCVerificationCheck{{Check: "format", Status: Passed}}}}
 
 	t.Run("ok", func(t *testing.T) {
 		testContext := newMockContext(t)
@@ -1077,10 +1348,12 @@ func TestWrapper_VerifyVP(t *testing.T) {
 			ValidAt:                &validAtStr,
 		}
 		// assert that allowUntrustedVCs=false default for did:nuts
-		testContext.mockVerifier.EXPECT().VerifyVP(vp, true, false, &validAt).Return(vp.VerifiableCredential, nil)
+		testContext.mockVerifier.EXPECT().VerifyVPWithReport(gomock.Any(), vp, true, false, &validAt).Return(validReport)
 		expectedResponse := VerifyVP200JSONResponse(VPVerificationResult{
-			Credentials: &expectedVCs,
-			Validity:    true,
+			Credentials:      &expectedVCs,
+			Validity:         true,
+			Checks:           &expectedChecks,
+			CredentialChecks: &expectedCredentialChecks,
 		})
 
 		response, err := testContext.client.VerifyVP(testContext.requestCtx, VerifyVPRequestObject{Body: &request})
@@ -1106,10 +1379,12 @@ func TestWrapper_VerifyVP(t *testing.T) {
 			ValidAt:                &validAtStr,
 		}
 		// assert that allowUntrustedVCs=true default for did:web
-		testContext.mockVerifier.EXPECT().VerifyVP(vp, true, true, &validAt).Return(vp.VerifiableCredential, nil)
+		testContext.mockVerifier.EXPECT().VerifyVPWithReport(gomock.Any(), vp, true, true, &validAt).Return(validReport)
 		expectedResponse := VerifyVP200JSONResponse(VPVerificationResult{
-			Credentials: &expectedVCs,
-			Validity:    true,
+			Credentials:      &expectedVCs,
+			Validity:         true,
+			Checks:           &expectedChecks,
+			CredentialChecks: &expectedCredentialChecks,
 		})
 
 		response, err := testContext.client.VerifyVP(testContext.requestCtx, VerifyVPRequestObject{Body: &request})
@@ -1121,10 +1396,11 @@ func TestWrapper_VerifyVP(t *testing.T) {
 		testContext := newMockContext(t)
 		verifyCredentials := false
 		request := VPVerificationRequest{VerifiablePresentation: vp, VerifyCredentials: &verifyCredentials}
-		testContext.mockVerifier.EXPECT().VerifyVP(vp, false, false, nil).Return(vp.VerifiableCredential, nil)
+		testContext.mockVerifier.EXPECT().VerifyVPWithReport(gomock.Any(), vp, false, false, nil).Return(verifier.PresentationVerificationReport{VerificationReport: validReport.VerificationReport})
 		expectedResponse := VerifyVP200JSONResponse(VPVerificationResult{
 			Credentials: &expectedVCs,
 			Validity:    true,
+			Checks:      &expectedChecks,
 		})
 
 		response, err := testContext.client.VerifyVP(testContext.requestCtx, VerifyVPRequestObject{Body: &request})
@@ -1135,7 +1411,9 @@ func TestWrapper_VerifyVP(t *testing.T) {
 	t.Run("error - verification failed (other error)", func(t *testing.T) {
 		testContext := newMockContext(t)
 		request := VPVerificationRequest{VerifiablePresentation: vp}
-		testContext.mockVerifier.EXPECT().VerifyVP(vp, true, false, nil).Return(nil, errors.New("failed"))
+		testContext.mockVerifier.EXPECT().VerifyVPWithReport(gomock.Any(), vp, true, false, nil).Return(verifier.PresentationVerificationReport{
+			VerificationReport: verifier.VerificationReport{Checks: []verifier.CheckResult{{Check: verifier.CheckSignature, Status: verifier.CheckFailed, Reason: "failed"}}},
+		})
 
 		response, err := testContext.client.VerifyVP(testContext.requestCtx, VerifyVPRequestObject{Body: &request})
 
@@ -1158,11 +1436,24 @@ func TestWrapper_VerifyVP(t *testing.T) {
 	t.Run("error - verification failed (verification error)", func(t *testing.T) {
 		testContext := newMockContext(t)
 		request := VPVerificationRequest{VerifiablePresentation: vp}
-		testContext.mockVerifier.EXPECT().VerifyVP(vp, true, false, nil).Return(nil, verifier.VerificationError{})
-		errMsg := "verification error: "
+		// a presentation without proof fails the holder and signature checks, the credentials are still verified
+		vpWithoutProof := vp
+		vpWithoutProof.Proof = nil
+		vcrVerifier := verifier.NewVerifier(nil, nil, nil, nil, nil, revocation.NewStatusList2021(nil, nil, ""), nil, nil)
+		report := vcrVerifier.VerifyVPWithReport(context.Background(), vpWithoutProof, false, false, nil)
+		report.Credentials = validReport.Credentials
+		testContext.mockVerifier.EXPECT().VerifyVPWithReport(gomock.Any(), vp, true, false, nil).Return(report)
+		errMsg := "verification error: presenter is credential subject: presentation should have exactly 1 proof, got 0"
+		holderReason := "verification error: presenter is credential subject: presentation should have exactly 1 proof, got 0"
+		signatureReason := report.Checks[1].Reason
 		expectedRepsonse := VerifyVP200JSONResponse(VPVerificationResult{
 			Message:  &errMsg,
 			Validity: false,
+			Checks: &[]VCVerificationCheck{
+				{Check: "holder", Status: Failed, Reason: &holderReason},
+				{Check: "signature", Status: Failed, Reason: &signatureReason},
+			},
+			CredentialChecks: &expectedCredentialChecks,
 		})
 
 		response, err := testContext.client.VerifyVP(testContext.requestCtx, VerifyVPRequestObject{Body: &request})
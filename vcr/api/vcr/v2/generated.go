@@ -37,10 +37,23 @@ const (
 	KeyAgreement         CreateVPRequestProofPurpose = "keyAgreement"
 )
 
+// Defines values for CredentialSchemaType.
+const (
+	JsonSchema           CredentialSchemaType = "JsonSchema"
+	JsonSchemaCredential CredentialSchemaType = "JsonSchemaCredential"
+)
+
 // Defines values for IssueVCRequestFormat.
 const (
-	JwtVc IssueVCRequestFormat = "jwt_vc"
-	LdpVc IssueVCRequestFormat = "ldp_vc"
+	JwtVc   IssueVCRequestFormat = "jwt_vc"
+	LdpVc   IssueVCRequestFormat = "ldp_vc"
+	VcSdJwt IssueVCRequestFormat = "vc+sd-jwt"
+)
+
+// Defines values for IssueVCRequestStatusListType.
+const (
+	BitstringStatusList IssueVCRequestStatusListType = "BitstringStatusList"
+	StatusList2021      IssueVCRequestStatusListType = "StatusList2021"
 )
 
 // Defines values for IssueVCRequestVisibility.
@@ -49,6 +62,14 @@ const (
 	Public  IssueVCRequestVisibility = "public"
 )
 
+// Defines values for VCVerificationCheckStatus.
+const (
+	Failed        VCVerificationCheckStatus = "failed"
+	Indeterminate VCVerificationCheckStatus = "indeterminate"
+	Passed        VCVerificationCheckStatus = "passed"
+	Skipped       VCVerificationCheckStatus = "skipped"
+)
+
 // CreateVPRequest A request for creating a new Verifiable Presentation for a set of Verifiable Credentials.
 type CreateVPRequest struct {
 	// Context Array of JSON-LD contexts, contain definitions of the given types.
@@ -99,19 +120,35 @@ type CredentialIssuer struct {
 	Issuer string `json:"issuer"`
 }
 
+// CredentialSchema A credentialSchema entry, referring to a JSON Schema (JsonSchema) or a credential containing a JSON Schema (JsonSchemaCredential).
+type CredentialSchema struct {
+	// Id URL of the JSON Schema or JsonSchemaCredential.
+	Id   string               `json:"id"`
+	Type CredentialSchemaType `json:"type"`
+}
+
+// CredentialSchemaType defines model for CredentialSchema.Type.
+type CredentialSchemaType string
+
 // IssueVCRequest A request for issuing a new Verifiable Credential.
 type IssueVCRequest struct {
 	// Context The resolvable context of the credentialSubject as URI. If omitted, the "https://nuts.nl/credentials/v1" context is used.
 	// It always adds the "https://www.w3.org/2018/credentials/v1" context if not present.
 	Context *IssueVCRequest_Context `json:"@context,omitempty"`
 
+	// CredentialSchema JSON Schemas the credential must conform to, added to the credentialSchema property of the credential.
+	// The credential is validated against the schemas before it is issued. See https://www.w3.org/TR/vc-json-schema/
+	// Only valid when format is jwt_vc.
+	CredentialSchema *[]CredentialSchema `json:"credentialSchema,omitempty"`
+
 	// CredentialSubject Subject of a Verifiable Credential identifying the holder and expressing claims.
 	CredentialSubject CredentialSubject `json:"credentialSubject"`
 
 	// ExpirationDate RFC3339 time string until when the credential is valid.
 	ExpirationDate *string `json:"expirationDate,omitempty"`
 
-	// Format Proof format for the credential (ldp_vc for JSON-LD or jwt_vc for JWT). If not set, it defaults to JSON-LD.
+	// Format Proof format for the credential (ldp_vc for JSON-LD, jwt_vc for JWT or vc+sd-jwt for SD-JWT VC). If not set, it defaults to JSON-LD.
+	// SD-JWT VCs are returned as EnvelopedVerifiableCredential, with the SD-JWT encoded in its id.
 	Format *IssueVCRequestFormat `json:"format,omitempty"`
 
 	// Issuer DID according to Nuts specification.
@@ -124,6 +161,17 @@ type IssueVCRequest struct {
 	// Only valid for did:nuts issuers.
 	PublishToNetwork *bool `json:"publishToNetwork,omitempty"`
 
+	// SelectivelyDisclosable Claims of the credentialSubject the holder can selectively disclose, specified as dot-separated paths relative to the credentialSubject (e.g. "organization.city").
+	// If not set, all claims of the credentialSubject (except id) are selectively disclosable.
+	// Only valid when format is vc+sd-jwt.
+	SelectivelyDisclosable *[]string `json:"selectivelyDisclosable,omitempty"`
+
+	// StatusListType The status list specification used for the credentialStatus added by withStatusList2021Revocation and withStatusListSuspension.
+	// 'StatusList2021' (default) adds a StatusList2021Entry, see https://www.w3.org/TR/2023/WD-vc-status-list-20230427/
+	// 'BitstringStatusList' adds a BitstringStatusListEntry, see https://www.w3.org/TR/vc-bitstring-status-list/
+	// Only valid for did:web issuers.
+	StatusListType *IssueVCRequestStatusListType `json:"statusListType,omitempty"`
+
 	// Type Type definition for the credential.
 	Type IssueVCRequest_Type `json:"type"`
 
@@ -133,13 +181,18 @@ type IssueVCRequest struct {
 	Visibility *IssueVCRequestVisibility `json:"visibility,omitempty"`
 
 	// WithStatusList2021Revocation Add a credentialStatus with statusPurpose 'revocation' to the issued credential. This allows a credential to
-	// be revoked using the referenced StatusList2021Credential. StatusPurpose 'suspension' is not supported (yet).
+	// be revoked using the referenced StatusList2021Credential. Use withStatusListSuspension for statusPurpose 'suspension'.
 	// See https://www.w3.org/TR/2023/WD-vc-status-list-20230427/
 	//
 	// Credentials with a short lifespan (expiry) are preferred over adding a credentialStatus.
 	// This is a required field for credentials without an expirationDate.
 	// Only valid for did:web issuers.
 	WithStatusList2021Revocation *bool `json:"withStatusList2021Revocation,omitempty"`
+
+	// WithStatusListSuspension Add a credentialStatus with statusPurpose 'suspension' to the issued credential. This allows a credential to
+	// be suspended and unsuspended using the referenced status list credential.
+	// Only valid for did:web issuers.
+	WithStatusListSuspension *bool `json:"withStatusListSuspension,omitempty"`
 }
 
 // IssueVCRequestContext0 defines model for .
@@ -154,9 +207,16 @@ type IssueVCRequest_Context struct {
 	union json.RawMessage
 }
 
-// IssueVCRequestFormat Proof format for the credential (ldp_vc for JSON-LD or jwt_vc for JWT). If not set, it defaults to JSON-LD.
+// IssueVCRequestFormat Proof format for the credential (ldp_vc for JSON-LD, jwt_vc for JWT or vc+sd-jwt for SD-JWT VC). If not set, it defaults to JSON-LD.
+// SD-JWT VCs are returned as EnvelopedVerifiableCredential, with the SD-JWT encoded in its id.
 type IssueVCRequestFormat string
 
+// IssueVCRequestStatusListType The status list specification used for the credentialStatus added by withStatusList2021Revocation and withStatusListSuspension.
+// 'StatusList2021' (default) adds a StatusList2021Entry, see https://www.w3.org/TR/2023/WD-vc-status-list-20230427/
+// 'BitstringStatusList' adds a BitstringStatusListEntry, see https://www.w3.org/TR/vc-bitstring-status-list/
+// Only valid for did:web issuers.
+type IssueVCRequestStatusListType string
+
 // IssueVCRequestType0 defines model for .
 type IssueVCRequestType0 = string
 
@@ -173,17 +233,81 @@ type IssueVCRequest_Type struct {
 // Only valid for did:nuts issuers.
 type IssueVCRequestVisibility string
 
+// SearchFilter A filter expression, which is either a combination of filters (and, or, not) or a condition on a credential property (path).
+// A condition compares the value of the property at the JSON path (e.g. credentialSubject.organization.city) using one or more operators, which must all match.
+// Only string properties can be searched for, values are compared as strings (so dates in ISO 8601 format can be compared).
+// Range operators (lt, lte, gt, gte) given a number compare numerically, and only match properties that contain a number.
+// The paths id, issuer, type and credentialSubject.id refer to the credential's ID, issuer, type (other than VerifiableCredential) and subject.
+type SearchFilter struct {
+	// And Matches if all filters match.
+	And *[]SearchFilter `json:"and,omitempty"`
+
+	// Eq Matches if the value equals the given value.
+	Eq *string `json:"eq,omitempty"`
+
+	// Gt Matches if the value is higher than the given value.
+	Gt *string `json:"gt,omitempty"`
+
+	// Gte Matches if the value is higher than or equal to the given value.
+	Gte *string `json:"gte,omitempty"`
+
+	// In Matches if the value equals any of the given values.
+	In *[]string `json:"in,omitempty"`
+
+	// Lt Matches if the value is lower than the given value.
+	Lt *string `json:"lt,omitempty"`
+
+	// Lte Matches if the value is lower than or equal to the given value.
+	Lte *string `json:"lte,omitempty"`
+
+	// Ne Matches if the credential has the property and its value differs from the given value.
+	Ne *string `json:"ne,omitempty"`
+
+	// Not A filter expression, which is either a combination of filters (and, or, not) or a condition on a credential property (path).
+	// A condition compares the value of the property at the JSON path (e.g. credentialSubject.organization.city) using one or more operators, which must all match.
+	// Only string properties can be searched for, values are compared as strings (so dates in ISO 8601 format can be compared).
+	// Range operators (lt, lte, gt, gte) given a number compare numerically, and only match properties that contain a number.
+	// The paths id, issuer, type and credentialSubject.id refer to the credential's ID, issuer, type (other than VerifiableCredential) and subject.
+	Not *SearchFilter `json:"not,omitempty"`
+
+	// Or Matches if any of the filters matches.
+	Or *[]SearchFilter `json:"or,omitempty"`
+
+	// Path JSON path of the property to compare.
+	Path *string `json:"path,omitempty"`
+
+	// Prefix Matches if the value starts with the given value.
+	Prefix *string `json:"prefix,omitempty"`
+}
+
 // SearchOptions defines model for SearchOptions.
 type SearchOptions struct {
 	// AllowUntrustedIssuer If set to true, VCs from an untrusted issuer are returned.
 	AllowUntrustedIssuer *bool `json:"allowUntrustedIssuer,omitempty"`
 }
 
-// SearchVCRequest request body for searching VCs
+// SearchVCRequest request body for searching VCs. Either query or filter must be specified.
 type SearchVCRequest struct {
+	// Filter A filter expression, which is either a combination of filters (and, or, not) or a condition on a credential property (path).
+	// A condition compares the value of the property at the JSON path (e.g. credentialSubject.organization.city) using one or more operators, which must all match.
+	// Only string properties can be searched for, values are compared as strings (so dates in ISO 8601 format can be compared).
+	// Range operators (lt, lte, gt, gte) given a number compare numerically, and only match properties that contain a number.
+	// The paths id, issuer, type and credentialSubject.id refer to the credential's ID, issuer, type (other than VerifiableCredential) and subject.
+	Filter *SearchFilter `json:"filter,omitempty"`
+
+	// Limit Maximum number of results to return. If not set, all results are returned. Can only be used with filter.
+	Limit *int `json:"limit,omitempty"`
+
+	// Offset Number of results to skip. Can only be used with filter.
+	Offset *int `json:"offset,omitempty"`
+
 	// Query A partial VerifiableCredential in JSON-LD format. Each field will be used to match credentials against. All fields MUST be present.
-	Query         map[string]interface{} `json:"query"`
-	SearchOptions *SearchOptions         `json:"searchOptions,omitempty"`
+	Query         *map[string]interface{} `json:"query,omitempty"`
+	SearchOptions *SearchOptions          `json:"searchOptions,omitempty"`
+
+	// Sort JSON paths to sort the results on, e.g. credentialSubject.organization.name. Prefix a path with '-' to sort descending.
+	// Can only be used with filter.
+	Sort *[]string `json:"sort,omitempty"`
 }
 
 // SearchVCResult Result of a Search operation.
@@ -197,9 +321,35 @@ type SearchVCResult struct {
 
 // SearchVCResults result of a Search operation.
 type SearchVCResults struct {
+	// Total Total number of credentials that match the filter and aren't revoked. Only set when searching using a filter.
+	Total                 *int             `json:"total,omitempty"`
 	VerifiableCredentials []SearchVCResult `json:"verifiableCredentials"`
 }
 
+// VCVerificationCheck The result of a single check performed when verifying a verifiable credential.
+type VCVerificationCheck struct {
+	// Check Name of the check:
+	// * format: contents of the credential (required fields, types, JSON-LD and credential type specific rules)
+	// * schema: the JSON Schemas referenced by the credentialSchema of the credential
+	// * revocation: revocation of the credential on the Nuts network
+	// * credentialStatus: the credentialStatus (StatusList2021 or BitstringStatusList) of the credential
+	// * trust: whether the issuer is trusted for the credential type
+	// * validityPeriod: issuance and expiration date of the credential
+	// * issuer: whether the issuer DID could be resolved and was not deactivated
+	// * signature: the signature of the credential or presentation and whether the signing key was valid
+	// * holder: whether the credentials in a presentation are presented by their subject
+	Check string `json:"check"`
+
+	// Reason Explains why the check failed, was skipped or is indeterminate.
+	Reason *string `json:"reason,omitempty"`
+
+	// Status Outcome of the check. "indeterminate" means the check could not be completed, but does not make the credential invalid.
+	Status VCVerificationCheckStatus `json:"status"`
+}
+
+// VCVerificationCheckStatus Outcome of the check. "indeterminate" means the check could not be completed, but does not make the credential invalid.
+type VCVerificationCheckStatus string
+
 // VCVerificationOptions defines model for VCVerificationOptions.
 type VCVerificationOptions struct {
 	// AllowUntrustedIssuer If set to true, an untrusted credential issuer is allowed.
@@ -215,6 +365,9 @@ type VCVerificationRequest struct {
 
 // VCVerificationResult Contains the verifiable credential verification result.
 type VCVerificationResult struct {
+	// Checks The result of each check performed on the credential, in the order they were performed.
+	Checks *[]VCVerificationCheck `json:"checks,omitempty"`
+
 	// Message Indicates what went wrong
 	Message *string `json:"message,omitempty"`
 
@@ -222,6 +375,15 @@ type VCVerificationResult struct {
 	Validity bool `json:"validity"`
 }
 
+// VPCredentialVerificationResult The result of the checks performed on a credential in a verifiable presentation.
+type VPCredentialVerificationResult struct {
+	// Checks The result of each check performed on the credential, in the order they were performed.
+	Checks []VCVerificationCheck `json:"checks"`
+
+	// Id The ID of the credential, if it has one.
+	Id *string `json:"id,omitempty"`
+}
+
 // VPVerificationRequest defines model for VPVerificationRequest.
 type VPVerificationRequest struct {
 	// ValidAt Date and time at which the VP should be valid. If not supplied, the current date/time is used.
@@ -234,6 +396,12 @@ type VPVerificationRequest struct {
 
 // VPVerificationResult Contains the verifiable presentation verification result.
 type VPVerificationResult struct {
+	// Checks The result of each check performed on the presentation itself, in the order they were performed.
+	Checks *[]VCVerificationCheck `json:"checks,omitempty"`
+
+	// CredentialChecks The result of the checks performed on each credential in the presentation, if the credentials were verified.
+	CredentialChecks *[]VPCredentialVerificationResult `json:"credentialChecks,omitempty"`
+
 	// Credentials If the VP is valid, it will contain the credentials inside the VP.
 	Credentials *[]VerifiableCredential `json:"credentials,omitempty"`
 
@@ -504,6 +672,12 @@ type ClientInterface interface {
 	// RevokeVC request
 	RevokeVC(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// UnsuspendVC request
+	UnsuspendVC(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SuspendVC request
+	SuspendVC(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// SearchVCsWithBody request with any body
 	SearchVCsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -659,6 +833,30 @@ func (c *Client) RevokeVC(ctx context.Context, id string, reqEditors ...RequestE
 	return c.Client.Do(req)
 }
 
+func (c *Client) UnsuspendVC(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUnsuspendVCRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SuspendVC(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSuspendVCRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) SearchVCsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewSearchVCsRequestWithBody(c.Server, contentType, body)
 	if err != nil {
@@ -1112,6 +1310,74 @@ func NewRevokeVCRequest(server string, id string) (*http.Request, error) {
 	return req, nil
 }
 
+// NewUnsuspendVCRequest generates requests for UnsuspendVC
+func NewUnsuspendVCRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vcr/v2/issuer/vc/%s/suspension", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSuspendVCRequest generates requests for SuspendVC
+func NewSuspendVCRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vcr/v2/issuer/vc/%s/suspension", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewSearchVCsRequest calls the generic SearchVCs builder with application/json body
 func NewSearchVCsRequest(server string, body SearchVCsJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
@@ -1484,6 +1750,12 @@ type ClientWithResponsesInterface interface {
 	// RevokeVCWithResponse request
 	RevokeVCWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*RevokeVCResponse, error)
 
+	// UnsuspendVCWithResponse request
+	UnsuspendVCWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*UnsuspendVCResponse, error)
+
+	// SuspendVCWithResponse request
+	SuspendVCWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*SuspendVCResponse, error)
+
 	// SearchVCsWithBodyWithResponse request with any body
 	SearchVCsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SearchVCsResponse, error)
 
@@ -1741,6 +2013,68 @@ func (r RevokeVCResponse) StatusCode() int {
 	return 0
 }
 
+type UnsuspendVCResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r UnsuspendVCResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UnsuspendVCResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SuspendVCResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r SuspendVCResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SuspendVCResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type SearchVCsResponse struct {
 	Body                          []byte
 	HTTPResponse                  *http.Response
@@ -2082,6 +2416,24 @@ func (c *ClientWithResponses) RevokeVCWithResponse(ctx context.Context, id strin
 	return ParseRevokeVCResponse(rsp)
 }
 
+// UnsuspendVCWithResponse request returning *UnsuspendVCResponse
+func (c *ClientWithResponses) UnsuspendVCWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*UnsuspendVCResponse, error) {
+	rsp, err := c.UnsuspendVC(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUnsuspendVCResponse(rsp)
+}
+
+// SuspendVCWithResponse request returning *SuspendVCResponse
+func (c *ClientWithResponses) SuspendVCWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*SuspendVCResponse, error) {
+	rsp, err := c.SuspendVC(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSuspendVCResponse(rsp)
+}
+
 // SearchVCsWithBodyWithResponse request with arbitrary body returning *SearchVCsResponse
 func (c *ClientWithResponses) SearchVCsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SearchVCsResponse, error) {
 	rsp, err := c.SearchVCsWithBody(ctx, contentType, body, reqEditors...)
@@ -2474,6 +2826,76 @@ func ParseRevokeVCResponse(rsp *http.Response) (*RevokeVCResponse, error) {
 	return response, nil
 }
 
+// ParseUnsuspendVCResponse parses an HTTP response from a UnsuspendVCWithResponse call
+func ParseUnsuspendVCResponse(rsp *http.Response) (*UnsuspendVCResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UnsuspendVCResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSuspendVCResponse parses an HTTP response from a SuspendVCWithResponse call
+func ParseSuspendVCResponse(rsp *http.Response) (*SuspendVCResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SuspendVCResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseSearchVCsResponse parses an HTTP response from a SearchVCsWithResponse call
 func ParseSearchVCsResponse(rsp *http.Response) (*SearchVCsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -2819,6 +3241,12 @@ type ServerInterface interface {
 	// Revoke an issued credential
 	// (DELETE /internal/vcr/v2/issuer/vc/{id})
 	RevokeVC(ctx echo.Context, id string) error
+	// Unsuspend an issued credential
+	// (DELETE /internal/vcr/v2/issuer/vc/{id}/suspension)
+	UnsuspendVC(ctx echo.Context, id string) error
+	// Suspend an issued credential
+	// (PUT /internal/vcr/v2/issuer/vc/{id}/suspension)
+	SuspendVC(ctx echo.Context, id string) error
 	// Searches for verifiable credentials that could be used for different use-cases.
 	// (POST /internal/vcr/v2/search)
 	SearchVCs(ctx echo.Context) error
@@ -2974,6 +3402,42 @@ func (w *ServerInterfaceWrapper) RevokeVC(ctx echo.Context) error {
 	return err
 }
 
+// UnsuspendVC converts echo context to params.
+func (w *ServerInterfaceWrapper) UnsuspendVC(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.UnsuspendVC(ctx, id)
+	return err
+}
+
+// SuspendVC converts echo context to params.
+func (w *ServerInterfaceWrapper) SuspendVC(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.SuspendVC(ctx, id)
+	return err
+}
+
 // SearchVCs converts echo context to params.
 func (w *ServerInterfaceWrapper) SearchVCs(ctx echo.Context) error {
 	var err error
@@ -3118,6 +3582,8 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	router.POST(baseURL+"/internal/vcr/v2/issuer/vc", wrapper.IssueVC)
 	router.GET(baseURL+"/internal/vcr/v2/issuer/vc/search", wrapper.SearchIssuedVCs)
 	router.DELETE(baseURL+"/internal/vcr/v2/issuer/vc/:id", wrapper.RevokeVC)
+	router.DELETE(baseURL+"/internal/vcr/v2/issuer/vc/:id/suspension", wrapper.UnsuspendVC)
+	router.PUT(baseURL+"/internal/vcr/v2/issuer/vc/:id/suspension", wrapper.SuspendVC)
 	router.POST(baseURL+"/internal/vcr/v2/search", wrapper.SearchVCs)
 	router.GET(baseURL+"/internal/vcr/v2/vc/:id", wrapper.ResolveVC)
 	router.DELETE(baseURL+"/internal/vcr/v2/verifier/trust", wrapper.UntrustIssuer)
@@ -3403,6 +3869,80 @@ func (response RevokeVCdefaultApplicationProblemPlusJSONResponse) VisitRevokeVCR
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
+type UnsuspendVCRequestObject struct {
+	Id string `json:"id"`
+}
+
+type UnsuspendVCResponseObject interface {
+	VisitUnsuspendVCResponse(w http.ResponseWriter) error
+}
+
+type UnsuspendVC204Response struct {
+}
+
+func (response UnsuspendVC204Response) VisitUnsuspendVCResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type UnsuspendVCdefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
+}
+
+func (response UnsuspendVCdefaultApplicationProblemPlusJSONResponse) VisitUnsuspendVCResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type SuspendVCRequestObject struct {
+	Id string `json:"id"`
+}
+
+type SuspendVCResponseObject interface {
+	VisitSuspendVCResponse(w http.ResponseWriter) error
+}
+
+type SuspendVC204Response struct {
+}
+
+func (response SuspendVC204Response) VisitSuspendVCResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type SuspendVCdefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
+}
+
+func (response SuspendVCdefaultApplicationProblemPlusJSONResponse) VisitSuspendVCResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
 type SearchVCsRequestObject struct {
 	Body *SearchVCsJSONRequestBody
 }
@@ -3728,6 +4268,12 @@ type StrictServerInterface interface {
 	// Revoke an issued credential
 	// (DELETE /internal/vcr/v2/issuer/vc/{id})
 	RevokeVC(ctx context.Context, request RevokeVCRequestObject) (RevokeVCResponseObject, error)
+	// Unsuspend an issued credential
+	// (DELETE /internal/vcr/v2/issuer/vc/{id}/suspension)
+	UnsuspendVC(ctx context.Context, request UnsuspendVCRequestObject) (UnsuspendVCResponseObject, error)
+	// Suspend an issued credential
+	// (PUT /internal/vcr/v2/issuer/vc/{id}/suspension)
+	SuspendVC(ctx context.Context, request SuspendVCRequestObject) (SuspendVCResponseObject, error)
 	// Searches for verifiable credentials that could be used for different use-cases.
 	// (POST /internal/vcr/v2/search)
 	SearchVCs(ctx context.Context, request SearchVCsRequestObject) (SearchVCsResponseObject, error)
@@ -3956,6 +4502,56 @@ func (sh *strictHandler) RevokeVC(ctx echo.Context, id string) error {
 	return nil
 }
 
+// UnsuspendVC operation middleware
+func (sh *strictHandler) UnsuspendVC(ctx echo.Context, id string) error {
+	var request UnsuspendVCRequestObject
+
+	request.Id = id
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.UnsuspendVC(ctx.Request().Context(), request.(UnsuspendVCRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UnsuspendVC")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(UnsuspendVCResponseObject); ok {
+		return validResponse.VisitUnsuspendVCResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// SuspendVC operation middleware
+func (sh *strictHandler) SuspendVC(ctx echo.Context, id string) error {
+	var request SuspendVCRequestObject
+
+	request.Id = id
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.SuspendVC(ctx.Request().Context(), request.(SuspendVCRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SuspendVC")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(SuspendVCResponseObject); ok {
+		return validResponse.VisitSuspendVCResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // SearchVCs operation middleware
 func (sh *strictHandler) SearchVCs(ctx echo.Context) error {
 	var request SearchVCsRequestObject
@@ -21,6 +21,8 @@ package v2
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/nuts-foundation/nuts-node/vcr/log"
 	"github.com/sirupsen/logrus"
 	"sort"
@@ -30,6 +32,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/jsonld"
 	"github.com/nuts-foundation/nuts-node/vcr"
+	"github.com/nuts-foundation/nuts-node/vcr/credential/store"
 )
 
 // ResolveVC handles the API request for resolving a VC
@@ -49,18 +52,33 @@ func (w *Wrapper) ResolveVC(ctx context.Context, request ResolveVCRequestObject)
 
 // SearchVCs checks the context used in the JSON-LD query, based on the contents it maps to a non-JSON-LD query
 // After V1, this needs to be remapped to a DB search that supports native JSON-LD
+// If the request contains a filter expression instead of a JSON-LD query, the credentials are searched for in the SQL database.
 func (w *Wrapper) SearchVCs(ctx context.Context, request SearchVCsRequestObject) (SearchVCsResponseObject, error) {
 	untrusted := false
 	if request.Body.SearchOptions != nil && request.Body.SearchOptions.AllowUntrustedIssuer != nil {
 		untrusted = *request.Body.SearchOptions.AllowUntrustedIssuer
 	}
 
-	if credentials, ok := request.Body.Query["credentialSubject"].([]interface{}); ok && len(credentials) > 1 {
+	if request.Body.Filter != nil {
+		if request.Body.Query != nil {
+			return nil, core.InvalidInputError("query and filter can't be combined")
+		}
+		return w.searchVCsWithFilter(ctx, *request.Body, untrusted)
+	}
+	if request.Body.Query == nil {
+		return nil, core.InvalidInputError("either query or filter must be specified")
+	}
+	if request.Body.Sort != nil || request.Body.Offset != nil || request.Body.Limit != nil {
+		return nil, core.InvalidInputError("sort, offset and limit can only be used with filter")
+	}
+	query := *request.Body.Query
+
+	if credentials, ok := query["credentialSubject"].([]interface{}); ok && len(credentials) > 1 {
 		return nil, core.InvalidInputError("can't match on multiple VC subjects")
 	}
 
 	reader := jsonld.Reader{DocumentLoader: w.ContextManager.DocumentLoader()}
-	document, err := reader.Read(request.Body.Query)
+	document, err := reader.Read(query)
 	if err != nil {
 		return nil, core.InvalidInputError("failed to convert query to JSON-LD expanded form: %w", err)
 	}
@@ -88,7 +106,125 @@ func (w *Wrapper) SearchVCs(ctx context.Context, request SearchVCsRequestObject)
 	if err != nil {
 		return nil, err
 	}
-	return SearchVCs200JSONResponse(SearchVCResults{searchResults}), nil
+	return SearchVCs200JSONResponse(SearchVCResults{VerifiableCredentials: searchResults}), nil
+}
+
+// searchVCsWithFilter searches for credentials in the SQL database using the filter expression of the request.
+func (w *Wrapper) searchVCsWithFilter(ctx context.Context, request SearchVCRequest, untrusted bool) (SearchVCsResponseObject, error) {
+	filter, err := toStoreFilter(*request.Filter)
+	if err != nil {
+		return nil, core.InvalidInputError("invalid filter: %w", err)
+	}
+	query := store.Query{Filter: filter}
+	if request.Sort != nil {
+		for _, path := range *request.Sort {
+			field := store.SortField{Path: strings.TrimPrefix(path, "-"), Descending: strings.HasPrefix(path, "-")}
+			if field.Path == "" {
+				return nil, core.InvalidInputError("invalid sort: empty path")
+			}
+			query.Sort = append(query.Sort, field)
+		}
+	}
+	if request.Offset != nil {
+		query.Offset = *request.Offset
+	}
+	if request.Limit != nil {
+		query.Limit = *request.Limit
+	}
+	if query.Offset < 0 || query.Limit < 0 {
+		return nil, core.InvalidInputError("offset and limit must not be negative")
+	}
+
+	results, total, err := w.VCR.Query(ctx, query, untrusted)
+	if err != nil {
+		return nil, err
+	}
+	searchResults, err := w.vcsWithRevocationsToSearchResults(results)
+	if err != nil {
+		return nil, err
+	}
+	return SearchVCs200JSONResponse(SearchVCResults{VerifiableCredentials: searchResults, Total: &total}), nil
+}
+
+// toStoreFilter converts a filter expression from the API to a filter for the SQL credential store.
+// Combinations (and, or, not) and a condition (path) in the same filter object must all match.
+func toStoreFilter(filter SearchFilter) (store.Filter, error) {
+	var result store.And
+	for _, combination := range []struct {
+		filters *[]SearchFilter
+		create  func([]store.Filter) store.Filter
+	}{
+		{filter.And, func(filters []store.Filter) store.Filter { return store.And(filters) }},
+		{filter.Or, func(filters []store.Filter) store.Filter { return store.Or(filters) }},
+	} {
+		if combination.filters == nil {
+			continue
+		}
+		if len(*combination.filters) == 0 {
+			return nil, errors.New("and/or must contain at least one filter")
+		}
+		var filters []store.Filter
+		for _, curr := range *combination.filters {
+			converted, err := toStoreFilter(curr)
+			if err != nil {
+				return nil, err
+			}
+			filters = append(filters, converted)
+		}
+		result = append(result, combination.create(filters))
+	}
+	if filter.Not != nil {
+		converted, err := toStoreFilter(*filter.Not)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, store.Not{Filter: converted})
+	}
+	operators := []struct {
+		operator store.Operator
+		value    *string
+	}{
+		{store.Equal, filter.Eq},
+		{store.NotEqual, filter.Ne},
+		{store.LessThan, filter.Lt},
+		{store.LessOrEqual, filter.Lte},
+		{store.GreaterThan, filter.Gt},
+		{store.GreaterOrEqual, filter.Gte},
+		{store.Prefix, filter.Prefix},
+	}
+	var conditions []store.Condition
+	for _, operator := range operators {
+		if operator.value != nil {
+			conditions = append(conditions, store.Condition{Operator: operator.operator, Values: []string{*operator.value}})
+		}
+	}
+	if filter.In != nil {
+		if len(*filter.In) == 0 {
+			return nil, errors.New("in must contain at least one value")
+		}
+		conditions = append(conditions, store.Condition{Operator: store.In, Values: *filter.In})
+	}
+	if filter.Path == nil || *filter.Path == "" {
+		if len(conditions) > 0 {
+			return nil, errors.New("operators require a path")
+		}
+	} else {
+		if len(conditions) == 0 {
+			return nil, fmt.Errorf("no operator specified for path %s", *filter.Path)
+		}
+		for _, condition := range conditions {
+			condition.Path = *filter.Path
+			result = append(result, condition)
+		}
+	}
+	switch len(result) {
+	case 0:
+		return nil, errors.New("empty filter")
+	case 1:
+		return result[0], nil
+	default:
+		return result, nil
+	}
 }
 
 func flatten(document interface{}, currentPath []string) []vcr.SearchTerm {
@@ -23,10 +23,13 @@ import (
 	"errors"
 	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/jsonld"
 	"github.com/nuts-foundation/nuts-node/vcr"
+	"github.com/nuts-foundation/nuts-node/vcr/credential/store"
 	"github.com/nuts-foundation/nuts-node/vcr/test"
 	"github.com/stretchr/testify/require"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -116,7 +119,7 @@ func TestWrapper_SearchVCs(t *testing.T) {
 		actualVC := test.ValidNutsAuthorizationCredential(t)
 		ctx.vcr.EXPECT().Search(ctx.requestCtx, searchTerms, false, gomock.Any()).Return([]vc.VerifiableCredential{actualVC}, nil)
 		ctx.mockVerifier.EXPECT().GetRevocation(*actualVC.ID).Return(nil, nil)
-		expectedResponse := SearchVCs200JSONResponse(SearchVCResults{[]SearchVCResult{{VerifiableCredential: actualVC}}})
+		expectedResponse := SearchVCs200JSONResponse(SearchVCResults{VerifiableCredentials: []SearchVCResult{{VerifiableCredential: actualVC}}})
 
 		response, err := ctx.client.SearchVCs(ctx.requestCtx, SearchVCsRequestObject{Body: &request})
 
@@ -154,7 +157,7 @@ func TestWrapper_SearchVCs(t *testing.T) {
 		actualVC := test.ValidNutsAuthorizationCredential(t)
 		ctx.vcr.EXPECT().Search(ctx.requestCtx, searchTerms, false, gomock.Any()).Return([]vc.VerifiableCredential{actualVC}, nil)
 		ctx.mockVerifier.EXPECT().GetRevocation(*actualVC.ID).Return(nil, nil)
-		expectedResponse := SearchVCs200JSONResponse(SearchVCResults{[]SearchVCResult{{VerifiableCredential: actualVC}}})
+		expectedResponse := SearchVCs200JSONResponse(SearchVCResults{VerifiableCredentials: []SearchVCResult{{VerifiableCredential: actualVC}}})
 
 		response, err := ctx.client.SearchVCs(ctx.requestCtx, SearchVCsRequestObject{Body: &request})
 
@@ -190,7 +193,7 @@ func TestWrapper_SearchVCs(t *testing.T) {
 		actualVC := test.ValidNutsAuthorizationCredential(t)
 		ctx.vcr.EXPECT().Search(ctx.requestCtx, searchTerms, false, gomock.Any()).Return([]vc.VerifiableCredential{actualVC}, nil)
 		ctx.mockVerifier.EXPECT().GetRevocation(*actualVC.ID).Return(nil, nil)
-		expectedResponse := SearchVCs200JSONResponse(SearchVCResults{[]SearchVCResult{{VerifiableCredential: actualVC}}})
+		expectedResponse := SearchVCs200JSONResponse(SearchVCResults{VerifiableCredentials: []SearchVCResult{{VerifiableCredential: actualVC}}})
 
 		response, err := ctx.client.SearchVCs(ctx.requestCtx, SearchVCsRequestObject{Body: &request})
 
@@ -204,7 +207,7 @@ func TestWrapper_SearchVCs(t *testing.T) {
 		err := json.Unmarshal([]byte(organizationQuery), &request)
 		require.NoError(t, err)
 		ctx.vcr.EXPECT().Search(ctx.requestCtx, searchTerms, false, gomock.Any()).Return([]vc.VerifiableCredential{}, nil)
-		expectedResponse := SearchVCs200JSONResponse(SearchVCResults{[]SearchVCResult{}})
+		expectedResponse := SearchVCs200JSONResponse(SearchVCResults{VerifiableCredentials: []SearchVCResult{}})
 
 		response, err := ctx.client.SearchVCs(ctx.requestCtx, SearchVCsRequestObject{Body: &request})
 
@@ -231,7 +234,7 @@ func TestWrapper_SearchVCs(t *testing.T) {
 				assert.Equal(t, 2, count)
 			}
 		})
-		expectedResponse := SearchVCs200JSONResponse(SearchVCResults{[]SearchVCResult{}})
+		expectedResponse := SearchVCs200JSONResponse(SearchVCResults{VerifiableCredentials: []SearchVCResult{}})
 
 		response, err := ctx.client.SearchVCs(ctx.requestCtx, SearchVCsRequestObject{Body: &request})
 
@@ -245,7 +248,7 @@ func TestWrapper_SearchVCs(t *testing.T) {
 		err := json.Unmarshal([]byte(untrustedOrganizationQuery), &request)
 		require.NoError(t, err)
 		ctx.vcr.EXPECT().Search(ctx.requestCtx, searchTerms, true, gomock.Any()).Return([]vc.VerifiableCredential{}, nil)
-		expectedResponse := SearchVCs200JSONResponse(SearchVCResults{[]SearchVCResult{}})
+		expectedResponse := SearchVCs200JSONResponse(SearchVCResults{VerifiableCredentials: []SearchVCResult{}})
 
 		response, err := ctx.client.SearchVCs(ctx.requestCtx, SearchVCsRequestObject{Body: &request})
 
@@ -321,6 +324,105 @@ func TestWrapper_SearchVCs(t *testing.T) {
 	})
 }
 
+func TestWrapper_SearchVCs_filter(t *testing.T) {
+	const filterQuery = `
+{
+	"filter": {
+		"and": [
+			{ "path": "type", "eq": "NutsOrganizationCredential" },
+			{ "or": [
+				{ "path": "credentialSubject.organization.city", "in": ["Amandelmere", "Notendam"] },
+				{ "not": { "path": "credentialSubject.organization.name", "prefix": "Zorggroep" } }
+			] },
+			{ "path": "credentialSubject.organization.since", "gte": "2020-01-01", "lt": "2025-01-01" }
+		]
+	},
+	"sort": ["credentialSubject.organization.name", "-issuer"],
+	"offset": 10,
+	"limit": 5,
+	"searchOptions": {
+		"allowUntrustedIssuer": true
+	}
+}
+`
+	expectedQuery := store.Query{
+		Filter: store.And{
+			store.Condition{Path: "type", Operator: store.Equal, Values: []string{"NutsOrganizationCredential"}},
+			store.Or{
+				store.Condition{Path: "credentialSubject.organization.city", Operator: store.In, Values: []string{"Amandelmere", "Notendam"}},
+				store.Not{Filter: store.Condition{Path: "credentialSubject.organization.name", Operator: store.Prefix, Values: []string{"Zorggroep"}}},
+			},
+			store.And{
+				store.Condition{Path: "credentialSubject.organization.since", Operator: store.LessThan, Values: []string{"2025-01-01"}},
+				store.Condition{Path: "credentialSubject.organization.since", Operator: store.GreaterOrEqual, Values: []string{"2020-01-01"}},
+			},
+		},
+		Sort: []store.SortField{
+			{Path: "credentialSubject.organization.name"},
+			{Path: "issuer", Descending: true},
+		},
+		Offset: 10,
+		Limit:  5,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ctx := newMockContext(t)
+		request := SearchVCsJSONRequestBody{}
+		require.NoError(t, json.Unmarshal([]byte(filterQuery), &request))
+		actualVC := test.ValidNutsOrganizationCredential(t)
+		ctx.vcr.EXPECT().Query(ctx.requestCtx, expectedQuery, true).Return([]vc.VerifiableCredential{actualVC}, 12, nil)
+		ctx.mockVerifier.EXPECT().GetRevocation(*actualVC.ID).Return(nil, nil)
+		total := 12
+		expectedResponse := SearchVCs200JSONResponse(SearchVCResults{VerifiableCredentials: []SearchVCResult{{VerifiableCredential: actualVC}}, Total: &total})
+
+		response, err := ctx.client.SearchVCs(ctx.requestCtx, SearchVCsRequestObject{Body: &request})
+
+		require.NoError(t, err)
+		assert.Equal(t, expectedResponse, response)
+	})
+	t.Run("error - Query fails", func(t *testing.T) {
+		ctx := newMockContext(t)
+		request := SearchVCsJSONRequestBody{}
+		require.NoError(t, json.Unmarshal([]byte(filterQuery), &request))
+		ctx.vcr.EXPECT().Query(ctx.requestCtx, gomock.Any(), true).Return(nil, 0, errors.New("failure"))
+
+		_, err := ctx.client.SearchVCs(ctx.requestCtx, SearchVCsRequestObject{Body: &request})
+
+		assert.EqualError(t, err, "failure")
+	})
+	t.Run("invalid requests", func(t *testing.T) {
+		testCases := []struct {
+			name    string
+			request string
+			err     string
+		}{
+			{"query and filter", `{"query": {}, "filter": {"path": "id", "eq": "1"}}`, "query and filter can't be combined"},
+			{"neither query nor filter", `{}`, "either query or filter must be specified"},
+			{"pagination with query", `{"query": {}, "limit": 10}`, "sort, offset and limit can only be used with filter"},
+			{"empty filter", `{"filter": {}}`, "invalid filter: empty filter"},
+			{"empty and", `{"filter": {"and": []}}`, "invalid filter: and/or must contain at least one filter"},
+			{"empty in", `{"filter": {"path": "id", "in": []}}`, "invalid filter: in must contain at least one value"},
+			{"operator without path", `{"filter": {"eq": "1"}}`, "invalid filter: operators require a path"},
+			{"path without operator", `{"filter": {"path": "id"}}`, "invalid filter: no operator specified for path id"},
+			{"invalid nested filter", `{"filter": {"not": {"or": [{"path": "id"}]}}}`, "invalid filter: no operator specified for path id"},
+			{"empty sort path", `{"filter": {"path": "id", "eq": "1"}, "sort": ["-"]}`, "invalid sort: empty path"},
+			{"negative limit", `{"filter": {"path": "id", "eq": "1"}, "limit": -1}`, "offset and limit must not be negative"},
+		}
+		for _, testCase := range testCases {
+			t.Run(testCase.name, func(t *testing.T) {
+				ctx := newMockContext(t)
+				request := SearchVCsJSONRequestBody{}
+				require.NoError(t, json.Unmarshal([]byte(testCase.request), &request))
+
+				_, err := ctx.client.SearchVCs(ctx.requestCtx, SearchVCsRequestObject{Body: &request})
+
+				assert.EqualError(t, err, testCase.err)
+				assert.ErrorIs(t, err, core.Error(http.StatusBadRequest, ""))
+			})
+		}
+	})
+}
+
 func TestWrapper_ResolveVC(t *testing.T) {
 	id := ssi.MustParseURI("did:nuts:some-did#some-vc")
 
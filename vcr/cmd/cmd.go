@@ -40,6 +40,10 @@ func FlagSet() *pflag.FlagSet {
 	flagSet.String("vcr.openid4vci.definitionsdir", defs.OpenID4VCI.DefinitionsDIR, "Directory with the additional credential definitions the node could issue (experimental, may change without notice).")
 	flagSet.Bool("vcr.openid4vci.enabled", defs.OpenID4VCI.Enabled, "Enable issuing and receiving credentials over OpenID4VCI.")
 	flagSet.Duration("vcr.openid4vci.timeout", time.Second*30, "Time-out for OpenID4VCI HTTP client operations.")
+	flagSet.String("vcr.trust.rulesfile", defs.Trust.RulesFile, "Path to a YAML file with trust rules, which trust credential issuers by DID method, did:x509 CA or remote trust lists. "+
+		"Issuers trusted through 'nuts vcr trust' are always trusted.")
+	flagSet.StringSlice("vcr.schema.allowedurls", defs.Schema.AllowedURLs, "URL prefixes (e.g. https://schemas.example.com/) JSON Schemas in credentialSchema may be downloaded from. "+
+		"Credentials referring to other schema URLs can't be issued or verified.")
 
 	return flagSet
 }
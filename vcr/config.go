@@ -31,6 +31,22 @@ const ModuleName = "VCR"
 type Config struct {
 	// OpenID4VCI holds the config for the OpenID4VCI credential issuer and wallet
 	OpenID4VCI openid4vci.Config `koanf:"openid4vci"`
+	// Trust holds the config for trusting credential issuers
+	Trust TrustConfig `koanf:"trust"`
+	// Schema holds the config for validating credentials against JSON Schemas
+	Schema SchemaConfig `koanf:"schema"`
+}
+
+// SchemaConfig holds the config for validating credentials against the JSON Schemas in their credentialSchema
+type SchemaConfig struct {
+	// AllowedURLs contains the URL prefixes JSON Schemas may be downloaded from.
+	AllowedURLs []string `koanf:"allowedurls"`
+}
+
+// TrustConfig holds the config for trusting credential issuers
+type TrustConfig struct {
+	// RulesFile is the path to a YAML file with trust rules and remote trust lists.
+	RulesFile string `koanf:"rulesfile"`
 }
 
 // DefaultConfig returns a fresh Config filled with default values
@@ -0,0 +1,314 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package credential
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/santhosh-tekuri/jsonschema"
+	// disables loading of schemas referenced by $ref from the filesystem or network
+	_ "github.com/nuts-foundation/nuts-node/vcr/pe/schema/v2"
+)
+
+const (
+	// JsonSchemaType is the credentialSchema type for a JSON Schema that can be downloaded from the credentialSchema's id.
+	JsonSchemaType = "JsonSchema"
+	// JsonSchemaCredentialType is the credentialSchema type for a credential containing the JSON Schema (in credentialSubject.jsonSchema),
+	// which can be downloaded from the credentialSchema's id.
+	JsonSchemaCredentialType = "JsonSchemaCredential"
+)
+
+// maxSchemaSize is the maximum size of a downloaded JSON Schema (or JsonSchemaCredential) in bytes.
+const maxSchemaSize = 1024 * 1024
+
+// maxCompiledSchemas is the maximum number of compiled schemas kept in memory.
+const maxCompiledSchemas = 100
+
+// CredentialSchema is an entry of the credentialSchema property of a Verifiable Credential,
+// as specified by https://www.w3.org/TR/vc-json-schema/
+type CredentialSchema struct {
+	// ID is the URL of the JSON Schema or JsonSchemaCredential.
+	ID string `json:"id"`
+	// Type is either JsonSchema or JsonSchemaCredential.
+	Type string `json:"type"`
+}
+
+// CredentialSchemas returns the credentialSchema entries of the given credential.
+// The credentialSchema property isn't part of vc.VerifiableCredential, so it's read from the raw credential.
+// It returns no entries if the credential doesn't have a raw representation (i.e. it wasn't parsed).
+func CredentialSchemas(credential vc.VerifiableCredential) ([]CredentialSchema, error) {
+	document, err := credentialDocument(credential)
+	if err != nil || document == nil {
+		return nil, err
+	}
+	value, ok := document["credentialSchema"]
+	if !ok {
+		return nil, nil
+	}
+	// credentialSchema is either a single object or an array of objects
+	if _, isArray := value.([]interface{}); !isArray {
+		value = []interface{}{value}
+	}
+	data, _ := json.Marshal(value)
+	var result []CredentialSchema
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("invalid credentialSchema: %w", err)
+	}
+	for _, schema := range result {
+		if schema.ID == "" || schema.Type == "" {
+			return nil, errors.New("invalid credentialSchema: id and type are required")
+		}
+	}
+	return result, nil
+}
+
+// credentialDocument returns the JSON document of the credential that is validated against its JSON schemas.
+// For JSON-LD credentials that's the credential itself, for JWT credentials it's the 'vc' claim.
+// The 'vc' claim always contains credentialSubject and credentialStatus as array,
+// so a single credentialSubject or credentialStatus is unwrapped to match how it's represented in JSON-LD credentials.
+func credentialDocument(credential vc.VerifiableCredential) (map[string]interface{}, error) {
+	var document map[string]interface{}
+	switch credential.Format() {
+	case vc.JWTCredentialProofFormat:
+		token := credential.JWT()
+		if token == nil {
+			return nil, nil
+		}
+		if claim, ok := token.PrivateClaims()["vc"].(map[string]interface{}); ok {
+			// copy, since the claim is shared with the credential
+			document = maps.Clone(claim)
+			for _, key := range []string{"credentialSubject", "credentialStatus"} {
+				if values, isArray := document[key].([]interface{}); isArray && len(values) == 1 {
+					document[key] = values[0]
+				}
+			}
+		}
+	case vc.JSONLDCredentialProofFormat:
+		if credential.Raw() == "" {
+			return nil, nil
+		}
+		if err := json.Unmarshal([]byte(credential.Raw()), &document); err != nil {
+			return nil, fmt.Errorf("invalid credential: %w", err)
+		}
+	}
+	return document, nil
+}
+
+// ErrSchemaURLNotAllowed is returned when a credentialSchema refers to a URL that isn't allowed to be downloaded.
+var ErrSchemaURLNotAllowed = errors.New("credentialSchema URL is not allowed")
+
+// ErrSchemaUnavailable is returned when a credentialSchema can't be loaded, e.g. because its URL isn't allowed,
+// it can't be downloaded or it isn't a valid JSON Schema. It means the credential couldn't be validated against the schema,
+// not that it doesn't conform to it.
+var ErrSchemaUnavailable = errors.New("unable to load credentialSchema")
+
+// SchemaValidator validates credentials against the JSON Schemas referenced by their credentialSchema property.
+// Schemas are downloaded using the given HTTP client, which should cache responses and must not follow redirects.
+// Only schemas from the allowed URLs are downloaded, to prevent credentials from making the node send requests to arbitrary URLs.
+// Compiled schemas are kept in memory, keyed by their contents. The least recently used ones are evicted when the limit is reached.
+type SchemaValidator struct {
+	httpClient  core.HTTPRequestDoer
+	allowedURLs []*url.URL
+	timeout     time.Duration
+	// VerifySchemaCredential is used to verify the signature of a JsonSchemaCredential.
+	VerifySchemaCredential func(credential vc.VerifiableCredential) error
+	// compiledOrder is ordered from most to least recently used
+	compiledOrder *list.List
+	compiled      map[[32]byte]*list.Element
+	mutex         sync.Mutex
+}
+
+type compiledSchema struct {
+	key    [32]byte
+	schema *jsonschema.Schema
+}
+
+// NewSchemaValidator creates a new SchemaValidator that downloads schemas using the given HTTP client.
+// Schemas can only be downloaded from URLs starting with one of the allowed URLs (matching scheme, host and path prefix).
+// Validating a credential (including downloading its schemas) times out after the given timeout.
+func NewSchemaValidator(httpClient core.HTTPRequestDoer, allowedURLs []string, timeout time.Duration) (*SchemaValidator, error) {
+	result := &SchemaValidator{
+		httpClient:    httpClient,
+		timeout:       timeout,
+		compiledOrder: list.New(),
+		compiled:      map[[32]byte]*list.Element{},
+	}
+	for _, allowedURL := range allowedURLs {
+		parsed, err := url.Parse(allowedURL)
+		if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+			return nil, fmt.Errorf("invalid allowed credentialSchema URL: %s", allowedURL)
+		}
+		result.allowedURLs = append(result.allowedURLs, parsed)
+	}
+	return result, nil
+}
+
+// Validate validates the credential against the JSON Schemas in its credentialSchema property.
+// It returns nil if the credential doesn't have a credentialSchema. Entries with an unsupported type are ignored.
+func (s *SchemaValidator) Validate(ctx context.Context, credential vc.VerifiableCredential) error {
+	schemas, err := CredentialSchemas(credential)
+	if err != nil || len(schemas) == 0 {
+		return err
+	}
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+	document, _ := credentialDocument(credential)
+	documentJSON, _ := json.Marshal(document)
+	for _, credentialSchema := range schemas {
+		var schema *jsonschema.Schema
+		switch credentialSchema.Type {
+		case JsonSchemaType:
+			schema, err = s.loadJsonSchema(ctx, credentialSchema.ID)
+		case JsonSchemaCredentialType:
+			schema, err = s.loadJsonSchemaCredential(ctx, credentialSchema.ID)
+		default:
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("%w (id=%s): %w", ErrSchemaUnavailable, credentialSchema.ID, err)
+		}
+		if err := schema.Validate(bytes.NewReader(documentJSON)); err != nil {
+			return fmt.Errorf("credential does not conform to credentialSchema (id=%s): %w", credentialSchema.ID, err)
+		}
+	}
+	return nil
+}
+
+func (s *SchemaValidator) loadJsonSchema(ctx context.Context, schemaURL string) (*jsonschema.Schema, error) {
+	data, err := s.download(ctx, schemaURL)
+	if err != nil {
+		return nil, err
+	}
+	return s.compile(schemaURL, data)
+}
+
+func (s *SchemaValidator) loadJsonSchemaCredential(ctx context.Context, schemaURL string) (*jsonschema.Schema, error) {
+	data, err := s.download(ctx, schemaURL)
+	if err != nil {
+		return nil, err
+	}
+	var schemaCredential vc.VerifiableCredential
+	if err := json.Unmarshal(data, &schemaCredential); err != nil {
+		return nil, fmt.Errorf("invalid JsonSchemaCredential: %w", err)
+	}
+	if !schemaCredential.IsType(JsonSchemaCredentialTypeURI) {
+		return nil, fmt.Errorf("credential is not of type %s", JsonSchemaCredentialType)
+	}
+	if s.VerifySchemaCredential != nil {
+		if err := s.VerifySchemaCredential(schemaCredential); err != nil {
+			return nil, fmt.Errorf("invalid JsonSchemaCredential: %w", err)
+		}
+	}
+	var subjects []struct {
+		Type       string          `json:"type"`
+		JsonSchema json.RawMessage `json:"jsonSchema"`
+	}
+	if err := schemaCredential.UnmarshalCredentialSubject(&subjects); err != nil || len(subjects) != 1 {
+		return nil, errors.New("invalid JsonSchemaCredential: expected a single credentialSubject")
+	}
+	if subjects[0].Type != JsonSchemaType || len(subjects[0].JsonSchema) == 0 {
+		return nil, errors.New("invalid JsonSchemaCredential: credentialSubject must be of type JsonSchema and contain jsonSchema")
+	}
+	return s.compile(schemaURL, subjects[0].JsonSchema)
+}
+
+// isAllowed returns whether the given URL starts with one of the allowed URLs.
+func (s *SchemaValidator) isAllowed(schemaURL *url.URL) bool {
+	if schemaURL.User != nil || slices.Contains(strings.Split(schemaURL.Path, "/"), "..") {
+		return false
+	}
+	for _, allowedURL := range s.allowedURLs {
+		if strings.EqualFold(schemaURL.Scheme, allowedURL.Scheme) && strings.EqualFold(schemaURL.Host, allowedURL.Host) &&
+			strings.HasPrefix(schemaURL.Path, allowedURL.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// download retrieves the resource at the given URL, if it's allowed.
+func (s *SchemaValidator) download(ctx context.Context, schemaURL string) ([]byte, error) {
+	parsedURL, err := url.Parse(schemaURL)
+	if err != nil {
+		return nil, err
+	}
+	if !s.isAllowed(parsedURL) {
+		return nil, ErrSchemaURLNotAllowed
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, parsedURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := s.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP response status: %d", response.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(response.Body, maxSchemaSize))
+}
+
+// compile compiles the JSON Schema, or returns the previously compiled schema if the contents didn't change.
+// References to other schemas are not resolved.
+func (s *SchemaValidator) compile(schemaURL string, data []byte) (*jsonschema.Schema, error) {
+	key := sha256.Sum256(append([]byte(schemaURL+"\n"), data...))
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if element, ok := s.compiled[key]; ok {
+		s.compiledOrder.MoveToFront(element)
+		return element.Value.(compiledSchema).schema, nil
+	}
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft7
+	if err := compiler.AddResource(schemaURL, bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("invalid JSON Schema: %w", err)
+	}
+	schema, err := compiler.Compile(schemaURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON Schema: %w", err)
+	}
+	s.compiled[key] = s.compiledOrder.PushFront(compiledSchema{key: key, schema: schema})
+	for s.compiledOrder.Len() > maxCompiledSchemas {
+		oldest := s.compiledOrder.Back()
+		s.compiledOrder.Remove(oldest)
+		delete(s.compiled, oldest.Value.(compiledSchema).key)
+	}
+	return schema, nil
+}
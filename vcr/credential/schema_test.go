@@ -0,0 +1,270 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package credential
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nuts-foundation/go-did/vc"
+	http2 "github.com/nuts-foundation/nuts-node/test/http"
+	"github.com/nuts-foundation/nuts-node/vcr/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "properties": {
+    "credentialSubject": {
+      "type": "object",
+      "required": ["name"],
+      "properties": {
+        "name": {"type": "string"}
+      }
+    }
+  }
+}`
+
+func testSchemaCredential(t *testing.T, schemaURL string, schemaType string, subject string) vc.VerifiableCredential {
+	t.Helper()
+	raw := `{
+  "@context": ["https://www.w3.org/2018/credentials/v1"],
+  "type": ["VerifiableCredential", "MembershipCredential"],
+  "issuer": "did:web:example.com",
+  "issuanceDate": "2025-01-01T00:00:00Z",
+  "credentialSchema": {"id": "` + schemaURL + `", "type": "` + schemaType + `"},
+  "credentialSubject": ` + subject + `
+}`
+	result, err := vc.ParseVerifiableCredential(raw)
+	require.NoError(t, err)
+	return *result
+}
+
+func TestCredentialSchemas(t *testing.T) {
+	t.Run("single", func(t *testing.T) {
+		credential := testSchemaCredential(t, "https://example.com/schema.json", JsonSchemaType, `{"id": "did:web:example.com:alice"}`)
+
+		schemas, err := CredentialSchemas(credential)
+
+		require.NoError(t, err)
+		assert.Equal(t, []CredentialSchema{{ID: "https://example.com/schema.json", Type: JsonSchemaType}}, schemas)
+	})
+	t.Run("none", func(t *testing.T) {
+		schemas, err := CredentialSchemas(test.ValidNutsOrganizationCredential(t))
+
+		require.NoError(t, err)
+		assert.Empty(t, schemas)
+	})
+	t.Run("not parsed", func(t *testing.T) {
+		schemas, err := CredentialSchemas(vc.VerifiableCredential{})
+
+		require.NoError(t, err)
+		assert.Empty(t, schemas)
+	})
+	t.Run("missing type", func(t *testing.T) {
+		credential := testSchemaCredential(t, "https://example.com/schema.json", "", `{"id": "did:web:example.com:alice"}`)
+
+		_, err := CredentialSchemas(credential)
+
+		assert.EqualError(t, err, "invalid credentialSchema: id and type are required")
+	})
+}
+
+func TestSchemaValidator_Validate(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(&http2.Handler{StatusCode: http.StatusOK, ResponseData: testSchema})
+	defer server.Close()
+
+	t.Run("JsonSchema", func(t *testing.T) {
+		validator := newTestSchemaValidator(t, server.URL)
+
+		t.Run("ok", func(t *testing.T) {
+			credential := testSchemaCredential(t, server.URL, JsonSchemaType, `{"id": "did:web:example.com:alice", "name": "Alice"}`)
+
+			err := validator.Validate(ctx, credential)
+
+			assert.NoError(t, err)
+			assert.Len(t, validator.compiled, 1)
+		})
+		t.Run("does not conform", func(t *testing.T) {
+			credential := testSchemaCredential(t, server.URL, JsonSchemaType, `{"id": "did:web:example.com:alice"}`)
+
+			err := validator.Validate(ctx, credential)
+
+			assert.ErrorContains(t, err, "credential does not conform to credentialSchema (id="+server.URL+")")
+			assert.Len(t, validator.compiled, 1, "expected compiled schema to be reused")
+		})
+	})
+	t.Run("JsonSchemaCredential", func(t *testing.T) {
+		schemaCredential := `{
+  "@context": ["https://www.w3.org/2018/credentials/v1"],
+  "type": ["VerifiableCredential", "JsonSchemaCredential"],
+  "issuer": "did:web:example.com",
+  "issuanceDate": "2025-01-01T00:00:00Z",
+  "credentialSubject": {"id": "https://example.com/schema", "type": "JsonSchema", "jsonSchema": ` + testSchema + `}
+}`
+		schemaServer := httptest.NewServer(&http2.Handler{StatusCode: http.StatusOK, ResponseData: schemaCredential})
+		defer schemaServer.Close()
+
+		t.Run("ok", func(t *testing.T) {
+			validator := newTestSchemaValidator(t, schemaServer.URL)
+			var verified bool
+			validator.VerifySchemaCredential = func(credential vc.VerifiableCredential) error {
+				verified = true
+				return nil
+			}
+			credential := testSchemaCredential(t, schemaServer.URL, JsonSchemaCredentialType, `{"id": "did:web:example.com:alice", "name": "Alice"}`)
+
+			err := validator.Validate(ctx, credential)
+
+			assert.NoError(t, err)
+			assert.True(t, verified)
+		})
+		t.Run("does not conform", func(t *testing.T) {
+			validator := newTestSchemaValidator(t, schemaServer.URL)
+			credential := testSchemaCredential(t, schemaServer.URL, JsonSchemaCredentialType, `{"id": "did:web:example.com:alice", "name": 1}`)
+
+			err := validator.Validate(ctx, credential)
+
+			assert.ErrorContains(t, err, "credential does not conform to credentialSchema")
+		})
+		t.Run("invalid signature", func(t *testing.T) {
+			validator := newTestSchemaValidator(t, schemaServer.URL)
+			validator.VerifySchemaCredential = func(credential vc.VerifiableCredential) error {
+				return errors.New("invalid signature")
+			}
+			credential := testSchemaCredential(t, schemaServer.URL, JsonSchemaCredentialType, `{"id": "did:web:example.com:alice", "name": "Alice"}`)
+
+			err := validator.Validate(ctx, credential)
+
+			assert.EqualError(t, err, "unable to load credentialSchema (id="+schemaServer.URL+"): invalid JsonSchemaCredential: invalid signature")
+		})
+		t.Run("not a JsonSchemaCredential", func(t *testing.T) {
+			validator := newTestSchemaValidator(t, server.URL)
+			credential := testSchemaCredential(t, server.URL, JsonSchemaCredentialType, `{"id": "did:web:example.com:alice", "name": "Alice"}`)
+
+			err := validator.Validate(ctx, credential)
+
+			assert.ErrorContains(t, err, "unable to load credentialSchema")
+		})
+	})
+	t.Run("unsupported JSON Schema version", func(t *testing.T) {
+		schemaServer := httptest.NewServer(&http2.Handler{StatusCode: http.StatusOK, ResponseData: `{"$schema": "https://json-schema.org/draft/2020-12/schema"}`})
+		defer schemaServer.Close()
+		credential := testSchemaCredential(t, schemaServer.URL, JsonSchemaType, `{"id": "did:web:example.com:alice"}`)
+
+		err := newTestSchemaValidator(t, schemaServer.URL).Validate(ctx, credential)
+
+		assert.ErrorContains(t, err, "invalid JSON Schema: unknown $schema")
+	})
+	t.Run("schema not found", func(t *testing.T) {
+		schemaServer := httptest.NewServer(&http2.Handler{StatusCode: http.StatusNotFound})
+		defer schemaServer.Close()
+		credential := testSchemaCredential(t, schemaServer.URL, JsonSchemaType, `{"id": "did:web:example.com:alice"}`)
+
+		err := newTestSchemaValidator(t, schemaServer.URL).Validate(ctx, credential)
+
+		assert.EqualError(t, err, "unable to load credentialSchema (id="+schemaServer.URL+"): unexpected HTTP response status: 404")
+	})
+	t.Run("unknown credentialSchema type is ignored", func(t *testing.T) {
+		credential := testSchemaCredential(t, "https://example.com/schema", "OtherSchema", `{"id": "did:web:example.com:alice"}`)
+
+		err := newTestSchemaValidator(t).Validate(ctx, credential)
+
+		assert.NoError(t, err)
+	})
+	t.Run("URL not allowed", func(t *testing.T) {
+		for _, schemaURL := range []string{
+			"http://localhost:8080/schema",
+			server.URL + "/../schema",
+			strings.Replace(server.URL, "http://", "http://user@", 1),
+		} {
+			credential := testSchemaCredential(t, schemaURL, JsonSchemaType, `{"id": "did:web:example.com:alice"}`)
+
+			err := newTestSchemaValidator(t, server.URL).Validate(ctx, credential)
+
+			assert.ErrorIs(t, err, ErrSchemaURLNotAllowed, schemaURL)
+		}
+	})
+	t.Run("allowed URL prefix", func(t *testing.T) {
+		credential := testSchemaCredential(t, server.URL+"/schemas/person", JsonSchemaType, `{"id": "did:web:example.com:alice", "name": "Alice"}`)
+
+		assert.NoError(t, newTestSchemaValidator(t, server.URL+"/schemas/").Validate(ctx, credential))
+		assert.ErrorIs(t, newTestSchemaValidator(t, server.URL+"/other/").Validate(ctx, credential), ErrSchemaURLNotAllowed)
+	})
+	t.Run("timeout", func(t *testing.T) {
+		slowServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			<-request.Context().Done()
+		}))
+		defer slowServer.Close()
+		credential := testSchemaCredential(t, slowServer.URL, JsonSchemaType, `{"id": "did:web:example.com:alice"}`)
+		validator, err := NewSchemaValidator(http.DefaultClient, []string{slowServer.URL}, 10*time.Millisecond)
+		require.NoError(t, err)
+
+		err = validator.Validate(ctx, credential)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestNewSchemaValidator(t *testing.T) {
+	t.Run("invalid allowed URL", func(t *testing.T) {
+		_, err := NewSchemaValidator(http.DefaultClient, []string{"example.com"}, time.Minute)
+
+		assert.EqualError(t, err, "invalid allowed credentialSchema URL: example.com")
+	})
+}
+
+func TestSchemaValidator_compile(t *testing.T) {
+	t.Run("least recently used schema is evicted", func(t *testing.T) {
+		validator := newTestSchemaValidator(t)
+		first, err := validator.compile("https://example.com/0", []byte(testSchema))
+		require.NoError(t, err)
+		for i := 1; i < maxCompiledSchemas; i++ {
+			_, err := validator.compile(fmt.Sprintf("https://example.com/%d", i), []byte(testSchema))
+			require.NoError(t, err)
+		}
+		// use the first schema, so the second one is the least recently used
+		reused, err := validator.compile("https://example.com/0", []byte(testSchema))
+		require.NoError(t, err)
+		require.Same(t, first, reused)
+
+		_, err = validator.compile("https://example.com/new", []byte(testSchema))
+		require.NoError(t, err)
+
+		assert.Len(t, validator.compiled, maxCompiledSchemas)
+		assert.Contains(t, validator.compiled, sha256.Sum256([]byte("https://example.com/0\n"+testSchema)))
+		assert.NotContains(t, validator.compiled, sha256.Sum256([]byte("https://example.com/1\n"+testSchema)))
+	})
+}
+
+func newTestSchemaValidator(t *testing.T, allowedURLs ...string) *SchemaValidator {
+	validator, err := NewSchemaValidator(http.DefaultClient, allowedURLs, time.Minute)
+	require.NoError(t, err)
+	return validator
+}
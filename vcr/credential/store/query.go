@@ -0,0 +1,284 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package store
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Operator specifies how a Condition compares the value of a credential property.
+type Operator string
+
+const (
+	// Equal matches if the value equals the given value.
+	Equal Operator = "eq"
+	// NotEqual matches if the credential has the property, and its value differs from the given value.
+	NotEqual Operator = "ne"
+	// LessThan matches if the value is lower than the given value.
+	LessThan Operator = "lt"
+	// LessOrEqual matches if the value is lower than or equal to the given value.
+	LessOrEqual Operator = "lte"
+	// GreaterThan matches if the value is higher than the given value.
+	GreaterThan Operator = "gt"
+	// GreaterOrEqual matches if the value is higher than or equal to the given value.
+	GreaterOrEqual Operator = "gte"
+	// Prefix matches if the value starts with the given value.
+	Prefix Operator = "prefix"
+	// In matches if the value equals any of the given values.
+	In Operator = "in"
+)
+
+var sqlOperators = map[Operator]string{
+	Equal:          "=",
+	NotEqual:       "<>",
+	LessThan:       "<",
+	LessOrEqual:    "<=",
+	GreaterThan:    ">",
+	GreaterOrEqual: ">=",
+	Prefix:         "LIKE",
+	In:             "IN",
+}
+
+// rangeOperators are the operators that compare values numerically if the given value is a number.
+var rangeOperators = map[Operator]bool{
+	LessThan:       true,
+	LessOrEqual:    true,
+	GreaterThan:    true,
+	GreaterOrEqual: true,
+}
+
+// numberPattern matches the (decimal) numbers that are compared numerically.
+// Its syntax is supported by the regular expressions of Go, Postgres and MySQL.
+const numberPattern = `^[+-]?([0-9]+[.]?[0-9]*|[.][0-9]+)([eE][+-]?[0-9]+)?$`
+
+var numberRegex = regexp.MustCompile(numberPattern)
+
+// likeEscaper escapes the characters that have a special meaning in LIKE patterns, using '!' as escape character.
+// '[' is escaped for SQL Server, which supports character ranges in LIKE patterns.
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_", "[", "![")
+
+// Filter is a condition on Verifiable Credentials stored in the SQL database.
+// It's one of And, Or, Not or Condition.
+type Filter interface {
+	// where returns the SQL condition and its arguments, for the given SQL dialect (e.g. sqlite, postgres).
+	where(dialect string) (string, []interface{}, error)
+}
+
+// And matches credentials that match all of the filters.
+type And []Filter
+
+// Or matches credentials that match any of the filters.
+type Or []Filter
+
+// Not matches credentials that don't match the filter.
+type Not struct {
+	Filter Filter
+}
+
+// Condition compares the value of a credential property, identified by its JSON path (e.g. credentialSubject.organization.city), with the given values.
+// Only string properties can be compared. Values are compared as strings, except for the range operators (lt, lte, gt, gte) given a number:
+// they compare numerically, and only match properties containing a number (e.g. "9" is lower than "10").
+// The In operator takes one or more values, the other operators exactly one.
+type Condition struct {
+	Path     string
+	Operator Operator
+	Values   []string
+}
+
+// SortField specifies a JSON path to sort credentials on.
+type SortField struct {
+	Path       string
+	Descending bool
+}
+
+// Query is a search for Verifiable Credentials in the SQL database.
+type Query struct {
+	// Filter specifies which credentials match. If nil, all credentials match.
+	Filter Filter
+	// Sort specifies the order of the results. Results are ordered on credential ID last, to make pagination stable.
+	Sort []SortField
+	// Offset specifies the number of results to skip.
+	Offset int
+	// Limit specifies the maximum number of results. If 0, all results are returned.
+	Limit int
+}
+
+func (a And) where(dialect string) (string, []interface{}, error) {
+	return combine(dialect, a, " AND ")
+}
+
+func (o Or) where(dialect string) (string, []interface{}, error) {
+	return combine(dialect, o, " OR ")
+}
+
+func combine(dialect string, filters []Filter, operator string) (string, []interface{}, error) {
+	if len(filters) == 0 {
+		return "", nil, errors.New("AND/OR filter must contain at least one filter")
+	}
+	conditions := make([]string, len(filters))
+	var args []interface{}
+	for i, filter := range filters {
+		condition, filterArgs, err := filter.where(dialect)
+		if err != nil {
+			return "", nil, err
+		}
+		conditions[i] = condition
+		args = append(args, filterArgs...)
+	}
+	return "(" + strings.Join(conditions, operator) + ")", args, nil
+}
+
+func (n Not) where(dialect string) (string, []interface{}, error) {
+	if n.Filter == nil {
+		return "", nil, errors.New("NOT filter must contain a filter")
+	}
+	condition, args, err := n.Filter.where(dialect)
+	if err != nil {
+		return "", nil, err
+	}
+	return "NOT " + condition, args, nil
+}
+
+func (c Condition) where(dialect string) (string, []interface{}, error) {
+	if c.Path == "" {
+		return "", nil, errors.New("condition must specify a path")
+	}
+	sqlOperator, ok := sqlOperators[c.Operator]
+	if !ok {
+		return "", nil, fmt.Errorf("unsupported operator: %s", c.Operator)
+	}
+	var value interface{}
+	numeric := false
+	switch {
+	case c.Operator == In:
+		if len(c.Values) == 0 {
+			return "", nil, fmt.Errorf("operator %s requires at least one value (path=%s)", c.Operator, c.Path)
+		}
+		value = c.Values
+	case len(c.Values) != 1:
+		return "", nil, fmt.Errorf("operator %s requires exactly one value (path=%s)", c.Operator, c.Path)
+	case c.Operator == Prefix:
+		value = likeEscaper.Replace(c.Values[0]) + "%"
+		sqlOperator = "LIKE ? ESCAPE '!'"
+	case rangeOperators[c.Operator] && numberRegex.MatchString(c.Values[0]):
+		number, err := strconv.ParseFloat(c.Values[0], 64)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid number (path=%s): %w", c.Path, err)
+		}
+		value = number
+		numeric = true
+	default:
+		value = c.Values[0]
+	}
+	if !strings.Contains(sqlOperator, "?") {
+		sqlOperator += " ?"
+	}
+	if column := propertyColumns[c.Path]; column != "" {
+		if numeric {
+			column = numericValue(dialect, column)
+		}
+		// the type column is nullable: a comparison with NULL isn't false but unknown, which would also make its negation (NOT) unknown
+		return fmt.Sprintf("(%s IS NOT NULL AND %s %s)", column, column, sqlOperator), []interface{}{value}, nil
+	}
+	column := "cp.value"
+	if numeric {
+		// values that aren't numbers are NULL, which never match
+		column = numericValue(dialect, column)
+	}
+	return fmt.Sprintf("EXISTS (SELECT 1 FROM credential_prop cp WHERE cp.credential_id = credential.id AND cp.path = ? AND %s %s)", column, sqlOperator),
+		[]interface{}{c.Path, value}, nil
+}
+
+// numericValue returns a SQL expression that converts the given (string) column to a number, or NULL if it doesn't contain a number.
+// The check comes first, since databases other than SQLite fail the query if the conversion fails.
+func numericValue(dialect string, column string) string {
+	switch dialect {
+	case "sqlserver":
+		return fmt.Sprintf("TRY_CAST(%s AS FLOAT)", column)
+	case "postgres":
+		return fmt.Sprintf("(CASE WHEN %s ~ '%s' THEN CAST(%s AS DOUBLE PRECISION) END)", column, numberPattern, column)
+	case "mysql":
+		return fmt.Sprintf("(CASE WHEN %s REGEXP '%s' THEN CAST(%s AS DOUBLE) END)", column, numberPattern, column)
+	default:
+		// SQLite has no regular expressions, GLOB only allows digits, signs, decimal points and exponents
+		return fmt.Sprintf("(CASE WHEN %s GLOB '*[0-9]*' AND %s NOT GLOB '*[^0-9.eE+-]*' THEN CAST(%s AS REAL) END)", column, column, column)
+	}
+}
+
+// Search returns the credentials that match the query, and the total number of credentials that match the query's filter (ignoring offset and limit).
+// Only the credentials of the module that owns the given table are searched, which refers to the credentials using its id column (e.g. vcr_credential).
+func (c CredentialStore) Search(db *gorm.DB, ownerTable string, query Query) ([]CredentialRecord, int, error) {
+	if query.Offset < 0 || query.Limit < 0 {
+		return nil, 0, errors.New("offset and limit must not be negative")
+	}
+	var condition string
+	var args []interface{}
+	if query.Filter != nil {
+		var err error
+		condition, args, err = query.Filter.where(db.Dialector.Name())
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	filtered := func() *gorm.DB {
+		stmt := db.Model(&CredentialRecord{}).Joins("INNER JOIN " + ownerTable + " ON " + ownerTable + ".id = credential.id")
+		if condition != "" {
+			stmt = stmt.Where(condition, args...)
+		}
+		return stmt
+	}
+
+	var total int64
+	if err := filtered().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	stmt := filtered().Select("credential.*")
+	for i, field := range query.Sort {
+		column := propertyColumns[field.Path]
+		if column == "" {
+			// credentials might not have the property, so they're joined using a LEFT JOIN
+			alias := "s" + strconv.Itoa(i)
+			stmt = stmt.Joins("LEFT JOIN credential_prop "+alias+" ON "+alias+".credential_id = credential.id AND "+alias+".path = ?", field.Path)
+			column = alias + ".value"
+		}
+		if field.Descending {
+			column += " DESC"
+		}
+		stmt = stmt.Order(column)
+	}
+	stmt = stmt.Order("credential.id")
+	if query.Offset > 0 {
+		stmt = stmt.Offset(query.Offset)
+	}
+	if query.Limit > 0 {
+		stmt = stmt.Limit(query.Limit)
+	}
+	var results []CredentialRecord
+	if err := stmt.Find(&results).Error; err != nil {
+		return nil, 0, err
+	}
+	return results, int(total), nil
+}
@@ -0,0 +1,184 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package store
+
+import (
+	"testing"
+
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredentialStore_Search(t *testing.T) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	t.Cleanup(func() {
+		_ = storageEngine.Shutdown()
+	})
+	db := storageEngine.GetSQLDatabase()
+	setupStore(t, db)
+	vcCharlie := createPersonCredential("4", "did:example:charlie", map[string]interface{}{
+		"givenName":  "Charlie",
+		"familyName": "Jo_nes",
+		"birthDate":  "1990-01-01",
+		"rank":       "9",
+	})
+	alice := createPersonCredential("1", "did:example:alice", map[string]interface{}{
+		"givenName":  "Alice",
+		"familyName": "Jones",
+		"birthDate":  "1980-06-15",
+		"rank":       "10",
+	})
+	for _, cred := range []vc.VerifiableCredential{alice, vcBob, vcCharlie, vcOrganization} {
+		_, err := CredentialStore{}.Store(db, cred)
+		require.NoError(t, err)
+		require.NoError(t, db.Exec("INSERT INTO test_credential (id) VALUES (?)", cred.ID.String()).Error)
+	}
+	// credentials of other modules are not searched
+	_, err := CredentialStore{}.Store(db, createPersonCredential("5", "did:example:dave", map[string]interface{}{
+		"givenName": "Dave",
+	}))
+	require.NoError(t, err)
+	search := func(t *testing.T, query Query) ([]string, int) {
+		results, total, err := CredentialStore{}.Search(db, "test_credential", query)
+		require.NoError(t, err)
+		ids := make([]string, len(results))
+		for i, result := range results {
+			ids[i] = result.ID
+		}
+		return ids, total
+	}
+
+	t.Run("no filter", func(t *testing.T) {
+		ids, total := search(t, Query{})
+
+		assert.Equal(t, []string{"1", "2", "3", "4"}, ids)
+		assert.Equal(t, 4, total)
+	})
+	t.Run("equal", func(t *testing.T) {
+		ids, _ := search(t, Query{Filter: Condition{Path: "credentialSubject.person.givenName", Operator: Equal, Values: []string{"Bob"}}})
+
+		assert.Equal(t, []string{"2"}, ids)
+	})
+	t.Run("not equal (only credentials with the property)", func(t *testing.T) {
+		ids, _ := search(t, Query{Filter: Condition{Path: "credentialSubject.person.givenName", Operator: NotEqual, Values: []string{"Bob"}}})
+
+		assert.Equal(t, []string{"1", "4"}, ids)
+	})
+	t.Run("column", func(t *testing.T) {
+		ids, _ := search(t, Query{Filter: Condition{Path: "issuer", Operator: Equal, Values: []string{organizationIssuer.String()}}})
+
+		assert.Equal(t, []string{"3"}, ids)
+	})
+	t.Run("range", func(t *testing.T) {
+		ids, _ := search(t, Query{Filter: And{
+			Condition{Path: "credentialSubject.person.birthDate", Operator: GreaterOrEqual, Values: []string{"1985-01-01"}},
+			Condition{Path: "credentialSubject.person.birthDate", Operator: LessThan, Values: []string{"2000-01-01"}},
+		}})
+
+		assert.Equal(t, []string{"4"}, ids)
+	})
+	t.Run("numeric range", func(t *testing.T) {
+		ids, _ := search(t, Query{Filter: Condition{Path: "credentialSubject.person.rank", Operator: GreaterThan, Values: []string{"8.5"}}})
+
+		// compared as strings, "9" would be greater than "10"
+		assert.Equal(t, []string{"1", "4"}, ids)
+	})
+	t.Run("numeric range only matches numbers", func(t *testing.T) {
+		ids, _ := search(t, Query{Filter: Condition{Path: "credentialSubject.person.givenName", Operator: LessThan, Values: []string{"100"}}})
+
+		assert.Empty(t, ids)
+	})
+	t.Run("numeric range negated", func(t *testing.T) {
+		ids, _ := search(t, Query{Filter: Not{Filter: Condition{Path: "credentialSubject.person.rank", Operator: GreaterOrEqual, Values: []string{"10"}}}})
+
+		assert.Equal(t, []string{"2", "3", "4"}, ids)
+	})
+	t.Run("prefix", func(t *testing.T) {
+		ids, _ := search(t, Query{Filter: Condition{Path: "credentialSubject.person.familyName", Operator: Prefix, Values: []string{"Jo"}}})
+
+		assert.Equal(t, []string{"1", "2", "4"}, ids)
+	})
+	t.Run("prefix with LIKE wildcard", func(t *testing.T) {
+		ids, _ := search(t, Query{Filter: Condition{Path: "credentialSubject.person.familyName", Operator: Prefix, Values: []string{"Jo_"}}})
+
+		assert.Equal(t, []string{"4"}, ids)
+	})
+	t.Run("in", func(t *testing.T) {
+		ids, _ := search(t, Query{Filter: Condition{Path: "credentialSubject.id", Operator: In, Values: []string{"did:example:alice", "did:example:org"}}})
+
+		assert.Equal(t, []string{"1", "3"}, ids)
+	})
+	t.Run("or", func(t *testing.T) {
+		ids, _ := search(t, Query{Filter: Or{
+			Condition{Path: "credentialSubject.person.givenName", Operator: Equal, Values: []string{"Alice"}},
+			Condition{Path: "credentialSubject.organization.name", Operator: Equal, Values: []string{"Example Corp"}},
+		}})
+
+		assert.Equal(t, []string{"1", "3"}, ids)
+	})
+	t.Run("not", func(t *testing.T) {
+		ids, _ := search(t, Query{Filter: Not{Filter: Condition{Path: "type", Operator: Equal, Values: []string{"PersonCredential"}}}})
+
+		assert.Equal(t, []string{"3"}, ids)
+	})
+	t.Run("sort", func(t *testing.T) {
+		ids, _ := search(t, Query{
+			Filter: Condition{Path: "type", Operator: Equal, Values: []string{"PersonCredential"}},
+			Sort:   []SortField{{Path: "credentialSubject.person.givenName", Descending: true}},
+		})
+
+		assert.Equal(t, []string{"4", "2", "1"}, ids)
+	})
+	t.Run("sort on column", func(t *testing.T) {
+		ids, _ := search(t, Query{Sort: []SortField{{Path: "credentialSubject.id"}}})
+
+		assert.Equal(t, []string{"1", "2", "4", "3"}, ids)
+	})
+	t.Run("pagination", func(t *testing.T) {
+		ids, total := search(t, Query{Offset: 1, Limit: 2})
+
+		assert.Equal(t, []string{"2", "3"}, ids)
+		assert.Equal(t, 4, total)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		testCases := []struct {
+			name  string
+			query Query
+			err   string
+		}{
+			{"empty AND", Query{Filter: And{}}, "AND/OR filter must contain at least one filter"},
+			{"empty NOT", Query{Filter: Not{}}, "NOT filter must contain a filter"},
+			{"no path", Query{Filter: Condition{Operator: Equal, Values: []string{"a"}}}, "condition must specify a path"},
+			{"unsupported operator", Query{Filter: Condition{Path: "id", Operator: "like", Values: []string{"a"}}}, "unsupported operator: like"},
+			{"IN without values", Query{Filter: Condition{Path: "id", Operator: In}}, "operator in requires at least one value (path=id)"},
+			{"multiple values", Query{Filter: Condition{Path: "id", Operator: Equal, Values: []string{"a", "b"}}}, "operator eq requires exactly one value (path=id)"},
+			{"negative offset", Query{Offset: -1}, "offset and limit must not be negative"},
+		}
+		for _, testCase := range testCases {
+			t.Run(testCase.name, func(t *testing.T) {
+				_, _, err := CredentialStore{}.Search(db, "test_credential", testCase.query)
+
+				assert.EqualError(t, err, testCase.err)
+			})
+		}
+	})
+}
@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"gorm.io/gorm"
 	"strconv"
 	"strings"
@@ -67,7 +68,12 @@ type CredentialStore struct {
 }
 
 // Store stores a Verifiable Credential in the SQL database.
-func (c CredentialStore) Store(db *gorm.DB, credential vc.VerifiableCredential) (*CredentialRecord, error) {
+func (c CredentialStore) Store(db *gorm.DB, original vc.VerifiableCredential) (*CredentialRecord, error) {
+	// SD-JWT VCs are indexed using the claims they disclose, but stored as-is
+	credential, err := sdjwt.Unwrap(original)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SD-JWT VC: %w", err)
+	}
 	subjectDID, err := credential.SubjectDID()
 	if err != nil {
 		return nil, fmt.Errorf("failed to extract subject DID: %w", err)
@@ -77,7 +83,7 @@ func (c CredentialStore) Store(db *gorm.DB, credential vc.VerifiableCredential)
 		ID:        credential.ID.String(),
 		Issuer:    credential.Issuer.String(),
 		SubjectID: subjectDID.String(),
-		Raw:       credential.Raw(),
+		Raw:       original.Raw(),
 	}
 	// Set type
 	for _, currType := range credential.Type {
@@ -130,6 +136,14 @@ func stripWhitespaceAndLinebreaks(s string) string {
 	return strings.ReplaceAll(strings.ReplaceAll(s, " ", ""), "\n", "")
 }
 
+// propertyColumns maps the JSON paths of credential properties that are stored in columns of the credential table, to those columns.
+var propertyColumns = map[string]string{
+	"id":                   "credential.id",
+	"issuer":               "credential.issuer",
+	"type":                 "credential.type",
+	"credentialSubject.id": "credential.subject_id",
+}
+
 // BuildSearchStatement enriches a Gorm query to search for Verifiable Credentials in the SQL database.
 // The db instance must a Gorm query builder which determines the model and subset of credentials to search in
 // using a JOIN or WHERE clause, e.g.:
@@ -143,13 +157,6 @@ func stripWhitespaceAndLinebreaks(s string) string {
 // ).Find(&results)
 // In this case, issuedCredential must have a Credential field of type CredentialRecord, which can be mapped by Gorm.
 func (c CredentialStore) BuildSearchStatement(db *gorm.DB, onClauseColumn string, query map[string]string) *gorm.DB {
-	propertyColumns := map[string]string{
-		"id":                   "credential.id",
-		"issuer":               "credential.issuer",
-		"type":                 "credential.type",
-		"credentialSubject.id": "credential.subject_id",
-	}
-
 	stmt := db.Joins("inner join credential ON credential.id = " + onClauseColumn)
 	numProps := 0
 	for jsonPath, value := range query {
@@ -39,6 +39,8 @@ var (
 	NutsOrganizationCredentialTypeURI, _ = ssi.ParseURI(NutsOrganizationCredentialType)
 	// NutsAuthorizationCredentialTypeURI is the VC type for a NutsAuthorizationCredentialType as URI
 	NutsAuthorizationCredentialTypeURI, _ = ssi.ParseURI(NutsAuthorizationCredentialType)
+	// JsonSchemaCredentialTypeURI is the VC type for a JsonSchemaCredential as URI
+	JsonSchemaCredentialTypeURI = ssi.MustParseURI(JsonSchemaCredentialType)
 	// NutsV1ContextURI is the nuts V1 json-ld context as URI
 	NutsV1ContextURI = ssi.MustParseURI(NutsV1Context)
 )
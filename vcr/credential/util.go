@@ -24,6 +24,7 @@ import (
 	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"slices"
 	"time"
 )
@@ -35,7 +36,11 @@ import (
 func ResolveSubjectDID(credentials ...vc.VerifiableCredential) (*did.DID, error) {
 	var subjectID did.DID
 	for _, credential := range credentials {
-		sid, err := credential.SubjectDID()
+		view, err := sdjwt.Unwrap(credential)
+		if err != nil {
+			return nil, err
+		}
+		sid, err := view.SubjectDID()
 		if err != nil {
 			return nil, err
 		}
@@ -102,21 +102,27 @@ func validateCredentialStatus(credential vc.VerifiableCredential) error {
 			return errors.New("credentialStatus.type is required")
 		}
 
-		// only validate StatusList2021Entry for now
+		// only validate StatusList2021Entry and BitstringStatusListEntry for now
 		switch credentialStatus.Type {
 		case revocation.StatusList2021EntryType:
 			// TODO: json.AllFieldsDefined validator should be sufficient?
 			if !credential.ContainsContext(revocation.StatusList2021ContextURI) {
 				return errors.New("StatusList2021 context is required")
 			}
-
-			var cs revocation.StatusList2021Entry
-			if err = json.Unmarshal(credentialStatus.Raw(), &cs); err != nil {
-				return err
-			}
-			if err = cs.Validate(); err != nil {
-				return err
+		case revocation.BitstringStatusListEntryType:
+			if !credential.ContainsContext(revocation.BitstringStatusListContextURI) {
+				return errors.New("BitstringStatusList context is required")
 			}
+		default:
+			continue
+		}
+
+		var cs revocation.StatusList2021Entry
+		if err = json.Unmarshal(credentialStatus.Raw(), &cs); err != nil {
+			return err
+		}
+		if err = cs.Validate(); err != nil {
+			return err
 		}
 	}
 
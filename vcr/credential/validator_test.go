@@ -497,6 +497,35 @@ func Test_validateCredentialStatus(t *testing.T) {
 			assert.EqualError(t, err, "parse StatusList2021Entry.statusListCredential URL: parse \"make sure validator is called\": invalid URI for request")
 		})
 	})
+	t.Run(revocation.BitstringStatusListEntryType, func(t *testing.T) {
+		makeValidCSEntry := func() vc.VerifiableCredential {
+			return vc.VerifiableCredential{
+				Context: []ssi.URI{ssi.MustParseURI(jsonld.W3cBitstringStatusListContext)},
+				CredentialStatus: []any{&revocation.StatusList2021Entry{
+					ID:                   "https://example-com/credentials/status/3#94567",
+					Type:                 revocation.BitstringStatusListEntryType,
+					StatusPurpose:        "revocation",
+					StatusListIndex:      "94567",
+					StatusListCredential: "https://example-com/credentials/status/3",
+				}},
+			}
+		}
+		t.Run("ok", func(t *testing.T) {
+			assert.NoError(t, validateCredentialStatus(makeValidCSEntry()))
+		})
+		t.Run("error - missing context", func(t *testing.T) {
+			cred := makeValidCSEntry()
+			cred.Context = []ssi.URI{ssi.MustParseURI(jsonld.W3cStatusList2021Context)}
+			err := validateCredentialStatus(cred)
+			assert.EqualError(t, err, "BitstringStatusList context is required")
+		})
+		t.Run("error - credentialStatus validation is called", func(t *testing.T) {
+			cred := makeValidCSEntry()
+			cred.CredentialStatus[0].(*revocation.StatusList2021Entry).StatusSize = 2
+			err := validateCredentialStatus(cred)
+			assert.EqualError(t, err, "BitstringStatusListEntry.statusMessage must contain 4 messages for statusSize 2")
+		})
+	})
 }
 
 func TestX509CredentialValidator_Validate(t *testing.T) {
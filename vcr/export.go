@@ -0,0 +1,80 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+package vcr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/vcr/credential"
+	"github.com/nuts-foundation/nuts-node/vcr/types"
+	"github.com/nuts-foundation/nuts-node/vdr/didsubject"
+)
+
+var _ didsubject.SubjectDataExporter = (*vcr)(nil)
+
+// ExportSubjectData exports the credentials in the wallets of the subject's DIDs.
+func (c *vcr) ExportSubjectData(ctx context.Context, _ string, dids []did.DID) ([]byte, error) {
+	credentials := make([]vc.VerifiableCredential, 0)
+	for _, id := range dids {
+		walletCredentials, err := c.wallet.List(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		credentials = append(credentials, walletCredentials...)
+	}
+	if len(credentials) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(credentials)
+}
+
+// ImportSubjectData adds the exported credentials to the wallet.
+func (c *vcr) ImportSubjectData(ctx context.Context, _ string, data []byte) error {
+	var credentials []vc.VerifiableCredential
+	if err := json.Unmarshal(data, &credentials); err != nil {
+		return err
+	}
+	return c.wallet.Put(ctx, credentials...)
+}
+
+// RemoveSubjectData removes the imported credentials from the wallet.
+func (c *vcr) RemoveSubjectData(ctx context.Context, _ string, data []byte) error {
+	var credentials []vc.VerifiableCredential
+	if err := json.Unmarshal(data, &credentials); err != nil {
+		return err
+	}
+	var errs []error
+	for _, curr := range credentials {
+		if curr.ID == nil {
+			continue
+		}
+		holderDID, err := credential.ResolveSubjectDID(curr)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err = c.wallet.Remove(ctx, *holderDID, *curr.ID); err != nil && !errors.Is(err, types.ErrNotFound) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
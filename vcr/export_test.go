@@ -0,0 +1,140 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+package vcr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/vcr/holder"
+	"github.com/nuts-foundation/nuts-node/vcr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestVCR_ExportSubjectData(t *testing.T) {
+	didA := did.MustParseDID("did:web:example.com:iam:a")
+	didB := did.MustParseDID("did:web:example.com:iam:b")
+	credentialA := vc.VerifiableCredential{Issuer: ssi.MustParseURI("https://example.com/a")}
+	credentialB := vc.VerifiableCredential{Issuer: ssi.MustParseURI("https://example.com/b")}
+	t.Run("ok", func(t *testing.T) {
+		wallet := holder.NewMockWallet(gomock.NewController(t))
+		wallet.EXPECT().List(gomock.Any(), didA).Return([]vc.VerifiableCredential{credentialA}, nil)
+		wallet.EXPECT().List(gomock.Any(), didB).Return([]vc.VerifiableCredential{credentialB}, nil)
+		instance := &vcr{wallet: wallet}
+
+		data, err := instance.ExportSubjectData(context.Background(), "subject", []did.DID{didA, didB})
+
+		require.NoError(t, err)
+		var credentials []vc.VerifiableCredential
+		require.NoError(t, json.Unmarshal(data, &credentials))
+		require.Len(t, credentials, 2)
+		assert.Equal(t, credentialA.Issuer.String(), credentials[0].Issuer.String())
+		assert.Equal(t, credentialB.Issuer.String(), credentials[1].Issuer.String())
+	})
+	t.Run("no credentials", func(t *testing.T) {
+		wallet := holder.NewMockWallet(gomock.NewController(t))
+		wallet.EXPECT().List(gomock.Any(), didA).Return(nil, nil)
+		instance := &vcr{wallet: wallet}
+
+		data, err := instance.ExportSubjectData(context.Background(), "subject", []did.DID{didA})
+
+		require.NoError(t, err)
+		assert.Nil(t, data)
+	})
+	t.Run("wallet fails", func(t *testing.T) {
+		wallet := holder.NewMockWallet(gomock.NewController(t))
+		wallet.EXPECT().List(gomock.Any(), didA).Return(nil, errors.New("failed"))
+		instance := &vcr{wallet: wallet}
+
+		_, err := instance.ExportSubjectData(context.Background(), "subject", []did.DID{didA})
+
+		assert.EqualError(t, err, "failed")
+	})
+}
+
+func TestVCR_ImportSubjectData(t *testing.T) {
+	credential := vc.VerifiableCredential{Issuer: ssi.MustParseURI("https://example.com/a")}
+	t.Run("ok", func(t *testing.T) {
+		wallet := holder.NewMockWallet(gomock.NewController(t))
+		wallet.EXPECT().Put(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, credentials ...vc.VerifiableCredential) error {
+			require.Len(t, credentials, 1)
+			assert.Equal(t, credential.Issuer.String(), credentials[0].Issuer.String())
+			return nil
+		})
+		instance := &vcr{wallet: wallet}
+		data, _ := json.Marshal([]vc.VerifiableCredential{credential})
+
+		err := instance.ImportSubjectData(context.Background(), "subject", data)
+
+		assert.NoError(t, err)
+	})
+	t.Run("invalid data", func(t *testing.T) {
+		instance := &vcr{}
+
+		err := instance.ImportSubjectData(context.Background(), "subject", []byte("{"))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestVCR_RemoveSubjectData(t *testing.T) {
+	holderDID := did.MustParseDID("did:web:example.com:iam:a")
+	credentialID := ssi.MustParseURI("https://example.com/credential/1")
+	credential := vc.VerifiableCredential{
+		ID:                &credentialID,
+		Issuer:            ssi.MustParseURI("https://example.com/a"),
+		CredentialSubject: []interface{}{map[string]interface{}{"id": holderDID.String()}},
+	}
+	t.Run("ok", func(t *testing.T) {
+		wallet := holder.NewMockWallet(gomock.NewController(t))
+		wallet.EXPECT().Remove(gomock.Any(), holderDID, *credential.ID).Return(nil)
+		instance := &vcr{wallet: wallet}
+		data, _ := json.Marshal([]vc.VerifiableCredential{credential})
+
+		err := instance.RemoveSubjectData(context.Background(), "subject", data)
+
+		assert.NoError(t, err)
+	})
+	t.Run("credential was not imported", func(t *testing.T) {
+		wallet := holder.NewMockWallet(gomock.NewController(t))
+		wallet.EXPECT().Remove(gomock.Any(), holderDID, *credential.ID).Return(types.ErrNotFound)
+		instance := &vcr{wallet: wallet}
+		data, _ := json.Marshal([]vc.VerifiableCredential{credential})
+
+		err := instance.RemoveSubjectData(context.Background(), "subject", data)
+
+		assert.NoError(t, err)
+	})
+	t.Run("wallet fails", func(t *testing.T) {
+		wallet := holder.NewMockWallet(gomock.NewController(t))
+		wallet.EXPECT().Remove(gomock.Any(), holderDID, *credential.ID).Return(errors.New("failed"))
+		instance := &vcr{wallet: wallet}
+		data, _ := json.Marshal([]vc.VerifiableCredential{credential})
+
+		err := instance.RemoveSubjectData(context.Background(), "subject", data)
+
+		assert.EqualError(t, err, "failed")
+	})
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
 	"github.com/nuts-foundation/nuts-node/vcr/pe"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"github.com/nuts-foundation/nuts-node/vcr/signature"
 	"github.com/nuts-foundation/nuts-node/vcr/signature/proof"
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
@@ -123,6 +124,10 @@ func (p presenter) buildPresentation(ctx context.Context, signerDID *did.DID, cr
 
 // buildJWTPresentation builds a JWT presentation according to https://www.w3.org/TR/vc-data-model/#json-web-token
 func (p presenter) buildJWTPresentation(ctx context.Context, subjectDID did.DID, credentials []vc.VerifiableCredential, options PresentationOptions, keyID string) (*vc.VerifiablePresentation, error) {
+	credentials, err := p.addKeyBinding(ctx, credentials, options, keyID)
+	if err != nil {
+		return nil, err
+	}
 	headers := map[string]interface{}{
 		jws.TypeKey: "JWT",
 	}
@@ -162,7 +167,48 @@ func (p presenter) buildJWTPresentation(ctx context.Context, subjectDID did.DID,
 	return vc.ParseVerifiablePresentation(token)
 }
 
+// addKeyBinding adds a Key Binding JWT to the SD-JWT VCs, proving the holder possesses the key of the credential subject.
+// It contains the audience and nonce of the presentation, to prevent replay.
+func (p presenter) addKeyBinding(ctx context.Context, credentials []vc.VerifiableCredential, options PresentationOptions, keyID string) ([]vc.VerifiableCredential, error) {
+	var audience, nonce string
+	if options.ProofOptions.Domain != nil {
+		audience = *options.ProofOptions.Domain
+	}
+	if options.ProofOptions.Nonce != nil {
+		nonce = *options.ProofOptions.Nonce
+	}
+	result := make([]vc.VerifiableCredential, len(credentials))
+	for i, current := range credentials {
+		if !sdjwt.IsEnveloped(current) {
+			result[i] = current
+			continue
+		}
+		sdJWT, err := sdjwt.FromCredential(current)
+		if err != nil {
+			return nil, err
+		}
+		headers := map[string]interface{}{
+			jws.TypeKey: sdjwt.KeyBindingJWTType,
+		}
+		sdJWT.KeyBindingJWT, err = p.signer.SignJWT(ctx, sdJWT.KeyBindingClaims(audience, nonce), headers, keyID)
+		if err != nil {
+			return nil, fmt.Errorf("unable to sign Key Binding JWT: %w", err)
+		}
+		bound, err := sdJWT.Envelope()
+		if err != nil {
+			return nil, err
+		}
+		result[i] = *bound
+	}
+	return result, nil
+}
+
 func (p presenter) buildJSONLDPresentation(ctx context.Context, subjectDID did.DID, credentials []vc.VerifiableCredential, options PresentationOptions, keyID string) (*vc.VerifiablePresentation, error) {
+	for _, current := range credentials {
+		if sdjwt.IsEnveloped(current) {
+			return nil, errors.New("SD-JWT VCs can only be presented in JWT presentations")
+		}
+	}
 	ldContext := []ssi.URI{VerifiableCredentialLDContextV1, signature.JSONWebSignature2020Context}
 	ldContext = append(ldContext, options.AdditionalContexts...)
 	types := []ssi.URI{VerifiablePresentationLDType}
@@ -32,6 +32,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/storage/orm"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
 	"github.com/nuts-foundation/nuts-node/vcr/pe"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"github.com/nuts-foundation/nuts-node/vcr/signature/proof"
 	"github.com/nuts-foundation/nuts-node/vcr/test"
 	"github.com/nuts-foundation/nuts-node/vdr"
@@ -218,6 +219,47 @@ func TestPresenter_buildPresentation(t *testing.T) {
 			assert.Equal(t, "claim", actualCustomClaim)
 		})
 	})
+	t.Run("SD-JWT", func(t *testing.T) {
+		sdJWTCredential := test.SDJWTNutsOrganizationCredential(t, testDID)
+		t.Run("ok - JWT presentation adds Key Binding JWT", func(t *testing.T) {
+			domain := "https://example.com"
+			nonce := "the-nonce"
+			options := PresentationOptions{
+				Format: JWTPresentationFormat,
+				ProofOptions: proof.ProofOptions{
+					Domain: &domain,
+					Nonce:  &nonce,
+				},
+			}
+			ctrl := gomock.NewController(t)
+			keyResolver := resolver.NewMockKeyResolver(ctrl)
+			keyResolver.EXPECT().ResolveKey(testDID, nil, resolver.NutsSigningKeyType).Return(kid, key.PublicKey, nil)
+			w := presenter{documentLoader: jsonldManager.DocumentLoader(), signer: keyStore, keyResolver: keyResolver}
+
+			result, err := w.buildPresentation(ctx, &testDID, []vc.VerifiableCredential{sdJWTCredential}, options)
+
+			require.NoError(t, err)
+			require.Len(t, result.VerifiableCredential, 1)
+			sdJWT, err := sdjwt.FromCredential(result.VerifiableCredential[0])
+			require.NoError(t, err)
+			keyBinding, err := sdJWT.ValidateKeyBinding(sdjwt.KeyBindingOptions{Audience: []string{domain}, Nonce: nonce, MaxAge: time.Minute})
+			require.NoError(t, err)
+			assert.Equal(t, []string{domain}, keyBinding.Audience())
+			actualNonce, _ := keyBinding.Get("nonce")
+			assert.Equal(t, nonce, actualNonce)
+		})
+		t.Run("error - JSON-LD presentation", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			keyResolver := resolver.NewMockKeyResolver(ctrl)
+			keyResolver.EXPECT().ResolveKey(testDID, nil, resolver.NutsSigningKeyType).Return(kid, key.PublicKey, nil)
+			w := presenter{documentLoader: jsonldManager.DocumentLoader(), signer: keyStore, keyResolver: keyResolver}
+
+			result, err := w.buildPresentation(ctx, &testDID, []vc.VerifiableCredential{sdJWTCredential}, PresentationOptions{Format: JSONLDPresentationFormat})
+
+			assert.EqualError(t, err, "SD-JWT VCs can only be presented in JWT presentations")
+			assert.Nil(t, result)
+		})
+	})
 	t.Run("deriving signer from VCs", func(t *testing.T) {
 		options := PresentationOptions{ProofOptions: proof.ProofOptions{}}
 
@@ -322,6 +364,66 @@ func TestPresenter_buildSubmission(t *testing.T) {
 		require.NotNil(t, submission)
 		assert.Equal(t, nutsWalletDID.String(), vp.Holder.String(), "holder must be the DID of the signer")
 	})
+	t.Run("ok - SD-JWT VC only discloses requested claims", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		keyResolver := resolver.NewMockKeyResolver(ctrl)
+		keyResolver.EXPECT().ResolveKey(webWalletDID, nil, resolver.NutsSigningKeyType).Return(key.KID, key.PublicKey, nil)
+		w := presenter{documentLoader: jsonldManager.DocumentLoader(), signer: keyStore, keyResolver: keyResolver}
+		sdJWTCredentials := map[did.DID][]vc.VerifiableCredential{
+			webWalletDID: {test.SDJWTNutsOrganizationCredential(t, webWalletDID)},
+		}
+		definition := pe.PresentationDefinition{
+			InputDescriptors: []*pe.InputDescriptor{
+				{
+					Id: "organization",
+					Constraints: &pe.Constraints{
+						Fields: []pe.Field{{Path: []string{"$.credentialSubject.organization.city"}}},
+					},
+				},
+			},
+		}
+
+		vp, submission, err := w.buildSubmission(ctx, sdJWTCredentials, definition, BuildParams{Audience: verifierDID.String(), Expires: time.Now().Add(time.Second), Format: vpFormats, Nonce: "nonce"})
+
+		require.NoError(t, err)
+		require.NotNil(t, submission)
+		assert.Equal(t, sdjwt.Format, submission.DescriptorMap[0].Format)
+		assert.Equal(t, JWTPresentationFormat, vp.Format())
+		require.Len(t, vp.VerifiableCredential, 1)
+		disclosed, err := sdjwt.Unwrap(vp.VerifiableCredential[0])
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"city": "IJbergen"}, disclosed.CredentialSubject[0].(map[string]interface{})["organization"])
+		// the verifier must accept the submission of the SD-JWT VC, which now contains a Key Binding JWT
+		envelope, err := pe.ParseEnvelope([]byte(vp.Raw()))
+		require.NoError(t, err)
+		credentials, err := submission.Validate(*envelope, definition)
+		require.NoError(t, err)
+		assert.Len(t, credentials, 1)
+	})
+	t.Run("error - limit_disclosure required, but no VC supports selective disclosure", func(t *testing.T) {
+		w := presenter{documentLoader: jsonldManager.DocumentLoader(), signer: keyStore}
+		jwtCredentials := map[did.DID][]vc.VerifiableCredential{
+			webWalletDID: {test.JWTNutsOrganizationCredential(t, webWalletDID)},
+		}
+		definition := pe.PresentationDefinition{
+			InputDescriptors: []*pe.InputDescriptor{
+				{
+					Id: "organization",
+					Constraints: &pe.Constraints{
+						LimitDisclosure: pe.LimitDisclosureRequired,
+						Fields:          []pe.Field{{Path: []string{"$.credentialSubject[0].organization.city"}}},
+					},
+				},
+			},
+		}
+
+		vp, submission, err := w.buildSubmission(ctx, jwtCredentials, definition, BuildParams{Audience: verifierDID.String(), Expires: time.Now().Add(time.Second), Format: vpFormats, Nonce: "nonce"})
+
+		assert.ErrorIs(t, err, pe.ErrNoCredentials)
+		assert.ErrorIs(t, err, pe.ErrLimitDisclosureNotSupported)
+		assert.Nil(t, vp)
+		assert.Nil(t, submission)
+	})
 	t.Run("error - no matching credentials", func(t *testing.T) {
 		resetStore(t, storageEngine.GetSQLDatabase())
 
@@ -119,7 +119,7 @@ func (h sqlWallet) Put(_ context.Context, credentials ...vc.VerifiableCredential
 	return h.walletStore.put(credentials...)
 }
 
-func (h sqlWallet) List(_ context.Context, holderDID did.DID) ([]vc.VerifiableCredential, error) {
+func (h sqlWallet) List(ctx context.Context, holderDID did.DID) ([]vc.VerifiableCredential, error) {
 	credentials, err := h.walletStore.list(holderDID)
 	if err != nil {
 		return nil, err
@@ -128,9 +128,9 @@ func (h sqlWallet) List(_ context.Context, holderDID did.DID) ([]vc.VerifiableCr
 	validCredentials := make([]vc.VerifiableCredential, 0, len(credentials))
 	for _, credential := range credentials {
 		// we only want to check expiration and revocation status
-		if err = h.verifier.Verify(credential, true, false, nil); err == nil {
+		if err = h.verifier.Verify(ctx, credential, true, false, nil); err == nil {
 			validCredentials = append(validCredentials, credential)
-		} else if !errors.Is(err, types.ErrCredentialNotValidAtTime) && !errors.Is(err, types.ErrRevoked) {
+		} else if !errors.Is(err, types.ErrCredentialNotValidAtTime) && !errors.Is(err, types.ErrRevoked) && !errors.Is(err, types.ErrSuspended) {
 			// a possible technical error has occurred that should be logged.
 			log.Logger().WithError(err).WithField(core.LogFieldCredentialID, credential.ID).Warn("unable to verify credential")
 		}
@@ -210,16 +210,12 @@ func (s walletStore) list(holderDID did.DID) ([]vc.VerifiableCredential, error)
 func (s walletStore) put(credentials ...vc.VerifiableCredential) error {
 	return s.db.Transaction(func(tx *gorm.DB) error {
 		for _, curr := range credentials {
-			subjectDID, err := curr.SubjectDID()
-			if err != nil {
-				return fmt.Errorf("unable to resolve subject DID from VC %s: %w", curr.ID, err)
-			}
 			record, err := store.CredentialStore{}.Store(tx, curr)
 			if err != nil {
 				return err
 			}
 			if err := tx.FirstOrCreate(&walletRecord{
-				HolderDID:    subjectDID.String(),
+				HolderDID:    record.SubjectID,
 				CredentialID: record.ID,
 			}).Error; err != nil {
 				return err
@@ -383,10 +383,14 @@ type testVerifier struct {
 	err error
 }
 
-func (t testVerifier) Verify(credential vc.VerifiableCredential, allowUntrusted bool, checkSignature bool, validAt *time.Time) error {
+func (t testVerifier) Verify(_ context.Context, credential vc.VerifiableCredential, allowUntrusted bool, checkSignature bool, validAt *time.Time) error {
 	return t.err
 }
 
+func (t testVerifier) VerifyWithReport(_ context.Context, credential vc.VerifiableCredential, allowUntrusted bool, checkSignature bool, validAt *time.Time) verifier.VerificationReport {
+	panic("implement me")
+}
+
 func (t testVerifier) VerifySignature(credentialToVerify vc.VerifiableCredential, at *time.Time) error {
 	panic("implement me")
 }
@@ -403,6 +407,10 @@ func (t testVerifier) RegisterRevocation(revocation credential.Revocation) error
 	panic("implement me")
 }
 
-func (t testVerifier) VerifyVP(presentation vc.VerifiablePresentation, verifyVCs bool, allowUntrustedVCs bool, validAt *time.Time) ([]vc.VerifiableCredential, error) {
+func (t testVerifier) VerifyVP(_ context.Context, presentation vc.VerifiablePresentation, verifyVCs bool, allowUntrustedVCs bool, validAt *time.Time) ([]vc.VerifiableCredential, error) {
+	panic("implement me")
+}
+
+func (t testVerifier) VerifyVPWithReport(_ context.Context, presentation vc.VerifiablePresentation, verifyVCs bool, allowUntrustedVCs bool, validAt *time.Time) verifier.PresentationVerificationReport {
 	panic("implement me")
 }
@@ -23,6 +23,7 @@ import (
 	"context"
 	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/vcr/credential/store"
 	"github.com/nuts-foundation/nuts-node/vcr/types"
 	"time"
 
@@ -39,6 +40,11 @@ type Finder interface {
 	// It also returns untrusted credentials when allowUntrusted == true
 	// a context must be passed to prevent long-running queries
 	Search(ctx context.Context, searchTerms []SearchTerm, allowUntrusted bool, resolveTime *time.Time) ([]vc.VerifiableCredential, error)
+	// Query searches for VCs received through the Nuts network, in the SQL credential store.
+	// It returns the matching credentials on the requested page and the total number of matching credentials that aren't revoked.
+	// Filtering, counting and pagination are done by the database, only the credentials on the page are verified:
+	// invalid credentials (e.g. expired) are omitted from the page, as are untrusted credentials unless allowUntrusted == true.
+	Query(ctx context.Context, query store.Query, allowUntrusted bool) ([]vc.VerifiableCredential, int, error)
 }
 
 // TrustManager bundles all trust related methods in one interface
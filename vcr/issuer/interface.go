@@ -27,6 +27,7 @@ import (
 	"github.com/nuts-foundation/go-did/vc"
 	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
+	"github.com/nuts-foundation/nuts-node/vcr/revocation"
 )
 
 // Publisher publishes new credentials and revocations to a channel. Used by a credential issuer.
@@ -47,9 +48,20 @@ type Issuer interface {
 	// It returns types.ErrNotFound if the credential is not issued by this node, or types.ErrRevoked if already revoked.
 	// The revocation will be published to the network by the issuers Publisher if issuer by did:nuts.
 	Revoke(ctx context.Context, credentialID ssi.URI) (*credential.Revocation, error)
+	// Suspend credential with credentialID, by setting its status on the 'suspension' status list referenced in its credentialStatus.
+	// It returns types.ErrNotFound if the credential is not issued by this node, types.ErrStatusNotFound if it can't be suspended,
+	// or types.ErrSuspended if already suspended.
+	Suspend(ctx context.Context, credentialID ssi.URI) error
+	// Unsuspend lifts the suspension of the credential with credentialID.
+	// It returns types.ErrNotFound if the credential is not issued by this node, types.ErrStatusNotFound if it can't be suspended,
+	// or types.ErrNotSuspended if not suspended.
+	Unsuspend(ctx context.Context, credentialID ssi.URI) error
 	// StatusList returns the StatusList2021Credential tracking status list revocations for this issuer at /iam/issuerID/status/page.
 	// Returns types.ErrNotFound when no credential statuses have been published using the issuer and page combination.
 	StatusList(ctx context.Context, issuer did.DID, page int) (*vc.VerifiableCredential, error)
+	// ResignStatusLists re-issues all status list credentials of the issuer, signed with its current assertion key.
+	// It's called after the keys of the issuer were rotated.
+	ResignStatusLists(ctx context.Context, issuer did.DID) error
 	CredentialSearcher
 }
 
@@ -85,12 +97,24 @@ type CredentialSearcher interface {
 // CredentialOptions specifies options for issuing a credential.
 type CredentialOptions struct {
 	// Format specifies the proof format for the issued credential. If not set, it defaults to JSON-LD.
-	// Valid options are: ldp_vc or jwt_vc
+	// Valid options are: ldp_vc, jwt_vc or vc+sd-jwt
 	Format string
+	// SelectivelyDisclosable lists the claims of the credentialSubject that can be selectively disclosed by the holder,
+	// as dot-separated paths relative to the credentialSubject (e.g. 'organization.city'). Only applies to vc+sd-jwt.
+	// If not set, all claims of the credentialSubject (except its id) are selectively disclosable.
+	SelectivelyDisclosable []string
 	// Publish param indicates if the credential should be published to the network.
 	Publish bool
 	// Public param instructs the Publisher to publish the param with a certain visibility.
 	Public bool
 	// WithStatusListRevocation adds a 'revocation' entry to the credential. Requires Publish to be False.
 	WithStatusListRevocation bool
+	// WithStatusListSuspension adds a 'suspension' entry to the credential, so it can be suspended and unsuspended. Requires Publish to be False.
+	WithStatusListSuspension bool
+	// StatusListType specifies the status list specification used for the entries added by WithStatusListRevocation and WithStatusListSuspension.
+	// Valid options are: StatusList2021 or BitstringStatusList. If not set, it defaults to StatusList2021.
+	StatusListType revocation.StatusListType
+	// CredentialSchema lists the JSON Schemas (JsonSchema or JsonSchemaCredential) the credential must conform to.
+	// They're added to the credentialSchema property of the credential. Only supported for jwt_vc.
+	CredentialSchema []credential.CredentialSchema
 }
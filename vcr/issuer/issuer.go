@@ -26,6 +26,7 @@ import (
 	"github.com/nuts-foundation/go-stoabs"
 	"github.com/nuts-foundation/nuts-node/vcr/openid4vci"
 	"github.com/nuts-foundation/nuts-node/vcr/revocation"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"github.com/nuts-foundation/nuts-node/vdr/didnuts"
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
 	"gorm.io/gorm"
@@ -59,7 +60,7 @@ var TimeFunc = time.Now
 func NewIssuer(store Store, vcrStore types.Writer, networkPublisher Publisher,
 	openidHandlerFn func(ctx context.Context, id did.DID) (OpenIDHandler, error),
 	didResolver resolver.DIDResolver, keyStore crypto.KeyStore, jsonldManager jsonld.JSONLD, trustConfig *trust.Config,
-	statusList *revocation.StatusList2021) Issuer {
+	statusList *revocation.StatusList2021, schemaValidator *credential.SchemaValidator) Issuer {
 	keyResolver := resolver.DIDKeyResolver{Resolver: didResolver}
 	i := &issuer{
 		store:            store,
@@ -68,12 +69,13 @@ func NewIssuer(store Store, vcrStore types.Writer, networkPublisher Publisher,
 		walletResolver: openid4vci.DIDIdentifierResolver{
 			ServiceResolver: resolver.DIDServiceResolver{Resolver: didResolver},
 		},
-		keyResolver:   keyResolver,
-		keyStore:      keyStore,
-		jsonldManager: jsonldManager,
-		trustConfig:   trustConfig,
-		vcrStore:      vcrStore,
-		statusList:    statusList,
+		keyResolver:     keyResolver,
+		keyStore:        keyStore,
+		jsonldManager:   jsonldManager,
+		trustConfig:     trustConfig,
+		vcrStore:        vcrStore,
+		statusList:      statusList,
+		schemaValidator: schemaValidator,
 	}
 	statusList.Sign = i.buildJSONLDCredential
 	statusList.ResolveKey = i.keyResolver.ResolveKey
@@ -91,6 +93,7 @@ type issuer struct {
 	vcrStore         types.Writer
 	walletResolver   openid4vci.IdentifierResolver
 	statusList       revocation.StatusList2021Issuer
+	schemaValidator  *credential.SchemaValidator
 }
 
 // Issue creates a new credential, signs, stores it.
@@ -101,14 +104,22 @@ func (i issuer) Issue(ctx context.Context, template vc.VerifiableCredential, opt
 	if options.Publish && options.Format == vc.JWTCredentialProofFormat {
 		return nil, errors.New("publishing VC JWTs is not supported")
 	}
+	if options.Publish && options.Format == sdjwt.Format {
+		return nil, errors.New("publishing SD-JWT VCs is not supported")
+	}
 
 	createdVC, err := i.buildAndSignVC(ctx, template, options)
 	if err != nil {
 		return nil, err
 	}
+	// SD-JWT VCs are checked using the credential they contain (with all disclosures applied)
+	view, err := sdjwt.Unwrap(*createdVC)
+	if err != nil {
+		return nil, err
+	}
 
 	// Sanity check: all provided fields must be defined by the context: otherwise they're not protected by the signature
-	createdVCJSON, _ := json.Marshal(createdVC) // can't use createdVC.Raw() it does not return json for JWT-VCs
+	createdVCJSON, _ := json.Marshal(view) // can't use createdVC.Raw() it does not return json for JWT-VCs
 	err = jsonld.AllFieldsDefined(i.jsonldManager.DocumentLoader(), createdVCJSON)
 	if err != nil {
 		return nil, err
@@ -116,18 +127,27 @@ func (i issuer) Issue(ctx context.Context, template vc.VerifiableCredential, opt
 
 	// Validate the VC using the type-specific validator
 	// we don't pass a pki.Validator since we don't issue x509 certs
-	validator := credential.FindValidator(*createdVC, nil)
-	if err := validator.Validate(*createdVC); err != nil {
+	validator := credential.FindValidator(*view, nil)
+	if err := validator.Validate(*view); err != nil {
 		return nil, err
 	}
+	// Validate the VC against the JSON schemas in its credentialSchema
+	if len(options.CredentialSchema) > 0 {
+		if i.schemaValidator == nil {
+			return nil, core.InvalidInputError("credentialSchema is not supported")
+		}
+		if err := i.schemaValidator.Validate(ctx, *view); err != nil {
+			return nil, core.InvalidInputError("%w", err)
+		}
+	}
 
 	// Trust credential before storing/publishing, otherwise it might self-issued credentials might not be trusted,
 	// if AddTrust() fails for whatever reason.
 	// Only 1 allowed for now, but looping over all types (VerifiableCredential is excluded by ExtractTypes()) is future-proof.
-	for _, credentialType := range credential.ExtractTypes(*createdVC) {
+	for _, credentialType := range credential.ExtractTypes(*view) {
 		// MustParseURI is safe since it came from vc.Type, which contains URIs
-		if err := i.trustConfig.AddTrust(ssi.MustParseURI(credentialType), createdVC.Issuer); err != nil {
-			return nil, fmt.Errorf("failed to trust issuer when issuing VC (did=%s,type=%s): %w", createdVC.Issuer, credentialType, err)
+		if err := i.trustConfig.AddTrust(ssi.MustParseURI(credentialType), view.Issuer); err != nil {
+			return nil, fmt.Errorf("failed to trust issuer when issuing VC (did=%s,type=%s): %w", view.Issuer, credentialType, err)
 		}
 	}
 
@@ -221,17 +241,34 @@ func (i issuer) buildAndSignVC(ctx context.Context, template vc.VerifiableCreden
 	}
 
 	// statuslist
-	if options.WithStatusListRevocation {
+	if options.WithStatusListRevocation || options.WithStatusListSuspension {
 		// add credential status
-		credentialStatusEntry, err := i.statusList.Entry(ctx, *issuerDID, revocation.StatusPurposeRevocation)
-		if err != nil {
-			return nil, err
+		statusListType, statusListContext := revocation.StatusList2021Type, revocation.StatusList2021ContextURI
+		switch options.StatusListType {
+		case "", revocation.StatusList2021Type:
+		case revocation.BitstringStatusListType:
+			statusListType, statusListContext = revocation.BitstringStatusListType, revocation.BitstringStatusListContextURI
+		default:
+			return nil, core.InvalidInputError("unsupported status list type: %s", options.StatusListType)
+		}
+		if options.WithStatusListRevocation {
+			credentialStatusEntry, err := i.statusList.Entry(ctx, *issuerDID, statusListType, revocation.StatusPurposeRevocation)
+			if err != nil {
+				return nil, err
+			}
+			unsignedCredential.CredentialStatus = append(unsignedCredential.CredentialStatus, credentialStatusEntry)
+		}
+		if options.WithStatusListSuspension {
+			credentialStatusEntry, err := i.statusList.Entry(ctx, *issuerDID, statusListType, revocation.StatusPurposeSuspension)
+			if err != nil {
+				return nil, err
+			}
+			unsignedCredential.CredentialStatus = append(unsignedCredential.CredentialStatus, credentialStatusEntry)
 		}
-		unsignedCredential.CredentialStatus = append(unsignedCredential.CredentialStatus, credentialStatusEntry)
 
 		// add status list context
-		if !unsignedCredential.ContainsContext(revocation.StatusList2021ContextURI) {
-			unsignedCredential.Context = append(unsignedCredential.Context, revocation.StatusList2021ContextURI)
+		if !unsignedCredential.ContainsContext(statusListContext) {
+			unsignedCredential.Context = append(unsignedCredential.Context, statusListContext)
 		}
 	}
 
@@ -251,12 +288,28 @@ func (i issuer) buildAndSignVC(ctx context.Context, template vc.VerifiableCreden
 		unsignedCredential.Type = append(unsignedCredential.Type, vc.VerifiableCredentialTypeV1URI())
 	}
 
+	// credentialSchema isn't part of vc.VerifiableCredential, so it can only be added to the 'vc' claim of JWT VCs
+	if len(options.CredentialSchema) > 0 && options.Format != vc.JWTCredentialProofFormat {
+		return nil, core.InvalidInputError("credentialSchema is only supported for credential format: %s", vc.JWTCredentialProofFormat)
+	}
+
 	// sign
 	switch options.Format {
 	case vc.JWTCredentialProofFormat:
 		return vc.CreateJWTVerifiableCredential(ctx, unsignedCredential, func(ctx context.Context, claims map[string]interface{}, headers map[string]interface{}) (string, error) {
+			if len(options.CredentialSchema) > 0 {
+				claims["vc"].(map[string]interface{})["credentialSchema"] = options.CredentialSchema
+			}
 			return i.keyStore.SignJWT(ctx, claims, headers, keyURI)
 		})
+	case sdjwt.Format:
+		sdJWT, err := sdjwt.Create(ctx, unsignedCredential, options.SelectivelyDisclosable, func(ctx context.Context, claims map[string]interface{}, headers map[string]interface{}) (string, error) {
+			return i.keyStore.SignJWT(ctx, claims, headers, keyURI)
+		})
+		if err != nil {
+			return nil, core.InvalidInputError("failed to create SD-JWT VC: %w", err)
+		}
+		return sdJWT.Envelope()
 	case "":
 		fallthrough
 	case vc.JSONLDCredentialProofFormat:
@@ -331,33 +384,74 @@ func (i issuer) revokeDIDNuts(ctx context.Context, credentialID ssi.URI) (*crede
 
 // revokeStatusList revokes a credential through its credential status
 func (i issuer) revokeStatusList(ctx context.Context, credentialID ssi.URI) error {
-	cred, err := i.store.GetCredential(credentialID)
+	slEntry, err := i.statusListEntry(credentialID, revocation.StatusPurposeRevocation)
 	if err != nil {
 		return err
 	}
+	return i.statusList.Revoke(ctx, credentialID, *slEntry)
+}
 
-	statuses, err := cred.CredentialStatuses()
+func (i issuer) Suspend(ctx context.Context, credentialID ssi.URI) error {
+	slEntry, err := i.statusListEntry(credentialID, revocation.StatusPurposeSuspension)
+	if err != nil {
+		return err
+	}
+	if err = i.statusList.Suspend(ctx, credentialID, *slEntry); err != nil {
+		return err
+	}
+	log.Logger().
+		WithField(core.LogFieldCredentialID, credentialID).
+		Info("Verifiable Credential suspended")
+	return nil
+}
+
+func (i issuer) Unsuspend(ctx context.Context, credentialID ssi.URI) error {
+	slEntry, err := i.statusListEntry(credentialID, revocation.StatusPurposeSuspension)
 	if err != nil {
 		return err
 	}
+	if err = i.statusList.Unsuspend(ctx, credentialID, *slEntry); err != nil {
+		return err
+	}
+	log.Logger().
+		WithField(core.LogFieldCredentialID, credentialID).
+		Info("Verifiable Credential unsuspended")
+	return nil
+}
 
-	// find the correct credentialStatus and revoke it on the relevant statuslist
+// statusListEntry returns the status list entry with the given purpose from the credentialStatus of an issued credential.
+// It returns types.ErrStatusNotFound if the credential does not contain such an entry.
+func (i issuer) statusListEntry(credentialID ssi.URI, purpose string) (*revocation.StatusList2021Entry, error) {
+	stored, err := i.store.GetCredential(credentialID)
+	if err != nil {
+		return nil, err
+	}
+	cred, err := sdjwt.Unwrap(*stored)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, err := cred.CredentialStatuses()
+	if err != nil {
+		return nil, err
+	}
+
+	// find the credentialStatus with the correct purpose
 	for _, status := range statuses {
-		if status.Type == revocation.StatusList2021EntryType {
+		if status.Type == revocation.StatusList2021EntryType || status.Type == revocation.BitstringStatusListEntryType {
 			var slEntry revocation.StatusList2021Entry
 			err = json.Unmarshal(status.Raw(), &slEntry)
 			if err != nil {
-				return err
+				return nil, err
 			}
-			// TODO: make sure it is the correct entry when we allow other purposes, or VC issuance that include other credential statuses
-			if slEntry.StatusPurpose != revocation.StatusPurposeRevocation {
+			if slEntry.StatusPurpose != purpose {
 				continue
 			}
-			return i.statusList.Revoke(ctx, credentialID, slEntry)
+			return &slEntry, nil
 		}
 	}
 
-	return types.ErrStatusNotFound
+	return nil, types.ErrStatusNotFound
 }
 
 func (i issuer) buildRevocation(ctx context.Context, credentialID ssi.URI) (*credential.Revocation, error) {
@@ -432,6 +526,10 @@ func (i issuer) StatusList(ctx context.Context, issuerDID did.DID, page int) (*v
 	return i.statusList.Credential(ctx, issuerDID, page)
 }
 
+func (i issuer) ResignStatusLists(ctx context.Context, issuerDID did.DID) error {
+	return i.statusList.Resign(ctx, issuerDID)
+}
+
 func NewStore(db *gorm.DB, leiaIssuerStorePath string, leiaIssuerBackupStore stoabs.KVStore) (Store, error) {
 	didNutsStore, err := NewLeiaIssuerStore(leiaIssuerStorePath, leiaIssuerBackupStore)
 	if err != nil {
@@ -480,7 +578,12 @@ func (c combinedStore) GetCredential(id ssi.URI) (*vc.VerifiableCredential, erro
 }
 
 func (c combinedStore) StoreCredential(vc vc.VerifiableCredential) error {
-	if strings.HasPrefix(vc.Issuer.String(), "did:nuts:") {
+	// enveloped SD-JWT VCs don't have an issuer, only the credential they contain has
+	view, err := sdjwt.Unwrap(vc)
+	if err != nil {
+		return err
+	}
+	if strings.HasPrefix(view.Issuer.String(), "did:nuts:") {
 		return c.didNutsStore.StoreCredential(vc)
 	}
 	return c.otherDIDsStore.StoreCredential(vc)
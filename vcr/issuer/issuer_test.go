@@ -25,11 +25,14 @@ import (
 	"errors"
 	"fmt"
 	"gorm.io/gorm"
+	"net/http"
+	"net/http/httptest"
 	"path"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lestrrat-go/jwx/v2/jws"
 	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/go-did/vc"
@@ -39,10 +42,12 @@ import (
 	"github.com/nuts-foundation/nuts-node/jsonld"
 	"github.com/nuts-foundation/nuts-node/storage"
 	"github.com/nuts-foundation/nuts-node/storage/orm"
+	http2 "github.com/nuts-foundation/nuts-node/test/http"
 	"github.com/nuts-foundation/nuts-node/test/io"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
 	"github.com/nuts-foundation/nuts-node/vcr/openid4vci"
 	"github.com/nuts-foundation/nuts-node/vcr/revocation"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"github.com/nuts-foundation/nuts-node/vcr/signature"
 	"github.com/nuts-foundation/nuts-node/vcr/test"
 	"github.com/nuts-foundation/nuts-node/vcr/trust"
@@ -147,6 +152,73 @@ func Test_issuer_buildAndSignVC(t *testing.T) {
 			assert.Equal(t, *result.ExpirationDate, result.JWT().Expiration())
 			assert.Equal(t, result.ID.String(), result.JWT().JwtID())
 		})
+		t.Run("with credentialSchema", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			keyResolverMock := resolver.NewMockKeyResolver(ctrl)
+			keyResolverMock.EXPECT().ResolveKey(issuerDID, nil, resolver.AssertionMethod).Return(kid, signingKey, nil)
+			sut := issuer{keyResolver: keyResolverMock, jsonldManager: jsonld.NewTestJSONLDManager(t), keyStore: keyStore}
+			schemas := []credential.CredentialSchema{{ID: "https://example.com/schema.json", Type: credential.JsonSchemaType}}
+
+			result, err := sut.buildAndSignVC(ctx, template, CredentialOptions{Format: vc.JWTCredentialProofFormat, CredentialSchema: schemas})
+
+			require.NoError(t, err)
+			actual, err := credential.CredentialSchemas(*result)
+			require.NoError(t, err)
+			assert.Equal(t, schemas, actual)
+		})
+	})
+	t.Run("credentialSchema is only supported for JWT", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		keyResolverMock := resolver.NewMockKeyResolver(ctrl)
+		keyResolverMock.EXPECT().ResolveKey(issuerDID, nil, resolver.AssertionMethod).Return(kid, signingKey, nil)
+		sut := issuer{keyResolver: keyResolverMock, jsonldManager: jsonld.NewTestJSONLDManager(t), keyStore: keyStore}
+		schemas := []credential.CredentialSchema{{ID: "https://example.com/schema.json", Type: credential.JsonSchemaType}}
+
+		result, err := sut.buildAndSignVC(ctx, template, CredentialOptions{CredentialSchema: schemas})
+
+		assert.EqualError(t, err, "credentialSchema is only supported for credential format: jwt_vc")
+		assert.Nil(t, result)
+	})
+	t.Run("SD-JWT", func(t *testing.T) {
+		sdTemplate := template
+		sdTemplate.CredentialSubject = []interface{}{map[string]interface{}{
+			"id":   subjectDID,
+			"name": "Alice",
+			"city": "Amsterdam",
+		}}
+		ctrl := gomock.NewController(t)
+		keyResolverMock := resolver.NewMockKeyResolver(ctrl)
+		keyResolverMock.EXPECT().ResolveKey(issuerDID, nil, resolver.AssertionMethod).Return(kid, signingKey, nil).AnyTimes()
+		sut := issuer{keyResolver: keyResolverMock, jsonldManager: jsonld.NewTestJSONLDManager(t), keyStore: keyStore}
+		t.Run("ok", func(t *testing.T) {
+			result, err := sut.buildAndSignVC(ctx, sdTemplate, CredentialOptions{Format: sdjwt.Format, SelectivelyDisclosable: []string{"name"}})
+
+			require.NoError(t, err)
+			require.True(t, sdjwt.IsEnveloped(*result))
+			sdJWT, err := sdjwt.FromCredential(*result)
+			require.NoError(t, err)
+			assert.Len(t, sdJWT.Disclosures, 1)
+			assert.Equal(t, "name", sdJWT.Disclosures[0].Name)
+			view, err := sdJWT.Credential()
+			require.NoError(t, err)
+			assert.Contains(t, view.Type, credentialType, "expected vc to be of right type")
+			assert.Contains(t, view.Context, vc.VCContextV1URI())
+			assert.Equal(t, issuerID.String(), view.Issuer.String())
+			assert.Equal(t, issuance.Unix(), view.IssuanceDate.Unix())
+			assert.Equal(t, template.ExpirationDate.Unix(), view.ExpirationDate.Unix())
+			assert.Equal(t, sdTemplate.CredentialSubject, view.CredentialSubject)
+			// Assert JWT
+			message, err := jws.ParseString(sdJWT.IssuerJWT)
+			require.NoError(t, err)
+			assert.Equal(t, kid, message.Signatures()[0].ProtectedHeaders().KeyID())
+			assert.Equal(t, sdjwt.Format, message.Signatures()[0].ProtectedHeaders().Type())
+		})
+		t.Run("unknown selectively disclosable claim", func(t *testing.T) {
+			result, err := sut.buildAndSignVC(ctx, sdTemplate, CredentialOptions{Format: sdjwt.Format, SelectivelyDisclosable: []string{"street"}})
+
+			assert.EqualError(t, err, "failed to create SD-JWT VC: claim 'credentialSubject.street' not found")
+			assert.Nil(t, result)
+		})
 	})
 	t.Run("credentialStatus", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
@@ -171,6 +243,54 @@ func Test_issuer_buildAndSignVC(t *testing.T) {
 			require.Len(t, statuses, 1)
 			assert.Equal(t, revocation.StatusList2021EntryType, statuses[0].Type)
 		})
+		t.Run("ok - BitstringStatusList", func(t *testing.T) {
+			slTemplate := template
+			slTemplate.Issuer = webIssuerDID.URI()
+
+			jsonldManager := jsonld.NewTestJSONLDManager(t)
+			sut := issuer{keyResolver: keyResolverMock, jsonldManager: jsonldManager, keyStore: keyStore, statusList: newTestStatusList2021(t, orm.NewTestDatabase(t), signingKey, webIssuerDID)}
+
+			result, err := sut.buildAndSignVC(ctx, slTemplate, CredentialOptions{WithStatusListRevocation: true, StatusListType: revocation.BitstringStatusListType})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			assert.Contains(t, result.Context, revocation.BitstringStatusListContextURI)
+			assert.NotContains(t, result.Context, revocation.StatusList2021ContextURI)
+
+			statuses, err := result.CredentialStatuses()
+			require.NoError(t, err)
+			require.Len(t, statuses, 1)
+			assert.Equal(t, revocation.BitstringStatusListEntryType, statuses[0].Type)
+		})
+		t.Run("ok - suspension", func(t *testing.T) {
+			slTemplate := template
+			slTemplate.Issuer = webIssuerDID.URI()
+
+			jsonldManager := jsonld.NewTestJSONLDManager(t)
+			sut := issuer{keyResolver: keyResolverMock, jsonldManager: jsonldManager, keyStore: keyStore, statusList: newTestStatusList2021(t, orm.NewTestDatabase(t), signingKey, webIssuerDID)}
+
+			result, err := sut.buildAndSignVC(ctx, slTemplate, CredentialOptions{WithStatusListRevocation: true, WithStatusListSuspension: true})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			var entries []revocation.StatusList2021Entry
+			require.NoError(t, result.UnmarshalCredentialStatus(&entries))
+			require.Len(t, entries, 2)
+			assert.Equal(t, revocation.StatusPurposeRevocation, entries[0].StatusPurpose)
+			assert.Equal(t, revocation.StatusPurposeSuspension, entries[1].StatusPurpose)
+			assert.NotEqual(t, entries[0].StatusListCredential, entries[1].StatusListCredential)
+		})
+		t.Run("error - unsupported status list type", func(t *testing.T) {
+			slTemplate := template
+			slTemplate.Issuer = webIssuerDID.URI()
+
+			sut := issuer{keyResolver: keyResolverMock, statusList: newTestStatusList2021(t, orm.NewTestDatabase(t), signingKey, webIssuerDID)}
+
+			result, err := sut.buildAndSignVC(ctx, slTemplate, CredentialOptions{WithStatusListRevocation: true, StatusListType: "RevocationList2020"})
+
+			assert.EqualError(t, err, "unsupported status list type: RevocationList2020")
+			assert.Nil(t, result)
+		})
 	})
 
 	t.Run("it does not add the default context twice", func(t *testing.T) {
@@ -327,6 +447,17 @@ func Test_issuer_Issue(t *testing.T) {
 		assert.Nil(t, result)
 	})
 
+	t.Run("publishing SD-JWT VCs is disallowed", func(t *testing.T) {
+		sut := issuer{}
+
+		result, err := sut.Issue(ctx, template, CredentialOptions{
+			Publish: true,
+			Format:  sdjwt.Format,
+		})
+		require.EqualError(t, err, "publishing SD-JWT VCs is not supported")
+		assert.Nil(t, result)
+	})
+
 	t.Run("OpenID4VCI", func(t *testing.T) {
 		const walletIdentifier = "http://example.com/wallet"
 		t.Run("ok - publish over OpenID4VCI fails - fallback to network", func(t *testing.T) {
@@ -530,11 +661,32 @@ func Test_issuer_Issue(t *testing.T) {
 			assert.EqualError(t, err, "jsonld: invalid property: Dropping property that did not expand into an absolute IRI or keyword.")
 			assert.Nil(t, result)
 		})
+
+		t.Run("credential does not conform to credentialSchema", func(t *testing.T) {
+			const schema = `{"type": "object", "properties": {"credentialSubject": {"required": ["name"]}}}`
+			server := httptest.NewServer(&http2.Handler{StatusCode: http.StatusOK, ResponseData: schema})
+			defer server.Close()
+			keyResolverMock := resolver.NewMockKeyResolver(ctrl)
+			keyResolverMock.EXPECT().ResolveKey(issuerDID, nil, resolver.AssertionMethod).Return(issuerKeyID, issuerKey, nil)
+			schemaValidator, err := credential.NewSchemaValidator(http.DefaultClient, []string{server.URL}, time.Minute)
+			require.NoError(t, err)
+			sut := issuer{keyResolver: keyResolverMock, jsonldManager: jsonldManager, keyStore: nutsCryptoInstance,
+				schemaValidator: schemaValidator}
+
+			result, err := sut.Issue(ctx, template, CredentialOptions{
+				Format:           vc.JWTCredentialProofFormat,
+				CredentialSchema: []credential.CredentialSchema{{ID: server.URL, Type: credential.JsonSchemaType}},
+			})
+
+			assert.ErrorContains(t, err, "credential does not conform to credentialSchema")
+			assert.ErrorIs(t, err, core.InvalidInputError(""))
+			assert.Nil(t, result)
+		})
 	})
 }
 
 func TestNewIssuer(t *testing.T) {
-	createdIssuer := NewIssuer(nil, nil, nil, nil, nil, nil, nil, nil, &revocation.StatusList2021{})
+	createdIssuer := NewIssuer(nil, nil, nil, nil, nil, nil, nil, nil, &revocation.StatusList2021{}, nil)
 	assert.IsType(t, &issuer{}, createdIssuer)
 }
 
@@ -781,7 +933,7 @@ func TestIssuer_revokeStatusList(t *testing.T) {
 
 	t.Run("ok", func(t *testing.T) {
 		status := newTestStatusList2021(t, orm.NewTestDatabase(t), signingKey, webIssuerDID)
-		entry, err := status.Entry(context.Background(), webIssuerDID, revocation.StatusPurposeRevocation)
+		entry, err := status.Entry(context.Background(), webIssuerDID, revocation.StatusList2021Type, revocation.StatusPurposeRevocation)
 		require.NoError(t, err)
 		issuerStore, credentialID := storeWithCred(gomock.NewController(t), *entry)
 		sut := issuer{
@@ -796,7 +948,7 @@ func TestIssuer_revokeStatusList(t *testing.T) {
 	})
 	t.Run("error - double revocation", func(t *testing.T) {
 		status := newTestStatusList2021(t, orm.NewTestDatabase(t), signingKey, webIssuerDID)
-		entry, err := status.Entry(context.Background(), webIssuerDID, revocation.StatusPurposeRevocation)
+		entry, err := status.Entry(context.Background(), webIssuerDID, revocation.StatusList2021Type, revocation.StatusPurposeRevocation)
 		require.NoError(t, err)
 		issuerStore, credentialID := storeWithCred(gomock.NewController(t), *entry)
 		sut := issuer{
@@ -822,7 +974,7 @@ func TestIssuer_revokeStatusList(t *testing.T) {
 	})
 	t.Run("error - statuslist credential not found", func(t *testing.T) {
 		status := newTestStatusList2021(t, orm.NewTestDatabase(t), signingKey, webIssuerDID)
-		entry, err := status.Entry(context.Background(), webIssuerDID, revocation.StatusPurposeRevocation)
+		entry, err := status.Entry(context.Background(), webIssuerDID, revocation.StatusList2021Type, revocation.StatusPurposeRevocation)
 		require.NoError(t, err)
 		entry.StatusListCredential = "unknown status list"
 		issuerStore, credentialID := storeWithCred(gomock.NewController(t), *entry)
@@ -850,6 +1002,65 @@ func TestIssuer_revokeStatusList(t *testing.T) {
 	})
 }
 
+func TestIssuer_Suspend(t *testing.T) {
+	storeWithCred := func(c *gomock.Controller, entries ...any) (*MockStore, ssi.URI) {
+		credentialID := ssi.MustParseURI(webIssuerDID.String() + "#identifier")
+		cred := &vc.VerifiableCredential{
+			ID:               &credentialID,
+			Issuer:           ssi.MustParseURI(webIssuerDID.String()),
+			CredentialStatus: entries,
+		}
+		store := NewMockStore(c)
+		store.EXPECT().GetCredential(*cred.ID).Return(cred, nil).MinTimes(1)
+		return store, credentialID
+	}
+
+	ctx := audit.TestContext()
+	keyStore := nutsCrypto.NewMemoryCryptoInstance(t)
+	_, signingKey, err := keyStore.New(ctx, nutsCrypto.StringNamingFunc(webIssuerDID.String()+"#abc"))
+	require.NoError(t, err)
+
+	t.Run("ok - suspend and unsuspend", func(t *testing.T) {
+		status := newTestStatusList2021(t, orm.NewTestDatabase(t), signingKey, webIssuerDID)
+		revocationEntry, err := status.Entry(ctx, webIssuerDID, revocation.StatusList2021Type, revocation.StatusPurposeRevocation)
+		require.NoError(t, err)
+		suspensionEntry, err := status.Entry(ctx, webIssuerDID, revocation.StatusList2021Type, revocation.StatusPurposeSuspension)
+		require.NoError(t, err)
+		issuerStore, credentialID := storeWithCred(gomock.NewController(t), *revocationEntry, *suspensionEntry)
+		sut := issuer{
+			store:      issuerStore,
+			statusList: status,
+		}
+
+		require.NoError(t, sut.Suspend(ctx, credentialID))
+		assert.ErrorIs(t, sut.Suspend(ctx, credentialID), vcr.ErrSuspended)
+		require.NoError(t, sut.Unsuspend(ctx, credentialID))
+		assert.ErrorIs(t, sut.Unsuspend(ctx, credentialID), vcr.ErrNotSuspended)
+	})
+	t.Run("error - no suspension credential status", func(t *testing.T) {
+		status := newTestStatusList2021(t, orm.NewTestDatabase(t), signingKey, webIssuerDID)
+		revocationEntry, err := status.Entry(ctx, webIssuerDID, revocation.StatusList2021Type, revocation.StatusPurposeRevocation)
+		require.NoError(t, err)
+		issuerStore, credentialID := storeWithCred(gomock.NewController(t), *revocationEntry)
+		sut := issuer{
+			store:      issuerStore,
+			statusList: status,
+		}
+
+		assert.ErrorIs(t, sut.Suspend(ctx, credentialID), vcr.ErrStatusNotFound)
+		assert.ErrorIs(t, sut.Unsuspend(ctx, credentialID), vcr.ErrStatusNotFound)
+	})
+	t.Run("error - credential not found", func(t *testing.T) {
+		store := NewMockStore(gomock.NewController(t))
+		store.EXPECT().GetCredential(gomock.Any()).Return(nil, vcr.ErrNotFound)
+		sut := issuer{store: store}
+
+		err := sut.Suspend(ctx, ssi.MustParseURI("did:web:example.com:iam#not-found"))
+
+		assert.ErrorIs(t, err, vcr.ErrNotFound)
+	})
+}
+
 func TestIssuer_isRevoked(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
@@ -912,7 +1123,7 @@ func TestIssuer_StatusList(t *testing.T) {
 			statusList:    newTestStatusList2021(t, db, signingKey, webIssuerDID),
 		}
 		sut.statusList.(*revocation.StatusList2021).Sign = sut.buildJSONLDCredential
-		_, err = sut.statusList.Entry(ctx, webIssuerDID, revocation.StatusPurposeRevocation)
+		_, err = sut.statusList.Entry(ctx, webIssuerDID, revocation.StatusList2021Type, revocation.StatusPurposeRevocation)
 		require.NoError(t, err)
 
 		result, err := sut.StatusList(ctx, webIssuerDID, 1)
@@ -944,8 +1155,8 @@ func TestIssuer_StatusList(t *testing.T) {
 		vDIDResolverMock.EXPECT().Resolve(gomock.Any(), gomock.Any())
 		vKeyResolverMock := resolver.NewMockKeyResolver(ctrl)
 		vKeyResolverMock.EXPECT().ResolveKeyByID(gomock.Any(), gomock.Any(), gomock.Any()).Return(signingKey, nil)
-		verif := verifier.NewVerifier(vStoreMock, vDIDResolverMock, vKeyResolverMock, jsonldManager, nil, &revocation.StatusList2021{}, nil)
-		assert.NoError(t, verif.Verify(*result, true, true, nil))
+		verif := verifier.NewVerifier(vStoreMock, vDIDResolverMock, vKeyResolverMock, jsonldManager, nil, &revocation.StatusList2021{}, nil, nil)
+		assert.NoError(t, verif.Verify(context.Background(), *result, true, true, nil))
 	})
 	t.Run("error - unknown status list credential", func(t *testing.T) {
 		sut := issuer{statusList: newTestStatusList2021(t, orm.NewTestDatabase(t), signingKey, webIssuerDID)}
@@ -1091,6 +1302,33 @@ func Test_combinedStore_StoreCredential(t *testing.T) {
 			Issuer: webIssuerDID.URI(),
 		})
 
+		assert.NoError(t, err)
+	})
+	t.Run("SD-JWT VC issued by did:web", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		nutsStore := NewMockStore(ctrl)
+		webStore := NewMockStore(ctrl)
+		webStore.EXPECT().StoreCredential(gomock.Any()).Return(nil)
+		sut := combinedStore{
+			didNutsStore:   nutsStore,
+			otherDIDsStore: webStore,
+		}
+		keyStore := nutsCrypto.NewMemoryCryptoInstance(t)
+		kid := webIssuerDID.String() + "#1"
+		_, _, err := keyStore.New(audit.TestContext(), nutsCrypto.StringNamingFunc(kid))
+		require.NoError(t, err)
+		sdJWT, err := sdjwt.Create(audit.TestContext(), vc.VerifiableCredential{
+			Issuer:            webIssuerDID.URI(),
+			CredentialSubject: []interface{}{map[string]interface{}{"id": "did:web:example.com:holder"}},
+		}, nil, func(ctx context.Context, claims map[string]interface{}, headers map[string]interface{}) (string, error) {
+			return keyStore.SignJWT(ctx, claims, headers, kid)
+		})
+		require.NoError(t, err)
+		envelope, err := sdJWT.Envelope()
+		require.NoError(t, err)
+
+		err = sut.StoreCredential(*envelope)
+
 		assert.NoError(t, err)
 	})
 }
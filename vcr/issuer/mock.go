@@ -112,6 +112,20 @@ func (mr *MockIssuerMockRecorder) Issue(ctx, template, options any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Issue", reflect.TypeOf((*MockIssuer)(nil).Issue), ctx, template, options)
 }
 
+// ResignStatusLists mocks base method.
+func (m *MockIssuer) ResignStatusLists(ctx context.Context, issuer did.DID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResignStatusLists", ctx, issuer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResignStatusLists indicates an expected call of ResignStatusLists.
+func (mr *MockIssuerMockRecorder) ResignStatusLists(ctx, issuer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResignStatusLists", reflect.TypeOf((*MockIssuer)(nil).ResignStatusLists), ctx, issuer)
+}
+
 // Revoke mocks base method.
 func (m *MockIssuer) Revoke(ctx context.Context, credentialID ssi.URI) (*credential.Revocation, error) {
 	m.ctrl.T.Helper()
@@ -157,6 +171,34 @@ func (mr *MockIssuerMockRecorder) StatusList(ctx, issuer, page any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StatusList", reflect.TypeOf((*MockIssuer)(nil).StatusList), ctx, issuer, page)
 }
 
+// Suspend mocks base method.
+func (m *MockIssuer) Suspend(ctx context.Context, credentialID ssi.URI) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suspend", ctx, credentialID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Suspend indicates an expected call of Suspend.
+func (mr *MockIssuerMockRecorder) Suspend(ctx, credentialID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suspend", reflect.TypeOf((*MockIssuer)(nil).Suspend), ctx, credentialID)
+}
+
+// Unsuspend mocks base method.
+func (m *MockIssuer) Unsuspend(ctx context.Context, credentialID ssi.URI) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unsuspend", ctx, credentialID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unsuspend indicates an expected call of Unsuspend.
+func (mr *MockIssuerMockRecorder) Unsuspend(ctx, credentialID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsuspend", reflect.TypeOf((*MockIssuer)(nil).Unsuspend), ctx, credentialID)
+}
+
 // MockStore is a mock of Store interface.
 type MockStore struct {
 	ctrl     *gomock.Controller
@@ -84,7 +84,7 @@ func (s sqlStore) StoreCredential(credential vc.VerifiableCredential) error {
 			return err
 		}
 		return tx.Create(&issuedCredential{
-			ID:         credential.ID.String(),
+			ID:         credentialRecord.ID,
 			Credential: *credentialRecord,
 		}).Error
 	})
@@ -0,0 +1,184 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package pe
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
+)
+
+const (
+	// LimitDisclosureRequired indicates the holder MUST only disclose the claims referenced by the constraint fields.
+	LimitDisclosureRequired = "required"
+	// LimitDisclosurePreferred indicates the holder SHOULD only disclose the claims referenced by the constraint fields.
+	LimitDisclosurePreferred = "preferred"
+)
+
+// limitDisclosure returns the limit_disclosure value of the input descriptor's constraints, or an empty string if not set.
+func (inputDescriptor InputDescriptor) limitDisclosure() string {
+	if inputDescriptor.Constraints == nil {
+		return ""
+	}
+	return inputDescriptor.Constraints.LimitDisclosure
+}
+
+// supportsSelectiveDisclosure returns true if the format of the credential allows the holder to only disclose a subset of its claims.
+func supportsSelectiveDisclosure(credential vc.VerifiableCredential) bool {
+	return credentialFormat(credential) == sdjwt.Format
+}
+
+// limitDisclosureError returns ErrLimitDisclosureNotSupported if any of the candidates could not be matched,
+// because it requires limited disclosure. It returns nil otherwise.
+func limitDisclosureError(candidates []Candidate) error {
+	for _, candidate := range candidates {
+		if candidate.limitDisclosureUnsupported {
+			return ErrLimitDisclosureNotSupported
+		}
+	}
+	return nil
+}
+
+// SelectDisclosures returns a copy of the given SD-JWT VC, only containing the disclosures of the claims
+// referenced by the constraint fields of the input descriptor.
+// For each field, the claims are disclosed of the first path that yields a value matching the field's filter.
+// Claims nested in a referenced claim are disclosed as well.
+// Credentials that aren't SD-JWT VCs are returned as-is.
+func SelectDisclosures(inputDescriptor InputDescriptor, credential vc.VerifiableCredential) (*vc.VerifiableCredential, error) {
+	if !sdjwt.IsEnveloped(credential) {
+		return &credential, nil
+	}
+	sdJWT, err := sdjwt.FromCredential(credential)
+	if err != nil {
+		return nil, err
+	}
+	disclosed, err := sdJWT.Credential()
+	if err != nil {
+		return nil, err
+	}
+	credentialAsMap, err := remarshalToMap(disclosed)
+	if err != nil {
+		return nil, err
+	}
+	var referenced [][]string
+	if inputDescriptor.Constraints != nil {
+		for _, field := range inputDescriptor.Constraints.Fields {
+			path, err := fieldPath(field, credentialAsMap)
+			if err != nil {
+				return nil, err
+			}
+			if path != "" {
+				referenced = append(referenced, jsonPathSegments(path))
+			}
+		}
+	}
+	selected, err := sdJWT.Select(func(claimPath []string) bool {
+		// the credential is contained by the 'vc' claim of the SD-JWT
+		if len(claimPath) == 0 || claimPath[0] != "vc" {
+			return false
+		}
+		claimPath = claimPath[1:]
+		for _, current := range referenced {
+			if hasPathPrefix(claimPath, current) || hasPathPrefix(current, claimPath) {
+				return true
+			}
+		}
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+	return selected.Envelope()
+}
+
+// fieldPath returns the first path of the field that yields a value matching the field's filter.
+// It returns an empty string if none of the paths match.
+func fieldPath(field Field, credential map[string]interface{}) (string, error) {
+	for _, path := range field.Path {
+		value, err := getValueAtPath(path, credential)
+		if err != nil {
+			return "", err
+		}
+		if value == nil {
+			continue
+		}
+		if field.Filter != nil {
+			match, _, err := matchFilter(*field.Filter, value)
+			if err != nil {
+				return "", err
+			}
+			if !match {
+				continue
+			}
+		}
+		return path, nil
+	}
+	return "", nil
+}
+
+// jsonPathSegments returns the object property names referenced by a JSONPath expression,
+// e.g. [credentialSubject organization city] for '$.credentialSubject.organization.city'.
+// Array indices are skipped, since claim paths of SD-JWTs don't contain them.
+// Parsing stops at expressions that don't reference a single property (wildcards, filters, deep scans),
+// meaning the returned path references all claims below it.
+func jsonPathSegments(path string) []string {
+	var result []string
+	remaining := strings.TrimPrefix(path, "$")
+	for len(remaining) > 0 {
+		switch {
+		case strings.HasPrefix(remaining, ".."):
+			return result
+		case remaining[0] == '.':
+			remaining = remaining[1:]
+			end := strings.IndexAny(remaining, ".[")
+			if end == -1 {
+				end = len(remaining)
+			}
+			name := remaining[:end]
+			if name == "*" {
+				return result
+			}
+			result = append(result, name)
+			remaining = remaining[end:]
+		case remaining[0] == '[':
+			end := strings.Index(remaining, "]")
+			if end == -1 {
+				return result
+			}
+			selector := remaining[1:end]
+			remaining = remaining[end+1:]
+			if len(selector) >= 2 && (selector[0] == '\'' || selector[0] == '"') && selector[len(selector)-1] == selector[0] {
+				result = append(result, selector[1:len(selector)-1])
+			} else if _, err := strconv.Atoi(selector); err != nil {
+				// wildcard, filter, slice or union
+				return result
+			}
+		default:
+			return result
+		}
+	}
+	return result
+}
+
+func hasPathPrefix(path []string, prefix []string) bool {
+	return len(path) >= len(prefix) && slices.Equal(path[:len(prefix)], prefix)
+}
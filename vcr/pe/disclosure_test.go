@@ -0,0 +1,115 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package pe
+
+import (
+	"testing"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
+	vcrTest "github.com/nuts-foundation/nuts-node/vcr/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectDisclosures(t *testing.T) {
+	sdJWTVC := vcrTest.SDJWTNutsOrganizationCredential(t, did.MustParseDID("did:web:example.com"))
+	disclosedOrganization := func(t *testing.T, credential vc.VerifiableCredential) map[string]interface{} {
+		view, err := sdjwt.Unwrap(credential)
+		require.NoError(t, err)
+		organization, _ := view.CredentialSubject[0].(map[string]interface{})["organization"].(map[string]interface{})
+		return organization
+	}
+
+	t.Run("only discloses referenced claims", func(t *testing.T) {
+		inputDescriptor := *definitions().SDJWT.InputDescriptors[0]
+
+		result, err := SelectDisclosures(inputDescriptor, sdJWTVC)
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"city": "IJbergen"}, disclosedOrganization(t, *result))
+		sdJWT, err := sdjwt.FromCredential(*result)
+		require.NoError(t, err)
+		assert.Len(t, sdJWT.Disclosures, 2)
+	})
+	t.Run("discloses nested claims of referenced claim", func(t *testing.T) {
+		inputDescriptor := InputDescriptor{
+			Constraints: &Constraints{
+				Fields: []Field{{Path: []string{"$.credentialSubject.organization"}}},
+			},
+		}
+
+		result, err := SelectDisclosures(inputDescriptor, sdJWTVC)
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"city": "IJbergen", "name": "care"}, disclosedOrganization(t, *result))
+	})
+	t.Run("path of which filter does not match is not disclosed", func(t *testing.T) {
+		constant := "other"
+		inputDescriptor := InputDescriptor{
+			Constraints: &Constraints{
+				Fields: []Field{{
+					Path:   []string{"$.credentialSubject.organization.name", "$.credentialSubject.organization.city"},
+					Filter: &Filter{Type: "string", Const: &constant},
+				}},
+			},
+		}
+
+		result, err := SelectDisclosures(inputDescriptor, sdJWTVC)
+
+		require.NoError(t, err)
+		assert.Nil(t, disclosedOrganization(t, *result))
+	})
+	t.Run("no constraints", func(t *testing.T) {
+		result, err := SelectDisclosures(InputDescriptor{}, sdJWTVC)
+
+		require.NoError(t, err)
+		assert.Nil(t, disclosedOrganization(t, *result))
+	})
+	t.Run("not an SD-JWT VC", func(t *testing.T) {
+		credential := vcrTest.ValidNutsOrganizationCredential(t)
+
+		result, err := SelectDisclosures(*definitions().SDJWT.InputDescriptors[0], credential)
+
+		require.NoError(t, err)
+		assert.Equal(t, credential.Raw(), result.Raw())
+	})
+}
+
+func Test_jsonPathSegments(t *testing.T) {
+	testCases := []struct {
+		path     string
+		expected []string
+	}{
+		{"$.credentialSubject.organization.city", []string{"credentialSubject", "organization", "city"}},
+		{"$.credentialSubject[0].organization.city", []string{"credentialSubject", "organization", "city"}},
+		{"$['credentialSubject'][\"organization\"]", []string{"credentialSubject", "organization"}},
+		{"$.credentialSubject[*].organization", []string{"credentialSubject"}},
+		{"$.credentialSubject.*", []string{"credentialSubject"}},
+		{"$..city", nil},
+		{"$.credentialSubject[?(@.id)]", []string{"credentialSubject"}},
+		{"$", nil},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.path, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, jsonPathSegments(testCase.path))
+		})
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/lestrrat-go/jwx/v2/jwa"
 	"github.com/lestrrat-go/jwx/v2/jws"
 	v2 "github.com/nuts-foundation/nuts-node/vcr/pe/schema/v2"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"strings"
 
 	"github.com/PaesslerAG/jsonpath"
@@ -54,6 +55,9 @@ func ParsePresentationDefinition(raw []byte) (*PresentationDefinition, error) {
 type Candidate struct {
 	InputDescriptor InputDescriptor
 	VC              *vc.VerifiableCredential
+	// limitDisclosureUnsupported is set when the input descriptor requires limit disclosure,
+	// and VCs matched the constraints, but none of them support selective disclosure.
+	limitDisclosureUnsupported bool
 }
 
 // PresentationContext is a helper struct to keep track of the index of the VP in the nested paths of a PresentationSubmission.
@@ -68,9 +72,14 @@ type PresentationContext struct {
 // It supports the following:
 // - ldp_vc format
 // - jwt_vc format
+// - vc+sd-jwt format
 // - pattern, const and enum only on string fields
 // - number, boolean, array and string JSON schema types
 // - Submission Requirements Feature
+// - Limited Disclosure Feature ('required' and 'preferred')
+// The returned SD-JWT VCs only disclose the claims referenced by the constraint fields of their input descriptor.
+// If an input descriptor requires limited disclosure and only VCs that don't support selective disclosure match,
+// an error wrapping ErrNoCredentials and ErrLimitDisclosureNotSupported is returned.
 // ErrUnsupportedFilter is returned when a filter uses unsupported features.
 // Other errors can be returned for faulty JSON paths or regex patterns.
 func (presentationDefinition PresentationDefinition) Match(vcs []vc.VerifiableCredential) ([]vc.VerifiableCredential, []InputDescriptorMappingObject, error) {
@@ -145,16 +154,39 @@ func (presentationDefinition PresentationDefinition) matchConstraints(vcs []vc.V
 		match := Candidate{
 			InputDescriptor: *inputDescriptor,
 		}
+		limitDisclosure := inputDescriptor.limitDisclosure()
+		// VC that matches, but doesn't support selective disclosure while limited disclosure is required or preferred
+		var fallback *vc.VerifiableCredential
 		for _, credential := range vcs {
 			isMatch, err := matchCredential(*inputDescriptor, credential)
 			if err != nil {
 				return nil, err
 			}
 			// InputDescriptor formats must be a subset of the PresentationDefinition formats, so it must satisfy both.
-			if isMatch && matchFormat(presentationDefinition.Format, credential) && matchFormat(inputDescriptor.Format, credential) {
+			if !isMatch || !matchFormat(presentationDefinition.Format, credential) || !matchFormat(inputDescriptor.Format, credential) {
+				continue
+			}
+			if limitDisclosure == "" || supportsSelectiveDisclosure(credential) {
 				match.VC = &credential
 				break
 			}
+			if fallback == nil {
+				fallback = &credential
+			}
+		}
+		if match.VC == nil && fallback != nil {
+			if limitDisclosure == LimitDisclosurePreferred {
+				match.VC = fallback
+			} else {
+				match.limitDisclosureUnsupported = true
+			}
+		}
+		if match.VC != nil {
+			derived, err := SelectDisclosures(*inputDescriptor, *match.VC)
+			if err != nil {
+				return nil, fmt.Errorf("failed to select disclosures for input descriptor '%s': %w", inputDescriptor.Id, err)
+			}
+			match.VC = derived
 		}
 		candidates = append(candidates, match)
 	}
@@ -175,7 +207,9 @@ func (presentationDefinition PresentationDefinition) matchBasic(vcs []vc.Verifia
 	// select all candidates without a VC
 	descriptorsNotMatched := make([]string, 0)
 	for _, candidate := range candidates {
-		if candidate.VC == nil {
+		if candidate.limitDisclosureUnsupported {
+			descriptorsNotMatched = append(descriptorsNotMatched, fmt.Sprintf("no VC supporting selective disclosure for InputDescriptor (%s)", candidate.InputDescriptor.Id))
+		} else if candidate.VC == nil {
 			descriptorsNotMatched = append(descriptorsNotMatched, fmt.Sprintf("no VC for InputDescriptor (%s)", candidate.InputDescriptor.Id))
 		}
 	}
@@ -183,14 +217,15 @@ func (presentationDefinition PresentationDefinition) matchBasic(vcs []vc.Verifia
 	// we do not raise an error here since SubmissionRequirements might specify a "pick" rule
 
 	if len(descriptorsNotMatched) > 0 {
-		return nil, nil, errors.Join(ErrNoCredentials, fmt.Errorf("constraints not matched: %s", strings.Join(descriptorsNotMatched, ", ")))
+		err := fmt.Errorf("constraints not matched: %s", strings.Join(descriptorsNotMatched, ", "))
+		return nil, nil, errors.Join(ErrNoCredentials, limitDisclosureError(candidates), err)
 	}
 
 	for i, candidate := range candidates {
 		// create the InputDescriptorMappingObject with the relative path
 		mapping := InputDescriptorMappingObject{
 			Id:     candidate.InputDescriptor.Id,
-			Format: candidate.VC.Format(),
+			Format: credentialFormat(*candidate.VC),
 			Path:   fmt.Sprintf("$.verifiableCredential[%d]", index),
 		}
 		descriptors = append(descriptors, mapping)
@@ -242,7 +277,7 @@ func (presentationDefinition PresentationDefinition) matchSubmissionRequirements
 	for _, submissionRequirement := range presentationDefinition.SubmissionRequirements {
 		submissionRequirementVCs, err := submissionRequirement.match(availableGroups)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, errors.Join(err, limitDisclosureError(candidates))
 		}
 		selectedVCs = append(selectedVCs, submissionRequirementVCs...)
 	}
@@ -260,7 +295,7 @@ outer:
 			if candidate.VC != nil && vcEqual(uniqueVC, *candidate.VC) {
 				mapping := InputDescriptorMappingObject{
 					Id:     candidate.InputDescriptor.Id,
-					Format: candidate.VC.Format(),
+					Format: credentialFormat(*candidate.VC),
 					Path:   fmt.Sprintf("$.verifiableCredential[%d]", index),
 				}
 				descriptors = append(descriptors, mapping)
@@ -310,7 +345,7 @@ func matchFormat(format *PresentationDefinitionClaimFormatDesignations, credenti
 	}
 
 	asMap := map[string]map[string][]string(*format)
-	switch credential.Format() {
+	switch credentialFormat(credential) {
 	case vc.JSONLDCredentialProofFormat:
 		if entry := asMap[vc.JSONLDCredentialProofFormat]; entry != nil {
 			if len(credential.Proof) == 0 {
@@ -343,10 +378,36 @@ func matchFormat(format *PresentationDefinitionClaimFormatDesignations, credenti
 				}
 			}
 		}
+	case sdjwt.Format:
+		// Check that the algorithm used to sign the issuer-signed JWT is specified by the presentation definition
+		if entry := asMap[sdjwt.Format]; entry != nil {
+			sdJWT, err := sdjwt.FromCredential(credential)
+			if err != nil {
+				return false
+			}
+			signingAlgorithm, err := sdJWT.Algorithm()
+			if err != nil {
+				return false
+			}
+			for _, supportedAlgorithm := range entry["sd-jwt_alg_values"] {
+				if signingAlgorithm == supportedAlgorithm {
+					return true
+				}
+			}
+		}
 	}
 	return false
 }
 
+// credentialFormat returns the Presentation Exchange format of the credential.
+// SD-JWT VCs are enveloped in a credential that is parsed as JSON-LD credential, so they need to be detected separately.
+func credentialFormat(credential vc.VerifiableCredential) string {
+	if sdjwt.IsEnveloped(credential) {
+		return sdjwt.Format
+	}
+	return credential.Format()
+}
+
 func matchProofType(proofType string, credential vc.VerifiableCredential) bool {
 	proofs, _ := credential.Proofs()
 	for _, p := range proofs {
@@ -373,13 +434,20 @@ func matchCredential(descriptor InputDescriptor, credential vc.VerifiableCredent
 // matchConstraint matches the constraint against the VC.
 // All Fields need to match according to the Field rules.
 // IsHolder, SameSubject, SubjectIsIssuer, Statuses are not supported for now.
-// LimitDisclosure is not supported for now.
+// LimitDisclosure is handled by matchConstraints, since it concerns the choice between matching VCs.
+// SD-JWT VCs are matched on the claims they disclose.
 // If the constraint matches, it returns true and a map containing constraint field IDs and matched values.
 func matchConstraint(constraint *Constraints, credential vc.VerifiableCredential) (bool, map[string]interface{}, error) {
 	// jsonpath works on interfaces, so convert the VC to an interface
 	var credentialAsMap map[string]interface{}
 	var err error
-	switch credential.Format() {
+	switch credentialFormat(credential) {
+	case sdjwt.Format:
+		var disclosed *vc.VerifiableCredential
+		disclosed, err = sdjwt.Unwrap(credential)
+		if err == nil {
+			credentialAsMap, err = remarshalToMap(disclosed)
+		}
 	case vc.JWTCredentialProofFormat:
 		// JWT-VCs marshal to a JSON string, so marshal an alias to make sure we get a JSON object with the VC properties,
 		// instead of a JWT string.
@@ -26,6 +26,7 @@ import (
 	"encoding/json"
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/nuts-node/core/to"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	vcrTest "github.com/nuts-foundation/nuts-node/vcr/test"
 	"strings"
 	"testing"
@@ -55,6 +56,7 @@ type testDefinitions struct {
 	JSONLDorJWT         PresentationDefinition
 	JSONLDorJWTWithPick PresentationDefinition
 	JWT                 PresentationDefinition
+	SDJWT               PresentationDefinition
 }
 
 func definitions() testDefinitions {
@@ -87,6 +89,13 @@ func definitions() testDefinitions {
 			panic(err)
 		}
 	}
+	if data, err := testFiles.ReadFile("test/pd_sdjwt.json"); err != nil {
+		panic(err)
+	} else {
+		if err = json.Unmarshal(data, &result.SDJWT); err != nil {
+			panic(err)
+		}
+	}
 	return result
 }
 
@@ -233,6 +242,112 @@ func TestMatch(t *testing.T) {
 				assert.Empty(t, mappingObjects)
 			})
 		})
+		t.Run("SD-JWT", func(t *testing.T) {
+			sdJWTVC := vcrTest.SDJWTNutsOrganizationCredential(t, did.MustParseDID("did:web:example.com"))
+			t.Run("Happy flow", func(t *testing.T) {
+				vcs, mappingObjects, err := definitions().SDJWT.Match([]vc.VerifiableCredential{jwtVC, sdJWTVC})
+
+				require.NoError(t, err)
+				require.Len(t, vcs, 1)
+				require.Len(t, mappingObjects, 1)
+				assert.Equal(t, "vc+sd-jwt", mappingObjects[0].Format)
+				t.Run("only discloses constrained claims", func(t *testing.T) {
+					disclosed, err := sdjwt.Unwrap(vcs[0])
+					require.NoError(t, err)
+					assert.Equal(t, map[string]interface{}{"city": "IJbergen"}, disclosed.CredentialSubject[0].(map[string]interface{})["organization"])
+				})
+			})
+			t.Run("claim not disclosed", func(t *testing.T) {
+				sdJWT, err := sdjwt.FromCredential(sdJWTVC)
+				require.NoError(t, err)
+				sdJWT.Disclosures = nil
+				undisclosed, err := sdJWT.Envelope()
+				require.NoError(t, err)
+
+				vcs, _, err := definitions().SDJWT.Match([]vc.VerifiableCredential{*undisclosed})
+
+				assert.EqualError(t, err, "missing credentials\nconstraints not matched: no VC for InputDescriptor (as_sd_jwt)")
+				assert.Empty(t, vcs)
+			})
+		})
+	})
+	t.Run("Limit disclosure", func(t *testing.T) {
+		subjectDID := did.MustParseDID("did:web:example.com")
+		jwtVC := vcrTest.JWTNutsOrganizationCredential(t, subjectDID)
+		sdJWTVC := vcrTest.SDJWTNutsOrganizationCredential(t, subjectDID)
+		definition := func(limitDisclosure string) PresentationDefinition {
+			return PresentationDefinition{
+				InputDescriptors: []*InputDescriptor{
+					{
+						Id: "organization",
+						Constraints: &Constraints{
+							LimitDisclosure: limitDisclosure,
+							Fields:          []Field{{Path: []string{"$.credentialSubject.organization.city", "$.credentialSubject[0].organization.city"}}},
+						},
+					},
+				},
+			}
+		}
+		t.Run("required", func(t *testing.T) {
+			t.Run("selects VC supporting selective disclosure", func(t *testing.T) {
+				vcs, mappingObjects, err := definition(LimitDisclosureRequired).Match([]vc.VerifiableCredential{jwtVC, sdJWTVC})
+
+				require.NoError(t, err)
+				require.Len(t, vcs, 1)
+				assert.Equal(t, "vc+sd-jwt", mappingObjects[0].Format)
+				disclosed, err := sdjwt.Unwrap(vcs[0])
+				require.NoError(t, err)
+				assert.Equal(t, map[string]interface{}{"city": "IJbergen"}, disclosed.CredentialSubject[0].(map[string]interface{})["organization"])
+			})
+			t.Run("no VC supporting selective disclosure", func(t *testing.T) {
+				vcs, _, err := definition(LimitDisclosureRequired).Match([]vc.VerifiableCredential{jwtVC})
+
+				assert.ErrorIs(t, err, ErrNoCredentials)
+				assert.ErrorIs(t, err, ErrLimitDisclosureNotSupported)
+				assert.ErrorContains(t, err, "constraints not matched: no VC supporting selective disclosure for InputDescriptor (organization)")
+				assert.Empty(t, vcs)
+			})
+			t.Run("no matching VC at all", func(t *testing.T) {
+				_, _, err := definition(LimitDisclosureRequired).Match([]vc.VerifiableCredential{})
+
+				assert.ErrorIs(t, err, ErrNoCredentials)
+				assert.NotErrorIs(t, err, ErrLimitDisclosureNotSupported)
+			})
+			t.Run("submission requirements", func(t *testing.T) {
+				pd := definition(LimitDisclosureRequired)
+				pd.InputDescriptors[0].Group = []string{"A"}
+				pd.SubmissionRequirements = []*SubmissionRequirement{{Rule: "all", From: "A"}}
+
+				_, _, err := pd.Match([]vc.VerifiableCredential{jwtVC})
+
+				assert.ErrorIs(t, err, ErrNoCredentials)
+				assert.ErrorIs(t, err, ErrLimitDisclosureNotSupported)
+			})
+		})
+		t.Run("preferred", func(t *testing.T) {
+			t.Run("selects VC supporting selective disclosure", func(t *testing.T) {
+				vcs, mappingObjects, err := definition(LimitDisclosurePreferred).Match([]vc.VerifiableCredential{jwtVC, sdJWTVC})
+
+				require.NoError(t, err)
+				require.Len(t, vcs, 1)
+				assert.Equal(t, "vc+sd-jwt", mappingObjects[0].Format)
+			})
+			t.Run("falls back to VC not supporting selective disclosure", func(t *testing.T) {
+				vcs, mappingObjects, err := definition(LimitDisclosurePreferred).Match([]vc.VerifiableCredential{jwtVC})
+
+				require.NoError(t, err)
+				require.Len(t, vcs, 1)
+				assert.Equal(t, "jwt_vc", mappingObjects[0].Format)
+				assert.Equal(t, jwtVC.Raw(), vcs[0].Raw())
+			})
+		})
+		t.Run("not set, first matching VC is selected", func(t *testing.T) {
+			vcs, mappingObjects, err := definition("").Match([]vc.VerifiableCredential{jwtVC, sdJWTVC})
+
+			require.NoError(t, err)
+			require.Len(t, vcs, 1)
+			assert.Equal(t, "jwt_vc", mappingObjects[0].Format)
+		})
 	})
 	t.Run("Input Descriptor Claim Format matching", func(t *testing.T) {
 		// making sure this test doesn't break when testPresentationDefinition changes
@@ -531,6 +646,31 @@ txJy6M1-lD7a5HTzanYTWBPAUHDZGyGKXdJw-W_x0IWChBzI8t3kpG253fg6V3tPgHeKXE94fz_QpYfg
 		})
 	})
 
+	t.Run("SD-JWT", func(t *testing.T) {
+		verifiableCredential := vcrTest.SDJWTNutsOrganizationCredential(t, did.MustParseDID("did:web:example.com"))
+		t.Run("alg match", func(t *testing.T) {
+			asFormat := PresentationDefinitionClaimFormatDesignations{"vc+sd-jwt": {"sd-jwt_alg_values": {"ES256"}}}
+
+			match := matchFormat(&asFormat, verifiableCredential)
+
+			assert.True(t, match)
+		})
+		t.Run("no alg match", func(t *testing.T) {
+			asFormat := PresentationDefinitionClaimFormatDesignations{"vc+sd-jwt": {"sd-jwt_alg_values": {"ES384"}}}
+
+			match := matchFormat(&asFormat, verifiableCredential)
+
+			assert.False(t, match)
+		})
+		t.Run("format not requested", func(t *testing.T) {
+			asFormat := PresentationDefinitionClaimFormatDesignations{"ldp_vc": {"proof_type": {"JsonWebSignature2020"}}}
+
+			match := matchFormat(&asFormat, verifiableCredential)
+
+			assert.False(t, match)
+		})
+	})
+
 	t.Run("JSON-LD", func(t *testing.T) {
 		verifiableCredential := vcrTest.ValidNutsOrganizationCredential(t)
 
@@ -872,6 +1012,17 @@ func TestPresentationDefinition_ResolveConstraintsFields(t *testing.T) {
 		assert.Equal(t, "IJbergen", fieldValues["credentialsubject_organization_city"])
 		assert.Equal(t, "care", fieldValues["credentialsubject_organization_name"])
 	})
+	t.Run("match SD-JWT", func(t *testing.T) {
+		credentialMap := map[string]vc.VerifiableCredential{
+			"as_sd_jwt": vcrTest.SDJWTNutsOrganizationCredential(t, subjectDID),
+		}
+
+		fieldValues, err := definitions().SDJWT.ResolveConstraintsFields(credentialMap)
+
+		require.NoError(t, err)
+		require.Len(t, fieldValues, 1)
+		assert.Equal(t, "IJbergen", fieldValues["organization_city"])
+	})
 	t.Run("input descriptor without constraints", func(t *testing.T) {
 		format := PresentationDefinitionClaimFormatDesignations(map[string]map[string][]string{"jwt_vc": {"alg": {"ES256"}}})
 		definition := PresentationDefinition{
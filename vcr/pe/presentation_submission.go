@@ -28,6 +28,7 @@ import (
 	"github.com/nuts-foundation/go-did/vc"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
 	v2 "github.com/nuts-foundation/nuts-node/vcr/pe/schema/v2"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"strings"
 )
 
@@ -188,6 +189,11 @@ func resolveCredential(path []string, mapping InputDescriptorMappingObject, valu
 			if err != nil {
 				return nil, fmt.Errorf("invalid JWT presentation at path '%s': %w", fullPathString, err)
 			}
+		} else if mapping.Format == sdjwt.Format {
+			decodedTargetValue, err = parseSDJWTCredential(targetValue)
+			if err != nil {
+				return nil, fmt.Errorf("invalid SD-JWT credential at path '%s': %w", fullPathString, err)
+			}
 		}
 	case map[string]interface{}:
 		// must be JSON-LD
@@ -202,6 +208,16 @@ func resolveCredential(path []string, mapping InputDescriptorMappingObject, valu
 			if err != nil {
 				return nil, fmt.Errorf("invalid JSON-LD presentation at path '%s': %w", fullPathString, err)
 			}
+		} else if mapping.Format == sdjwt.Format {
+			// SD-JWT VC enveloped in an EnvelopedVerifiableCredential
+			decodedCredential, err := vc.ParseVerifiableCredential(string(targetValueAsJSON))
+			if err == nil && !sdjwt.IsEnveloped(*decodedCredential) {
+				err = sdjwt.ErrNotEnveloped
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid SD-JWT credential at path '%s': %w", fullPathString, err)
+			}
+			decodedTargetValue = decodedCredential
 		}
 	}
 	if decodedTargetValue == nil {
@@ -221,6 +237,15 @@ func resolveCredential(path []string, mapping InputDescriptorMappingObject, valu
 	return resolveCredential(fullPath, *mapping.PathNested, decodedValueMap)
 }
 
+// parseSDJWTCredential parses a serialized SD-JWT VC and envelopes it, so it can be handled as Verifiable Credential.
+func parseSDJWTCredential(serialized string) (*vc.VerifiableCredential, error) {
+	sdJWT, err := sdjwt.Parse(serialized)
+	if err != nil {
+		return nil, err
+	}
+	return sdJWT.Envelope()
+}
+
 // Validate validates the Presentation Submission to the Verifiable Presentations and Presentation Definitions and returns the mapped credentials.
 // The credentials will be returned as map with the InputDescriptor.Id as key.
 // The Presentation Definitions are passed in the envelope, as specified by the PEX specification.
@@ -260,9 +285,29 @@ func (s PresentationSubmission) Validate(envelope Envelope, definition Presentat
 		return nil, fmt.Errorf("expected %d credentials, got %d", len(expectedCredentials), len(actualCredentials))
 	}
 	for inputDescriptorID, expectedCredential := range expectedCredentials {
-		if actualCredentials[inputDescriptorID].Raw() != expectedCredential.Raw() {
+		if !sameCredential(actualCredentials[inputDescriptorID], expectedCredential) {
 			return nil, fmt.Errorf("incorrect mapping for input descriptor: %s", inputDescriptorID)
 		}
 	}
 	return expectedCredentials, nil
 }
+
+// sameCredential checks whether the presented credential is the credential the submission builder selected.
+// SD-JWT VCs are compared on their issuer-signed JWT and disclosures only:
+// the Key Binding JWT is added by the holder when presenting, so it's not part of the selected credential.
+func sameCredential(actual vc.VerifiableCredential, expected vc.VerifiableCredential) bool {
+	if !sdjwt.IsEnveloped(actual) || !sdjwt.IsEnveloped(expected) {
+		return actual.Raw() == expected.Raw()
+	}
+	actualSDJWT, err := sdjwt.FromCredential(actual)
+	if err != nil {
+		return false
+	}
+	expectedSDJWT, err := sdjwt.FromCredential(expected)
+	if err != nil {
+		return false
+	}
+	actualSDJWT.KeyBindingJWT = ""
+	expectedSDJWT.KeyBindingJWT = ""
+	return actualSDJWT.String() == expectedSDJWT.String()
+}
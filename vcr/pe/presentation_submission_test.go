@@ -24,7 +24,9 @@ import (
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/go-did/vc"
 	"github.com/nuts-foundation/nuts-node/vcr/pe/test"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"github.com/nuts-foundation/nuts-node/vcr/signature/proof"
+	vcrTest "github.com/nuts-foundation/nuts-node/vcr/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"testing"
@@ -405,6 +407,42 @@ func TestPresentationSubmission_Resolve(t *testing.T) {
 		assert.EqualError(t, err, "unable to resolve credential for input descriptor '1': value of Go type 'string' at path '$.verifiableCredential.expirationDate' can't be decoded using format 'ldp_vc'")
 		assert.Nil(t, credentials)
 	})
+	t.Run("SD-JWT", func(t *testing.T) {
+		sdJWTVC := vcrTest.SDJWTNutsOrganizationCredential(t, did.MustParseDID("did:web:example.com"))
+		mapping := InputDescriptorMappingObject{Id: "1", Format: "vc+sd-jwt", Path: "$.verifiableCredential"}
+		t.Run("enveloped", func(t *testing.T) {
+			vp := vc.VerifiablePresentation{
+				VerifiableCredential: []vc.VerifiableCredential{sdJWTVC},
+			}
+			submission := PresentationSubmission{DescriptorMap: []InputDescriptorMappingObject{mapping}}
+
+			credentials, err := submission.Resolve(toEnvelope(t, vp))
+
+			require.NoError(t, err)
+			require.Len(t, credentials, 1)
+			assert.Equal(t, sdJWTVC.ID.String(), credentials["1"].ID.String())
+		})
+		t.Run("serialized", func(t *testing.T) {
+			sdJWT, err := sdjwt.FromCredential(sdJWTVC)
+			require.NoError(t, err)
+
+			credential, err := resolveCredential(nil, mapping, map[string]interface{}{"verifiableCredential": sdJWT.String()})
+
+			require.NoError(t, err)
+			assert.Equal(t, sdJWTVC.ID.String(), credential.ID.String())
+		})
+		t.Run("not an SD-JWT VC", func(t *testing.T) {
+			vp := vc.VerifiablePresentation{
+				VerifiableCredential: []vc.VerifiableCredential{vc1},
+			}
+			submission := PresentationSubmission{DescriptorMap: []InputDescriptorMappingObject{mapping}}
+
+			credentials, err := submission.Resolve(toEnvelope(t, vp))
+
+			assert.EqualError(t, err, "unable to resolve credential for input descriptor '1': invalid SD-JWT credential at path '$.verifiableCredential': credential is not an enveloped SD-JWT VC")
+			assert.Nil(t, credentials)
+		})
+	})
 }
 
 func TestPresentationSubmission_Validate(t *testing.T) {
@@ -23,6 +23,10 @@ import "errors"
 
 var ErrNoCredentials = errors.New("missing credentials")
 
+// ErrLimitDisclosureNotSupported is returned when an input descriptor requires limited disclosure,
+// but none of the matching credentials support selective disclosure.
+var ErrLimitDisclosureNotSupported = errors.New("limit_disclosure is required, but no matching credential supports selective disclosure")
+
 // PresentationDefinitionClaimFormatDesignations (replaces generated one)
 type PresentationDefinitionClaimFormatDesignations map[string]map[string][]string
 
@@ -20,16 +20,10 @@
 package vcr
 
 import (
-	"github.com/nuts-foundation/go-leia/v4"
 	gophonetics "gopkg.in/Regis24GmbH/go-phonetics.v2"
 )
 
-// CologneTransformer is a go-leia compatible function for generating the phonetic representation of a string.
-func CologneTransformer(scalar leia.Scalar) leia.Scalar {
-	switch v := scalar.(type) {
-	case leia.StringScalar:
-		return leia.MustParseScalar(gophonetics.NewPhoneticCode(string(v.Bytes())))
-	default:
-		return scalar
-	}
+// CologneTransformer generates the phonetic representation of a string.
+func CologneTransformer(value string) string {
+	return gophonetics.NewPhoneticCode(value)
 }
@@ -26,6 +26,7 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"strings"
 )
 
 var ErrIndexNotInBitstring = errors.New("index not in status list")
@@ -33,6 +34,12 @@ var ErrIndexNotInBitstring = errors.New("index not in status list")
 const defaultBitstringLengthInBytes = 16 * 1024 // *8 = herd privacy of 16kB or 131072 bit
 const maxBitstringIndex = defaultBitstringLengthInBytes*8 - 1
 
+// maxStatusSize is the maximum number of bits per status in a BitstringStatusList that is supported by this node.
+const maxStatusSize = 8
+
+// multibaseBase64URL is the Multibase prefix for base64url encoding without padding, as used by the Bitstring Status List.
+const multibaseBase64URL = "u"
+
 var _ sql.Scanner = (*bitstring)(nil)
 var _ driver.Valuer = (*bitstring)(nil)
 
@@ -86,6 +93,26 @@ func (bs *bitstring) bit(statusListIndex int) (bool, error) {
 	return isSet((*bs)[q], r), nil
 }
 
+// value returns the status at statusListIndex for a status list with entries of statusSize bits.
+// The bits are read from left (most significant) to right, starting at statusListIndex*statusSize.
+func (bs *bitstring) value(statusListIndex int, statusSize int) (int, error) {
+	if statusListIndex < 0 || statusSize < 1 {
+		return 0, ErrIndexNotInBitstring
+	}
+	var result int
+	for i := statusListIndex * statusSize; i < (statusListIndex+1)*statusSize; i++ {
+		set, err := bs.bit(i)
+		if err != nil {
+			return 0, err
+		}
+		result <<= 1
+		if set {
+			result |= 1
+		}
+	}
+	return result, nil
+}
+
 // setBit set the value of the bit at statusListIndex to 1, or returns an error when the index is out of bounds.
 func (bs *bitstring) setBit(statusListIndex int, value bool) error {
 	q, r := statusListIndex/8, byte(statusListIndex%8)
@@ -123,6 +150,29 @@ func compress(bitstring []byte) (string, error) {
 	return base64.RawURLEncoding.EncodeToString(buf.Bytes()), nil
 }
 
+// compressEncodedList compresses the bitstring to the credentialSubject.encodedList of a status list credential of the given type.
+func compressEncodedList(listType StatusListType, bitstring []byte) (string, error) {
+	encodedList, err := compress(bitstring)
+	if err != nil {
+		return "", err
+	}
+	if listType == BitstringStatusListType {
+		return multibaseBase64URL + encodedList, nil
+	}
+	return encodedList, nil
+}
+
+// expandEncodedList expands the credentialSubject.encodedList of a status list credential of the given type.
+func expandEncodedList(listType StatusListType, encodedList string) (bitstring, error) {
+	if listType == BitstringStatusListType {
+		var ok bool
+		if encodedList, ok = strings.CutPrefix(encodedList, multibaseBase64URL); !ok {
+			return nil, errors.New("expected multibase base64url encoded list")
+		}
+	}
+	return expand(encodedList)
+}
+
 // expand a compressed StatusList2021 bitstring. It first applies base64 decoding followed by gzip decompression.
 func expand(encodedList string) (bitstring, error) {
 	// base64 decode
@@ -24,6 +24,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"math/rand"
+	"strings"
 	"testing"
 )
 
@@ -119,6 +120,37 @@ func TestBitstring_Bit(t *testing.T) {
 	}
 }
 
+func TestBitstring_Value(t *testing.T) {
+	bs := bitstring{33, 128} // 33 => 00100001, 128 => 10000000
+	type test struct {
+		index      int
+		statusSize int
+		value      int
+		err        error
+	}
+
+	tt := []test{
+		{1, 1, 0, nil},
+		{2, 1, 1, nil},
+		{0, 2, 0, nil}, // 00
+		{1, 2, 2, nil}, // 10
+		{3, 2, 1, nil}, // 01
+		{1, 4, 1, nil}, // 0001
+		{2, 4, 8, nil}, // 1000
+		{1, 3, 0, nil}, // 000
+		{2, 3, 3, nil}, // 011
+		{-1, 1, 0, ErrIndexNotInBitstring},
+		{4, 4, 0, ErrIndexNotInBitstring},
+		{5, 3, 0, ErrIndexNotInBitstring}, // partially out of bounds
+		{0, 0, 0, ErrIndexNotInBitstring},
+	}
+	for _, tc := range tt {
+		v, err := bs.value(tc.index, tc.statusSize)
+		assert.ErrorIs(t, err, tc.err)
+		assert.Equal(t, tc.value, v, "index %d, statusSize %d", tc.index, tc.statusSize)
+	}
+}
+
 func TestBitstring_SetBit(t *testing.T) {
 	t.Run("ok - set value", func(t *testing.T) {
 		bs := bitstring{0}
@@ -190,3 +222,35 @@ func Test_CompressExpand(t *testing.T) {
 		assert.Equal(t, padExpanded, nopadExpanded)
 	})
 }
+
+func Test_CompressExpandEncodedList(t *testing.T) {
+	bs := *newBitstring()
+	require.NoError(t, bs.setBit(5, true))
+
+	t.Run("StatusList2021", func(t *testing.T) {
+		encodedList, err := compressEncodedList(StatusList2021Type, bs)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(encodedList, "H4sI"))
+
+		expanded, err := expandEncodedList(StatusList2021Type, encodedList)
+		require.NoError(t, err)
+		assert.Equal(t, bs, expanded)
+	})
+	t.Run("BitstringStatusList", func(t *testing.T) {
+		encodedList, err := compressEncodedList(BitstringStatusListType, bs)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(encodedList, "uH4sI"))
+
+		expanded, err := expandEncodedList(BitstringStatusListType, encodedList)
+		require.NoError(t, err)
+		assert.Equal(t, bs, expanded)
+	})
+	t.Run("error - BitstringStatusList without multibase prefix", func(t *testing.T) {
+		encodedList, err := compressEncodedList(StatusList2021Type, bs)
+		require.NoError(t, err)
+
+		_, err = expandEncodedList(BitstringStatusListType, encodedList)
+
+		assert.EqualError(t, err, "expected multibase base64url encoded list")
+	})
+}
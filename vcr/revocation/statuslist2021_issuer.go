@@ -57,11 +57,15 @@ type credentialIssuerRecord struct {
 	SubjectID string `gorm:"primaryKey"`
 	// Issuer of the StatusListCredential.
 	Issuer string
-	// Page number corresponding to this SubjectID.
+	// Page number corresponding to this SubjectID. Pages are numbered per Issuer, regardless of the StatusType.
 	Page int
+	// StatusType is the type of status list issued on this page, StatusList2021 or BitstringStatusList.
+	StatusType StatusListType
+	// StatusPurpose of the status list issued on this page, 'revocation' or 'suspension'.
+	StatusPurpose string
 	// LastIssuedIndex on this page. Range:  0 <= StatusListIndex < maxBitstringIndex
 	LastIssuedIndex int
-	// Revocations list all revocations for this SubjectID
+	// Revocations list all revocations (or suspensions, depending on the StatusPurpose) for this SubjectID
 	Revocations []revocationRecord `gorm:"foreignKey:StatusListCredential;references:SubjectID"`
 }
 
@@ -78,6 +82,8 @@ type credentialRecord struct {
 	SubjectID string `gorm:"primaryKey"`
 	// StatusPurpose is the purpose listed in the StatusList2021Credential.credentialSubject
 	StatusPurpose string
+	// StatusType is the credentialSubject.type of the status list credential, StatusList2021 or BitstringStatusList.
+	StatusType StatusListType
 	// Bitstring is the expanded StatusList2021 bitstring
 	Bitstring bitstring
 	// CreatedAt is the UNIX timestamp this credentialRecord was generated
@@ -92,7 +98,7 @@ func (s revocationRecord) TableName() string {
 	return "status_list_entry"
 }
 
-// revocationRecord is created when a statusList entry has been revoked.
+// revocationRecord is created when a statusList entry has been revoked or suspended. It is removed when a suspension is lifted.
 type revocationRecord struct {
 	// StatusListCredential is the credentialSubject.ID this revocation belongs to. Example https://example.com/iam/id/statuslist/1
 	StatusListCredential string `gorm:"primaryKey"`
@@ -163,10 +169,41 @@ func (cs *StatusList2021) Credential(ctx context.Context, issuerDID did.DID, pag
 	}
 
 	// issue a new StatusList2021Credential if we can't load the existing, or it's about to expire
+	return cs.reissueCredential(ctx, statusListCredentialURL, kid)
+}
+
+// Resign re-issues all status list credentials of the issuer, signed with its current assertion key.
+// It's used after the issuer's keys were rotated, so status list credentials don't have to be signed with the old key until they expire.
+func (cs *StatusList2021) Resign(ctx context.Context, issuerDID did.DID) error {
+	var statusListCredentialURLs []string
+	err := cs.db.Model(new(credentialIssuerRecord)).
+		Where("issuer = ?", issuerDID.String()).
+		Pluck("subject_id", &statusListCredentialURLs).Error
+	if err != nil {
+		return err
+	}
+	if len(statusListCredentialURLs) == 0 {
+		return nil
+	}
+	// resign using the issuer's current (after key rotation: new) assertion key
+	kid, _, err := cs.ResolveKey(issuerDID, nil, resolver.AssertionMethod)
+	if err != nil {
+		return err
+	}
+	for _, statusListCredentialURL := range statusListCredentialURLs {
+		if _, err = cs.reissueCredential(ctx, statusListCredentialURL, kid); err != nil {
+			return fmt.Errorf("failed to re-sign status list credential (url=%s): %w", statusListCredentialURL, err)
+		}
+	}
+	return nil
+}
+
+// reissueCredential issues and stores a new status list credential for the given status list, signed with the given key.
+func (cs *StatusList2021) reissueCredential(ctx context.Context, statusListCredentialURL string, kid string) (*vc.VerifiableCredential, error) {
 	var cred *vc.VerifiableCredential // is nil, so if this panics outside this method the var name is probably shadowed in the db.Transaction.
-	err = cs.db.Transaction(func(tx *gorm.DB) error {
+	err := cs.db.Transaction(func(tx *gorm.DB) error {
 		// lock credentialRecord row for statusListCredentialURL since it will be updated.
-		err = lockCredentialRecord(tx, statusListCredentialURL)
+		err := lockCredentialRecord(tx, statusListCredentialURL)
 		if err != nil {
 			return err
 		}
@@ -174,10 +211,11 @@ func (cs *StatusList2021) Credential(ctx context.Context, issuerDID did.DID, pag
 		issuerRecord := new(credentialIssuerRecord)
 		err = tx.Preload("Revocations").First(issuerRecord, "subject_id = ?", statusListCredentialURL).Error
 		if err != nil {
-			// gorm.ErrRecordNotFound can't happen, isManaged() confirmed it exists
+			// gorm.ErrRecordNotFound can't happen, the caller confirmed it exists
 			return err
 		}
 		transactionContext := context.WithValue(ctx, storage.TransactionKey{}, tx)
+		var credRecord *credentialRecord
 		cred, credRecord, err = cs.updateCredential(transactionContext, issuerRecord, kid)
 		if err != nil {
 			return err
@@ -216,7 +254,7 @@ func (cs *StatusList2021) updateCredential(ctx context.Context, issuerRecord *cr
 			return nil, nil, err
 		}
 	}
-	encodedList, err := compress(*expanded)
+	encodedList, err := compressEncodedList(issuerRecord.StatusType, *expanded)
 	if err != nil {
 		// can't happen
 		return nil, nil, err
@@ -225,8 +263,8 @@ func (cs *StatusList2021) updateCredential(ctx context.Context, issuerRecord *cr
 	// credential subject
 	credSubject := &StatusList2021CredentialSubject{
 		ID:            issuerRecord.SubjectID,
-		Type:          StatusList2021CredentialSubjectType,
-		StatusPurpose: StatusPurposeRevocation,
+		Type:          string(issuerRecord.StatusType),
+		StatusPurpose: issuerRecord.StatusPurpose,
 		EncodedList:   encodedList,
 	}
 	// create and sign a new StatusList2021Credential
@@ -240,6 +278,7 @@ func (cs *StatusList2021) updateCredential(ctx context.Context, issuerRecord *cr
 	credRecord := &credentialRecord{
 		SubjectID:     credSubject.ID,
 		StatusPurpose: credSubject.StatusPurpose,
+		StatusType:    issuerRecord.StatusType,
 		Bitstring:     *expanded,
 		Expires:       &expires,
 		Raw:           statusListCredential.Raw(),
@@ -247,19 +286,24 @@ func (cs *StatusList2021) updateCredential(ctx context.Context, issuerRecord *cr
 	return statusListCredential, credRecord, nil
 }
 
-// buildAndSignVC intends to do the same as vcr.issuer.buildAndSignVC
+// buildAndSignVC intends to do the same as vcr.issuer.buildAndSignVC.
+// It builds a StatusList2021Credential or BitstringStatusListCredential depending on the credSubject.Type.
 func (cs *StatusList2021) buildAndSignVC(ctx context.Context, issuerDID did.DID, credSubject StatusList2021CredentialSubject, kid string) (*vc.VerifiableCredential, error) {
 	iss := time.Now()
 	exp := iss.Add(statusListValidity)
 	credentialID := ssi.MustParseURI(fmt.Sprintf("%s#%s", issuerDID.String(), uuid.New().String()))
+	contextURI, credentialType := StatusList2021ContextURI, statusList2021CredentialTypeURI
+	if credSubject.Type == BitstringStatusListCredentialSubjectType {
+		contextURI, credentialType = BitstringStatusListContextURI, bitstringStatusListCredentialTypeURI
+	}
 	template := vc.VerifiableCredential{
 		Context: []ssi.URI{
 			vc.VCContextV1URI(),
-			StatusList2021ContextURI,
+			contextURI,
 		},
 		Type: []ssi.URI{
 			vc.VerifiableCredentialTypeV1URI(),
-			statusList2021CredentialTypeURI,
+			credentialType,
 		},
 		ID:                &credentialID,
 		CredentialSubject: []any{credSubject},
@@ -272,8 +316,11 @@ func (cs *StatusList2021) buildAndSignVC(ctx context.Context, issuerDID did.DID,
 	return cs.Sign(ctx, template, kid)
 }
 
-func (cs *StatusList2021) Entry(ctx context.Context, issuer did.DID, purpose StatusPurpose) (*StatusList2021Entry, error) {
-	if purpose != StatusPurposeRevocation {
+func (cs *StatusList2021) Entry(ctx context.Context, issuer did.DID, listType StatusListType, purpose StatusPurpose) (*StatusList2021Entry, error) {
+	if err := listType.Validate(); err != nil {
+		return nil, err
+	}
+	if purpose != StatusPurposeRevocation && purpose != StatusPurposeSuspension {
 		return nil, errUnsupportedPurpose
 	}
 
@@ -286,42 +333,43 @@ func (cs *StatusList2021) Entry(ctx context.Context, issuer did.DID, purpose Sta
 	credentialIssuer := new(credentialIssuerRecord)
 	for {
 		err := cs.db.Transaction(func(tx *gorm.DB) error {
-			// Find issuer's last page; if it exists. Lock all pages.
+			// Find all the issuer's pages; if they exist. Lock all pages.
+			var pages []credentialIssuerRecord
 			// Microsoft SQL server does not support the locking clause, so we have to use a raw query instead.
 			// See https://github.com/nuts-foundation/nuts-node/issues/3393
 			if tx.Dialector.Name() == "sqlserver" {
-				var pages []credentialIssuerRecord
-				if err = tx.Raw("SELECT * FROM status_list WITH (UPDLOCK, ROWLOCK) WHERE issuer = ?", issuer.String()).
+				err = tx.Raw("SELECT * FROM status_list WITH (UPDLOCK, ROWLOCK) WHERE issuer = ?", issuer.String()).
 					Scan(&pages).
-					Error; err != nil {
-					return err
-				}
-				if len(pages) == 0 {
-					// mimic non-SQL Server behavior
-					err = gorm.ErrRecordNotFound
-				} else {
-					// get last page, order by page first
-					slices.SortFunc(pages, func(i, j credentialIssuerRecord) int {
-						return i.Page - j.Page
-					})
-					credentialIssuer = &pages[len(pages)-1]
-				}
+					Error
 			} else {
 				err = tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
-					Order("page").
-					Last(credentialIssuer, "issuer = ?", issuer.String()).
+					Find(&pages, "issuer = ?", issuer.String()).
 					Error
 			}
 			if err != nil {
-				if !errors.Is(err, gorm.ErrRecordNotFound) {
-					return err
-				}
+				return err
+			}
 
-				// first time issuer; prepare to create a new Page / StatusListCredential
+			// get the last page of the requested type and purpose.
+			// Pages are numbered per issuer, so a new page follows the issuer's last page of any type or purpose.
+			slices.SortFunc(pages, func(i, j credentialIssuerRecord) int {
+				return i.Page - j.Page
+			})
+			lastPage := 0
+			credentialIssuer = nil
+			for i := range pages {
+				lastPage = pages[i].Page
+				if pages[i].StatusType == listType && pages[i].StatusPurpose == string(purpose) {
+					credentialIssuer = &pages[i]
+				}
+			}
+			if credentialIssuer == nil {
+				// first time issuer (for this type and purpose); prepare to create a new Page / StatusListCredential
 				credentialIssuer = &credentialIssuerRecord{
 					Issuer:          issuer.String(),
-					LastIssuedIndex: maxBitstringIndex, // this will be incremented to move to page 1
-					Page:            0,
+					StatusType:      listType,
+					StatusPurpose:   string(purpose),
+					LastIssuedIndex: maxBitstringIndex, // this will be incremented to move to the next page
 				}
 			}
 
@@ -332,7 +380,7 @@ func (cs *StatusList2021) Entry(ctx context.Context, issuer did.DID, purpose Sta
 			// write actions here are not protected by the SELECT FOR UPDATE clause, so can fail with gorm.ErrDuplicatedKey
 			if credentialIssuer.LastIssuedIndex > maxBitstringIndex {
 				credentialIssuer.LastIssuedIndex = 0
-				credentialIssuer.Page++
+				credentialIssuer.Page = lastPage + 1
 				credentialIssuer.SubjectID = cs.statusListURL(issuer, credentialIssuer.Page)
 				// add new credentialIssuerRecord
 				if err = tx.Create(credentialIssuer).Error; err != nil {
@@ -366,24 +414,45 @@ func (cs *StatusList2021) Entry(ctx context.Context, issuer did.DID, purpose Sta
 
 	return &StatusList2021Entry{
 		ID:                   fmt.Sprintf("%s#%d", credentialIssuer.SubjectID, credentialIssuer.LastIssuedIndex),
-		Type:                 StatusList2021EntryType,
-		StatusPurpose:        StatusPurposeRevocation,
+		Type:                 listType.entryType(),
+		StatusPurpose:        string(purpose),
 		StatusListIndex:      strconv.Itoa(credentialIssuer.LastIssuedIndex),
 		StatusListCredential: credentialIssuer.SubjectID,
 	}, nil
 }
 
 func (cs *StatusList2021) Revoke(ctx context.Context, credentialID ssi.URI, entry StatusList2021Entry) error {
-	// parse StatusListIndex
-	statusListIndex, err := strconv.Atoi(entry.StatusListIndex)
-	if err != nil {
-		return err
+	// validate StatusPurpose
+	if entry.StatusPurpose != StatusPurposeRevocation {
+		return errUnsupportedPurpose
 	}
+	return cs.updateStatus(ctx, credentialID, entry, true)
+}
 
+func (cs *StatusList2021) Suspend(ctx context.Context, credentialID ssi.URI, entry StatusList2021Entry) error {
 	// validate StatusPurpose
-	if entry.StatusPurpose != StatusPurposeRevocation {
+	if entry.StatusPurpose != StatusPurposeSuspension {
 		return errUnsupportedPurpose
 	}
+	return cs.updateStatus(ctx, credentialID, entry, true)
+}
+
+func (cs *StatusList2021) Unsuspend(ctx context.Context, credentialID ssi.URI, entry StatusList2021Entry) error {
+	// validate StatusPurpose
+	if entry.StatusPurpose != StatusPurposeSuspension {
+		return errUnsupportedPurpose
+	}
+	return cs.updateStatus(ctx, credentialID, entry, false)
+}
+
+// updateStatus sets (or clears) the status bit of the entry, and re-issues the status list credential.
+// Setting a bit that is already set returns errRevoked or errSuspended depending on the purpose, clearing a bit that is not set returns errNotSuspended.
+func (cs *StatusList2021) updateStatus(ctx context.Context, credentialID ssi.URI, entry StatusList2021Entry, set bool) error {
+	// parse StatusListIndex
+	statusListIndex, err := strconv.Atoi(entry.StatusListIndex)
+	if err != nil {
+		return err
+	}
 
 	// check if StatusList2021Credential is managed by this node
 	if !cs.isManaged(entry.StatusListCredential) {
@@ -415,20 +484,34 @@ func (cs *StatusList2021) Revoke(ctx context.Context, credentialID ssi.URI, entr
 			return err
 		}
 
-		// revoke
+		// revoke or suspend by adding the entry, or lift the suspension by removing it
 		revocation := revocationRecord{
 			StatusListCredential: entry.StatusListCredential,
 			StatusListIndex:      statusListIndex,
 			CredentialID:         credentialID.String(),
 		}
-
-		// fail fast, immediately fail if revocation already exists
-		err = tx.Create(&revocation).Error
-		if err != nil {
-			if errors.Is(err, gorm.ErrDuplicatedKey) {
-				return errRevoked // already revoked
+		if set {
+			// fail fast, immediately fail if revocation already exists
+			err = tx.Create(&revocation).Error
+			if err != nil {
+				if errors.Is(err, gorm.ErrDuplicatedKey) {
+					// already revoked/suspended
+					if entry.StatusPurpose == StatusPurposeSuspension {
+						return errSuspended
+					}
+					return errRevoked
+				}
+				return err
+			}
+		} else {
+			result := tx.Where("status_list_credential = ? AND status_list_index = ?", entry.StatusListCredential, statusListIndex).
+				Delete(new(revocationRecord))
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return errNotSuspended
 			}
-			return err
 		}
 
 		// load all revocations
@@ -446,8 +529,12 @@ func (cs *StatusList2021) Revoke(ctx context.Context, credentialID ssi.URI, entr
 		if statusListIndex < 0 || statusListIndex > issuerRecord.LastIssuedIndex {
 			return ErrIndexNotInBitstring
 		}
+		// validate the entry is on a status list with the same purpose; triggers a rollback after the fact.
+		if issuerRecord.StatusPurpose != entry.StatusPurpose {
+			return fmt.Errorf("%w: status list has purpose '%s'", errUnsupportedPurpose, issuerRecord.StatusPurpose)
+		}
 
-		// append new revocation and re-issue the StatusList2021Credential.
+		// apply the new status and re-issue the StatusList2021Credential.
 		transactionContext := context.WithValue(ctx, storage.TransactionKey{}, tx)
 		_, credRecord, err := cs.updateCredential(transactionContext, issuerRecord, kid)
 		if err != nil {
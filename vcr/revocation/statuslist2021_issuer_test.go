@@ -25,6 +25,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -65,7 +66,7 @@ func TestStatusList2021_Entry(t *testing.T) {
 			assert.ErrorIs(t, s.db.First(new(credentialIssuerRecord), "subject_id = ?", statusListCredential).Error, gorm.ErrRecordNotFound)
 			assert.ErrorIs(t, s.db.First(new(credentialRecord), "subject_id = ?", statusListCredential).Error, gorm.ErrRecordNotFound)
 
-			entry, err = s.Entry(testCtx, aliceDID, StatusPurposeRevocation)
+			entry, err = s.Entry(testCtx, aliceDID, StatusList2021Type, StatusPurposeRevocation)
 
 			assert.NoError(t, err)
 			require.NotNil(t, entry)
@@ -83,7 +84,7 @@ func TestStatusList2021_Entry(t *testing.T) {
 		t.Run("second entry", func(t *testing.T) {
 			statusListCredential := s.statusListURL(aliceDID, 1)
 
-			entry, err = s.Entry(testCtx, aliceDID, StatusPurposeRevocation)
+			entry, err = s.Entry(testCtx, aliceDID, StatusList2021Type, StatusPurposeRevocation)
 
 			assert.NoError(t, err)
 			require.NotNil(t, entry)
@@ -101,7 +102,7 @@ func TestStatusList2021_Entry(t *testing.T) {
 				Update("last_issued_index", maxBitstringIndex)
 			statusListCredential = s.statusListURL(aliceDID, 2) // now expect page 2 to be used
 
-			entry, err = s.Entry(testCtx, aliceDID, StatusPurposeRevocation)
+			entry, err = s.Entry(testCtx, aliceDID, StatusList2021Type, StatusPurposeRevocation)
 
 			assert.NoError(t, err)
 			require.NotNil(t, entry)
@@ -111,7 +112,7 @@ func TestStatusList2021_Entry(t *testing.T) {
 			assert.Equal(t, fmt.Sprintf("%s#0", statusListCredential), entry.ID)
 		})
 		t.Run("second issuer", func(t *testing.T) {
-			entry, err = s.Entry(testCtx, bobDID, StatusPurposeRevocation)
+			entry, err = s.Entry(testCtx, bobDID, StatusList2021Type, StatusPurposeRevocation)
 
 			assert.NoError(t, err)
 			require.NotNil(t, entry)
@@ -120,19 +121,55 @@ func TestStatusList2021_Entry(t *testing.T) {
 			assert.Equal(t, int64(3), s.db.Find(&[]credentialIssuerRecord{}).RowsAffected)
 		})
 	})
+	t.Run("ok - BitstringStatusList", func(t *testing.T) {
+		s := newTestStatusList2021(t, aliceDID)
+
+		entry2021, err := s.Entry(testCtx, aliceDID, StatusList2021Type, StatusPurposeRevocation)
+		require.NoError(t, err)
+		entry, err := s.Entry(testCtx, aliceDID, BitstringStatusListType, StatusPurposeRevocation)
+		require.NoError(t, err)
+
+		// pages are numbered per issuer, so the BitstringStatusList gets the next page
+		assert.Equal(t, s.statusListURL(aliceDID, 1), entry2021.StatusListCredential)
+		assert.Equal(t, s.statusListURL(aliceDID, 2), entry.StatusListCredential)
+		assert.Equal(t, BitstringStatusListEntryType, entry.Type)
+		assert.Equal(t, "0", entry.StatusListIndex)
+		assert.Zero(t, entry.StatusSize)
+
+		// next entry is on the same page
+		entry, err = s.Entry(testCtx, aliceDID, BitstringStatusListType, StatusPurposeRevocation)
+		require.NoError(t, err)
+		assert.Equal(t, s.statusListURL(aliceDID, 2), entry.StatusListCredential)
+		assert.Equal(t, "1", entry.StatusListIndex)
+
+		// the issued credential is a BitstringStatusListCredential
+		cred, err := s.Credential(testCtx, aliceDID, 2)
+		require.NoError(t, err)
+		assert.True(t, cred.IsType(bitstringStatusListCredentialTypeURI))
+		assert.True(t, cred.ContainsContext(BitstringStatusListContextURI))
+		var credSubjects []StatusList2021CredentialSubject
+		require.NoError(t, cred.UnmarshalCredentialSubject(&credSubjects))
+		assert.Equal(t, BitstringStatusListCredentialSubjectType, credSubjects[0].Type)
+		assert.True(t, strings.HasPrefix(credSubjects[0].EncodedList, multibaseBase64URL))
+	})
+	t.Run("error - unsupported type", func(t *testing.T) {
+		entry, err = s.Entry(testCtx, aliceDID, "RevocationList2020", StatusPurposeRevocation)
+		assert.ErrorIs(t, err, errUnsupportedType)
+		assert.Nil(t, entry)
+	})
 	t.Run("error - singing key not found", func(t *testing.T) {
 		s := newTestStatusList2021(t, aliceDID, bobDID)
 		s.ResolveKey = func(_ did.DID, _ *time.Time, _ resolver.RelationType) (string, crypto.PublicKey, error) {
 			return "", nil, errors.New("do re mi")
 		}
 
-		entry, err = s.Entry(testCtx, aliceDID, StatusPurposeRevocation)
+		entry, err = s.Entry(testCtx, aliceDID, StatusList2021Type, StatusPurposeRevocation)
 
 		assert.EqualError(t, err, "do re mi")
 		assert.Nil(t, entry)
 	})
 	t.Run("error - unsupported purpose", func(t *testing.T) {
-		entry, err = s.Entry(testCtx, aliceDID, statusPurposeSuspension)
+		entry, err = s.Entry(testCtx, aliceDID, StatusList2021Type, "message")
 		assert.ErrorIs(t, err, errUnsupportedPurpose)
 		assert.Nil(t, entry)
 	})
@@ -148,7 +185,7 @@ func TestStatusList2021_Entry(t *testing.T) {
 			worker := func(dids <-chan did.DID, done chan<- struct{}) {
 				defer close(done) // senders close
 				for issuer := range dids {
-					_, _ = cs.Entry(testCtx, issuer, StatusPurposeRevocation)
+					_, _ = cs.Entry(testCtx, issuer, StatusList2021Type, StatusPurposeRevocation)
 					done <- struct{}{}
 				}
 			}
@@ -208,7 +245,7 @@ func TestStatusList2021_Entry(t *testing.T) {
 func TestStatusList2021_Revoke(t *testing.T) {
 	s := newTestStatusList2021(t, aliceDID, bobDID)
 
-	entryP, err := s.Entry(context.Background(), aliceDID, StatusPurposeRevocation)
+	entryP, err := s.Entry(context.Background(), aliceDID, StatusList2021Type, StatusPurposeRevocation)
 	require.NoError(t, err)
 	entry := *entryP
 
@@ -238,7 +275,7 @@ func TestStatusList2021_Revoke(t *testing.T) {
 	})
 	t.Run("error - signing key not found", func(t *testing.T) {
 		s := newTestStatusList2021(t, aliceDID, bobDID)
-		entry, err := s.Entry(context.Background(), aliceDID, StatusPurposeRevocation)
+		entry, err := s.Entry(context.Background(), aliceDID, StatusList2021Type, StatusPurposeRevocation)
 		require.NoError(t, err)
 		s.ResolveKey = func(_ did.DID, _ *time.Time, _ resolver.RelationType) (string, crypto.PublicKey, error) {
 			return "", nil, errors.New("no key")
@@ -250,7 +287,7 @@ func TestStatusList2021_Revoke(t *testing.T) {
 	})
 	t.Run("error - unsupportedPurpose", func(t *testing.T) {
 		cEntry := entry
-		cEntry.StatusPurpose = statusPurposeSuspension
+		cEntry.StatusPurpose = StatusPurposeSuspension
 		assert.ErrorIs(t, s.Revoke(context.Background(), ssi.URI{}, cEntry), errUnsupportedPurpose)
 	})
 	t.Run("error - ErrNotFound", func(t *testing.T) {
@@ -270,12 +307,73 @@ func TestStatusList2021_Revoke(t *testing.T) {
 	})
 }
 
+func TestStatusList2021_Suspend(t *testing.T) {
+	s := newTestStatusList2021(t, aliceDID)
+	ctx := context.Background()
+	credentialID := ssi.MustParseURI(aliceDID.String() + "#1")
+
+	revocationEntry, err := s.Entry(ctx, aliceDID, BitstringStatusListType, StatusPurposeRevocation)
+	require.NoError(t, err)
+	entryP, err := s.Entry(ctx, aliceDID, BitstringStatusListType, StatusPurposeSuspension)
+	require.NoError(t, err)
+	entry := *entryP
+	// separate status list credentials per purpose
+	assert.Equal(t, s.statusListURL(aliceDID, 1), revocationEntry.StatusListCredential)
+	assert.Equal(t, s.statusListURL(aliceDID, 2), entry.StatusListCredential)
+	assert.Equal(t, StatusPurposeSuspension, entry.StatusPurpose)
+
+	isSuspended := func(t *testing.T) bool {
+		credRecord, err := s.loadCredential(entry.StatusListCredential)
+		require.NoError(t, err)
+		assert.Equal(t, StatusPurposeSuspension, credRecord.StatusPurpose)
+		set, _ := credRecord.Bitstring.bit(0)
+		return set
+	}
+
+	t.Run("ok - suspend", func(t *testing.T) {
+		require.NoError(t, s.Suspend(ctx, credentialID, entry))
+		assert.True(t, isSuspended(t))
+	})
+	t.Run("error - ErrSuspended", func(t *testing.T) {
+		assert.ErrorIs(t, s.Suspend(ctx, credentialID, entry), types.ErrSuspended)
+	})
+	t.Run("ok - unsuspend", func(t *testing.T) {
+		require.NoError(t, s.Unsuspend(ctx, credentialID, entry))
+		assert.False(t, isSuspended(t))
+	})
+	t.Run("error - ErrNotSuspended", func(t *testing.T) {
+		assert.ErrorIs(t, s.Unsuspend(ctx, credentialID, entry), types.ErrNotSuspended)
+	})
+	t.Run("ok - suspend again", func(t *testing.T) {
+		require.NoError(t, s.Suspend(ctx, credentialID, entry))
+		assert.True(t, isSuspended(t))
+	})
+	t.Run("error - unsupportedPurpose", func(t *testing.T) {
+		assert.ErrorIs(t, s.Suspend(ctx, credentialID, *revocationEntry), errUnsupportedPurpose)
+		assert.ErrorIs(t, s.Unsuspend(ctx, credentialID, *revocationEntry), errUnsupportedPurpose)
+	})
+	t.Run("error - entry purpose does not match status list", func(t *testing.T) {
+		cEntry := *revocationEntry
+		cEntry.StatusPurpose = StatusPurposeSuspension
+		err := s.Suspend(ctx, credentialID, cEntry)
+		assert.EqualError(t, err, "status list: purpose not supported: status list has purpose 'revocation'")
+		// rolled back
+		assert.ErrorIs(t, s.Revoke(ctx, credentialID, *revocationEntry), nil)
+	})
+	t.Run("error - ErrNotFound", func(t *testing.T) {
+		cEntry := entry
+		cEntry.StatusListCredential += "unknown"
+		assert.ErrorIs(t, s.Suspend(ctx, credentialID, cEntry), types.ErrNotFound)
+		assert.ErrorIs(t, s.Unsuspend(ctx, credentialID, cEntry), types.ErrNotFound)
+	})
+}
+
 func TestStatusList2021_Credential(t *testing.T) {
 	s := newTestStatusList2021(t, aliceDID, bobDID)
 	auditCtx := audit.TestContext()
 
 	// create status list credential for alice
-	entryP, err := s.Entry(auditCtx, aliceDID, StatusPurposeRevocation)
+	entryP, err := s.Entry(auditCtx, aliceDID, StatusList2021Type, StatusPurposeRevocation)
 	require.NoError(t, err)
 	entry := *entryP
 
@@ -317,8 +415,9 @@ func TestStatusList2021_Credential(t *testing.T) {
 		// add bob as issuer for page 0
 		subjectID := s.statusListURL(bobDID, 0)
 		s.db.Create(&credentialIssuerRecord{
-			SubjectID: subjectID,
-			Issuer:    bobDID.String(),
+			SubjectID:  subjectID,
+			Issuer:     bobDID.String(),
+			StatusType: StatusList2021Type,
 		})
 		// try load the credential
 		cred, err := s.Credential(auditCtx, bobDID, 0)
@@ -346,7 +445,7 @@ func TestStatusList2021_Credential(t *testing.T) {
 	})
 	t.Run("error - signing key not found", func(t *testing.T) {
 		s := newTestStatusList2021(t, aliceDID)
-		entry2, err := s.Entry(context.Background(), aliceDID, StatusPurposeRevocation)
+		entry2, err := s.Entry(context.Background(), aliceDID, StatusList2021Type, StatusPurposeRevocation)
 		require.NoError(t, err)
 		// change expires to now so the StatusList2021Credential has to be signed again
 		err = s.db.Model(new(credentialRecord)).Where("subject_id = ?", entry2.StatusListCredential).UpdateColumn("expires", time.Now()).Error
@@ -367,6 +466,64 @@ func TestStatusList2021_Credential(t *testing.T) {
 	})
 }
 
+func TestStatusList2021_Resign(t *testing.T) {
+	auditCtx := audit.TestContext()
+
+	t.Run("ok", func(t *testing.T) {
+		s := newTestStatusList2021(t, aliceDID)
+		_, err := s.Entry(auditCtx, aliceDID, StatusList2021Type, StatusPurposeRevocation)
+		require.NoError(t, err)
+		_, err = s.Entry(auditCtx, aliceDID, StatusList2021Type, StatusPurposeSuspension)
+		require.NoError(t, err)
+		s.ResolveKey = func(_ did.DID, _ *time.Time, _ resolver.RelationType) (string, crypto.PublicKey, error) {
+			return "new-key", nil, nil
+		}
+		var signedWith []string
+		s.Sign = func(ctx context.Context, unsignedCredential vc.VerifiableCredential, kid string) (*vc.VerifiableCredential, error) {
+			signedWith = append(signedWith, kid)
+			return noopSign(ctx, unsignedCredential, kid)
+		}
+
+		err = s.Resign(auditCtx, aliceDID)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"new-key", "new-key"}, signedWith)
+	})
+	t.Run("ok - no status lists", func(t *testing.T) {
+		s := newTestStatusList2021(t, aliceDID)
+		s.ResolveKey = nil // guarantees no key is resolved
+
+		err := s.Resign(auditCtx, aliceDID)
+
+		assert.NoError(t, err)
+	})
+	t.Run("error - signing key not found", func(t *testing.T) {
+		s := newTestStatusList2021(t, aliceDID)
+		_, err := s.Entry(auditCtx, aliceDID, StatusList2021Type, StatusPurposeRevocation)
+		require.NoError(t, err)
+		s.ResolveKey = func(_ did.DID, _ *time.Time, _ resolver.RelationType) (string, crypto.PublicKey, error) {
+			return "", nil, errors.New("no key")
+		}
+
+		err = s.Resign(auditCtx, aliceDID)
+
+		assert.EqualError(t, err, "no key")
+	})
+	t.Run("error - signing fails", func(t *testing.T) {
+		s := newTestStatusList2021(t, aliceDID)
+		_, err := s.Entry(auditCtx, aliceDID, StatusList2021Type, StatusPurposeRevocation)
+		require.NoError(t, err)
+		s.Sign = func(_ context.Context, _ vc.VerifiableCredential, _ string) (*vc.VerifiableCredential, error) {
+			return nil, assert.AnError
+		}
+
+		err = s.Resign(auditCtx, aliceDID)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to re-sign status list credential")
+	})
+}
+
 func TestStatusList2021_buildAndSignVC(t *testing.T) {
 	cs := newTestStatusList2021(t, aliceDID)
 	cs.Sign = noopSign
@@ -36,7 +36,9 @@ import (
 // maxAgeExternal is the maximum age of external StatusList2021Credentials. If older than this we try to refresh.
 const maxAgeExternal = 15 * time.Minute
 
-// Verify StatusList2021 returns a types.ErrRevoked when the credentialStatus contains a 'StatusList2021Entry' that can be resolved and lists the credential as 'revoked'
+// Verify StatusList2021 returns a types.ErrRevoked when the credentialStatus contains a 'StatusList2021Entry' or 'BitstringStatusListEntry'
+// that can be resolved and lists the credential as 'revoked'. It returns a types.ErrSuspended for entries with statusPurpose 'suspension'
+// that list the credential as 'suspended'. For entries with a statusSize > 1 any non-zero status is considered 'revoked' or 'suspended'.
 // Other credentialStatus type/statusPurpose are ignored. Verification may fail with other non-standardized errors.
 func (cs *StatusList2021) Verify(credentialToVerify vc.VerifiableCredential) error {
 	if credentialToVerify.CredentialStatus == nil {
@@ -48,11 +50,14 @@ func (cs *StatusList2021) Verify(credentialToVerify vc.VerifiableCredential) err
 		return err
 	}
 
-	// only check credentialStatus of type StatusList2021Entry with statusPurpose == revocation other types/purposes are ignored
+	// only check credentialStatus of type StatusList2021Entry or BitstringStatusListEntry with statusPurpose == revocation or suspension
+	// other types/purposes are ignored
 	// returns errors if processing fails -> TODO: hard/soft fail option?
-	// returns types.ErrRevoked if correct type, purpose, and listed.
+	// returns types.ErrRevoked or types.ErrSuspended if correct type, purpose, and listed.
+	// A revocation takes precedence over a suspension.
+	var suspended error
 	for _, status := range statuses {
-		if status.Type != StatusList2021EntryType {
+		if status.Type != StatusList2021EntryType && status.Type != BitstringStatusListEntryType {
 			// ignore other credentialStatus.type
 			log.Logger().
 				WithField("credentialStatus.type", status.Type).
@@ -66,13 +71,13 @@ func (cs *StatusList2021) Verify(credentialToVerify vc.VerifiableCredential) err
 			// cannot happen. already validated in credential.defaultCredentialValidator{}
 			return err
 		}
-		if slEntry.StatusPurpose != "revocation" {
-			// ignore non-revocation purposes
+		if slEntry.StatusPurpose != StatusPurposeRevocation && slEntry.StatusPurpose != StatusPurposeSuspension {
+			// ignore other purposes
 			log.Logger().
 				WithField("credentialStatus.statusPurpose", slEntry.StatusPurpose).
 				WithField(core.LogFieldCredentialID, credentialToVerify.ID).
 				WithField(core.LogFieldCredentialType, credentialToVerify.Type).
-				Info("Ignoring credentialStatus with purpose other than 'revocation'")
+				Info("Ignoring credentialStatus with purpose other than 'revocation' or 'suspension'")
 			continue
 		}
 
@@ -84,6 +89,9 @@ func (cs *StatusList2021) Verify(credentialToVerify vc.VerifiableCredential) err
 		if sList.StatusPurpose != slEntry.StatusPurpose {
 			return fmt.Errorf("StatusList2021Credential.credentialSubject.statusPuspose='%s' does not match vc.credentialStatus.statusPurpose='%s'", sList.StatusPurpose, slEntry.StatusPurpose)
 		}
+		if sList.StatusType.entryType() != slEntry.Type {
+			return fmt.Errorf("status list credential of type '%s' does not match vc.credentialStatus.type='%s'", sList.StatusType, slEntry.Type)
+		}
 
 		// check if listed
 		index, err := strconv.Atoi(slEntry.StatusListIndex)
@@ -91,15 +99,28 @@ func (cs *StatusList2021) Verify(credentialToVerify vc.VerifiableCredential) err
 			// can't happen, checked during validation of credentialToVerify
 			return err
 		}
-		revoked, err := sList.Bitstring.bit(index)
+		status, err := sList.Bitstring.value(index, slEntry.statusSize())
 		if err != nil {
 			return err
 		}
-		if revoked {
-			return errRevoked
+		if status == 0 {
+			continue
+		}
+		statusErr := errRevoked
+		if slEntry.StatusPurpose == StatusPurposeSuspension {
+			statusErr = errSuspended
+		}
+		if message := slEntry.message(status); message != "" {
+			statusErr = fmt.Errorf("%w (%s)", statusErr, message)
 		}
+		if slEntry.StatusPurpose == StatusPurposeSuspension {
+			// keep looking for a revocation
+			suspended = statusErr
+			continue
+		}
+		return statusErr
 	}
-	return nil
+	return suspended
 }
 
 func (cs *StatusList2021) statusList(statusListCredential string) (*credentialRecord, error) {
@@ -114,7 +135,7 @@ func (cs *StatusList2021) statusList(statusListCredential string) (*credentialRe
 		return cr, nil
 	}
 
-	// TODO: renewal criteria need to be reconsidered if we add other purposes. A 'suspension' may have been canceled
+	// TODO: renewal criteria need to be reconsidered for the 'suspension' purpose, since a suspension may have been lifted.
 	// renew expired certificates
 	if (cr.Expires != nil && time.Unix(*cr.Expires, 0).Before(time.Now())) || // expired
 		time.Unix(cr.CreatedAt, 0).Add(maxAgeExternal).Before(time.Now()) { // older than 15 min
@@ -151,7 +172,8 @@ func (cs *StatusList2021) update(statusListCredential string) (*credentialRecord
 	}
 
 	// make bit string
-	expanded, err := expand(credSubject.EncodedList)
+	listType := StatusListType(credSubject.Type)
+	expanded, err := expandEncodedList(listType, credSubject.EncodedList)
 	if err != nil {
 		// cant happen, already checked in verify
 		return nil, err
@@ -166,6 +188,7 @@ func (cs *StatusList2021) update(statusListCredential string) (*credentialRecord
 	sl := credentialRecord{
 		SubjectID:     statusListCredential,
 		StatusPurpose: credSubject.StatusPurpose,
+		StatusType:    listType,
 		Bitstring:     expanded,
 		//Created:              time.Now(), // set by gorm when stored
 		Expires: expiresPtr,
@@ -218,7 +241,7 @@ func (cs *StatusList2021) verify(cred vc.VerifiableCredential) (*StatusList2021C
 		return nil, err
 	}
 
-	if _, err = expand(credSubj.EncodedList); err != nil {
+	if _, err = expandEncodedList(StatusListType(credSubj.Type), credSubj.EncodedList); err != nil {
 		return nil, fmt.Errorf("credentialSubject.encodedList is invalid: %w", err)
 	}
 
@@ -230,8 +253,13 @@ func (cs *StatusList2021) verify(cred vc.VerifiableCredential) (*StatusList2021C
 	return credSubj, nil
 }
 
-// validate returns an error when the StatusList2021Credential doesn't meet the spec.
+// validate returns an error when the StatusList2021Credential or BitstringStatusListCredential doesn't meet the spec.
 func (cs *StatusList2021) validate(cred vc.VerifiableCredential) (*StatusList2021CredentialSubject, error) {
+	listType, contextURI, credentialType := StatusList2021Type, StatusList2021ContextURI, statusList2021CredentialTypeURI
+	if cred.IsType(bitstringStatusListCredentialTypeURI) {
+		listType, contextURI, credentialType = BitstringStatusListType, BitstringStatusListContextURI, bitstringStatusListCredentialTypeURI
+	}
+
 	// TODO: replace with json schema validator?
 	{ // Credential checks
 		// context
@@ -247,19 +275,19 @@ func (cs *StatusList2021) validate(cred vc.VerifiableCredential) (*StatusList202
 		if !cred.ContainsContext(vc.VCContextV1URI()) {
 			return nil, errors.New("default context is required")
 		}
-		if !cred.ContainsContext(StatusList2021ContextURI) {
-			return nil, errors.New("context 'https://w3id.org/vc/status-list/2021/v1' is required")
+		if !cred.ContainsContext(contextURI) {
+			return nil, fmt.Errorf("context '%s' is required", contextURI)
 		}
 
 		// type
 		if !cred.IsType(vc.VerifiableCredentialTypeV1URI()) { // same type for vc v2 spec
 			return nil, errors.New("type 'VerifiableCredential' is required")
 		}
-		if !cred.IsType(statusList2021CredentialTypeURI) {
-			return nil, fmt.Errorf("type '%s' is required", statusList2021CredentialTypeURI)
+		if !cred.IsType(credentialType) {
+			return nil, fmt.Errorf("type '%s' is required", credentialType)
 		}
 		if len(cred.Type) > 2 {
-			return nil, errors.New("StatusList2021Credential contains other types")
+			return nil, fmt.Errorf("%s contains other types", credentialType)
 		}
 
 		// id
@@ -277,7 +305,7 @@ func (cs *StatusList2021) validate(cred vc.VerifiableCredential) (*StatusList202
 
 		// prevent an infinite loops in credentialStatus resolution; note that this is not prohibited by the spec
 		if cred.CredentialStatus != nil {
-			return nil, errors.New("StatusList2021Credential with a CredentialStatus is not supported")
+			return nil, fmt.Errorf("%s with a CredentialStatus is not supported", credentialType)
 		}
 	}
 
@@ -295,8 +323,8 @@ func (cs *StatusList2021) validate(cred vc.VerifiableCredential) (*StatusList202
 		}
 		credentialSubject = target[0]
 
-		if credentialSubject.Type != StatusList2021CredentialSubjectType {
-			return nil, fmt.Errorf("credentialSubject.type '%s' is required", StatusList2021CredentialSubjectType)
+		if credentialSubject.Type != string(listType) {
+			return nil, fmt.Errorf("credentialSubject.type '%s' is required", listType)
 		}
 		if credentialSubject.StatusPurpose == "" {
 			return nil, errors.New("credentialSubject.statusPurpose is required")
@@ -67,13 +67,125 @@ func TestStatusList2021_Verify(t *testing.T) {
 		err := cs.Verify(cred)
 		assert.ErrorIs(t, err, types.ErrRevoked)
 	})
-	t.Run("ok - credentialStatus.statusPurpose != 'revocation' is ignored", func(t *testing.T) {
+	t.Run("ok - credentialStatus.statusPurpose other than 'revocation' or 'suspension' is ignored", func(t *testing.T) {
 		cs, entry, _ := testSetup(t, true) // true
-		entry.StatusPurpose = "suspension"
+		entry.StatusPurpose = "message"
 		cred := test.ValidNutsOrganizationCredential(t)
 		cred.CredentialStatus = []any{entry}
 		assert.NoError(t, cs.Verify(cred))
 	})
+	t.Run("suspension", func(t *testing.T) {
+		// serves a revocation list on /revocation and a suspension list on /suspension, both have bit 1 set
+		suspensionSetup := func(t *testing.T) (*StatusList2021, StatusList2021Entry, StatusList2021Entry) {
+			cs, entry, ts := testSetup(t, true)
+			credentials := map[string][]byte{}
+			for _, purpose := range []string{StatusPurposeRevocation, StatusPurposeSuspension} {
+				statusListCredential := test.ValidStatusList2021Credential(t)
+				statusListCredential.CredentialSubject[0].(map[string]any)["id"] = ts.URL + "/" + purpose
+				statusListCredential.CredentialSubject[0].(map[string]any)["statusPurpose"] = purpose
+				credBytes, err := json.Marshal(statusListCredential)
+				require.NoError(t, err)
+				credentials["/"+purpose] = credBytes
+			}
+			ts.Config.Handler = http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				_, _ = writer.Write(credentials[request.URL.Path])
+			})
+			revocationEntry := entry
+			revocationEntry.StatusListCredential = ts.URL + "/" + StatusPurposeRevocation
+			suspensionEntry := entry
+			suspensionEntry.StatusPurpose = StatusPurposeSuspension
+			suspensionEntry.StatusListCredential = ts.URL + "/" + StatusPurposeSuspension
+			return cs, revocationEntry, suspensionEntry
+		}
+		t.Run("ok - suspended", func(t *testing.T) {
+			cs, _, suspensionEntry := suspensionSetup(t)
+			cred := test.ValidNutsOrganizationCredential(t)
+			cred.CredentialStatus = []any{suspensionEntry}
+
+			err := cs.Verify(cred)
+
+			assert.ErrorIs(t, err, types.ErrSuspended)
+			assert.NotErrorIs(t, err, types.ErrRevoked)
+		})
+		t.Run("ok - not suspended", func(t *testing.T) {
+			cs, _, suspensionEntry := suspensionSetup(t)
+			suspensionEntry.StatusListIndex = "76248"
+			cred := test.ValidNutsOrganizationCredential(t)
+			cred.CredentialStatus = []any{suspensionEntry}
+
+			assert.NoError(t, cs.Verify(cred))
+		})
+		t.Run("ok - revocation takes precedence", func(t *testing.T) {
+			cs, revocationEntry, suspensionEntry := suspensionSetup(t)
+			cred := test.ValidNutsOrganizationCredential(t)
+			cred.CredentialStatus = []any{suspensionEntry, revocationEntry}
+
+			assert.ErrorIs(t, cs.Verify(cred), types.ErrRevoked)
+		})
+		t.Run("error - statusPurpose does not match status list", func(t *testing.T) {
+			cs, revocationEntry, _ := suspensionSetup(t)
+			revocationEntry.StatusPurpose = StatusPurposeSuspension
+			cred := test.ValidNutsOrganizationCredential(t)
+			cred.CredentialStatus = []any{revocationEntry}
+
+			assert.ErrorContains(t, cs.Verify(cred), "does not match vc.credentialStatus.statusPurpose='suspension'")
+		})
+	})
+	t.Run("BitstringStatusList", func(t *testing.T) {
+		bitstringSetup := func(t *testing.T, entryIsRevoked bool) (*StatusList2021, StatusList2021Entry) {
+			cs, entry, ts := testSetup(t, entryIsRevoked)
+			statusListCredential := test.ValidBitstringStatusListCredential(t)
+			statusListCredential.CredentialSubject[0].(map[string]any)["id"] = ts.URL
+			credBytes, err := json.Marshal(statusListCredential)
+			require.NoError(t, err)
+			ts.Config.Handler = http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				_, _ = writer.Write(credBytes)
+			})
+			entry.Type = BitstringStatusListEntryType
+			return cs, entry
+		}
+		t.Run("ok", func(t *testing.T) {
+			cs, entry := bitstringSetup(t, false)
+			cred := test.ValidNutsOrganizationCredential(t)
+			cred.CredentialStatus = []any{entry}
+			assert.NoError(t, cs.Verify(cred))
+		})
+		t.Run("ok - revoked", func(t *testing.T) {
+			cs, entry := bitstringSetup(t, true)
+			cred := test.ValidNutsOrganizationCredential(t)
+			cred.CredentialStatus = []any{entry}
+			assert.ErrorIs(t, cs.Verify(cred), types.ErrRevoked)
+		})
+		t.Run("ok - revoked with statusSize and statusMessage", func(t *testing.T) {
+			cs, entry := bitstringSetup(t, false)
+			// bit 1 is set, which is the least significant bit of the status at index 0 with statusSize 2
+			entry.StatusListIndex = "0"
+			entry.StatusSize = 2
+			entry.StatusMessage = []StatusMessage{
+				{Status: "0x0", Message: "valid"},
+				{Status: "0x1", Message: "employee left the organization"},
+				{Status: "0x2", Message: "key compromised"},
+				{Status: "0x3", Message: "other"},
+			}
+			cred := test.ValidNutsOrganizationCredential(t)
+			cred.CredentialStatus = []any{entry}
+
+			err := cs.Verify(cred)
+
+			assert.ErrorIs(t, err, types.ErrRevoked)
+			assert.ErrorContains(t, err, "employee left the organization")
+		})
+		t.Run("error - credentialStatus.type does not match status list", func(t *testing.T) {
+			cs, entry, _ := testSetup(t, false) // serves a StatusList2021Credential
+			entry.Type = BitstringStatusListEntryType
+			cred := test.ValidNutsOrganizationCredential(t)
+			cred.CredentialStatus = []any{entry}
+
+			err := cs.Verify(cred)
+
+			assert.EqualError(t, err, "status list credential of type 'StatusList2021' does not match vc.credentialStatus.type='BitstringStatusListEntry'")
+		})
+	})
 	t.Run("error - cannot get statusList", func(t *testing.T) {
 		cs, entry, _ := testSetup(t, false)
 		cs.client = http.DefaultClient
@@ -119,9 +231,10 @@ func TestStatusList2021_Verify(t *testing.T) {
 func TestStatusList2021_statusList(t *testing.T) {
 	makeRecords := func(subjectID string) (credentialRecord, credentialIssuerRecord) {
 		cir := credentialIssuerRecord{
-			SubjectID: subjectID,
-			Issuer:    aliceDID.String(),
-			Page:      1,
+			SubjectID:  subjectID,
+			Issuer:     aliceDID.String(),
+			Page:       1,
+			StatusType: StatusList2021Type,
 			Revocations: []revocationRecord{{
 				StatusListCredential: subjectID,
 				StatusListIndex:      1,
@@ -404,6 +517,24 @@ func TestStatusList2021_validate(t *testing.T) {
 		_, err := cs.validate(cred)
 		assert.NoError(t, err)
 	})
+	t.Run("ok - BitstringStatusListCredential", func(t *testing.T) {
+		cred := test.ValidBitstringStatusListCredential(t)
+		credSubject, err := cs.validate(cred)
+		assert.NoError(t, err)
+		assert.Equal(t, BitstringStatusListCredentialSubjectType, credSubject.Type)
+	})
+	t.Run("error - BitstringStatusListCredential missing status list context", func(t *testing.T) {
+		cred := test.ValidBitstringStatusListCredential(t)
+		cred.Context = []ssi.URI{vc.VCContextV1URI(), StatusList2021ContextURI}
+		_, err := cs.validate(cred)
+		assert.EqualError(t, err, "context 'https://www.w3.org/ns/credentials/status/v1' is required")
+	})
+	t.Run("error - BitstringStatusListCredential with StatusList2021 credential subject", func(t *testing.T) {
+		cred := test.ValidBitstringStatusListCredential(t)
+		cred.CredentialSubject[0].(map[string]any)["type"] = StatusList2021CredentialSubjectType
+		_, err := cs.validate(cred)
+		assert.EqualError(t, err, "credentialSubject.type 'BitstringStatusList' is required")
+	})
 	t.Run("error - missing credential/v1 context", func(t *testing.T) {
 		cred := test.ValidStatusList2021Credential(t)
 		cred.Context = []ssi.URI{statusList2021CredentialTypeURI}
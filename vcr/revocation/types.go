@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/nuts-foundation/go-did"
@@ -44,10 +45,18 @@ const (
 	StatusList2021CredentialSubjectType = "StatusList2021"
 	// StatusList2021EntryType is the credentialStatus.type
 	StatusList2021EntryType = "StatusList2021Entry"
+	// BitstringStatusListCredentialType is the type of BitstringStatusListCredential
+	BitstringStatusListCredentialType = "BitstringStatusListCredential"
+	// BitstringStatusListCredentialSubjectType is the credentialSubject.type in a BitstringStatusListCredential
+	BitstringStatusListCredentialSubjectType = "BitstringStatusList"
+	// BitstringStatusListEntryType is the credentialStatus.type
+	BitstringStatusListEntryType = "BitstringStatusListEntry"
 )
 
 var StatusList2021ContextURI = ssi.MustParseURI(jsonld.W3cStatusList2021Context)
 var statusList2021CredentialTypeURI = ssi.MustParseURI(StatusList2021CredentialType)
+var BitstringStatusListContextURI = ssi.MustParseURI(jsonld.W3cBitstringStatusListContext)
+var bitstringStatusListCredentialTypeURI = ssi.MustParseURI(BitstringStatusListCredentialType)
 
 // errNotFound wraps types.ErrNotFound to clarify which StatusList2021Credential is not found
 var errNotFound = fmt.Errorf("status list: %w", types.ErrNotFound)
@@ -55,14 +64,52 @@ var errNotFound = fmt.Errorf("status list: %w", types.ErrNotFound)
 // errRevoked wraps types.ErrRevoked to clarify the source of the error
 var errRevoked = fmt.Errorf("status list: %w", types.ErrRevoked)
 
-// errUnsupportedPurpose limits current usage to 'revocation'
+// errSuspended wraps types.ErrSuspended to clarify the source of the error
+var errSuspended = fmt.Errorf("status list: %w", types.ErrSuspended)
+
+// errNotSuspended wraps types.ErrNotSuspended to clarify the source of the error
+var errNotSuspended = fmt.Errorf("status list: %w", types.ErrNotSuspended)
+
+// errUnsupportedPurpose limits current usage to 'revocation' and 'suspension'
 var errUnsupportedPurpose = errors.New("status list: purpose not supported")
 
+// errUnsupportedType is returned when a status list type other than StatusList2021 or BitstringStatusList is requested
+var errUnsupportedType = errors.New("status list: type not supported")
+
+// StatusListType identifies the specification a status list is issued under.
+// Its value is the credentialSubject.type of the status list credential.
+type StatusListType string
+
+const (
+	// StatusList2021Type identifies the W3C Verifiable Credentials Status List v2021 draft specification.
+	StatusList2021Type StatusListType = StatusList2021CredentialSubjectType
+	// BitstringStatusListType identifies the W3C Bitstring Status List v1.0 specification.
+	BitstringStatusListType StatusListType = BitstringStatusListCredentialSubjectType
+)
+
+// entryType returns the credentialStatus.type of entries on a status list of this type.
+func (t StatusListType) entryType() string {
+	if t == BitstringStatusListType {
+		return BitstringStatusListEntryType
+	}
+	return StatusList2021EntryType
+}
+
+// Validate returns errUnsupportedType if the StatusListType is unknown.
+func (t StatusListType) Validate() error {
+	switch t {
+	case StatusList2021Type, BitstringStatusListType:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", errUnsupportedType, t)
+	}
+}
+
 type StatusPurpose string
 
 const (
 	StatusPurposeRevocation = "revocation"
-	statusPurposeSuspension = "suspension" // currently not supported
+	StatusPurposeSuspension = "suspension"
 )
 
 // StatusList2021Issuer is the issuer side of StatusList2021
@@ -70,19 +117,29 @@ type StatusList2021Issuer interface {
 	// Credential provides a valid StatusList2021Credential with subject ID derived from the issuer and page.
 	// It returns the last issued StatusList2021Credential if it is still valid or issues a new StatusList2021Credential.
 	Credential(ctx context.Context, issuer did.DID, page int) (*vc.VerifiableCredential, error)
-	// Entry creates a StatusList2021Entry that can be added to the credentialStatus of a VC.
-	// The corresponding StatusList2021Credential will have a gap in the bitstring if the returned entry does not make it into a VC.
-	// If the entry belongs to a new StatusList2021Credential, an empty StatusList2021Credential is issued and stored.
-	Entry(ctx context.Context, issuer did.DID, purpose StatusPurpose) (*StatusList2021Entry, error)
+	// Entry creates a StatusList2021Entry or BitstringStatusListEntry (depending on listType) that can be added to the credentialStatus of a VC.
+	// The corresponding status list credential will have a gap in the bitstring if the returned entry does not make it into a VC.
+	// If the entry belongs to a new status list credential, an empty status list credential is issued and stored.
+	// Supported purposes are 'revocation' and 'suspension', each purpose has its own status list credential.
+	Entry(ctx context.Context, issuer did.DID, listType StatusListType, purpose StatusPurpose) (*StatusList2021Entry, error)
 	// Revoke by adding the StatusList2021Entry to the list of revocations, and updates the relevant StatusList2021Credential.
 	// The credentialID allows reverse search of revocations, its issuer is NOT verified against the entry issuer or VC.
 	// Returns types.ErrRevoked if already revoked, or types.ErrNotFound when the entry.StatusListCredential is unknown.
 	Revoke(ctx context.Context, credentialID ssi.URI, entry StatusList2021Entry) error
+	// Suspend sets the status of an entry with statusPurpose 'suspension', and updates the relevant status list credential.
+	// Returns types.ErrSuspended if already suspended, or types.ErrNotFound when the entry.StatusListCredential is unknown.
+	Suspend(ctx context.Context, credentialID ssi.URI, entry StatusList2021Entry) error
+	// Unsuspend clears the status of an entry with statusPurpose 'suspension', and updates the relevant status list credential.
+	// Returns types.ErrNotSuspended if not suspended, or types.ErrNotFound when the entry.StatusListCredential is unknown.
+	Unsuspend(ctx context.Context, credentialID ssi.URI, entry StatusList2021Entry) error
+	// Resign re-issues all status list credentials of the issuer, signed with the issuer's current assertion key.
+	Resign(ctx context.Context, issuer did.DID) error
 }
 
 // StatusList2021Verifier is the verifier side of StatusList2021
 type StatusList2021Verifier interface {
-	// Verify returns a types.ErrRevoked when the credentialStatus contains a 'StatusList2021Entry' that can be resolved and lists the credential as 'revoked'
+	// Verify returns a types.ErrRevoked when the credentialStatus contains a 'StatusList2021Entry' or 'BitstringStatusListEntry'
+	// that can be resolved and lists the credential as 'revoked', or types.ErrSuspended if it lists the credential as 'suspended'.
 	// Other credentialStatus type/statusPurpose are ignored. Verification may fail with other non-standardized errors.
 	Verify(credentialToVerify vc.VerifiableCredential) error
 }
@@ -100,8 +157,10 @@ type ResolveKeyFn func(issuerDID did.DID, at *time.Time, relationType resolver.R
 var _ StatusList2021Issuer = (*StatusList2021)(nil)
 var _ StatusList2021Verifier = (*StatusList2021)(nil)
 
-// StatusList2021 implements the W3C Verifiable Credentials Status List v2021 draft specification.
+// StatusList2021 implements the W3C Verifiable Credentials Status List v2021 draft specification,
+// and its successor the W3C Bitstring Status List v1.0 specification.
 // https://www.w3.org/TR/2023/WD-vc-status-list-20230427/
+// https://www.w3.org/TR/vc-bitstring-status-list/
 // VerifySignature and Sign methods are used to verify and sign StatusList2021Credentials
 type StatusList2021 struct {
 	client          core.HTTPRequestDoer
@@ -119,11 +178,12 @@ func NewStatusList2021(db *gorm.DB, client core.HTTPRequestDoer, baseURL string)
 }
 
 // StatusList2021Entry is the "credentialStatus" property used by issuers to enable VerifiableCredential status information.
+// It is also used for a BitstringStatusListEntry, which adds the optional StatusSize and StatusMessage properties.
 type StatusList2021Entry struct {
 	// ID is expected to be a URL that identifies the status information associated with the verifiable credential.
 	// It MUST NOT be the URL for the status list, which is in StatusListCredential.
 	ID string `json:"id,omitempty"`
-	// Type MUST be "StatusList2021Entry"
+	// Type MUST be "StatusList2021Entry" or "BitstringStatusListEntry"
 	Type string `json:"type,omitempty"`
 	// StatusPurpose indicates what it means if the VerifiableCredential is on the list.
 	// The value is arbitrary, with predefined values `revocation` and `suspension`.
@@ -133,48 +193,114 @@ type StatusList2021Entry struct {
 	// The value identifies the position in the bitstring of the corresponding StatusListCredential.
 	StatusListIndex string `json:"statusListIndex,omitempty"`
 	// StatusListCredential property MUST be a URL to the StatusList2021Credential.
-	// When the URL is dereferenced, the resulting verifiable credential's type MUST include the "StatusList2021Credential".
+	// When the URL is dereferenced, the resulting verifiable credential's type MUST include the "StatusList2021Credential",
+	// or "BitstringStatusListCredential" for a BitstringStatusListEntry.
 	StatusListCredential string `json:"statusListCredential,omitempty"`
+	// StatusSize is the size of the status entry in bits. Only used by BitstringStatusListEntry; defaults to 1 if not set.
+	StatusSize int `json:"statusSize,omitempty"`
+	// StatusMessage lists the meaning of each possible status value. Only used by BitstringStatusListEntry.
+	// It MUST be present if StatusSize is greater than 1.
+	StatusMessage []StatusMessage `json:"statusMessage,omitempty"`
+}
+
+// StatusMessage describes the meaning of a status value in a BitstringStatusListEntry.
+type StatusMessage struct {
+	// Status is the hexadecimal value of the status, prefixed with 0x. e.g. "0x2"
+	Status string `json:"status"`
+	// Message is a human-readable description of the status.
+	Message string `json:"message"`
 }
 
 // Validate returns an error if the contents of the StatusList2021Entry violate the spec.
 func (e StatusList2021Entry) Validate() error {
-	// 'id' MUST NOT be the URL for the status list
-	if e.ID == e.StatusListCredential {
-		return errors.New("StatusList2021Entry.id is the same as the StatusList2021Entry.statusListCredential")
+	if e.Type != StatusList2021EntryType && e.Type != BitstringStatusListEntryType {
+		return errors.New("StatusList2021Entry.type must be StatusList2021Entry or BitstringStatusListEntry")
 	}
 
-	if e.Type != StatusList2021EntryType {
-		return errors.New("StatusList2021Entry.type must be StatusList2021Entry")
+	// 'id' MUST NOT be the URL for the status list
+	if e.ID == e.StatusListCredential {
+		return fmt.Errorf("%s.id is the same as the %s.statusListCredential", e.Type, e.Type)
 	}
 
 	// StatusPurpose must contain a purpose
 	if e.StatusPurpose == "" {
-		return errors.New("StatusList2021Entry.statusPurpose is required")
+		return fmt.Errorf("%s.statusPurpose is required", e.Type)
 	}
 
 	// statusListIndex must be a non-negative number
 	if n, err := strconv.Atoi(e.StatusListIndex); err != nil || n < 0 {
-		return errors.New("invalid StatusList2021Entry.statusListIndex")
+		return fmt.Errorf("invalid %s.statusListIndex", e.Type)
 	}
 
 	// 'statusListCredential' must be a URL
 	if _, err := url.ParseRequestURI(e.StatusListCredential); err != nil {
-		return fmt.Errorf("parse StatusList2021Entry.statusListCredential URL: %w", err)
+		return fmt.Errorf("parse %s.statusListCredential URL: %w", e.Type, err)
+	}
+
+	if e.Type == StatusList2021EntryType {
+		if e.StatusSize != 0 || len(e.StatusMessage) != 0 {
+			return errors.New("StatusList2021Entry does not support statusSize and statusMessage")
+		}
+		return nil
+	}
+
+	// statusSize defaults to 1; statusMessage MUST be present if statusSize > 1, and describe all possible values.
+	if e.StatusSize < 0 || e.StatusSize > maxStatusSize {
+		return fmt.Errorf("invalid BitstringStatusListEntry.statusSize, must be in range [1, %d]", maxStatusSize)
+	}
+	if e.StatusSize > 1 && len(e.StatusMessage) != 1<<e.StatusSize {
+		return fmt.Errorf("BitstringStatusListEntry.statusMessage must contain %d messages for statusSize %d", 1<<e.StatusSize, e.StatusSize)
+	}
+	for _, message := range e.StatusMessage {
+		if _, err := message.value(); err != nil {
+			return fmt.Errorf("invalid BitstringStatusListEntry.statusMessage: %w", err)
+		}
 	}
 
 	return nil
 }
 
-// StatusList2021CredentialSubject of a StatusList2021Credential
+// statusSize returns the number of bits used per status in the status list.
+func (e StatusList2021Entry) statusSize() int {
+	if e.StatusSize == 0 {
+		return 1
+	}
+	return e.StatusSize
+}
+
+// message returns the StatusMessage.Message for the given status value, or an empty string if there is none.
+func (e StatusList2021Entry) message(status int) string {
+	for _, message := range e.StatusMessage {
+		if value, err := message.value(); err == nil && value == status {
+			return message.Message
+		}
+	}
+	return ""
+}
+
+// value parses the hexadecimal StatusMessage.Status.
+func (m StatusMessage) value() (int, error) {
+	hex, ok := strings.CutPrefix(m.Status, "0x")
+	if !ok {
+		return 0, fmt.Errorf("status '%s' must be a hexadecimal value prefixed with 0x", m.Status)
+	}
+	value, err := strconv.ParseInt(hex, 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("status '%s' must be a hexadecimal value prefixed with 0x", m.Status)
+	}
+	return int(value), nil
+}
+
+// StatusList2021CredentialSubject of a StatusList2021Credential or BitstringStatusListCredential
 type StatusList2021CredentialSubject struct {
 	// ID for the credential subject
 	ID string `json:"id"`
-	// Type MUST be "StatusList2021Credential"
+	// Type MUST be "StatusList2021" or "BitstringStatusList"
 	Type string `json:"type"`
 	// StatusPurpose defines the reason credentials are listed. ('revocation', 'suspension')
 	StatusPurpose string `json:"statusPurpose"`
 	// EncodedList is the GZIP-compressed [RFC1952], base-64 encoded [RFC4648] bitstring values for the associated range
 	// of verifiable credential status values. The uncompressed bitstring MUST be at least 16KB in size.
+	// For a BitstringStatusList it is a Multibase-encoded (base64url without padding, prefixed with 'u') value.
 	EncodedList string `json:"encodedList"`
 }
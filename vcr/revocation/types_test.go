@@ -82,7 +82,7 @@ func TestEntry_Validate(t *testing.T) {
 		entry := makeValidCSEntry()
 		entry.Type = "Wrong Type"
 		err := entry.Validate()
-		assert.EqualError(t, err, "StatusList2021Entry.type must be StatusList2021Entry")
+		assert.EqualError(t, err, "StatusList2021Entry.type must be StatusList2021Entry or BitstringStatusListEntry")
 	})
 	t.Run("error - missing statusPurpose", func(t *testing.T) {
 		entry := makeValidCSEntry()
@@ -108,4 +108,70 @@ func TestEntry_Validate(t *testing.T) {
 		err := entry.Validate()
 		assert.EqualError(t, err, "parse StatusList2021Entry.statusListCredential URL: parse \"not a URL\": invalid URI for request")
 	})
+	t.Run("error - statusSize on StatusList2021Entry", func(t *testing.T) {
+		entry := makeValidCSEntry()
+		entry.StatusSize = 1
+		err := entry.Validate()
+		assert.EqualError(t, err, "StatusList2021Entry does not support statusSize and statusMessage")
+	})
+	t.Run("BitstringStatusListEntry", func(t *testing.T) {
+		makeValidBitstringEntry := func() StatusList2021Entry {
+			entry := makeValidCSEntry()
+			entry.Type = BitstringStatusListEntryType
+			return entry
+		}
+		t.Run("ok", func(t *testing.T) {
+			assert.NoError(t, makeValidBitstringEntry().Validate())
+		})
+		t.Run("ok - statusSize and statusMessage", func(t *testing.T) {
+			entry := makeValidBitstringEntry()
+			entry.StatusPurpose = "message"
+			entry.StatusSize = 2
+			entry.StatusMessage = []StatusMessage{
+				{Status: "0x0", Message: "valid"},
+				{Status: "0x1", Message: "pending review"},
+				{Status: "0x2", Message: "under investigation"},
+				{Status: "0x3", Message: "invalid"},
+			}
+			assert.NoError(t, entry.Validate())
+		})
+		t.Run("error - type in error message", func(t *testing.T) {
+			entry := makeValidBitstringEntry()
+			entry.StatusPurpose = ""
+			err := entry.Validate()
+			assert.EqualError(t, err, "BitstringStatusListEntry.statusPurpose is required")
+		})
+		t.Run("error - statusSize out of range", func(t *testing.T) {
+			entry := makeValidBitstringEntry()
+			entry.StatusSize = 9
+			err := entry.Validate()
+			assert.EqualError(t, err, "invalid BitstringStatusListEntry.statusSize, must be in range [1, 8]")
+		})
+		t.Run("error - statusMessage missing", func(t *testing.T) {
+			entry := makeValidBitstringEntry()
+			entry.StatusSize = 2
+			err := entry.Validate()
+			assert.EqualError(t, err, "BitstringStatusListEntry.statusMessage must contain 4 messages for statusSize 2")
+		})
+		t.Run("error - invalid statusMessage.status", func(t *testing.T) {
+			entry := makeValidBitstringEntry()
+			entry.StatusMessage = []StatusMessage{{Status: "1", Message: "revoked"}}
+			err := entry.Validate()
+			assert.EqualError(t, err, "invalid BitstringStatusListEntry.statusMessage: status '1' must be a hexadecimal value prefixed with 0x")
+		})
+	})
+}
+
+func TestStatusList2021Entry_message(t *testing.T) {
+	entry := StatusList2021Entry{StatusMessage: []StatusMessage{{Status: "0x0", Message: "valid"}, {Status: "0xA", Message: "invalid"}}}
+
+	assert.Equal(t, "valid", entry.message(0))
+	assert.Equal(t, "invalid", entry.message(10))
+	assert.Empty(t, entry.message(1))
+}
+
+func TestStatusListType_Validate(t *testing.T) {
+	assert.NoError(t, StatusList2021Type.Validate())
+	assert.NoError(t, BitstringStatusListType.Validate())
+	assert.ErrorIs(t, StatusListType("RevocationList2020").Validate(), errUnsupportedType)
 }
@@ -0,0 +1,218 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package sdjwt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/vc"
+)
+
+// EnvelopedCredentialType is the type of a Verifiable Credential that envelopes a secured credential,
+// as specified by https://www.w3.org/TR/vc-data-model-2.0/#enveloped-verifiable-credentials
+var EnvelopedCredentialType = ssi.MustParseURI("EnvelopedVerifiableCredential")
+
+// VCContextV2URI is the base context of the Verifiable Credentials Data Model v2.0, used by enveloped credentials.
+var VCContextV2URI = ssi.MustParseURI("https://www.w3.org/ns/credentials/v2")
+
+// ErrNotEnveloped is returned when a credential is not an SD-JWT VC enveloped in an EnvelopedVerifiableCredential.
+var ErrNotEnveloped = errors.New("credential is not an enveloped SD-JWT VC")
+
+const dataURLPrefix = "data:" + MediaType + ","
+
+// vcClaim is the JWT claim that contains the Verifiable Credential.
+const vcClaim = "vc"
+
+// Create creates an SD-JWT VC from the given template and signs it using the given signer.
+// The credential is encoded as JWT according to https://www.w3.org/TR/2022/REC-vc-data-model-20220303/#jwt-encoding.
+// The claims of the credentialSubject listed in selectivelyDisclosable are made selectively disclosable.
+// They're specified as dot-separated path, relative to the credentialSubject (e.g. 'organization.city').
+// If none are given, all claims of the credentialSubject except 'id' are made selectively disclosable.
+func Create(ctx context.Context, template vc.VerifiableCredential, selectivelyDisclosable []string, signer vc.JWTSigner) (*SDJWT, error) {
+	subjectDID, err := template.SubjectDID()
+	if err != nil {
+		return nil, err
+	}
+	if len(template.CredentialSubject) != 1 {
+		return nil, errors.New("SD-JWT VC must contain exactly one credentialSubject")
+	}
+	var credentialSubject map[string]any
+	data, _ := json.Marshal(template.CredentialSubject[0])
+	if err = json.Unmarshal(data, &credentialSubject); err != nil {
+		return nil, fmt.Errorf("invalid credentialSubject: %w", err)
+	}
+	var paths [][]string
+	if len(selectivelyDisclosable) == 0 {
+		for name := range credentialSubject {
+			if name != "id" {
+				paths = append(paths, []string{name})
+			}
+		}
+	}
+	for _, claim := range selectivelyDisclosable {
+		path := strings.Split(claim, ".")
+		if path[0] == "id" {
+			return nil, errors.New("credentialSubject.id can't be selectively disclosable")
+		}
+		paths = append(paths, path)
+	}
+	concealedSubject, disclosures, err := conceal(credentialSubject, paths, "credentialSubject.")
+	if err != nil {
+		return nil, err
+	}
+
+	vcMap := map[string]any{
+		"@context":          template.Context,
+		"type":              template.Type,
+		"credentialSubject": concealedSubject,
+	}
+	if template.CredentialStatus != nil {
+		vcMap["credentialStatus"] = template.CredentialStatus
+	}
+	claims := map[string]any{
+		jwt.NotBeforeKey: template.IssuanceDate,
+		jwt.IssuerKey:    template.Issuer.String(),
+		jwt.SubjectKey:   subjectDID.String(),
+		vcClaim:          vcMap,
+		sdAlgClaim:       HashAlgorithm,
+	}
+	if template.ID != nil {
+		claims[jwt.JwtIDKey] = template.ID.String()
+	}
+	if template.ExpirationDate != nil {
+		claims[jwt.ExpirationKey] = *template.ExpirationDate
+	}
+	headers := map[string]any{
+		jws.TypeKey: Format,
+	}
+	token, err := signer(ctx, claims, headers)
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign SD-JWT credential: %w", err)
+	}
+	return &SDJWT{IssuerJWT: token, Disclosures: disclosures}, nil
+}
+
+// Credential returns the Verifiable Credential contained in the SD-JWT, with the included disclosures applied.
+// Claims of which the disclosure is not included are omitted.
+// The JWT claims are decoded according to https://www.w3.org/TR/2022/REC-vc-data-model-20220303/#jwt-decoding
+// Note that the returned credential is only a view on the SD-JWT: it does not contain a proof.
+func (s SDJWT) Credential() (*vc.VerifiableCredential, error) {
+	claims, err := s.Claims()
+	if err != nil {
+		return nil, err
+	}
+	vcMap, ok := claims[vcClaim].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("SD-JWT does not contain a '%s' claim", vcClaim)
+	}
+	data, _ := json.Marshal(vcMap)
+	result, err := vc.ParseVerifiableCredential(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid '%s' claim: %w", vcClaim, err)
+	}
+	if exp, ok := claims[jwt.ExpirationKey].(float64); ok {
+		expirationDate := time.Unix(int64(exp), 0)
+		result.ExpirationDate = &expirationDate
+	}
+	if nbf, ok := claims[jwt.NotBeforeKey].(float64); ok {
+		result.IssuanceDate = time.Unix(int64(nbf), 0)
+	}
+	if iss, ok := claims[jwt.IssuerKey].(string); ok {
+		issuer, err := ssi.ParseURI(iss)
+		if err != nil {
+			return nil, fmt.Errorf("invalid '%s' claim: %w", jwt.IssuerKey, err)
+		}
+		result.Issuer = *issuer
+	}
+	if jti, ok := claims[jwt.JwtIDKey].(string); ok {
+		id, err := ssi.ParseURI(jti)
+		if err != nil {
+			return nil, fmt.Errorf("invalid '%s' claim: %w", jwt.JwtIDKey, err)
+		}
+		result.ID = id
+	}
+	if sub, ok := claims[jwt.SubjectKey].(string); ok {
+		for _, credentialSubject := range result.CredentialSubject {
+			if asMap, isMap := credentialSubject.(map[string]any); isMap {
+				asMap["id"] = sub
+			}
+		}
+	}
+	return result, nil
+}
+
+// Algorithm returns the algorithm used to sign the issuer-signed JWT.
+func (s SDJWT) Algorithm() (string, error) {
+	message, err := jws.ParseString(s.IssuerJWT)
+	if err != nil {
+		return "", err
+	}
+	if len(message.Signatures()) != 1 {
+		return "", errors.New("expected exactly one signature")
+	}
+	return message.Signatures()[0].ProtectedHeaders().Algorithm().String(), nil
+}
+
+// Envelope returns the SD-JWT as EnvelopedVerifiableCredential,
+// so it can be handled as Verifiable Credential (e.g. stored in a wallet or included in a Verifiable Presentation).
+func (s SDJWT) Envelope() (*vc.VerifiableCredential, error) {
+	envelope := map[string]any{
+		"@context": []string{VCContextV2URI.String()},
+		"id":       dataURLPrefix + s.String(),
+		"type":     EnvelopedCredentialType.String(),
+	}
+	data, _ := json.Marshal(envelope)
+	return vc.ParseVerifiableCredential(string(data))
+}
+
+// IsEnveloped returns true if the given credential is an SD-JWT VC enveloped in an EnvelopedVerifiableCredential.
+func IsEnveloped(credential vc.VerifiableCredential) bool {
+	return credential.IsType(EnvelopedCredentialType) &&
+		credential.ID != nil && strings.HasPrefix(credential.ID.String(), dataURLPrefix)
+}
+
+// FromCredential returns the SD-JWT enveloped by the given credential.
+// It returns ErrNotEnveloped if the credential is not an enveloped SD-JWT VC.
+func FromCredential(credential vc.VerifiableCredential) (*SDJWT, error) {
+	if !IsEnveloped(credential) {
+		return nil, ErrNotEnveloped
+	}
+	return Parse(strings.TrimPrefix(credential.ID.String(), dataURLPrefix))
+}
+
+// Unwrap returns the Verifiable Credential contained by the given enveloped SD-JWT VC, with the included disclosures applied.
+// Other credentials are returned as-is.
+func Unwrap(credential vc.VerifiableCredential) (*vc.VerifiableCredential, error) {
+	if !IsEnveloped(credential) {
+		return &credential, nil
+	}
+	sdJWT, err := FromCredential(credential)
+	if err != nil {
+		return nil, err
+	}
+	return sdJWT.Credential()
+}
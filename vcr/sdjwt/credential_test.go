@@ -0,0 +1,198 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package sdjwt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreate(t *testing.T) {
+	key := newTestKey(t)
+	signer := testSigner(key)
+
+	t.Run("ok - selected claims", func(t *testing.T) {
+		sdJWT, err := Create(context.Background(), testCredential(), []string{"organization.city", "role"}, signer)
+		require.NoError(t, err)
+
+		assert.Len(t, sdJWT.Disclosures, 2)
+		t.Run("undisclosed claims are concealed", func(t *testing.T) {
+			credential, err := SDJWT{IssuerJWT: sdJWT.IssuerJWT}.Credential()
+
+			require.NoError(t, err)
+			subject := credential.CredentialSubject[0].(map[string]any)
+			assert.Equal(t, "did:example:holder", subject["id"])
+			assert.NotContains(t, subject, "role")
+			assert.Equal(t, map[string]any{"name": "Care Home"}, subject["organization"])
+		})
+		t.Run("disclosed claims are present", func(t *testing.T) {
+			credential, err := sdJWT.Credential()
+
+			require.NoError(t, err)
+			subject := credential.CredentialSubject[0].(map[string]any)
+			assert.Equal(t, "nurse", subject["role"])
+			assert.Equal(t, map[string]any{"name": "Care Home", "city": "Amsterdam"}, subject["organization"])
+		})
+	})
+	t.Run("ok - all claims", func(t *testing.T) {
+		sdJWT, err := Create(context.Background(), testCredential(), nil, signer)
+		require.NoError(t, err)
+
+		credential, err := SDJWT{IssuerJWT: sdJWT.IssuerJWT}.Credential()
+
+		require.NoError(t, err)
+		assert.Len(t, sdJWT.Disclosures, 2)
+		assert.Equal(t, map[string]any{"id": "did:example:holder"}, credential.CredentialSubject[0])
+	})
+	t.Run("error - id is selectively disclosable", func(t *testing.T) {
+		_, err := Create(context.Background(), testCredential(), []string{"id"}, signer)
+
+		assert.EqualError(t, err, "credentialSubject.id can't be selectively disclosable")
+	})
+	t.Run("error - claim not found", func(t *testing.T) {
+		_, err := Create(context.Background(), testCredential(), []string{"organization.street"}, signer)
+
+		assert.EqualError(t, err, "claim 'credentialSubject.organization.street' not found")
+	})
+	t.Run("error - multiple credentialSubjects", func(t *testing.T) {
+		template := testCredential()
+		template.CredentialSubject = append(template.CredentialSubject, template.CredentialSubject[0])
+
+		_, err := Create(context.Background(), template, nil, signer)
+
+		assert.EqualError(t, err, "SD-JWT VC must contain exactly one credentialSubject")
+	})
+	t.Run("error - signing fails", func(t *testing.T) {
+		_, err := Create(context.Background(), testCredential(), nil, func(_ context.Context, _ map[string]any, _ map[string]any) (string, error) {
+			return "", errors.New("failed")
+		})
+
+		assert.EqualError(t, err, "unable to sign SD-JWT credential: failed")
+	})
+}
+
+func TestSDJWT_Credential(t *testing.T) {
+	key := newTestKey(t)
+	template := testCredential()
+	sdJWT, err := Create(context.Background(), template, nil, testSigner(key))
+	require.NoError(t, err)
+
+	credential, err := sdJWT.Credential()
+
+	require.NoError(t, err)
+	assert.Equal(t, template.ID.String(), credential.ID.String())
+	assert.Equal(t, template.Issuer.String(), credential.Issuer.String())
+	assert.Equal(t, template.IssuanceDate.Unix(), credential.IssuanceDate.Unix())
+	assert.Equal(t, template.ExpirationDate.Unix(), credential.ExpirationDate.Unix())
+	assert.True(t, credential.IsType(ssi.MustParseURI("NutsEmployeeCredential")))
+	subjectDID, err := credential.SubjectDID()
+	require.NoError(t, err)
+	assert.Equal(t, "did:example:holder", subjectDID.String())
+}
+
+func TestSDJWT_Algorithm(t *testing.T) {
+	sdJWT, err := Create(context.Background(), testCredential(), nil, testSigner(newTestKey(t)))
+	require.NoError(t, err)
+
+	alg, err := sdJWT.Algorithm()
+
+	require.NoError(t, err)
+	assert.Equal(t, "ES256", alg)
+}
+
+func TestSDJWT_Envelope(t *testing.T) {
+	sdJWT, err := Create(context.Background(), testCredential(), nil, testSigner(newTestKey(t)))
+	require.NoError(t, err)
+
+	envelope, err := sdJWT.Envelope()
+	require.NoError(t, err)
+
+	t.Run("is enveloped", func(t *testing.T) {
+		assert.True(t, IsEnveloped(*envelope))
+		assert.Equal(t, dataURLPrefix+sdJWT.String(), envelope.ID.String())
+	})
+	t.Run("JSON roundtrip", func(t *testing.T) {
+		data, err := json.Marshal(envelope)
+		require.NoError(t, err)
+		var parsed vc.VerifiableCredential
+		require.NoError(t, json.Unmarshal(data, &parsed))
+
+		actual, err := FromCredential(parsed)
+
+		require.NoError(t, err)
+		assert.Equal(t, sdJWT.String(), actual.String())
+	})
+	t.Run("unwrap", func(t *testing.T) {
+		credential, err := Unwrap(*envelope)
+
+		require.NoError(t, err)
+		assert.Equal(t, "did:example:issuer", credential.Issuer.String())
+		assert.False(t, IsEnveloped(*credential))
+	})
+}
+
+func TestUnwrap(t *testing.T) {
+	t.Run("not enveloped", func(t *testing.T) {
+		credential := testCredential()
+
+		actual, err := Unwrap(credential)
+
+		require.NoError(t, err)
+		assert.Same(t, credential.ID, actual.ID)
+	})
+}
+
+func TestFromCredential(t *testing.T) {
+	t.Run("error - not enveloped", func(t *testing.T) {
+		_, err := FromCredential(testCredential())
+
+		assert.ErrorIs(t, err, ErrNotEnveloped)
+	})
+}
+
+func testCredential() vc.VerifiableCredential {
+	id := ssi.MustParseURI("did:example:issuer#1")
+	expirationDate := time.Now().Add(time.Hour).Truncate(time.Second)
+	return vc.VerifiableCredential{
+		Context:        []ssi.URI{vc.VCContextV1URI()},
+		ID:             &id,
+		Type:           []ssi.URI{vc.VerifiableCredentialTypeV1URI(), ssi.MustParseURI("NutsEmployeeCredential")},
+		Issuer:         ssi.MustParseURI("did:example:issuer"),
+		IssuanceDate:   time.Now().Truncate(time.Second),
+		ExpirationDate: &expirationDate,
+		CredentialSubject: []interface{}{
+			map[string]any{
+				"id":   "did:example:holder",
+				"role": "nurse",
+				"organization": map[string]any{
+					"name": "Care Home",
+					"city": "Amsterdam",
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,525 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+// Package sdjwt implements Selective Disclosure for JWTs (SD-JWT, https://datatracker.ietf.org/doc/draft-ietf-oauth-selective-disclosure-jwt/)
+// as used to secure W3C Verifiable Credentials (https://www.w3.org/TR/vc-jose-cose/#with-sd-jwt).
+package sdjwt
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+)
+
+// Format is the format designation of Verifiable Credentials secured using SD-JWT.
+const Format = "vc+sd-jwt"
+
+// MediaType is the media type of Verifiable Credentials secured using SD-JWT.
+const MediaType = "application/vc+sd-jwt"
+
+// KeyBindingJWTType is the 'typ' header of a Key Binding JWT.
+const KeyBindingJWTType = "kb+jwt"
+
+// HashAlgorithm is the hash algorithm used to calculate the digests of disclosures. It is the only one supported.
+const HashAlgorithm = "sha-256"
+
+const (
+	separator         = "~"
+	sdClaim           = "_sd"
+	sdAlgClaim        = "_sd_alg"
+	arrayElementClaim = "..."
+	sdHashClaim       = "sd_hash"
+	nonceClaim        = "nonce"
+	saltSize          = 16
+)
+
+// ErrNoKeyBinding is returned when an SD-JWT does not contain a Key Binding JWT.
+var ErrNoKeyBinding = errors.New("SD-JWT does not contain a Key Binding JWT")
+
+// Disclosure is a selectively disclosable claim of an SD-JWT.
+type Disclosure struct {
+	// Salt is the random salt that makes the digest of the disclosure unguessable.
+	Salt string
+	// Name is the name of the disclosed claim. It is empty for disclosures of array elements.
+	Name string
+	// Value is the value of the disclosed claim.
+	Value any
+	// encoded is the base64url encoded form of the disclosure, from which its digest is calculated.
+	encoded string
+}
+
+// NewDisclosure creates a disclosure for the claim with the given name and value, using a random salt.
+// If name is empty, the disclosure is an array element disclosure.
+func NewDisclosure(name string, value any) (*Disclosure, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	encodedSalt := base64.RawURLEncoding.EncodeToString(salt)
+	elements := []any{encodedSalt, name, value}
+	if name == "" {
+		elements = []any{encodedSalt, value}
+	}
+	data, err := json.Marshal(elements)
+	if err != nil {
+		return nil, fmt.Errorf("invalid disclosure value for claim '%s': %w", name, err)
+	}
+	// parse the encoded form, so the value has the same representation as when received from a holder
+	return ParseDisclosure(base64.RawURLEncoding.EncodeToString(data))
+}
+
+// ParseDisclosure parses an encoded disclosure.
+func ParseDisclosure(encoded string) (*Disclosure, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid disclosure: %w", err)
+	}
+	var elements []any
+	if err = json.Unmarshal(data, &elements); err != nil {
+		return nil, fmt.Errorf("invalid disclosure: %w", err)
+	}
+	result := Disclosure{encoded: encoded}
+	var ok bool
+	switch len(elements) {
+	case 2:
+		result.Value = elements[1]
+	case 3:
+		if result.Name, ok = elements[1].(string); !ok {
+			return nil, errors.New("invalid disclosure: claim name must be a string")
+		}
+		if result.Name == sdClaim || result.Name == arrayElementClaim || result.Name == "" {
+			return nil, fmt.Errorf("invalid disclosure: illegal claim name '%s'", result.Name)
+		}
+		result.Value = elements[2]
+	default:
+		return nil, fmt.Errorf("invalid disclosure: expected 2 or 3 elements, got %d", len(elements))
+	}
+	if result.Salt, ok = elements[0].(string); !ok {
+		return nil, errors.New("invalid disclosure: salt must be a string")
+	}
+	return &result, nil
+}
+
+// Encoded returns the base64url-encoded form of the disclosure, as it appears in the SD-JWT.
+func (d Disclosure) Encoded() string {
+	return d.encoded
+}
+
+// Digest returns the digest of the disclosure, as it is referenced in the SD-JWT payload.
+func (d Disclosure) Digest() string {
+	return digest(d.encoded)
+}
+
+// SDJWT is an issuer-signed JWT with the disclosures of the claims that are disclosed and an optional Key Binding JWT.
+type SDJWT struct {
+	// IssuerJWT contains the issuer-signed JWT.
+	IssuerJWT string
+	// Disclosures contains the disclosures of the selectively disclosable claims that are disclosed.
+	Disclosures []Disclosure
+	// KeyBindingJWT contains the Key Binding JWT signed by the holder. It is optional.
+	KeyBindingJWT string
+}
+
+// Parse parses an SD-JWT in compact serialization (<Issuer-signed JWT>~<Disclosure 1>~...~<Disclosure N>~<optional KB-JWT>).
+// It does not verify any signatures, nor whether the disclosures are referenced by the issuer-signed JWT.
+func Parse(raw string) (*SDJWT, error) {
+	parts := strings.Split(strings.TrimSpace(raw), separator)
+	if len(parts) < 2 {
+		return nil, errors.New("invalid SD-JWT: expected at least one separator")
+	}
+	if _, err := jws.ParseString(parts[0]); err != nil {
+		return nil, fmt.Errorf("invalid SD-JWT: invalid issuer-signed JWT: %w", err)
+	}
+	result := SDJWT{
+		IssuerJWT:     parts[0],
+		KeyBindingJWT: parts[len(parts)-1],
+	}
+	for _, encoded := range parts[1 : len(parts)-1] {
+		disclosure, err := ParseDisclosure(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SD-JWT: %w", err)
+		}
+		result.Disclosures = append(result.Disclosures, *disclosure)
+	}
+	return &result, nil
+}
+
+// String returns the SD-JWT in compact serialization.
+func (s SDJWT) String() string {
+	var builder strings.Builder
+	builder.WriteString(s.IssuerJWT)
+	builder.WriteString(separator)
+	for _, disclosure := range s.Disclosures {
+		builder.WriteString(disclosure.encoded)
+		builder.WriteString(separator)
+	}
+	builder.WriteString(s.KeyBindingJWT)
+	return builder.String()
+}
+
+// SDHash returns the digest of the SD-JWT without its Key Binding JWT.
+// It is included in the Key Binding JWT as 'sd_hash', binding it to the presented disclosures.
+func (s SDJWT) SDHash() string {
+	s.KeyBindingJWT = ""
+	return digest(s.String())
+}
+
+// Claims returns the payload of the issuer-signed JWT, with the included disclosures applied.
+// Claims of which the disclosure is not included are omitted.
+// It returns an error if a disclosure is not referenced by the payload, or referenced more than once.
+func (s SDJWT) Claims() (map[string]any, error) {
+	claims, _, err := s.process()
+	return claims, err
+}
+
+// Select returns a copy of the SD-JWT that only contains the disclosures for which include returns true.
+// include is called with the path of the disclosed claim in the payload (e.g. [vc credentialSubject name]),
+// array indices are not part of the path. Disclosures that contain the claims of selected disclosures are retained as well.
+// The Key Binding JWT is not retained, since it is bound to the disclosures.
+func (s SDJWT) Select(include func(path []string) bool) (*SDJWT, error) {
+	_, references, err := s.process()
+	if err != nil {
+		return nil, err
+	}
+	selected := make(map[string]bool)
+	for current, reference := range references {
+		if include(reference.path) {
+			// also select the disclosures containing this one
+			for d := current; d != ""; d = references[d].parent {
+				selected[d] = true
+			}
+		}
+	}
+	result := SDJWT{IssuerJWT: s.IssuerJWT}
+	for _, disclosure := range s.Disclosures {
+		if selected[disclosure.Digest()] {
+			result.Disclosures = append(result.Disclosures, disclosure)
+		}
+	}
+	return &result, nil
+}
+
+// KeyBindingClaims returns the claims of a Key Binding JWT for this SD-JWT.
+// Audience and nonce are optional.
+func (s SDJWT) KeyBindingClaims(audience string, nonce string) map[string]any {
+	claims := map[string]any{
+		jwt.IssuedAtKey: time.Now(),
+		sdHashClaim:     s.SDHash(),
+	}
+	if audience != "" {
+		claims[jwt.AudienceKey] = audience
+	}
+	if nonce != "" {
+		claims[nonceClaim] = nonce
+	}
+	return claims
+}
+
+// KeyBindingOptions contains the expected claims of a Key Binding JWT.
+// They're taken from the presentation the SD-JWT is presented in, to prevent replay of the SD-JWT in another presentation.
+type KeyBindingOptions struct {
+	// Audience is the audience of the presentation. The 'aud' claim must be one of them, or absent if there's no audience.
+	Audience []string
+	// Nonce is the nonce of the presentation. The 'nonce' claim must be equal to it.
+	Nonce string
+	// MaxAge is the maximum age of the Key Binding JWT ('iat' claim) at the time it's validated.
+	MaxAge time.Duration
+	// At is the time at which the Key Binding JWT is validated. If zero, the current time is used.
+	At time.Time
+}
+
+// keyBindingLeeway is the allowed clock skew when checking the 'iat' claim of a Key Binding JWT.
+const keyBindingLeeway = 5 * time.Second
+
+// ValidateKeyBinding parses the Key Binding JWT and checks that it is bound to the SD-JWT,
+// and that its audience, nonce and issuance time match the given options.
+// It does not verify its signature, that is up to the caller.
+// It returns ErrNoKeyBinding if the SD-JWT does not contain a Key Binding JWT.
+func (s SDJWT) ValidateKeyBinding(options KeyBindingOptions) (jwt.Token, error) {
+	if s.KeyBindingJWT == "" {
+		return nil, ErrNoKeyBinding
+	}
+	message, err := jws.ParseString(s.KeyBindingJWT)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Key Binding JWT: %w", err)
+	}
+	if len(message.Signatures()) != 1 {
+		return nil, errors.New("invalid Key Binding JWT: expected exactly one signature")
+	}
+	if typ := message.Signatures()[0].ProtectedHeaders().Type(); typ != KeyBindingJWTType {
+		return nil, fmt.Errorf("invalid Key Binding JWT: expected typ '%s', got '%s'", KeyBindingJWTType, typ)
+	}
+	token, err := jwt.ParseString(s.KeyBindingJWT, jwt.WithVerify(false), jwt.WithValidate(false))
+	if err != nil {
+		return nil, fmt.Errorf("invalid Key Binding JWT: %w", err)
+	}
+	if sdHash, _ := token.Get(sdHashClaim); sdHash != s.SDHash() {
+		return nil, errors.New("invalid Key Binding JWT: 'sd_hash' does not match SD-JWT")
+	}
+	if err = validateKeyBindingClaims(token, options); err != nil {
+		return nil, fmt.Errorf("invalid Key Binding JWT: %w", err)
+	}
+	return token, nil
+}
+
+func validateKeyBindingClaims(token jwt.Token, options KeyBindingOptions) error {
+	switch audience := token.Audience(); {
+	case len(audience) > 1:
+		return errors.New("'aud' must contain a single value")
+	case len(audience) == 0 && len(options.Audience) > 0:
+		return errors.New("missing 'aud'")
+	case len(audience) == 1 && !slices.Contains(options.Audience, audience[0]):
+		return fmt.Errorf("'aud' does not match presentation (aud=%s)", audience[0])
+	}
+	nonce, ok := token.Get(nonceClaim)
+	if !ok {
+		nonce = ""
+	}
+	if nonce != options.Nonce {
+		return errors.New("'nonce' does not match presentation")
+	}
+	at := options.At
+	if at.IsZero() {
+		at = time.Now()
+	}
+	issuedAt := token.IssuedAt()
+	switch {
+	case issuedAt.IsZero():
+		return errors.New("missing 'iat'")
+	case issuedAt.After(at.Add(keyBindingLeeway)):
+		return errors.New("'iat' is in the future")
+	case issuedAt.Before(at.Add(-options.MaxAge - keyBindingLeeway)):
+		return fmt.Errorf("too old (max age: %s)", options.MaxAge)
+	}
+	return nil
+}
+
+// reference describes where a disclosed claim is located in the payload of the issuer-signed JWT.
+type reference struct {
+	// path contains the claim names leading to the disclosed claim.
+	path []string
+	// parent contains the digest of the disclosure that contains this one, if any.
+	parent string
+}
+
+// process applies the disclosures to the payload of the issuer-signed JWT.
+// It returns the resulting claims and the location of each of the disclosures, by digest.
+func (s SDJWT) process() (map[string]any, map[string]reference, error) {
+	message, err := jws.ParseString(s.IssuerJWT)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid issuer-signed JWT: %w", err)
+	}
+	var payload map[string]any
+	if err = json.Unmarshal(message.Payload(), &payload); err != nil {
+		return nil, nil, fmt.Errorf("invalid issuer-signed JWT payload: %w", err)
+	}
+	if alg, ok := payload[sdAlgClaim]; ok && alg != HashAlgorithm {
+		return nil, nil, fmt.Errorf("unsupported %s: %v", sdAlgClaim, alg)
+	}
+	delete(payload, sdAlgClaim)
+
+	p := processor{
+		disclosures: make(map[string]Disclosure, len(s.Disclosures)),
+		references:  make(map[string]reference, len(s.Disclosures)),
+	}
+	for _, disclosure := range s.Disclosures {
+		if _, exists := p.disclosures[disclosure.Digest()]; exists {
+			return nil, nil, errors.New("duplicate disclosure")
+		}
+		p.disclosures[disclosure.Digest()] = disclosure
+	}
+	claims, err := p.object(payload, nil, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	for d, disclosure := range p.disclosures {
+		if _, referenced := p.references[d]; !referenced {
+			return nil, nil, fmt.Errorf("disclosure is not referenced by the SD-JWT (claim: '%s')", disclosure.Name)
+		}
+	}
+	return claims, p.references, nil
+}
+
+type processor struct {
+	disclosures map[string]Disclosure
+	references  map[string]reference
+}
+
+func (p *processor) value(value any, path []string, parent string) (any, error) {
+	switch typed := value.(type) {
+	case map[string]any:
+		return p.object(typed, path, parent)
+	case []any:
+		return p.array(typed, path, parent)
+	default:
+		return value, nil
+	}
+}
+
+func (p *processor) object(object map[string]any, path []string, parent string) (map[string]any, error) {
+	result := make(map[string]any, len(object))
+	for name, value := range object {
+		if name == sdClaim {
+			continue
+		}
+		processed, err := p.value(value, append(slices.Clone(path), name), parent)
+		if err != nil {
+			return nil, err
+		}
+		result[name] = processed
+	}
+	digests, ok := object[sdClaim]
+	if !ok {
+		return result, nil
+	}
+	digestList, ok := digests.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%s must be an array", sdClaim)
+	}
+	for _, digestValue := range digestList {
+		d, ok := digestValue.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s must contain strings", sdClaim)
+		}
+		disclosure, disclosed := p.disclosures[d]
+		if !disclosed {
+			// not disclosed or a decoy digest
+			continue
+		}
+		if _, referenced := p.references[d]; referenced {
+			return nil, errors.New("disclosure is referenced more than once")
+		}
+		if disclosure.Name == "" {
+			return nil, errors.New("array element disclosure referenced as object property")
+		}
+		if _, exists := result[disclosure.Name]; exists {
+			return nil, fmt.Errorf("disclosed claim '%s' already exists", disclosure.Name)
+		}
+		claimPath := append(slices.Clone(path), disclosure.Name)
+		p.references[d] = reference{path: claimPath, parent: parent}
+		processed, err := p.value(disclosure.Value, claimPath, d)
+		if err != nil {
+			return nil, err
+		}
+		result[disclosure.Name] = processed
+	}
+	return result, nil
+}
+
+func (p *processor) array(array []any, path []string, parent string) ([]any, error) {
+	result := make([]any, 0, len(array))
+	for _, element := range array {
+		if object, ok := element.(map[string]any); ok && len(object) == 1 && object[arrayElementClaim] != nil {
+			d, ok := object[arrayElementClaim].(string)
+			if !ok {
+				return nil, fmt.Errorf("'%s' must be a string", arrayElementClaim)
+			}
+			disclosure, disclosed := p.disclosures[d]
+			if !disclosed {
+				// not disclosed or a decoy digest: element is removed
+				continue
+			}
+			if _, referenced := p.references[d]; referenced {
+				return nil, errors.New("disclosure is referenced more than once")
+			}
+			if disclosure.Name != "" {
+				return nil, errors.New("object property disclosure referenced as array element")
+			}
+			p.references[d] = reference{path: path, parent: parent}
+			processed, err := p.value(disclosure.Value, path, d)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, processed)
+			continue
+		}
+		processed, err := p.value(element, path, parent)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, processed)
+	}
+	return result, nil
+}
+
+// conceal makes the claims at the given paths of the object selectively disclosable,
+// by replacing them with the digests of their disclosures. Paths may point to nested objects.
+// The prefix is used to report the full path of claims in errors.
+func conceal(object map[string]any, paths [][]string, prefix string) (map[string]any, []Disclosure, error) {
+	result := make(map[string]any, len(object))
+	for name, value := range object {
+		result[name] = value
+	}
+	concealed := make(map[string]bool)
+	nested := make(map[string][][]string)
+	for _, path := range paths {
+		if len(path) == 1 {
+			concealed[path[0]] = true
+		} else if len(path) > 1 {
+			nested[path[0]] = append(nested[path[0]], path[1:])
+		}
+	}
+	var disclosures []Disclosure
+	// conceal nested claims first, so they end up in the disclosure of their parent if that is concealed as well
+	for name, nestedPaths := range nested {
+		child, ok := result[name].(map[string]any)
+		if !ok {
+			return nil, nil, fmt.Errorf("claim '%s%s' is not an object", prefix, name)
+		}
+		concealedChild, childDisclosures, err := conceal(child, nestedPaths, prefix+name+".")
+		if err != nil {
+			return nil, nil, err
+		}
+		result[name] = concealedChild
+		disclosures = append(disclosures, childDisclosures...)
+	}
+	var digests []string
+	for name := range concealed {
+		value, ok := result[name]
+		if !ok {
+			return nil, nil, fmt.Errorf("claim '%s%s' not found", prefix, name)
+		}
+		disclosure, err := NewDisclosure(name, value)
+		if err != nil {
+			return nil, nil, err
+		}
+		delete(result, name)
+		disclosures = append(disclosures, *disclosure)
+		digests = append(digests, disclosure.Digest())
+	}
+	if len(digests) > 0 {
+		// sort digests, so their order doesn't reveal the order of the claims
+		slices.Sort(digests)
+		result[sdClaim] = digests
+	}
+	return result, disclosures, nil
+}
+
+func digest(input string) string {
+	sum := sha256.Sum256([]byte(input))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,434 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package sdjwt
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDisclosure(t *testing.T) {
+	t.Run("object property", func(t *testing.T) {
+		disclosure, err := NewDisclosure("name", "Alice")
+		require.NoError(t, err)
+
+		parsed, err := ParseDisclosure(disclosure.Encoded())
+
+		require.NoError(t, err)
+		assert.Equal(t, disclosure.Salt, parsed.Salt)
+		assert.Equal(t, "name", parsed.Name)
+		assert.Equal(t, "Alice", parsed.Value)
+		assert.Equal(t, disclosure.Digest(), parsed.Digest())
+	})
+	t.Run("array element", func(t *testing.T) {
+		disclosure, err := NewDisclosure("", "FR")
+		require.NoError(t, err)
+
+		parsed, err := ParseDisclosure(disclosure.Encoded())
+
+		require.NoError(t, err)
+		assert.Empty(t, parsed.Name)
+		assert.Equal(t, "FR", parsed.Value)
+	})
+	t.Run("random salt", func(t *testing.T) {
+		a, _ := NewDisclosure("name", "Alice")
+		b, _ := NewDisclosure("name", "Alice")
+
+		assert.NotEqual(t, a.Digest(), b.Digest())
+	})
+}
+
+func TestParseDisclosure(t *testing.T) {
+	encode := func(s string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(s))
+	}
+	t.Run("digest", func(t *testing.T) {
+		// example from the SD-JWT specification
+		disclosure, err := ParseDisclosure("WyI2cU1RdlJMNWhhaiIsICJmYW1pbHlfbmFtZSIsICJNw7ZiaXVzIl0")
+
+		require.NoError(t, err)
+		assert.Equal(t, "family_name", disclosure.Name)
+		assert.Equal(t, "Möbius", disclosure.Value)
+		assert.Equal(t, "uutlBuYeMDyjLLTpf6Jxi7yNkEF35jdyWMn9U7b_RYY", disclosure.Digest())
+	})
+	t.Run("error - invalid base64", func(t *testing.T) {
+		_, err := ParseDisclosure("%%")
+		assert.ErrorContains(t, err, "invalid disclosure: illegal base64 data")
+	})
+	t.Run("error - not an array", func(t *testing.T) {
+		_, err := ParseDisclosure(encode(`{}`))
+		assert.ErrorContains(t, err, "invalid disclosure: json")
+	})
+	t.Run("error - invalid number of elements", func(t *testing.T) {
+		_, err := ParseDisclosure(encode(`["salt"]`))
+		assert.EqualError(t, err, "invalid disclosure: expected 2 or 3 elements, got 1")
+	})
+	t.Run("error - salt is not a string", func(t *testing.T) {
+		_, err := ParseDisclosure(encode(`[1, "name", "value"]`))
+		assert.EqualError(t, err, "invalid disclosure: salt must be a string")
+	})
+	t.Run("error - name is not a string", func(t *testing.T) {
+		_, err := ParseDisclosure(encode(`["salt", 1, "value"]`))
+		assert.EqualError(t, err, "invalid disclosure: claim name must be a string")
+	})
+	t.Run("error - illegal name", func(t *testing.T) {
+		_, err := ParseDisclosure(encode(`["salt", "_sd", "value"]`))
+		assert.EqualError(t, err, "invalid disclosure: illegal claim name '_sd'")
+	})
+}
+
+func TestParse(t *testing.T) {
+	key := newTestKey(t)
+	sdJWT := createTestSDJWT(t, key, map[string]any{
+		"given_name":  "Alice",
+		"family_name": "Jones",
+	}, [][]string{{"given_name"}, {"family_name"}})
+
+	t.Run("without key binding", func(t *testing.T) {
+		parsed, err := Parse(sdJWT.String())
+
+		require.NoError(t, err)
+		assert.Equal(t, sdJWT.IssuerJWT, parsed.IssuerJWT)
+		assert.Len(t, parsed.Disclosures, 2)
+		assert.Empty(t, parsed.KeyBindingJWT)
+		assert.Equal(t, sdJWT.String(), parsed.String())
+	})
+	t.Run("with key binding", func(t *testing.T) {
+		withKB := *sdJWT
+		withKB.KeyBindingJWT = signKeyBinding(t, key, withKB, KeyBindingJWTType)
+
+		parsed, err := Parse(withKB.String())
+
+		require.NoError(t, err)
+		assert.Equal(t, withKB.KeyBindingJWT, parsed.KeyBindingJWT)
+		assert.Len(t, parsed.Disclosures, 2)
+	})
+	t.Run("error - no separator", func(t *testing.T) {
+		_, err := Parse(sdJWT.IssuerJWT)
+		assert.EqualError(t, err, "invalid SD-JWT: expected at least one separator")
+	})
+	t.Run("error - invalid issuer-signed JWT", func(t *testing.T) {
+		_, err := Parse("invalid~")
+		assert.ErrorContains(t, err, "invalid SD-JWT: invalid issuer-signed JWT")
+	})
+	t.Run("error - invalid disclosure", func(t *testing.T) {
+		_, err := Parse(sdJWT.IssuerJWT + "~%%~")
+		assert.ErrorContains(t, err, "invalid SD-JWT: invalid disclosure")
+	})
+}
+
+func TestSDJWT_Claims(t *testing.T) {
+	key := newTestKey(t)
+	claims := map[string]any{
+		"given_name": "Alice",
+		"address": map[string]any{
+			"street": "Main street",
+			"city":   "Amsterdam",
+		},
+	}
+	sdJWT := createTestSDJWT(t, key, claims, [][]string{{"given_name"}, {"address"}, {"address", "city"}})
+
+	t.Run("all disclosed", func(t *testing.T) {
+		result, err := sdJWT.Claims()
+
+		require.NoError(t, err)
+		assert.Equal(t, "Alice", result["given_name"])
+		assert.Equal(t, map[string]any{"street": "Main street", "city": "Amsterdam"}, result["address"])
+		assert.NotContains(t, result, sdClaim)
+		assert.NotContains(t, result, sdAlgClaim)
+	})
+	t.Run("none disclosed", func(t *testing.T) {
+		result, err := SDJWT{IssuerJWT: sdJWT.IssuerJWT}.Claims()
+
+		require.NoError(t, err)
+		assert.NotContains(t, result, "given_name")
+		assert.NotContains(t, result, "address")
+	})
+	t.Run("array elements", func(t *testing.T) {
+		first, _ := NewDisclosure("", "NL")
+		second, _ := NewDisclosure("", "DE")
+		token := signIssuerJWT(t, key, map[string]any{
+			"nationalities": []any{
+				map[string]any{arrayElementClaim: first.Digest()},
+				map[string]any{arrayElementClaim: second.Digest()},
+				"FR",
+			},
+		})
+
+		result, err := SDJWT{IssuerJWT: token, Disclosures: []Disclosure{*second}}.Claims()
+
+		require.NoError(t, err)
+		assert.Equal(t, []any{"DE", "FR"}, result["nationalities"])
+	})
+	t.Run("error - unreferenced disclosure", func(t *testing.T) {
+		unreferenced, _ := NewDisclosure("email", "alice@example.com")
+		invalid := *sdJWT
+		invalid.Disclosures = append(invalid.Disclosures, *unreferenced)
+
+		_, err := invalid.Claims()
+
+		assert.EqualError(t, err, "disclosure is not referenced by the SD-JWT (claim: 'email')")
+	})
+	t.Run("error - duplicate disclosure", func(t *testing.T) {
+		invalid := *sdJWT
+		invalid.Disclosures = append(invalid.Disclosures, sdJWT.Disclosures[0])
+
+		_, err := invalid.Claims()
+
+		assert.EqualError(t, err, "duplicate disclosure")
+	})
+	t.Run("error - unsupported _sd_alg", func(t *testing.T) {
+		token := signIssuerJWT(t, key, map[string]any{sdAlgClaim: "md5"})
+
+		_, err := SDJWT{IssuerJWT: token}.Claims()
+
+		assert.EqualError(t, err, "unsupported _sd_alg: md5")
+	})
+	t.Run("error - disclosed claim already exists", func(t *testing.T) {
+		disclosure, _ := NewDisclosure("given_name", "Bob")
+		token := signIssuerJWT(t, key, map[string]any{"given_name": "Alice", sdClaim: []string{disclosure.Digest()}})
+
+		_, err := SDJWT{IssuerJWT: token, Disclosures: []Disclosure{*disclosure}}.Claims()
+
+		assert.EqualError(t, err, "disclosed claim 'given_name' already exists")
+	})
+}
+
+func TestSDJWT_Select(t *testing.T) {
+	key := newTestKey(t)
+	claims := map[string]any{
+		"given_name": "Alice",
+		"address": map[string]any{
+			"street": "Main street",
+			"city":   "Amsterdam",
+		},
+	}
+	sdJWT := createTestSDJWT(t, key, claims, [][]string{{"given_name"}, {"address"}, {"address", "city"}})
+	sdJWT.KeyBindingJWT = signKeyBinding(t, key, *sdJWT, KeyBindingJWTType)
+
+	t.Run("nested claim includes its parent", func(t *testing.T) {
+		selected, err := sdJWT.Select(func(path []string) bool {
+			return strings.Join(path, ".") == "address.city"
+		})
+		require.NoError(t, err)
+
+		result, err := selected.Claims()
+
+		require.NoError(t, err)
+		assert.Len(t, selected.Disclosures, 2)
+		assert.Empty(t, selected.KeyBindingJWT)
+		assert.NotContains(t, result, "given_name")
+		assert.Equal(t, map[string]any{"street": "Main street", "city": "Amsterdam"}, result["address"])
+	})
+	t.Run("parent without nested claim", func(t *testing.T) {
+		selected, err := sdJWT.Select(func(path []string) bool {
+			return strings.Join(path, ".") == "address"
+		})
+		require.NoError(t, err)
+
+		result, err := selected.Claims()
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"street": "Main street"}, result["address"])
+	})
+	t.Run("nothing", func(t *testing.T) {
+		selected, err := sdJWT.Select(func(path []string) bool {
+			return false
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, selected.Disclosures)
+	})
+}
+
+func TestSDJWT_ValidateKeyBinding(t *testing.T) {
+	key := newTestKey(t)
+	sdJWT := createTestSDJWT(t, key, map[string]any{"given_name": "Alice"}, [][]string{{"given_name"}})
+	options := KeyBindingOptions{
+		Audience: []string{"https://verifier.example.com"},
+		Nonce:    "nonce",
+		MaxAge:   time.Minute,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		withKB := *sdJWT
+		withKB.KeyBindingJWT = signKeyBinding(t, key, withKB, KeyBindingJWTType)
+
+		token, err := withKB.ValidateKeyBinding(options)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"https://verifier.example.com"}, token.Audience())
+		nonce, _ := token.Get(nonceClaim)
+		assert.Equal(t, "nonce", nonce)
+	})
+	t.Run("error - no key binding", func(t *testing.T) {
+		_, err := sdJWT.ValidateKeyBinding(options)
+
+		assert.ErrorIs(t, err, ErrNoKeyBinding)
+	})
+	t.Run("error - invalid typ", func(t *testing.T) {
+		withKB := *sdJWT
+		withKB.KeyBindingJWT = signKeyBinding(t, key, withKB, "JWT")
+
+		_, err := withKB.ValidateKeyBinding(options)
+
+		assert.EqualError(t, err, "invalid Key Binding JWT: expected typ 'kb+jwt', got 'JWT'")
+	})
+	t.Run("error - disclosures changed", func(t *testing.T) {
+		withKB := *sdJWT
+		withKB.KeyBindingJWT = signKeyBinding(t, key, withKB, KeyBindingJWTType)
+		withKB.Disclosures = nil
+
+		_, err := withKB.ValidateKeyBinding(options)
+
+		assert.EqualError(t, err, "invalid Key Binding JWT: 'sd_hash' does not match SD-JWT")
+	})
+	t.Run("error - audience does not match", func(t *testing.T) {
+		withKB := *sdJWT
+		withKB.KeyBindingJWT = signKeyBinding(t, key, withKB, KeyBindingJWTType)
+		options := options
+		options.Audience = []string{"https://other.example.com"}
+
+		_, err := withKB.ValidateKeyBinding(options)
+
+		assert.EqualError(t, err, "invalid Key Binding JWT: 'aud' does not match presentation (aud=https://verifier.example.com)")
+	})
+	t.Run("error - missing audience", func(t *testing.T) {
+		withKB := *sdJWT
+		withKB.KeyBindingJWT = signKeyBindingClaims(t, key, withKB.KeyBindingClaims("", "nonce"))
+
+		_, err := withKB.ValidateKeyBinding(options)
+
+		assert.EqualError(t, err, "invalid Key Binding JWT: missing 'aud'")
+	})
+	t.Run("error - nonce does not match", func(t *testing.T) {
+		withKB := *sdJWT
+		withKB.KeyBindingJWT = signKeyBinding(t, key, withKB, KeyBindingJWTType)
+		options := options
+		options.Nonce = "other"
+
+		_, err := withKB.ValidateKeyBinding(options)
+
+		assert.EqualError(t, err, "invalid Key Binding JWT: 'nonce' does not match presentation")
+	})
+	t.Run("error - missing nonce", func(t *testing.T) {
+		withKB := *sdJWT
+		withKB.KeyBindingJWT = signKeyBindingClaims(t, key, withKB.KeyBindingClaims("https://verifier.example.com", ""))
+
+		_, err := withKB.ValidateKeyBinding(options)
+
+		assert.EqualError(t, err, "invalid Key Binding JWT: 'nonce' does not match presentation")
+	})
+	t.Run("error - too old", func(t *testing.T) {
+		withKB := *sdJWT
+		withKB.KeyBindingJWT = signKeyBinding(t, key, withKB, KeyBindingJWTType)
+		options := options
+		options.At = time.Now().Add(2 * time.Minute)
+
+		_, err := withKB.ValidateKeyBinding(options)
+
+		assert.EqualError(t, err, "invalid Key Binding JWT: too old (max age: 1m0s)")
+	})
+	t.Run("error - issued in the future", func(t *testing.T) {
+		withKB := *sdJWT
+		withKB.KeyBindingJWT = signKeyBinding(t, key, withKB, KeyBindingJWTType)
+		options := options
+		options.At = time.Now().Add(-time.Minute)
+
+		_, err := withKB.ValidateKeyBinding(options)
+
+		assert.EqualError(t, err, "invalid Key Binding JWT: 'iat' is in the future")
+	})
+	t.Run("error - missing iat", func(t *testing.T) {
+		withKB := *sdJWT
+		claims := withKB.KeyBindingClaims("https://verifier.example.com", "nonce")
+		delete(claims, jwt.IssuedAtKey)
+		withKB.KeyBindingJWT = signKeyBindingClaims(t, key, claims)
+
+		_, err := withKB.ValidateKeyBinding(options)
+
+		assert.EqualError(t, err, "invalid Key Binding JWT: missing 'iat'")
+	})
+}
+
+func Test_conceal(t *testing.T) {
+	t.Run("error - claim not found", func(t *testing.T) {
+		_, _, err := conceal(map[string]any{"address": map[string]any{}}, [][]string{{"address", "city"}}, "credentialSubject.")
+
+		assert.EqualError(t, err, "claim 'credentialSubject.address.city' not found")
+	})
+	t.Run("error - not an object", func(t *testing.T) {
+		_, _, err := conceal(map[string]any{"address": "Main street"}, [][]string{{"address", "city"}}, "")
+
+		assert.EqualError(t, err, "claim 'address' is not an object")
+	})
+}
+
+func newTestKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+func testSigner(key *ecdsa.PrivateKey) func(ctx context.Context, claims map[string]any, headers map[string]any) (string, error) {
+	return func(ctx context.Context, claims map[string]any, headers map[string]any) (string, error) {
+		headers[jws.KeyIDKey] = "did:example:issuer#1"
+		return crypto.SignJWT(audit.TestContext(), key, jwa.ES256, claims, headers)
+	}
+}
+
+func signIssuerJWT(t *testing.T, key *ecdsa.PrivateKey, claims map[string]any) string {
+	token, err := testSigner(key)(context.Background(), claims, map[string]any{jws.TypeKey: Format})
+	require.NoError(t, err)
+	return token
+}
+
+func createTestSDJWT(t *testing.T, key *ecdsa.PrivateKey, claims map[string]any, disclosable [][]string) *SDJWT {
+	concealed, disclosures, err := conceal(claims, disclosable, "")
+	require.NoError(t, err)
+	concealed[sdAlgClaim] = HashAlgorithm
+	return &SDJWT{IssuerJWT: signIssuerJWT(t, key, concealed), Disclosures: disclosures}
+}
+
+func signKeyBindingClaims(t *testing.T, key *ecdsa.PrivateKey, claims map[string]any) string {
+	token, err := testSigner(key)(context.Background(), claims, map[string]any{jws.TypeKey: KeyBindingJWTType})
+	require.NoError(t, err)
+	return token
+}
+
+func signKeyBinding(t *testing.T, key *ecdsa.PrivateKey, sdJWT SDJWT, typ string) string {
+	claims := sdJWT.KeyBindingClaims("https://verifier.example.com", "nonce")
+	token, err := testSigner(key)(context.Background(), claims, map[string]any{jws.TypeKey: typ})
+	require.NoError(t, err)
+	return token
+}
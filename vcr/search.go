@@ -21,18 +21,17 @@ package vcr
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/vcr/credential/store"
 	"github.com/nuts-foundation/nuts-node/vcr/log"
 	"github.com/sirupsen/logrus"
-	"reflect"
+	"gorm.io/gorm"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/nuts-foundation/go-did/vc"
-	"github.com/nuts-foundation/go-leia/v4"
 )
 
 const (
@@ -52,49 +51,45 @@ type SearchTerm struct {
 }
 
 func (c *vcr) Search(ctx context.Context, searchTerms []SearchTerm, allowUntrusted bool, resolveTime *time.Time) ([]vc.VerifiableCredential, error) {
-	query := leia.Query{}
-	var VCs = make([]vc.VerifiableCredential, 0)
-
-	for _, searchTerm := range searchTerms {
-		var scalar leia.Scalar
-		var err error
-		if searchTerm.Type != NotNil {
-			scalar, err = leia.ParseScalar(searchTerm.Value)
-			if err != nil {
-				return nil, fmt.Errorf("value type (value=%v, type=%s) not supported at %s", searchTerm.Value, reflect.TypeOf(searchTerm.Value), strings.Join(searchTerm.IRIPath, ", "))
-			}
-		}
-
-		switch searchTerm.Type {
-		case NotNil:
-			query = query.And(leia.NotNil(leia.NewIRIPath(searchTerm.IRIPath...)))
-		case Prefix:
-			query = query.And(leia.Prefix(leia.NewIRIPath(searchTerm.IRIPath...), scalar))
-		default:
-			fallthrough
-		case Exact:
-			query = query.And(leia.Eq(leia.NewIRIPath(searchTerm.IRIPath...), scalar))
-		}
+	VCs, err := c.credentialStore.search(ctx, searchTerms)
+	if err != nil {
+		return nil, err
 	}
+	return c.filterValid(ctx, VCs, allowUntrusted, resolveTime), nil
+}
 
-	docs, err := c.credentialCollection().Find(ctx, query)
+// notRevokedCondition excludes the credentials that have been revoked, so they're not counted in the total of a query.
+const notRevokedCondition = "NOT EXISTS (SELECT 1 FROM credential_revocation WHERE credential_revocation.subject_id = credential.id)"
+
+func (c *vcr) Query(ctx context.Context, query store.Query, allowUntrusted bool) ([]vc.VerifiableCredential, int, error) {
+	// the database filters, counts and paginates the credentials, only the credentials on the page are verified
+	db := c.storageClient.GetSQLDatabase().WithContext(ctx).Where(notRevokedCondition).Session(&gorm.Session{})
+	records, total, err := store.CredentialStore{}.Search(db, credentialRecord{}.TableName(), query)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	verifyErrors := make(map[string]int, 0)
-	for _, doc := range docs {
-		foundCredential := vc.VerifiableCredential{}
-		err = json.Unmarshal(doc, &foundCredential)
+	VCs := make([]vc.VerifiableCredential, 0, len(records))
+	for _, record := range records {
+		foundCredential, err := vc.ParseVerifiableCredential(record.Raw)
 		if err != nil {
-			return nil, fmt.Errorf("unable to parse credential from db: %w", err)
+			return nil, 0, fmt.Errorf("unable to parse credential from db (id=%s): %w", record.ID, err)
 		}
+		VCs = append(VCs, *foundCredential)
+	}
+	return c.filterValid(ctx, VCs, allowUntrusted, nil), total, nil
+}
 
-		if err = c.verifier.Verify(foundCredential, allowUntrusted, false, resolveTime); err == nil {
-			VCs = append(VCs, foundCredential)
+// filterValid returns the given credentials without the invalid ones, and without the untrusted ones unless allowUntrusted == true.
+func (c *vcr) filterValid(ctx context.Context, credentials []vc.VerifiableCredential, allowUntrusted bool, resolveTime *time.Time) []vc.VerifiableCredential {
+	result := make([]vc.VerifiableCredential, 0, len(credentials))
+	verifyErrors := make(map[string]int, 0)
+	for _, credential := range credentials {
+		if err := c.verifier.Verify(ctx, credential, allowUntrusted, false, resolveTime); err == nil {
+			result = append(result, credential)
 		} else {
 			log.Logger().
 				WithError(err).
-				WithField(core.LogFieldCredentialID, foundCredential.ID).
+				WithField(core.LogFieldCredentialID, credential.ID).
 				Trace("Encountered invalid VC, omitting from search results.")
 			verifyErrors[err.Error()]++
 		}
@@ -104,8 +99,7 @@ func (c *vcr) Search(ctx context.Context, searchTerms []SearchTerm, allowUntrust
 	if len(verifyErrors) > 0 && log.Logger().Level >= logrus.DebugLevel {
 		log.Logger().Debug(formatFilteredVCsLogMessage(verifyErrors))
 	}
-
-	return VCs, nil
+	return result
 }
 
 func formatFilteredVCsLogMessage(verifyErrors map[string]int) string {
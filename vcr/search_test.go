@@ -26,8 +26,8 @@ import (
 	"testing"
 	"time"
 
-	"github.com/nuts-foundation/go-leia/v4"
 	"github.com/nuts-foundation/nuts-node/jsonld"
+	"github.com/nuts-foundation/nuts-node/vcr/credential/store"
 	"github.com/nuts-foundation/nuts-node/vcr/types"
 	"github.com/nuts-foundation/nuts-node/vcr/verifier"
 	"github.com/stretchr/testify/assert"
@@ -51,8 +51,7 @@ func TestVCR_Search(t *testing.T) {
 		ctx := newMockContext(t2)
 
 		// add document
-		doc := []byte(jsonld.TestCredential)
-		err := ctx.vcr.credentialCollection().Add([]leia.Document{doc})
+		err := ctx.vcr.credentialStore.add(ctx.vcr.storageClient.GetSQLDatabase(), vc)
 		require.NoError(t, err)
 		return ctx
 	}
@@ -153,7 +152,7 @@ func TestVCR_Search(t *testing.T) {
 		ctx.vcr.Trust(vc.Type[0], vc.Issuer)
 		mockVerifier := verifier.NewMockVerifier(ctx.ctrl)
 		ctx.vcr.verifier = mockVerifier
-		mockVerifier.EXPECT().Verify(vc, true, false, gomock.Any()).Return(types.ErrRevoked)
+		mockVerifier.EXPECT().Verify(gomock.Any(), vc, true, false, gomock.Any()).Return(types.ErrRevoked)
 
 		creds, err := ctx.vcr.Search(reqCtx, prefixSearchTerms, true, nil)
 
@@ -172,3 +171,85 @@ func Test_formatFilteredVCsLogMessage(t *testing.T) {
 	msg := formatFilteredVCsLogMessage(input)
 	assert.Equal(t, "Filtered 13 invalid VCs from search results (more info on TRACE): 'EOF' (1 times), 'credential is revoked' (2 times), 'credential issuer is untrusted' (10 times)", msg)
 }
+
+func TestVCR_Query(t *testing.T) {
+	testVC := jsonld.TestVC()
+	query := store.Query{
+		Filter: store.Condition{Path: "credentialSubject.human.eyeColour", Operator: store.Prefix, Values: []string{"blue"}},
+	}
+	testInstance := func(t *testing.T) mockContext {
+		ctx := newMockContext(t)
+		require.NoError(t, ctx.vcr.credentialStore.add(ctx.vcr.storageClient.GetSQLDatabase(), testVC))
+		return ctx
+	}
+	reqCtx := context.Background()
+
+	t.Run("ok", func(t *testing.T) {
+		ctx := testInstance(t)
+		require.NoError(t, ctx.vcr.Trust(testVC.Type[0], testVC.Issuer))
+		require.NoError(t, ctx.vcr.Trust(testVC.Type[1], testVC.Issuer))
+
+		results, total, err := ctx.vcr.Query(reqCtx, query, false)
+
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, testVC.ID.String(), results[0].ID.String())
+		assert.Equal(t, 1, total)
+	})
+	t.Run("untrusted", func(t *testing.T) {
+		ctx := testInstance(t)
+
+		results, total, err := ctx.vcr.Query(reqCtx, query, false)
+
+		require.NoError(t, err)
+		assert.Empty(t, results)
+		// only the credentials on the page are verified, so the total includes untrusted credentials
+		assert.Equal(t, 1, total)
+	})
+	t.Run("revoked credentials aren't counted", func(t *testing.T) {
+		ctx := testInstance(t)
+		require.NoError(t, ctx.vcr.storageClient.GetSQLDatabase().
+			Exec("INSERT INTO credential_revocation (id, subject_id, issuer, raw) VALUES (?, ?, ?, ?)", "1", testVC.ID.String(), testVC.Issuer.String(), "{}").Error)
+
+		results, total, err := ctx.vcr.Query(reqCtx, query, true)
+
+		require.NoError(t, err)
+		assert.Empty(t, results)
+		assert.Equal(t, 0, total)
+	})
+	t.Run("allow untrusted", func(t *testing.T) {
+		ctx := testInstance(t)
+
+		results, _, err := ctx.vcr.Query(reqCtx, query, true)
+
+		require.NoError(t, err)
+		assert.Len(t, results, 1)
+	})
+	t.Run("pagination", func(t *testing.T) {
+		ctx := testInstance(t)
+
+		results, total, err := ctx.vcr.Query(reqCtx, store.Query{Filter: query.Filter, Offset: 1}, true)
+
+		require.NoError(t, err)
+		assert.Empty(t, results)
+		assert.Equal(t, 1, total)
+	})
+	t.Run("credentials of other modules aren't searched", func(t *testing.T) {
+		ctx := newMockContext(t)
+		_, err := store.CredentialStore{}.Store(ctx.vcr.storageClient.GetSQLDatabase(), testVC)
+		require.NoError(t, err)
+
+		results, total, err := ctx.vcr.Query(reqCtx, query, true)
+
+		require.NoError(t, err)
+		assert.Empty(t, results)
+		assert.Equal(t, 0, total)
+	})
+	t.Run("invalid query", func(t *testing.T) {
+		ctx := testInstance(t)
+
+		_, _, err := ctx.vcr.Query(reqCtx, store.Query{Filter: store.And{}}, false)
+
+		assert.Error(t, err)
+	})
+}
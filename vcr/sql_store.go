@@ -0,0 +1,355 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package vcr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/go-leia/v4"
+	"github.com/nuts-foundation/go-stoabs"
+	"github.com/nuts-foundation/nuts-node/jsonld"
+	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/nuts-foundation/nuts-node/vcr/credential/store"
+	"github.com/nuts-foundation/nuts-node/vcr/types"
+	"github.com/piprate/json-gold/ld"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	// maxTermPathLength is the maximum length of an IRI path in the vcr_credential_term table.
+	maxTermPathLength = 255
+	// maxTermValueLength is the maximum length of a value in the vcr_credential_term table.
+	maxTermValueLength = 500
+)
+
+// likeEscaper escapes the characters that have a special meaning in LIKE patterns, using '!' as escape character.
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_", "[", "![")
+
+var _ schema.Tabler = &credentialRecord{}
+var _ schema.Tabler = &credentialTermRecord{}
+
+// credentialRecord is a public credential received through the Nuts network.
+type credentialRecord struct {
+	ID         string                 `gorm:"primaryKey"`
+	Credential store.CredentialRecord `gorm:"foreignKey:ID;references:ID"`
+}
+
+func (c credentialRecord) TableName() string {
+	return "vcr_credential"
+}
+
+// credentialTermRecord is a value of a credential at a JSON-LD IRI path, used for searching.
+type credentialTermRecord struct {
+	CredentialID string
+	IRIPath      string `gorm:"column:iri_path"`
+	Value        string
+}
+
+func (c credentialTermRecord) TableName() string {
+	return "vcr_credential_term"
+}
+
+// termIndexer tokenizes and/or transforms values at an IRI path, as configured in the index configuration.
+type termIndexer struct {
+	tokenizer   func(string) []string
+	transformer func(string) string
+}
+
+// sqlCredentialStore stores the public credentials in the SQL database.
+// Values of the credentials are indexed on their (expanded) JSON-LD IRI path, so they can be searched using SearchTerms.
+type sqlCredentialStore struct {
+	db     *gorm.DB
+	reader jsonld.Reader
+	// indexers contains the tokenizers and transformers for IRI paths, keyed on the joined IRI path.
+	indexers map[string]termIndexer
+}
+
+func newSQLCredentialStore(db *gorm.DB, documentLoader ld.DocumentLoader, configs []indexConfig) (*sqlCredentialStore, error) {
+	indexers := make(map[string]termIndexer)
+	for _, config := range configs {
+		for _, index := range config.Indices {
+			for _, part := range index.Parts {
+				var indexer termIndexer
+				if part.Tokenizer != nil {
+					switch strings.ToLower(*part.Tokenizer) {
+					case "whitespaceorexact":
+						indexer.tokenizer = whitespaceOrExactTokenizer
+					case "whitespace":
+						indexer.tokenizer = strings.Fields
+					default:
+						return nil, fmt.Errorf("unknown tokenizer %s for %s", *part.Tokenizer, index.Name)
+					}
+				}
+				if part.Transformer != nil {
+					switch strings.ToLower(*part.Transformer) {
+					case "cologne":
+						indexer.transformer = CologneTransformer
+					case "lowercase":
+						indexer.transformer = strings.ToLower
+					default:
+						return nil, fmt.Errorf("unknown transformer %s for %s", *part.Transformer, index.Name)
+					}
+				}
+				if indexer.tokenizer != nil || indexer.transformer != nil {
+					indexers[termPath(part.IRIPath)] = indexer
+				}
+			}
+		}
+	}
+	return &sqlCredentialStore{
+		db: db,
+		reader: jsonld.Reader{
+			DocumentLoader:           documentLoader,
+			AllowUndefinedProperties: true,
+		},
+		indexers: indexers,
+	}, nil
+}
+
+func whitespaceOrExactTokenizer(text string) (tokens []string) {
+	tokens = strings.Fields(text)
+	tokens = append(tokens, text)
+
+	return
+}
+
+// termPath joins the IRIs of a path into the format used in the vcr_credential_term table.
+func termPath(iriPath []string) string {
+	return strings.Join(iriPath, " ")
+}
+
+// add stores the credential and indexes its values. It does nothing if the credential is already stored.
+func (s sqlCredentialStore) add(db *gorm.DB, credential vc.VerifiableCredential) error {
+	if credential.ID == nil {
+		return errors.New("credential must have an ID")
+	}
+	if credential.Raw() == "" {
+		// credential wasn't parsed (but constructed), make sure it's stored with its raw JSON
+		data, _ := json.Marshal(credential)
+		parsed, err := vc.ParseVerifiableCredential(string(data))
+		if err != nil {
+			return err
+		}
+		credential = *parsed
+	}
+	terms, err := s.terms(credential)
+	if err != nil {
+		return fmt.Errorf("unable to index credential (id=%s): %w", credential.ID, err)
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&credentialRecord{}).Where("id = ?", credential.ID.String()).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+		if _, err := (store.CredentialStore{}).Store(tx, credential); err != nil {
+			return err
+		}
+		if err := tx.Create(&credentialRecord{ID: credential.ID.String()}).Error; err != nil {
+			return err
+		}
+		if len(terms) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(terms, 100).Error
+	})
+}
+
+// terms expands the credential and returns the (tokenized and transformed) values at every IRI path.
+func (s sqlCredentialStore) terms(credential vc.VerifiableCredential) ([]credentialTermRecord, error) {
+	expanded, err := s.reader.Read(credential)
+	if err != nil {
+		return nil, err
+	}
+	var result []credentialTermRecord
+	seen := make(map[credentialTermRecord]bool)
+	collectValues([]interface{}(expanded), nil, func(iriPath []string, value string) {
+		path := termPath(iriPath)
+		if len(path) > maxTermPathLength {
+			return
+		}
+		values := []string{value}
+		indexer := s.indexers[path]
+		if indexer.tokenizer != nil {
+			values = indexer.tokenizer(value)
+		}
+		for _, curr := range values {
+			if indexer.transformer != nil {
+				curr = indexer.transformer(curr)
+			}
+			term := credentialTermRecord{CredentialID: credential.ID.String(), IRIPath: path, Value: curr}
+			// values that don't fit the column can't be searched for
+			if len(curr) > maxTermValueLength || seen[term] {
+				continue
+			}
+			seen[term] = true
+			result = append(result, term)
+		}
+	})
+	return result, nil
+}
+
+// collectValues traverses an expanded JSON-LD document and calls fn for every @id, @value and @type value.
+func collectValues(document interface{}, iriPath []string, fn func(iriPath []string, value string)) {
+	switch typed := document.(type) {
+	case []interface{}:
+		for _, sub := range typed {
+			collectValues(sub, iriPath, fn)
+		}
+	case map[string]interface{}:
+		if value, ok := typed["@value"]; ok {
+			collectValues(value, iriPath, fn)
+			return
+		}
+		if list, ok := typed["@list"]; ok {
+			collectValues(list, iriPath, fn)
+			return
+		}
+		for key, value := range typed {
+			if key == "@id" {
+				collectValues(value, iriPath, fn)
+				continue
+			}
+			collectValues(value, append(iriPath[:len(iriPath):len(iriPath)], key), fn)
+		}
+	default:
+		if value, ok := scalarString(typed); ok {
+			fn(iriPath, value)
+		}
+	}
+}
+
+// scalarString returns the string representation of a JSON scalar value.
+func scalarString(value interface{}) (string, bool) {
+	switch typed := value.(type) {
+	case string:
+		return typed, true
+	case bool:
+		return strconv.FormatBool(typed), true
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64), true
+	default:
+		return "", false
+	}
+}
+
+// find returns the credential with the given ID, or types.ErrNotFound if it isn't stored.
+func (s sqlCredentialStore) find(ctx context.Context, id string) (*vc.VerifiableCredential, error) {
+	var record credentialRecord
+	err := s.db.WithContext(ctx).Preload("Credential").First(&record, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, types.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseCredentialRecord(record)
+}
+
+// search returns the credentials that match all given search terms.
+func (s sqlCredentialStore) search(ctx context.Context, searchTerms []SearchTerm) ([]vc.VerifiableCredential, error) {
+	db := s.db.WithContext(ctx)
+	stmt := db.Model(&credentialRecord{}).Preload("Credential")
+	for _, searchTerm := range searchTerms {
+		path := termPath(searchTerm.IRIPath)
+		condition := db.Table("vcr_credential_term").Select("1").
+			Where("vcr_credential_term.credential_id = vcr_credential.id AND vcr_credential_term.iri_path = ?", path)
+		if searchTerm.Type != NotNil {
+			value, ok := scalarString(searchTerm.Value)
+			if !ok {
+				return nil, fmt.Errorf("value type (value=%v, type=%s) not supported at %s", searchTerm.Value, reflect.TypeOf(searchTerm.Value), strings.Join(searchTerm.IRIPath, ", "))
+			}
+			if transformer := s.indexers[path].transformer; transformer != nil {
+				value = transformer(value)
+			}
+			if searchTerm.Type == Prefix {
+				condition = condition.Where("vcr_credential_term.value LIKE ? ESCAPE '!'", likeEscaper.Replace(value)+"%")
+			} else {
+				condition = condition.Where("vcr_credential_term.value = ?", value)
+			}
+		}
+		stmt = stmt.Where("EXISTS (?)", condition)
+	}
+	var records []credentialRecord
+	if err := stmt.Order("vcr_credential.id").Find(&records).Error; err != nil {
+		return nil, err
+	}
+	result := make([]vc.VerifiableCredential, 0, len(records))
+	for _, record := range records {
+		credential, err := parseCredentialRecord(record)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *credential)
+	}
+	return result, nil
+}
+
+func parseCredentialRecord(record credentialRecord) (*vc.VerifiableCredential, error) {
+	credential, err := vc.ParseVerifiableCredential(record.Credential.Raw)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse credential from db (id=%s): %w", record.ID, err)
+	}
+	return credential, nil
+}
+
+// issuers returns the distinct issuers of the stored credentials.
+func (s sqlCredentialStore) issuers() ([]string, error) {
+	var result []string
+	err := s.db.Model(&store.CredentialRecord{}).
+		Joins("inner join vcr_credential ON vcr_credential.id = credential.id").
+		Distinct().
+		Order("credential.issuer").
+		Pluck("credential.issuer", &result).Error
+	return result, err
+}
+
+// count returns the number of stored credentials.
+func (s sqlCredentialStore) count() (int, error) {
+	var result int64
+	err := s.db.Model(&credentialRecord{}).Count(&result).Error
+	return int(result), err
+}
+
+// migrateLeiaStore copies the credentials of the (legacy) Leia credential store to the SQL database, once.
+// Credentials that can't be stored in SQL (e.g. because they're invalid) are skipped.
+func (s sqlCredentialStore) migrateLeiaStore(dbPath string, backupStore stoabs.KVStore) error {
+	config := storage.LeiaBackupConfiguration{
+		CollectionName: "credentials",
+		CollectionType: leia.JSONCollection,
+		BackupShelf:    credentialsBackupShelf,
+		SearchQuery:    leia.NewJSONPath("id"),
+	}
+	return storage.MigrateLeiaCollection(s.db, "vcr_credentials", dbPath, backupStore, config, func(tx *gorm.DB, doc leia.Document) error {
+		credential, err := vc.ParseVerifiableCredential(string(doc))
+		if err != nil {
+			return err
+		}
+		return s.add(tx, *credential)
+	})
+}
@@ -0,0 +1,193 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package vcr
+
+import (
+	"context"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/go-leia/v4"
+	"github.com/nuts-foundation/nuts-node/jsonld"
+	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/nuts-foundation/nuts-node/test/io"
+	"github.com/nuts-foundation/nuts-node/vcr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"path"
+	"strings"
+	"testing"
+)
+
+func Test_sqlCredentialStore_add(t *testing.T) {
+	credential, _ := vc.ParseVerifiableCredential(jsonld.TestOrganizationCredential)
+
+	t.Run("ok", func(t *testing.T) {
+		sut := newTestSQLCredentialStore(t)
+
+		err := sut.add(sut.db, *credential)
+
+		require.NoError(t, err)
+		var terms []credentialTermRecord
+		require.NoError(t, sut.db.Find(&terms, "credential_id = ?", credential.ID.String()).Error)
+		assert.Contains(t, terms, credentialTermRecord{CredentialID: credential.ID.String(), IRIPath: termPath(jsonld.CredentialIssuerPath), Value: credential.Issuer.String()})
+		// name is tokenized and transformed using the cologne phonetic algorithm
+		assert.Contains(t, terms, credentialTermRecord{CredentialID: credential.ID.String(), IRIPath: termPath(jsonld.OrganizationNamePath), Value: CologneTransformer("CareBears")})
+	})
+	t.Run("already exists", func(t *testing.T) {
+		sut := newTestSQLCredentialStore(t)
+		require.NoError(t, sut.add(sut.db, *credential))
+
+		err := sut.add(sut.db, *credential)
+
+		require.NoError(t, err)
+		count, _ := sut.count()
+		assert.Equal(t, 1, count)
+	})
+	t.Run("without ID", func(t *testing.T) {
+		sut := newTestSQLCredentialStore(t)
+
+		err := sut.add(sut.db, vc.VerifiableCredential{})
+
+		assert.EqualError(t, err, "credential must have an ID")
+	})
+}
+
+func Test_sqlCredentialStore_search(t *testing.T) {
+	sut := newTestSQLCredentialStore(t)
+	credential, _ := vc.ParseVerifiableCredential(jsonld.TestOrganizationCredential)
+	require.NoError(t, sut.add(sut.db, *credential))
+	other, _ := vc.ParseVerifiableCredential(strings.ReplaceAll(strings.ReplaceAll(jsonld.TestOrganizationCredential, "CareBears", "Zorggroep Nootjes"), "#d2aa8189", "#other"))
+	require.NoError(t, sut.add(sut.db, *other))
+	ctx := context.Background()
+
+	t.Run("exact", func(t *testing.T) {
+		results, err := sut.search(ctx, []SearchTerm{
+			{IRIPath: jsonld.CredentialIssuerPath, Value: credential.Issuer.String(), Type: Exact},
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, results, 2)
+	})
+	t.Run("prefix on phonetic representation", func(t *testing.T) {
+		results, err := sut.search(ctx, []SearchTerm{
+			{IRIPath: jsonld.OrganizationNamePath, Value: "notjes", Type: Prefix},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, other.ID, results[0].ID)
+	})
+	t.Run("not nil", func(t *testing.T) {
+		results, err := sut.search(ctx, []SearchTerm{
+			{IRIPath: jsonld.OrganizationNamePath, Value: "carebears", Type: Exact},
+			{IRIPath: jsonld.OrganizationCityPath, Type: NotNil},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, credential.ID, results[0].ID)
+	})
+	t.Run("type", func(t *testing.T) {
+		results, err := sut.search(ctx, []SearchTerm{
+			{IRIPath: []string{"@type"}, Value: "https://nuts.nl/credentials/v1#NutsOrganizationCredential"},
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, results, 2)
+	})
+	t.Run("no match", func(t *testing.T) {
+		results, err := sut.search(ctx, []SearchTerm{
+			{IRIPath: jsonld.CredentialIssuerPath, Value: "did:nuts:other"},
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	})
+	t.Run("unsupported value type", func(t *testing.T) {
+		_, err := sut.search(ctx, []SearchTerm{
+			{IRIPath: jsonld.CredentialIssuerPath, Value: []string{"a"}},
+		})
+
+		assert.ErrorContains(t, err, "value type (value=[a], type=[]string) not supported")
+	})
+}
+
+func Test_sqlCredentialStore_find(t *testing.T) {
+	sut := newTestSQLCredentialStore(t)
+	credential, _ := vc.ParseVerifiableCredential(jsonld.TestOrganizationCredential)
+	require.NoError(t, sut.add(sut.db, *credential))
+
+	t.Run("found", func(t *testing.T) {
+		result, err := sut.find(context.Background(), credential.ID.String())
+
+		require.NoError(t, err)
+		assert.Equal(t, credential.ID, result.ID)
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, err := sut.find(context.Background(), "did:nuts:other#1")
+
+		assert.ErrorIs(t, err, types.ErrNotFound)
+	})
+}
+
+func Test_sqlCredentialStore_issuers(t *testing.T) {
+	sut := newTestSQLCredentialStore(t)
+	credential, _ := vc.ParseVerifiableCredential(jsonld.TestOrganizationCredential)
+	require.NoError(t, sut.add(sut.db, *credential))
+	other, _ := vc.ParseVerifiableCredential(strings.ReplaceAll(jsonld.TestOrganizationCredential, "#d2aa8189", "#other"))
+	require.NoError(t, sut.add(sut.db, *other))
+
+	issuers, err := sut.issuers()
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{credential.Issuer.String()}, issuers)
+}
+
+func TestVcr_migrateLeiaStore(t *testing.T) {
+	testVC, _ := vc.ParseVerifiableCredential(jsonld.TestOrganizationCredential)
+	testDir := io.TestDirectory(t)
+	leiaStore, err := leia.NewStore(path.Join(testDir, "vcr", "credentials.db"))
+	require.NoError(t, err)
+	err = leiaStore.Collection(leia.JSONCollection, "credentials").Add([]leia.Document{[]byte(jsonld.TestOrganizationCredential)})
+	require.NoError(t, err)
+	require.NoError(t, leiaStore.Close())
+
+	instance := NewTestVCRInstanceInDir(t, testDir)
+
+	result, err := instance.find(*testVC.ID)
+	require.NoError(t, err)
+	assert.Equal(t, testVC.ID, result.ID)
+	results, err := instance.credentialStore.search(context.Background(), []SearchTerm{
+		{IRIPath: jsonld.OrganizationNamePath, Value: "CareBears"},
+	})
+	require.NoError(t, err)
+	assert.Len(t, results, 1)
+}
+
+func newTestSQLCredentialStore(t *testing.T) *sqlCredentialStore {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	t.Cleanup(func() {
+		_ = storageEngine.Shutdown()
+	})
+	configs, err := (&vcr{}).loadJSONLDConfig()
+	require.NoError(t, err)
+	result, err := newSQLCredentialStore(storageEngine.GetSQLDatabase(), jsonld.NewTestJSONLDManager(t).DocumentLoader(), configs)
+	require.NoError(t, err)
+	return result
+}
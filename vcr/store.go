@@ -23,7 +23,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"github.com/nuts-foundation/go-leia/v4"
 	"github.com/nuts-foundation/nuts-node/core"
 	"reflect"
 	"time"
@@ -94,6 +93,5 @@ func (c *vcr) writeCredential(subject vc.VerifiableCredential) error {
 		return fmt.Errorf("unable to write credential to wallet (id=%s): %w", subject.ID, err)
 	}
 
-	doc, _ := json.Marshal(subject)
-	return c.credentialCollection().Add([]leia.Document{doc})
+	return c.credentialStore.add(c.storageClient.GetSQLDatabase(), subject)
 }
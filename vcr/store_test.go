@@ -22,9 +22,10 @@ package vcr
 import (
 	"context"
 	"crypto/ecdsa"
-	"crypto/sha1"
 	"encoding/json"
+	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/core/to"
 	"github.com/nuts-foundation/nuts-node/crypto/storage/spi"
 	"github.com/nuts-foundation/nuts-node/vcr/test"
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
@@ -135,31 +136,33 @@ func TestStore_writeCredential(t *testing.T) {
 	// load VC
 	target := test.ValidNutsOrganizationCredential(t)
 
-	t.Run("ok - stored in JSON-LD collection", func(t *testing.T) {
+	t.Run("ok - stored in SQL credential store", func(t *testing.T) {
 		ctx := newMockContext(t)
 		ctx.documentOwner.EXPECT().IsOwner(gomock.Any(), gomock.Any()).Return(false, nil)
-		vcBytes, _ := json.Marshal(target)
-		ref := sha1.Sum(vcBytes)
 
 		err := ctx.vcr.writeCredential(target)
 
 		require.NoError(t, err)
-		doc, err := ctx.vcr.credentialCollection().Get(ref[:])
-		assert.NoError(t, err)
-		assert.NotNil(t, doc)
+		stored, err := ctx.vcr.credentialStore.find(context.Background(), target.ID.String())
+		require.NoError(t, err)
+		assert.Equal(t, target.ID, stored.ID)
 	})
 
 	t.Run("ok - accepts credentials without custom type", func(t *testing.T) {
 		ctx := newMockContext(t)
-		vc := vc.VerifiableCredential{}
-		vcBytes, _ := json.Marshal(vc)
-		ref := sha1.Sum(vcBytes)
+		ctx.documentOwner.EXPECT().IsOwner(gomock.Any(), gomock.Any()).Return(false, nil)
+		credential := vc.VerifiableCredential{
+			Context:           []ssi.URI{vc.VCContextV1URI()},
+			ID:                to.Ptr(ssi.MustParseURI("did:nuts:issuer#1")),
+			Type:              []ssi.URI{vc.VerifiableCredentialTypeV1URI()},
+			Issuer:            ssi.MustParseURI("did:nuts:issuer"),
+			CredentialSubject: []interface{}{map[string]interface{}{"id": "did:nuts:subject"}},
+		}
 
-		err := ctx.vcr.writeCredential(vc)
+		err := ctx.vcr.writeCredential(credential)
 
 		require.NoError(t, err)
-		doc, err := ctx.vcr.credentialCollection().Get(ref[:])
+		_, err = ctx.vcr.credentialStore.find(context.Background(), credential.ID.String())
 		assert.NoError(t, err)
-		assert.NotNil(t, doc)
 	})
 }
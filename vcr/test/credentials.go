@@ -20,6 +20,7 @@
 package test
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
@@ -27,6 +28,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"github.com/google/uuid"
 	"github.com/lestrrat-go/jwx/v2/cert"
 	"github.com/lestrrat-go/jwx/v2/jwa"
 	"github.com/lestrrat-go/jwx/v2/jws"
@@ -34,6 +36,7 @@ import (
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/nuts-node/test/pki"
 	"github.com/nuts-foundation/nuts-node/vcr/assets"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"github.com/stretchr/testify/require"
 	"testing"
 	"time"
@@ -106,6 +109,47 @@ func JWTNutsOrganizationCredential(t *testing.T, subjectID did.DID) vc.Verifiabl
 	return *jwtVC
 }
 
+// SDJWTNutsOrganizationCredential returns an SD-JWT VC (enveloped in an EnvelopedVerifiableCredential) for the given subject,
+// of which the organization and its name and city are selectively disclosable.
+func SDJWTNutsOrganizationCredential(t *testing.T, subjectID did.DID) vc.VerifiableCredential {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	issuerID := ssi.MustParseURI("did:web:example.com:iam:issuer")
+	credentialID := ssi.MustParseURI(issuerID.String() + "#" + uuid.NewString())
+	template := vc.VerifiableCredential{
+		Context:      []ssi.URI{vc.VCContextV1URI(), ssi.MustParseURI("https://nuts.nl/credentials/v1")},
+		ID:           &credentialID,
+		Type:         []ssi.URI{vc.VerifiableCredentialTypeV1URI(), ssi.MustParseURI("NutsOrganizationCredential")},
+		Issuer:       issuerID,
+		IssuanceDate: time.Now(),
+		CredentialSubject: []interface{}{
+			map[string]interface{}{
+				"id": subjectID.String(),
+				"organization": map[string]interface{}{
+					"city": "IJbergen",
+					"name": "care",
+				},
+			},
+		},
+	}
+	sdJWT, err := sdjwt.Create(context.Background(), template, []string{"organization", "organization.city", "organization.name"}, func(_ context.Context, claims map[string]interface{}, headers map[string]interface{}) (string, error) {
+		token := jwt.New()
+		for key, value := range claims {
+			require.NoError(t, token.Set(key, value))
+		}
+		protectedHeaders := jws.NewHeaders()
+		for key, value := range headers {
+			require.NoError(t, protectedHeaders.Set(key, value))
+		}
+		signed, err := jwt.Sign(token, jwt.WithKey(jwa.ES256, privateKey, jws.WithProtectedHeaders(protectedHeaders)))
+		return string(signed), err
+	})
+	require.NoError(t, err)
+	result, err := sdJWT.Envelope()
+	require.NoError(t, err)
+	return *result
+}
+
 func ValidStatusList2021Credential(t testing.TB) vc.VerifiableCredential {
 	if t == nil {
 		panic("can only be used in tests")
@@ -133,6 +177,22 @@ func ValidStatusList2021Credential(t testing.TB) vc.VerifiableCredential {
 	}
 }
 
+// ValidBitstringStatusListCredential returns a BitstringStatusListCredential with the same bitstring as ValidStatusList2021Credential.
+func ValidBitstringStatusListCredential(t testing.TB) vc.VerifiableCredential {
+	cred := ValidStatusList2021Credential(t)
+	cred.Context = []ssi.URI{vc.VCContextV1URI(), ssi.MustParseURI("https://www.w3.org/ns/credentials/status/v1")}
+	cred.Type = []ssi.URI{vc.VerifiableCredentialTypeV1URI(), ssi.MustParseURI("BitstringStatusListCredential")}
+	cred.CredentialSubject = []any{
+		map[string]any{
+			"id":            "https://example-com/status/3#list",
+			"type":          "BitstringStatusList",
+			"statusPurpose": "revocation",
+			"encodedList":   "uH4sIAAAAAAAA_-zAsQAAAAACsNDypwqjZ2sAAAAAAAAAAAAAAAAAAACAtwUAAP__NxdfzQBAAAA", // multibase prefix 'u', has bit 1 set to true
+		},
+	}
+	return cred
+}
+
 type credentialOption func(*jwt.Builder) *jwt.Builder
 
 func ValidX509Credential(t *testing.T, options ...credentialOption) vc.VerifiableCredential {
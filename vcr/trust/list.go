@@ -0,0 +1,192 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package trust
+
+import (
+	"context"
+	crypt "crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/vcr/log"
+	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+)
+
+// defaultListRefreshInterval is used when a trust list does not specify a refresh interval.
+const defaultListRefreshInterval = time.Hour
+
+// maxListSize is the maximum size of a remote trust list in bytes.
+const maxListSize = 10 * 1024 * 1024
+
+// ListConfig configures a remote trust list: a JWT signed by a trusted DID, containing trust rules in the 'rules' claim.
+type ListConfig struct {
+	// Name identifies the trust list in trust decisions.
+	Name string `yaml:"name"`
+	// URL is the location the trust list is downloaded from.
+	URL string `yaml:"url"`
+	// Signer is the DID that must have signed the trust list (using a key from its assertionMethod).
+	Signer string `yaml:"signer"`
+	// RefreshInterval specifies how often the trust list is downloaded. Defaults to 1 hour.
+	RefreshInterval time.Duration `yaml:"refreshInterval"`
+	// CredentialTypes contains the credential types the trust list may trust issuers for.
+	// Rules of the list only apply to these types, also if they list other types or none at all.
+	CredentialTypes []string `yaml:"credentialTypes"`
+}
+
+// remoteList contains the rules of a downloaded trust list.
+type remoteList struct {
+	rules []Rule
+	// expires contains the value of the 'exp' claim of the trust list, if present.
+	expires *time.Time
+}
+
+func (l ListConfig) validate() error {
+	if l.Name == "" || l.URL == "" || l.Signer == "" {
+		return errors.New("trust list must have a name, url and signer")
+	}
+	if _, err := did.ParseDID(l.Signer); err != nil {
+		return fmt.Errorf("trust list '%s': invalid signer: %w", l.Name, err)
+	}
+	if len(l.CredentialTypes) == 0 {
+		return fmt.Errorf("trust list '%s': must specify credentialTypes", l.Name)
+	}
+	return nil
+}
+
+// StartRefreshing downloads the configured remote trust lists in the background and keeps refreshing them until the context is cancelled.
+// The goroutines are added to the given WaitGroup, so the caller can wait for them to stop.
+// If a trust list can't be downloaded or verified, the last successfully downloaded version is kept.
+func (tc *Config) StartRefreshing(ctx context.Context, routines *sync.WaitGroup, httpClient core.HTTPRequestDoer, keyResolver resolver.KeyResolver) {
+	for _, list := range tc.lists {
+		routines.Add(1)
+		go func(list ListConfig) {
+			defer routines.Done()
+			interval := list.RefreshInterval
+			if interval <= 0 {
+				interval = defaultListRefreshInterval
+			}
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				if err := tc.refreshList(ctx, list, httpClient, keyResolver); err != nil {
+					log.Logger().
+						WithError(err).
+						WithField("trustList", list.Name).
+						Warn("Unable to refresh trust list")
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+			}
+		}(list)
+	}
+}
+
+// refreshList downloads and verifies a remote trust list, and replaces the rules of the list.
+func (tc *Config) refreshList(ctx context.Context, list ListConfig, httpClient core.HTTPRequestDoer, keyResolver resolver.KeyResolver) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, list.URL, nil)
+	if err != nil {
+		return err
+	}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected HTTP response status: %d", response.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(response.Body, maxListSize))
+	if err != nil {
+		return err
+	}
+	result, err := parseList(list, string(data), keyResolver)
+	if err != nil {
+		return err
+	}
+	tc.rulesMutex.Lock()
+	defer tc.rulesMutex.Unlock()
+	tc.remoteLists[list.Name] = *result
+	log.Logger().
+		WithField("trustList", list.Name).
+		Debugf("Refreshed trust list (%d rules)", len(result.rules))
+	return nil
+}
+
+// parseList verifies the signature of a trust list and returns its rules.
+func parseList(list ListConfig, data string, keyResolver resolver.KeyResolver) (*remoteList, error) {
+	token, err := crypto.ParseJWT(data, func(kid string) (crypt.PublicKey, error) {
+		keyID, err := did.ParseDIDURL(kid)
+		if err != nil {
+			return nil, fmt.Errorf("invalid kid: %w", err)
+		}
+		if keyID.DID.String() != list.Signer {
+			return nil, fmt.Errorf("trust list is not signed by %s", list.Signer)
+		}
+		return keyResolver.ResolveKeyByID(kid, nil, resolver.AssertionMethod)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid trust list: %w", err)
+	}
+	rawRules, _ := token.Get("rules")
+	rulesJSON, _ := json.Marshal(rawRules)
+	var rules []Rule
+	if err := json.Unmarshal(rulesJSON, &rules); err != nil {
+		return nil, fmt.Errorf("invalid trust list rules: %w", err)
+	}
+	result := &remoteList{}
+	for _, rule := range rules {
+		if err := rule.init(); err != nil {
+			return nil, err
+		}
+		// a DID method alone trusts any issuer that can create a DID of that method
+		if rule.Issuer.DID == "" && rule.Issuer.X509 == nil {
+			return nil, fmt.Errorf("trust rule '%s': issuer in a trust list must specify did or x509", rule.Name)
+		}
+		// limit the rule to the credential types the list is configured for
+		if len(rule.CredentialTypes) == 0 {
+			rule.CredentialTypes = list.CredentialTypes
+		} else {
+			rule.CredentialTypes = slices.DeleteFunc(rule.CredentialTypes, func(credentialType string) bool {
+				return !slices.Contains(list.CredentialTypes, credentialType)
+			})
+			if len(rule.CredentialTypes) == 0 {
+				continue
+			}
+		}
+		rule.Name = list.Name + "/" + rule.Name
+		result.rules = append(result.rules, rule)
+	}
+	if !token.Expiration().IsZero() {
+		expires := token.Expiration()
+		result.expires = &expires
+	}
+	return result, nil
+}
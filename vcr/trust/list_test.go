@@ -0,0 +1,186 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package trust
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/crypto"
+	http2 "github.com/nuts-foundation/nuts-node/test/http"
+	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const listSigner = "did:web:example.com:trustlist"
+
+func TestConfig_refreshList(t *testing.T) {
+	key := crypto.NewTestKey(listSigner + "#0")
+	list := ListConfig{Name: "national", Signer: listSigner, CredentialTypes: []string{"NutsUraCredential"}}
+	rules := []interface{}{
+		map[string]interface{}{
+			"name":            "hospitals",
+			"credentialTypes": []string{"NutsUraCredential"},
+			"issuer":          map[string]interface{}{"did": "did:web:example.org"},
+		},
+	}
+	signList := func(t *testing.T, key *crypto.TestKey, claims map[string]interface{}) string {
+		token, err := crypto.SignJWT(audit.TestContext(), key.Signer(), jwa.ES256, claims, map[string]interface{}{"kid": key.KID})
+		require.NoError(t, err)
+		return token
+	}
+	setup := func(t *testing.T, responseData string) (*Config, ListConfig, *resolver.MockKeyResolver) {
+		server := httptest.NewServer(&http2.Handler{StatusCode: http.StatusOK, ResponseData: responseData})
+		t.Cleanup(server.Close)
+		list := list
+		list.URL = server.URL
+		tc := NewConfig("")
+		tc.lists = []ListConfig{list}
+		keyResolver := resolver.NewMockKeyResolver(gomock.NewController(t))
+		return tc, list, keyResolver
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		expires := time.Now().Add(time.Hour).Truncate(time.Second)
+		tc, list, keyResolver := setup(t, signList(t, key, map[string]interface{}{"rules": rules, "exp": expires.Unix()}))
+		keyResolver.EXPECT().ResolveKeyByID(key.KID, nil, resolver.AssertionMethod).Return(key.PublicKey, nil)
+
+		err := tc.refreshList(context.Background(), list, http.DefaultClient, keyResolver)
+
+		require.NoError(t, err)
+		require.Len(t, tc.remoteLists["national"].rules, 1)
+		assert.Equal(t, "national/hospitals", tc.remoteLists["national"].rules[0].Name)
+		assert.True(t, expires.Equal(*tc.remoteLists["national"].expires))
+		assert.NoError(t, tc.Evaluate(ssi.MustParseURI("NutsUraCredential"), ssi.MustParseURI("did:web:example.org"), time.Now()))
+	})
+	t.Run("rules are limited to the credential types of the list", func(t *testing.T) {
+		scopedRules := []interface{}{
+			map[string]interface{}{
+				"name":   "any-type",
+				"issuer": map[string]interface{}{"did": "did:web:example.org"},
+			},
+			map[string]interface{}{
+				"name":            "other-types",
+				"credentialTypes": []string{"NutsUraCredential", "NutsOrganizationCredential"},
+				"issuer":          map[string]interface{}{"did": "did:web:example.org"},
+			},
+			map[string]interface{}{
+				"name":            "unlisted-type",
+				"credentialTypes": []string{"NutsOrganizationCredential"},
+				"issuer":          map[string]interface{}{"did": "did:web:example.org"},
+			},
+		}
+		tc, list, keyResolver := setup(t, signList(t, key, map[string]interface{}{"rules": scopedRules}))
+		keyResolver.EXPECT().ResolveKeyByID(key.KID, nil, resolver.AssertionMethod).Return(key.PublicKey, nil)
+
+		err := tc.refreshList(context.Background(), list, http.DefaultClient, keyResolver)
+
+		require.NoError(t, err)
+		require.Len(t, tc.remoteLists["national"].rules, 2)
+		assert.Equal(t, []string{"NutsUraCredential"}, tc.remoteLists["national"].rules[0].CredentialTypes)
+		assert.Equal(t, []string{"NutsUraCredential"}, tc.remoteLists["national"].rules[1].CredentialTypes)
+		assert.NoError(t, tc.Evaluate(ssi.MustParseURI("NutsUraCredential"), ssi.MustParseURI("did:web:example.org"), time.Now()))
+		assert.Error(t, tc.Evaluate(ssi.MustParseURI("NutsOrganizationCredential"), ssi.MustParseURI("did:web:example.org"), time.Now()))
+	})
+	t.Run("rule with only a DID method", func(t *testing.T) {
+		methodRules := []interface{}{map[string]interface{}{"name": "web", "issuer": map[string]interface{}{"method": "web"}}}
+		tc, list, keyResolver := setup(t, signList(t, key, map[string]interface{}{"rules": methodRules}))
+		keyResolver.EXPECT().ResolveKeyByID(key.KID, nil, resolver.AssertionMethod).Return(key.PublicKey, nil)
+
+		err := tc.refreshList(context.Background(), list, http.DefaultClient, keyResolver)
+
+		assert.EqualError(t, err, "trust rule 'web': issuer in a trust list must specify did or x509")
+		assert.Empty(t, tc.remoteLists)
+	})
+	t.Run("expired list", func(t *testing.T) {
+		tc := NewConfig("")
+		tc.lists = []ListConfig{list}
+		expired := time.Now().Add(-time.Minute)
+		tc.remoteLists["national"] = remoteList{rules: []Rule{{Name: "national/hospitals", Issuer: IssuerMatcher{Method: "web"}}}, expires: &expired}
+
+		err := tc.Evaluate(ssi.MustParseURI("NutsUraCredential"), ssi.MustParseURI("did:web:example.org"), time.Now())
+
+		assert.ErrorContains(t, err, "rule 'national/hospitals': trust list expired at")
+	})
+	t.Run("signed by other DID", func(t *testing.T) {
+		otherKey := crypto.NewTestKey("did:web:example.com:other#0")
+		tc, list, keyResolver := setup(t, signList(t, otherKey, map[string]interface{}{"rules": rules}))
+
+		err := tc.refreshList(context.Background(), list, http.DefaultClient, keyResolver)
+
+		assert.EqualError(t, err, "invalid trust list: trust list is not signed by did:web:example.com:trustlist")
+		assert.Empty(t, tc.remoteLists)
+	})
+	t.Run("invalid signature", func(t *testing.T) {
+		otherKey := crypto.NewTestKey(key.KID)
+		tc, list, keyResolver := setup(t, signList(t, otherKey, map[string]interface{}{"rules": rules}))
+		keyResolver.EXPECT().ResolveKeyByID(key.KID, nil, resolver.AssertionMethod).Return(key.PublicKey, nil)
+
+		err := tc.refreshList(context.Background(), list, http.DefaultClient, keyResolver)
+
+		assert.ErrorContains(t, err, "invalid trust list")
+		assert.Empty(t, tc.remoteLists)
+	})
+	t.Run("invalid rule keeps previous version", func(t *testing.T) {
+		invalidRules := []interface{}{map[string]interface{}{"name": "no-issuer"}}
+		tc, list, keyResolver := setup(t, signList(t, key, map[string]interface{}{"rules": invalidRules}))
+		keyResolver.EXPECT().ResolveKeyByID(key.KID, nil, resolver.AssertionMethod).Return(key.PublicKey, nil)
+		tc.remoteLists["national"] = remoteList{rules: []Rule{{Name: "national/previous"}}}
+
+		err := tc.refreshList(context.Background(), list, http.DefaultClient, keyResolver)
+
+		assert.EqualError(t, err, "trust rule 'no-issuer': issuer must specify did, method or x509")
+		assert.Equal(t, "national/previous", tc.remoteLists["national"].rules[0].Name)
+	})
+	t.Run("HTTP error", func(t *testing.T) {
+		server := httptest.NewServer(&http2.Handler{StatusCode: http.StatusNotFound})
+		defer server.Close()
+		list := list
+		list.URL = server.URL
+
+		err := NewConfig("").refreshList(context.Background(), list, http.DefaultClient, nil)
+
+		assert.EqualError(t, err, "unexpected HTTP response status: 404")
+	})
+}
+
+func TestConfig_StartRefreshing(t *testing.T) {
+	t.Run("goroutines stop when the context is cancelled", func(t *testing.T) {
+		server := httptest.NewServer(&http2.Handler{StatusCode: http.StatusNotFound})
+		defer server.Close()
+		tc := NewConfig("")
+		tc.lists = []ListConfig{{Name: "national", URL: server.URL, Signer: listSigner, CredentialTypes: []string{"NutsUraCredential"}}}
+		ctx, cancel := context.WithCancel(context.Background())
+		routines := &sync.WaitGroup{}
+
+		tc.StartRefreshing(ctx, routines, http.DefaultClient, nil)
+		cancel()
+
+		routines.Wait()
+	})
+}
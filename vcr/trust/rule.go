@@ -0,0 +1,218 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package trust
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/vdr/didx509"
+)
+
+// Rule defines which issuers are trusted for which credential types, other than listing the issuers one by one.
+type Rule struct {
+	// Name identifies the rule in trust decisions.
+	Name string `yaml:"name" json:"name"`
+	// CredentialTypes contains the credential types the rule applies to. If empty, the rule applies to all credential types.
+	CredentialTypes []string `yaml:"credentialTypes" json:"credentialTypes,omitempty"`
+	// Issuer specifies which issuers are trusted by the rule.
+	Issuer IssuerMatcher `yaml:"issuer" json:"issuer"`
+	// NotBefore optionally specifies the time from which the rule applies.
+	NotBefore *time.Time `yaml:"notBefore" json:"notBefore,omitempty"`
+	// NotAfter optionally specifies the time until which the rule applies.
+	NotAfter *time.Time `yaml:"notAfter" json:"notAfter,omitempty"`
+}
+
+// IssuerMatcher specifies which issuers are trusted. All specified properties must match.
+type IssuerMatcher struct {
+	// DID matches the issuer DID exactly.
+	DID string `yaml:"did" json:"did,omitempty"`
+	// Method matches the DID method of the issuer (e.g. web or x509).
+	Method string `yaml:"method" json:"method,omitempty"`
+	// X509 matches did:x509 issuers by their CA and policies.
+	X509 *X509Matcher `yaml:"x509" json:"x509,omitempty"`
+}
+
+// X509Matcher matches did:x509 issuers. The did:x509 resolver validates the certificate chain against the CA fingerprint and policies in the DID,
+// so the matcher only needs to check the DID itself.
+type X509Matcher struct {
+	// CAFingerprint contains the base64url encoded SHA-256 fingerprint of the CA certificate in the DID.
+	CAFingerprint string `yaml:"caFingerprint" json:"caFingerprint,omitempty"`
+	// CACertificate contains the path to a PEM file with the CA certificate in the DID, as alternative to CAFingerprint.
+	// Not supported in remote trust lists.
+	CACertificate string `yaml:"caCertificate" json:"-"`
+	// Policies contains did:x509 policies (e.g. subject:O:Hospital) that must be present in the DID.
+	Policies []string `yaml:"policies" json:"policies,omitempty"`
+	// fingerprints contains the accepted CA fingerprints, keyed by hash algorithm.
+	fingerprints map[didx509.HashAlgorithm]string
+}
+
+// init validates the rule and prepares it for matching.
+func (r *Rule) init() error {
+	if r.Name == "" {
+		return errors.New("trust rule must have a name")
+	}
+	if r.Issuer.DID == "" && r.Issuer.Method == "" && r.Issuer.X509 == nil {
+		return fmt.Errorf("trust rule '%s': issuer must specify did, method or x509", r.Name)
+	}
+	if r.Issuer.X509 != nil {
+		if err := r.Issuer.X509.init(); err != nil {
+			return fmt.Errorf("trust rule '%s': %w", r.Name, err)
+		}
+	}
+	return nil
+}
+
+func (m *X509Matcher) init() error {
+	m.fingerprints = map[didx509.HashAlgorithm]string{}
+	if m.CAFingerprint != "" {
+		m.fingerprints[didx509.HashSha256] = m.CAFingerprint
+	}
+	if m.CACertificate != "" {
+		data, err := os.ReadFile(m.CACertificate)
+		if err != nil {
+			return fmt.Errorf("unable to read CA certificate: %w", err)
+		}
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return errors.New("CA certificate file does not contain a PEM block")
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return fmt.Errorf("invalid CA certificate: %w", err)
+		}
+		sha1Sum := sha1.Sum(block.Bytes)
+		sha256Sum := sha256.Sum256(block.Bytes)
+		sha384Sum := sha512.Sum384(block.Bytes)
+		sha512Sum := sha512.Sum512(block.Bytes)
+		m.fingerprints[didx509.HashSha1] = base64.RawURLEncoding.EncodeToString(sha1Sum[:])
+		m.fingerprints[didx509.HashSha256] = base64.RawURLEncoding.EncodeToString(sha256Sum[:])
+		m.fingerprints[didx509.HashSha384] = base64.RawURLEncoding.EncodeToString(sha384Sum[:])
+		m.fingerprints[didx509.HashSha512] = base64.RawURLEncoding.EncodeToString(sha512Sum[:])
+	}
+	if len(m.fingerprints) == 0 {
+		return errors.New("x509 issuer must specify caFingerprint or caCertificate")
+	}
+	return nil
+}
+
+// appliesTo returns true if the rule applies to the given credential type.
+func (r Rule) appliesTo(credentialType string) bool {
+	if len(r.CredentialTypes) == 0 {
+		return true
+	}
+	for _, curr := range r.CredentialTypes {
+		if curr == credentialType {
+			return true
+		}
+	}
+	return false
+}
+
+// match checks whether the rule trusts the issuer at the given time. If not, it returns the reason.
+func (r Rule) match(issuer ssi.URI, at time.Time) error {
+	if r.NotBefore != nil && at.Before(*r.NotBefore) {
+		return fmt.Errorf("rule is not valid before %s", r.NotBefore.Format(time.RFC3339))
+	}
+	if r.NotAfter != nil && at.After(*r.NotAfter) {
+		return fmt.Errorf("rule is not valid after %s", r.NotAfter.Format(time.RFC3339))
+	}
+	return r.Issuer.match(issuer)
+}
+
+func (m IssuerMatcher) match(issuer ssi.URI) error {
+	if m.DID != "" && m.DID != issuer.String() {
+		return fmt.Errorf("issuer is not %s", m.DID)
+	}
+	if m.Method == "" && m.X509 == nil {
+		return nil
+	}
+	issuerDID, err := did.ParseDID(issuer.String())
+	if err != nil {
+		return errors.New("issuer is not a DID")
+	}
+	if m.Method != "" && issuerDID.Method != m.Method {
+		return fmt.Errorf("issuer DID method is not %s", m.Method)
+	}
+	if m.X509 != nil {
+		return m.X509.match(*issuerDID)
+	}
+	return nil
+}
+
+func (m X509Matcher) match(issuerDID did.DID) error {
+	if issuerDID.Method != "x509" {
+		return errors.New("issuer is not a did:x509 DID")
+	}
+	ref, err := didx509.ParseX509Did(issuerDID)
+	if err != nil {
+		return fmt.Errorf("invalid did:x509: %w", err)
+	}
+	if m.fingerprints[ref.Method] != ref.CAFingerprint {
+		return errors.New("issuer did:x509 is not issued under the trusted CA")
+	}
+	for _, policy := range m.Policies {
+		if !hasPolicy(ref.Policies, policy) {
+			return fmt.Errorf("issuer did:x509 does not contain policy %s", policy)
+		}
+	}
+	return nil
+}
+
+// hasPolicy returns true if the given policy (name:value) is present in the policies of the did:x509.
+// Values are percent-decoded before comparison.
+func hasPolicy(policies []didx509.X509DidPolicy, policy string) bool {
+	for _, curr := range policies {
+		value, err := url.PathUnescape(curr.Value)
+		if err != nil {
+			value = curr.Value
+		}
+		if string(curr.Name)+":"+value == policy || string(curr.Name)+":"+curr.Value == policy {
+			return true
+		}
+	}
+	return false
+}
+
+// UntrustedError explains why an issuer is not trusted for a credential type.
+type UntrustedError struct {
+	CredentialType string
+	Issuer         string
+	// Reasons contains why each trust rule that applies to the credential type did not match.
+	Reasons []string
+}
+
+func (e UntrustedError) Error() string {
+	msg := fmt.Sprintf("issuer %s is not trusted for credential type %s", e.Issuer, e.CredentialType)
+	if len(e.Reasons) == 0 {
+		return msg + " (not in the list of trusted issuers and no trust rules apply)"
+	}
+	return msg + " (" + strings.Join(e.Reasons, "; ") + ")"
+}
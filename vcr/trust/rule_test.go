@@ -0,0 +1,158 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package trust
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/nuts-node/test/io"
+	"github.com/nuts-foundation/nuts-node/test/pki"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const x509Issuer = "did:x509:0:sha256:NF0zPPu0wcoiJtMNRdQ_W6vuKvGE2urE9GqOwnbqwG0::subject:O:Hospital%20East::subject:L:Amsterdam"
+
+func TestRule_init(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		rule := Rule{Name: "test", Issuer: IssuerMatcher{Method: "web"}}
+
+		assert.NoError(t, rule.init())
+	})
+	t.Run("no name", func(t *testing.T) {
+		rule := Rule{Issuer: IssuerMatcher{Method: "web"}}
+
+		assert.EqualError(t, rule.init(), "trust rule must have a name")
+	})
+	t.Run("no issuer", func(t *testing.T) {
+		rule := Rule{Name: "test"}
+
+		assert.EqualError(t, rule.init(), "trust rule 'test': issuer must specify did, method or x509")
+	})
+	t.Run("x509 without CA", func(t *testing.T) {
+		rule := Rule{Name: "test", Issuer: IssuerMatcher{X509: &X509Matcher{}}}
+
+		assert.EqualError(t, rule.init(), "trust rule 'test': x509 issuer must specify caFingerprint or caCertificate")
+	})
+	t.Run("x509 CA certificate", func(t *testing.T) {
+		certFile := path.Join(io.TestDirectory(t), "ca.pem")
+		caDER := pki.Certificate().Certificate[0]
+		require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}), 0600))
+		rule := Rule{Name: "test", Issuer: IssuerMatcher{X509: &X509Matcher{CACertificate: certFile}}}
+
+		require.NoError(t, rule.init())
+
+		expected := sha256.Sum256(caDER)
+		assert.Equal(t, base64.RawURLEncoding.EncodeToString(expected[:]), rule.Issuer.X509.fingerprints["sha256"])
+		assert.Len(t, rule.Issuer.X509.fingerprints, 4)
+	})
+	t.Run("x509 CA certificate file does not exist", func(t *testing.T) {
+		rule := Rule{Name: "test", Issuer: IssuerMatcher{X509: &X509Matcher{CACertificate: "non-existing.pem"}}}
+
+		assert.ErrorContains(t, rule.init(), "unable to read CA certificate")
+	})
+}
+
+func TestRule_appliesTo(t *testing.T) {
+	t.Run("all types", func(t *testing.T) {
+		assert.True(t, Rule{}.appliesTo("NutsUraCredential"))
+	})
+	t.Run("specific types", func(t *testing.T) {
+		rule := Rule{CredentialTypes: []string{"NutsUraCredential"}}
+
+		assert.True(t, rule.appliesTo("NutsUraCredential"))
+		assert.False(t, rule.appliesTo("NutsOrganizationCredential"))
+	})
+}
+
+func TestRule_match(t *testing.T) {
+	now := time.Now()
+	t.Run("DID", func(t *testing.T) {
+		rule := Rule{Name: "test", Issuer: IssuerMatcher{DID: "did:web:example.com"}}
+		require.NoError(t, rule.init())
+
+		assert.NoError(t, rule.match(ssi.MustParseURI("did:web:example.com"), now))
+		assert.EqualError(t, rule.match(ssi.MustParseURI("did:web:example.org"), now), "issuer is not did:web:example.com")
+	})
+	t.Run("method", func(t *testing.T) {
+		rule := Rule{Name: "test", Issuer: IssuerMatcher{Method: "web"}}
+		require.NoError(t, rule.init())
+
+		assert.NoError(t, rule.match(ssi.MustParseURI("did:web:example.com"), now))
+		assert.EqualError(t, rule.match(ssi.MustParseURI("did:nuts:123"), now), "issuer DID method is not web")
+		assert.EqualError(t, rule.match(ssi.MustParseURI("https://example.com"), now), "issuer is not a DID")
+	})
+	t.Run("x509", func(t *testing.T) {
+		rule := Rule{Name: "test", Issuer: IssuerMatcher{X509: &X509Matcher{
+			CAFingerprint: "NF0zPPu0wcoiJtMNRdQ_W6vuKvGE2urE9GqOwnbqwG0",
+			Policies:      []string{"subject:O:Hospital East"},
+		}}}
+		require.NoError(t, rule.init())
+
+		t.Run("ok", func(t *testing.T) {
+			assert.NoError(t, rule.match(ssi.MustParseURI(x509Issuer), now))
+		})
+		t.Run("other CA", func(t *testing.T) {
+			err := rule.match(ssi.MustParseURI("did:x509:0:sha256:other::subject:O:Hospital%20East"), now)
+
+			assert.EqualError(t, err, "issuer did:x509 is not issued under the trusted CA")
+		})
+		t.Run("missing policy", func(t *testing.T) {
+			err := rule.match(ssi.MustParseURI("did:x509:0:sha256:NF0zPPu0wcoiJtMNRdQ_W6vuKvGE2urE9GqOwnbqwG0::subject:O:Hospital%20West"), now)
+
+			assert.EqualError(t, err, "issuer did:x509 does not contain policy subject:O:Hospital East")
+		})
+		t.Run("not did:x509", func(t *testing.T) {
+			err := rule.match(ssi.MustParseURI("did:web:example.com"), now)
+
+			assert.EqualError(t, err, "issuer is not a did:x509 DID")
+		})
+	})
+	t.Run("validity window", func(t *testing.T) {
+		notBefore := now.Add(-time.Hour)
+		notAfter := now.Add(time.Hour)
+		rule := Rule{Name: "test", Issuer: IssuerMatcher{Method: "web"}, NotBefore: &notBefore, NotAfter: &notAfter}
+		require.NoError(t, rule.init())
+		issuer := ssi.MustParseURI("did:web:example.com")
+
+		assert.NoError(t, rule.match(issuer, now))
+		assert.ErrorContains(t, rule.match(issuer, now.Add(-2*time.Hour)), "rule is not valid before")
+		assert.ErrorContains(t, rule.match(issuer, now.Add(2*time.Hour)), "rule is not valid after")
+	})
+}
+
+func TestUntrustedError_Error(t *testing.T) {
+	t.Run("no rules", func(t *testing.T) {
+		err := UntrustedError{CredentialType: "NutsUraCredential", Issuer: "did:web:example.com"}
+
+		assert.EqualError(t, err, "issuer did:web:example.com is not trusted for credential type NutsUraCredential (not in the list of trusted issuers and no trust rules apply)")
+	})
+	t.Run("with reasons", func(t *testing.T) {
+		err := UntrustedError{CredentialType: "NutsUraCredential", Issuer: "did:web:example.com", Reasons: []string{"rule 'a': x", "rule 'b': y"}}
+
+		assert.EqualError(t, err, "issuer did:web:example.com is not trusted for credential type NutsUraCredential (rule 'a': x; rule 'b': y)")
+	})
+}
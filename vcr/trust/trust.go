@@ -21,8 +21,10 @@ package trust
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"sync"
+	"time"
 
 	ssi "github.com/nuts-foundation/go-did"
 	"gopkg.in/yaml.v3"
@@ -31,11 +33,24 @@ import (
 // ErrNoFilename is returned when trust actions are performed but no file for storing those is specified.
 var ErrNoFilename = errors.New("no filename specified")
 
-// Config holds the trusted issuers per credential type
+// Config holds the trusted issuers per credential type, and the trust rules that trust issuers by other means.
 type Config struct {
 	filename       string
 	issuersPerType map[string][]string
 	mutex          sync.Mutex
+	// rules contains the trust rules from the rules file.
+	rules []Rule
+	// lists contains the remote trust lists from the rules file.
+	lists []ListConfig
+	// remoteLists contains the rules of the downloaded remote trust lists, keyed on list name.
+	remoteLists map[string]remoteList
+	rulesMutex  sync.RWMutex
+}
+
+// rulesFile is the format of the trust rules file.
+type rulesFile struct {
+	Rules []Rule       `yaml:"rules"`
+	Lists []ListConfig `yaml:"lists"`
 }
 
 // NewConfig returns a fully configured Config
@@ -44,6 +59,7 @@ func NewConfig(filename string) *Config {
 		filename:       filename,
 		issuersPerType: map[string][]string{},
 		mutex:          sync.Mutex{},
+		remoteLists:    map[string]remoteList{},
 	}
 }
 
@@ -70,6 +86,42 @@ func (tc *Config) Load() error {
 	return yaml.Unmarshal(data, &tc.issuersPerType)
 }
 
+// LoadRules loads the trust rules and remote trust lists from the given YAML file.
+func (tc *Config) LoadRules(filename string) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("unable to read trust rules file: %w", err)
+	}
+	var file rulesFile
+	if err = yaml.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("unable to parse trust rules file: %w", err)
+	}
+	names := map[string]bool{}
+	for i := range file.Rules {
+		if err := file.Rules[i].init(); err != nil {
+			return err
+		}
+		if names[file.Rules[i].Name] {
+			return fmt.Errorf("duplicate trust rule name: %s", file.Rules[i].Name)
+		}
+		names[file.Rules[i].Name] = true
+	}
+	for _, list := range file.Lists {
+		if err := list.validate(); err != nil {
+			return err
+		}
+		if names[list.Name] {
+			return fmt.Errorf("duplicate trust rule or list name: %s", list.Name)
+		}
+		names[list.Name] = true
+	}
+	tc.rulesMutex.Lock()
+	defer tc.rulesMutex.Unlock()
+	tc.rules = file.Rules
+	tc.lists = file.Lists
+	return nil
+}
+
 // Save the list of trusted issuers per credential type to file
 func (tc *Config) save() error {
 	if tc.filename == "" {
@@ -106,6 +158,53 @@ func (tc *Config) IsTrusted(credentialType ssi.URI, issuer ssi.URI) bool {
 	return false
 }
 
+// Evaluate checks whether the issuer is trusted for the given credential type at the given time.
+// The issuer is trusted if it's in the list of trusted issuers for the credential type,
+// or if one of the trust rules (local or from a remote trust list) that applies to the credential type matches the issuer.
+// If the issuer is not trusted, it returns an UntrustedError that explains why.
+func (tc *Config) Evaluate(credentialType ssi.URI, issuer ssi.URI, at time.Time) error {
+	if tc.IsTrusted(credentialType, issuer) {
+		return nil
+	}
+	tc.rulesMutex.RLock()
+	defer tc.rulesMutex.RUnlock()
+
+	result := UntrustedError{
+		CredentialType: credentialType.String(),
+		Issuer:         issuer.String(),
+	}
+	evaluate := func(rules []Rule, expired error) bool {
+		for _, rule := range rules {
+			if !rule.appliesTo(result.CredentialType) {
+				continue
+			}
+			err := expired
+			if err == nil {
+				err = rule.match(issuer, at)
+			}
+			if err == nil {
+				return true
+			}
+			result.Reasons = append(result.Reasons, fmt.Sprintf("rule '%s': %s", rule.Name, err))
+		}
+		return false
+	}
+	if evaluate(tc.rules, nil) {
+		return nil
+	}
+	for _, list := range tc.lists {
+		remote := tc.remoteLists[list.Name]
+		var expired error
+		if remote.expires != nil && time.Now().After(*remote.expires) {
+			expired = fmt.Errorf("trust list expired at %s", remote.expires.Format(time.RFC3339))
+		}
+		if evaluate(remote.rules, expired) {
+			return nil
+		}
+	}
+	return result
+}
+
 // AddTrust adds trust in a specific Issuer for a credential type.
 // It returns an error if the Save fails
 func (tc *Config) AddTrust(credentialType ssi.URI, issuer ssi.URI) error {
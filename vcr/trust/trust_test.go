@@ -21,8 +21,10 @@ package trust
 
 import (
 	"github.com/stretchr/testify/require"
+	"os"
 	"path"
 	"testing"
+	"time"
 
 	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/vc"
@@ -170,3 +172,79 @@ func TestConfig_RemoveTrust(t *testing.T) {
 		assert.True(t, tc.IsTrusted(vc.VerifiableCredentialTypeV1URI(), issuer3))
 	})
 }
+
+func TestTrustConfig_LoadRules(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		tc := NewConfig("")
+
+		err := tc.LoadRules("../test/trust-rules.yaml")
+
+		require.NoError(t, err)
+		require.Len(t, tc.rules, 2)
+		assert.Equal(t, "hospitals", tc.rules[0].Name)
+		assert.Equal(t, []string{"subject:O:Hospital East"}, tc.rules[0].Issuer.X509.Policies)
+		require.Len(t, tc.lists, 1)
+		assert.Equal(t, 30*time.Minute, tc.lists[0].RefreshInterval)
+	})
+	t.Run("file does not exist", func(t *testing.T) {
+		err := NewConfig("").LoadRules("non-existing.yaml")
+
+		assert.ErrorContains(t, err, "unable to read trust rules file")
+	})
+	t.Run("duplicate name", func(t *testing.T) {
+		filename := path.Join(io.TestDirectory(t), "rules.yaml")
+		data := "rules:\n  - name: a\n    issuer:\n      method: web\nlists:\n  - name: a\n    url: https://example.com\n    signer: did:web:example.com\n    credentialTypes:\n      - NutsUraCredential\n"
+		require.NoError(t, os.WriteFile(filename, []byte(data), 0600))
+
+		err := NewConfig("").LoadRules(filename)
+
+		assert.EqualError(t, err, "duplicate trust rule or list name: a")
+	})
+	t.Run("invalid list", func(t *testing.T) {
+		filename := path.Join(io.TestDirectory(t), "rules.yaml")
+		require.NoError(t, os.WriteFile(filename, []byte("lists:\n  - name: a\n"), 0600))
+
+		err := NewConfig("").LoadRules(filename)
+
+		assert.EqualError(t, err, "trust list must have a name, url and signer")
+	})
+	t.Run("list without credential types", func(t *testing.T) {
+		filename := path.Join(io.TestDirectory(t), "rules.yaml")
+		data := "lists:\n  - name: a\n    url: https://example.com\n    signer: did:web:example.com\n"
+		require.NoError(t, os.WriteFile(filename, []byte(data), 0600))
+
+		err := NewConfig("").LoadRules(filename)
+
+		assert.EqualError(t, err, "trust list 'a': must specify credentialTypes")
+	})
+}
+
+func TestTrustConfig_Evaluate(t *testing.T) {
+	tc := NewConfig("")
+	require.NoError(t, tc.LoadRules("../test/trust-rules.yaml"))
+	tc.issuersPerType[nutsTestCredential] = []string{"did:nuts:1"}
+	uraCredential := ssi.MustParseURI("NutsUraCredential")
+	now := time.Now()
+
+	t.Run("trusted issuer", func(t *testing.T) {
+		assert.NoError(t, tc.Evaluate(ssi.MustParseURI(nutsTestCredential), ssi.MustParseURI("did:nuts:1"), now))
+	})
+	t.Run("trusted by rule", func(t *testing.T) {
+		err := tc.Evaluate(uraCredential, ssi.MustParseURI("did:x509:0:sha256:NF0zPPu0wcoiJtMNRdQ_W6vuKvGE2urE9GqOwnbqwG0::subject:O:Hospital%20East"), now)
+
+		assert.NoError(t, err)
+	})
+	t.Run("rule no longer valid", func(t *testing.T) {
+		err := tc.Evaluate(ssi.MustParseURI(nutsTestCredential), ssi.MustParseURI("did:web:example.com"), now)
+
+		var untrustedErr UntrustedError
+		require.ErrorAs(t, err, &untrustedErr)
+		assert.Equal(t, []string{"rule 'example': rule is not valid after 2020-01-01T00:00:00Z"}, untrustedErr.Reasons)
+	})
+	t.Run("untrusted", func(t *testing.T) {
+		err := tc.Evaluate(uraCredential, ssi.MustParseURI("did:web:example.org"), now)
+
+		assert.EqualError(t, err, "issuer did:web:example.org is not trusted for credential type NutsUraCredential "+
+			"(rule 'hospitals': issuer is not a did:x509 DID; rule 'example': rule is not valid after 2020-01-01T00:00:00Z)")
+	})
+}
@@ -21,10 +21,8 @@ package vcr
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"github.com/nuts-foundation/go-leia/v4"
 	"github.com/nuts-foundation/nuts-node/http/client"
 	"github.com/nuts-foundation/nuts-node/pki"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
@@ -35,7 +33,7 @@ import (
 	"io/fs"
 	"net/http"
 	"path"
-	"strings"
+	"sync"
 	"time"
 
 	ssi "github.com/nuts-foundation/go-did"
@@ -82,7 +80,7 @@ type vcr struct {
 	// strictmode holds a copy of the core.ServerConfig.Strictmode value
 	strictmode          bool
 	config              Config
-	store               storage.KVBackedLeiaStore
+	credentialStore     *sqlCredentialStore
 	keyStore            crypto.KeyStore
 	keyResolver         resolver.KeyResolver
 	serviceResolver     resolver.ServiceResolver
@@ -101,8 +99,13 @@ type vcr struct {
 	localWalletResolver openid4vci.IdentifierResolver
 	issuerHttpClient    core.HTTPRequestDoer
 	walletHttpClient    core.HTTPRequestDoer
+	trustListHttpClient core.HTTPRequestDoer
 	pkiProvider         pki.Provider
 	vdrInstance         vdr.VDR
+	ctx                 context.Context
+	cancel              context.CancelFunc
+	// routines tracks the goroutines started by the VCR (e.g. refreshing trust lists), so Shutdown can wait for them.
+	routines sync.WaitGroup
 }
 
 func (c *vcr) GetOpenIDIssuer(ctx context.Context, id did.DID) (issuer.OpenIDHandler, error) {
@@ -177,13 +180,14 @@ func (c *vcr) Configure(config core.ServerConfig) error {
 	}
 
 	// create verifier store (for revocations)
+	c.verifierStore = verifier.NewSQLStore(c.storageClient.GetSQLDatabase())
+	// migrate revocations from the (legacy) Leia verifier store
 	verifierStorePath := path.Join(c.datadir, "vcr", "verifier-store.db")
 	verifierBackupStore, err := c.storageClient.GetProvider(ModuleName).GetKVStore("backup-revoked-credentials", storage.PersistentStorageClass)
 	if err != nil {
 		return err
 	}
-	c.verifierStore, err = verifier.NewLeiaVerifierStore(verifierStorePath, verifierBackupStore)
-	if err != nil {
+	if err = verifier.MigrateLeiaStore(c.storageClient.GetSQLDatabase(), verifierStorePath, verifierBackupStore); err != nil {
 		return err
 	}
 
@@ -195,6 +199,12 @@ func (c *vcr) Configure(config core.ServerConfig) error {
 	// create trust config
 	tcPath := path.Join(config.Datadir, "vcr", "trusted_issuers.yaml")
 	c.trustConfig = trust.NewConfig(tcPath)
+	c.trustListHttpClient = client.New(config.HTTPClient.Timeout)
+	if c.config.Trust.RulesFile != "" {
+		if err = c.trustConfig.LoadRules(c.config.Trust.RulesFile); err != nil {
+			return err
+		}
+	}
 
 	// default to nil openidHandlerFn when OpenID4VCI.Enabled==false
 	var openidHandlerFn func(ctx context.Context, id did.DID) (issuer.OpenIDHandler, error)
@@ -230,8 +240,13 @@ func (c *vcr) Configure(config core.ServerConfig) error {
 	}
 
 	status := revocation.NewStatusList2021(c.storageClient.GetSQLDatabase(), client.NewWithCache(config.HTTPClient.Timeout), config.URL)
-	c.issuer = issuer.NewIssuer(c.issuerStore, c, networkPublisher, openidHandlerFn, didResolver, c.keyStore, c.jsonldManager, c.trustConfig, status)
-	c.verifier = verifier.NewVerifier(c.verifierStore, didResolver, c.keyResolver, c.jsonldManager, c.trustConfig, status, c.pkiProvider)
+	// the schema URLs are restricted to an allow-list, so redirects (possibly to other URLs) aren't followed
+	schemaValidator, err := credential.NewSchemaValidator(client.NewWithCacheWithoutRedirects(config.HTTPClient.Timeout), c.config.Schema.AllowedURLs, config.HTTPClient.Timeout)
+	if err != nil {
+		return err
+	}
+	c.issuer = issuer.NewIssuer(c.issuerStore, c, networkPublisher, openidHandlerFn, didResolver, c.keyStore, c.jsonldManager, c.trustConfig, status, schemaValidator)
+	c.verifier = verifier.NewVerifier(c.verifierStore, didResolver, c.keyResolver, c.jsonldManager, c.trustConfig, status, c.pkiProvider, schemaValidator)
 
 	if !c.network.Disabled() {
 		c.ambassador = NewAmbassador(c.network, c, c.verifier, c.eventManager)
@@ -240,41 +255,32 @@ func (c *vcr) Configure(config core.ServerConfig) error {
 	// Create holder/wallet
 	c.wallet = holder.NewSQLWallet(c.keyResolver, c.keyStore, c.verifier, c.jsonldManager, c.storageClient)
 
-	if err = c.store.HandleRestore(); err != nil {
-		return err
-	}
-
 	return c.trustConfig.Load()
 }
 
 func (c *vcr) createCredentialsStore() error {
-	credentialsStorePath := path.Join(c.datadir, "vcr", "credentials.db")
-	credentialsBackupStore, err := c.storageClient.GetProvider(ModuleName).GetKVStore("backup-credentials", storage.PersistentStorageClass)
+	configs, err := c.loadJSONLDConfig()
 	if err != nil {
 		return err
 	}
-	credentialsStore, err := leia.NewStore(credentialsStorePath, leia.WithDocumentLoader(c.jsonldManager.DocumentLoader()))
+	c.credentialStore, err = newSQLCredentialStore(c.storageClient.GetSQLDatabase(), c.jsonldManager.DocumentLoader(), configs)
 	if err != nil {
 		return err
 	}
-	c.store, err = storage.NewKVBackedLeiaStore(credentialsStore, credentialsBackupStore)
+	// migrate credentials from the (legacy) Leia credential store
+	credentialsStorePath := path.Join(c.datadir, "vcr", "credentials.db")
+	credentialsBackupStore, err := c.storageClient.GetProvider(ModuleName).GetKVStore("backup-credentials", storage.PersistentStorageClass)
 	if err != nil {
 		return err
 	}
-
-	// set backup config
-	c.store.AddConfiguration(storage.LeiaBackupConfiguration{
-		CollectionName: "credentials",
-		CollectionType: leia.JSONLDCollection,
-		BackupShelf:    credentialsBackupShelf,
-		SearchQuery:    leia.NewIRIPath(),
-	})
-
-	// init indices
-	return c.initJSONLDIndices()
+	return c.credentialStore.migrateLeiaStore(credentialsStorePath, credentialsBackupStore)
 }
 
 func (c *vcr) Start() error {
+	c.ctx, c.cancel = context.WithCancel(context.Background())
+	// download remote trust lists
+	c.trustConfig.StartRefreshing(c.ctx, &c.routines, c.trustListHttpClient, c.keyResolver)
+
 	if c.ambassador == nil { // did:nuts / network layer is disabled
 		return nil
 	}
@@ -285,30 +291,17 @@ func (c *vcr) Start() error {
 }
 
 func (c *vcr) Shutdown() error {
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.routines.Wait()
 	err := c.issuerStore.Close()
 	if err != nil {
 		log.Logger().
 			WithError(err).
 			Error("Unable to close issuer store")
 	}
-	err = c.verifierStore.Close()
-	if err != nil {
-		log.Logger().
-			WithError(err).
-			Error("Unable to close verifier store")
-	}
-	return c.store.Close()
-}
-
-func whitespaceOrExactTokenizer(text string) (tokens []string) {
-	tokens = leia.WhiteSpaceTokenizer(text)
-	tokens = append(tokens, text)
-
-	return
-}
-
-func (c *vcr) credentialCollection() leia.Collection {
-	return c.store.Collection(leia.JSONLDCollection, "credentials")
+	return c.verifierStore.Close()
 }
 
 func (c *vcr) loadJSONLDConfig() ([]indexConfig, error) {
@@ -335,57 +328,6 @@ func (c *vcr) loadJSONLDConfig() ([]indexConfig, error) {
 	return configs, nil
 }
 
-func (c *vcr) initJSONLDIndices() error {
-	collection := c.credentialCollection()
-
-	configs, err := c.loadJSONLDConfig()
-	if err != nil {
-		return err
-	}
-
-	for _, config := range configs {
-		for _, index := range config.Indices {
-			var leiaParts []leia.FieldIndexer
-
-			for _, iParts := range index.Parts {
-				options := make([]leia.IndexOption, 0)
-				if iParts.Tokenizer != nil {
-					tokenizer := strings.ToLower(*iParts.Tokenizer)
-					switch tokenizer {
-					case "whitespaceorexact":
-						options = append(options, leia.TokenizerOption(whitespaceOrExactTokenizer))
-					case "whitespace":
-						options = append(options, leia.TokenizerOption(leia.WhiteSpaceTokenizer))
-					default:
-						return fmt.Errorf("unknown tokenizer %s for %s", *iParts.Tokenizer, index.Name)
-					}
-				}
-				if iParts.Transformer != nil {
-					transformer := strings.ToLower(*iParts.Transformer)
-					switch transformer {
-					case "cologne":
-						options = append(options, leia.TransformerOption(CologneTransformer))
-					case "lowercase":
-						options = append(options, leia.TransformerOption(leia.ToLower))
-					default:
-						return fmt.Errorf("unknown transformer %s for %s", *iParts.Transformer, index.Name)
-					}
-				}
-
-				leiaParts = append(leiaParts, leia.NewFieldIndexer(leia.NewIRIPath(iParts.IRIPath...), options...))
-			}
-
-			leiaIndex := collection.NewIndex(index.Name, leiaParts...)
-			log.Logger().Debugf("Adding index %s", index.Name)
-
-			if err := collection.AddIndex(leiaIndex); err != nil {
-				return err
-			}
-		}
-	}
-	return nil
-}
-
 func (c *vcr) Name() string {
 	return ModuleName
 }
@@ -405,12 +347,13 @@ func (c *vcr) Resolve(ID ssi.URI, resolveTime *time.Time) (*vc.VerifiableCredent
 	}
 
 	// we don't have to check the signature, it's coming from our own store.
-	if err = c.verifier.Verify(credential, false, false, resolveTime); err != nil {
-		switch err {
-		case types.ErrRevoked:
+	if err = c.verifier.Verify(context.Background(), credential, false, false, resolveTime); err != nil {
+		switch {
+		case errors.Is(err, types.ErrRevoked):
 			return &credential, types.ErrRevoked
-		case types.ErrUntrusted:
-			return &credential, types.ErrUntrusted
+		case errors.Is(err, types.ErrUntrusted):
+			// contains the reason the issuer is not trusted
+			return &credential, err
 		default:
 			return nil, err
 		}
@@ -420,28 +363,14 @@ func (c *vcr) Resolve(ID ssi.URI, resolveTime *time.Time) (*vc.VerifiableCredent
 
 // find only returns a VC from storage, it does not tell anything about validity
 func (c *vcr) find(ID ssi.URI) (vc.VerifiableCredential, error) {
-	credential := vc.VerifiableCredential{}
-	qp := leia.Eq(leia.NewIRIPath(), leia.MustParseScalar(ID.String()))
-	q := leia.New(qp)
-
 	ctx, cancel := context.WithTimeout(context.Background(), maxFindExecutionTime)
 	defer cancel()
 
-	docs, err := c.credentialCollection().Find(ctx, q)
+	credential, err := c.credentialStore.find(ctx, ID.String())
 	if err != nil {
-		return credential, err
+		return vc.VerifiableCredential{}, err
 	}
-	if len(docs) > 0 {
-		// there can be only one
-		err = json.Unmarshal(docs[0], &credential)
-		if err != nil {
-			return credential, fmt.Errorf("unable to parse credential from db: %w", err)
-		}
-
-		return credential, nil
-	}
-
-	return credential, types.ErrNotFound
+	return *credential, nil
 }
 
 func (c *vcr) Trust(credentialType ssi.URI, issuer ssi.URI) error {
@@ -478,48 +407,36 @@ func (c *vcr) Untrusted(credentialType ssi.URI) ([]ssi.URI, error) {
 		trustMap[trusted.String()] = true
 	}
 
-	// check all issued VCs
-	query := leia.New(leia.NotNil(leia.NewIRIPath(jsonld.CredentialIssuerPath...)))
-
-	// use type specific collection
-	collection := c.credentialCollection()
-
-	// for each key: add to untrusted if not present in trusted
-	err := collection.IndexIterate(query, func(key []byte, value []byte) error {
-		// we iterate over all issuers->reference pairs
-		issuer := string(key)
-		if _, ok := trustMap[issuer]; !ok {
-			u, err := ssi.ParseURI(issuer)
-			if err != nil {
-				return err
-			}
-			trustMap[issuer] = true
+	// check all issuers of stored VCs
+	issuers, err := c.credentialStore.issuers()
+	if err != nil {
+		return nil, err
+	}
 
-			// only add to untrusted if issuer is not deactivated or has active controllers
-			issuerDid, err := did.ParseDIDURL(issuer)
-			if err != nil {
-				return err
-			}
-			_, _, err = didResolver.Resolve(issuerDid.DID, nil)
-			if err != nil {
-				if !(errors.Is(err, did.DeactivatedErr) || errors.Is(err, resolver.ErrNoActiveController)) {
-					return err
-				}
-			} else {
-				untrusted = append(untrusted, *u)
-			}
+	// for each issuer: add to untrusted if not present in trusted
+	for _, issuer := range issuers {
+		if _, ok := trustMap[issuer]; ok {
+			continue
 		}
-		return nil
-	})
-	if err != nil {
-		if errors.Is(err, leia.ErrNoIndex) {
-			log.Logger().
-				WithField(core.LogFieldCredentialType, credentialType).
-				Warn("No index with field 'issuer' found for credential")
+		u, err := ssi.ParseURI(issuer)
+		if err != nil {
+			return nil, err
+		}
+		trustMap[issuer] = true
 
-			return nil, types.ErrInvalidCredential
+		// only add to untrusted if issuer is not deactivated or has active controllers
+		issuerDid, err := did.ParseDIDURL(issuer)
+		if err != nil {
+			return nil, err
+		}
+		_, _, err = didResolver.Resolve(issuerDid.DID, nil)
+		if err != nil {
+			if !(errors.Is(err, did.DeactivatedErr) || errors.Is(err, resolver.ErrNoActiveController)) {
+				return nil, err
+			}
+		} else {
+			untrusted = append(untrusted, *u)
 		}
-		return nil, err
 	}
 
 	return untrusted, nil
@@ -528,7 +445,7 @@ func (c *vcr) Untrusted(credentialType ssi.URI) ([]ssi.URI, error) {
 func (c *vcr) Diagnostics() []core.DiagnosticResult {
 	var credentialCount int
 	var err error
-	credentialCount, err = c.credentialCollection().DocumentCount()
+	credentialCount, err = c.credentialStore.count()
 	if err != nil {
 		credentialCount = -1
 		log.Logger().
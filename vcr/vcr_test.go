@@ -24,7 +24,6 @@ import (
 	"crypto/sha1"
 	"encoding/json"
 	"errors"
-	"github.com/nuts-foundation/go-leia/v4"
 	"github.com/nuts-foundation/go-stoabs"
 	bbolt2 "github.com/nuts-foundation/go-stoabs/bbolt"
 	"github.com/nuts-foundation/nuts-node/http/client"
@@ -35,7 +34,6 @@ import (
 	"github.com/nuts-foundation/nuts-node/vdr"
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
 	"github.com/stretchr/testify/require"
-	"os"
 	"path"
 	"strings"
 	"testing"
@@ -44,7 +42,6 @@ import (
 	"github.com/nuts-foundation/nuts-node/events"
 	"github.com/nuts-foundation/nuts-node/jsonld"
 	"github.com/nuts-foundation/nuts-node/vcr/verifier"
-	"go.etcd.io/bbolt"
 
 	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/did"
@@ -115,59 +112,17 @@ func TestVCR_Start(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
 		instance := NewTestVCRInstance(t)
 
-		_, err := os.Stat(credentialsDBPath(instance.datadir))
-		assert.NoError(t, err)
+		assert.NotNil(t, instance.credentialStore)
 	})
 
 	t.Run("loads default indices", func(t *testing.T) {
-		testDirectory := io.TestDirectory(t)
-		ctrl := gomock.NewController(t)
-		vdrInstance := vdr.NewMockVDR(ctrl)
-		vdrInstance.EXPECT().Resolver().AnyTimes()
-		instance := NewVCRInstance(
-			nil,
-			vdrInstance,
-			network.NewTestNetworkInstance(t),
-			jsonld.NewTestJSONLDManager(t),
-			events.NewTestManager(t),
-			storage.NewTestStorageEngine(t),
-			pki.New(),
-		).(*vcr)
-		if err := instance.Configure(core.TestServerConfig(func(config *core.ServerConfig) {
-			config.Datadir = testDirectory
-		})); err != nil {
-			t.Fatal(err)
-		}
-		if err := instance.Start(); err != nil {
-			t.Fatal(err)
-		}
-		// add a single document so indices are created
-		if err := instance.credentialCollection().Add([]leia.Document{[]byte("{}")}); err != nil {
-			t.Fatal(err)
-		}
-		if err := instance.Shutdown(); err != nil {
-			t.Fatal(err)
-		}
-
-		dbPath := credentialsDBPath(instance.datadir)
-		db, err := bbolt.Open(dbPath, os.ModePerm, nil)
-		if err != nil {
-			t.Fatal(err)
-		}
-		defer db.Close()
-		db.View(func(tx *bbolt.Tx) error {
-			mainBucket := tx.Bucket([]byte("credentials"))
-
-			if !assert.NotNil(t, mainBucket) {
-				return nil
-			}
-			assert.NotNil(t, mainBucket.Bucket([]byte("index_id")))
-			assert.NotNil(t, mainBucket.Bucket([]byte("index_issuer")))
-			assert.NotNil(t, mainBucket.Bucket([]byte("index_organization")))
-			assert.NotNil(t, mainBucket.Bucket([]byte("index_auth_subject_purpose_resources")))
-
-			return nil
-		})
+		instance := NewTestVCRInstance(t)
+
+		indexer := instance.credentialStore.indexers[termPath(jsonld.OrganizationNamePath)]
+		require.NotNil(t, indexer.tokenizer)
+		require.NotNil(t, indexer.transformer)
+		assert.Equal(t, []string{"Ziekenhuis", "Hengelo", "Ziekenhuis Hengelo"}, indexer.tokenizer("Ziekenhuis Hengelo"), "tokenizer")
+		assert.Equal(t, "8468", indexer.transformer("Ziekenhuis"), "transformer")
 	})
 }
 
@@ -215,8 +170,8 @@ func TestVCR_Resolve(t *testing.T) {
 		ctx := newMockContext(t2)
 
 		// add document
-		doc := []byte(jsonld.TestOrganizationCredential)
-		err := ctx.vcr.credentialCollection().Add([]leia.Document{doc})
+		credential, _ := vc.ParseVerifiableCredential(jsonld.TestOrganizationCredential)
+		err := ctx.vcr.credentialStore.add(ctx.vcr.storageClient.GetSQLDatabase(), *credential)
 		require.NoError(t2, err)
 
 		return ctx
@@ -259,7 +214,7 @@ func TestVCR_Resolve(t *testing.T) {
 		ctx.vcr.trustConfig.AddTrust(ssi.MustParseURI("NutsOrganizationCredential"), testVC.Issuer)
 		mockVerifier := verifier.NewMockVerifier(ctx.ctrl)
 		ctx.vcr.verifier = mockVerifier
-		mockVerifier.EXPECT().Verify(testVC, false, false, gomock.Any()).Return(vcrTypes.ErrRevoked)
+		mockVerifier.EXPECT().Verify(gomock.Any(), testVC, false, false, gomock.Any()).Return(vcrTypes.ErrRevoked)
 
 		vc, err := ctx.vcr.Resolve(*testVC.ID, nil)
 
@@ -273,7 +228,7 @@ func TestVCR_Resolve(t *testing.T) {
 
 		vc, err := ctx.vcr.Resolve(*testVC.ID, nil)
 
-		assert.Equal(t, err, vcrTypes.ErrUntrusted)
+		assert.ErrorIs(t, err, vcrTypes.ErrUntrusted)
 		assert.Equal(t, testVC, *vc)
 	})
 
@@ -360,16 +315,13 @@ func TestVcr_Untrusted(t *testing.T) {
 	testCredential := vc.VerifiableCredential{}
 	_ = json.Unmarshal([]byte(jsonld.TestOrganizationCredential), &testCredential)
 
-	// reindex
-	err := instance.initJSONLDIndices()
-	require.NoError(t, err)
-
 	// add document
-	doc := []byte(jsonld.TestOrganizationCredential)
-	doc2 := []byte(strings.ReplaceAll(jsonld.TestOrganizationCredential, "#123", "#321"))
-	_ = instance.credentialCollection().Add([]leia.Document{doc})
+	doc, _ := vc.ParseVerifiableCredential(jsonld.TestOrganizationCredential)
+	doc2, _ := vc.ParseVerifiableCredential(strings.ReplaceAll(jsonld.TestOrganizationCredential, "#123", "#321"))
+	db := instance.storageClient.GetSQLDatabase()
+	require.NoError(t, instance.credentialStore.add(db, *doc))
 	// for duplicate detection
-	_ = instance.credentialCollection().Add([]leia.Document{doc2})
+	require.NoError(t, instance.credentialStore.add(db, *doc2))
 
 	t.Run("Trusted", func(t *testing.T) {
 		confirmTrustedStatus(t, instance, testCredential.Issuer, instance.Trusted, 1)
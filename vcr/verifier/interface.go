@@ -19,6 +19,7 @@
 package verifier
 
 import (
+	"context"
 	"errors"
 	"github.com/nuts-foundation/nuts-node/core"
 	"io"
@@ -33,9 +34,12 @@ import (
 type Verifier interface {
 	// Verify checks credential on full correctness. It checks:
 	// validity of the signature (optional)
-	// if it has been revoked
+	// if it has been revoked (types.ErrRevoked) or suspended (types.ErrSuspended)
 	// if the issuer is registered as trusted (optional)
-	Verify(credential vc.VerifiableCredential, allowUntrusted bool, checkSignature bool, validAt *time.Time) error
+	Verify(ctx context.Context, credential vc.VerifiableCredential, allowUntrusted bool, checkSignature bool, validAt *time.Time) error
+	// VerifyWithReport performs the same checks as Verify, but doesn't stop at the first failed check.
+	// It returns the result of each check, explaining why the credential is (in)valid.
+	VerifyWithReport(ctx context.Context, credential vc.VerifiableCredential, allowUntrusted bool, checkSignature bool, validAt *time.Time) VerificationReport
 	// VerifySignature checks that the signature on the verifiable credential is correct and valid at the given time and nothing else
 	VerifySignature(credentialToVerify vc.VerifiableCredential, at *time.Time) error
 	// IsRevoked checks if the credential is revoked
@@ -54,7 +58,10 @@ type Verifier interface {
 	// - trust status (unless allowUntrustedVCs is true).
 	// It always checks whether the signer of the presentation is the holder of the presented credentials,
 	// but only if there are credentials in the presentation.
-	VerifyVP(presentation vc.VerifiablePresentation, verifyVCs bool, allowUntrustedVCs bool, validAt *time.Time) ([]vc.VerifiableCredential, error)
+	VerifyVP(ctx context.Context, presentation vc.VerifiablePresentation, verifyVCs bool, allowUntrustedVCs bool, validAt *time.Time) ([]vc.VerifiableCredential, error)
+	// VerifyVPWithReport performs the same checks as VerifyVP, but doesn't stop at the first failed check.
+	// It returns the result of each check on the presentation, and the report of each credential if verifyVCs is true.
+	VerifyVPWithReport(ctx context.Context, presentation vc.VerifiablePresentation, verifyVCs bool, allowUntrustedVCs bool, validAt *time.Time) PresentationVerificationReport
 }
 
 // ErrNotFound is returned when a credential or revocation can not be found based on its ID.
@@ -10,6 +10,7 @@
 package verifier
 
 import (
+	context "context"
 	reflect "reflect"
 	time "time"
 
@@ -89,17 +90,17 @@ func (mr *MockVerifierMockRecorder) RegisterRevocation(revocation any) *gomock.C
 }
 
 // Verify mocks base method.
-func (m *MockVerifier) Verify(arg0 vc.VerifiableCredential, allowUntrusted, checkSignature bool, validAt *time.Time) error {
+func (m *MockVerifier) Verify(ctx context.Context, arg1 vc.VerifiableCredential, allowUntrusted, checkSignature bool, validAt *time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Verify", arg0, allowUntrusted, checkSignature, validAt)
+	ret := m.ctrl.Call(m, "Verify", ctx, arg1, allowUntrusted, checkSignature, validAt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Verify indicates an expected call of Verify.
-func (mr *MockVerifierMockRecorder) Verify(arg0, allowUntrusted, checkSignature, validAt any) *gomock.Call {
+func (mr *MockVerifierMockRecorder) Verify(ctx, arg1, allowUntrusted, checkSignature, validAt any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockVerifier)(nil).Verify), arg0, allowUntrusted, checkSignature, validAt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockVerifier)(nil).Verify), ctx, arg1, allowUntrusted, checkSignature, validAt)
 }
 
 // VerifySignature mocks base method.
@@ -117,18 +118,46 @@ func (mr *MockVerifierMockRecorder) VerifySignature(credentialToVerify, at any)
 }
 
 // VerifyVP mocks base method.
-func (m *MockVerifier) VerifyVP(presentation vc.VerifiablePresentation, verifyVCs, allowUntrustedVCs bool, validAt *time.Time) ([]vc.VerifiableCredential, error) {
+func (m *MockVerifier) VerifyVP(ctx context.Context, presentation vc.VerifiablePresentation, verifyVCs, allowUntrustedVCs bool, validAt *time.Time) ([]vc.VerifiableCredential, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "VerifyVP", presentation, verifyVCs, allowUntrustedVCs, validAt)
+	ret := m.ctrl.Call(m, "VerifyVP", ctx, presentation, verifyVCs, allowUntrustedVCs, validAt)
 	ret0, _ := ret[0].([]vc.VerifiableCredential)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // VerifyVP indicates an expected call of VerifyVP.
-func (mr *MockVerifierMockRecorder) VerifyVP(presentation, verifyVCs, allowUntrustedVCs, validAt any) *gomock.Call {
+func (mr *MockVerifierMockRecorder) VerifyVP(ctx, presentation, verifyVCs, allowUntrustedVCs, validAt any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyVP", reflect.TypeOf((*MockVerifier)(nil).VerifyVP), presentation, verifyVCs, allowUntrustedVCs, validAt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyVP", reflect.TypeOf((*MockVerifier)(nil).VerifyVP), ctx, presentation, verifyVCs, allowUntrustedVCs, validAt)
+}
+
+// VerifyVPWithReport mocks base method.
+func (m *MockVerifier) VerifyVPWithReport(ctx context.Context, presentation vc.VerifiablePresentation, verifyVCs, allowUntrustedVCs bool, validAt *time.Time) PresentationVerificationReport {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyVPWithReport", ctx, presentation, verifyVCs, allowUntrustedVCs, validAt)
+	ret0, _ := ret[0].(PresentationVerificationReport)
+	return ret0
+}
+
+// VerifyVPWithReport indicates an expected call of VerifyVPWithReport.
+func (mr *MockVerifierMockRecorder) VerifyVPWithReport(ctx, presentation, verifyVCs, allowUntrustedVCs, validAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyVPWithReport", reflect.TypeOf((*MockVerifier)(nil).VerifyVPWithReport), ctx, presentation, verifyVCs, allowUntrustedVCs, validAt)
+}
+
+// VerifyWithReport mocks base method.
+func (m *MockVerifier) VerifyWithReport(ctx context.Context, arg1 vc.VerifiableCredential, allowUntrusted, checkSignature bool, validAt *time.Time) VerificationReport {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyWithReport", ctx, arg1, allowUntrusted, checkSignature, validAt)
+	ret0, _ := ret[0].(VerificationReport)
+	return ret0
+}
+
+// VerifyWithReport indicates an expected call of VerifyWithReport.
+func (mr *MockVerifierMockRecorder) VerifyWithReport(ctx, arg1, allowUntrusted, checkSignature, validAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyWithReport", reflect.TypeOf((*MockVerifier)(nil).VerifyWithReport), ctx, arg1, allowUntrusted, checkSignature, validAt)
 }
 
 // MockStore is a mock of Store interface.
@@ -0,0 +1,206 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package verifier
+
+import (
+	"errors"
+	"fmt"
+
+	ssi "github.com/nuts-foundation/go-did"
+)
+
+// CheckName identifies a check performed when verifying a credential.
+type CheckName string
+
+const (
+	// CheckFormat checks the contents of the credential: required fields, types, JSON-LD and credential type specific rules.
+	CheckFormat CheckName = "format"
+	// CheckSchema checks the credential against the JSON Schemas referenced by its credentialSchema property.
+	CheckSchema CheckName = "schema"
+	// CheckRevocation checks whether the credential has been revoked on the Nuts network.
+	CheckRevocation CheckName = "revocation"
+	// CheckCredentialStatus checks the credentialStatus (StatusList2021 or BitstringStatusList) of the credential.
+	CheckCredentialStatus CheckName = "credentialStatus"
+	// CheckTrust checks whether the issuer is trusted for the credential type.
+	CheckTrust CheckName = "trust"
+	// CheckValidityPeriod checks the issuance and expiration date of the credential.
+	CheckValidityPeriod CheckName = "validityPeriod"
+	// CheckIssuer checks whether the issuer DID could be resolved (and was not deactivated) at the time of validation.
+	CheckIssuer CheckName = "issuer"
+	// CheckSignature checks the signature of the credential or presentation, and whether the signing key was valid at the time of validation.
+	CheckSignature CheckName = "signature"
+	// CheckHolder checks whether the credentials in a presentation are presented by their subject.
+	CheckHolder CheckName = "holder"
+)
+
+// CheckStatus is the outcome of a check.
+type CheckStatus string
+
+const (
+	// CheckPassed indicates the check succeeded.
+	CheckPassed CheckStatus = "passed"
+	// CheckFailed indicates the check failed, meaning the credential is invalid.
+	CheckFailed CheckStatus = "failed"
+	// CheckSkipped indicates the check was not performed, e.g. because it wasn't requested or a check it depends on failed.
+	CheckSkipped CheckStatus = "skipped"
+	// CheckIndeterminate indicates the check could not be completed, but does not make the credential invalid (soft fail).
+	CheckIndeterminate CheckStatus = "indeterminate"
+)
+
+// CheckResult contains the outcome of a single check.
+type CheckResult struct {
+	Check  CheckName   `json:"check"`
+	Status CheckStatus `json:"status"`
+	// Reason explains why the check failed, was skipped or is indeterminate.
+	Reason string `json:"reason,omitempty"`
+	// err contains the error of a failed check.
+	err error
+}
+
+// VerificationReport contains the results of all checks performed when verifying a credential, in the order they were performed.
+type VerificationReport struct {
+	Checks []CheckResult `json:"checks"`
+}
+
+// Valid returns true if none of the checks failed.
+func (r VerificationReport) Valid() bool {
+	return r.Err() == nil
+}
+
+// Err returns the error of the first failed check, or nil if none of the checks failed.
+// It is the same error Verifier.Verify returns.
+func (r VerificationReport) Err() error {
+	for _, result := range r.Checks {
+		if result.Status == CheckFailed {
+			if result.err == nil {
+				// report wasn't created by the verifier (e.g. unmarshalled from JSON)
+				return errors.New(result.Reason)
+			}
+			return result.err
+		}
+	}
+	return nil
+}
+
+// Get returns the result of the given check, or nil if it wasn't performed.
+func (r VerificationReport) Get(name CheckName) *CheckResult {
+	for i := range r.Checks {
+		if r.Checks[i].Check == name {
+			return &r.Checks[i]
+		}
+	}
+	return nil
+}
+
+// PresentationVerificationReport contains the results of the checks performed when verifying a presentation,
+// and the reports of the credentials in the presentation.
+type PresentationVerificationReport struct {
+	VerificationReport
+	// Credentials contains the report of each credential in the presentation, in the order they were presented.
+	// It is empty if the credentials weren't verified.
+	Credentials []CredentialVerificationReport `json:"credentials,omitempty"`
+}
+
+// CredentialVerificationReport contains the verification report of a credential in a presentation.
+type CredentialVerificationReport struct {
+	// ID is the ID of the credential, if it has one.
+	ID *ssi.URI `json:"id,omitempty"`
+	VerificationReport
+}
+
+// Valid returns true if none of the checks of the presentation and its credentials failed.
+func (r PresentationVerificationReport) Valid() bool {
+	return r.Err() == nil
+}
+
+// Err returns the error of the first failed check of the presentation, or if none failed, of the first invalid credential.
+// It is the same error Verifier.VerifyVP returns.
+func (r PresentationVerificationReport) Err() error {
+	if err := r.VerificationReport.Err(); err != nil {
+		return err
+	}
+	for _, credentialReport := range r.Credentials {
+		if err := credentialReport.Err(); err != nil {
+			return newVerificationError("invalid VC (id=%s): %w", credentialReport.ID, err)
+		}
+	}
+	return nil
+}
+
+// checkOutcome is returned by a check to indicate it was skipped or indeterminate, rather than passed or failed.
+type checkOutcome struct {
+	status CheckStatus
+	reason string
+}
+
+func (c checkOutcome) Error() string {
+	return c.reason
+}
+
+func skipCheck(format string, args ...interface{}) error {
+	return checkOutcome{status: CheckSkipped, reason: fmt.Sprintf(format, args...)}
+}
+
+func indeterminateCheck(cause error) error {
+	return checkOutcome{status: CheckIndeterminate, reason: cause.Error()}
+}
+
+// verificationCheck is a single check performed when verifying a credential.
+type verificationCheck struct {
+	name CheckName
+	run  func() error
+	// dependsOn lists the checks that must pass for this check to be performed.
+	dependsOn []CheckName
+}
+
+// runChecks performs the given checks. If failFast is true, it stops at the first failed check.
+// Otherwise, all checks are performed, except those depending on a check that did not pass.
+func runChecks(checks []verificationCheck, failFast bool) VerificationReport {
+	var report VerificationReport
+	for _, check := range checks {
+		result := CheckResult{Check: check.name}
+		for _, dependency := range check.dependsOn {
+			if dependencyResult := report.Get(dependency); dependencyResult != nil && dependencyResult.Status != CheckPassed {
+				result.Status = CheckSkipped
+				result.Reason = fmt.Sprintf("check '%s' did not pass", dependency)
+				break
+			}
+		}
+		if result.Status == "" {
+			err := check.run()
+			var outcome checkOutcome
+			switch {
+			case err == nil:
+				result.Status = CheckPassed
+			case errors.As(err, &outcome):
+				result.Status = outcome.status
+				result.Reason = outcome.reason
+			default:
+				result.Status = CheckFailed
+				result.Reason = err.Error()
+				result.err = err
+			}
+		}
+		report.Checks = append(report.Checks, result)
+		if failFast && result.Status == CheckFailed {
+			break
+		}
+	}
+	return report
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/jsonld"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"github.com/nuts-foundation/nuts-node/vcr/signature"
 	"github.com/nuts-foundation/nuts-node/vcr/signature/proof"
 	"github.com/nuts-foundation/nuts-node/vcr/types"
@@ -43,8 +44,14 @@ type signatureVerifier struct {
 
 var ExtractProtectedHeaders = crypto.ExtractProtectedHeaders
 
+// maxKeyBindingAge is the maximum age of the Key Binding JWT of an SD-JWT VC in a presentation.
+const maxKeyBindingAge = 5 * time.Minute
+
 // VerifySignature checks if the signature on a VP is valid at a given time
 func (sv *signatureVerifier) VerifySignature(credentialToVerify vc.VerifiableCredential, validateAt *time.Time) error {
+	if sdjwt.IsEnveloped(credentialToVerify) {
+		return sv.sdJWTSignature(credentialToVerify, validateAt)
+	}
 	switch credentialToVerify.Format() {
 	case vc.JSONLDCredentialProofFormat:
 		return sv.jsonldProof(credentialToVerify, credentialToVerify.Issuer.String(), validateAt)
@@ -55,7 +62,8 @@ func (sv *signatureVerifier) VerifySignature(credentialToVerify vc.VerifiableCre
 	}
 }
 
-// VerifyVPSignature checks if the signature on a VP is valid at a given time
+// VerifyVPSignature checks if the signature on a VP is valid at a given time.
+// It also verifies the Key Binding JWTs of the SD-JWT VCs in the VP, since they're bound to the VP.
 func (sv *signatureVerifier) VerifyVPSignature(presentation vc.VerifiablePresentation, validateAt *time.Time) error {
 	signerDID, err := credential.PresentationSigner(presentation)
 	if err != nil {
@@ -64,12 +72,16 @@ func (sv *signatureVerifier) VerifyVPSignature(presentation vc.VerifiablePresent
 
 	switch presentation.Format() {
 	case vc.JSONLDPresentationProofFormat:
-		return sv.jsonldProof(presentation, signerDID.String(), validateAt)
+		err = sv.jsonldProof(presentation, signerDID.String(), validateAt)
 	case vc.JWTPresentationProofFormat:
-		return sv.jwtSignature(presentation.Raw(), signerDID.String(), validateAt)
+		err = sv.jwtSignature(presentation.Raw(), signerDID.String(), validateAt)
 	default:
 		return errors.New("unsupported presentation proof format")
 	}
+	if err != nil {
+		return err
+	}
+	return sv.sdJWTKeyBindings(presentation, validateAt)
 }
 
 // jsonldProof implements the Proof Verification Algorithm: https://w3c-ccg.github.io/data-integrity-spec/#proof-verification-algorithm
@@ -148,6 +160,88 @@ func (sv *signatureVerifier) jwtSignature(jwtDocumentToVerify string, issuer str
 	return nil
 }
 
+// sdJWTSignature verifies the issuer-signed JWT of an SD-JWT VC and the integrity of its disclosures.
+// A Key Binding JWT is verified as part of the presentation the SD-JWT VC is presented in (see sdJWTKeyBindings),
+// since it's bound to the audience and nonce of that presentation.
+func (sv *signatureVerifier) sdJWTSignature(credentialToVerify vc.VerifiableCredential, at *time.Time) error {
+	sdJWT, err := sdjwt.FromCredential(credentialToVerify)
+	if err != nil {
+		return newVerificationError("invalid SD-JWT VC: %w", err)
+	}
+	// processing the disclosures verifies they're referenced by the issuer-signed JWT
+	view, err := sdJWT.Credential()
+	if err != nil {
+		return newVerificationError("invalid SD-JWT VC: %w", err)
+	}
+	return sv.jwtSignature(sdJWT.IssuerJWT, view.Issuer.String(), at)
+}
+
+// sdJWTKeyBindings verifies the Key Binding JWTs of the SD-JWT VCs in the presentation.
+// They must be signed by the credential subject, be issued recently and contain the audience and nonce of the presentation,
+// so an SD-JWT VC can't be replayed in another presentation.
+func (sv *signatureVerifier) sdJWTKeyBindings(presentation vc.VerifiablePresentation, at *time.Time) error {
+	var options *sdjwt.KeyBindingOptions
+	for _, current := range presentation.VerifiableCredential {
+		if !sdjwt.IsEnveloped(current) {
+			continue
+		}
+		sdJWT, err := sdjwt.FromCredential(current)
+		if err != nil {
+			return newVerificationError("invalid SD-JWT VC: %w", err)
+		}
+		if sdJWT.KeyBindingJWT == "" {
+			continue
+		}
+		if options == nil {
+			if options, err = keyBindingOptions(presentation, at); err != nil {
+				return toVerificationError(err)
+			}
+		}
+		view, err := sdJWT.Credential()
+		if err != nil {
+			return newVerificationError("invalid SD-JWT VC: %w", err)
+		}
+		subjectDID, err := view.SubjectDID()
+		if err != nil {
+			return newVerificationError("invalid SD-JWT VC: %w", err)
+		}
+		if _, err = sdJWT.ValidateKeyBinding(*options); err != nil {
+			return toVerificationError(err)
+		}
+		if err = sv.jwtSignature(sdJWT.KeyBindingJWT, subjectDID.String(), at); err != nil {
+			return fmt.Errorf("invalid Key Binding JWT: %w", err)
+		}
+	}
+	return nil
+}
+
+// keyBindingOptions returns the claims the Key Binding JWTs in the presentation must contain:
+// the audience and nonce of the presentation (aud and nonce claims for JWTs, domain and nonce properties for JSON-LD proofs).
+func keyBindingOptions(presentation vc.VerifiablePresentation, at *time.Time) (*sdjwt.KeyBindingOptions, error) {
+	result := sdjwt.KeyBindingOptions{MaxAge: maxKeyBindingAge}
+	if at != nil {
+		result.At = *at
+	}
+	switch presentation.Format() {
+	case vc.JWTPresentationProofFormat:
+		result.Audience = presentation.JWT().Audience()
+		nonce, _ := presentation.JWT().Get("nonce")
+		result.Nonce, _ = nonce.(string)
+	case vc.JSONLDPresentationProofFormat:
+		ldProof, err := credential.ParseLDProof(presentation)
+		if err != nil {
+			return nil, err
+		}
+		if ldProof.Domain != nil {
+			result.Audience = []string{*ldProof.Domain}
+		}
+		if ldProof.Nonce != nil {
+			result.Nonce = *ldProof.Nonce
+		}
+	}
+	return &result, nil
+}
+
 func (sv *signatureVerifier) resolveSigningKey(kid string, issuer string, metadata *resolver.ResolveMetadata) (crypt.PublicKey, error) {
 	// Compatibility: VC data model v1 puts key discovery out of scope and does not require the `kid` header.
 	// When `kid` isn't present use the JWT issuer as `kid`, then it is at least compatible with DID methods that contain a single verification method (did:jwk).
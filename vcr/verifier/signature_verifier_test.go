@@ -41,6 +41,7 @@ import (
 	"time"
 
 	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jwt"
 	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/go-did/vc"
@@ -48,6 +49,7 @@ import (
 	nutsCrypto "github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/crypto/storage/spi"
 	"github.com/nuts-foundation/nuts-node/jsonld"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"github.com/nuts-foundation/nuts-node/vdr/didjwk"
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
 	"github.com/stretchr/testify/assert"
@@ -206,6 +208,158 @@ func TestSignatureVerifier_VerifySignature(t *testing.T) {
 			assert.EqualError(t, err, "unable to validate JWT signature: could not verify message using any of the signatures or keys")
 		})
 	})
+	t.Run("SD-JWT", func(t *testing.T) {
+		// Create did:jwk for issuer, which also is the subject (so it can sign the Key Binding JWT)
+		keyStore := nutsCrypto.NewMemoryCryptoInstance(t)
+		kid, key, err := keyStore.New(audit.TestContext(), func(key crypto.PublicKey) (string, error) {
+			keyAsJWK, _ := jwk.FromRaw(key)
+			keyJSON, _ := json.Marshal(keyAsJWK)
+			return "did:jwk:" + base64.RawStdEncoding.EncodeToString(keyJSON) + "#0", nil
+		})
+		require.NoError(t, err)
+		signer := func(ctx context.Context, claims map[string]interface{}, headers map[string]interface{}) (string, error) {
+			return keyStore.SignJWT(ctx, claims, headers, kid.KID)
+		}
+		issuerDID := did.MustParseDIDURL(kid.KID).DID
+
+		template := testCredential(t)
+		template.Issuer = issuerDID.URI()
+		template.CredentialSubject = []interface{}{map[string]interface{}{
+			"id":   issuerDID.String(),
+			"name": "Alice",
+		}}
+		sdJWT, err := sdjwt.Create(audit.TestContext(), template, nil, signer)
+		require.NoError(t, err)
+		withKeyBinding := func(t *testing.T, sdJWT sdjwt.SDJWT) sdjwt.SDJWT {
+			sdJWT.KeyBindingJWT, err = signer(audit.TestContext(), sdJWT.KeyBindingClaims("did:example:verifier", "nonce"), map[string]interface{}{
+				"typ": sdjwt.KeyBindingJWTType,
+			})
+			require.NoError(t, err)
+			return sdJWT
+		}
+		envelope := func(t *testing.T, sdJWT sdjwt.SDJWT) vc.VerifiableCredential {
+			result, err := sdJWT.Envelope()
+			require.NoError(t, err)
+			return *result
+		}
+
+		t.Run("ok", func(t *testing.T) {
+			sv, mockKeyResolver := signatureVerifierTestSetup(t)
+			mockKeyResolver.EXPECT().ResolveKeyByID(kid.KID, gomock.Any(), resolver.NutsSigningKeyType).Return(key, nil)
+
+			err := sv.VerifySignature(envelope(t, *sdJWT), nil)
+
+			assert.NoError(t, err)
+		})
+		t.Run("key binding is not verified outside of a presentation", func(t *testing.T) {
+			sv, mockKeyResolver := signatureVerifierTestSetup(t)
+			mockKeyResolver.EXPECT().ResolveKeyByID(kid.KID, gomock.Any(), resolver.NutsSigningKeyType).Return(key, nil)
+
+			err := sv.VerifySignature(envelope(t, withKeyBinding(t, *sdJWT)), nil)
+
+			assert.NoError(t, err)
+		})
+		t.Run("disclosure not referenced by SD-JWT", func(t *testing.T) {
+			sv, _ := signatureVerifierTestSetup(t)
+			disclosure, err := sdjwt.NewDisclosure("name", "Bob")
+			require.NoError(t, err)
+			tampered := *sdJWT
+			tampered.Disclosures = []sdjwt.Disclosure{*disclosure}
+
+			err = sv.VerifySignature(envelope(t, tampered), nil)
+
+			assert.EqualError(t, err, "verification error: invalid SD-JWT VC: disclosure is not referenced by the SD-JWT (claim: 'name')")
+		})
+		t.Run("key binding in presentation", func(t *testing.T) {
+			presentation := func(t *testing.T, audience string, nonce string, credentials ...vc.VerifiableCredential) vc.VerifiablePresentation {
+				claims := map[string]interface{}{
+					jwt.IssuerKey:   issuerDID.String(),
+					jwt.SubjectKey:  issuerDID.String(),
+					jwt.AudienceKey: audience,
+					"nonce":         nonce,
+					"vp": vc.VerifiablePresentation{
+						Type:                 []ssi.URI{vc.VerifiablePresentationTypeV1URI()},
+						VerifiableCredential: credentials,
+					},
+				}
+				token, err := signer(audit.TestContext(), claims, map[string]interface{}{"typ": "JWT"})
+				require.NoError(t, err)
+				result, err := vc.ParseVerifiablePresentation(token)
+				require.NoError(t, err)
+				return *result
+			}
+			t.Run("ok", func(t *testing.T) {
+				sv, mockKeyResolver := signatureVerifierTestSetup(t)
+				mockKeyResolver.EXPECT().ResolveKeyByID(kid.KID, gomock.Any(), resolver.NutsSigningKeyType).Return(key, nil).Times(2)
+				vp := presentation(t, "did:example:verifier", "nonce", envelope(t, withKeyBinding(t, *sdJWT)))
+
+				err := sv.VerifyVPSignature(vp, nil)
+
+				assert.NoError(t, err)
+			})
+			t.Run("ok - without key binding", func(t *testing.T) {
+				sv, mockKeyResolver := signatureVerifierTestSetup(t)
+				mockKeyResolver.EXPECT().ResolveKeyByID(kid.KID, gomock.Any(), resolver.NutsSigningKeyType).Return(key, nil)
+				vp := presentation(t, "did:example:verifier", "nonce", envelope(t, *sdJWT))
+
+				err := sv.VerifyVPSignature(vp, nil)
+
+				assert.NoError(t, err)
+			})
+			t.Run("audience does not match presentation", func(t *testing.T) {
+				sv, mockKeyResolver := signatureVerifierTestSetup(t)
+				mockKeyResolver.EXPECT().ResolveKeyByID(kid.KID, gomock.Any(), resolver.NutsSigningKeyType).Return(key, nil)
+				vp := presentation(t, "did:example:other", "nonce", envelope(t, withKeyBinding(t, *sdJWT)))
+
+				err := sv.VerifyVPSignature(vp, nil)
+
+				assert.EqualError(t, err, "verification error: invalid Key Binding JWT: 'aud' does not match presentation (aud=did:example:verifier)")
+			})
+			t.Run("nonce does not match presentation", func(t *testing.T) {
+				sv, mockKeyResolver := signatureVerifierTestSetup(t)
+				mockKeyResolver.EXPECT().ResolveKeyByID(kid.KID, gomock.Any(), resolver.NutsSigningKeyType).Return(key, nil)
+				vp := presentation(t, "did:example:verifier", "other", envelope(t, withKeyBinding(t, *sdJWT)))
+
+				err := sv.VerifyVPSignature(vp, nil)
+
+				assert.EqualError(t, err, "verification error: invalid Key Binding JWT: 'nonce' does not match presentation")
+			})
+			t.Run("key binding too old", func(t *testing.T) {
+				sv, mockKeyResolver := signatureVerifierTestSetup(t)
+				mockKeyResolver.EXPECT().ResolveKeyByID(kid.KID, gomock.Any(), resolver.NutsSigningKeyType).Return(key, nil)
+				vp := presentation(t, "did:example:verifier", "nonce", envelope(t, withKeyBinding(t, *sdJWT)))
+				validAt := time.Now().Add(maxKeyBindingAge + time.Minute)
+
+				err := sv.VerifyVPSignature(vp, &validAt)
+
+				assert.EqualError(t, err, "verification error: invalid Key Binding JWT: too old (max age: 5m0s)")
+			})
+			t.Run("key binding does not match disclosures", func(t *testing.T) {
+				sv, mockKeyResolver := signatureVerifierTestSetup(t)
+				mockKeyResolver.EXPECT().ResolveKeyByID(kid.KID, gomock.Any(), resolver.NutsSigningKeyType).Return(key, nil)
+				tampered := withKeyBinding(t, *sdJWT)
+				tampered.Disclosures = nil
+				vp := presentation(t, "did:example:verifier", "nonce", envelope(t, tampered))
+
+				err := sv.VerifyVPSignature(vp, nil)
+
+				assert.EqualError(t, err, "verification error: invalid Key Binding JWT: 'sd_hash' does not match SD-JWT")
+			})
+			t.Run("key binding not signed by subject", func(t *testing.T) {
+				sv, mockKeyResolver := signatureVerifierTestSetup(t)
+				mockKeyResolver.EXPECT().ResolveKeyByID(kid.KID, gomock.Any(), resolver.NutsSigningKeyType).Return(key, nil).Times(2)
+				otherSubject := template
+				otherSubject.CredentialSubject = []interface{}{map[string]interface{}{"id": "did:example:other"}}
+				other, err := sdjwt.Create(audit.TestContext(), otherSubject, nil, signer)
+				require.NoError(t, err)
+				vp := presentation(t, "did:example:verifier", "nonce", envelope(t, withKeyBinding(t, *other)))
+
+				err = sv.VerifyVPSignature(vp, nil)
+
+				assert.ErrorIs(t, err, errVerificationMethodNotOfIssuer)
+			})
+		})
+	})
 
 	t.Run("error - invalid vm", func(t *testing.T) {
 		sv, _ := signatureVerifierTestSetup(t)
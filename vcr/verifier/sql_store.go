@@ -0,0 +1,143 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package verifier
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-leia/v4"
+	"github.com/nuts-foundation/go-stoabs"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/nuts-foundation/nuts-node/vcr/credential"
+	"github.com/nuts-foundation/nuts-node/vcr/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// revocationBackupShelf is the backup shelf of the (legacy) Leia verifier store.
+const revocationBackupShelf = "revocations"
+
+var _ Store = &sqlStore{}
+
+var _ schema.Tabler = &revocationRecord{}
+
+// revocationRecord is a credential.Revocation stored in the SQL database.
+type revocationRecord struct {
+	// ID is the hex-encoded SHA-256 hash of Raw.
+	ID string `gorm:"primaryKey"`
+	// SubjectID contains the ID of the revoked credential.
+	SubjectID string
+	// Issuer contains the issuer of the revoked credential.
+	Issuer string
+	// Raw contains the revocation as JSON.
+	Raw string
+}
+
+func (r revocationRecord) TableName() string {
+	return "credential_revocation"
+}
+
+// sqlStore implements the verifier Store interface using a SQL database.
+// Unlike the Leia store, it can be used in a clustered setup.
+type sqlStore struct {
+	db *gorm.DB
+}
+
+// NewSQLStore creates a new verifier Store backed by the given SQL database.
+func NewSQLStore(db *gorm.DB) Store {
+	return &sqlStore{db: db}
+}
+
+func (s sqlStore) StoreRevocation(revocation credential.Revocation) error {
+	data, _ := json.Marshal(revocation)
+	return storeRevocation(s.db, revocation, data)
+}
+
+func storeRevocation(db *gorm.DB, revocation credential.Revocation, data []byte) error {
+	hash := sha256.Sum256(data)
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&revocationRecord{
+		ID:        hex.EncodeToString(hash[:]),
+		SubjectID: revocation.Subject.String(),
+		Issuer:    revocation.Issuer.String(),
+		Raw:       string(data),
+	}).Error
+}
+
+func (s sqlStore) GetRevocations(id ssi.URI) ([]*credential.Revocation, error) {
+	var records []revocationRecord
+	if err := s.db.Find(&records, "subject_id = ?", id.String()).Error; err != nil {
+		return nil, fmt.Errorf("error while getting revocation by id: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, ErrNotFound
+	}
+	revocations := make([]*credential.Revocation, len(records))
+	for i, record := range records {
+		revocation := &credential.Revocation{}
+		if err := json.Unmarshal([]byte(record.Raw), revocation); err != nil {
+			return nil, err
+		}
+		revocations[i] = revocation
+	}
+	return revocations, nil
+}
+
+func (s sqlStore) Close() error {
+	// closed by storage module
+	return nil
+}
+
+func (s sqlStore) Diagnostics() []core.DiagnosticResult {
+	var count int64
+	if err := s.db.Model(&revocationRecord{}).Count(&count).Error; err != nil {
+		count = -1
+		log.Logger().
+			WithError(err).
+			Warn("unable to retrieve revocations count")
+	}
+	return []core.DiagnosticResult{
+		core.GenericDiagnosticResult{
+			Title:   "revocations_count",
+			Outcome: int(count),
+		},
+	}
+}
+
+// MigrateLeiaStore copies the revocations of the (legacy) Leia verifier store to the SQL database, once.
+// Revocations that can't be stored in SQL (e.g. because they're invalid) are skipped.
+func MigrateLeiaStore(db *gorm.DB, dbPath string, backupStore stoabs.KVStore) error {
+	config := storage.LeiaBackupConfiguration{
+		CollectionName: "revocations",
+		CollectionType: leia.JSONCollection,
+		BackupShelf:    revocationBackupShelf,
+		SearchQuery:    leia.NewJSONPath(credential.RevocationSubjectPath),
+	}
+	return storage.MigrateLeiaCollection(db, "vcr_revocations", dbPath, backupStore, config, func(tx *gorm.DB, doc leia.Document) error {
+		var revocation credential.Revocation
+		if err := json.Unmarshal(doc, &revocation); err != nil {
+			return fmt.Errorf("invalid revocation in Leia store: %w", err)
+		}
+		return storeRevocation(tx, revocation, doc)
+	})
+}
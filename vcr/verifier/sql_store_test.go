@@ -0,0 +1,207 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package verifier
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/json"
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-leia/v4"
+	"github.com/nuts-foundation/go-stoabs"
+	"github.com/nuts-foundation/go-stoabs/bbolt"
+	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/nuts-foundation/nuts-node/test/io"
+	"github.com/nuts-foundation/nuts-node/vcr/credential"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"path"
+	"testing"
+)
+
+func Test_sqlStore_StoreRevocation(t *testing.T) {
+	db := newTestDB(t)
+	sut := NewSQLStore(db)
+	subjectID := ssi.MustParseURI("did:nuts:123#ab-c")
+	revocation := credential.Revocation{Issuer: ssi.MustParseURI("did:nuts:123"), Subject: subjectID}
+
+	t.Run("ok", func(t *testing.T) {
+		err := sut.StoreRevocation(revocation)
+
+		require.NoError(t, err)
+		result, err := sut.GetRevocations(subjectID)
+		require.NoError(t, err)
+		assert.Equal(t, []*credential.Revocation{&revocation}, result)
+	})
+	t.Run("storing the same revocation twice is idempotent", func(t *testing.T) {
+		err := sut.StoreRevocation(revocation)
+
+		require.NoError(t, err)
+		result, err := sut.GetRevocations(subjectID)
+		require.NoError(t, err)
+		assert.Len(t, result, 1)
+	})
+}
+
+func Test_sqlStore_GetRevocations(t *testing.T) {
+	db := newTestDB(t)
+	sut := NewSQLStore(db)
+	subjectID := ssi.MustParseURI("did:nuts:123#ab-c")
+	revocation := &credential.Revocation{Subject: subjectID}
+	require.NoError(t, sut.StoreRevocation(*revocation))
+
+	t.Run("it can find a revocation", func(t *testing.T) {
+		result, err := sut.GetRevocations(subjectID)
+		assert.NoError(t, err)
+		assert.Equal(t, []*credential.Revocation{revocation}, result)
+	})
+
+	t.Run("it returns a ErrNotFound when revocation could not be found", func(t *testing.T) {
+		unknownSubjectID := ssi.MustParseURI("did:nuts:456#ab-cde")
+		result, err := sut.GetRevocations(unknownSubjectID)
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.Nil(t, result)
+	})
+}
+
+func Test_sqlStore_Diagnostics(t *testing.T) {
+	db := newTestDB(t)
+	sut := NewSQLStore(db)
+
+	t.Run("empty", func(t *testing.T) {
+		actual := sut.Diagnostics()
+		assert.Len(t, actual, 1)
+		assert.Equal(t, "revocations_count", actual[0].Name())
+		assert.Equal(t, 0, actual[0].Result())
+	})
+	t.Run("not empty", func(t *testing.T) {
+		_ = sut.StoreRevocation(credential.Revocation{Subject: ssi.MustParseURI("did:nuts:123#ab-c")})
+
+		actual := sut.Diagnostics()
+		assert.Len(t, actual, 1)
+		assert.Equal(t, 1, actual[0].Result())
+	})
+}
+
+func TestMigrateLeiaStore(t *testing.T) {
+	subjectID := ssi.MustParseURI("did:nuts:123#ab-c")
+	revocation := &credential.Revocation{Subject: subjectID}
+	revocationBytes, _ := json.Marshal(revocation)
+
+	t.Run("from Leia store", func(t *testing.T) {
+		db := newTestDB(t)
+		testDir := io.TestDirectory(t)
+		leiaStorePath := path.Join(testDir, "vcr", "verifier-store.db")
+		leiaStore, err := leia.NewStore(leiaStorePath)
+		require.NoError(t, err)
+		require.NoError(t, leiaStore.Collection(leia.JSONCollection, "revocations").Add([]leia.Document{revocationBytes}))
+		require.NoError(t, leiaStore.Close())
+
+		err = MigrateLeiaStore(db, leiaStorePath, newBackupStore(t, testDir))
+
+		require.NoError(t, err)
+		result, err := NewSQLStore(db).GetRevocations(subjectID)
+		require.NoError(t, err)
+		assert.Equal(t, []*credential.Revocation{revocation}, result)
+	})
+	t.Run("from backup store", func(t *testing.T) {
+		db := newTestDB(t)
+		testDir := io.TestDirectory(t)
+		backupStore := newBackupStore(t, testDir)
+		ref := sha1.Sum(revocationBytes)
+		_ = backupStore.WriteShelf(context.Background(), revocationBackupShelf, func(writer stoabs.Writer) error {
+			return writer.Put(stoabs.BytesKey(ref[:]), revocationBytes)
+		})
+
+		err := MigrateLeiaStore(db, path.Join(testDir, "vcr", "verifier-store.db"), backupStore)
+
+		require.NoError(t, err)
+		result, err := NewSQLStore(db).GetRevocations(subjectID)
+		require.NoError(t, err)
+		assert.Equal(t, []*credential.Revocation{revocation}, result)
+	})
+	t.Run("nothing to migrate", func(t *testing.T) {
+		db := newTestDB(t)
+		testDir := io.TestDirectory(t)
+		leiaStorePath := path.Join(testDir, "vcr", "verifier-store.db")
+
+		err := MigrateLeiaStore(db, leiaStorePath, newBackupStore(t, testDir))
+
+		require.NoError(t, err)
+		assert.NoFileExists(t, leiaStorePath)
+	})
+	t.Run("already migrated", func(t *testing.T) {
+		db := newTestDB(t)
+		testDir := io.TestDirectory(t)
+		leiaStorePath := path.Join(testDir, "vcr", "verifier-store.db")
+		backupStore := newBackupStore(t, testDir)
+		// nothing to migrate, but the migration is recorded
+		require.NoError(t, MigrateLeiaStore(db, leiaStorePath, backupStore))
+		ref := sha1.Sum(revocationBytes)
+		_ = backupStore.WriteShelf(context.Background(), revocationBackupShelf, func(writer stoabs.Writer) error {
+			return writer.Put(stoabs.BytesKey(ref[:]), revocationBytes)
+		})
+
+		err := MigrateLeiaStore(db, leiaStorePath, backupStore)
+
+		require.NoError(t, err)
+		_, err = NewSQLStore(db).GetRevocations(subjectID)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+	t.Run("invalid revocations are skipped", func(t *testing.T) {
+		db := newTestDB(t)
+		testDir := io.TestDirectory(t)
+		backupStore := newBackupStore(t, testDir)
+		invalid := []byte(`{"subject": 1}`)
+		_ = backupStore.WriteShelf(context.Background(), revocationBackupShelf, func(writer stoabs.Writer) error {
+			invalidRef := sha1.Sum(invalid)
+			if err := writer.Put(stoabs.BytesKey(invalidRef[:]), invalid); err != nil {
+				return err
+			}
+			ref := sha1.Sum(revocationBytes)
+			return writer.Put(stoabs.BytesKey(ref[:]), revocationBytes)
+		})
+
+		err := MigrateLeiaStore(db, path.Join(testDir, "vcr", "verifier-store.db"), backupStore)
+
+		require.NoError(t, err)
+		result, err := NewSQLStore(db).GetRevocations(subjectID)
+		require.NoError(t, err)
+		assert.Len(t, result, 1)
+	})
+}
+
+func newTestDB(t *testing.T) *gorm.DB {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	t.Cleanup(func() {
+		_ = storageEngine.Shutdown()
+	})
+	return storageEngine.GetSQLDatabase()
+}
+
+func newBackupStore(t *testing.T, testDir string) stoabs.KVStore {
+	backupStore, err := bbolt.CreateBBoltStore(path.Join(testDir, "vcr", "backup-revoked-credentials.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = backupStore.Close(context.Background())
+	})
+	return backupStore
+}
@@ -19,11 +19,13 @@
 package verifier
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/nuts-foundation/nuts-node/pki"
 	"github.com/nuts-foundation/nuts-node/vcr/revocation"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"strings"
 	"time"
 
@@ -58,6 +60,7 @@ type verifier struct {
 	signatureVerifier
 	credentialStatus revocation.StatusList2021Verifier
 	pkiValidator     pki.Validator
+	schemaValidator  *credential.SchemaValidator
 }
 
 // VerificationError is used to describe a VC/VP verification failure.
@@ -85,7 +88,8 @@ func (e VerificationError) Error() string {
 }
 
 // NewVerifier creates a new instance of the verifier. It needs a key resolver for validating signatures.
-func NewVerifier(store Store, didResolver resolver.DIDResolver, keyResolver resolver.KeyResolver, jsonldManager jsonld.JSONLD, trustConfig *trust.Config, credentialStatus *revocation.StatusList2021, pkiValidator pki.Validator) Verifier {
+func NewVerifier(store Store, didResolver resolver.DIDResolver, keyResolver resolver.KeyResolver, jsonldManager jsonld.JSONLD, trustConfig *trust.Config,
+	credentialStatus *revocation.StatusList2021, pkiValidator pki.Validator, schemaValidator *credential.SchemaValidator) Verifier {
 	v := &verifier{store: store, didResolver: didResolver, keyResolver: keyResolver, jsonldManager: jsonldManager, trustConfig: trustConfig,
 		signatureVerifier: signatureVerifier{
 			keyResolver:   keyResolver,
@@ -93,95 +97,194 @@ func NewVerifier(store Store, didResolver resolver.DIDResolver, keyResolver reso
 		},
 		credentialStatus: credentialStatus,
 		pkiValidator:     pkiValidator,
+		schemaValidator:  schemaValidator,
 	}
 	credentialStatus.VerifySignature = v.VerifySignature
+	if schemaValidator != nil {
+		schemaValidator.VerifySchemaCredential = func(schemaCredential vc.VerifiableCredential) error {
+			return v.VerifySignature(schemaCredential, nil)
+		}
+	}
 	return v
 }
 
 // Verify implements the verify interface.
 // It currently checks if the credential has the required fields and values, if it is valid at the given time and optional the signature.
-func (v verifier) Verify(credentialToVerify vc.VerifiableCredential, allowUntrusted bool, checkSignature bool, validAt *time.Time) error {
-	// it must have valid content
-	validator := credential.FindValidator(credentialToVerify, v.pkiValidator)
-	if err := validator.Validate(credentialToVerify); err != nil {
-		return err
-	}
-	// We only accept VCs with at most 2 types: "VerifiableCredential" and a specific type
-	// The Validate above already checks "VerifiableCredential" is one of them
-	// This is a custom requirement
-	if len(credentialToVerify.Type) > 2 {
-		return errors.New("verifiable credential must list at most 2 types")
-	}
-
-	// Check revocation status
-	if credentialToVerify.ID != nil {
-		revoked, err := v.IsRevoked(*credentialToVerify.ID)
-		if err != nil {
-			return err
-		}
-		if revoked {
-			return types.ErrRevoked
-		}
-
-	}
-
-	// Check the credentialStatus if the credential is revoked
-	err := v.credentialStatus.Verify(credentialToVerify)
-	if err != nil {
-		// soft fail, only return an error when revocation is confirmed and log everything else
-		if errors.Is(err, types.ErrRevoked) {
-			return err
-		} else {
-			// TODO: what log level
-			bs, _ := json.Marshal(credentialToVerify)
-			log.Logger().WithError(err).WithField("credential", string(bs)).Info("CredentialStatus verification failed")
-		}
-	}
+func (v verifier) Verify(ctx context.Context, credentialToVerify vc.VerifiableCredential, allowUntrusted bool, checkSignature bool, validAt *time.Time) error {
+	return v.verify(ctx, credentialToVerify, allowUntrusted, checkSignature, validAt, true).Err()
+}
 
-	// Check trust status
-	if !allowUntrusted {
-		for _, t := range credentialToVerify.Type {
-			// Don't need to check type "VerifiableCredential"
-			if t.String() == verifiableCredentialType {
-				continue
-			}
-			if !v.trustConfig.IsTrusted(t, credentialToVerify.Issuer) {
-				return types.ErrUntrusted
-			}
-		}
-	}
+// VerifyWithReport implements the verify interface.
+func (v verifier) VerifyWithReport(ctx context.Context, credentialToVerify vc.VerifiableCredential, allowUntrusted bool, checkSignature bool, validAt *time.Time) VerificationReport {
+	return v.verify(ctx, credentialToVerify, allowUntrusted, checkSignature, validAt, false)
+}
 
-	// Check issuance/expiration time of the credential
-	// if the signing key is valid at the given time is checked during signature verification
+func (v verifier) verify(ctx context.Context, credentialToVerify vc.VerifiableCredential, allowUntrusted bool, checkSignature bool, validAt *time.Time, failFast bool) VerificationReport {
+	// SD-JWT VCs are checked using the credential they contain (with the included disclosures applied),
+	// only the signature is verified on the SD-JWT itself.
+	securedCredential := credentialToVerify
+	rawJwt := credentialToVerify.Raw()
 	validAtNotNil := time.Now()
 	if validAt != nil {
 		validAtNotNil = *validAt
 	}
-	if !credentialToVerify.ValidAt(validAtNotNil, maxSkew) {
-		return types.ErrCredentialNotValidAtTime
-	}
 
-	// Check signature
-	if checkSignature {
-		issuerDID, _ := did.ParseDID(credentialToVerify.Issuer.String())
-		metadata := resolver.ResolveMetadata{ResolveTime: validAt, AllowDeactivated: false}
-		rawJwt := credentialToVerify.Raw()
-		if rawJwt != "" {
-			headers, err := ExtractProtectedHeaders(rawJwt)
-			if err != nil {
+	checks := []verificationCheck{
+		{
+			name: CheckFormat,
+			run: func() error {
+				if sdjwt.IsEnveloped(credentialToVerify) {
+					sdJWT, err := sdjwt.FromCredential(credentialToVerify)
+					if err != nil {
+						return newVerificationError("invalid SD-JWT VC: %w", err)
+					}
+					view, err := sdJWT.Credential()
+					if err != nil {
+						return newVerificationError("invalid SD-JWT VC: %w", err)
+					}
+					credentialToVerify = *view
+					rawJwt = sdJWT.IssuerJWT
+				}
+				// it must have valid content
+				validator := credential.FindValidator(credentialToVerify, v.pkiValidator)
+				if err := validator.Validate(credentialToVerify); err != nil {
+					return err
+				}
+				// We only accept VCs with at most 2 types: "VerifiableCredential" and a specific type
+				// The Validate above already checks "VerifiableCredential" is one of them
+				// This is a custom requirement
+				if len(credentialToVerify.Type) > 2 {
+					return errors.New("verifiable credential must list at most 2 types")
+				}
+				return nil
+			},
+		},
+		{
+			name:      CheckSchema,
+			dependsOn: []CheckName{CheckFormat},
+			run: func() error {
+				schemas, err := credential.CredentialSchemas(credentialToVerify)
+				if err != nil {
+					return err
+				}
+				if len(schemas) == 0 {
+					return skipCheck("credential has no credentialSchema")
+				}
+				if v.schemaValidator == nil {
+					return indeterminateCheck(errors.New("JSON Schema validation is not available"))
+				}
+				err = v.schemaValidator.Validate(ctx, credentialToVerify)
+				if errors.Is(err, credential.ErrSchemaUnavailable) {
+					// the schema couldn't be loaded, which doesn't mean the credential is invalid
+					return indeterminateCheck(err)
+				}
 				return err
-			}
-			metadata.JwtProtectedHeaders = headers
-
-		}
-		_, _, err = v.didResolver.Resolve(*issuerDID, &metadata)
-		if err != nil {
-			return fmt.Errorf("could not validate issuer: %w", err)
-		}
-		return v.VerifySignature(credentialToVerify, validAt)
+			},
+		},
+		{
+			name:      CheckRevocation,
+			dependsOn: []CheckName{CheckFormat},
+			run: func() error {
+				if credentialToVerify.ID == nil {
+					return skipCheck("credential has no ID")
+				}
+				revoked, err := v.IsRevoked(*credentialToVerify.ID)
+				if err != nil {
+					return err
+				}
+				if revoked {
+					return types.ErrRevoked
+				}
+				return nil
+			},
+		},
+		{
+			name:      CheckCredentialStatus,
+			dependsOn: []CheckName{CheckFormat},
+			run: func() error {
+				if credentialToVerify.CredentialStatus == nil {
+					return skipCheck("credential has no credentialStatus")
+				}
+				// Check the credentialStatus if the credential is revoked
+				err := v.credentialStatus.Verify(credentialToVerify)
+				if err != nil {
+					// soft fail, only return an error when revocation or suspension is confirmed and log everything else
+					if errors.Is(err, types.ErrRevoked) || errors.Is(err, types.ErrSuspended) {
+						return err
+					}
+					// TODO: what log level
+					bs, _ := json.Marshal(credentialToVerify)
+					log.Logger().WithError(err).WithField("credential", string(bs)).Info("CredentialStatus verification failed")
+					return indeterminateCheck(err)
+				}
+				return nil
+			},
+		},
+		{
+			name:      CheckTrust,
+			dependsOn: []CheckName{CheckFormat},
+			run: func() error {
+				if allowUntrusted {
+					return skipCheck("untrusted issuers are allowed")
+				}
+				for _, t := range credentialToVerify.Type {
+					// Don't need to check type "VerifiableCredential"
+					if t.String() == verifiableCredentialType {
+						continue
+					}
+					if err := v.trustConfig.Evaluate(t, credentialToVerify.Issuer, validAtNotNil); err != nil {
+						return fmt.Errorf("%w: %w", types.ErrUntrusted, err)
+					}
+				}
+				return nil
+			},
+		},
+		{
+			// Check issuance/expiration time of the credential
+			// if the signing key is valid at the given time is checked during signature verification
+			name:      CheckValidityPeriod,
+			dependsOn: []CheckName{CheckFormat},
+			run: func() error {
+				if !credentialToVerify.ValidAt(validAtNotNil, maxSkew) {
+					return types.ErrCredentialNotValidAtTime
+				}
+				return nil
+			},
+		},
+		{
+			name:      CheckIssuer,
+			dependsOn: []CheckName{CheckFormat},
+			run: func() error {
+				if !checkSignature {
+					return skipCheck("signature verification not requested")
+				}
+				issuerDID, _ := did.ParseDID(credentialToVerify.Issuer.String())
+				metadata := resolver.ResolveMetadata{ResolveTime: validAt, AllowDeactivated: false}
+				if rawJwt != "" {
+					headers, err := ExtractProtectedHeaders(rawJwt)
+					if err != nil {
+						return err
+					}
+					metadata.JwtProtectedHeaders = headers
+				}
+				if _, _, err := v.didResolver.Resolve(*issuerDID, &metadata); err != nil {
+					return fmt.Errorf("could not validate issuer: %w", err)
+				}
+				return nil
+			},
+		},
+		{
+			name:      CheckSignature,
+			dependsOn: []CheckName{CheckFormat},
+			run: func() error {
+				if !checkSignature {
+					return skipCheck("signature verification not requested")
+				}
+				return v.VerifySignature(securedCredential, validAt)
+			},
+		},
 	}
-
-	return nil
+	return runChecks(checks, failFast)
 }
 
 func (v *verifier) IsRevoked(credentialID ssi.URI) (bool, error) {
@@ -255,41 +358,24 @@ func (v *verifier) RegisterRevocation(revocation credential.Revocation) error {
 	return nil
 }
 
-func (v verifier) VerifyVP(vp vc.VerifiablePresentation, verifyVCs bool, allowUntrustedVCs bool, validAt *time.Time) ([]vc.VerifiableCredential, error) {
-	return v.doVerifyVP(&v, vp, verifyVCs, allowUntrustedVCs, validAt)
+func (v verifier) VerifyVP(ctx context.Context, vp vc.VerifiablePresentation, verifyVCs bool, allowUntrustedVCs bool, validAt *time.Time) ([]vc.VerifiableCredential, error) {
+	return v.doVerifyVP(ctx, &v, vp, verifyVCs, allowUntrustedVCs, validAt)
 }
 
-// doVerifyVP delegates VC verification to the supplied Verifier, to aid unit testing.
-func (v verifier) doVerifyVP(vcVerifier Verifier, presentation vc.VerifiablePresentation, verifyVCs bool, allowUntrustedVCs bool, validAt *time.Time) ([]vc.VerifiableCredential, error) {
-	// custom requirement: credentials may only be presented by subject
-	subjectDID, err := credential.PresenterIsCredentialSubject(presentation)
-	if err != nil {
-		return nil, newVerificationError("presenter is credential subject: %w", err)
-	} else if subjectDID == nil && len(presentation.VerifiableCredential) > 0 {
-		return nil, newVerificationError("credential(s) must be presented by subject")
-	}
-
-	// we only support presenter = holder = credential subject.
-	// Check optional holder property (required for self-attesting VCs)
-	if subjectDID != nil && presentation.Holder != nil && presentation.Holder.String() != subjectDID.String() {
-		return nil, newVerificationError("presentation holder must equal credential subject")
-	}
+// VerifyVPWithReport implements the verify interface.
+func (v verifier) VerifyVPWithReport(ctx context.Context, vp vc.VerifiablePresentation, verifyVCs bool, allowUntrustedVCs bool, validAt *time.Time) PresentationVerificationReport {
+	return v.doVerifyVPWithReport(ctx, &v, vp, verifyVCs, allowUntrustedVCs, validAt)
+}
 
-	// check signature
-	err = v.signatureVerifier.VerifyVPSignature(presentation, validAt)
-	if err != nil {
+// doVerifyVP delegates VC verification to the supplied Verifier, to aid unit testing.
+func (v verifier) doVerifyVP(ctx context.Context, vcVerifier Verifier, presentation vc.VerifiablePresentation, verifyVCs bool, allowUntrustedVCs bool, validAt *time.Time) ([]vc.VerifiableCredential, error) {
+	if err := runChecks(v.presentationChecks(presentation, validAt), true).Err(); err != nil {
 		return nil, err
 	}
 
 	if verifyVCs {
 		for _, current := range presentation.VerifiableCredential {
-			checkSignature := true
-			if presentation.Holder != nil && presentation.Holder.String() == current.Issuer.String() {
-				// self-attested VC: https://www.w3.org/TR/vc-data-model-2.0/#presentations-including-holder-claims
-				// These don't need a proof, since they're already protected by the VP's proof.
-				checkSignature = len(current.Proof) > 0
-			}
-			err = vcVerifier.Verify(current, allowUntrustedVCs, checkSignature, validAt)
+			err := vcVerifier.Verify(ctx, current, allowUntrustedVCs, credentialSignatureRequired(presentation, current), validAt)
 			if err != nil {
 				return nil, newVerificationError("invalid VC (id=%s): %w", current.ID, err)
 			}
@@ -298,3 +384,60 @@ func (v verifier) doVerifyVP(vcVerifier Verifier, presentation vc.VerifiablePres
 
 	return presentation.VerifiableCredential, nil
 }
+
+// doVerifyVPWithReport delegates VC verification to the supplied Verifier, to aid unit testing.
+func (v verifier) doVerifyVPWithReport(ctx context.Context, vcVerifier Verifier, presentation vc.VerifiablePresentation, verifyVCs bool, allowUntrustedVCs bool, validAt *time.Time) PresentationVerificationReport {
+	report := PresentationVerificationReport{
+		VerificationReport: runChecks(v.presentationChecks(presentation, validAt), false),
+	}
+	if verifyVCs {
+		for _, current := range presentation.VerifiableCredential {
+			report.Credentials = append(report.Credentials, CredentialVerificationReport{
+				ID:                 current.ID,
+				VerificationReport: vcVerifier.VerifyWithReport(ctx, current, allowUntrustedVCs, credentialSignatureRequired(presentation, current), validAt),
+			})
+		}
+	}
+	return report
+}
+
+// presentationChecks returns the checks performed on the presentation itself, not on the credentials it contains.
+func (v verifier) presentationChecks(presentation vc.VerifiablePresentation, validAt *time.Time) []verificationCheck {
+	return []verificationCheck{
+		{
+			name: CheckHolder,
+			run: func() error {
+				// custom requirement: credentials may only be presented by subject
+				subjectDID, err := credential.PresenterIsCredentialSubject(presentation)
+				if err != nil {
+					return newVerificationError("presenter is credential subject: %w", err)
+				} else if subjectDID == nil && len(presentation.VerifiableCredential) > 0 {
+					return newVerificationError("credential(s) must be presented by subject")
+				}
+
+				// we only support presenter = holder = credential subject.
+				// Check optional holder property (required for self-attesting VCs)
+				if subjectDID != nil && presentation.Holder != nil && presentation.Holder.String() != subjectDID.String() {
+					return newVerificationError("presentation holder must equal credential subject")
+				}
+				return nil
+			},
+		},
+		{
+			name: CheckSignature,
+			run: func() error {
+				return v.signatureVerifier.VerifyVPSignature(presentation, validAt)
+			},
+		},
+	}
+}
+
+// credentialSignatureRequired returns whether the signature of a credential in the presentation must be verified.
+func credentialSignatureRequired(presentation vc.VerifiablePresentation, current vc.VerifiableCredential) bool {
+	if presentation.Holder != nil && presentation.Holder.String() == current.Issuer.String() {
+		// self-attested VC: https://www.w3.org/TR/vc-data-model-2.0/#presentations-including-holder-claims
+		// These don't need a proof, since they're already protected by the VP's proof.
+		return len(current.Proof) > 0
+	}
+	return true
+}
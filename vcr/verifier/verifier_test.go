@@ -38,12 +38,16 @@ import (
 	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/audit"
+	nutsCrypto "github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/crypto/storage/spi"
 	"github.com/nuts-foundation/nuts-node/jsonld"
 	"github.com/nuts-foundation/nuts-node/storage"
+	http2 "github.com/nuts-foundation/nuts-node/test/http"
 	"github.com/nuts-foundation/nuts-node/test/io"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
 	"github.com/nuts-foundation/nuts-node/vcr/revocation"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"github.com/nuts-foundation/nuts-node/vcr/signature/proof"
 	"github.com/nuts-foundation/nuts-node/vcr/test"
 	"github.com/nuts-foundation/nuts-node/vcr/trust"
@@ -72,7 +76,7 @@ func TestVerifier_Verify(t *testing.T) {
 		credentialType := ssi.MustParseURI("unknown type")
 		subject.Type = []ssi.URI{vc.VerifiableCredentialTypeV1URI(), credentialType}
 
-		err := instance.Verify(subject, true, false, nil)
+		err := instance.Verify(context.Background(), subject, true, false, nil)
 
 		assert.EqualError(t, err, "unknown credential type")
 	})
@@ -104,7 +108,7 @@ func TestVerifier_Verify(t *testing.T) {
 			ctx.didResolver.EXPECT().Resolve(did.MustParseDID(vc.Issuer.String()), gomock.Any()).Return(nil, nil, nil)
 			ctx.keyResolver.EXPECT().ResolveKeyByID(proofs[0].VerificationMethod.String(), gomock.Any(), resolver.NutsSigningKeyType).Return(nil, resolver.ErrKeyNotFound)
 
-			validationErr := ctx.verifier.Verify(vc, true, true, nil)
+			validationErr := ctx.verifier.Verify(context.Background(), vc, true, true, nil)
 
 			assert.EqualError(t, validationErr, "unable to resolve valid signing key: key not found in DID document")
 		})
@@ -114,7 +118,7 @@ func TestVerifier_Verify(t *testing.T) {
 			ctx.store.EXPECT().GetRevocations(*vc.ID).Return(nil, ErrNotFound)
 			ctx.didResolver.EXPECT().Resolve(did.MustParseDID(vc.Issuer.String()), gomock.Any()).Return(nil, nil, resolver.ErrDeactivated)
 
-			validationErr := ctx.verifier.Verify(vc, true, true, nil)
+			validationErr := ctx.verifier.Verify(context.Background(), vc, true, true, nil)
 
 			assert.EqualError(t, validationErr, "could not validate issuer: the DID document has been deactivated")
 		})
@@ -125,10 +129,50 @@ func TestVerifier_Verify(t *testing.T) {
 		ctx := newMockContext(t)
 		ctx.store.EXPECT().GetRevocations(*vc.ID).Return([]*credential.Revocation{{}}, nil)
 		sut := ctx.verifier
-		validationErr := sut.Verify(vc, true, false, nil)
+		validationErr := sut.Verify(context.Background(), vc, true, false, nil)
 		assert.EqualError(t, validationErr, "credential is revoked")
 	})
 
+	t.Run("SD-JWT VC", func(t *testing.T) {
+		keyStore := nutsCrypto.NewMemoryCryptoInstance(t)
+		_, key, err := keyStore.New(audit.TestContext(), nutsCrypto.StringNamingFunc(testKID))
+		require.NoError(t, err)
+		template := testCredential(t)
+		sdJWT, err := sdjwt.Create(audit.TestContext(), template, nil, func(ctx context.Context, claims map[string]interface{}, headers map[string]interface{}) (string, error) {
+			return keyStore.SignJWT(ctx, claims, headers, testKID)
+		})
+		require.NoError(t, err)
+		envelope, err := sdJWT.Envelope()
+		require.NoError(t, err)
+
+		t.Run("ok", func(t *testing.T) {
+			ctx := newMockContext(t)
+			ctx.store.EXPECT().GetRevocations(*template.ID).Return(nil, ErrNotFound)
+			ctx.didResolver.EXPECT().Resolve(did.MustParseDID(template.Issuer.String()), gomock.Any()).Return(nil, nil, nil)
+			ctx.keyResolver.EXPECT().ResolveKeyByID(testKID, gomock.Any(), resolver.NutsSigningKeyType).Return(key, nil)
+
+			err := ctx.verifier.Verify(context.Background(), *envelope, true, true, nil)
+
+			assert.NoError(t, err)
+		})
+		t.Run("untrusted issuer of contained credential", func(t *testing.T) {
+			ctx := newMockContext(t)
+			ctx.store.EXPECT().GetRevocations(*template.ID).Return(nil, ErrNotFound)
+
+			err := ctx.verifier.Verify(context.Background(), *envelope, false, false, nil)
+
+			assert.ErrorIs(t, err, types.ErrUntrusted)
+		})
+		t.Run("invalid when contained credential is revoked", func(t *testing.T) {
+			ctx := newMockContext(t)
+			ctx.store.EXPECT().GetRevocations(*template.ID).Return([]*credential.Revocation{{}}, nil)
+
+			err := ctx.verifier.Verify(context.Background(), *envelope, true, false, nil)
+
+			assert.ErrorIs(t, err, types.ErrRevoked)
+		})
+	})
+
 	t.Run("validate credentialStatus", func(t *testing.T) {
 		// make StatusList2021Credential with a revocation bit set
 		statusListCred := test.ValidStatusList2021Credential(t)
@@ -172,14 +216,14 @@ func TestVerifier_Verify(t *testing.T) {
 			slEntry.StatusListIndex = strconv.Itoa(statusListIndex + 1)
 			cred.CredentialStatus = []any{slEntry}
 
-			validationErr := ctx.verifier.Verify(cred, true, false, nil)
+			validationErr := ctx.verifier.Verify(context.Background(), cred, true, false, nil)
 
 			assert.NoError(t, validationErr)
 		})
 		t.Run("is revoked", func(t *testing.T) {
 			didAlice := did.MustParseDID("did:web:example.com:iam:alice")
 			storage.AddDIDtoSQLDB(t, db, didAlice)
-			entry, err := ctx.verifier.credentialStatus.(*revocation.StatusList2021).Entry(context.Background(), didAlice, revocation.StatusPurposeRevocation)
+			entry, err := ctx.verifier.credentialStatus.(*revocation.StatusList2021).Entry(context.Background(), didAlice, revocation.StatusList2021Type, revocation.StatusPurposeRevocation)
 			require.NoError(t, err)
 			require.NoError(t, ctx.verifier.credentialStatus.(*revocation.StatusList2021).Revoke(context.Background(), ssi.URI{}, *entry))
 			cred := test.ValidNutsOrganizationCredential(t)
@@ -190,16 +234,34 @@ func TestVerifier_Verify(t *testing.T) {
 			cred.CredentialStatus = []any{entry}
 			cred.Context = append(cred.Context, revocation.StatusList2021ContextURI)
 
-			validationErr := ctx.verifier.Verify(cred, true, false, nil)
+			validationErr := ctx.verifier.Verify(context.Background(), cred, true, false, nil)
 
 			assert.ErrorIs(t, validationErr, types.ErrRevoked)
 		})
+		t.Run("is suspended", func(t *testing.T) {
+			didBob := did.MustParseDID("did:web:example.com:iam:bob")
+			storage.AddDIDtoSQLDB(t, db, didBob)
+			entry, err := ctx.verifier.credentialStatus.(*revocation.StatusList2021).Entry(context.Background(), didBob, revocation.StatusList2021Type, revocation.StatusPurposeSuspension)
+			require.NoError(t, err)
+			require.NoError(t, ctx.verifier.credentialStatus.(*revocation.StatusList2021).Suspend(context.Background(), ssi.URI{}, *entry))
+			cred := test.ValidNutsOrganizationCredential(t)
+			credentialID := didBob.URI()
+			credentialID.Fragment = "456"
+			cred.ID = &credentialID
+			cred.Issuer = didBob.URI()
+			cred.CredentialStatus = []any{entry}
+			cred.Context = append(cred.Context, revocation.StatusList2021ContextURI)
+
+			validationErr := ctx.verifier.Verify(context.Background(), cred, true, false, nil)
+
+			assert.ErrorIs(t, validationErr, types.ErrSuspended)
+		})
 		t.Run("ignore other purpose", func(t *testing.T) {
 			slEntry.StatusListIndex = strconv.Itoa(statusListIndex)
-			slEntry.StatusPurpose = "suspension"
+			slEntry.StatusPurpose = "message"
 			cred.CredentialStatus = []any{slEntry}
 
-			validationErr := ctx.verifier.Verify(cred, true, false, nil)
+			validationErr := ctx.verifier.Verify(context.Background(), cred, true, false, nil)
 
 			assert.NoError(t, validationErr)
 		})
@@ -216,7 +278,7 @@ func TestVerifier_Verify(t *testing.T) {
 			}
 			cred.CredentialStatus = []any{slEntry}
 
-			validationErr := ctx.verifier.Verify(cred, true, false, nil)
+			validationErr := ctx.verifier.Verify(context.Background(), cred, true, false, nil)
 
 			assert.NoError(t, validationErr)
 		})
@@ -232,7 +294,7 @@ func TestVerifier_Verify(t *testing.T) {
 				_ = ctx.trustConfig.AddTrust(vcType, vc.Issuer)
 			}
 			sut := ctx.verifier
-			validationErr := sut.Verify(vc, false, false, nil)
+			validationErr := sut.Verify(context.Background(), vc, false, false, nil)
 			assert.NoError(t, validationErr)
 		})
 		t.Run("untrusted", func(t *testing.T) {
@@ -241,7 +303,7 @@ func TestVerifier_Verify(t *testing.T) {
 			ctx := newMockContext(t)
 			ctx.store.EXPECT().GetRevocations(*vc.ID).Return(nil, ErrNotFound)
 			sut := ctx.verifier
-			err := sut.Verify(vc, false, false, nil)
+			err := sut.Verify(context.Background(), vc, false, false, nil)
 			assert.ErrorIs(t, err, types.ErrUntrusted)
 		})
 	})
@@ -252,7 +314,7 @@ func TestVerifier_Verify(t *testing.T) {
 			ctx := newMockContext(t)
 			ctx.store.EXPECT().GetRevocations(*vc.ID).Return(nil, ErrNotFound)
 			sut := ctx.verifier
-			validationErr := sut.Verify(vc, true, false, nil)
+			validationErr := sut.Verify(context.Background(), vc, true, false, nil)
 			assert.NoError(t, validationErr,
 				"expected no error when validating a valid vc")
 		})
@@ -263,7 +325,7 @@ func TestVerifier_Verify(t *testing.T) {
 			ctx := newMockContext(t)
 			ctx.store.EXPECT().GetRevocations(*vc.ID).Return(nil, ErrNotFound)
 			sut := ctx.verifier
-			validationErr := sut.Verify(vc, true, false, nil)
+			validationErr := sut.Verify(context.Background(), vc, true, false, nil)
 			assert.NoError(t, validationErr,
 				"expected no error when validating a valid vc")
 		})
@@ -276,7 +338,7 @@ func TestVerifier_Verify(t *testing.T) {
 				vc.Type = []ssi.URI{}
 				ctx := newMockContext(t)
 				sut := ctx.verifier
-				validationErr := sut.Verify(vc, true, false, nil)
+				validationErr := sut.Verify(context.Background(), vc, true, false, nil)
 				assert.EqualError(t, validationErr, "validation failed: type 'VerifiableCredential' is required")
 			})
 
@@ -287,7 +349,7 @@ func TestVerifier_Verify(t *testing.T) {
 				ctx := newMockContext(t)
 				ctx.store.EXPECT().GetRevocations(*vc.ID).Return(nil, ErrNotFound)
 				sut := ctx.verifier
-				validationErr := sut.Verify(vc, true, false, nil)
+				validationErr := sut.Verify(context.Background(), vc, true, false, nil)
 				assert.EqualError(t, validationErr, "credential not valid at given time")
 			})
 
@@ -300,7 +362,7 @@ func TestVerifier_Verify(t *testing.T) {
 		testCred := testCredential(t)
 		testCred.Type = []ssi.URI{vc.VerifiableCredentialTypeV1URI(), ssi.MustParseURI("a"), ssi.MustParseURI("b")}
 
-		err := instance.Verify(testCred, true, false, nil)
+		err := instance.Verify(context.Background(), testCred, true, false, nil)
 
 		assert.EqualError(t, err, "verifiable credential must list at most 2 types")
 	})
@@ -325,7 +387,7 @@ func TestVerifier_Verify(t *testing.T) {
 				_ = ctx.trustConfig.AddTrust(vcType, cred.Issuer)
 			}
 			validAt := time.Now()
-			err = ctx.verifier.Verify(*cred, false, true, &validAt)
+			err = ctx.verifier.Verify(context.Background(), *cred, false, true, &validAt)
 			assert.NoError(t, err)
 		})
 		t.Run("ok revoked", func(t *testing.T) {
@@ -337,7 +399,7 @@ func TestVerifier_Verify(t *testing.T) {
 				_ = ctx.trustConfig.AddTrust(vcType, cred.Issuer)
 			}
 			validAt := time.Now()
-			err = ctx.verifier.Verify(*cred, false, true, &validAt)
+			err = ctx.verifier.Verify(context.Background(), *cred, false, true, &validAt)
 			assert.EqualError(t, err, "credential is revoked")
 		})
 		t.Run("untrusted", func(t *testing.T) {
@@ -346,8 +408,9 @@ func TestVerifier_Verify(t *testing.T) {
 			ctx := newMockContext(t)
 			ctx.store.EXPECT().GetRevocations(*cred.ID).Return(nil, ErrNotFound)
 			validAt := time.Now()
-			err = ctx.verifier.Verify(*cred, false, true, &validAt)
-			assert.EqualError(t, err, "credential issuer is untrusted")
+			err = ctx.verifier.Verify(context.Background(), *cred, false, true, &validAt)
+			assert.ErrorIs(t, err, types.ErrUntrusted)
+			assert.ErrorContains(t, err, "is not trusted for credential type NutsUraCredential")
 		})
 		t.Run("expired", func(t *testing.T) {
 			cred, err := buildX509Credential(chain, signingCert, rootCert, signingKey, ura)
@@ -358,7 +421,7 @@ func TestVerifier_Verify(t *testing.T) {
 				_ = ctx.trustConfig.AddTrust(vcType, cred.Issuer)
 			}
 			validAt := time.Now().Add(-10 * time.Hour)
-			err = ctx.verifier.Verify(*cred, false, true, &validAt)
+			err = ctx.verifier.Verify(context.Background(), *cred, false, true, &validAt)
 			assert.EqualError(t, err, "credential not valid at given time")
 		})
 		t.Run("broken headers", func(t *testing.T) {
@@ -376,12 +439,154 @@ func TestVerifier_Verify(t *testing.T) {
 			ExtractProtectedHeaders = func(jwt string) (map[string]interface{}, error) {
 				return nil, expectedError
 			}
-			err = ctx.verifier.Verify(*cred, false, true, &validAt)
+			err = ctx.verifier.Verify(context.Background(), *cred, false, true, &validAt)
 			assert.ErrorIs(t, err, expectedError)
 		})
 	})
 }
 
+func TestVerifier_VerifyWithReport(t *testing.T) {
+	t.Run("all checks are performed", func(t *testing.T) {
+		vc := testCredential(t)
+		expirationTime := time.Now().Add(-10 * time.Hour)
+		vc.ExpirationDate = &expirationTime
+		ctx := newMockContext(t)
+		ctx.store.EXPECT().GetRevocations(*vc.ID).Return([]*credential.Revocation{{}}, nil)
+		ctx.didResolver.EXPECT().Resolve(did.MustParseDID(vc.Issuer.String()), gomock.Any()).Return(nil, nil, resolver.ErrDeactivated)
+		proofs, _ := vc.Proofs()
+		ctx.keyResolver.EXPECT().ResolveKeyByID(proofs[0].VerificationMethod.String(), gomock.Any(), resolver.NutsSigningKeyType).Return(nil, resolver.ErrKeyNotFound)
+
+		report := ctx.verifier.VerifyWithReport(context.Background(), vc, false, true, nil)
+
+		assert.False(t, report.Valid())
+		assert.ErrorIs(t, report.Err(), types.ErrRevoked)
+		assert.Equal(t, []CheckResult{
+			{Check: CheckFormat, Status: CheckPassed},
+			{Check: CheckSchema, Status: CheckSkipped, Reason: "credential has no credentialSchema"},
+			{Check: CheckRevocation, Status: CheckFailed, Reason: "credential is revoked", err: types.ErrRevoked},
+			{Check: CheckCredentialStatus, Status: CheckSkipped, Reason: "credential has no credentialStatus"},
+			{Check: CheckTrust, Status: CheckFailed, Reason: report.Get(CheckTrust).Reason, err: report.Get(CheckTrust).err},
+			{Check: CheckValidityPeriod, Status: CheckFailed, Reason: "credential not valid at given time", err: types.ErrCredentialNotValidAtTime},
+			{Check: CheckIssuer, Status: CheckFailed, Reason: "could not validate issuer: the DID document has been deactivated", err: report.Get(CheckIssuer).err},
+			{Check: CheckSignature, Status: CheckFailed, Reason: "unable to resolve valid signing key: key not found in DID document", err: report.Get(CheckSignature).err},
+		}, report.Checks)
+		assert.ErrorIs(t, report.Get(CheckTrust).err, types.ErrUntrusted)
+	})
+	t.Run("valid, signature not checked", func(t *testing.T) {
+		vc := testCredential(t)
+		ctx := newMockContext(t)
+		ctx.store.EXPECT().GetRevocations(*vc.ID).Return(nil, ErrNotFound)
+
+		report := ctx.verifier.VerifyWithReport(context.Background(), vc, true, false, nil)
+
+		assert.True(t, report.Valid())
+		assert.Equal(t, CheckSkipped, report.Get(CheckTrust).Status)
+		assert.Equal(t, "untrusted issuers are allowed", report.Get(CheckTrust).Reason)
+		assert.Equal(t, CheckSkipped, report.Get(CheckIssuer).Status)
+		assert.Equal(t, CheckSkipped, report.Get(CheckSignature).Status)
+		assert.Equal(t, "signature verification not requested", report.Get(CheckSignature).Reason)
+	})
+	t.Run("credentialSchema", func(t *testing.T) {
+		const schema = `{"type": "object", "properties": {"credentialSubject": {"required": ["name"]}}}`
+		server := httptest.NewServer(&http2.Handler{StatusCode: http.StatusOK, ResponseData: schema})
+		defer server.Close()
+		credentialWithSchema := func(t *testing.T, schemaURL string) *vc.VerifiableCredential {
+			result, err := vc.ParseVerifiableCredential(`{
+  "@context": ["https://www.w3.org/2018/credentials/v1"],
+  "id": "did:web:example.com#1",
+  "type": ["VerifiableCredential", "MembershipCredential"],
+  "issuer": "did:web:example.com",
+  "issuanceDate": "2025-01-01T00:00:00Z",
+  "credentialSchema": {"id": "` + schemaURL + `", "type": "JsonSchema"},
+  "credentialSubject": {"id": "did:web:example.com:alice"}
+}`)
+			require.NoError(t, err)
+			return result
+		}
+		cred := credentialWithSchema(t, server.URL)
+		var err error
+
+		t.Run("does not conform", func(t *testing.T) {
+			ctx := newMockContext(t)
+			ctx.verifier.schemaValidator, err = credential.NewSchemaValidator(http.DefaultClient, []string{server.URL}, time.Minute)
+			require.NoError(t, err)
+			ctx.store.EXPECT().GetRevocations(*cred.ID).Return(nil, ErrNotFound)
+
+			report := ctx.verifier.VerifyWithReport(context.Background(), *cred, true, false, nil)
+
+			require.Equal(t, CheckPassed, report.Get(CheckFormat).Status, report.Get(CheckFormat).Reason)
+			assert.Equal(t, CheckFailed, report.Get(CheckSchema).Status)
+			assert.Contains(t, report.Get(CheckSchema).Reason, "credential does not conform to credentialSchema")
+			assert.Equal(t, CheckPassed, report.Get(CheckValidityPeriod).Status)
+		})
+		t.Run("schema URL not allowed", func(t *testing.T) {
+			ctx := newMockContext(t)
+			ctx.verifier.schemaValidator, err = credential.NewSchemaValidator(http.DefaultClient, []string{"https://example.com/schemas/"}, time.Minute)
+			require.NoError(t, err)
+			ctx.store.EXPECT().GetRevocations(*cred.ID).Return(nil, ErrNotFound).Times(2)
+
+			report := ctx.verifier.VerifyWithReport(context.Background(), *cred, true, false, nil)
+
+			assert.True(t, report.Valid())
+			assert.Equal(t, CheckIndeterminate, report.Get(CheckSchema).Status)
+			assert.Contains(t, report.Get(CheckSchema).Reason, credential.ErrSchemaURLNotAllowed.Error())
+			assert.NoError(t, ctx.verifier.Verify(context.Background(), *cred, true, false, nil))
+		})
+		t.Run("schema can't be downloaded", func(t *testing.T) {
+			unavailableServer := httptest.NewServer(&http2.Handler{StatusCode: http.StatusServiceUnavailable})
+			defer unavailableServer.Close()
+			ctx := newMockContext(t)
+			ctx.verifier.schemaValidator, err = credential.NewSchemaValidator(http.DefaultClient, []string{unavailableServer.URL}, time.Minute)
+			require.NoError(t, err)
+			ctx.store.EXPECT().GetRevocations(*cred.ID).Return(nil, ErrNotFound)
+
+			report := ctx.verifier.VerifyWithReport(context.Background(), *credentialWithSchema(t, unavailableServer.URL), true, false, nil)
+
+			assert.True(t, report.Valid())
+			assert.Equal(t, CheckIndeterminate, report.Get(CheckSchema).Status)
+			assert.Contains(t, report.Get(CheckSchema).Reason, "unexpected HTTP response status: 503")
+		})
+		t.Run("schema can't be parsed", func(t *testing.T) {
+			invalidSchemaServer := httptest.NewServer(&http2.Handler{StatusCode: http.StatusOK, ResponseData: "not a schema"})
+			defer invalidSchemaServer.Close()
+			ctx := newMockContext(t)
+			ctx.verifier.schemaValidator, err = credential.NewSchemaValidator(http.DefaultClient, []string{invalidSchemaServer.URL}, time.Minute)
+			require.NoError(t, err)
+			ctx.store.EXPECT().GetRevocations(*cred.ID).Return(nil, ErrNotFound)
+
+			report := ctx.verifier.VerifyWithReport(context.Background(), *credentialWithSchema(t, invalidSchemaServer.URL), true, false, nil)
+
+			assert.True(t, report.Valid())
+			assert.Equal(t, CheckIndeterminate, report.Get(CheckSchema).Status)
+			assert.Contains(t, report.Get(CheckSchema).Reason, "invalid JSON Schema")
+		})
+		t.Run("schema validation not available", func(t *testing.T) {
+			ctx := newMockContext(t)
+			ctx.store.EXPECT().GetRevocations(*cred.ID).Return(nil, ErrNotFound)
+
+			report := ctx.verifier.VerifyWithReport(context.Background(), *cred, true, false, nil)
+
+			assert.True(t, report.Valid())
+			assert.Equal(t, CheckIndeterminate, report.Get(CheckSchema).Status)
+			assert.Equal(t, "JSON Schema validation is not available", report.Get(CheckSchema).Reason)
+		})
+	})
+	t.Run("invalid format skips other checks", func(t *testing.T) {
+		vc := testCredential(t)
+		vc.Type = []ssi.URI{}
+		ctx := newMockContext(t)
+
+		report := ctx.verifier.VerifyWithReport(context.Background(), vc, false, true, nil)
+
+		require.Len(t, report.Checks, 8)
+		assert.Equal(t, CheckFailed, report.Checks[0].Status)
+		for _, result := range report.Checks[1:] {
+			assert.Equal(t, CheckSkipped, result.Status)
+			assert.Equal(t, "check 'format' did not pass", result.Reason)
+		}
+	})
+}
+
 func Test_verifier_CheckAndStoreRevocation(t *testing.T) {
 	rawVerificationMethod, _ := os.ReadFile("../test/revocation-public.json")
 	rawRevocation, _ := os.ReadFile("../test/ld-revocation.json")
@@ -491,7 +696,7 @@ func TestVerifier_VerifyVP(t *testing.T) {
 			ctx.keyResolver.EXPECT().ResolveKeyByID(keyID, gomock.Any(), resolver.NutsSigningKeyType).Return(publicKey, nil)
 
 			validAt := time.Date(2023, 10, 18, 12, 0, 0, 0, time.UTC)
-			vcs, err := ctx.verifier.VerifyVP(*presentation, false, false, &validAt)
+			vcs, err := ctx.verifier.VerifyVP(context.Background(), *presentation, false, false, &validAt)
 
 			assert.NoError(t, err)
 			assert.Len(t, vcs, 1)
@@ -501,7 +706,7 @@ func TestVerifier_VerifyVP(t *testing.T) {
 			presentation, key := test.CreateJWTPresentation(t, subjectDID, nil)
 			ctx.keyResolver.EXPECT().ResolveKeyByID(gomock.Any(), gomock.Any(), resolver.NutsSigningKeyType).Return(key, nil)
 
-			vcs, err := ctx.verifier.VerifyVP(presentation, false, false, nil)
+			vcs, err := ctx.verifier.VerifyVP(context.Background(), presentation, false, false, nil)
 
 			assert.NoError(t, err)
 			assert.Empty(t, vcs)
@@ -529,9 +734,9 @@ func TestVerifier_VerifyVP(t *testing.T) {
 				ctx.keyResolver.EXPECT().ResolveKeyByID(gomock.Any(), gomock.Any(), gomock.Any()).Return(key, nil)
 
 				mockVerifier := NewMockVerifier(ctx.ctrl)
-				mockVerifier.EXPECT().Verify(vp.VerifiableCredential[0], true, false, nil)
+				mockVerifier.EXPECT().Verify(gomock.Any(), vp.VerifiableCredential[0], true, false, nil)
 
-				vcs, err := ctx.verifier.doVerifyVP(mockVerifier, vp, true, true, nil)
+				vcs, err := ctx.verifier.doVerifyVP(context.Background(), mockVerifier, vp, true, true, nil)
 
 				assert.NoError(t, err)
 				assert.Len(t, vcs, 1)
@@ -545,7 +750,7 @@ func TestVerifier_VerifyVP(t *testing.T) {
 
 			mockVerifier := NewMockVerifier(ctx.ctrl)
 
-			vcs, err := ctx.verifier.doVerifyVP(mockVerifier, presentationWithHolder, true, true, nil)
+			vcs, err := ctx.verifier.doVerifyVP(context.Background(), mockVerifier, presentationWithHolder, true, true, nil)
 
 			assert.EqualError(t, err, "verification error: presentation holder must equal credential subject")
 			assert.Empty(t, vcs)
@@ -555,7 +760,7 @@ func TestVerifier_VerifyVP(t *testing.T) {
 			ctx.keyResolver.EXPECT().ResolveKeyByID(keyID, gomock.Any(), resolver.NutsSigningKeyType).Return(publicKey, nil)
 
 			validAt := time.Date(2023, 10, 21, 12, 0, 0, 0, time.UTC)
-			vcs, err := ctx.verifier.VerifyVP(*presentation, false, false, &validAt)
+			vcs, err := ctx.verifier.VerifyVP(context.Background(), *presentation, false, false, &validAt)
 
 			assert.EqualError(t, err, "unable to validate JWT signature: \"exp\" not satisfied")
 			assert.Empty(t, vcs)
@@ -577,7 +782,7 @@ func TestVerifier_VerifyVP(t *testing.T) {
 			require.NoError(t, err)
 			ctx := newMockContext(t)
 
-			vcs, err := ctx.verifier.VerifyVP(*presentation, false, false, nil)
+			vcs, err := ctx.verifier.VerifyVP(context.Background(), *presentation, false, false, nil)
 
 			assert.EqualError(t, err, "verification error: credential(s) must be presented by subject")
 			assert.Empty(t, vcs)
@@ -638,7 +843,7 @@ func TestVerifier_VerifyVP(t *testing.T) {
 			ctx := newMockContext(t)
 			ctx.keyResolver.EXPECT().ResolveKeyByID(vpSignerKeyID.String(), &metadata, resolver.NutsSigningKeyType).Return(vdr.TestMethodDIDAPrivateKey().PublicKey, nil)
 
-			vcs, err := ctx.verifier.VerifyVP(vp, false, false, validAt)
+			vcs, err := ctx.verifier.VerifyVP(context.Background(), vp, false, false, validAt)
 
 			assert.NoError(t, err)
 			assert.Len(t, vcs, 1)
@@ -653,9 +858,9 @@ func TestVerifier_VerifyVP(t *testing.T) {
 			ctx.keyResolver.EXPECT().ResolveKeyByID(vpSignerKeyID.String(), &metadata, resolver.NutsSigningKeyType).Return(vdr.TestMethodDIDAPrivateKey().PublicKey, nil)
 
 			mockVerifier := NewMockVerifier(ctx.ctrl)
-			mockVerifier.EXPECT().Verify(vp.VerifiableCredential[0], false, true, validAt)
+			mockVerifier.EXPECT().Verify(gomock.Any(), vp.VerifiableCredential[0], false, true, validAt)
 
-			vcs, err := ctx.verifier.doVerifyVP(mockVerifier, vp, true, false, validAt)
+			vcs, err := ctx.verifier.doVerifyVP(context.Background(), mockVerifier, vp, true, false, validAt)
 
 			assert.NoError(t, err)
 			assert.Len(t, vcs, 1)
@@ -670,9 +875,9 @@ func TestVerifier_VerifyVP(t *testing.T) {
 			ctx.keyResolver.EXPECT().ResolveKeyByID(vpSignerKeyID.String(), &metadata, resolver.NutsSigningKeyType).Return(vdr.TestMethodDIDAPrivateKey().PublicKey, nil)
 
 			mockVerifier := NewMockVerifier(ctx.ctrl)
-			mockVerifier.EXPECT().Verify(vp.VerifiableCredential[0], true, true, validAt)
+			mockVerifier.EXPECT().Verify(gomock.Any(), vp.VerifiableCredential[0], true, true, validAt)
 
-			vcs, err := ctx.verifier.doVerifyVP(mockVerifier, vp, true, true, validAt)
+			vcs, err := ctx.verifier.doVerifyVP(context.Background(), mockVerifier, vp, true, true, validAt)
 
 			assert.NoError(t, err)
 			assert.Len(t, vcs, 1)
@@ -686,7 +891,7 @@ func TestVerifier_VerifyVP(t *testing.T) {
 
 			mockVerifier := NewMockVerifier(ctx.ctrl)
 
-			vcs, err := ctx.verifier.doVerifyVP(mockVerifier, vp, true, true, &validAt)
+			vcs, err := ctx.verifier.doVerifyVP(context.Background(), mockVerifier, vp, true, true, &validAt)
 
 			assert.EqualError(t, err, "verification error: presentation not valid at given time")
 			assert.Empty(t, vcs)
@@ -701,9 +906,9 @@ func TestVerifier_VerifyVP(t *testing.T) {
 			ctx.keyResolver.EXPECT().ResolveKeyByID(vpSignerKeyID.String(), &metadata, resolver.NutsSigningKeyType).Return(vdr.TestMethodDIDAPrivateKey().PublicKey, nil)
 
 			mockVerifier := NewMockVerifier(ctx.ctrl)
-			mockVerifier.EXPECT().Verify(vp.VerifiableCredential[0], false, true, validAt).Return(errors.New("invalid"))
+			mockVerifier.EXPECT().Verify(gomock.Any(), vp.VerifiableCredential[0], false, true, validAt).Return(errors.New("invalid"))
 
-			vcs, err := ctx.verifier.doVerifyVP(mockVerifier, vp, true, false, validAt)
+			vcs, err := ctx.verifier.doVerifyVP(context.Background(), mockVerifier, vp, true, false, validAt)
 
 			assert.Error(t, err)
 			assert.Empty(t, vcs)
@@ -718,7 +923,7 @@ func TestVerifier_VerifyVP(t *testing.T) {
 			// Return incorrect key, causing signature verification failure
 			ctx.keyResolver.EXPECT().ResolveKeyByID(vpSignerKeyID.String(), &metadata, resolver.NutsSigningKeyType).Return(vdr.TestMethodDIDBPrivateKey().PublicKey, nil)
 
-			vcs, err := ctx.verifier.VerifyVP(vp, false, false, validAt)
+			vcs, err := ctx.verifier.VerifyVP(context.Background(), vp, false, false, validAt)
 
 			assert.EqualError(t, err, "verification error: invalid signature: invalid proof signature: failed to verify signature using ecdsa")
 			assert.Empty(t, vcs)
@@ -733,7 +938,7 @@ func TestVerifier_VerifyVP(t *testing.T) {
 			// Return incorrect key, causing signature verification failure
 			ctx.keyResolver.EXPECT().ResolveKeyByID(vpSignerKeyID.String(), &metadata, resolver.NutsSigningKeyType).Return(nil, resolver.ErrKeyNotFound)
 
-			vcs, err := ctx.verifier.VerifyVP(vp, false, false, validAt)
+			vcs, err := ctx.verifier.VerifyVP(context.Background(), vp, false, false, validAt)
 
 			assert.ErrorIs(t, err, resolver.ErrKeyNotFound)
 			assert.Empty(t, vcs)
@@ -747,7 +952,7 @@ func TestVerifier_VerifyVP(t *testing.T) {
 
 			ctx := newMockContext(t)
 
-			vcs, err := ctx.verifier.VerifyVP(vp, false, false, validAt)
+			vcs, err := ctx.verifier.VerifyVP(context.Background(), vp, false, false, validAt)
 
 			assert.EqualError(t, err, "verification error: presenter is credential subject: invalid LD-proof for presentation: json: cannot unmarshal string into Go value of type proof.LDProof")
 			assert.Empty(t, vcs)
@@ -761,11 +966,87 @@ func TestVerifier_VerifyVP(t *testing.T) {
 
 			ctx := newMockContext(t)
 
-			vcs, err := ctx.verifier.VerifyVP(vp, false, false, validAt)
+			vcs, err := ctx.verifier.VerifyVP(context.Background(), vp, false, false, validAt)
 
 			assert.EqualError(t, err, "verification error: presenter is credential subject: presentation should have exactly 1 proof, got 0")
 			assert.Empty(t, vcs)
 		})
+		t.Run("report - ok", func(t *testing.T) {
+			_ = json.Unmarshal([]byte(rawVP), &vp)
+
+			var validAt *time.Time
+			metadata := resolver.ResolveMetadata{ResolveTime: validAt}
+
+			ctx := newMockContext(t)
+			ctx.keyResolver.EXPECT().ResolveKeyByID(vpSignerKeyID.String(), &metadata, resolver.NutsSigningKeyType).Return(vdr.TestMethodDIDAPrivateKey().PublicKey, nil)
+			credentialReport := VerificationReport{Checks: []CheckResult{{Check: CheckFormat, Status: CheckPassed}}}
+			mockVerifier := NewMockVerifier(ctx.ctrl)
+			mockVerifier.EXPECT().VerifyWithReport(gomock.Any(), vp.VerifiableCredential[0], false, true, validAt).Return(credentialReport)
+
+			report := ctx.verifier.doVerifyVPWithReport(context.Background(), mockVerifier, vp, true, false, validAt)
+
+			assert.True(t, report.Valid())
+			assert.Equal(t, []CheckResult{
+				{Check: CheckHolder, Status: CheckPassed},
+				{Check: CheckSignature, Status: CheckPassed},
+			}, report.Checks)
+			require.Len(t, report.Credentials, 1)
+			assert.Equal(t, vp.VerifiableCredential[0].ID, report.Credentials[0].ID)
+			assert.Equal(t, credentialReport, report.Credentials[0].VerificationReport)
+		})
+		t.Run("report - all checks are performed", func(t *testing.T) {
+			_ = json.Unmarshal([]byte(rawVP), &vp)
+
+			var validAt *time.Time
+			metadata := resolver.ResolveMetadata{ResolveTime: validAt}
+
+			ctx := newMockContext(t)
+			// Return incorrect key, causing signature verification failure
+			ctx.keyResolver.EXPECT().ResolveKeyByID(vpSignerKeyID.String(), &metadata, resolver.NutsSigningKeyType).Return(vdr.TestMethodDIDBPrivateKey().PublicKey, nil)
+			credentialReport := VerificationReport{Checks: []CheckResult{{Check: CheckTrust, Status: CheckFailed, Reason: "untrusted", err: types.ErrUntrusted}}}
+			mockVerifier := NewMockVerifier(ctx.ctrl)
+			mockVerifier.EXPECT().VerifyWithReport(gomock.Any(), vp.VerifiableCredential[0], false, true, validAt).Return(credentialReport)
+
+			report := ctx.verifier.doVerifyVPWithReport(context.Background(), mockVerifier, vp, true, false, validAt)
+
+			assert.False(t, report.Valid())
+			assert.EqualError(t, report.Err(), "verification error: invalid signature: invalid proof signature: failed to verify signature using ecdsa")
+			assert.Equal(t, CheckPassed, report.Get(CheckHolder).Status)
+			assert.Equal(t, CheckFailed, report.Get(CheckSignature).Status)
+			require.Len(t, report.Credentials, 1)
+			assert.Equal(t, credentialReport, report.Credentials[0].VerificationReport)
+		})
+		t.Run("report - invalid VC", func(t *testing.T) {
+			_ = json.Unmarshal([]byte(rawVP), &vp)
+
+			var validAt *time.Time
+			metadata := resolver.ResolveMetadata{ResolveTime: validAt}
+
+			ctx := newMockContext(t)
+			ctx.keyResolver.EXPECT().ResolveKeyByID(vpSignerKeyID.String(), &metadata, resolver.NutsSigningKeyType).Return(vdr.TestMethodDIDAPrivateKey().PublicKey, nil)
+			credentialReport := VerificationReport{Checks: []CheckResult{{Check: CheckTrust, Status: CheckFailed, Reason: "untrusted", err: types.ErrUntrusted}}}
+			mockVerifier := NewMockVerifier(ctx.ctrl)
+			mockVerifier.EXPECT().VerifyWithReport(gomock.Any(), vp.VerifiableCredential[0], false, true, validAt).Return(credentialReport)
+
+			report := ctx.verifier.doVerifyVPWithReport(context.Background(), mockVerifier, vp, true, false, validAt)
+
+			assert.ErrorIs(t, report.Err(), VerificationError{})
+			assert.EqualError(t, report.Err(), "verification error: invalid VC (id="+vp.VerifiableCredential[0].ID.String()+"): credential issuer is untrusted")
+		})
+		t.Run("report - credentials not verified", func(t *testing.T) {
+			_ = json.Unmarshal([]byte(rawVP), &vp)
+
+			var validAt *time.Time
+			metadata := resolver.ResolveMetadata{ResolveTime: validAt}
+
+			ctx := newMockContext(t)
+			ctx.keyResolver.EXPECT().ResolveKeyByID(vpSignerKeyID.String(), &metadata, resolver.NutsSigningKeyType).Return(vdr.TestMethodDIDAPrivateKey().PublicKey, nil)
+
+			report := ctx.verifier.VerifyVPWithReport(context.Background(), vp, false, false, validAt)
+
+			assert.True(t, report.Valid())
+			assert.Empty(t, report.Credentials)
+		})
 	})
 }
 
@@ -848,7 +1129,7 @@ func newMockContext(t *testing.T) mockContext {
 	verifierStore := NewMockStore(ctrl)
 	trustConfig := trust.NewConfig(path.Join(io.TestDirectory(t), "trust.yaml"))
 	db := orm.NewTestDatabase(t)
-	verifier := NewVerifier(verifierStore, didResolver, keyResolver, jsonldManager, trustConfig, revocation.NewStatusList2021(db, nil, ""), nil).(*verifier)
+	verifier := NewVerifier(verifierStore, didResolver, keyResolver, jsonldManager, trustConfig, revocation.NewStatusList2021(db, nil, ""), nil, nil).(*verifier)
 	return mockContext{
 		ctrl:        ctrl,
 		verifier:    verifier,
@@ -27,6 +27,7 @@ import (
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/nuts-node/audit"
 	"github.com/nuts-foundation/nuts-node/core"
+	nutsCrypto "github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/http/cache"
 	"github.com/nuts-foundation/nuts-node/storage/orm"
 	"github.com/nuts-foundation/nuts-node/vdr"
@@ -68,6 +69,9 @@ func (w *Wrapper) ResolveStatusCode(err error) int {
 		resolver.ErrDeactivated:                http.StatusConflict,
 		did.ErrInvalidService:                  http.StatusBadRequest,
 		resolver.ErrDuplicateService:           http.StatusConflict,
+		didsubject.ErrInvalidExport:            http.StatusBadRequest,
+		didsubject.ErrWeakExportPassword:       http.StatusBadRequest,
+		nutsCrypto.ErrPrivateKeyNotExportable:  http.StatusBadRequest,
 	})
 }
 
@@ -255,24 +259,90 @@ func (w *Wrapper) UpdateService(ctx context.Context, request UpdateServiceReques
 
 func (w *Wrapper) AddVerificationMethod(ctx context.Context, request AddVerificationMethodRequestObject) (AddVerificationMethodResponseObject, error) {
 	subject := request.Id
+	keyUsage, err := keyUsageFromOptions(request.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	vms, err := w.SubjectManager.AddVerificationMethod(ctx, subject, keyUsage)
+	if err != nil {
+		return nil, err
+	}
+	return AddVerificationMethod200JSONResponse(vms), nil
+}
+
+func (w *Wrapper) RotateKeys(ctx context.Context, request RotateKeysRequestObject) (RotateKeysResponseObject, error) {
+	keyUsage, err := keyUsageFromOptions(request.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	vms, err := w.SubjectManager.RotateKeys(ctx, request.Id, keyUsage)
+	if err != nil {
+		return nil, err
+	}
+	return RotateKeys200JSONResponse(vms), nil
+}
+
+func (w *Wrapper) ExportSubject(ctx context.Context, request ExportSubjectRequestObject) (ExportSubjectResponseObject, error) {
+	password := ""
+	if request.Body.Password != nil {
+		password = *request.Body.Password
+	}
+	encryption := didsubject.ExportEncryption{Password: password}
+	if request.Body.RecipientKeyId != nil {
+		if password != "" {
+			return nil, core.InvalidInputError("either password or recipientKeyId must be given, not both")
+		}
+		keyResolver := resolver.DIDKeyResolver{Resolver: w.VDR.Resolver()}
+		publicKey, err := keyResolver.ResolveKeyByID(*request.Body.RecipientKeyId, nil, resolver.KeyAgreement)
+		if err != nil {
+			return nil, core.InvalidInputError("unable to resolve recipient key: %w", err)
+		}
+		encryption.RecipientKeyID = *request.Body.RecipientKeyId
+		encryption.RecipientKey = publicKey
+	} else if password == "" {
+		return nil, core.InvalidInputError("either password or recipientKeyId must be given")
+	}
+
+	bundle, err := w.SubjectManager.Export(ctx, request.Id, encryption)
+	if err != nil {
+		return nil, err
+	}
+	return ExportSubject200JSONResponse(SubjectExportResult{Bundle: bundle}), nil
+}
+
+func (w *Wrapper) ImportSubject(ctx context.Context, request ImportSubjectRequestObject) (ImportSubjectResponseObject, error) {
+	password := ""
+	if request.Body.Password != nil {
+		password = *request.Body.Password
+	}
+
+	docs, subject, err := w.SubjectManager.Import(ctx, request.Body.Bundle, password)
+	if err != nil {
+		return nil, err
+	}
+	return ImportSubject200JSONResponse(SubjectCreationResult{
+		Documents: docs,
+		Subject:   subject,
+	}), nil
+}
+
+// keyUsageFromOptions returns the key usage for the given key creation options. If no options are given, an assertion key is created.
+func keyUsageFromOptions(options *KeyCreationOptions) (orm.DIDKeyFlags, error) {
 	keyUsage := orm.AssertionKeyUsage()
-	if request.Body != nil {
-		if request.Body.EncryptionKey {
+	if options != nil {
+		if options.EncryptionKey {
 			keyUsage ^= orm.EncryptionKeyUsage()
 		}
-		if !request.Body.AssertionKey {
+		if !options.AssertionKey {
 			keyUsage ^= orm.AssertionKeyUsage()
 		}
 		if keyUsage == 0 {
-			return nil, core.InvalidInputError("at least one key must be created")
+			return 0, core.InvalidInputError("at least one key must be created")
 		}
 	}
-
-	vms, err := w.SubjectManager.AddVerificationMethod(ctx, subject, keyUsage)
-	if err != nil {
-		return nil, err
-	}
-	return AddVerificationMethod200JSONResponse(vms), nil
+	return keyUsage, nil
 }
 
 // requestedWebDID constructs a did:web DID as it was requested by the API caller. It can be a DID with or without user path, e.g.:
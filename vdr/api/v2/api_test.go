@@ -21,6 +21,9 @@ package v2
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"encoding/json"
 	"errors"
 	"github.com/labstack/echo/v4"
@@ -446,6 +449,154 @@ func TestWrapper_AddVerificationMethod(t *testing.T) {
 	})
 }
 
+func TestWrapper_RotateKeys(t *testing.T) {
+	vm := did.VerificationMethod{ID: did.MustParseDIDURL("did:example:1#key-2")}
+	t.Run("ok - defaults", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.subjectManager.EXPECT().RotateKeys(gomock.Any(), "subject", orm.AssertionKeyUsage()).Return([]did.VerificationMethod{vm}, nil)
+
+		response, err := ctx.client.RotateKeys(nil, RotateKeysRequestObject{
+			Id: "subject",
+		})
+
+		require.NoError(t, err)
+		require.Len(t, response.(RotateKeys200JSONResponse), 1)
+	})
+	t.Run("error on no keys", func(t *testing.T) {
+		ctx := newMockContext(t)
+
+		_, err := ctx.client.RotateKeys(nil, RotateKeysRequestObject{
+			Id:   "subject",
+			Body: &KeyCreationOptions{},
+		})
+
+		require.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.(core.HTTPStatusCodeError).StatusCode())
+	})
+	t.Run("error - rotation fails", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.subjectManager.EXPECT().RotateKeys(gomock.Any(), "subject", orm.AssertionKeyUsage()).Return(nil, didsubject.ErrSubjectNotFound)
+
+		_, err := ctx.client.RotateKeys(nil, RotateKeysRequestObject{
+			Id: "subject",
+		})
+
+		assert.ErrorIs(t, err, didsubject.ErrSubjectNotFound)
+	})
+}
+
+func TestWrapper_ExportSubject(t *testing.T) {
+	password := "secret"
+	t.Run("ok - password", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.subjectManager.EXPECT().Export(gomock.Any(), "subject", didsubject.ExportEncryption{Password: password}).Return("bundle", nil)
+
+		response, err := ctx.client.ExportSubject(nil, ExportSubjectRequestObject{
+			Id:   "subject",
+			Body: &ExportSubjectJSONRequestBody{Password: &password},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "bundle", response.(ExportSubject200JSONResponse).Bundle)
+	})
+	t.Run("ok - recipient key", func(t *testing.T) {
+		ctx := newMockContext(t)
+		keyPair, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		document := did.Document{ID: did.MustParseDID("did:web:example.com")}
+		vm, _ := did.NewVerificationMethod(did.MustParseDIDURL("did:web:example.com#0"), ssi.JsonWebKey2020, document.ID, keyPair.Public())
+		document.AddKeyAgreement(vm)
+		ctx.didResolver.EXPECT().Resolve(document.ID, gomock.Any()).Return(&document, nil, nil)
+		ctx.subjectManager.EXPECT().Export(gomock.Any(), "subject", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, encryption didsubject.ExportEncryption) (string, error) {
+			assert.Equal(t, vm.ID.String(), encryption.RecipientKeyID)
+			assert.Equal(t, keyPair.Public(), encryption.RecipientKey)
+			return "bundle", nil
+		})
+		recipientKeyID := vm.ID.String()
+
+		response, err := ctx.client.ExportSubject(nil, ExportSubjectRequestObject{
+			Id:   "subject",
+			Body: &ExportSubjectJSONRequestBody{RecipientKeyId: &recipientKeyID},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "bundle", response.(ExportSubject200JSONResponse).Bundle)
+	})
+	t.Run("error - recipient key not found", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.didResolver.EXPECT().Resolve(gomock.Any(), gomock.Any()).Return(nil, nil, resolver.ErrNotFound)
+		recipientKeyID := "did:web:example.com#0"
+
+		_, err := ctx.client.ExportSubject(nil, ExportSubjectRequestObject{
+			Id:   "subject",
+			Body: &ExportSubjectJSONRequestBody{RecipientKeyId: &recipientKeyID},
+		})
+
+		require.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.(core.HTTPStatusCodeError).StatusCode())
+	})
+	t.Run("error - no encryption", func(t *testing.T) {
+		ctx := newMockContext(t)
+
+		_, err := ctx.client.ExportSubject(nil, ExportSubjectRequestObject{
+			Id:   "subject",
+			Body: &ExportSubjectJSONRequestBody{},
+		})
+
+		require.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.(core.HTTPStatusCodeError).StatusCode())
+	})
+	t.Run("error - both password and recipient key", func(t *testing.T) {
+		ctx := newMockContext(t)
+		recipientKeyID := "did:web:example.com#0"
+
+		_, err := ctx.client.ExportSubject(nil, ExportSubjectRequestObject{
+			Id:   "subject",
+			Body: &ExportSubjectJSONRequestBody{Password: &password, RecipientKeyId: &recipientKeyID},
+		})
+
+		require.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.(core.HTTPStatusCodeError).StatusCode())
+	})
+	t.Run("error - export fails", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.subjectManager.EXPECT().Export(gomock.Any(), "subject", gomock.Any()).Return("", didsubject.ErrSubjectNotFound)
+
+		_, err := ctx.client.ExportSubject(nil, ExportSubjectRequestObject{
+			Id:   "subject",
+			Body: &ExportSubjectJSONRequestBody{Password: &password},
+		})
+
+		assert.ErrorIs(t, err, didsubject.ErrSubjectNotFound)
+	})
+}
+
+func TestWrapper_ImportSubject(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctx := newMockContext(t)
+		password := "secret"
+		document := did.Document{ID: did.MustParseDID("did:web:example.com")}
+		ctx.subjectManager.EXPECT().Import(gomock.Any(), "bundle", password).Return([]did.Document{document}, "subject", nil)
+
+		response, err := ctx.client.ImportSubject(nil, ImportSubjectRequestObject{
+			Body: &ImportSubjectJSONRequestBody{Bundle: "bundle", Password: &password},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "subject", response.(ImportSubject200JSONResponse).Subject)
+		assert.Len(t, response.(ImportSubject200JSONResponse).Documents, 1)
+	})
+	t.Run("error - import fails", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.subjectManager.EXPECT().Import(gomock.Any(), "bundle", "").Return(nil, "", didsubject.ErrInvalidExport)
+
+		_, err := ctx.client.ImportSubject(nil, ImportSubjectRequestObject{
+			Body: &ImportSubjectJSONRequestBody{Bundle: "bundle"},
+		})
+
+		assert.ErrorIs(t, err, didsubject.ErrInvalidExport)
+	})
+}
+
 func TestWrapper_GetTenantWebDID(t *testing.T) {
 	const webIDPart = "123"
 	var webDID = did.MustParseDID("did:web:example.com:iam:123")
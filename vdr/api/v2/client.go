@@ -58,3 +58,57 @@ func (hb HTTPClient) Create(options CreateSubjectOptions) (string, []did.Documen
 		return createDIDResponse.JSON200.Subject, createDIDResponse.JSON200.Documents, nil
 	}
 }
+
+// RotateKeys calls the server to rotate the keys of all DID documents of the subject.
+// It returns the new verification methods.
+func (hb HTTPClient) RotateKeys(subject string, options KeyCreationOptions) ([]did.VerificationMethod, error) {
+	ctx := context.Background()
+
+	if response, err := hb.client().RotateKeys(ctx, subject, options); err != nil {
+		return nil, err
+	} else if err := core.TestResponseCode(http.StatusOK, response); err != nil {
+		return nil, err
+	} else {
+		rotateKeysResponse, err := ParseRotateKeysResponse(response)
+		if err != nil {
+			return nil, err
+		}
+		return *rotateKeysResponse.JSON200, nil
+	}
+}
+
+// ExportSubject calls the server to export the subject, encrypted as specified by the request.
+// It returns the encrypted export bundle.
+func (hb HTTPClient) ExportSubject(subject string, request SubjectExportRequest) (string, error) {
+	ctx := context.Background()
+
+	if response, err := hb.client().ExportSubject(ctx, subject, request); err != nil {
+		return "", err
+	} else if err := core.TestResponseCode(http.StatusOK, response); err != nil {
+		return "", err
+	} else {
+		exportResponse, err := ParseExportSubjectResponse(response)
+		if err != nil {
+			return "", err
+		}
+		return exportResponse.JSON200.Bundle, nil
+	}
+}
+
+// ImportSubject calls the server to import a subject from an export bundle.
+// It returns the subject and its DID documents.
+func (hb HTTPClient) ImportSubject(request SubjectImportRequest) (string, []did.Document, error) {
+	ctx := context.Background()
+
+	if response, err := hb.client().ImportSubject(ctx, request); err != nil {
+		return "", nil, err
+	} else if err := core.TestResponseCode(http.StatusOK, response); err != nil {
+		return "", nil, err
+	} else {
+		importResponse, err := ParseImportSubjectResponse(response)
+		if err != nil {
+			return "", nil, err
+		}
+		return importResponse.JSON200.Subject, importResponse.JSON200.Documents, nil
+	}
+}
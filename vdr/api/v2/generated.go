@@ -65,6 +65,31 @@ type SubjectCreationResult struct {
 	Subject string `json:"subject"`
 }
 
+// SubjectExportRequest Specifies how the subject export is encrypted. Either password or recipientKeyId must be given.
+type SubjectExportRequest struct {
+	// Password Password used to encrypt the export. It's needed to import the export. It must be at least 16 characters long.
+	Password *string `json:"password,omitempty"`
+
+	// RecipientKeyId ID of a key agreement verification method (DID URL) of the node that's going to import the export.
+	// The export is encrypted for this key, so only that node can import it.
+	RecipientKeyId *string `json:"recipientKeyId,omitempty"`
+}
+
+// SubjectExportResult Result of a subject export.
+type SubjectExportResult struct {
+	// Bundle The encrypted export, in JWE compact serialization.
+	Bundle string `json:"bundle"`
+}
+
+// SubjectImportRequest A subject export bundle to import.
+type SubjectImportRequest struct {
+	// Bundle The encrypted export, as returned by the export operation.
+	Bundle string `json:"bundle"`
+
+	// Password Password the export was encrypted with. If not given, the export must be encrypted for a key of this node.
+	Password *string `json:"password,omitempty"`
+}
+
 // FindServicesParams defines parameters for FindServices.
 type FindServicesParams struct {
 	// Type Type of the service to filter for. If specified, only services with the given service type are returned.
@@ -87,6 +112,12 @@ type FindServicesParamsEndpointType string
 // CreateSubjectJSONRequestBody defines body for CreateSubject for application/json ContentType.
 type CreateSubjectJSONRequestBody = CreateSubjectOptions
 
+// ImportSubjectJSONRequestBody defines body for ImportSubject for application/json ContentType.
+type ImportSubjectJSONRequestBody = SubjectImportRequest
+
+// ExportSubjectJSONRequestBody defines body for ExportSubject for application/json ContentType.
+type ExportSubjectJSONRequestBody = SubjectExportRequest
+
 // CreateServiceJSONRequestBody defines body for CreateService for application/json ContentType.
 type CreateServiceJSONRequestBody = ServiceRequest
 
@@ -96,6 +127,9 @@ type UpdateServiceJSONRequestBody = ServiceRequest
 // AddVerificationMethodJSONRequestBody defines body for AddVerificationMethod for application/json ContentType.
 type AddVerificationMethodJSONRequestBody = KeyCreationOptions
 
+// RotateKeysJSONRequestBody defines body for RotateKeys for application/json ContentType.
+type RotateKeysJSONRequestBody = KeyCreationOptions
+
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -186,12 +220,22 @@ type ClientInterface interface {
 
 	CreateSubject(ctx context.Context, body CreateSubjectJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// ImportSubjectWithBody request with any body
+	ImportSubjectWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ImportSubject(ctx context.Context, body ImportSubjectJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// Deactivate request
 	Deactivate(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// SubjectDIDs request
 	SubjectDIDs(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// ExportSubjectWithBody request with any body
+	ExportSubjectWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ExportSubject(ctx context.Context, id string, body ExportSubjectJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// FindServices request
 	FindServices(ctx context.Context, id string, params *FindServicesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -212,6 +256,11 @@ type ClientInterface interface {
 	AddVerificationMethodWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	AddVerificationMethod(ctx context.Context, id string, body AddVerificationMethodJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RotateKeysWithBody request with any body
+	RotateKeysWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	RotateKeys(ctx context.Context, id string, body RotateKeysJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
 func (c *Client) GetRootWebDID(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -286,6 +335,30 @@ func (c *Client) CreateSubject(ctx context.Context, body CreateSubjectJSONReques
 	return c.Client.Do(req)
 }
 
+func (c *Client) ImportSubjectWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewImportSubjectRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ImportSubject(ctx context.Context, body ImportSubjectJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewImportSubjectRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) Deactivate(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewDeactivateRequest(c.Server, id)
 	if err != nil {
@@ -310,6 +383,30 @@ func (c *Client) SubjectDIDs(ctx context.Context, id string, reqEditors ...Reque
 	return c.Client.Do(req)
 }
 
+func (c *Client) ExportSubjectWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewExportSubjectRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ExportSubject(ctx context.Context, id string, body ExportSubjectJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewExportSubjectRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) FindServices(ctx context.Context, id string, params *FindServicesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewFindServicesRequest(c.Server, id, params)
 	if err != nil {
@@ -406,6 +503,30 @@ func (c *Client) AddVerificationMethod(ctx context.Context, id string, body AddV
 	return c.Client.Do(req)
 }
 
+func (c *Client) RotateKeysWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRotateKeysRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RotateKeys(ctx context.Context, id string, body RotateKeysJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRotateKeysRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 // NewGetRootWebDIDRequest generates requests for GetRootWebDID
 func NewGetRootWebDIDRequest(server string) (*http.Request, error) {
 	var err error
@@ -565,6 +686,46 @@ func NewCreateSubjectRequestWithBody(server string, contentType string, body io.
 	return req, nil
 }
 
+// NewImportSubjectRequest calls the generic ImportSubject builder with application/json body
+func NewImportSubjectRequest(server string, body ImportSubjectJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewImportSubjectRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewImportSubjectRequestWithBody generates requests for ImportSubject with any type of body
+func NewImportSubjectRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vdr/v2/subject/import")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
 // NewDeactivateRequest generates requests for Deactivate
 func NewDeactivateRequest(server string, id string) (*http.Request, error) {
 	var err error
@@ -627,6 +788,50 @@ func NewSubjectDIDsRequest(server string, id string) (*http.Request, error) {
 	return req, nil
 }
 
+// NewExportSubjectRequest calls the generic ExportSubject builder with application/json body
+func NewExportSubjectRequest(server string, id string, body ExportSubjectJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewExportSubjectRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewExportSubjectRequestWithBody generates requests for ExportSubject with any type of body
+func NewExportSubjectRequestWithBody(server string, id string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0 = id
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vdr/v2/subject/%s/export", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
 // NewFindServicesRequest generates requests for FindServices
 func NewFindServicesRequest(server string, id string, params *FindServicesParams) (*http.Request, error) {
 	var err error
@@ -867,6 +1072,50 @@ func NewAddVerificationMethodRequestWithBody(server string, id string, contentTy
 	return req, nil
 }
 
+// NewRotateKeysRequest calls the generic RotateKeys builder with application/json body
+func NewRotateKeysRequest(server string, id string, body RotateKeysJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRotateKeysRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewRotateKeysRequestWithBody generates requests for RotateKeys with any type of body
+func NewRotateKeysRequestWithBody(server string, id string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0 = id
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vdr/v2/subject/%s/verificationmethod/rotate", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
 	for _, r := range c.RequestEditors {
 		if err := r(ctx, req); err != nil {
@@ -927,12 +1176,22 @@ type ClientWithResponsesInterface interface {
 
 	CreateSubjectWithResponse(ctx context.Context, body CreateSubjectJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateSubjectResponse, error)
 
+	// ImportSubjectWithBodyWithResponse request with any body
+	ImportSubjectWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ImportSubjectResponse, error)
+
+	ImportSubjectWithResponse(ctx context.Context, body ImportSubjectJSONRequestBody, reqEditors ...RequestEditorFn) (*ImportSubjectResponse, error)
+
 	// DeactivateWithResponse request
 	DeactivateWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeactivateResponse, error)
 
 	// SubjectDIDsWithResponse request
 	SubjectDIDsWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*SubjectDIDsResponse, error)
 
+	// ExportSubjectWithBodyWithResponse request with any body
+	ExportSubjectWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ExportSubjectResponse, error)
+
+	ExportSubjectWithResponse(ctx context.Context, id string, body ExportSubjectJSONRequestBody, reqEditors ...RequestEditorFn) (*ExportSubjectResponse, error)
+
 	// FindServicesWithResponse request
 	FindServicesWithResponse(ctx context.Context, id string, params *FindServicesParams, reqEditors ...RequestEditorFn) (*FindServicesResponse, error)
 
@@ -953,6 +1212,11 @@ type ClientWithResponsesInterface interface {
 	AddVerificationMethodWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddVerificationMethodResponse, error)
 
 	AddVerificationMethodWithResponse(ctx context.Context, id string, body AddVerificationMethodJSONRequestBody, reqEditors ...RequestEditorFn) (*AddVerificationMethodResponse, error)
+
+	// RotateKeysWithBodyWithResponse request with any body
+	RotateKeysWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RotateKeysResponse, error)
+
+	RotateKeysWithResponse(ctx context.Context, id string, body RotateKeysJSONRequestBody, reqEditors ...RequestEditorFn) (*RotateKeysResponse, error)
 }
 
 type GetRootWebDIDResponse struct {
@@ -1095,6 +1359,38 @@ func (r CreateSubjectResponse) StatusCode() int {
 	return 0
 }
 
+type ImportSubjectResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *SubjectCreationResult
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r ImportSubjectResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ImportSubjectResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type DeactivateResponse struct {
 	Body                          []byte
 	HTTPResponse                  *http.Response
@@ -1158,6 +1454,38 @@ func (r SubjectDIDsResponse) StatusCode() int {
 	return 0
 }
 
+type ExportSubjectResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *SubjectExportResult
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r ExportSubjectResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ExportSubjectResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type FindServicesResponse struct {
 	Body                          []byte
 	HTTPResponse                  *http.Response
@@ -1317,6 +1645,38 @@ func (r AddVerificationMethodResponse) StatusCode() int {
 	return 0
 }
 
+type RotateKeysResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *[]VerificationMethod
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r RotateKeysResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RotateKeysResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 // GetRootWebDIDWithResponse request returning *GetRootWebDIDResponse
 func (c *ClientWithResponses) GetRootWebDIDWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetRootWebDIDResponse, error) {
 	rsp, err := c.GetRootWebDID(ctx, reqEditors...)
@@ -1370,6 +1730,23 @@ func (c *ClientWithResponses) CreateSubjectWithResponse(ctx context.Context, bod
 	return ParseCreateSubjectResponse(rsp)
 }
 
+// ImportSubjectWithBodyWithResponse request with arbitrary body returning *ImportSubjectResponse
+func (c *ClientWithResponses) ImportSubjectWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ImportSubjectResponse, error) {
+	rsp, err := c.ImportSubjectWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseImportSubjectResponse(rsp)
+}
+
+func (c *ClientWithResponses) ImportSubjectWithResponse(ctx context.Context, body ImportSubjectJSONRequestBody, reqEditors ...RequestEditorFn) (*ImportSubjectResponse, error) {
+	rsp, err := c.ImportSubject(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseImportSubjectResponse(rsp)
+}
+
 // DeactivateWithResponse request returning *DeactivateResponse
 func (c *ClientWithResponses) DeactivateWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeactivateResponse, error) {
 	rsp, err := c.Deactivate(ctx, id, reqEditors...)
@@ -1388,6 +1765,23 @@ func (c *ClientWithResponses) SubjectDIDsWithResponse(ctx context.Context, id st
 	return ParseSubjectDIDsResponse(rsp)
 }
 
+// ExportSubjectWithBodyWithResponse request with arbitrary body returning *ExportSubjectResponse
+func (c *ClientWithResponses) ExportSubjectWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ExportSubjectResponse, error) {
+	rsp, err := c.ExportSubjectWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseExportSubjectResponse(rsp)
+}
+
+func (c *ClientWithResponses) ExportSubjectWithResponse(ctx context.Context, id string, body ExportSubjectJSONRequestBody, reqEditors ...RequestEditorFn) (*ExportSubjectResponse, error) {
+	rsp, err := c.ExportSubject(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseExportSubjectResponse(rsp)
+}
+
 // FindServicesWithResponse request returning *FindServicesResponse
 func (c *ClientWithResponses) FindServicesWithResponse(ctx context.Context, id string, params *FindServicesParams, reqEditors ...RequestEditorFn) (*FindServicesResponse, error) {
 	rsp, err := c.FindServices(ctx, id, params, reqEditors...)
@@ -1457,6 +1851,23 @@ func (c *ClientWithResponses) AddVerificationMethodWithResponse(ctx context.Cont
 	return ParseAddVerificationMethodResponse(rsp)
 }
 
+// RotateKeysWithBodyWithResponse request with arbitrary body returning *RotateKeysResponse
+func (c *ClientWithResponses) RotateKeysWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RotateKeysResponse, error) {
+	rsp, err := c.RotateKeysWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRotateKeysResponse(rsp)
+}
+
+func (c *ClientWithResponses) RotateKeysWithResponse(ctx context.Context, id string, body RotateKeysJSONRequestBody, reqEditors ...RequestEditorFn) (*RotateKeysResponse, error) {
+	rsp, err := c.RotateKeys(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRotateKeysResponse(rsp)
+}
+
 // ParseGetRootWebDIDResponse parses an HTTP response from a GetRootWebDIDWithResponse call
 func ParseGetRootWebDIDResponse(rsp *http.Response) (*GetRootWebDIDResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -1601,19 +2012,96 @@ func ParseCreateSubjectResponse(rsp *http.Response) (*CreateSubjectResponse, err
 		return nil, err
 	}
 
-	response := &CreateSubjectResponse{
+	response := &CreateSubjectResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SubjectCreationResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseImportSubjectResponse parses an HTTP response from a ImportSubjectWithResponse call
+func ParseImportSubjectResponse(rsp *http.Response) (*ImportSubjectResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ImportSubjectResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SubjectCreationResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeactivateResponse parses an HTTP response from a DeactivateWithResponse call
+func ParseDeactivateResponse(rsp *http.Response) (*DeactivateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeactivateResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest SubjectCreationResult
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON200 = &dest
-
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
 		var dest struct {
 			// Detail A human-readable explanation specific to this occurrence of the problem.
@@ -1635,20 +2123,27 @@ func ParseCreateSubjectResponse(rsp *http.Response) (*CreateSubjectResponse, err
 	return response, nil
 }
 
-// ParseDeactivateResponse parses an HTTP response from a DeactivateWithResponse call
-func ParseDeactivateResponse(rsp *http.Response) (*DeactivateResponse, error) {
+// ParseSubjectDIDsResponse parses an HTTP response from a SubjectDIDsWithResponse call
+func ParseSubjectDIDsResponse(rsp *http.Response) (*SubjectDIDsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &DeactivateResponse{
+	response := &SubjectDIDsResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []string
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
 		var dest struct {
 			// Detail A human-readable explanation specific to this occurrence of the problem.
@@ -1670,22 +2165,22 @@ func ParseDeactivateResponse(rsp *http.Response) (*DeactivateResponse, error) {
 	return response, nil
 }
 
-// ParseSubjectDIDsResponse parses an HTTP response from a SubjectDIDsWithResponse call
-func ParseSubjectDIDsResponse(rsp *http.Response) (*SubjectDIDsResponse, error) {
+// ParseExportSubjectResponse parses an HTTP response from a ExportSubjectWithResponse call
+func ParseExportSubjectResponse(rsp *http.Response) (*ExportSubjectResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &SubjectDIDsResponse{
+	response := &ExportSubjectResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest []string
+		var dest SubjectExportResult
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1915,6 +2410,48 @@ func ParseAddVerificationMethodResponse(rsp *http.Response) (*AddVerificationMet
 	return response, nil
 }
 
+// ParseRotateKeysResponse parses an HTTP response from a RotateKeysWithResponse call
+func ParseRotateKeysResponse(rsp *http.Response) (*RotateKeysResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RotateKeysResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []VerificationMethod
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// Returns the root did:web DID of this domain.
@@ -1932,12 +2469,18 @@ type ServerInterface interface {
 	// Creates new DID Documents for a subject.
 	// (POST /internal/vdr/v2/subject)
 	CreateSubject(ctx echo.Context) error
+	// Imports a subject that was exported by another node.
+	// (POST /internal/vdr/v2/subject/import)
+	ImportSubject(ctx echo.Context) error
 	// Deactivate all DID Documents for a subject.
 	// (DELETE /internal/vdr/v2/subject/{id})
 	Deactivate(ctx echo.Context, id string) error
 	// Lists all DIDs for a subject
 	// (GET /internal/vdr/v2/subject/{id})
 	SubjectDIDs(ctx echo.Context, id string) error
+	// Exports the subject, so it can be imported on another node.
+	// (POST /internal/vdr/v2/subject/{id}/export)
+	ExportSubject(ctx echo.Context, id string) error
 	// Find services of a subject
 	// (GET /internal/vdr/v2/subject/{id}/service)
 	FindServices(ctx echo.Context, id string, params FindServicesParams) error
@@ -1953,6 +2496,9 @@ type ServerInterface interface {
 	// Creates and adds one or more verificationMethods to each DID document in the subject.
 	// (POST /internal/vdr/v2/subject/{id}/verificationmethod)
 	AddVerificationMethod(ctx echo.Context, id string) error
+	// Rotates the keys of each DID document in the subject.
+	// (POST /internal/vdr/v2/subject/{id}/verificationmethod/rotate)
+	RotateKeys(ctx echo.Context, id string) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -2026,6 +2572,17 @@ func (w *ServerInterfaceWrapper) CreateSubject(ctx echo.Context) error {
 	return err
 }
 
+// ImportSubject converts echo context to params.
+func (w *ServerInterfaceWrapper) ImportSubject(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ImportSubject(ctx)
+	return err
+}
+
 // Deactivate converts echo context to params.
 func (w *ServerInterfaceWrapper) Deactivate(ctx echo.Context) error {
 	var err error
@@ -2056,6 +2613,21 @@ func (w *ServerInterfaceWrapper) SubjectDIDs(ctx echo.Context) error {
 	return err
 }
 
+// ExportSubject converts echo context to params.
+func (w *ServerInterfaceWrapper) ExportSubject(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	id = ctx.Param("id")
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ExportSubject(ctx, id)
+	return err
+}
+
 // FindServices converts echo context to params.
 func (w *ServerInterfaceWrapper) FindServices(ctx echo.Context) error {
 	var err error
@@ -2157,6 +2729,21 @@ func (w *ServerInterfaceWrapper) AddVerificationMethod(ctx echo.Context) error {
 	return err
 }
 
+// RotateKeys converts echo context to params.
+func (w *ServerInterfaceWrapper) RotateKeys(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	id = ctx.Param("id")
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RotateKeys(ctx, id)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -2190,13 +2777,16 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	router.GET(baseURL+"/internal/vdr/v2/did/:did", wrapper.ResolveDID)
 	router.GET(baseURL+"/internal/vdr/v2/subject", wrapper.ListSubjects)
 	router.POST(baseURL+"/internal/vdr/v2/subject", wrapper.CreateSubject)
+	router.POST(baseURL+"/internal/vdr/v2/subject/import", wrapper.ImportSubject)
 	router.DELETE(baseURL+"/internal/vdr/v2/subject/:id", wrapper.Deactivate)
 	router.GET(baseURL+"/internal/vdr/v2/subject/:id", wrapper.SubjectDIDs)
+	router.POST(baseURL+"/internal/vdr/v2/subject/:id/export", wrapper.ExportSubject)
 	router.GET(baseURL+"/internal/vdr/v2/subject/:id/service", wrapper.FindServices)
 	router.POST(baseURL+"/internal/vdr/v2/subject/:id/service", wrapper.CreateService)
 	router.DELETE(baseURL+"/internal/vdr/v2/subject/:id/service/:serviceId", wrapper.DeleteService)
 	router.POST(baseURL+"/internal/vdr/v2/subject/:id/service/:serviceId", wrapper.UpdateService)
 	router.POST(baseURL+"/internal/vdr/v2/subject/:id/verificationmethod", wrapper.AddVerificationMethod)
+	router.POST(baseURL+"/internal/vdr/v2/subject/:id/verificationmethod/rotate", wrapper.RotateKeys)
 
 }
 
@@ -2362,6 +2952,44 @@ func (response CreateSubjectdefaultApplicationProblemPlusJSONResponse) VisitCrea
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
+type ImportSubjectRequestObject struct {
+	Body *ImportSubjectJSONRequestBody
+}
+
+type ImportSubjectResponseObject interface {
+	VisitImportSubjectResponse(w http.ResponseWriter) error
+}
+
+type ImportSubject200JSONResponse SubjectCreationResult
+
+func (response ImportSubject200JSONResponse) VisitImportSubjectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportSubjectdefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
+}
+
+func (response ImportSubjectdefaultApplicationProblemPlusJSONResponse) VisitImportSubjectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
 type DeactivateRequestObject struct {
 	Id string `json:"id"`
 }
@@ -2437,6 +3065,45 @@ func (response SubjectDIDsdefaultApplicationProblemPlusJSONResponse) VisitSubjec
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
+type ExportSubjectRequestObject struct {
+	Id   string `json:"id"`
+	Body *ExportSubjectJSONRequestBody
+}
+
+type ExportSubjectResponseObject interface {
+	VisitExportSubjectResponse(w http.ResponseWriter) error
+}
+
+type ExportSubject200JSONResponse SubjectExportResult
+
+func (response ExportSubject200JSONResponse) VisitExportSubjectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportSubjectdefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
+}
+
+func (response ExportSubjectdefaultApplicationProblemPlusJSONResponse) VisitExportSubjectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
 type FindServicesRequestObject struct {
 	Id     string `json:"id"`
 	Params FindServicesParams
@@ -2632,6 +3299,45 @@ func (response AddVerificationMethoddefaultApplicationProblemPlusJSONResponse) V
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
+type RotateKeysRequestObject struct {
+	Id   string `json:"id"`
+	Body *RotateKeysJSONRequestBody
+}
+
+type RotateKeysResponseObject interface {
+	VisitRotateKeysResponse(w http.ResponseWriter) error
+}
+
+type RotateKeys200JSONResponse []VerificationMethod
+
+func (response RotateKeys200JSONResponse) VisitRotateKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateKeysdefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
+}
+
+func (response RotateKeysdefaultApplicationProblemPlusJSONResponse) VisitRotateKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
 	// Returns the root did:web DID of this domain.
@@ -2649,12 +3355,18 @@ type StrictServerInterface interface {
 	// Creates new DID Documents for a subject.
 	// (POST /internal/vdr/v2/subject)
 	CreateSubject(ctx context.Context, request CreateSubjectRequestObject) (CreateSubjectResponseObject, error)
+	// Imports a subject that was exported by another node.
+	// (POST /internal/vdr/v2/subject/import)
+	ImportSubject(ctx context.Context, request ImportSubjectRequestObject) (ImportSubjectResponseObject, error)
 	// Deactivate all DID Documents for a subject.
 	// (DELETE /internal/vdr/v2/subject/{id})
 	Deactivate(ctx context.Context, request DeactivateRequestObject) (DeactivateResponseObject, error)
 	// Lists all DIDs for a subject
 	// (GET /internal/vdr/v2/subject/{id})
 	SubjectDIDs(ctx context.Context, request SubjectDIDsRequestObject) (SubjectDIDsResponseObject, error)
+	// Exports the subject, so it can be imported on another node.
+	// (POST /internal/vdr/v2/subject/{id}/export)
+	ExportSubject(ctx context.Context, request ExportSubjectRequestObject) (ExportSubjectResponseObject, error)
 	// Find services of a subject
 	// (GET /internal/vdr/v2/subject/{id}/service)
 	FindServices(ctx context.Context, request FindServicesRequestObject) (FindServicesResponseObject, error)
@@ -2670,6 +3382,9 @@ type StrictServerInterface interface {
 	// Creates and adds one or more verificationMethods to each DID document in the subject.
 	// (POST /internal/vdr/v2/subject/{id}/verificationmethod)
 	AddVerificationMethod(ctx context.Context, request AddVerificationMethodRequestObject) (AddVerificationMethodResponseObject, error)
+	// Rotates the keys of each DID document in the subject.
+	// (POST /internal/vdr/v2/subject/{id}/verificationmethod/rotate)
+	RotateKeys(ctx context.Context, request RotateKeysRequestObject) (RotateKeysResponseObject, error)
 }
 
 type StrictHandlerFunc = strictecho.StrictEchoHandlerFunc
@@ -2809,6 +3524,35 @@ func (sh *strictHandler) CreateSubject(ctx echo.Context) error {
 	return nil
 }
 
+// ImportSubject operation middleware
+func (sh *strictHandler) ImportSubject(ctx echo.Context) error {
+	var request ImportSubjectRequestObject
+
+	var body ImportSubjectJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ImportSubject(ctx.Request().Context(), request.(ImportSubjectRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ImportSubject")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ImportSubjectResponseObject); ok {
+		return validResponse.VisitImportSubjectResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // Deactivate operation middleware
 func (sh *strictHandler) Deactivate(ctx echo.Context, id string) error {
 	var request DeactivateRequestObject
@@ -2859,6 +3603,37 @@ func (sh *strictHandler) SubjectDIDs(ctx echo.Context, id string) error {
 	return nil
 }
 
+// ExportSubject operation middleware
+func (sh *strictHandler) ExportSubject(ctx echo.Context, id string) error {
+	var request ExportSubjectRequestObject
+
+	request.Id = id
+
+	var body ExportSubjectJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ExportSubject(ctx.Request().Context(), request.(ExportSubjectRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ExportSubject")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ExportSubjectResponseObject); ok {
+		return validResponse.VisitExportSubjectResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // FindServices operation middleware
 func (sh *strictHandler) FindServices(ctx echo.Context, id string, params FindServicesParams) error {
 	var request FindServicesRequestObject
@@ -3004,3 +3779,34 @@ func (sh *strictHandler) AddVerificationMethod(ctx echo.Context, id string) erro
 	}
 	return nil
 }
+
+// RotateKeys operation middleware
+func (sh *strictHandler) RotateKeys(ctx echo.Context, id string) error {
+	var request RotateKeysRequestObject
+
+	request.Id = id
+
+	var body RotateKeysJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RotateKeys(ctx.Request().Context(), request.(RotateKeysRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RotateKeys")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(RotateKeysResponseObject); ok {
+		return validResponse.VisitRotateKeysResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
@@ -30,13 +30,28 @@ import (
 
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/vdr"
 	api "github.com/nuts-foundation/nuts-node/vdr/api/v1"
 	apiv2 "github.com/nuts-foundation/nuts-node/vdr/api/v2"
 	"github.com/nuts-foundation/nuts-node/vdr/didnuts"
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
+// FlagSet contains flags relevant for the module
+func FlagSet() *pflag.FlagSet {
+	defs := vdr.DefaultConfig()
+	flagSet := pflag.NewFlagSet("vdr", pflag.ContinueOnError)
+	flagSet.Duration("vdr.keyrotation.graceperiod", defs.KeyRotation.GracePeriod,
+		"Period rotated keys remain in DID documents (so signatures made with them can still be verified), "+
+			"after which they are removed and their private keys are deleted. Specified as Golang duration (e.g. 1m, 1h30m).")
+	flagSet.Duration("vdr.keyrotation.maxage", defs.KeyRotation.MaxAge,
+		"Age after which the keys of DID subjects are rotated automatically. If not set, keys are only rotated on request. "+
+			"Specified as Golang duration (e.g. 1m, 1h30m).")
+	return flagSet
+}
+
 // Cmd contains sub-commands for the remote client
 func Cmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -52,6 +67,9 @@ func Cmd() *cobra.Command {
 	cmd.AddCommand(addVerificationMethodCmd())
 	cmd.AddCommand(deleteVerificationMethodCmd())
 	cmd.AddCommand(addKeyAgreementKeyCmd())
+	cmd.AddCommand(rotateKeysCmd())
+	cmd.AddCommand(exportSubjectCmd())
+	cmd.AddCommand(importSubjectCmd())
 
 	return cmd
 }
@@ -355,6 +373,107 @@ func deleteVerificationMethodCmd() *cobra.Command {
 	return result
 }
 
+func rotateKeysCmd() *cobra.Command {
+	options := apiv2.KeyCreationOptions{}
+	result := &cobra.Command{
+		Use:   "rotate-keys [subject]",
+		Short: "Rotates the keys of the DID documents of a subject.",
+		Long: "Rotates the keys of the DID documents of a subject. A new key is generated for every DID document, which replaces the existing keys with the same usage. " +
+			"The replaced keys remain in the DID documents for the configured grace period (vdr.keyrotation.graceperiod), after which they are removed. " +
+			"When successful, it outputs the new verification methods.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientConfig := core.NewClientConfigForCommand(cmd)
+			verificationMethods, err := httpClientV2(clientConfig).RotateKeys(args[0], options)
+			if err != nil {
+				return fmt.Errorf("failed to rotate keys: %w", err)
+			}
+			bytes, _ := json.MarshalIndent(verificationMethods, "", "  ")
+			cmd.Printf("%s\n", string(bytes))
+			return nil
+		},
+	}
+	result.Flags().BoolVar(&options.AssertionKey, "assertion-key", true, "Pass 'false' to not rotate the assertion (signing and authentication) key.")
+	result.Flags().BoolVar(&options.EncryptionKey, "encryption-key", false, "Pass 'true' to rotate the encryption (key agreement) key.")
+	return result
+}
+
+// subjectPasswordEnv is the environment variable holding the password subject exports are encrypted with.
+// It's not a flag, to avoid leaking it through the command line.
+const subjectPasswordEnv = "NUTS_SUBJECT_PASSWORD"
+
+func exportSubjectCmd() *cobra.Command {
+	var recipientKeyID string
+	result := &cobra.Command{
+		Use:   "export-subject [subject]",
+		Short: "Exports a subject, so it can be imported on another node.",
+		Long: "Exports the DID documents of a subject, their private keys, wallet credentials and Discovery Service activations as encrypted bundle. " +
+			"The bundle is encrypted using the password (at least 16 characters) in the " + subjectPasswordEnv + " environment variable, " +
+			"or for a key agreement key of the node that's going to import it (--recipient-key). " +
+			"When successful, it outputs the bundle.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			request := apiv2.SubjectExportRequest{}
+			if password := os.Getenv(subjectPasswordEnv); password != "" {
+				request.Password = &password
+			}
+			if recipientKeyID != "" {
+				request.RecipientKeyId = &recipientKeyID
+			}
+			clientConfig := core.NewClientConfigForCommand(cmd)
+			bundle, err := httpClientV2(clientConfig).ExportSubject(args[0], request)
+			if err != nil {
+				return fmt.Errorf("failed to export subject: %w", err)
+			}
+			cmd.Println(bundle)
+			return nil
+		},
+	}
+	result.Flags().StringVar(&recipientKeyID, "recipient-key", "", "ID of the key agreement key (DID URL) of the node that's going to import the export, to encrypt the export for.")
+	return result
+}
+
+func importSubjectCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "import-subject [file]",
+		Short: "Imports a subject that was exported by another node. If no file is given, a pipe is assumed.",
+		Long: "Imports a subject from a bundle created by export-subject. " +
+			"If the bundle was encrypted using a password, it's read from the " + subjectPasswordEnv + " environment variable. " +
+			"When successful, it outputs the subject and its DID documents.",
+		Args: cobra.RangeArgs(0, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var bytes []byte
+			var err error
+			if len(args) == 1 {
+				// read from file
+				bytes, err = os.ReadFile(args[0])
+				if err != nil {
+					return fmt.Errorf("failed to read file %s: %w", args[0], err)
+				}
+			} else {
+				// read from stdin
+				bytes, err = readFromStdin()
+				if err != nil {
+					return fmt.Errorf("failed to read from pipe: %w", err)
+				}
+			}
+
+			request := apiv2.SubjectImportRequest{Bundle: strings.TrimSpace(string(bytes))}
+			if password := os.Getenv(subjectPasswordEnv); password != "" {
+				request.Password = &password
+			}
+			clientConfig := core.NewClientConfigForCommand(cmd)
+			subject, documents, err := httpClientV2(clientConfig).ImportSubject(request)
+			if err != nil {
+				return fmt.Errorf("failed to import subject: %w", err)
+			}
+			bytes, _ = json.MarshalIndent(apiv2.SubjectCreationResult{Subject: subject, Documents: documents}, "", "  ")
+			cmd.Printf("%s\n", string(bytes))
+			return nil
+		},
+	}
+}
+
 func askYesNo(question string, cmd *cobra.Command) (answer bool) {
 	reader := bufio.NewReader(cmd.InOrStdin())
 	question += "[yes/no]: "
@@ -28,7 +28,10 @@ import (
 	"github.com/stretchr/testify/require"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path"
 	"testing"
+	"time"
 
 	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/did"
@@ -324,6 +327,128 @@ func TestEngine_Command(t *testing.T) {
 		})
 	})
 
+	t.Run("rotate-keys", func(t *testing.T) {
+		pair, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		verificationMethod, _ := did.NewVerificationMethod(vdr.TestMethodDIDA, ssi.JsonWebKey2020, vdr.TestDIDA, pair.PublicKey)
+
+		t.Run("ok", func(t *testing.T) {
+			cmd := newCmdWithServer(t, http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				assert.Equal(t, "/internal/vdr/v2/subject/subject/verificationmethod/rotate", request.URL.Path)
+				var options v2.KeyCreationOptions
+				_ = json.NewDecoder(request.Body).Decode(&options)
+				assert.True(t, options.AssertionKey)
+				assert.True(t, options.EncryptionKey)
+				writer.Header().Set("Content-Type", "application/json")
+				writer.WriteHeader(http.StatusOK)
+				bytes, _ := json.Marshal([]did.VerificationMethod{*verificationMethod})
+				_, _ = writer.Write(bytes)
+			}))
+			cmd.SetArgs([]string{"rotate-keys", "subject", "--encryption-key"})
+			cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+
+			err := cmd.Execute()
+
+			require.NoError(t, err)
+			var verificationMethods []did.VerificationMethod
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &verificationMethods))
+			assert.Equal(t, []did.VerificationMethod{*verificationMethod}, verificationMethods)
+			assert.Empty(t, errBuf.Bytes())
+		})
+		t.Run("error - subject not found", func(t *testing.T) {
+			cmd := newCmdWithServer(t, &http2.Handler{StatusCode: http.StatusNotFound})
+			cmd.SetArgs([]string{"rotate-keys", "subject"})
+			cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+
+			err := cmd.Execute()
+
+			require.Error(t, err)
+			assert.Contains(t, errBuf.String(), "failed to rotate keys: server returned HTTP 404 (expected: 200)")
+		})
+	})
+
+	t.Run("export-subject", func(t *testing.T) {
+		t.Run("ok", func(t *testing.T) {
+			cmd := newCmdWithServer(t, http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				assert.Equal(t, "/internal/vdr/v2/subject/subject/export", request.URL.Path)
+				var body v2.SubjectExportRequest
+				_ = json.NewDecoder(request.Body).Decode(&body)
+				assert.Equal(t, "secret", *body.Password)
+				assert.Nil(t, body.RecipientKeyId)
+				writer.Header().Set("Content-Type", "application/json")
+				writer.WriteHeader(http.StatusOK)
+				bytes, _ := json.Marshal(v2.SubjectExportResult{Bundle: "bundle"})
+				_, _ = writer.Write(bytes)
+			}))
+			t.Setenv("NUTS_SUBJECT_PASSWORD", "secret")
+			cmd.SetArgs([]string{"export-subject", "subject"})
+			cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+
+			err := cmd.Execute()
+
+			require.NoError(t, err)
+			assert.Equal(t, "bundle\n", buf.String())
+		})
+		t.Run("error - server error", func(t *testing.T) {
+			cmd := newCmdWithServer(t, &http2.Handler{StatusCode: http.StatusNotFound})
+			cmd.SetArgs([]string{"export-subject", "subject", "--recipient-key", "did:web:example.com#0"})
+			cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+
+			err := cmd.Execute()
+
+			require.Error(t, err)
+			assert.Contains(t, errBuf.String(), "failed to export subject: server returned HTTP 404 (expected: 200)")
+		})
+	})
+
+	t.Run("import-subject", func(t *testing.T) {
+		bundleFile := path.Join(t.TempDir(), "bundle.jwe")
+		require.NoError(t, os.WriteFile(bundleFile, []byte("bundle\n"), 0600))
+
+		t.Run("ok", func(t *testing.T) {
+			cmd := newCmdWithServer(t, http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				assert.Equal(t, "/internal/vdr/v2/subject/import", request.URL.Path)
+				var body v2.SubjectImportRequest
+				_ = json.NewDecoder(request.Body).Decode(&body)
+				assert.Equal(t, "bundle", body.Bundle)
+				assert.Equal(t, "secret", *body.Password)
+				writer.Header().Set("Content-Type", "application/json")
+				writer.WriteHeader(http.StatusOK)
+				bytes, _ := json.Marshal(v2.SubjectCreationResult{Subject: "subject", Documents: []did.Document{exampleDIDDocument}})
+				_, _ = writer.Write(bytes)
+			}))
+			t.Setenv("NUTS_SUBJECT_PASSWORD", "secret")
+			cmd.SetArgs([]string{"import-subject", bundleFile})
+			cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+
+			err := cmd.Execute()
+
+			require.NoError(t, err)
+			var result v2.SubjectCreationResult
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+			assert.Equal(t, "subject", result.Subject)
+			assert.Empty(t, errBuf.Bytes())
+		})
+		t.Run("error - file not found", func(t *testing.T) {
+			cmd := newCmd(t)
+			cmd.SetArgs([]string{"import-subject", path.Join(t.TempDir(), "unknown")})
+
+			err := cmd.Execute()
+
+			require.Error(t, err)
+			assert.Contains(t, errBuf.String(), "failed to read file")
+		})
+		t.Run("error - server error", func(t *testing.T) {
+			cmd := newCmdWithServer(t, &http2.Handler{StatusCode: http.StatusBadRequest})
+			cmd.SetArgs([]string{"import-subject", bundleFile})
+			cmd.PersistentFlags().AddFlagSet(core.ClientConfigFlags())
+
+			err := cmd.Execute()
+
+			require.Error(t, err)
+			assert.Contains(t, errBuf.String(), "failed to import subject: server returned HTTP 400 (expected: 200)")
+		})
+	})
+
 	t.Run("deleteVerificationMethod", func(t *testing.T) {
 		t.Run("ok", func(t *testing.T) {
 			cmd := newCmdWithServer(t, &http2.Handler{StatusCode: http.StatusNoContent})
@@ -418,6 +543,16 @@ func TestEngine_Command(t *testing.T) {
 	})
 }
 
+func TestFlagSet(t *testing.T) {
+	flags := FlagSet()
+
+	gracePeriod, _ := flags.GetDuration("vdr.keyrotation.graceperiod")
+	maxAge, _ := flags.GetDuration("vdr.keyrotation.maxage")
+
+	assert.Equal(t, 7*24*time.Hour, gracePeriod)
+	assert.Zero(t, maxAge)
+}
+
 func Test_askYesNo(t *testing.T) {
 	question := "do you believe that the earth is a convex sphere?"
 	cmd := Cmd()
@@ -0,0 +1,42 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+package vdr
+
+import "time"
+
+// Config holds the config of the module
+type Config struct {
+	KeyRotation KeyRotationConfig `koanf:"keyrotation"`
+}
+
+// KeyRotationConfig holds the config for rotating the keys of DID subjects.
+type KeyRotationConfig struct {
+	// GracePeriod specifies how long rotated keys remain in the DID documents, before they are removed and their private keys deleted.
+	GracePeriod time.Duration `koanf:"graceperiod"`
+	// MaxAge specifies the age after which keys are rotated automatically. If 0, keys are not rotated automatically.
+	MaxAge time.Duration `koanf:"maxage"`
+}
+
+// DefaultConfig returns the default configuration.
+func DefaultConfig() Config {
+	return Config{
+		KeyRotation: KeyRotationConfig{
+			GracePeriod: 7 * 24 * time.Hour,
+		},
+	}
+}
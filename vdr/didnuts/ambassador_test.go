@@ -113,6 +113,14 @@ func (m *mockKeyStore) Delete(ctx context.Context, kid string) error {
 	panic("not implemented")
 }
 
+func (m *mockKeyStore) Export(ctx context.Context, kid string) (crypto.PrivateKey, error) {
+	panic("not implemented")
+}
+
+func (m *mockKeyStore) Import(ctx context.Context, kid string, key crypto.PrivateKey) error {
+	panic("not implemented")
+}
+
 type testTransaction struct {
 	clock        uint32
 	signingKey   jwk.Key
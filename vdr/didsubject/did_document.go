@@ -84,7 +84,10 @@ func (s *SqlDIDDocumentManager) Latest(did did.DID, resolveTime *time.Time) (*or
 	if resolveTime != nil {
 		notAfter = resolveTime.Unix()
 	}
-	err := s.tx.Preload("DID").Preload("Services").Preload("VerificationMethods").Where("did = ? AND updated_at <= ?", did.String(), notAfter).Order("version desc").First(&doc).Error
+	// verification methods are ordered by weight, so the most recently rotated key takes precedence
+	err := s.tx.Preload("DID").Preload("Services").Preload("VerificationMethods", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("weight desc")
+	}).Where("did = ? AND updated_at <= ?", did.String(), notAfter).Order("version desc").First(&doc).Error
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,392 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+package didsubject
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwe"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/core"
+	nutsCrypto "github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/crypto/jwx"
+	"github.com/nuts-foundation/nuts-node/storage/orm"
+	"github.com/nuts-foundation/nuts-node/vdr/log"
+	"gorm.io/gorm"
+)
+
+// passwordKeyEncryptionAlgorithm is the algorithm used to derive the content encryption key of password-protected exports.
+const passwordKeyEncryptionAlgorithm = jwa.PBES2_HS512_A256KW
+
+// minExportPasswordLength is the minimum length (in characters) of the password a subject export is encrypted with.
+// The export contains private keys and can be attacked offline, so the password must not be guessable.
+const minExportPasswordLength = 16
+
+// ErrInvalidExport is returned when a subject export bundle can't be decrypted or is malformed.
+var ErrInvalidExport = errors.New("invalid subject export")
+
+// ErrWeakExportPassword is returned when the password to encrypt a subject export with is too short.
+var ErrWeakExportPassword = fmt.Errorf("export password must be at least %d characters", minExportPasswordLength)
+
+// SubjectDataExporter exports and imports data of other modules that relates to a subject (e.g. wallet credentials),
+// so it's moved along with the subject when it's exported to another node.
+type SubjectDataExporter interface {
+	// ExportSubjectData returns the data related to the subject and its DIDs as JSON. It returns nil if there's no data.
+	ExportSubjectData(ctx context.Context, subject string, dids []did.DID) ([]byte, error)
+	// ImportSubjectData restores the data exported by ExportSubjectData.
+	ImportSubjectData(ctx context.Context, subject string, data []byte) error
+	// RemoveSubjectData removes the data restored by ImportSubjectData. It's called when importing the subject fails.
+	RemoveSubjectData(ctx context.Context, subject string, data []byte) error
+}
+
+// ExportEncryption specifies how a subject export is encrypted. Either Password or RecipientKey must be set.
+type ExportEncryption struct {
+	// Password is used to derive the encryption key (PBES2).
+	Password string
+	// RecipientKeyID is the ID of the key of the node the export is encrypted for.
+	// It's added to the JWE header, so the importing node can find the key to decrypt it.
+	RecipientKeyID string
+	// RecipientKey is the public key the export is encrypted for.
+	RecipientKey crypto.PublicKey
+}
+
+// SubjectExport is the (decrypted) content of a subject export bundle.
+type SubjectExport struct {
+	// Subject is the ID of the exported subject.
+	Subject string `json:"subject"`
+	// Documents contains the latest version of each DID document of the subject.
+	Documents []DocumentExport `json:"documents"`
+	// Keys contains the private keys of the verification methods as JWK. The kid is the ID of the verification method.
+	Keys []json.RawMessage `json:"keys"`
+	// Data contains the data of other modules related to the subject, by name of the SubjectDataExporter.
+	Data map[string]json.RawMessage `json:"data,omitempty"`
+}
+
+// DocumentExport contains a DID document and its metadata as exported by SqlManager.Export.
+type DocumentExport struct {
+	// Document is the DID document, as stored by the DID method.
+	Document json.RawMessage `json:"document"`
+	// Version is the version of the DID document.
+	Version int `json:"version"`
+	// Created is the (unix) timestamp at which the DID document was created.
+	Created int64 `json:"created"`
+}
+
+func (r *SqlManager) Export(ctx context.Context, subject string, encryption ExportEncryption) (string, error) {
+	if encryption.Password != "" && utf8.RuneCountInString(encryption.Password) < minExportPasswordLength {
+		return "", ErrWeakExportPassword
+	}
+	dids, err := NewDIDManager(r.DB).FindBySubject(subject)
+	if err != nil {
+		return "", err
+	}
+	export := SubjectExport{
+		Subject: subject,
+		Keys:    make([]json.RawMessage, 0),
+	}
+	ids := make([]did.DID, 0, len(dids))
+	for _, sqlDID := range dids {
+		id, err := did.ParseDID(sqlDID.ID)
+		if err != nil {
+			return "", err
+		}
+		ids = append(ids, *id)
+		latest, err := NewDIDDocumentManager(r.DB).Latest(*id, nil)
+		if err != nil {
+			return "", err
+		}
+		document, err := latest.ToDIDDocument()
+		if err != nil {
+			return "", err
+		}
+		for _, vm := range document.VerificationMethod {
+			privateKey, err := r.KeyStore.Export(ctx, vm.ID.String())
+			if errors.Is(err, nutsCrypto.ErrPrivateKeyNotFound) && !vm.Controller.Equals(document.ID) {
+				// a key of another controller
+				log.Logger().WithField(core.LogFieldDIDSubject, subject).Warnf("Private key of verification method not found, not exporting it (id: %s)", vm.ID)
+				continue
+			} else if err != nil {
+				return "", fmt.Errorf("could not export private key (id: %s): %w", vm.ID, err)
+			}
+			privateJWK, err := jwk.FromRaw(privateKey)
+			if err != nil {
+				return "", err
+			}
+			_ = privateJWK.Set(jwk.KeyIDKey, vm.ID.String())
+			keyAsJSON, _ := json.Marshal(privateJWK)
+			export.Keys = append(export.Keys, keyAsJSON)
+		}
+		export.Documents = append(export.Documents, DocumentExport{
+			Document: json.RawMessage(latest.Raw),
+			Version:  latest.Version,
+			Created:  latest.CreatedAt,
+		})
+	}
+	for name, exporter := range r.SubjectDataExporters {
+		data, err := exporter.ExportSubjectData(ctx, subject, ids)
+		if err != nil {
+			return "", fmt.Errorf("could not export %s data: %w", name, err)
+		}
+		if data == nil {
+			continue
+		}
+		if export.Data == nil {
+			export.Data = make(map[string]json.RawMessage)
+		}
+		export.Data[name] = data
+	}
+
+	payload, _ := json.Marshal(export)
+	var bundle string
+	if encryption.Password != "" {
+		var encrypted []byte
+		encrypted, err = jwe.Encrypt(payload, jwe.WithKey(passwordKeyEncryptionAlgorithm, []byte(encryption.Password)), jwe.WithContentEncryption(jwx.DefaultContentEncryptionAlgorithm))
+		bundle = string(encrypted)
+	} else if encryption.RecipientKey != nil {
+		bundle, err = r.KeyStore.EncryptJWE(ctx, payload, map[string]interface{}{jwe.KeyIDKey: encryption.RecipientKeyID}, encryption.RecipientKey)
+	} else {
+		return "", errors.New("export must be encrypted with either a password or a recipient key")
+	}
+	if err != nil {
+		return "", fmt.Errorf("could not encrypt subject export: %w", err)
+	}
+	log.Logger().
+		WithField(core.LogFieldDIDSubject, subject).
+		Infof("Exported subject (DIDs: [%s], keys: %d)", joinDIDs(ids), len(export.Keys))
+	return bundle, nil
+}
+
+func (r *SqlManager) Import(ctx context.Context, bundle string, password string) ([]did.Document, string, error) {
+	var payload []byte
+	var err error
+	if password != "" {
+		payload, err = jwe.Decrypt([]byte(bundle), jwe.WithKey(passwordKeyEncryptionAlgorithm, []byte(password)))
+	} else {
+		payload, _, err = r.KeyStore.DecryptJWE(ctx, bundle)
+	}
+	if err != nil {
+		return nil, "", errors.Join(ErrInvalidExport, fmt.Errorf("could not decrypt subject export: %w", err))
+	}
+	var export SubjectExport
+	if err = json.Unmarshal(payload, &export); err != nil {
+		return nil, "", errors.Join(ErrInvalidExport, err)
+	}
+	if !subjectPattern.MatchString(export.Subject) {
+		return nil, "", errors.Join(ErrInvalidExport, fmt.Errorf("invalid subject (must follow pattern: %s)", subjectPattern.String()))
+	}
+	if len(export.Documents) == 0 {
+		return nil, "", errors.Join(ErrInvalidExport, errors.New("no DID documents"))
+	}
+	// parse the keys before importing anything, so a malformed export doesn't leave a partially imported subject
+	keys := make(map[string]crypto.PrivateKey, len(export.Keys))
+	for _, keyAsJSON := range export.Keys {
+		privateJWK, err := jwk.ParseKey(keyAsJSON)
+		if err != nil {
+			return nil, "", errors.Join(ErrInvalidExport, err)
+		}
+		var privateKey crypto.PrivateKey
+		if err = privateJWK.Raw(&privateKey); err != nil {
+			return nil, "", errors.Join(ErrInvalidExport, err)
+		}
+		keys[privateJWK.KeyID()] = privateKey
+	}
+	if err = validateExportKeys(export.Documents, keys); err != nil {
+		return nil, "", errors.Join(ErrInvalidExport, err)
+	}
+
+	documents, ids, err := r.importDocuments(export)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not import subject: %w", err)
+	}
+	// The private keys and the data of other modules are stored outside the DID document transaction (e.g. in Vault).
+	// If importing one of them fails, everything imported so far is deleted again.
+	imported := subjectImport{subject: export.Subject}
+	if err = r.importKeysAndData(ctx, export, keys, &imported); err != nil {
+		if undoErr := r.undoImport(ctx, imported); undoErr != nil {
+			log.Logger().
+				WithError(undoErr).
+				WithField(core.LogFieldDIDSubject, export.Subject).
+				Error("Failed to undo partial subject import")
+		}
+		return nil, "", fmt.Errorf("could not import subject: %w", err)
+	}
+	sortDIDDocumentsByMethod(documents, r.PreferredOrder)
+	log.Logger().
+		WithField(core.LogFieldDIDSubject, export.Subject).
+		Infof("Imported subject (DIDs: [%s], keys: %d)", joinDIDs(ids), len(export.Keys))
+	return documents, export.Subject, nil
+}
+
+// validateExportKeys checks that the private keys of an export belong to the verification methods of its DID documents:
+// each key must match the public key of a verification method, and each verification method controlled by the DID itself must have a key.
+// Verification methods of other controllers don't have a key, since they aren't exported.
+func validateExportKeys(documents []DocumentExport, keys map[string]crypto.PrivateKey) error {
+	publicKeys := make(map[string]crypto.PublicKey)
+	for _, documentExport := range documents {
+		document, err := did.ParseDocument(string(documentExport.Document))
+		if err != nil {
+			return err
+		}
+		for _, vm := range document.VerificationMethod {
+			publicKey, err := vm.PublicKey()
+			if err != nil {
+				return fmt.Errorf("invalid verification method (id: %s): %w", vm.ID, err)
+			}
+			publicKeys[vm.ID.String()] = publicKey
+			if _, ok := keys[vm.ID.String()]; !ok && vm.Controller.Equals(document.ID) {
+				return fmt.Errorf("missing private key of verification method (id: %s)", vm.ID)
+			}
+		}
+	}
+	for kid, privateKey := range keys {
+		publicKey, ok := publicKeys[kid]
+		if !ok {
+			return fmt.Errorf("private key doesn't belong to a verification method (id: %s)", kid)
+		}
+		signer, ok := privateKey.(crypto.Signer)
+		if !ok {
+			return fmt.Errorf("unsupported private key (id: %s)", kid)
+		}
+		if actual, ok := signer.Public().(interface{ Equal(crypto.PublicKey) bool }); !ok || !actual.Equal(publicKey) {
+			return fmt.Errorf("private key doesn't match the verification method (id: %s)", kid)
+		}
+	}
+	return nil
+}
+
+// subjectImport keeps track of what's been imported, so it can be deleted again if the import fails.
+type subjectImport struct {
+	subject string
+	keyIDs  []string
+	data    map[string][]byte
+}
+
+// importDocuments stores the DIDs and DID documents of the export in a single transaction.
+func (r *SqlManager) importDocuments(export SubjectExport) ([]did.Document, []did.DID, error) {
+	var documents []did.Document
+	var ids []did.DID
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		didManager := NewDIDManager(tx)
+		exists, err := didManager.SubjectExists(export.Subject)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return ErrSubjectAlreadyExists
+		}
+		now := time.Now()
+		for _, documentExport := range export.Documents {
+			sqlDoc, err := orm.MigrationDocument{
+				Raw:     documentExport.Document,
+				Created: time.Unix(documentExport.Created, 0),
+				Updated: now,
+				Version: documentExport.Version,
+			}.ToORMDocument(export.Subject)
+			if err != nil {
+				return errors.Join(ErrInvalidExport, err)
+			}
+			document, err := sqlDoc.ToDIDDocument()
+			if err != nil {
+				return errors.Join(ErrInvalidExport, err)
+			}
+			if _, ok := r.MethodManagers[document.ID.Method]; !ok {
+				return fmt.Errorf("%w: %s", ErrUnsupportedDIDMethod, document.ID.Method)
+			}
+			if existing, err := didManager.Find(document.ID); err != nil {
+				return err
+			} else if existing != nil {
+				return fmt.Errorf("DID already exists: %s", document.ID)
+			}
+			// the first verification method takes precedence, like it did on the exporting node
+			for i := range sqlDoc.VerificationMethods {
+				sqlDoc.VerificationMethods[i].Weight = int16(len(sqlDoc.VerificationMethods) - i)
+			}
+			if err = tx.Create(&sqlDoc.DID).Error; err != nil {
+				return err
+			}
+			if err = tx.Create(&sqlDoc).Error; err != nil {
+				return err
+			}
+			documents = append(documents, document)
+			ids = append(ids, document.ID)
+		}
+		return nil
+	})
+	return documents, ids, err
+}
+
+// importKeysAndData imports the private keys and the data of other modules of the export, registering them in imported.
+func (r *SqlManager) importKeysAndData(ctx context.Context, export SubjectExport, keys map[string]crypto.PrivateKey, imported *subjectImport) error {
+	for kid, privateKey := range keys {
+		if err := r.KeyStore.Import(ctx, kid, privateKey); err != nil {
+			return fmt.Errorf("could not import private key (id: %s): %w", kid, err)
+		}
+		imported.keyIDs = append(imported.keyIDs, kid)
+	}
+	for name, data := range export.Data {
+		exporter, ok := r.SubjectDataExporters[name]
+		if !ok {
+			log.Logger().WithField(core.LogFieldDIDSubject, export.Subject).Warnf("Subject export contains unsupported data, ignoring it: %s", name)
+			continue
+		}
+		if err := exporter.ImportSubjectData(ctx, export.Subject, data); err != nil {
+			return fmt.Errorf("could not import %s data: %w", name, err)
+		}
+		if imported.data == nil {
+			imported.data = make(map[string][]byte)
+		}
+		imported.data[name] = data
+	}
+	return nil
+}
+
+// undoImport deletes the data of other modules, private keys and DIDs of a partially imported subject.
+func (r *SqlManager) undoImport(ctx context.Context, imported subjectImport) error {
+	var errs []error
+	for name, data := range imported.data {
+		if err := r.SubjectDataExporters[name].RemoveSubjectData(ctx, imported.subject, data); err != nil {
+			errs = append(errs, fmt.Errorf("could not remove %s data: %w", name, err))
+		}
+	}
+	for _, kid := range imported.keyIDs {
+		if err := r.KeyStore.Delete(ctx, kid); err != nil {
+			errs = append(errs, fmt.Errorf("could not delete private key (id: %s): %w", kid, err))
+		}
+	}
+	if err := NewDIDManager(r.DB).DeleteAll(imported.subject); err != nil {
+		errs = append(errs, fmt.Errorf("could not delete DIDs: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+func joinDIDs(ids []did.DID) string {
+	result := make([]string, len(ids))
+	for i, id := range ids {
+		result[i] = id.String()
+	}
+	return strings.Join(result, ", ")
+}
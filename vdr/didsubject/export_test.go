@@ -0,0 +1,384 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+package didsubject
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwe"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/audit"
+	nutsCrypto "github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/storage/orm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestManager_ExportImport(t *testing.T) {
+	ctx := audit.TestContext()
+	subject := "subject"
+	password := "correct horse battery staple"
+	newManager := func(t *testing.T) (*SqlManager, nutsCrypto.KeyStore, *testDataExporter) {
+		db := testDB(t)
+		keyStore := nutsCrypto.NewDatabaseCryptoInstance(db)
+		exporter := &testDataExporter{}
+		return &SqlManager{
+			DB:                   db,
+			MethodManagers:       map[string]MethodManager{"example": keyMethod{keyStore: keyStore}},
+			KeyStore:             keyStore,
+			SubjectDataExporters: map[string]SubjectDataExporter{"test": exporter},
+		}, keyStore, exporter
+	}
+	setup := func(t *testing.T) (*SqlManager, []did.Document) {
+		source, _, exporter := newManager(t)
+		exporter.data = []byte(`{"hello":"world"}`)
+		documents, _, err := source.Create(ctx, DefaultCreationOptions().With(SubjectCreationOption{Subject: subject}))
+		require.NoError(t, err)
+		_, err = source.RotateKeys(ctx, subject, orm.AssertionKeyUsage())
+		require.NoError(t, err)
+		return source, documents
+	}
+	// tamper decrypts the bundle, lets fn alter the export and encrypts it again
+	tamper := func(t *testing.T, bundle string, fn func(export *SubjectExport)) string {
+		payload, err := jwe.Decrypt([]byte(bundle), jwe.WithKey(passwordKeyEncryptionAlgorithm, []byte(password)))
+		require.NoError(t, err)
+		var export SubjectExport
+		require.NoError(t, json.Unmarshal(payload, &export))
+		fn(&export)
+		payload, _ = json.Marshal(export)
+		result, err := jwe.Encrypt(payload, jwe.WithKey(passwordKeyEncryptionAlgorithm, []byte(password)), jwe.WithContentEncryption(jwa.A256GCM))
+		require.NoError(t, err)
+		return string(result)
+	}
+	newKey := func(t *testing.T, kid string) json.RawMessage {
+		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		privateJWK, err := jwk.FromRaw(privateKey)
+		require.NoError(t, err)
+		require.NoError(t, privateJWK.Set(jwk.KeyIDKey, kid))
+		keyAsJSON, _ := json.Marshal(privateJWK)
+		return keyAsJSON
+	}
+
+	t.Run("ok - password", func(t *testing.T) {
+		source, _ := setup(t)
+		target, targetKeyStore, targetExporter := newManager(t)
+
+		bundle, err := source.Export(ctx, subject, ExportEncryption{Password: password})
+		require.NoError(t, err)
+		documents, importedSubject, err := target.Import(ctx, bundle, password)
+
+		require.NoError(t, err)
+		assert.Equal(t, subject, importedSubject)
+		require.Len(t, documents, 1)
+		t.Run("DID documents are restored", func(t *testing.T) {
+			sourceDoc, err := NewDIDDocumentManager(source.DB).Latest(documents[0].ID, nil)
+			require.NoError(t, err)
+			targetDoc, err := NewDIDDocumentManager(target.DB).Latest(documents[0].ID, nil)
+			require.NoError(t, err)
+			assert.Equal(t, sourceDoc.Raw, targetDoc.Raw)
+			assert.Equal(t, sourceDoc.Version, targetDoc.Version)
+			dids, err := target.ListDIDs(ctx, subject)
+			require.NoError(t, err)
+			assert.Equal(t, []did.DID{documents[0].ID}, dids)
+		})
+		t.Run("most recent key keeps precedence", func(t *testing.T) {
+			sourceDoc, _ := NewDIDDocumentManager(source.DB).Latest(documents[0].ID, nil)
+			targetDoc, _ := NewDIDDocumentManager(target.DB).Latest(documents[0].ID, nil)
+			require.Len(t, targetDoc.VerificationMethods, 2)
+			assert.Equal(t, sourceDoc.VerificationMethods[0].ID, targetDoc.VerificationMethods[0].ID)
+			assert.Equal(t, sourceDoc.VerificationMethods[0].KeyTypes, targetDoc.VerificationMethods[0].KeyTypes)
+		})
+		t.Run("private keys are restored", func(t *testing.T) {
+			for _, vm := range documents[0].VerificationMethod {
+				publicKey, err := targetKeyStore.Resolve(ctx, vm.ID.String())
+				require.NoError(t, err)
+				expected, _ := vm.PublicKey()
+				assert.Equal(t, expected, publicKey)
+			}
+		})
+		t.Run("data of other modules is restored", func(t *testing.T) {
+			assert.JSONEq(t, `{"hello":"world"}`, string(targetExporter.imported))
+		})
+	})
+	t.Run("ok - recipient key", func(t *testing.T) {
+		source, _ := setup(t)
+		target, targetKeyStore, _ := newManager(t)
+		ref, publicKey, err := targetKeyStore.New(ctx, nutsCrypto.StringNamingFunc("target-key"))
+		require.NoError(t, err)
+
+		bundle, err := source.Export(ctx, subject, ExportEncryption{RecipientKeyID: ref.KID, RecipientKey: publicKey})
+		require.NoError(t, err)
+		_, importedSubject, err := target.Import(ctx, bundle, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, subject, importedSubject)
+	})
+	t.Run("ok - no data of other modules", func(t *testing.T) {
+		source, _ := setup(t)
+		source.SubjectDataExporters["test"].(*testDataExporter).data = nil
+		target, _, targetExporter := newManager(t)
+
+		bundle, err := source.Export(ctx, subject, ExportEncryption{Password: password})
+		require.NoError(t, err)
+		_, _, err = target.Import(ctx, bundle, password)
+
+		require.NoError(t, err)
+		assert.Nil(t, targetExporter.imported)
+	})
+	t.Run("ok - unsupported data is ignored", func(t *testing.T) {
+		source, _ := setup(t)
+		target, _, _ := newManager(t)
+		target.SubjectDataExporters = nil
+
+		bundle, err := source.Export(ctx, subject, ExportEncryption{Password: password})
+		require.NoError(t, err)
+		_, _, err = target.Import(ctx, bundle, password)
+
+		assert.NoError(t, err)
+	})
+	t.Run("export - subject not found", func(t *testing.T) {
+		source, _, _ := newManager(t)
+
+		_, err := source.Export(ctx, subject, ExportEncryption{Password: password})
+
+		assert.ErrorIs(t, err, ErrSubjectNotFound)
+	})
+	t.Run("export - no encryption", func(t *testing.T) {
+		source, _ := setup(t)
+
+		_, err := source.Export(ctx, subject, ExportEncryption{})
+
+		assert.EqualError(t, err, "export must be encrypted with either a password or a recipient key")
+	})
+	t.Run("export - exporting data fails", func(t *testing.T) {
+		source, _ := setup(t)
+		source.SubjectDataExporters["test"].(*testDataExporter).err = assert.AnError
+
+		_, err := source.Export(ctx, subject, ExportEncryption{Password: password})
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("export - password too short", func(t *testing.T) {
+		source, _ := setup(t)
+
+		_, err := source.Export(ctx, subject, ExportEncryption{Password: "secret"})
+
+		assert.ErrorIs(t, err, ErrWeakExportPassword)
+	})
+	t.Run("import - wrong password", func(t *testing.T) {
+		source, _ := setup(t)
+		target, _, _ := newManager(t)
+		bundle, err := source.Export(ctx, subject, ExportEncryption{Password: password})
+		require.NoError(t, err)
+
+		_, _, err = target.Import(ctx, bundle, "wrong password, but long enough")
+
+		assert.ErrorIs(t, err, ErrInvalidExport)
+	})
+	t.Run("import - subject already exists", func(t *testing.T) {
+		source, _ := setup(t)
+		bundle, err := source.Export(ctx, subject, ExportEncryption{Password: password})
+		require.NoError(t, err)
+
+		_, _, err = source.Import(ctx, bundle, password)
+
+		assert.ErrorIs(t, err, ErrSubjectAlreadyExists)
+	})
+	t.Run("import - unsupported DID method", func(t *testing.T) {
+		source, _ := setup(t)
+		target, _, _ := newManager(t)
+		target.MethodManagers = map[string]MethodManager{"test": testMethod{method: "test"}}
+		bundle, err := source.Export(ctx, subject, ExportEncryption{Password: password})
+		require.NoError(t, err)
+
+		_, _, err = target.Import(ctx, bundle, password)
+
+		assert.ErrorIs(t, err, ErrUnsupportedDIDMethod)
+		exists, _ := target.Exists(ctx, subject)
+		assert.False(t, exists)
+	})
+	t.Run("import - importing data fails", func(t *testing.T) {
+		source, _ := setup(t)
+		target, _, targetExporter := newManager(t)
+		targetExporter.err = assert.AnError
+		bundle, err := source.Export(ctx, subject, ExportEncryption{Password: password})
+		require.NoError(t, err)
+
+		_, _, err = target.Import(ctx, bundle, password)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "could not import subject: could not import test data")
+		t.Run("import is undone", func(t *testing.T) {
+			exists, err := target.Exists(ctx, subject)
+			require.NoError(t, err)
+			assert.False(t, exists)
+			var keyCount int64
+			require.NoError(t, target.DB.Model(&orm.KeyReference{}).Count(&keyCount).Error)
+			assert.Zero(t, keyCount)
+		})
+	})
+	t.Run("import - data of other modules is removed when the import fails", func(t *testing.T) {
+		source, _ := setup(t)
+		source.SubjectDataExporters["other"] = &testDataExporter{data: []byte(`{"other":"data"}`)}
+		target, _, targetExporter := newManager(t)
+		targetExporter.err = assert.AnError
+		otherExporter := &testDataExporter{}
+		target.SubjectDataExporters["other"] = otherExporter
+		bundle, err := source.Export(ctx, subject, ExportEncryption{Password: password})
+		require.NoError(t, err)
+
+		_, _, err = target.Import(ctx, bundle, password)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		// depending on the order, the other data is imported before the failing one and must be removed then
+		assert.Equal(t, otherExporter.imported, otherExporter.removed)
+	})
+	t.Run("import - key doesn't belong to a verification method", func(t *testing.T) {
+		source, documents := setup(t)
+		target, _, _ := newManager(t)
+		bundle, err := source.Export(ctx, subject, ExportEncryption{Password: password})
+		require.NoError(t, err)
+		bundle = tamper(t, bundle, func(export *SubjectExport) {
+			export.Keys = append(export.Keys, newKey(t, documents[0].ID.String()+"#orphan"))
+		})
+
+		_, _, err = target.Import(ctx, bundle, password)
+
+		assert.ErrorIs(t, err, ErrInvalidExport)
+		assert.ErrorContains(t, err, "private key doesn't belong to a verification method")
+		exists, _ := target.Exists(ctx, subject)
+		assert.False(t, exists)
+	})
+	t.Run("import - key doesn't match the verification method", func(t *testing.T) {
+		source, _ := setup(t)
+		target, _, _ := newManager(t)
+		bundle, err := source.Export(ctx, subject, ExportEncryption{Password: password})
+		require.NoError(t, err)
+		bundle = tamper(t, bundle, func(export *SubjectExport) {
+			exported, err := jwk.ParseKey(export.Keys[0])
+			require.NoError(t, err)
+			export.Keys[0] = newKey(t, exported.KeyID())
+		})
+
+		_, _, err = target.Import(ctx, bundle, password)
+
+		assert.ErrorIs(t, err, ErrInvalidExport)
+		assert.ErrorContains(t, err, "private key doesn't match the verification method")
+		exists, _ := target.Exists(ctx, subject)
+		assert.False(t, exists)
+	})
+	t.Run("import - verification method without key", func(t *testing.T) {
+		source, _ := setup(t)
+		target, _, _ := newManager(t)
+		bundle, err := source.Export(ctx, subject, ExportEncryption{Password: password})
+		require.NoError(t, err)
+		bundle = tamper(t, bundle, func(export *SubjectExport) {
+			export.Keys = export.Keys[1:]
+		})
+
+		_, _, err = target.Import(ctx, bundle, password)
+
+		assert.ErrorIs(t, err, ErrInvalidExport)
+		assert.ErrorContains(t, err, "missing private key of verification method")
+		exists, _ := target.Exists(ctx, subject)
+		assert.False(t, exists)
+	})
+	t.Run("import - malformed key", func(t *testing.T) {
+		target, _, _ := newManager(t)
+		payload, _ := json.Marshal(SubjectExport{
+			Subject:   subject,
+			Documents: []DocumentExport{{Document: json.RawMessage(`{"id":"did:example:123"}`), Version: 1}},
+			Keys:      []json.RawMessage{json.RawMessage(`{"kty":"invalid"}`)},
+		})
+		bundle, err := jwe.Encrypt(payload, jwe.WithKey(passwordKeyEncryptionAlgorithm, []byte(password)), jwe.WithContentEncryption(jwa.A256GCM))
+		require.NoError(t, err)
+
+		_, _, err = target.Import(ctx, string(bundle), password)
+
+		assert.ErrorIs(t, err, ErrInvalidExport)
+		exists, _ := target.Exists(ctx, subject)
+		assert.False(t, exists)
+	})
+}
+
+// keyMethod is a MethodManager that creates verification methods with keys from the KeyStore, so they can be exported.
+type keyMethod struct {
+	testMethod
+	keyStore nutsCrypto.KeyStore
+}
+
+func (k keyMethod) NewDocument(ctx context.Context, keyFlags orm.DIDKeyFlags) (*orm.DidDocument, error) {
+	id := did.MustParseDID("did:example:" + uuid.NewString())
+	vm, err := k.NewVerificationMethod(ctx, id, keyFlags)
+	if err != nil {
+		return nil, err
+	}
+	vmAsJSON, _ := json.Marshal(vm)
+	return &orm.DidDocument{
+		DID:                 orm.DID{ID: id.String()},
+		VerificationMethods: []orm.VerificationMethod{{ID: vm.ID.String(), KeyTypes: orm.VerificationMethodKeyType(keyFlags), Data: vmAsJSON}},
+	}, nil
+}
+
+func (k keyMethod) NewVerificationMethod(ctx context.Context, controller did.DID, _ orm.DIDKeyFlags) (*did.VerificationMethod, error) {
+	ref, publicKey, err := k.keyStore.New(ctx, func(_ crypto.PublicKey) (string, error) {
+		return controller.String() + "#" + uuid.NewString(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return did.NewVerificationMethod(did.MustParseDIDURL(ref.KID), ssi.JsonWebKey2020, controller, publicKey)
+}
+
+type testDataExporter struct {
+	data     []byte
+	imported []byte
+	removed  []byte
+	err      error
+}
+
+func (t *testDataExporter) ExportSubjectData(_ context.Context, _ string, dids []did.DID) ([]byte, error) {
+	if len(dids) == 0 {
+		return nil, errors.New("no DIDs")
+	}
+	return t.data, t.err
+}
+
+func (t *testDataExporter) ImportSubjectData(_ context.Context, _ string, data []byte) error {
+	if t.err != nil {
+		return t.err
+	}
+	t.imported = data
+	return nil
+}
+
+func (t *testDataExporter) RemoveSubjectData(_ context.Context, _ string, data []byte) error {
+	t.removed = data
+	return nil
+}
@@ -21,6 +21,7 @@ package didsubject
 import (
 	"context"
 	"errors"
+	"time"
 
 	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/did"
@@ -128,6 +129,39 @@ type Manager interface {
 	// It returns an ErrDeactivated when the subject has the deactivated state.
 	AddVerificationMethod(ctx context.Context, subject string, keyUsage orm.DIDKeyFlags) ([]did.VerificationMethod, error)
 
+	// RotateKeys generates new keys for all DID documents of a subject, replacing the verification methods of which all usages are covered by keyUsage.
+	// For each DID method a new key will be generated, which takes precedence over the existing keys when signing.
+	// The replaced verification methods remain in the DID documents for the configured grace period, so signatures made with them can still be verified.
+	// After that, RemoveRotatedKeys removes them and deletes their private keys.
+	// It returns an ErrSubjectNotFound when the subject could not be found.
+	// It returns an ErrDeactivated when the subject has the deactivated state.
+	RotateKeys(ctx context.Context, subject string, keyUsage orm.DIDKeyFlags) ([]did.VerificationMethod, error)
+
+	// NotifyKeyRotations calls the key rotation listener for DID documents of which keys were replaced by RotateKeys,
+	// once the DID document update is applied. DID documents of some methods (e.g. did:nuts) are updated asynchronously,
+	// so it must be called periodically.
+	NotifyKeyRotations(ctx context.Context) error
+
+	// RemoveRotatedKeys removes verification methods replaced by RotateKeys from the DID documents once their grace period has passed,
+	// and deletes their private keys. Verification methods are only removed after NotifyKeyRotations processed their rotation.
+	RemoveRotatedKeys(ctx context.Context) error
+
+	// RotateExpiredKeys calls RotateKeys for all subjects with verification methods that were added longer than maxAge ago.
+	// Key usages that aren't supported by a DID method (e.g. key agreement for did:web) are not rotated for DIDs of that method.
+	RotateExpiredKeys(ctx context.Context, maxAge time.Duration) error
+
+	// Export exports the DID documents of a subject, their private keys and the data of other modules related to the subject,
+	// so the subject can be moved to another node. The export is returned as JWE, encrypted as specified by encryption.
+	// It returns an ErrSubjectNotFound when the subject could not be found.
+	// It returns nutsCrypto.ErrPrivateKeyNotExportable if the private keys can't be exported from the crypto backend.
+	Export(ctx context.Context, subject string, encryption ExportEncryption) (string, error)
+
+	// Import restores a subject exported by Export on another node. If password is empty, the export is decrypted using the node's keys.
+	// It returns the DID documents and the subject.
+	// It returns an ErrInvalidExport if the export can't be decrypted or is malformed.
+	// It returns an ErrSubjectAlreadyExists if the subject already exists.
+	Import(ctx context.Context, bundle string, password string) ([]did.Document, string, error)
+
 	// Rollback queries the did_change_log table for all changes that are older than 1 minute.
 	// Any entry that's still there is considered not committed and will be rolled back.
 	// All DID Document versions that are part of the same transaction_id will be deleted.
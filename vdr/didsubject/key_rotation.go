@@ -0,0 +1,346 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+package didsubject
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/core"
+	nutsCrypto "github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/nuts-foundation/nuts-node/storage/orm"
+	"github.com/nuts-foundation/nuts-node/vdr/log"
+	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+	"gorm.io/gorm"
+	"slices"
+	"strings"
+	"time"
+)
+
+// KeyRotationListener is called for every DID document of which keys were replaced by a key rotation,
+// once the updated DID document is applied (e.g. published on the network for did:nuts),
+// e.g. to re-sign credentials that were signed with the replaced key.
+type KeyRotationListener func(ctx context.Context, id did.DID) error
+
+func (r *SqlManager) RotateKeys(ctx context.Context, subject string, keyUsage orm.DIDKeyFlags) ([]did.VerificationMethod, error) {
+	return r.rotateKeys(ctx, subject, keyUsage, false)
+}
+
+// rotateKeys implements RotateKeys. If skipUnsupported is true, key usages not supported by a DID method are not rotated for DIDs of that method,
+// instead of failing the rotation for the whole subject.
+func (r *SqlManager) rotateKeys(ctx context.Context, subject string, keyUsage orm.DIDKeyFlags, skipUnsupported bool) ([]did.VerificationMethod, error) {
+	log.Logger().
+		WithField(core.LogFieldDIDSubject, subject).
+		Debug("Rotating keys.")
+
+	verificationMethods := make([]did.VerificationMethod, 0)
+	var rotations []orm.KeyRotation
+	removeAt := time.Now().Add(r.KeyRotationGracePeriod).Unix()
+	err := r.applyToDIDDocuments(ctx, subject, func(tx *gorm.DB, id did.DID, current *orm.DidDocument) (*orm.DidDocument, error) {
+		usage := keyUsage
+		// known limitation, see AddVerificationMethod
+		if usage.Is(orm.KeyAgreementUsage) && id.Method == "web" {
+			if !skipUnsupported {
+				return nil, ErrKeyAgreementNotSupported
+			}
+			usage = usage &^ orm.KeyAgreementUsage
+			if usage == 0 {
+				return nil, nil
+			}
+		}
+		if len(current.VerificationMethods) == 0 {
+			return nil, resolver.ErrDeactivated
+		}
+		rotated, err := rotatedVerificationMethods(tx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		transactionContext := context.WithValue(ctx, storage.TransactionKey{}, tx)
+		vm, err := r.MethodManagers[id.Method].NewVerificationMethod(transactionContext, id, usage)
+		if err != nil {
+			return nil, err
+		}
+		verificationMethods = append(verificationMethods, *vm)
+		data, _ := json.Marshal(*vm)
+		sqlMethod := orm.VerificationMethod{
+			ID:       vm.ID.String(),
+			KeyTypes: orm.VerificationMethodKeyType(usage),
+			Data:     data,
+		}
+		var replaced []orm.KeyRotation
+		for _, curr := range current.VerificationMethods {
+			// the new verification method must take precedence over the existing ones when resolving a signing key
+			if curr.Weight >= sqlMethod.Weight {
+				sqlMethod.Weight = curr.Weight + 1
+			}
+			// only replace verification methods of which all usages are covered by the new key
+			if orm.DIDKeyFlags(curr.KeyTypes)&^usage == 0 && !slices.Contains(rotated, curr.ID) {
+				replaced = append(replaced, orm.KeyRotation{
+					VerificationMethodID: curr.ID,
+					DID:                  id.String(),
+					ReplacedBy:           sqlMethod.ID,
+					RemoveAt:             removeAt,
+				})
+			}
+		}
+		// register the replaced verification methods in the same transaction as the DID document update,
+		// so they're only removed if the update was stored.
+		if len(replaced) > 0 {
+			if err = tx.Create(&replaced).Error; err != nil {
+				return nil, fmt.Errorf("could not register rotated keys: %w", err)
+			}
+			rotations = append(rotations, replaced...)
+		}
+		current.VerificationMethods = append([]orm.VerificationMethod{sqlMethod}, current.VerificationMethods...)
+		return current, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not rotate keys of DID documents: %w", err)
+	}
+
+	replaced := make([]string, len(rotations))
+	for i, rotation := range rotations {
+		replaced[i] = rotation.VerificationMethodID
+	}
+	log.Logger().
+		WithField(core.LogFieldDIDSubject, subject).
+		Infof("Rotated keys for subject (replaced: [%s], removal at: %s)", strings.Join(replaced, ", "), time.Unix(removeAt, 0).Format(time.RFC3339))
+
+	// DID documents that are updated synchronously (e.g. did:web) can be processed right away, the others are processed once they're applied.
+	if err = r.NotifyKeyRotations(ctx); err != nil {
+		log.Logger().
+			WithError(err).
+			WithField(core.LogFieldDIDSubject, subject).
+			Error("Failed to process key rotation")
+	}
+	return verificationMethods, nil
+}
+
+func (r *SqlManager) NotifyKeyRotations(ctx context.Context) error {
+	var rotations []orm.KeyRotation
+	if err := r.DB.Where("notified_at = ?", 0).Find(&rotations).Error; err != nil {
+		return err
+	}
+	byDID := make(map[string][]orm.KeyRotation)
+	for _, rotation := range rotations {
+		byDID[rotation.DID] = append(byDID[rotation.DID], rotation)
+	}
+	var errs []error
+	for didStr, didRotations := range byDID {
+		if err := r.notifyKeyRotation(ctx, didStr, didRotations); err != nil {
+			errs = append(errs, fmt.Errorf("could not process key rotation of %s: %w", didStr, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// notifyKeyRotation calls the key rotation listener for the given DID if the DID document update that replaced the keys has been applied.
+// Rotations of which the DID document update was rolled back are deleted, so the replaced keys aren't removed.
+func (r *SqlManager) notifyKeyRotation(ctx context.Context, didStr string, rotations []orm.KeyRotation) error {
+	id, err := did.ParseDID(didStr)
+	if err != nil {
+		return err
+	}
+	current, err := NewDIDDocumentManager(r.DB).Latest(*id, nil)
+	if err != nil {
+		return err
+	}
+	applied := 0
+	for _, rotation := range rotations {
+		if slices.ContainsFunc(current.VerificationMethods, func(vm orm.VerificationMethod) bool {
+			return vm.ID == rotation.ReplacedBy
+		}) {
+			applied++
+			continue
+		}
+		log.Logger().
+			WithField(core.LogFieldDID, didStr).
+			WithField(core.LogFieldKeyID, rotation.VerificationMethodID).
+			Warn("Key rotation was rolled back, key won't be removed")
+		if err = r.DB.Delete(&rotation).Error; err != nil {
+			return err
+		}
+	}
+	if applied == 0 {
+		return nil
+	}
+	committed, err := r.MethodManagers[id.Method].IsCommitted(ctx, orm.DIDChangeLog{DIDDocumentVersion: *current})
+	if err != nil || !committed {
+		return err
+	}
+	if r.KeyRotationListener != nil {
+		if err = r.KeyRotationListener(ctx, *id); err != nil {
+			// errors are logged, they don't prevent the rotated keys from being removed after the grace period
+			log.Logger().
+				WithError(err).
+				WithField(core.LogFieldDID, didStr).
+				Error("Failed to process key rotation")
+		}
+	}
+	return r.DB.Model(&orm.KeyRotation{}).
+		Where("did = ? AND notified_at = ?", didStr, 0).
+		Update("notified_at", time.Now().Unix()).Error
+}
+
+func (r *SqlManager) RemoveRotatedKeys(ctx context.Context) error {
+	var rotations []orm.KeyRotation
+	// rotations of which the DID document update hasn't been applied yet are removed later, since the replacing key isn't usable yet
+	if err := r.DB.Where("remove_at <= ? AND notified_at > ?", time.Now().Unix(), 0).Find(&rotations).Error; err != nil {
+		return err
+	}
+	// group the verification methods to remove by subject, so each DID document is updated only once
+	bySubject := make(map[string][]orm.KeyRotation)
+	for _, rotation := range rotations {
+		id, err := did.ParseDID(rotation.DID)
+		if err != nil {
+			return err
+		}
+		sqlDID, err := NewDIDManager(r.DB).Find(*id)
+		if err != nil {
+			return err
+		}
+		if sqlDID == nil {
+			// can't happen: rotations are deleted when the DID is deleted
+			continue
+		}
+		bySubject[sqlDID.Subject] = append(bySubject[sqlDID.Subject], rotation)
+	}
+
+	var errs []error
+	for subject, subjectRotations := range bySubject {
+		if err := r.removeRotatedKeys(ctx, subject, subjectRotations); err != nil {
+			errs = append(errs, fmt.Errorf("could not remove rotated keys of subject %s: %w", subject, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// removeRotatedKeys removes the rotated verification methods from the DID documents of the subject,
+// and then deletes the private keys.
+func (r *SqlManager) removeRotatedKeys(ctx context.Context, subject string, rotations []orm.KeyRotation) error {
+	isRotated := func(vmID string) bool {
+		return slices.ContainsFunc(rotations, func(rotation orm.KeyRotation) bool {
+			return rotation.VerificationMethodID == vmID
+		})
+	}
+	err := r.applyToDIDDocuments(ctx, subject, func(_ *gorm.DB, _ did.DID, current *orm.DidDocument) (*orm.DidDocument, error) {
+		remaining := slices.DeleteFunc(slices.Clone(current.VerificationMethods), func(vm orm.VerificationMethod) bool {
+			return isRotated(vm.ID)
+		})
+		if len(remaining) == len(current.VerificationMethods) {
+			// nothing to remove (or deactivated)
+			return nil, nil
+		}
+		current.VerificationMethods = remaining
+		return current, nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, rotation := range rotations {
+		if err = r.KeyStore.Delete(ctx, rotation.VerificationMethodID); err != nil && !errors.Is(err, nutsCrypto.ErrPrivateKeyNotFound) {
+			return fmt.Errorf("could not delete private key (kid=%s): %w", rotation.VerificationMethodID, err)
+		}
+		if err = r.DB.Delete(&rotation).Error; err != nil {
+			return err
+		}
+		log.Logger().
+			WithField(core.LogFieldDIDSubject, subject).
+			WithField(core.LogFieldKeyID, rotation.VerificationMethodID).
+			Info("Removed rotated key")
+	}
+	return nil
+}
+
+func (r *SqlManager) RotateExpiredKeys(ctx context.Context, maxAge time.Duration) error {
+	subjects, err := r.List(ctx)
+	if err != nil {
+		return err
+	}
+	notBefore := time.Now().Add(-maxAge).Unix()
+	var errs []error
+	for subject, dids := range subjects {
+		expired, err := r.expiredKeyUsages(dids, notBefore)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("could not determine key age of subject %s: %w", subject, err))
+			continue
+		}
+		for _, keyUsage := range expired {
+			if _, err = r.rotateKeys(ctx, subject, keyUsage, true); err != nil {
+				errs = append(errs, fmt.Errorf("could not rotate keys of subject %s: %w", subject, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// expiredKeyUsages returns the key usages of the verification methods of the given DIDs that were added before notBefore,
+// and that aren't already rotated. Key usages that are covered by another returned key usage are omitted,
+// since rotating the latter also replaces the verification methods of the former.
+func (r *SqlManager) expiredKeyUsages(dids []did.DID, notBefore int64) ([]orm.DIDKeyFlags, error) {
+	var usages []orm.DIDKeyFlags
+	for _, id := range dids {
+		current, err := NewDIDDocumentManager(r.DB).Latest(id, nil)
+		if err != nil {
+			return nil, err
+		}
+		rotated, err := rotatedVerificationMethods(r.DB, id)
+		if err != nil {
+			return nil, err
+		}
+		for _, vm := range current.VerificationMethods {
+			if slices.Contains(rotated, vm.ID) {
+				continue
+			}
+			addedAt, err := verificationMethodAddedAt(r.DB, vm.ID)
+			if err != nil {
+				return nil, err
+			}
+			if addedAt < notBefore && !slices.Contains(usages, orm.DIDKeyFlags(vm.KeyTypes)) {
+				usages = append(usages, orm.DIDKeyFlags(vm.KeyTypes))
+			}
+		}
+	}
+	return slices.DeleteFunc(slices.Clone(usages), func(usage orm.DIDKeyFlags) bool {
+		return slices.ContainsFunc(usages, func(other orm.DIDKeyFlags) bool {
+			return other != usage && usage&^other == 0
+		})
+	}), nil
+}
+
+// rotatedVerificationMethods returns the IDs of the verification methods of the DID that were replaced by a key rotation.
+func rotatedVerificationMethods(tx *gorm.DB, id did.DID) ([]string, error) {
+	var result []string
+	err := tx.Model(&orm.KeyRotation{}).Where("did = ?", id.String()).Pluck("verification_method_id", &result).Error
+	return result, err
+}
+
+// verificationMethodAddedAt returns the (unix) timestamp of the first DID document version that contains the verification method.
+func verificationMethodAddedAt(tx *gorm.DB, vmID string) (int64, error) {
+	var result int64
+	err := tx.Model(&orm.DidDocument{}).
+		Select("MIN(did_document_version.updated_at)").
+		Joins("JOIN did_document_to_verification_method ON did_document_to_verification_method.did_document_id = did_document_version.id").
+		Where("did_document_to_verification_method.verification_method_id = ?", vmID).
+		Scan(&result).Error
+	return result, err
+}
@@ -0,0 +1,383 @@
+/*
+ * Copyright (C) 2025 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+package didsubject
+
+import (
+	"context"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/audit"
+	nutsCrypto "github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/storage/orm"
+	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+func TestManager_RotateKeys(t *testing.T) {
+	ctx := audit.TestContext()
+	subject := "subject"
+	setup := func(t *testing.T) (*SqlManager, *gorm.DB, []did.VerificationMethod) {
+		db := testDB(t)
+		m := &SqlManager{DB: db, MethodManagers: map[string]MethodManager{"example": testMethod{committed: true}, "test": testMethod{method: "test", committed: true}}, KeyRotationGracePeriod: time.Hour}
+		_, _, err := m.Create(ctx, DefaultCreationOptions().With(SubjectCreationOption{Subject: subject}))
+		require.NoError(t, err)
+		vms, err := m.AddVerificationMethod(ctx, subject, orm.AssertionKeyUsage())
+		require.NoError(t, err)
+		return m, db, vms
+	}
+
+	// exampleDID returns the did:example DID of the subject, since the order of the verification methods isn't fixed
+	exampleDID := func(vms []did.VerificationMethod) did.DID {
+		for _, vm := range vms {
+			if vm.ID.DID.Method == "example" {
+				return vm.ID.DID
+			}
+		}
+		t.Fatal("no did:example verification method")
+		return did.DID{}
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		m, db, oldVMs := setup(t)
+		var notified []did.DID
+		m.KeyRotationListener = func(_ context.Context, id did.DID) error {
+			notified = append(notified, id)
+			return nil
+		}
+
+		vms, err := m.RotateKeys(ctx, subject, orm.AssertionKeyUsage())
+
+		require.NoError(t, err)
+		require.Len(t, vms, 2)
+		assert.Len(t, notified, 2)
+		for i, oldVM := range oldVMs {
+			newVM := vms[i]
+			t.Run("old key remains for verification, new key takes precedence", func(t *testing.T) {
+				current := latestVerificationMethods(t, db, oldVM.ID.DID)
+				require.Len(t, current, 2)
+				assert.Equal(t, newVM.ID.String(), current[0].ID)
+				assert.Equal(t, oldVM.ID.String(), current[1].ID)
+			})
+			t.Run("old key is scheduled for removal", func(t *testing.T) {
+				var rotation orm.KeyRotation
+				require.NoError(t, db.First(&rotation, "verification_method_id = ?", oldVM.ID.String()).Error)
+				assert.Equal(t, newVM.ID.String(), rotation.ReplacedBy)
+				assert.Equal(t, oldVM.ID.DID.String(), rotation.DID)
+				assert.InDelta(t, time.Now().Add(time.Hour).Unix(), rotation.RemoveAt, 5)
+				assert.NotZero(t, rotation.NotifiedAt)
+			})
+		}
+		t.Run("new key keeps precedence after subsequent updates", func(t *testing.T) {
+			_, err := m.CreateService(ctx, subject, did.Service{Type: "test", ServiceEndpoint: "https://example.com"})
+			require.NoError(t, err)
+
+			current := latestVerificationMethods(t, db, vms[0].ID.DID)
+
+			require.Len(t, current, 2)
+			assert.Equal(t, vms[0].ID.String(), current[0].ID)
+		})
+		t.Run("rotating again doesn't reschedule already rotated keys", func(t *testing.T) {
+			_, err := m.RotateKeys(ctx, subject, orm.AssertionKeyUsage())
+			require.NoError(t, err)
+
+			var count int64
+			require.NoError(t, db.Model(&orm.KeyRotation{}).Count(&count).Error)
+			assert.Equal(t, int64(4), count)
+		})
+	})
+	t.Run("listener is called once the DID document update is applied", func(t *testing.T) {
+		m, db, oldVMs := setup(t)
+		m.MethodManagers["example"] = testMethod{committed: false}
+		var notified []did.DID
+		m.KeyRotationListener = func(_ context.Context, id did.DID) error {
+			notified = append(notified, id)
+			return nil
+		}
+
+		_, err := m.RotateKeys(ctx, subject, orm.AssertionKeyUsage())
+
+		require.NoError(t, err)
+		require.Len(t, notified, 1)
+		assert.Equal(t, "test", notified[0].Method)
+
+		m.MethodManagers["example"] = testMethod{committed: true}
+		require.NoError(t, m.NotifyKeyRotations(ctx))
+
+		require.Len(t, notified, 2)
+		assert.Equal(t, exampleDID(oldVMs), notified[1])
+		t.Run("rotations are only processed once", func(t *testing.T) {
+			require.NoError(t, m.NotifyKeyRotations(ctx))
+
+			assert.Len(t, notified, 2)
+		})
+		t.Run("rotations are not removed", func(t *testing.T) {
+			var count int64
+			require.NoError(t, db.Model(&orm.KeyRotation{}).Count(&count).Error)
+			assert.Equal(t, int64(2), count)
+		})
+	})
+	t.Run("rolled back DID document update", func(t *testing.T) {
+		m, db, oldVMs := setup(t)
+		m.MethodManagers["example"] = testMethod{committed: false}
+		var notified []did.DID
+		m.KeyRotationListener = func(_ context.Context, id did.DID) error {
+			notified = append(notified, id)
+			return nil
+		}
+		_, err := m.RotateKeys(ctx, subject, orm.AssertionKeyUsage())
+		require.NoError(t, err)
+		// roll back the update of the did:example DID document
+		latest, err := NewDIDDocumentManager(db).Latest(exampleDID(oldVMs), nil)
+		require.NoError(t, err)
+		require.NoError(t, db.Where("id = ?", latest.ID).Delete(&orm.DidDocument{}).Error)
+
+		require.NoError(t, m.NotifyKeyRotations(ctx))
+
+		assert.Len(t, notified, 1)
+		var count int64
+		require.NoError(t, db.Model(&orm.KeyRotation{}).Where("did = ?", exampleDID(oldVMs).String()).Count(&count).Error)
+		assert.Equal(t, int64(0), count)
+	})
+	t.Run("keys with usages not covered by the new key are not replaced", func(t *testing.T) {
+		m, db, _ := setup(t)
+
+		_, err := m.RotateKeys(ctx, subject, orm.AssertionMethodUsage)
+
+		require.NoError(t, err)
+		var count int64
+		require.NoError(t, db.Model(&orm.KeyRotation{}).Count(&count).Error)
+		assert.Equal(t, int64(0), count)
+	})
+	t.Run("listener error doesn't fail rotation", func(t *testing.T) {
+		m, _, _ := setup(t)
+		m.KeyRotationListener = func(_ context.Context, _ did.DID) error {
+			return assert.AnError
+		}
+
+		vms, err := m.RotateKeys(ctx, subject, orm.AssertionKeyUsage())
+
+		require.NoError(t, err)
+		assert.Len(t, vms, 2)
+	})
+	t.Run("subject not found", func(t *testing.T) {
+		m, _, _ := setup(t)
+
+		_, err := m.RotateKeys(ctx, "other", orm.AssertionKeyUsage())
+
+		assert.ErrorIs(t, err, ErrSubjectNotFound)
+	})
+	t.Run("deactivated", func(t *testing.T) {
+		m, _, _ := setup(t)
+		require.NoError(t, m.Deactivate(ctx, subject))
+
+		_, err := m.RotateKeys(ctx, subject, orm.AssertionKeyUsage())
+
+		assert.ErrorIs(t, err, resolver.ErrDeactivated)
+	})
+	t.Run("key agreement not supported for did:web", func(t *testing.T) {
+		db := testDB(t)
+		m := &SqlManager{DB: db, MethodManagers: map[string]MethodManager{"web": testMethod{method: "web"}}}
+		_, _, err := m.Create(ctx, DefaultCreationOptions().With(SubjectCreationOption{Subject: subject}))
+		require.NoError(t, err)
+
+		_, err = m.RotateKeys(ctx, subject, orm.KeyAgreementUsage)
+
+		assert.ErrorIs(t, err, ErrKeyAgreementNotSupported)
+	})
+}
+
+func TestManager_RemoveRotatedKeys(t *testing.T) {
+	ctx := audit.TestContext()
+	subject := "subject"
+	setup := func(t *testing.T, gracePeriod time.Duration) (*SqlManager, *gorm.DB, *nutsCrypto.MockKeyStore, []did.VerificationMethod) {
+		db := testDB(t)
+		keyStore := nutsCrypto.NewMockKeyStore(gomock.NewController(t))
+		m := &SqlManager{DB: db, MethodManagers: map[string]MethodManager{"example": testMethod{committed: true}}, KeyStore: keyStore, KeyRotationGracePeriod: gracePeriod}
+		_, _, err := m.Create(ctx, DefaultCreationOptions().With(SubjectCreationOption{Subject: subject}))
+		require.NoError(t, err)
+		oldVMs, err := m.AddVerificationMethod(ctx, subject, orm.AssertionKeyUsage())
+		require.NoError(t, err)
+		_, err = m.RotateKeys(ctx, subject, orm.AssertionKeyUsage())
+		require.NoError(t, err)
+		return m, db, keyStore, oldVMs
+	}
+
+	t.Run("grace period passed", func(t *testing.T) {
+		m, db, keyStore, oldVMs := setup(t, 0)
+		keyStore.EXPECT().Delete(ctx, oldVMs[0].ID.String()).Return(nil)
+
+		err := m.RemoveRotatedKeys(ctx)
+
+		require.NoError(t, err)
+		current := latestVerificationMethods(t, db, oldVMs[0].ID.DID)
+		require.Len(t, current, 1)
+		assert.NotEqual(t, oldVMs[0].ID.String(), current[0].ID)
+		var count int64
+		require.NoError(t, db.Model(&orm.KeyRotation{}).Count(&count).Error)
+		assert.Equal(t, int64(0), count)
+	})
+	t.Run("private key already deleted", func(t *testing.T) {
+		m, db, keyStore, oldVMs := setup(t, 0)
+		keyStore.EXPECT().Delete(ctx, oldVMs[0].ID.String()).Return(nutsCrypto.ErrPrivateKeyNotFound)
+
+		err := m.RemoveRotatedKeys(ctx)
+
+		require.NoError(t, err)
+		var count int64
+		require.NoError(t, db.Model(&orm.KeyRotation{}).Count(&count).Error)
+		assert.Equal(t, int64(0), count)
+	})
+	t.Run("deleting private key fails", func(t *testing.T) {
+		m, db, keyStore, oldVMs := setup(t, 0)
+		keyStore.EXPECT().Delete(ctx, oldVMs[0].ID.String()).Return(assert.AnError)
+
+		err := m.RemoveRotatedKeys(ctx)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		// removal is retried later
+		var count int64
+		require.NoError(t, db.Model(&orm.KeyRotation{}).Count(&count).Error)
+		assert.Equal(t, int64(1), count)
+	})
+	t.Run("grace period not passed", func(t *testing.T) {
+		m, db, _, oldVMs := setup(t, time.Hour)
+
+		err := m.RemoveRotatedKeys(ctx)
+
+		require.NoError(t, err)
+		assert.Len(t, latestVerificationMethods(t, db, oldVMs[0].ID.DID), 2)
+	})
+	t.Run("DID document update not applied yet", func(t *testing.T) {
+		m, db, _, oldVMs := setup(t, 0)
+		require.NoError(t, db.Model(&orm.KeyRotation{}).Where("1 = 1").Update("notified_at", 0).Error)
+
+		err := m.RemoveRotatedKeys(ctx)
+
+		require.NoError(t, err)
+		assert.Len(t, latestVerificationMethods(t, db, oldVMs[0].ID.DID), 2)
+	})
+}
+
+func TestManager_RotateExpiredKeys(t *testing.T) {
+	ctx := audit.TestContext()
+	subject := "subject"
+	db := testDB(t)
+	m := &SqlManager{DB: db, MethodManagers: map[string]MethodManager{"example": testMethod{committed: true}}, KeyRotationGracePeriod: time.Hour}
+	_, _, err := m.Create(ctx, DefaultCreationOptions().With(SubjectCreationOption{Subject: subject}))
+	require.NoError(t, err)
+	oldVMs, err := m.AddVerificationMethod(ctx, subject, orm.AssertionKeyUsage())
+	require.NoError(t, err)
+
+	t.Run("keys not expired", func(t *testing.T) {
+		err := m.RotateExpiredKeys(ctx, time.Hour)
+
+		require.NoError(t, err)
+		assert.Len(t, latestVerificationMethods(t, db, oldVMs[0].ID.DID), 1)
+	})
+	t.Run("keys expired", func(t *testing.T) {
+		// make the DID document versions an hour older
+		require.NoError(t, db.Model(&orm.DidDocument{}).Where("1 = 1").Update("updated_at", gorm.Expr("updated_at - 3600")).Error)
+
+		err := m.RotateExpiredKeys(ctx, time.Minute)
+
+		require.NoError(t, err)
+		current := latestVerificationMethods(t, db, oldVMs[0].ID.DID)
+		require.Len(t, current, 2)
+		assert.NotEqual(t, oldVMs[0].ID.String(), current[0].ID)
+	})
+	t.Run("rotated keys are not rotated again", func(t *testing.T) {
+		require.NoError(t, db.Model(&orm.DidDocument{}).Where("1 = 1").Update("updated_at", gorm.Expr("updated_at - 3600")).Error)
+		// new key is expired as well now, so only that one is rotated
+		err := m.RotateExpiredKeys(ctx, 90*time.Minute)
+
+		require.NoError(t, err)
+		assert.Len(t, latestVerificationMethods(t, db, oldVMs[0].ID.DID), 2)
+	})
+}
+
+func TestSqlManager_rotateKeys(t *testing.T) {
+	ctx := audit.TestContext()
+	subject := "subject"
+	setup := func(t *testing.T) (*SqlManager, *gorm.DB, []did.Document) {
+		db := testDB(t)
+		m := &SqlManager{DB: db, MethodManagers: map[string]MethodManager{"example": testMethod{committed: true}, "web": testMethod{method: "web", committed: true}}, KeyRotationGracePeriod: time.Hour}
+		documents, _, err := m.Create(ctx, DefaultCreationOptions().With(SubjectCreationOption{Subject: subject}))
+		require.NoError(t, err)
+		_, err = m.AddVerificationMethod(ctx, subject, orm.AssertionKeyUsage())
+		require.NoError(t, err)
+		return m, db, documents
+	}
+	webDID := func(documents []did.Document) did.DID {
+		for _, document := range documents {
+			if document.ID.Method == "web" {
+				return document.ID
+			}
+		}
+		t.Fatal("no did:web document")
+		return did.DID{}
+	}
+	t.Run("unsupported key usages are skipped for did:web", func(t *testing.T) {
+		m, db, documents := setup(t)
+
+		vms, err := m.rotateKeys(ctx, subject, orm.AssertionKeyUsage()|orm.KeyAgreementUsage, true)
+
+		require.NoError(t, err)
+		assert.Len(t, vms, 2)
+		current := latestVerificationMethods(t, db, webDID(documents))
+		require.Len(t, current, 2)
+		assert.Equal(t, orm.VerificationMethodKeyType(orm.AssertionKeyUsage()), current[0].KeyTypes)
+	})
+	t.Run("did:web is skipped if no supported key usages remain", func(t *testing.T) {
+		m, db, documents := setup(t)
+
+		vms, err := m.rotateKeys(ctx, subject, orm.KeyAgreementUsage, true)
+
+		require.NoError(t, err)
+		assert.Len(t, vms, 1)
+		assert.Len(t, latestVerificationMethods(t, db, webDID(documents)), 1)
+	})
+}
+
+func TestSqlManager_expiredKeyUsages(t *testing.T) {
+	t.Run("usages covered by other usages are omitted", func(t *testing.T) {
+		db := testDB(t)
+		ctx := audit.TestContext()
+		m := &SqlManager{DB: db, MethodManagers: map[string]MethodManager{"example": testMethod{}}}
+		documents, _, err := m.Create(ctx, DefaultCreationOptions().With(SubjectCreationOption{Subject: "subject"}))
+		require.NoError(t, err)
+		_, err = m.AddVerificationMethod(ctx, "subject", orm.AssertionMethodUsage)
+		require.NoError(t, err)
+		_, err = m.AddVerificationMethod(ctx, "subject", orm.AssertionKeyUsage())
+		require.NoError(t, err)
+
+		usages, err := m.expiredKeyUsages([]did.DID{documents[0].ID}, time.Now().Add(time.Hour).Unix())
+
+		require.NoError(t, err)
+		assert.Equal(t, []orm.DIDKeyFlags{orm.AssertionKeyUsage()}, usages)
+	})
+}
+
+func latestVerificationMethods(t *testing.T, db *gorm.DB, id did.DID) []orm.VerificationMethod {
+	doc, err := NewDIDDocumentManager(db).Latest(id, nil)
+	require.NoError(t, err)
+	return doc.VerificationMethods
+}
@@ -52,6 +52,12 @@ type SqlManager struct {
 	KeyStore       nutsCrypto.KeyStore
 	// PreferredOrder is the order in which the methods are preferred, which dictates the order in which they are returned.
 	PreferredOrder []string
+	// KeyRotationGracePeriod is the period verification methods replaced by RotateKeys remain in the DID documents.
+	KeyRotationGracePeriod time.Duration
+	// KeyRotationListener is called for every DID document of which the keys were rotated, if set.
+	KeyRotationListener KeyRotationListener
+	// SubjectDataExporters export and import the data of other modules related to a subject, by name.
+	SubjectDataExporters map[string]SubjectDataExporter
 }
 
 func New(db *gorm.DB, methodManagers map[string]MethodManager, keyStore nutsCrypto.KeyStore, preferredOrder []string) *SqlManager {
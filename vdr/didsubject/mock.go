@@ -12,6 +12,7 @@ package didsubject
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	ssi "github.com/nuts-foundation/go-did"
 	did "github.com/nuts-foundation/go-did/did"
@@ -267,6 +268,21 @@ func (mr *MockManagerMockRecorder) Exists(ctx, subject any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockManager)(nil).Exists), ctx, subject)
 }
 
+// Export mocks base method.
+func (m *MockManager) Export(ctx context.Context, subject string, encryption ExportEncryption) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, subject, encryption)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockManagerMockRecorder) Export(ctx, subject, encryption any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockManager)(nil).Export), ctx, subject, encryption)
+}
+
 // FindServices mocks base method.
 func (m *MockManager) FindServices(ctx context.Context, subject string, serviceType *string) ([]did.Service, error) {
 	m.ctrl.T.Helper()
@@ -282,6 +298,22 @@ func (mr *MockManagerMockRecorder) FindServices(ctx, subject, serviceType any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindServices", reflect.TypeOf((*MockManager)(nil).FindServices), ctx, subject, serviceType)
 }
 
+// Import mocks base method.
+func (m *MockManager) Import(ctx context.Context, bundle, password string) ([]did.Document, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, bundle, password)
+	ret0, _ := ret[0].([]did.Document)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockManagerMockRecorder) Import(ctx, bundle, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockManager)(nil).Import), ctx, bundle, password)
+}
+
 // List mocks base method.
 func (m *MockManager) List(ctx context.Context) (map[string][]did.DID, error) {
 	m.ctrl.T.Helper()
@@ -312,6 +344,34 @@ func (mr *MockManagerMockRecorder) ListDIDs(ctx, subject any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDIDs", reflect.TypeOf((*MockManager)(nil).ListDIDs), ctx, subject)
 }
 
+// NotifyKeyRotations mocks base method.
+func (m *MockManager) NotifyKeyRotations(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyKeyRotations", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyKeyRotations indicates an expected call of NotifyKeyRotations.
+func (mr *MockManagerMockRecorder) NotifyKeyRotations(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyKeyRotations", reflect.TypeOf((*MockManager)(nil).NotifyKeyRotations), ctx)
+}
+
+// RemoveRotatedKeys mocks base method.
+func (m *MockManager) RemoveRotatedKeys(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveRotatedKeys", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveRotatedKeys indicates an expected call of RemoveRotatedKeys.
+func (mr *MockManagerMockRecorder) RemoveRotatedKeys(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveRotatedKeys", reflect.TypeOf((*MockManager)(nil).RemoveRotatedKeys), ctx)
+}
+
 // Rollback mocks base method.
 func (m *MockManager) Rollback(ctx context.Context) {
 	m.ctrl.T.Helper()
@@ -324,6 +384,35 @@ func (mr *MockManagerMockRecorder) Rollback(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockManager)(nil).Rollback), ctx)
 }
 
+// RotateExpiredKeys mocks base method.
+func (m *MockManager) RotateExpiredKeys(ctx context.Context, maxAge time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateExpiredKeys", ctx, maxAge)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RotateExpiredKeys indicates an expected call of RotateExpiredKeys.
+func (mr *MockManagerMockRecorder) RotateExpiredKeys(ctx, maxAge any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateExpiredKeys", reflect.TypeOf((*MockManager)(nil).RotateExpiredKeys), ctx, maxAge)
+}
+
+// RotateKeys mocks base method.
+func (m *MockManager) RotateKeys(ctx context.Context, subject string, keyUsage orm.DIDKeyFlags) ([]did.VerificationMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateKeys", ctx, subject, keyUsage)
+	ret0, _ := ret[0].([]did.VerificationMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateKeys indicates an expected call of RotateKeys.
+func (mr *MockManagerMockRecorder) RotateKeys(ctx, subject, keyUsage any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateKeys", reflect.TypeOf((*MockManager)(nil).RotateKeys), ctx, subject, keyUsage)
+}
+
 // UpdateService mocks base method.
 func (m *MockManager) UpdateService(ctx context.Context, subject string, serviceID ssi.URI, service did.Service) ([]did.Service, error) {
 	m.ctrl.T.Helper()
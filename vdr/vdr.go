@@ -54,15 +54,23 @@ import (
 // ModuleName is the name of the engine
 const ModuleName = "VDR"
 
+// keyRotationInterval is the interval at which rotated keys are removed and expired keys are rotated.
+var keyRotationInterval = time.Hour
+
+// keyRotationNotifyInterval is the interval at which key rotations of which the DID document update was applied are processed.
+var keyRotationNotifyInterval = 10 * time.Second
+
 var _ VDR = (*Module)(nil)
 var _ core.Named = (*Module)(nil)
 var _ core.Configurable = (*Module)(nil)
+var _ core.Injectable = (*Module)(nil)
 var _ didsubject.Manager = (*Module)(nil)
 
 // Module implements VDR, which stands for the Verifiable Data Registry. It is the public entrypoint to work with W3C DID documents.
 // It connects the Resolve, Create and Update DID methods to the network, and receives events back from the network which are processed in the store.
 // It is also a Runnable, Diagnosable and Configurable Nuts Engine.
 type Module struct {
+	config              Config
 	supportedDIDMethods []string
 	publicURL           *url.URL
 	store               didnutsStore.Store
@@ -82,6 +90,10 @@ type Module struct {
 	// migrations are registered functions to simplify testing
 	migrations   []migration
 	pkiValidator pki.Validator
+	// keyRotationListeners are notified when the keys of a DID document were rotated
+	keyRotationListeners []didsubject.KeyRotationListener
+	// subjectDataExporters include the data of other modules in subject exports
+	subjectDataExporters map[string]didsubject.SubjectDataExporter
 
 	// new style DID management
 	didsubject.Manager
@@ -116,6 +128,7 @@ func (r *Module) Resolver() resolver.DIDResolver {
 func NewVDR(cryptoClient crypto.KeyStore, networkClient network.Transactions,
 	didStore didnutsStore.Store, eventManager events.Event, storageInstance storage.Engine, pkiValidator pki.Validator) *Module {
 	m := &Module{
+		config:          DefaultConfig(),
 		didResolver:     &resolver.DIDResolverRouter{},
 		network:         networkClient,
 		eventManager:    eventManager,
@@ -134,8 +147,32 @@ func (r *Module) Name() string {
 	return ModuleName
 }
 
+func (r *Module) Config() interface{} {
+	return &r.config
+}
+
+// AddKeyRotationListener registers a listener that is called for every DID document of which the keys were rotated.
+func (r *Module) AddKeyRotationListener(listener didsubject.KeyRotationListener) {
+	r.keyRotationListeners = append(r.keyRotationListeners, listener)
+}
+
+// AddSubjectDataExporter registers an exporter for data of another module, which is then included in subject exports and imports.
+// The name identifies the data in the export, so it must be the same on the exporting and importing node.
+func (r *Module) AddSubjectDataExporter(name string, exporter didsubject.SubjectDataExporter) {
+	if r.subjectDataExporters == nil {
+		r.subjectDataExporters = make(map[string]didsubject.SubjectDataExporter)
+	}
+	r.subjectDataExporters[name] = exporter
+}
+
 // Configure configures the Module engine.
 func (r *Module) Configure(config core.ServerConfig) error {
+	if r.config.KeyRotation.GracePeriod <= 0 {
+		return errors.New("vdr.keyrotation.graceperiod must be greater than 0")
+	}
+	if r.config.KeyRotation.MaxAge < 0 {
+		return errors.New("vdr.keyrotation.maxage must not be negative")
+	}
 	r.supportedDIDMethods = config.DIDMethods
 	var err error
 	if r.publicURL, err = config.ServerURL(); err != nil {
@@ -207,7 +244,11 @@ func (r *Module) Configure(config core.ServerConfig) error {
 		r.didResolver.(*resolver.DIDResolverRouter).Register(didweb.MethodName, webResolver)
 	}
 
-	r.Manager = didsubject.New(db, methodManagers, r.keyStore, r.supportedDIDMethods)
+	manager := didsubject.New(db, methodManagers, r.keyStore, r.supportedDIDMethods)
+	manager.KeyRotationGracePeriod = r.config.KeyRotation.GracePeriod
+	manager.KeyRotationListener = r.notifyKeyRotationListeners
+	manager.SubjectDataExporters = r.subjectDataExporters
+	r.Manager = manager
 
 	// Initiate the routines for auto-updating the data.
 	if r.networkAmbassador != nil {
@@ -217,7 +258,14 @@ func (r *Module) Configure(config core.ServerConfig) error {
 }
 
 func (r *Module) Start() error {
-	// nothing to start if did:nuts is disabled
+	// start key rotation loop
+	r.routines.Add(1)
+	go func() {
+		defer r.routines.Done()
+		r.keyRotationLoop()
+	}()
+
+	// nothing else to start if did:nuts is disabled
 	if r.networkAmbassador == nil {
 		return nil
 	}
@@ -256,6 +304,49 @@ func (r *Module) rollbackLoop() {
 	}
 }
 
+// keyRotationLoop periodically removes rotated keys of which the grace period has passed,
+// and rotates keys that exceed the configured maximum age (if set).
+// It also processes key rotations once the DID document update is applied, which is asynchronous for did:nuts.
+func (r *Module) keyRotationLoop() {
+	ticker := time.NewTicker(keyRotationInterval)
+	defer ticker.Stop()
+	notifyTicker := time.NewTicker(keyRotationNotifyInterval)
+	defer notifyTicker.Stop()
+
+	ctx := audit.Context(r.ctx, "system", ModuleName, "KeyRotation")
+	for {
+		select {
+		// stop at shutdown
+		case <-r.ctx.Done():
+			return
+		case <-notifyTicker.C:
+			if err := r.NotifyKeyRotations(ctx); err != nil {
+				log.Logger().WithError(err).Error("Failed to process key rotations")
+			}
+		case <-ticker.C:
+			if r.config.KeyRotation.MaxAge > 0 {
+				if err := r.RotateExpiredKeys(ctx, r.config.KeyRotation.MaxAge); err != nil {
+					log.Logger().WithError(err).Error("Failed to rotate expired keys")
+				}
+			}
+			if err := r.RemoveRotatedKeys(ctx); err != nil {
+				log.Logger().WithError(err).Error("Failed to remove rotated keys")
+			}
+		}
+	}
+}
+
+// notifyKeyRotationListeners calls all registered key rotation listeners.
+func (r *Module) notifyKeyRotationListeners(ctx context.Context, id did.DID) error {
+	var errs []error
+	for _, listener := range r.keyRotationListeners {
+		if err := listener(ctx, id); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
 func (r *Module) Shutdown() error {
 	r.cancel()
 	r.routines.Wait()
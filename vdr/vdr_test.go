@@ -49,6 +49,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 )
 
 // testCtx contains the controller and mocks needed fot testing the Manipulator
@@ -297,6 +298,22 @@ func TestVDR_Configure(t *testing.T) {
 		assert.NotNil(t, doc)
 		assert.NotNil(t, md)
 	})
+	t.Run("invalid key rotation grace period", func(t *testing.T) {
+		instance := NewVDR(nil, nil, nil, nil, storageInstance, pkiMock)
+		instance.config.KeyRotation.GracePeriod = 0
+
+		err := instance.Configure(core.TestServerConfig())
+
+		assert.EqualError(t, err, "vdr.keyrotation.graceperiod must be greater than 0")
+	})
+	t.Run("invalid key rotation max age", func(t *testing.T) {
+		instance := NewVDR(nil, nil, nil, nil, storageInstance, pkiMock)
+		instance.config.KeyRotation.MaxAge = -time.Hour
+
+		err := instance.Configure(core.TestServerConfig())
+
+		assert.EqualError(t, err, "vdr.keyrotation.maxage must not be negative")
+	})
 }
 
 func TestVDR_Migrate(t *testing.T) {